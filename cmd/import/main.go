@@ -0,0 +1,130 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"wallpaper-system/internal/adapters/repositories"
+	"wallpaper-system/internal/adapters/spreadsheet"
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/infrastructure/config"
+	"wallpaper-system/internal/infrastructure/database"
+	"wallpaper-system/internal/usecases"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	command := os.Args[1]
+	if command != "products" && command != "materials" {
+		fmt.Printf("Неизвестная команда: %s\n", command)
+		printUsage()
+		os.Exit(1)
+	}
+
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "только проверить файл, ничего не сохранять")
+	flags.Usage = printUsage
+	flags.Parse(os.Args[2:])
+
+	if flags.NArg() != 1 {
+		printUsage()
+		os.Exit(1)
+	}
+	filename := flags.Arg(0)
+
+	table, err := readTable(filename)
+	if err != nil {
+		log.Fatalf("Ошибка чтения файла %s: %v", filename, err)
+	}
+
+	// Загрузка конфигурации и подключение к базе данных
+	cfg := config.Load()
+	db, err := database.New(&cfg.Database)
+	if err != nil {
+		log.Fatalf("Ошибка подключения к базе данных: %v", err)
+	}
+	defer db.Close()
+
+	productRepo := repositories.NewProductRepository(db.GetConnection())
+	materialRepo := repositories.NewMaterialRepository(db.GetConnection())
+	pricingRuleRepo := repositories.NewPricingRuleRepository(db.GetConnection())
+	productUseCase := usecases.NewProductUseCase(productRepo, materialRepo, pricingRuleRepo)
+	importUseCase := usecases.NewImportUseCase(productRepo, materialRepo, productUseCase)
+
+	var report *entities.ImportReport
+	if command == "products" {
+		report, err = importUseCase.ImportProducts(table, *dryRun)
+	} else {
+		report, err = importUseCase.ImportMaterials(table, *dryRun)
+	}
+	if err != nil {
+		log.Fatalf("Ошибка импорта: %v", err)
+	}
+
+	printReport(report)
+
+	// Ненулевой код выхода позволяет остановить сценарий загрузки, если в файле есть ошибки
+	if report.Skipped() > 0 {
+		os.Exit(2)
+	}
+}
+
+func printUsage() {
+	fmt.Println("Использование:")
+	fmt.Println("  go run cmd/import/main.go <команда> [-dry-run] <файл.csv|файл.xlsx>")
+	fmt.Println("")
+	fmt.Println("Команды:")
+	fmt.Println("  products  - Импортировать продукцию")
+	fmt.Println("  materials - Импортировать материалы")
+	fmt.Println("")
+	fmt.Println("Флаги:")
+	fmt.Println("  -dry-run  - Только проверить файл, ничего не сохранять")
+}
+
+func readTable(filename string) (*entities.ImportTable, error) {
+	format, err := entities.ImportFormatFromFilename(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(filename)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > entities.MaxImportFileSize {
+		return nil, fmt.Errorf("размер файла превышает %d МБ", entities.MaxImportFileSize>>20)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return spreadsheet.ReadTable(format, data)
+}
+
+func printReport(report *entities.ImportReport) {
+	if len(report.IgnoredColumns) > 0 {
+		fmt.Printf("Пропущены нераспознанные столбцы: %v\n", report.IgnoredColumns)
+	}
+
+	for _, rowErr := range report.Errors() {
+		fmt.Println(rowErr.Error())
+	}
+
+	if report.DryRun {
+		fmt.Println("Проверка файла (данные не сохранены):")
+	} else {
+		fmt.Println("Импорт выполнен:")
+	}
+	fmt.Printf("  строк в файле:     %d\n", len(report.Rows))
+	fmt.Printf("  создано:           %d\n", report.Created())
+	fmt.Printf("  обновлено:         %d\n", report.Updated())
+	fmt.Printf("  строк с ошибками:  %d\n", report.Skipped())
+}
@@ -15,11 +15,13 @@ import (
 	// Слой инфраструктуры
 	"wallpaper-system/internal/infrastructure/config"
 	"wallpaper-system/internal/infrastructure/database"
+	"wallpaper-system/internal/infrastructure/jobs"
 	"wallpaper-system/internal/infrastructure/server"
 
 	// Слой адаптеров
 	"wallpaper-system/internal/adapters/controllers"
 	"wallpaper-system/internal/adapters/repositories"
+	"wallpaper-system/internal/adapters/storage"
 
 	// Слой вариантов использования
 	"wallpaper-system/internal/usecases"
@@ -80,16 +82,66 @@ func main() {
 	// Инициализируем репозитории (слой адаптеров)
 	productRepo := repositories.NewProductRepository(db.GetConnection())
 	materialRepo := repositories.NewMaterialRepository(db.GetConnection())
+	pricingRuleRepo := repositories.NewPricingRuleRepository(db.GetConnection())
+	searchRepo := repositories.NewSearchRepository(db.GetConnection())
+	certificateRepo := repositories.NewCertificateRepository(db.GetConnection())
+	variantRepo := repositories.NewProductVariantRepository(db.GetConnection())
+	productTypeRepo := repositories.NewProductTypeRepository(db.GetConnection())
+	materialTypeRepo := repositories.NewMaterialTypeRepository(db.GetConnection())
+	unitRepo := repositories.NewUnitRepository(db.GetConnection())
+	orderRepo := repositories.NewOrderRepository(db.GetConnection())
+	movementRepo := repositories.NewMaterialMovementRepository(db.GetConnection())
+	stockAlertRepo := repositories.NewStockAlertRepository(db.GetConnection())
+	stocktakeRepo := repositories.NewStocktakeRepository(db.GetConnection())
+	lotRepo := repositories.NewMaterialLotRepository(db.GetConnection())
+	costingRepo := repositories.NewMaterialCostingRepository(db.GetConnection())
+
+	// Хранилище загруженных файлов на диске сервера
+	fileStorage := storage.NewLocalStorage(cfg.Storage.UploadDir, cfg.Storage.URLPrefix)
 
 	// Инициализируем варианты использования (слой бизнес-логики)
-	productUseCase := usecases.NewProductUseCase(productRepo, materialRepo)
-	materialUseCase := usecases.NewMaterialUseCase(materialRepo)
+	productUseCase := usecases.NewProductUseCase(productRepo, materialRepo, pricingRuleRepo)
+	materialUseCase := usecases.NewMaterialUseCase(materialRepo, productUseCase)
 	calculatorUseCase := usecases.NewCalculatorUseCase(materialRepo)
+	roomCalculatorUseCase := usecases.NewRoomCalculatorUseCase(productRepo, pricingRuleRepo, productUseCase)
+	pricingRuleUseCase := usecases.NewPricingRuleUseCase(pricingRuleRepo, productRepo)
+	searchUseCase := usecases.NewSearchUseCase(searchRepo)
+	importUseCase := usecases.NewImportUseCase(productRepo, materialRepo, productUseCase)
+	imageUseCase := usecases.NewImageUseCase(productRepo, materialRepo, variantRepo, fileStorage)
+	certificateUseCase := usecases.NewCertificateUseCase(certificateRepo, productRepo, fileStorage)
+	variantUseCase := usecases.NewProductVariantUseCase(variantRepo, productRepo, materialRepo, productUseCase, fileStorage)
+	productTypeUseCase := usecases.NewProductTypeUseCase(productTypeRepo, productUseCase)
+	materialTypeUseCase := usecases.NewMaterialTypeUseCase(materialTypeRepo)
+	unitUseCase := usecases.NewUnitConversionUseCase(unitRepo, materialRepo)
+	shippingUseCase := usecases.NewShippingUseCase(productRepo, orderRepo)
+	movementUseCase := usecases.NewMaterialMovementUseCase(movementRepo, materialRepo, productUseCase)
+	stockAlertUseCase := usecases.NewStockAlertUseCase(stockAlertRepo)
+	orderUseCase := usecases.NewOrderUseCase(orderRepo, productUseCase, productUseCase)
+	stocktakeUseCase := usecases.NewStocktakeUseCase(stocktakeRepo, productUseCase)
+	lotUseCase := usecases.NewMaterialLotUseCase(lotRepo, orderRepo, productRepo, productUseCase, productUseCase)
+	costingUseCase := usecases.NewMaterialCostingUseCase(costingRepo, productUseCase)
 
 	// Инициализируем контроллеры (слой адаптеров)
-	productController := controllers.NewProductController(productUseCase, materialUseCase)
+	productController := controllers.NewProductController(productUseCase, materialUseCase, unitUseCase)
 	materialController := controllers.NewMaterialController(materialUseCase)
-	calculatorController := controllers.NewCalculatorController(calculatorUseCase, materialUseCase, productUseCase)
+	calculatorController := controllers.NewCalculatorController(calculatorUseCase, roomCalculatorUseCase, materialUseCase, productUseCase)
+	pricingRuleController := controllers.NewPricingRuleController(pricingRuleUseCase)
+	searchController := controllers.NewSearchController(searchUseCase)
+	importController := controllers.NewImportController(importUseCase)
+	exportController := controllers.NewExportController(productUseCase, materialUseCase)
+	imageController := controllers.NewImageController(imageUseCase)
+	certificateController := controllers.NewCertificateController(certificateUseCase, productUseCase)
+	variantController := controllers.NewVariantController(variantUseCase, materialUseCase, unitUseCase)
+	productTypeController := controllers.NewProductTypeController(productTypeUseCase)
+	materialTypeController := controllers.NewMaterialTypeController(materialTypeUseCase)
+	unitController := controllers.NewUnitController(unitUseCase, materialUseCase)
+	shippingController := controllers.NewShippingController(shippingUseCase)
+	movementController := controllers.NewMovementController(movementUseCase, materialUseCase)
+	stockAlertController := controllers.NewStockAlertController(stockAlertUseCase)
+	orderController := controllers.NewOrderController(orderUseCase, certificateUseCase)
+	stocktakeController := controllers.NewStocktakeController(stocktakeUseCase)
+	lotController := controllers.NewLotController(lotUseCase, materialUseCase)
+	costingController := controllers.NewCostingController(costingUseCase)
 
 	// Создаем роутер Gin
 	router := gin.Default()
@@ -146,9 +198,10 @@ func main() {
 
 	// Подключаем статические файлы
 	router.Static("/static", "./static")
+	router.Static(cfg.Storage.URLPrefix, cfg.Storage.UploadDir)
 
 	// Настраиваем маршруты (слой инфраструктуры)
-	server.SetupRoutes(router, productController, calculatorController, materialController)
+	server.SetupRoutes(router, productController, calculatorController, materialController, pricingRuleController, searchController, importController, exportController, imageController, certificateController, variantController, productTypeController, materialTypeController, unitController, shippingController, movementController, stockAlertController, orderController, stocktakeController, lotController, costingController)
 
 	// Создаем HTTP сервер
 	srv := &http.Server{
@@ -167,6 +220,11 @@ func main() {
 		}
 	}()
 
+	// Запускаем фоновую проверку минимальных остатков материалов
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go jobs.NewLowStockChecker(stockAlertUseCase, cfg.Inventory.LowStockCheckInterval, sugar).Run(jobsCtx)
+
 	// Выводим информацию о запуске
 	printBanner(cfg)
 
@@ -176,6 +234,7 @@ func main() {
 	<-quit
 
 	sugar.Info("Получен сигнал завершения, останавливаем сервер...")
+	stopJobs()
 
 	// Graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -209,10 +268,25 @@ func printBanner(cfg *config.Config) {
    • GET  /                          - Главная страница
    • GET  /products                  - Список продукции
    • GET  /products/:id              - Детали продукции
+   • GET  /products/:id/variants     - Варианты (расцветки) продукции
    • GET  /calculator                - Калькулятор материалов
+   • GET  /calculator/room           - Расчет обоев для комнаты
+   • GET  /import                    - Импорт из CSV и XLSX
+   • GET  /certificates              - Сертификаты качества
+   • GET  /product-types             - Типы продукции и история коэффициентов
+   • GET  /material-types            - Типы материалов и история процента брака
+   • GET  /materials/:id/units       - Единицы измерения и пересчеты материала
+   • GET  /materials/:id/history     - История движения материала
+   • GET  /materials/:id/lots        - Партии материала и прием поставок
+   • GET  /lots/:id                  - Прослеживаемость партии до заказов
+   • GET  /materials/:id/costing     - Себестоимость материала: метод расчета и фиксация
+   • GET  /materials/low-stock       - Пополнение склада: материалы ниже минимума
+   • GET  /stocktakes                - Инвентаризация материалов
    • POST /calculator                - Расчет материалов
    • API  /api/v1/products           - REST API продукции
    • API  /api/v1/calculator         - REST API калькулятора
+   • POST /api/v1/calculator/shipping - Расчет отгрузки на поддонах
+   • PUT  /api/v1/orders/:id/status  - Смена статуса заказа и резервы материалов
 
 `,
 		os.Getenv("APP_ENV"),
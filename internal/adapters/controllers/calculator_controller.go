@@ -13,21 +13,24 @@ import (
 
 // CalculatorController обрабатывает HTTP запросы для калькулятора
 type CalculatorController struct {
-	calculatorUseCase usecases.CalculatorUseCaseInterface
-	materialUseCase   usecases.MaterialUseCaseInterface
-	productUseCase    usecases.ProductUseCaseInterface
+	calculatorUseCase     usecases.CalculatorUseCaseInterface
+	roomCalculatorUseCase usecases.RoomCalculatorUseCaseInterface
+	materialUseCase       usecases.MaterialUseCaseInterface
+	productUseCase        usecases.ProductUseCaseInterface
 }
 
 // NewCalculatorController создает новый контроллер калькулятора
 func NewCalculatorController(
 	calculatorUseCase usecases.CalculatorUseCaseInterface,
+	roomCalculatorUseCase usecases.RoomCalculatorUseCaseInterface,
 	materialUseCase usecases.MaterialUseCaseInterface,
 	productUseCase usecases.ProductUseCaseInterface,
 ) *CalculatorController {
 	return &CalculatorController{
-		calculatorUseCase: calculatorUseCase,
-		materialUseCase:   materialUseCase,
-		productUseCase:    productUseCase,
+		calculatorUseCase:     calculatorUseCase,
+		roomCalculatorUseCase: roomCalculatorUseCase,
+		materialUseCase:       materialUseCase,
+		productUseCase:        productUseCase,
 	}
 }
 
@@ -164,3 +167,85 @@ func (c *CalculatorController) renderCalculatorWithError(ctx *gin.Context, error
 		"error":         errorMsg,
 	})
 }
+
+// GetRoomCalculatorPage отображает страницу расчета рулонов обоев для комнаты
+func (c *CalculatorController) GetRoomCalculatorPage(ctx *gin.Context) {
+	c.renderRoomCalculator(ctx, http.StatusOK, gin.H{
+		"request": dto.RoomRollRequestDTO{},
+	})
+}
+
+// CalculateRoom обрабатывает форму расчета рулонов для комнаты
+func (c *CalculatorController) CalculateRoom(ctx *gin.Context) {
+	var requestDTO dto.RoomRollRequestDTO
+	if err := ctx.ShouldBind(&requestDTO); err != nil {
+		c.renderRoomCalculator(ctx, http.StatusBadRequest, gin.H{
+			"request": requestDTO,
+			"error":   "Укажите продукцию, периметр и высоту комнаты",
+		})
+		return
+	}
+
+	request := requestDTO.ToEntity()
+	calculation, err := c.roomCalculatorUseCase.CalculateRoomRolls(request)
+	if err != nil {
+		c.renderRoomCalculator(ctx, errorStatus(err), gin.H{
+			"request":  requestDTO,
+			"openings": request.Openings,
+			"error":    err.Error(),
+		})
+		return
+	}
+
+	c.renderRoomCalculator(ctx, http.StatusOK, gin.H{
+		"request":  requestDTO,
+		"openings": request.Openings,
+		"result":   dto.FromRoomRollCalculation(calculation),
+	})
+}
+
+// CalculateRoomAPI рассчитывает количество рулонов для комнаты через API
+func (c *CalculatorController) CalculateRoomAPI(ctx *gin.Context) {
+	var requestDTO dto.RoomRollRequestDTO
+	if err := ctx.ShouldBindJSON(&requestDTO); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса: "+err.Error()))
+		return
+	}
+
+	calculation, err := c.roomCalculatorUseCase.CalculateRoomRolls(requestDTO.ToEntity())
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Расчет выполнен", dto.FromRoomRollCalculation(calculation)))
+}
+
+// renderRoomCalculator отображает страницу калькулятора комнаты со списками продукции и типов партнеров
+func (c *CalculatorController) renderRoomCalculator(ctx *gin.Context, status int, data gin.H) {
+	products, err := c.roomCalculatorUseCase.GetRollProducts()
+	if err != nil {
+		ctx.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "Ошибка получения продукции",
+		})
+		return
+	}
+	partnerTypes, err := c.roomCalculatorUseCase.GetPartnerTypes()
+	if err != nil {
+		ctx.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "Ошибка получения типов партнеров",
+		})
+		return
+	}
+
+	data["title"] = "Расчет обоев для комнаты"
+	data["products"] = products
+	data["partnerTypes"] = partnerTypes
+	data["defaultReserve"] = entities.DefaultRoomReservePercent
+	if request, ok := data["request"].(dto.RoomRollRequestDTO); ok && request.PartnerTypeID != nil {
+		data["partnerTypeID"] = *request.PartnerTypeID
+	} else {
+		data["partnerTypeID"] = 0
+	}
+	ctx.HTML(status, "room_calculator.html", data)
+}
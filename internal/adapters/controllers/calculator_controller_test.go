@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CalculatorControllerTestSuite struct {
+	suite.Suite
+	roomCalculatorUseCase *mocks.MockRoomCalculatorUseCase
+	controller            *CalculatorController
+	router                *gin.Engine
+}
+
+func (suite *CalculatorControllerTestSuite) SetupTest() {
+	suite.roomCalculatorUseCase = new(mocks.MockRoomCalculatorUseCase)
+	suite.controller = NewCalculatorController(new(mocks.MockCalculatorUseCase), suite.roomCalculatorUseCase,
+		new(mocks.MockMaterialUseCase), new(mocks.MockProductUseCase))
+
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+	suite.router.POST("/api/v1/calculator/room", suite.controller.CalculateRoomAPI)
+}
+
+func (suite *CalculatorControllerTestSuite) TestCalculateRoomAPI_Success() {
+	// Подготовка данных
+	width, length := 0.53, 10.05
+	calculation := &entities.RoomRollCalculation{
+		Product:       &entities.Product{ID: 1, Article: "ART001", RollWidth: &width, RollLength: &length},
+		StripsPerRoll: 3,
+		Strips:        25,
+		Rolls:         9,
+		ReserveRolls:  1,
+		TotalRolls:    10,
+		RollPrice:     600,
+		TotalCost:     6000,
+	}
+
+	// Настройка мока: запас по умолчанию и проем из списка openings
+	suite.roomCalculatorUseCase.On("CalculateRoomRolls", mock.MatchedBy(func(r *entities.RoomRollRequest) bool {
+		return r.ProductID == 1 && r.ReservePercent == entities.DefaultRoomReservePercent &&
+			r.PartnerTypeID == nil && len(r.Openings) == 1 && r.Openings[0].Width == 1.5
+	})).Return(calculation, nil)
+
+	// Выполнение запроса
+	body := `{"product_id": 1, "perimeter": 14, "height": 2.7, "openings": [{"width": 1.5, "height": 1.4}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/calculator/room", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response struct {
+		Data struct {
+			Article    string  `json:"article"`
+			RollLength float64 `json:"roll_length"`
+			TotalRolls int     `json:"total_rolls"`
+			TotalCost  float64 `json:"total_cost"`
+		} `json:"data"`
+	}
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(suite.T(), "ART001", response.Data.Article)
+	assert.Equal(suite.T(), 10.05, response.Data.RollLength)
+	assert.Equal(suite.T(), 10, response.Data.TotalRolls)
+	assert.Equal(suite.T(), 6000.0, response.Data.TotalCost)
+}
+
+func (suite *CalculatorControllerTestSuite) TestCalculateRoomAPI_InvalidOpening() {
+	// Выполнение запроса
+	body := `{"product_id": 1, "perimeter": 14, "height": 2.7, "openings": [{"width": 0, "height": 1.4}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/calculator/room", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.roomCalculatorUseCase.AssertNotCalled(suite.T(), "CalculateRoomRolls", mock.Anything)
+}
+
+func (suite *CalculatorControllerTestSuite) TestCalculateRoomAPI_ProductNotFound() {
+	// Настройка мока
+	suite.roomCalculatorUseCase.On("CalculateRoomRolls", mock.Anything).
+		Return(nil, entities.NewNotFoundError("продукция", "99"))
+
+	// Выполнение запроса
+	body := `{"product_id": 99, "perimeter": 14, "height": 2.7}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/calculator/room", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+func TestCalculatorControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(CalculatorControllerTestSuite))
+}
@@ -0,0 +1,416 @@
+package controllers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"wallpaper-system/internal/adapters/controllers/dto"
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CertificateController обрабатывает HTTP запросы для сертификатов качества
+type CertificateController struct {
+	certificateUseCase usecases.CertificateUseCaseInterface
+	productUseCase     usecases.ProductUseCaseInterface
+}
+
+// NewCertificateController создает новый контроллер сертификатов качества
+func NewCertificateController(
+	certificateUseCase usecases.CertificateUseCaseInterface,
+	productUseCase usecases.ProductUseCaseInterface,
+) *CertificateController {
+	return &CertificateController{
+		certificateUseCase: certificateUseCase,
+		productUseCase:     productUseCase,
+	}
+}
+
+// GetCertificates возвращает список сертификатов через API
+func (c *CertificateController) GetCertificates(ctx *gin.Context) {
+	certificates, err := c.certificateUseCase.GetAllCertificates()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Сертификаты получены", dto.FromCertificateEntities(certificates, time.Now()))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetCertificateByID возвращает сертификат по ID через API
+func (c *CertificateController) GetCertificateByID(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID сертификата"))
+		return
+	}
+
+	certificate, err := c.certificateUseCase.GetCertificateByID(id)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Сертификат получен", dto.FromCertificateEntity(certificate, time.Now()))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// CreateCertificate создает сертификат через API
+func (c *CertificateController) CreateCertificate(ctx *gin.Context) {
+	var request dto.CertificateRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса: "+err.Error()))
+		return
+	}
+
+	certificate, err := request.ToEntity()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	if err := c.certificateUseCase.CreateCertificate(certificate); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Сертификат создан", dto.FromCertificateEntity(certificate, time.Now()))
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// UpdateCertificate обновляет реквизиты сертификата и список продукции через API
+func (c *CertificateController) UpdateCertificate(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID сертификата"))
+		return
+	}
+
+	var request dto.CertificateRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса: "+err.Error()))
+		return
+	}
+
+	certificate, err := request.ToEntity()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(err.Error()))
+		return
+	}
+	certificate.ID = id
+
+	if err := c.certificateUseCase.UpdateCertificate(certificate); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Сертификат обновлен", dto.FromCertificateEntity(certificate, time.Now()))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// DeleteCertificate удаляет сертификат через API
+func (c *CertificateController) DeleteCertificate(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID сертификата"))
+		return
+	}
+
+	if err := c.certificateUseCase.DeleteCertificate(id); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Сертификат удален", nil))
+}
+
+// UploadCertificateFile загружает скан сертификата:
+// POST /api/v1/certificates/:id/file (multipart, поле file)
+func (c *CertificateController) UploadCertificateFile(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID сертификата"))
+		return
+	}
+
+	data, contentType, err := readCertificateFile(ctx)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+	if data == nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("файл скана не передан"))
+		return
+	}
+
+	certificate, err := c.certificateUseCase.UploadCertificateFile(id, data, contentType)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Скан сертификата загружен", dto.FromCertificateEntity(certificate, time.Now()))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetExpiringCertificates возвращает продукцию с истекающими и истекшими сертификатами:
+// GET /api/v1/certificates/expiring?days=30
+func (c *CertificateController) GetExpiringCertificates(ctx *gin.Context) {
+	var query dto.ExpiringCertificatesQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные параметры запроса: "+err.Error()))
+		return
+	}
+
+	expiring, err := c.certificateUseCase.GetExpiringCertificates(query.Days)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Отчет об истекающих сертификатах сформирован", dto.FromExpiringCertificates(expiring))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// CheckProducts проверяет, есть ли у продукции действующие сертификаты, например перед
+// добавлением в заказ: POST /api/v1/certificates/check
+func (c *CertificateController) CheckProducts(ctx *gin.Context) {
+	var request dto.CertificateCheckRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса: "+err.Error()))
+		return
+	}
+
+	date, err := request.ParseDate()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	warnings, err := c.certificateUseCase.CheckProducts(request.ProductIDs, date)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse(certificateCheckMessage(warnings), dto.FromCertificateWarnings(warnings)))
+}
+
+// GetOrderCertificateWarnings возвращает предупреждения о продаже в заказе продукции
+// без действующего сертификата: GET /api/v1/orders/:id/certificate-warnings
+func (c *CertificateController) GetOrderCertificateWarnings(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID заказа"))
+		return
+	}
+
+	warnings, err := c.certificateUseCase.CheckOrder(id, time.Now())
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse(certificateCheckMessage(warnings), dto.FromCertificateWarnings(warnings)))
+}
+
+// GetProductCertificates возвращает сертификаты продукции: GET /api/v1/products/:id/certificates
+func (c *CertificateController) GetProductCertificates(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID продукции"))
+		return
+	}
+
+	certificates, err := c.certificateUseCase.GetProductCertificates(id)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Сертификаты продукции получены", dto.FromCertificateEntities(certificates, time.Now()))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetCertificatesPage отображает страницу сертификатов: отчет об истекающих сертификатах,
+// список сертификатов (всех или одной продукции при ?product_id=) и форму добавления
+func (c *CertificateController) GetCertificatesPage(ctx *gin.Context) {
+	c.renderCertificatesPage(ctx, http.StatusOK, "")
+}
+
+// CreateCertificateWeb создает сертификат из формы, при наличии файла сразу загружает скан
+func (c *CertificateController) CreateCertificateWeb(ctx *gin.Context) {
+	var request dto.CertificateRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		c.renderCertificatesPage(ctx, http.StatusBadRequest, "Некорректные данные формы: "+err.Error())
+		return
+	}
+
+	certificate, err := request.ToEntity()
+	if err != nil {
+		c.renderCertificatesPage(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Файл проверяется до создания сертификата, чтобы ошибка формата не оставила сертификат без скана
+	data, contentType, err := readCertificateFile(ctx)
+	if err != nil {
+		c.renderCertificatesPage(ctx, errorStatus(err), err.Error())
+		return
+	}
+	if data != nil {
+		if _, err := entities.CertificateFileExtension(contentType); err != nil {
+			c.renderCertificatesPage(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	if err := c.certificateUseCase.CreateCertificate(certificate); err != nil {
+		c.renderCertificatesPage(ctx, errorStatus(err), "Ошибка создания сертификата: "+err.Error())
+		return
+	}
+
+	if data != nil {
+		if _, err := c.certificateUseCase.UploadCertificateFile(certificate.ID, data, contentType); err != nil {
+			ctx.HTML(errorStatus(err), "error.html", gin.H{
+				"error": "Сертификат создан, но скан не сохранен: " + err.Error(),
+			})
+			return
+		}
+	}
+
+	ctx.Redirect(http.StatusFound, "/certificates")
+}
+
+// UploadCertificateFileWeb загружает скан сертификата из формы на странице сертификатов
+func (c *CertificateController) UploadCertificateFileWeb(ctx *gin.Context) {
+	c.certificateWeb(ctx, func(id int) error {
+		data, contentType, err := readCertificateFile(ctx)
+		if err != nil {
+			return err
+		}
+		if data == nil {
+			return entities.NewValidationError("file", "файл скана не передан")
+		}
+		_, err = c.certificateUseCase.UploadCertificateFile(id, data, contentType)
+		return err
+	})
+}
+
+// DeleteCertificateWeb удаляет сертификат из формы на странице сертификатов
+func (c *CertificateController) DeleteCertificateWeb(ctx *gin.Context) {
+	c.certificateWeb(ctx, c.certificateUseCase.DeleteCertificate)
+}
+
+func (c *CertificateController) certificateWeb(ctx *gin.Context, action func(id int) error) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID сертификата",
+		})
+		return
+	}
+
+	if err := action(id); err != nil {
+		ctx.HTML(errorStatus(err), "error.html", gin.H{
+			"error": "Ошибка сертификата: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/certificates")
+}
+
+func (c *CertificateController) renderCertificatesPage(ctx *gin.Context, status int, formError string) {
+	var query dto.CertificatesPageQuery
+	_ = ctx.ShouldBindQuery(&query)
+
+	days, productID, err := query.Parse()
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	expiring, err := c.certificateUseCase.GetExpiringCertificates(days)
+	if err != nil {
+		ctx.HTML(listErrorStatus(err), "error.html", gin.H{
+			"error": "Ошибка формирования отчета: " + err.Error(),
+		})
+		return
+	}
+
+	var certificates []entities.QualityCertificate
+	var warnings []entities.CertificateWarning
+	if productID > 0 {
+		certificates, err = c.certificateUseCase.GetProductCertificates(productID)
+		if err == nil {
+			warnings, err = c.certificateUseCase.CheckProducts([]int{productID}, time.Now())
+		}
+	} else {
+		certificates, err = c.certificateUseCase.GetAllCertificates()
+	}
+	if err != nil {
+		ctx.HTML(listErrorStatus(err), "error.html", gin.H{
+			"error": "Ошибка получения сертификатов: " + err.Error(),
+		})
+		return
+	}
+
+	products, err := c.productUseCase.GetAllProducts()
+	if err != nil {
+		ctx.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "Ошибка загрузки продукции: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.HTML(status, "certificates.html", gin.H{
+		"title":        "Сертификаты качества",
+		"days":         days,
+		"productID":    productID,
+		"expiring":     dto.FromExpiringCertificates(expiring),
+		"certificates": dto.FromCertificateEntities(certificates, time.Now()),
+		"warnings":     dto.FromCertificateWarnings(warnings),
+		"products":     products,
+		"error":        formError,
+	})
+}
+
+// readCertificateFile читает необязательный скан сертификата из поля file и определяет
+// тип содержимого по данным. Если файл не передан, возвращает nil без ошибки
+func readCertificateFile(ctx *gin.Context) ([]byte, string, error) {
+	header, err := ctx.FormFile("file")
+	if err != nil || header.Size == 0 {
+		return nil, "", nil
+	}
+	if header.Size > entities.MaxCertificateFileSize {
+		return nil, "", entities.NewValidationError("file",
+			fmt.Sprintf("размер скана превышает %d МБ", entities.MaxCertificateFileSize>>20))
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, "", fmt.Errorf("ошибка открытия файла: %w", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, entities.MaxCertificateFileSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("ошибка чтения файла: %w", err)
+	}
+
+	return data, http.DetectContentType(data), nil
+}
+
+func certificateCheckMessage(warnings []entities.CertificateWarning) string {
+	if len(warnings) == 0 {
+		return "Вся продукция сертифицирована"
+	}
+	return fmt.Sprintf("Продукция без действующего сертификата: %d", len(warnings))
+}
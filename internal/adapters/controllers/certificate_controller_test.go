@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CertificateControllerTestSuite struct {
+	suite.Suite
+	certificateUseCase *mocks.MockCertificateUseCase
+	productUseCase     *mocks.MockProductUseCase
+	controller         *CertificateController
+	router             *gin.Engine
+}
+
+func (suite *CertificateControllerTestSuite) SetupTest() {
+	suite.certificateUseCase = new(mocks.MockCertificateUseCase)
+	suite.productUseCase = new(mocks.MockProductUseCase)
+	suite.controller = NewCertificateController(suite.certificateUseCase, suite.productUseCase)
+
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+	v1 := suite.router.Group("/api/v1")
+	{
+		v1.POST("/certificates", suite.controller.CreateCertificate)
+		v1.GET("/certificates/expiring", suite.controller.GetExpiringCertificates)
+		v1.POST("/certificates/:id/file", suite.controller.UploadCertificateFile)
+		v1.GET("/orders/:id/certificate-warnings", suite.controller.GetOrderCertificateWarnings)
+	}
+}
+
+func (suite *CertificateControllerTestSuite) TestCreateCertificate_Success() {
+	// Настройка мока
+	suite.certificateUseCase.On("CreateCertificate", mock.MatchedBy(func(c *entities.QualityCertificate) bool {
+		return c.Number == "RU-001" && c.ValidTo.Equal(time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)) &&
+			len(c.ProductIDs) == 2 && c.StandardNumber == nil
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*entities.QualityCertificate).ID = 7
+	}).Return(nil)
+
+	// Выполнение запроса
+	body := `{"number": "RU-001", "issuing_body": "Центр", "standard_number": " ",
+		"valid_from": "2024-01-01", "valid_to": "2026-12-31", "product_ids": [1, 2]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/certificates", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+
+	var response struct {
+		Data struct {
+			ID      int    `json:"id"`
+			ValidTo string `json:"valid_to"`
+		} `json:"data"`
+	}
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(suite.T(), 7, response.Data.ID)
+	assert.Equal(suite.T(), "2026-12-31", response.Data.ValidTo)
+}
+
+func (suite *CertificateControllerTestSuite) TestCreateCertificate_InvalidDate() {
+	// Выполнение запроса
+	body := `{"number": "RU-001", "issuing_body": "Центр", "valid_from": "01.01.2024", "valid_to": "2026-12-31", "product_ids": [1]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/certificates", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.certificateUseCase.AssertNotCalled(suite.T(), "CreateCertificate", mock.Anything)
+}
+
+func (suite *CertificateControllerTestSuite) TestGetExpiringCertificates_Success() {
+	// Настройка мока
+	suite.certificateUseCase.On("GetExpiringCertificates", 60).Return([]entities.ExpiringCertificate{
+		{Product: entities.CertificateProduct{ID: 1, Article: "WP-001"}, CertificateNumber: "RU-001",
+			ValidTo: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), DaysLeft: -3},
+	}, nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/certificates/expiring?days=60", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response struct {
+		Data []struct {
+			DaysLeft int  `json:"days_left"`
+			Expired  bool `json:"expired"`
+		} `json:"data"`
+	}
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(suite.T(), response.Data, 1)
+	assert.True(suite.T(), response.Data[0].Expired)
+}
+
+func (suite *CertificateControllerTestSuite) TestUploadCertificateFile_DetectsContentType() {
+	// Подготовка данных
+	content := []byte("%PDF-1.4\n%скан сертификата")
+	path := "/uploads/certificates/3/a.pdf"
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", "scan.bin")
+	assert.NoError(suite.T(), err)
+	_, err = part.Write(content)
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), writer.Close())
+
+	// Настройка мока: тип определяется по содержимому, а не по имени файла
+	suite.certificateUseCase.On("UploadCertificateFile", 3, content, "application/pdf").
+		Return(&entities.QualityCertificate{ID: 3, FilePath: &path}, nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/certificates/3/file", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	suite.certificateUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *CertificateControllerTestSuite) TestGetOrderCertificateWarnings_OrderNotFound() {
+	// Настройка мока
+	suite.certificateUseCase.On("CheckOrder", 42, mock.AnythingOfType("time.Time")).
+		Return(nil, entities.NewNotFoundError("заказ", "42"))
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/42/certificate-warnings", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+func TestCertificateControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(CertificateControllerTestSuite))
+}
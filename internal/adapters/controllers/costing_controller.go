@@ -0,0 +1,177 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"wallpaper-system/internal/adapters/controllers/dto"
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CostingController обрабатывает HTTP запросы себестоимости материалов
+type CostingController struct {
+	costingUseCase usecases.MaterialCostingUseCaseInterface
+}
+
+// NewCostingController создает новый контроллер себестоимости материалов
+func NewCostingController(costingUseCase usecases.MaterialCostingUseCaseInterface) *CostingController {
+	return &CostingController{costingUseCase: costingUseCase}
+}
+
+// GetCosting возвращает метод расчета, фактическую и используемую себестоимость материала
+func (c *CostingController) GetCosting(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID материала"))
+		return
+	}
+
+	costing, err := c.costingUseCase.GetCosting(id)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Себестоимость материала получена", dto.FromMaterialCosting(costing)))
+}
+
+// UpdateCosting меняет метод расчета и фиксацию себестоимости материала через API
+func (c *CostingController) UpdateCosting(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID материала"))
+		return
+	}
+
+	var request dto.CostingRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса: "+err.Error()))
+		return
+	}
+
+	costing, err := c.costingUseCase.UpdateCosting(id, request.Method(), request.Locked, request.CostPerUnit)
+	if warning, ok := costRecalculationWarning(err); ok {
+		ctx.JSON(http.StatusOK, dto.NewWarningResponse("Себестоимость материала обновлена", warning, dto.FromMaterialCosting(costing)))
+		return
+	}
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Себестоимость материала обновлена", dto.FromMaterialCosting(costing)))
+}
+
+// RecalculateCost пересчитывает себестоимость материала по журналу через API
+func (c *CostingController) RecalculateCost(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID материала"))
+		return
+	}
+
+	costing, err := c.costingUseCase.RecalculateCost(id)
+	if warning, ok := costRecalculationWarning(err); ok {
+		ctx.JSON(http.StatusOK, dto.NewWarningResponse("Себестоимость материала пересчитана", warning, dto.FromMaterialCosting(costing)))
+		return
+	}
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Себестоимость материала пересчитана", dto.FromMaterialCosting(costing)))
+}
+
+// GetCostingPage отображает себестоимость материала и форму ее настройки
+func (c *CostingController) GetCostingPage(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID материала",
+		})
+		return
+	}
+
+	c.renderCostingPage(ctx, id, http.StatusOK, "")
+}
+
+// UpdateCostingWeb меняет метод расчета и фиксацию себестоимости материала из формы
+func (c *CostingController) UpdateCostingWeb(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID материала",
+		})
+		return
+	}
+
+	var form dto.CostingForm
+	if err := ctx.ShouldBind(&form); err != nil {
+		c.renderCostingPage(ctx, id, http.StatusBadRequest, "Некорректные данные формы: "+err.Error())
+		return
+	}
+
+	method, locked, manualCost, err := form.Parse()
+	if err != nil {
+		c.renderCostingPage(ctx, id, http.StatusBadRequest, "Некорректные данные формы: "+err.Error())
+		return
+	}
+
+	_, err = c.costingUseCase.UpdateCosting(id, method, locked, manualCost)
+	if _, ok := costRecalculationWarning(err); ok {
+		// Себестоимость материала сохранена, предупреждение о пересчете показывается на странице
+		ctx.Redirect(http.StatusFound, "/materials/"+strconv.Itoa(id)+"/costing?cost_warning=1")
+		return
+	}
+	if err != nil {
+		c.renderCostingPage(ctx, id, errorStatus(err), "Ошибка изменения себестоимости: "+err.Error())
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/materials/"+strconv.Itoa(id)+"/costing")
+}
+
+// RecalculateCostWeb пересчитывает себестоимость материала по журналу из веб-интерфейса
+func (c *CostingController) RecalculateCostWeb(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID материала",
+		})
+		return
+	}
+
+	_, err = c.costingUseCase.RecalculateCost(id)
+	if _, ok := costRecalculationWarning(err); ok {
+		ctx.Redirect(http.StatusFound, "/materials/"+strconv.Itoa(id)+"/costing?cost_warning=1")
+		return
+	}
+	if err != nil {
+		c.renderCostingPage(ctx, id, listErrorStatus(err), "Ошибка пересчета себестоимости: "+err.Error())
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/materials/"+strconv.Itoa(id)+"/costing")
+}
+
+func (c *CostingController) renderCostingPage(ctx *gin.Context, id int, status int, formError string) {
+	costing, err := c.costingUseCase.GetCosting(id)
+	if err != nil {
+		ctx.HTML(errorStatus(err), "error.html", gin.H{
+			"error": "Материал не найден: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.HTML(status, "material_costing.html", gin.H{
+		"title":       "Себестоимость материала " + costing.Material.Name,
+		"costing":     dto.FromMaterialCosting(costing),
+		"methods":     []entities.CostingMethod{entities.CostingWeightedAverage, entities.CostingFIFO},
+		"error":       formError,
+		"costWarning": ctx.Query("cost_warning") != "",
+	})
+}
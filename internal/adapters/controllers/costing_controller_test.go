@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CostingControllerTestSuite struct {
+	suite.Suite
+	costingUseCase *mocks.MockMaterialCostingUseCase
+	controller     *CostingController
+	router         *gin.Engine
+}
+
+func (suite *CostingControllerTestSuite) SetupTest() {
+	suite.costingUseCase = new(mocks.MockMaterialCostingUseCase)
+	suite.controller = NewCostingController(suite.costingUseCase)
+
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+
+	suite.router.POST("/materials/:id/costing", suite.controller.UpdateCostingWeb)
+	v1 := suite.router.Group("/api/v1")
+	{
+		v1.GET("/materials/:id/costing", suite.controller.GetCosting)
+		v1.PUT("/materials/:id/costing", suite.controller.UpdateCosting)
+	}
+}
+
+func (suite *CostingControllerTestSuite) TestGetCosting() {
+	// Настройка мока
+	suite.costingUseCase.On("GetCosting", 7).Return(&entities.MaterialCosting{
+		MaterialID: 7, Method: entities.CostingFIFO, ActualCost: 130, CostPerUnit: 130, StockQuantity: 5,
+		Material: &entities.Material{ID: 7, Article: "M-7", Name: "Флизелин"},
+	}, nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/materials/7/costing", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"costing_method":"fifo"`)
+	assert.Contains(suite.T(), w.Body.String(), `"stock_value":650`)
+}
+
+func (suite *CostingControllerTestSuite) TestUpdateCosting_LockWithManualCost() {
+	// Настройка мока
+	suite.costingUseCase.On("UpdateCosting", 7, entities.CostingWeightedAverage, true, mock.MatchedBy(func(cost *float64) bool {
+		return cost != nil && *cost == 95.5
+	})).Return(&entities.MaterialCosting{
+		MaterialID: 7, Method: entities.CostingWeightedAverage, Locked: true, ActualCost: 115, CostPerUnit: 95.5,
+	}, nil)
+
+	// Выполнение запроса
+	body := `{"costing_method": "weighted_average", "locked": true, "cost_per_unit": 95.5}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/materials/7/costing", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"locked":true`)
+	suite.costingUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *CostingControllerTestSuite) TestUpdateCosting_ValidationError() {
+	// Настройка мока
+	suite.costingUseCase.On("UpdateCosting", 7, entities.CostingMethod("lifo"), false, (*float64)(nil)).
+		Return(nil, entities.NewValidationError("costing_method", "неизвестный метод расчета себестоимости"))
+
+	// Выполнение запроса
+	body := `{"costing_method": "lifo"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/materials/7/costing", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.costingUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *CostingControllerTestSuite) TestUpdateCostingWeb_UnlockFollowsActualCost() {
+	// Настройка мока: без флажка фиксации пустая себестоимость не передается
+	suite.costingUseCase.On("UpdateCosting", 7, entities.CostingFIFO, false, (*float64)(nil)).
+		Return(&entities.MaterialCosting{MaterialID: 7, Method: entities.CostingFIFO}, nil)
+
+	// Выполнение запроса
+	form := "costing_method=fifo&cost_per_unit="
+	req := httptest.NewRequest(http.MethodPost, "/materials/7/costing", strings.NewReader(form))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusFound, w.Code)
+	assert.Equal(suite.T(), "/materials/7/costing", w.Header().Get("Location"))
+	suite.costingUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *CostingControllerTestSuite) TestUpdateCosting_CostWarning() {
+	// Настройка мока
+	suite.costingUseCase.On("UpdateCosting", 7, entities.CostingFIFO, false, (*float64)(nil)).Return(
+		&entities.MaterialCosting{MaterialID: 7, Method: entities.CostingFIFO, ActualCost: 130, CostPerUnit: 130},
+		entities.NewCostRecalculationError("себестоимость материала пересчитана", errors.New("database error")))
+
+	// Выполнение запроса
+	body := `{"costing_method": "fifo"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/materials/7/costing", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки: себестоимость материала сохранена, пересчет продукции показан предупреждением
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"warning":"себестоимость материала пересчитана, но себестоимость продукции не пересчитана`)
+	assert.Contains(suite.T(), w.Body.String(), `"costing_method":"fifo"`)
+}
+
+func (suite *CostingControllerTestSuite) TestUpdateCostingWeb_CostWarning() {
+	// Настройка мока
+	suite.costingUseCase.On("UpdateCosting", 7, entities.CostingFIFO, false, (*float64)(nil)).Return(
+		&entities.MaterialCosting{MaterialID: 7, Method: entities.CostingFIFO},
+		entities.NewCostRecalculationError("себестоимость материала пересчитана", errors.New("database error")))
+
+	// Выполнение запроса
+	form := "costing_method=fifo"
+	req := httptest.NewRequest(http.MethodPost, "/materials/7/costing", strings.NewReader(form))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusFound, w.Code)
+	assert.Equal(suite.T(), "/materials/7/costing?cost_warning=1", w.Header().Get("Location"))
+}
+
+func TestCostingControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(CostingControllerTestSuite))
+}
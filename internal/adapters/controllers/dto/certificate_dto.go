@@ -0,0 +1,225 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// CertificateProductDTO представляет продукцию, на которую распространяется сертификат
+type CertificateProductDTO struct {
+	ID      int    `json:"id"`
+	Article string `json:"article"`
+	Name    string `json:"name"`
+}
+
+// CertificateDTO представляет сертификат качества для API
+type CertificateDTO struct {
+	ID             int                     `json:"id"`
+	Number         string                  `json:"number"`
+	IssuingBody    string                  `json:"issuing_body"`
+	StandardNumber *string                 `json:"standard_number"`
+	ValidFrom      string                  `json:"valid_from"`
+	ValidTo        string                  `json:"valid_to"`
+	FilePath       *string                 `json:"file_path"`
+	Notes          *string                 `json:"notes"`
+	Status         string                  `json:"status"`
+	DaysLeft       int                     `json:"days_left"`
+	Products       []CertificateProductDTO `json:"products"`
+}
+
+// CertificateRequest представляет запрос на создание или изменение сертификата (JSON или форма)
+type CertificateRequest struct {
+	Number         string  `json:"number" form:"number" binding:"required"`
+	IssuingBody    string  `json:"issuing_body" form:"issuing_body" binding:"required"`
+	StandardNumber *string `json:"standard_number" form:"standard_number"`
+	ValidFrom      string  `json:"valid_from" form:"valid_from" binding:"required"`
+	ValidTo        string  `json:"valid_to" form:"valid_to" binding:"required"`
+	Notes          *string `json:"notes" form:"notes"`
+	ProductIDs     []int   `json:"product_ids" form:"product_ids" binding:"required,min=1,dive,gt=0"`
+}
+
+// ExpiringCertificateDTO представляет строку отчета об истекающих сертификатах
+type ExpiringCertificateDTO struct {
+	Product           CertificateProductDTO `json:"product"`
+	CertificateID     int                   `json:"certificate_id"`
+	CertificateNumber string                `json:"certificate_number"`
+	IssuingBody       string                `json:"issuing_body"`
+	ValidTo           string                `json:"valid_to"`
+	DaysLeft          int                   `json:"days_left"`
+	Expired           bool                  `json:"expired"`
+}
+
+// CertificateWarningDTO представляет предупреждение о продукции без действующего сертификата
+type CertificateWarningDTO struct {
+	Product     CertificateProductDTO `json:"product"`
+	Reason      string                `json:"reason"`
+	LastValidTo *string               `json:"last_valid_to"`
+	Message     string                `json:"message"`
+}
+
+// CertificateCheckRequest представляет запрос на проверку сертификации продукции, например
+// перед добавлением в заказ. Без даты проверка выполняется на сегодня
+type CertificateCheckRequest struct {
+	ProductIDs []int   `json:"product_ids" binding:"required,min=1,dive,gt=0"`
+	Date       *string `json:"date"`
+}
+
+// ExpiringCertificatesQuery представляет параметры отчета об истекающих сертификатах
+type ExpiringCertificatesQuery struct {
+	Days int `form:"days"`
+}
+
+// CertificatesPageQuery представляет параметры страницы сертификатов. Значения хранятся
+// строками, чтобы пустые поля формы не считались ошибкой
+type CertificatesPageQuery struct {
+	Days      string `form:"days"`
+	ProductID string `form:"product_id"`
+}
+
+// Parse возвращает горизонт отчета (по умолчанию - DefaultCertificateExpiryDays)
+// и ID продукции для отбора сертификатов (0 - все сертификаты)
+func (q *CertificatesPageQuery) Parse() (days int, productID int, err error) {
+	parsedDays, err := parseQueryInt("days", q.Days)
+	if err != nil {
+		return 0, 0, err
+	}
+	parsedProductID, err := parseQueryInt("product_id", q.ProductID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	days = entities.DefaultCertificateExpiryDays
+	if parsedDays != nil {
+		days = *parsedDays
+	}
+	if parsedProductID != nil {
+		productID = *parsedProductID
+	}
+	return days, productID, nil
+}
+
+// ToEntity преобразует DTO в доменную сущность
+func (dto *CertificateRequest) ToEntity() (*entities.QualityCertificate, error) {
+	validFrom, err := parseRequiredDate("valid_from", dto.ValidFrom)
+	if err != nil {
+		return nil, err
+	}
+	validTo, err := parseRequiredDate("valid_to", dto.ValidTo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.QualityCertificate{
+		Number:         strings.TrimSpace(dto.Number),
+		IssuingBody:    strings.TrimSpace(dto.IssuingBody),
+		StandardNumber: trimOptional(dto.StandardNumber),
+		ValidFrom:      validFrom,
+		ValidTo:        validTo,
+		Notes:          trimOptional(dto.Notes),
+		ProductIDs:     dto.ProductIDs,
+	}, nil
+}
+
+// ParseDate возвращает дату проверки: указанную в запросе или сегодняшнюю
+func (dto *CertificateCheckRequest) ParseDate() (time.Time, error) {
+	date, err := parseOptionalDate("date", dto.Date)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if date == nil {
+		return time.Now(), nil
+	}
+	return *date, nil
+}
+
+// FromCertificateEntity преобразует сертификат в DTO; состояние и остаток дней считаются на дату today
+func FromCertificateEntity(certificate *entities.QualityCertificate, today time.Time) CertificateDTO {
+	products := make([]CertificateProductDTO, len(certificate.Products))
+	for i, product := range certificate.Products {
+		products[i] = fromCertificateProduct(product)
+	}
+
+	return CertificateDTO{
+		ID:             certificate.ID,
+		Number:         certificate.Number,
+		IssuingBody:    certificate.IssuingBody,
+		StandardNumber: certificate.StandardNumber,
+		ValidFrom:      certificate.ValidFrom.Format(DateLayout),
+		ValidTo:        certificate.ValidTo.Format(DateLayout),
+		FilePath:       certificate.FilePath,
+		Notes:          certificate.Notes,
+		Status:         string(certificate.StatusOn(today)),
+		DaysLeft:       entities.DaysUntil(today, certificate.ValidTo),
+		Products:       products,
+	}
+}
+
+// FromCertificateEntities преобразует список сертификатов в DTO
+func FromCertificateEntities(certificates []entities.QualityCertificate, today time.Time) []CertificateDTO {
+	result := make([]CertificateDTO, len(certificates))
+	for i := range certificates {
+		result[i] = FromCertificateEntity(&certificates[i], today)
+	}
+	return result
+}
+
+// FromExpiringCertificates преобразует отчет об истекающих сертификатах в DTO
+func FromExpiringCertificates(expiring []entities.ExpiringCertificate) []ExpiringCertificateDTO {
+	result := make([]ExpiringCertificateDTO, len(expiring))
+	for i, item := range expiring {
+		result[i] = ExpiringCertificateDTO{
+			Product:           fromCertificateProduct(item.Product),
+			CertificateID:     item.CertificateID,
+			CertificateNumber: item.CertificateNumber,
+			IssuingBody:       item.IssuingBody,
+			ValidTo:           item.ValidTo.Format(DateLayout),
+			DaysLeft:          item.DaysLeft,
+			Expired:           item.DaysLeft < 0,
+		}
+	}
+	return result
+}
+
+// FromCertificateWarnings преобразует предупреждения о сертификации в DTO
+func FromCertificateWarnings(warnings []entities.CertificateWarning) []CertificateWarningDTO {
+	result := make([]CertificateWarningDTO, len(warnings))
+	for i, warning := range warnings {
+		result[i] = CertificateWarningDTO{
+			Product:     fromCertificateProduct(warning.Product),
+			Reason:      string(warning.Reason),
+			LastValidTo: formatOptionalDate(warning.LastValidTo),
+			Message:     warning.Message,
+		}
+	}
+	return result
+}
+
+func fromCertificateProduct(product entities.CertificateProduct) CertificateProductDTO {
+	return CertificateProductDTO{ID: product.ID, Article: product.Article, Name: product.Name}
+}
+
+// parseRequiredDate разбирает обязательную дату в формате ГГГГ-ММ-ДД
+func parseRequiredDate(field, value string) (time.Time, error) {
+	date, err := parseOptionalDate(field, &value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if date == nil {
+		return time.Time{}, entities.NewValidationError(field, "дата не указана")
+	}
+	return *date, nil
+}
+
+// trimOptional обрезает пробелы и заменяет пустую строку на nil
+func trimOptional(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -1,12 +1,47 @@
 package dto
 
-// SuccessResponse представляет успешный ответ API
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// SuccessResponse представляет успешный ответ API. Warning сообщает о сбое, который
+// не отменил выполненный запрос
 type SuccessResponse struct {
 	Success bool        `json:"success"`
 	Message string      `json:"message"`
+	Warning string      `json:"warning,omitempty"`
 	Data    interface{} `json:"data,omitempty"`
 }
 
+// PagedResponse представляет успешный ответ API со страницей списка
+type PagedResponse struct {
+	Success    bool          `json:"success"`
+	Message    string        `json:"message"`
+	Data       interface{}   `json:"data"`
+	Pagination PaginationDTO `json:"pagination"`
+}
+
+// PaginationDTO описывает страницу списка в ответе API
+type PaginationDTO struct {
+	Page       int `json:"page"`
+	PageSize   int `json:"page_size"`
+	Total      int `json:"total"`
+	TotalPages int `json:"total_pages"`
+}
+
+// PageLinksDTO описывает навигацию по страницам списка в веб-интерфейсе
+type PageLinksDTO struct {
+	Page       int
+	TotalPages int
+	Total      int
+	PrevURL    string
+	NextURL    string
+}
+
 // ErrorResponse представляет ответ с ошибкой
 type ErrorResponse struct {
 	Success bool   `json:"success"`
@@ -22,6 +57,13 @@ func NewSuccessResponse(message string, data interface{}) SuccessResponse {
 	}
 }
 
+// NewWarningResponse создает успешный ответ с предупреждением
+func NewWarningResponse(message, warning string, data interface{}) SuccessResponse {
+	response := NewSuccessResponse(message, data)
+	response.Warning = warning
+	return response
+}
+
 // NewErrorResponse создает новый ответ с ошибкой
 func NewErrorResponse(error string) ErrorResponse {
 	return ErrorResponse{
@@ -29,3 +71,118 @@ func NewErrorResponse(error string) ErrorResponse {
 		Error:   error,
 	}
 }
+
+// NewPagedResponse создает успешный ответ со страницей списка
+func NewPagedResponse(message string, data interface{}, info entities.PageInfo) PagedResponse {
+	return PagedResponse{
+		Success:    true,
+		Message:    message,
+		Data:       data,
+		Pagination: FromPageInfo(info),
+	}
+}
+
+// FromPageInfo преобразует сведения о странице в DTO
+func FromPageInfo(info entities.PageInfo) PaginationDTO {
+	return PaginationDTO{
+		Page:       info.Page,
+		PageSize:   info.PageSize,
+		Total:      info.Total,
+		TotalPages: info.TotalPages(),
+	}
+}
+
+// NewPageLinks строит ссылки на соседние страницы с сохранением фильтров
+func NewPageLinks(path string, filters url.Values, info entities.PageInfo) PageLinksDTO {
+	links := PageLinksDTO{
+		Page:       info.Page,
+		TotalPages: info.TotalPages(),
+		Total:      info.Total,
+	}
+
+	pageURL := func(page int) string {
+		values := url.Values{}
+		for key, value := range filters {
+			values[key] = value
+		}
+		values.Set("page", strconv.Itoa(page))
+		return path + "?" + values.Encode()
+	}
+
+	if info.HasPrev() {
+		links.PrevURL = pageURL(info.Page - 1)
+	}
+	if info.HasNext() {
+		links.NextURL = pageURL(info.Page + 1)
+	}
+
+	return links
+}
+
+// parseQueryInt разбирает необязательный целочисленный параметр запроса
+func parseQueryInt(field, value string) (*int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, entities.NewValidationError(field, "ожидается целое число")
+	}
+	return &parsed, nil
+}
+
+// parseQueryFloat разбирает необязательный дробный параметр запроса
+func parseQueryFloat(field, value string) (*float64, error) {
+	value = strings.TrimSpace(strings.Replace(value, ",", ".", 1))
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, entities.NewValidationError(field, "ожидается число")
+	}
+	return &parsed, nil
+}
+
+// parsePagination разбирает номер и размер страницы
+func parsePagination(page, pageSize string) (entities.Pagination, error) {
+	var pagination entities.Pagination
+
+	pageNumber, err := parseQueryInt("page", page)
+	if err != nil {
+		return pagination, err
+	}
+	if pageNumber != nil {
+		pagination.Page = *pageNumber
+	}
+
+	size, err := parseQueryInt("page_size", pageSize)
+	if err != nil {
+		return pagination, err
+	}
+	if size != nil {
+		pagination.PageSize = *size
+	}
+
+	return pagination, nil
+}
+
+// parseQueryBool разбирает необязательный флаг из строки запроса или флажка формы
+func parseQueryBool(field, value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "0", "false", "off":
+		return false, nil
+	case "1", "true", "on":
+		return true, nil
+	default:
+		return false, entities.NewValidationError(field, "ожидается true или false")
+	}
+}
+
+// setIfNotEmpty добавляет непустое значение фильтра в параметры ссылки
+func setIfNotEmpty(values url.Values, key, value string) {
+	if strings.TrimSpace(value) != "" {
+		values.Set(key, value)
+	}
+}
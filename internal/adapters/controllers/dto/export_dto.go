@@ -0,0 +1,86 @@
+package dto
+
+import (
+	"net/url"
+	"strings"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// ProductExportQuery представляет параметры выгрузки каталога продукции:
+// фильтры списка, формат, столбцы и тип партнера для расчета цен
+type ProductExportQuery struct {
+	ProductListQuery
+	Format        string   `form:"format"`
+	Columns       []string `form:"columns"`
+	PartnerTypeID string   `form:"partner_type_id"`
+}
+
+// ToRequest преобразует параметры запроса в запрос выгрузки и формат файла
+func (q *ProductExportQuery) ToRequest() (entities.ProductExportRequest, entities.ExportFormat, error) {
+	var request entities.ProductExportRequest
+
+	format, err := entities.ParseExportFormat(q.Format)
+	if err != nil {
+		return request, "", err
+	}
+	if request.Criteria, err = q.ToCriteria(); err != nil {
+		return request, "", err
+	}
+	if request.PartnerTypeID, err = parseQueryInt("partner_type_id", q.PartnerTypeID); err != nil {
+		return request, "", err
+	}
+	request.Columns = splitColumns(q.Columns)
+
+	return request, format, nil
+}
+
+// MaterialExportQuery представляет параметры выгрузки каталога материалов
+type MaterialExportQuery struct {
+	MaterialListQuery
+	Format  string   `form:"format"`
+	Columns []string `form:"columns"`
+}
+
+// ToRequest преобразует параметры запроса в запрос выгрузки и формат файла
+func (q *MaterialExportQuery) ToRequest() (entities.MaterialExportRequest, entities.ExportFormat, error) {
+	var request entities.MaterialExportRequest
+
+	format, err := entities.ParseExportFormat(q.Format)
+	if err != nil {
+		return request, "", err
+	}
+	if request.Criteria, err = q.ToCriteria(); err != nil {
+		return request, "", err
+	}
+	request.Columns = splitColumns(q.Columns)
+
+	return request, format, nil
+}
+
+// ExportLinks возвращает ссылки на выгрузку списка с текущими фильтрами во всех форматах
+func ExportLinks(path string, filters url.Values) map[string]string {
+	links := make(map[string]string, 3)
+	for _, format := range []entities.ExportFormat{entities.ExportFormatCSV, entities.ExportFormatXLSX, entities.ExportFormatHTML} {
+		values := url.Values{}
+		for key, value := range filters {
+			values[key] = value
+		}
+		values.Set("format", string(format))
+		links[string(format)] = path + "?" + values.Encode()
+	}
+	return links
+}
+
+// splitColumns принимает столбцы как повторяющимся параметром, так и списком через запятую
+func splitColumns(values []string) []string {
+	var columns []string
+	for _, value := range values {
+		for _, column := range strings.Split(value, ",") {
+			if column = strings.TrimSpace(column); column != "" {
+				columns = append(columns, column)
+			}
+		}
+	}
+	return columns
+}
@@ -0,0 +1,19 @@
+package dto
+
+import "wallpaper-system/internal/domain/entities"
+
+// ImageDTO представляет ссылки на изображение и его уменьшенные копии
+type ImageDTO struct {
+	ImagePath     *string `json:"image_path"`
+	ThumbnailPath *string `json:"thumbnail_path"`
+	PreviewPath   *string `json:"preview_path"`
+}
+
+// FromImagePaths преобразует ссылки на изображение в DTO
+func FromImagePaths(images entities.ImagePaths) ImageDTO {
+	return ImageDTO{
+		ImagePath:     images.ImagePath,
+		ThumbnailPath: images.ThumbnailPath,
+		PreviewPath:   images.PreviewPath,
+	}
+}
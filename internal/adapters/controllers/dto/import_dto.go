@@ -0,0 +1,79 @@
+package dto
+
+import "wallpaper-system/internal/domain/entities"
+
+// Виды импортируемых записей
+const (
+	ImportEntityProducts  = "products"
+	ImportEntityMaterials = "materials"
+)
+
+// ImportRequest представляет параметры загрузки файла импорта (сам файл передается в поле file)
+type ImportRequest struct {
+	Entity string `form:"entity"`
+	DryRun string `form:"dry_run"`
+}
+
+// IsDryRun сообщает, нужно ли только проверить файл без сохранения
+func (r ImportRequest) IsDryRun() (bool, error) {
+	return parseQueryBool("dry_run", r.DryRun)
+}
+
+// ImportRowErrorDTO представляет ошибку в строке файла импорта
+type ImportRowErrorDTO struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportRowDTO представляет результат обработки строки файла импорта
+type ImportRowDTO struct {
+	Row     int                 `json:"row"`
+	Article string              `json:"article"`
+	Action  string              `json:"action"`
+	Errors  []ImportRowErrorDTO `json:"errors,omitempty"`
+}
+
+// ImportReportDTO представляет отчет об импорте
+type ImportReportDTO struct {
+	DryRun         bool           `json:"dry_run"`
+	TotalRows      int            `json:"total_rows"`
+	Created        int            `json:"created"`
+	Updated        int            `json:"updated"`
+	Skipped        int            `json:"skipped"`
+	Columns        []string       `json:"columns"`
+	IgnoredColumns []string       `json:"ignored_columns,omitempty"`
+	Rows           []ImportRowDTO `json:"rows"`
+	CostWarning    string         `json:"cost_warning,omitempty"`
+}
+
+// FromImportReport преобразует отчет об импорте в DTO
+func FromImportReport(report *entities.ImportReport) ImportReportDTO {
+	rows := make([]ImportRowDTO, len(report.Rows))
+	for i, row := range report.Rows {
+		rows[i] = ImportRowDTO{
+			Row:     row.Row,
+			Article: row.Article,
+			Action:  string(row.Action),
+		}
+		for _, rowErr := range row.Errors {
+			rows[i].Errors = append(rows[i].Errors, ImportRowErrorDTO{
+				Row:     rowErr.Row,
+				Column:  rowErr.Column,
+				Message: rowErr.Message,
+			})
+		}
+	}
+
+	return ImportReportDTO{
+		DryRun:         report.DryRun,
+		TotalRows:      len(report.Rows),
+		Created:        report.Created(),
+		Updated:        report.Updated(),
+		Skipped:        report.Skipped(),
+		Columns:        report.Columns,
+		IgnoredColumns: report.IgnoredColumns,
+		Rows:           rows,
+		CostWarning:    report.CostWarning,
+	}
+}
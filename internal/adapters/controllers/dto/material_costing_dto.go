@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"strings"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// CostingRequest представляет запрос на изменение метода расчета и фиксации себестоимости
+// материала: cost_per_unit задает себестоимость вручную и допускается только с locked=true
+type CostingRequest struct {
+	CostingMethod string   `json:"costing_method" binding:"required"`
+	Locked        bool     `json:"locked"`
+	CostPerUnit   *float64 `json:"cost_per_unit"`
+}
+
+// CostingForm представляет форму себестоимости материала: locked - флажок,
+// cost_per_unit - необязательная себестоимость, заданная вручную
+type CostingForm struct {
+	CostingMethod string `form:"costing_method"`
+	Locked        string `form:"locked"`
+	CostPerUnit   string `form:"cost_per_unit"`
+}
+
+// MaterialCostingDTO представляет себестоимость материала. ActualCost - фактическая
+// себестоимость по журналу, CostPerUnit - используемая в расчете себестоимости продукции
+type MaterialCostingDTO struct {
+	MaterialID    int     `json:"material_id"`
+	Article       string  `json:"article,omitempty"`
+	Name          string  `json:"name,omitempty"`
+	Unit          string  `json:"unit,omitempty"`
+	CostingMethod string  `json:"costing_method"`
+	MethodLabel   string  `json:"method_label"`
+	Locked        bool    `json:"locked"`
+	ActualCost    float64 `json:"actual_cost"`
+	CostPerUnit   float64 `json:"cost_per_unit"`
+	StockQuantity float64 `json:"stock_quantity"`
+	StockValue    float64 `json:"stock_value"`
+}
+
+// Method возвращает метод расчета себестоимости из запроса
+func (r *CostingRequest) Method() entities.CostingMethod {
+	return entities.CostingMethod(strings.TrimSpace(r.CostingMethod))
+}
+
+// Parse разбирает форму в метод расчета, фиксацию и себестоимость, заданную вручную
+func (f *CostingForm) Parse() (entities.CostingMethod, bool, *float64, error) {
+	locked, err := parseQueryBool("locked", f.Locked)
+	if err != nil {
+		return "", false, nil, err
+	}
+	manualCost, err := parseQueryFloat("cost_per_unit", f.CostPerUnit)
+	if err != nil {
+		return "", false, nil, err
+	}
+	return entities.CostingMethod(strings.TrimSpace(f.CostingMethod)), locked, manualCost, nil
+}
+
+// FromMaterialCosting преобразует себестоимость материала в DTO
+func FromMaterialCosting(costing *entities.MaterialCosting) MaterialCostingDTO {
+	result := MaterialCostingDTO{
+		MaterialID:    costing.MaterialID,
+		CostingMethod: string(costing.Method),
+		MethodLabel:   costing.Method.Label(),
+		Locked:        costing.Locked,
+		ActualCost:    costing.ActualCost,
+		CostPerUnit:   costing.CostPerUnit,
+		StockQuantity: costing.StockQuantity,
+		StockValue:    costing.StockValue(),
+	}
+	if material := costing.Material; material != nil {
+		result.Article = material.Article
+		result.Name = material.Name
+		if material.MeasurementUnit != nil {
+			result.Unit = material.MeasurementUnit.Abbreviation
+		}
+	}
+	return result
+}
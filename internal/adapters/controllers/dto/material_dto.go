@@ -1,6 +1,9 @@
 package dto
 
 import (
+	"net/url"
+	"strings"
+
 	"wallpaper-system/internal/domain/entities"
 )
 
@@ -110,3 +113,64 @@ func (dto *UpdateMaterialDTO) ToEntity() *entities.Material {
 		ImagePath:           imagePath,
 	}
 }
+
+// MaterialListQuery представляет параметры фильтрации, сортировки и пагинации списка материалов
+type MaterialListQuery struct {
+	MaterialTypeID  string `form:"material_type_id"`
+	MinCost         string `form:"min_cost"`
+	MaxCost         string `form:"max_cost"`
+	Article         string `form:"article"`
+	BelowMinStock   string `form:"below_min_stock"`
+	IncludeArchived string `form:"include_archived"`
+	Sort            string `form:"sort"`
+	Order           string `form:"order"`
+	Page            string `form:"page"`
+	PageSize        string `form:"page_size"`
+}
+
+// ToCriteria преобразует параметры запроса в критерии выборки материалов
+func (q *MaterialListQuery) ToCriteria() (entities.MaterialCriteria, error) {
+	var criteria entities.MaterialCriteria
+	var err error
+
+	if criteria.MaterialTypeID, err = parseQueryInt("material_type_id", q.MaterialTypeID); err != nil {
+		return criteria, err
+	}
+	if criteria.MinCost, err = parseQueryFloat("min_cost", q.MinCost); err != nil {
+		return criteria, err
+	}
+	if criteria.MaxCost, err = parseQueryFloat("max_cost", q.MaxCost); err != nil {
+		return criteria, err
+	}
+	if criteria.Pagination, err = parsePagination(q.Page, q.PageSize); err != nil {
+		return criteria, err
+	}
+
+	if criteria.BelowMinStock, err = parseQueryBool("below_min_stock", q.BelowMinStock); err != nil {
+		return criteria, err
+	}
+	if criteria.IncludeArchived, err = parseQueryBool("include_archived", q.IncludeArchived); err != nil {
+		return criteria, err
+	}
+
+	criteria.ArticlePrefix = strings.TrimSpace(q.Article)
+	criteria.SortBy = strings.TrimSpace(q.Sort)
+	criteria.SortDirection = entities.SortDirection(strings.ToLower(strings.TrimSpace(q.Order)))
+
+	return criteria, nil
+}
+
+// Values возвращает заданные фильтры без номера страницы для построения ссылок
+func (q *MaterialListQuery) Values() url.Values {
+	values := url.Values{}
+	setIfNotEmpty(values, "material_type_id", q.MaterialTypeID)
+	setIfNotEmpty(values, "min_cost", q.MinCost)
+	setIfNotEmpty(values, "max_cost", q.MaxCost)
+	setIfNotEmpty(values, "article", q.Article)
+	setIfNotEmpty(values, "below_min_stock", q.BelowMinStock)
+	setIfNotEmpty(values, "include_archived", q.IncludeArchived)
+	setIfNotEmpty(values, "sort", q.Sort)
+	setIfNotEmpty(values, "order", q.Order)
+	setIfNotEmpty(values, "page_size", q.PageSize)
+	return values
+}
@@ -0,0 +1,179 @@
+package dto
+
+import (
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// SupplyRequest представляет запрос на прием поставки материала (JSON или форма):
+// quantity - в единице учета материала, supply_date - дата поступления в формате ГГГГ-ММ-ДД
+// (по умолчанию сегодня), batch_number - необязательный номер партии поставщика
+type SupplyRequest struct {
+	SupplierID  int      `json:"supplier_id" form:"supplier_id" binding:"required,gt=0"`
+	Quantity    *float64 `json:"quantity" form:"quantity" binding:"required,gt=0"`
+	UnitPrice   *float64 `json:"unit_price" form:"unit_price" binding:"required,gte=0"`
+	SupplyDate  *string  `json:"supply_date" form:"supply_date"`
+	BatchNumber *string  `json:"batch_number" form:"batch_number"`
+}
+
+// SupplierDTO представляет поставщика материалов
+type SupplierDTO struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	INN  string `json:"inn"`
+}
+
+// MaterialLotDTO представляет партию материала. ConsumedQuantity - количество, взятое из партии,
+// UnitCost - цена единицы партии для расчета себестоимости
+type MaterialLotDTO struct {
+	ID                int          `json:"id"`
+	Label             string       `json:"label"`
+	MaterialID        int          `json:"material_id"`
+	Article           string       `json:"article,omitempty"`
+	Name              string       `json:"name,omitempty"`
+	Unit              string       `json:"unit,omitempty"`
+	SupplyID          *int         `json:"supply_id"`
+	Supplier          *SupplierDTO `json:"supplier"`
+	BatchNumber       *string      `json:"batch_number"`
+	ReceivedAt        string       `json:"received_at"`
+	InitialQuantity   float64      `json:"initial_quantity"`
+	RemainingQuantity float64      `json:"remaining_quantity"`
+	ConsumedQuantity  float64      `json:"consumed_quantity"`
+	UnitCost          float64      `json:"unit_cost"`
+	Exhausted         bool         `json:"exhausted"`
+	MovementID        *int         `json:"movement_id"`
+}
+
+// LotConsumptionDTO представляет количество материала, взятое из партии движением
+type LotConsumptionDTO struct {
+	ID       int                  `json:"id"`
+	Quantity float64              `json:"quantity"`
+	Lot      *MaterialLotDTO      `json:"lot,omitempty"`
+	Movement *MaterialMovementDTO `json:"movement,omitempty"`
+}
+
+// LotProductUsageDTO представляет позицию заказа, в производство которой ушел материал партии
+type LotProductUsageDTO struct {
+	OrderID        int    `json:"order_id"`
+	ProductID      int    `json:"product_id"`
+	ProductArticle string `json:"product_article"`
+	ProductName    string `json:"product_name"`
+	Quantity       int    `json:"quantity"`
+}
+
+// LotTraceDTO представляет прослеживаемость партии до заказов и продукции
+type LotTraceDTO struct {
+	Lot          MaterialLotDTO       `json:"lot"`
+	OrderIDs     []int                `json:"order_ids"`
+	Consumptions []LotConsumptionDTO  `json:"consumptions"`
+	Products     []LotProductUsageDTO `json:"products"`
+}
+
+// ToEntity преобразует запрос в поставку материала и номер партии поставщика.
+// Без даты поставка принимается датой today
+func (r *SupplyRequest) ToEntity(materialID int, today time.Time) (*entities.MaterialSupply, *string, error) {
+	supply := &entities.MaterialSupply{
+		SupplierID: r.SupplierID,
+		MaterialID: materialID,
+		SupplyDate: time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC),
+	}
+	if r.Quantity != nil {
+		supply.Quantity = *r.Quantity
+	}
+	if r.UnitPrice != nil {
+		supply.UnitPrice = *r.UnitPrice
+	}
+
+	date, err := parseOptionalDate("supply_date", trimOptional(r.SupplyDate))
+	if err != nil {
+		return nil, nil, err
+	}
+	if date != nil {
+		supply.SupplyDate = *date
+	}
+
+	return supply, trimOptional(r.BatchNumber), nil
+}
+
+// FromSuppliers преобразует список поставщиков в DTO
+func FromSuppliers(suppliers []entities.Supplier) []SupplierDTO {
+	result := make([]SupplierDTO, len(suppliers))
+	for i, supplier := range suppliers {
+		result[i] = SupplierDTO{ID: supplier.ID, Name: supplier.Name, INN: supplier.INN}
+	}
+	return result
+}
+
+// FromMaterialLot преобразует партию материала в DTO
+func FromMaterialLot(lot *entities.MaterialLot) MaterialLotDTO {
+	item := MaterialLotDTO{
+		ID:                lot.ID,
+		Label:             lot.Label(),
+		MaterialID:        lot.MaterialID,
+		SupplyID:          lot.SupplyID,
+		BatchNumber:       lot.BatchNumber,
+		ReceivedAt:        lot.ReceivedAt.Format(DateLayout),
+		InitialQuantity:   lot.InitialQuantity,
+		RemainingQuantity: lot.RemainingQuantity,
+		ConsumedQuantity:  lot.ConsumedQuantity(),
+		UnitCost:          lot.UnitCost,
+		Exhausted:         lot.IsExhausted(),
+		MovementID:        lot.MovementID,
+	}
+	if supplier := lot.Supplier; supplier != nil {
+		item.Supplier = &SupplierDTO{ID: supplier.ID, Name: supplier.Name, INN: supplier.INN}
+	}
+	if material := lot.Material; material != nil {
+		item.Article = material.Article
+		item.Name = material.Name
+		if material.MeasurementUnit != nil {
+			item.Unit = material.MeasurementUnit.Abbreviation
+		}
+	}
+	return item
+}
+
+// FromMaterialLots преобразует список партий материала в DTO
+func FromMaterialLots(lots []entities.MaterialLot) []MaterialLotDTO {
+	result := make([]MaterialLotDTO, len(lots))
+	for i := range lots {
+		result[i] = FromMaterialLot(&lots[i])
+	}
+	return result
+}
+
+// FromLotConsumptions преобразует расход из партий в DTO
+func FromLotConsumptions(consumptions []entities.LotConsumption) []LotConsumptionDTO {
+	result := make([]LotConsumptionDTO, len(consumptions))
+	for i := range consumptions {
+		consumption := &consumptions[i]
+		result[i] = LotConsumptionDTO{ID: consumption.ID, Quantity: consumption.Quantity}
+		if consumption.Lot != nil {
+			lot := FromMaterialLot(consumption.Lot)
+			result[i].Lot = &lot
+		}
+		if consumption.Movement != nil {
+			movement := FromMaterialMovement(consumption.Movement)
+			result[i].Movement = &movement
+		}
+	}
+	return result
+}
+
+// FromLotTrace преобразует прослеживаемость партии в DTO
+func FromLotTrace(trace *entities.LotTrace) LotTraceDTO {
+	result := LotTraceDTO{
+		Lot:          FromMaterialLot(trace.Lot),
+		OrderIDs:     trace.OrderIDs(),
+		Consumptions: FromLotConsumptions(trace.Consumptions),
+		Products:     make([]LotProductUsageDTO, len(trace.Products)),
+	}
+	if result.OrderIDs == nil {
+		result.OrderIDs = []int{}
+	}
+	for i, usage := range trace.Products {
+		result.Products[i] = LotProductUsageDTO(usage)
+	}
+	return result
+}
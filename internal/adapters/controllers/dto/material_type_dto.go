@@ -0,0 +1,88 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// MaterialTypeDTO представляет тип материала для API; процент брака указывается в процентах (5 = 5%)
+type MaterialTypeDTO struct {
+	ID              int     `json:"id"`
+	Name            string  `json:"name"`
+	Description     *string `json:"description"`
+	WastePercentage float64 `json:"waste_percentage"`
+}
+
+// DefectRateChangeDTO представляет запись истории процента брака типа материала
+type DefectRateChangeDTO struct {
+	ID                 int       `json:"id"`
+	OldWastePercentage *float64  `json:"old_waste_percentage"`
+	NewWastePercentage float64   `json:"new_waste_percentage"`
+	ChangedBy          string    `json:"changed_by"`
+	Comment            *string   `json:"comment"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// MaterialTypeRequest представляет запрос на создание или изменение типа материала (JSON или форма).
+// Автор записывается в историю, если процент брака новый или изменился
+type MaterialTypeRequest struct {
+	Name            string   `json:"name" form:"name" binding:"required"`
+	Description     *string  `json:"description" form:"description"`
+	WastePercentage *float64 `json:"waste_percentage" form:"waste_percentage" binding:"required"`
+	ChangedBy       string   `json:"changed_by" form:"changed_by"`
+	Comment         *string  `json:"comment" form:"comment"`
+}
+
+// ToEntity преобразует DTO в тип материала и сведения об изменении процента брака
+func (dto *MaterialTypeRequest) ToEntity() (*entities.MaterialType, *entities.DefectRateChange) {
+	materialType := &entities.MaterialType{
+		Name:        strings.TrimSpace(dto.Name),
+		Description: trimOptional(dto.Description),
+	}
+	if dto.WastePercentage != nil {
+		materialType.WastePercentage = *dto.WastePercentage
+	}
+
+	change := &entities.DefectRateChange{
+		ChangedBy: strings.TrimSpace(dto.ChangedBy),
+		Comment:   trimOptional(dto.Comment),
+	}
+	return materialType, change
+}
+
+// FromMaterialTypeEntity преобразует тип материала в DTO
+func FromMaterialTypeEntity(materialType *entities.MaterialType) MaterialTypeDTO {
+	return MaterialTypeDTO{
+		ID:              materialType.ID,
+		Name:            materialType.Name,
+		Description:     materialType.Description,
+		WastePercentage: materialType.WastePercentage,
+	}
+}
+
+// FromMaterialTypeEntities преобразует список типов материалов в DTO
+func FromMaterialTypeEntities(materialTypes []entities.MaterialType) []MaterialTypeDTO {
+	result := make([]MaterialTypeDTO, len(materialTypes))
+	for i := range materialTypes {
+		result[i] = FromMaterialTypeEntity(&materialTypes[i])
+	}
+	return result
+}
+
+// FromDefectRateChanges преобразует историю процента брака в DTO
+func FromDefectRateChanges(history []entities.DefectRateChange) []DefectRateChangeDTO {
+	result := make([]DefectRateChangeDTO, len(history))
+	for i, change := range history {
+		result[i] = DefectRateChangeDTO{
+			ID:                 change.ID,
+			OldWastePercentage: change.OldWastePercentage,
+			NewWastePercentage: change.NewWastePercentage,
+			ChangedBy:          change.ChangedBy,
+			Comment:            change.Comment,
+			CreatedAt:          change.CreatedAt,
+		}
+	}
+	return result
+}
@@ -0,0 +1,117 @@
+package dto
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// MaterialMovementRequest представляет запрос на проведение движения материала (JSON или форма):
+// movement_type - income, consumption или write_off; quantity - в единице учета материала.
+// Пустой или нулевой reference_id в форме означает движение без документа
+type MaterialMovementRequest struct {
+	MovementType  string   `json:"movement_type" form:"movement_type" binding:"required"`
+	Quantity      *float64 `json:"quantity" form:"quantity" binding:"required,gt=0"`
+	ReferenceType string   `json:"reference_type" form:"reference_type"`
+	ReferenceID   *int     `json:"reference_id" form:"reference_id"`
+	Note          *string  `json:"note" form:"note"`
+}
+
+// MovementListQuery представляет фильтр и страницу журнала движений материала
+type MovementListQuery struct {
+	MovementType string `form:"movement_type"`
+	Page         string `form:"page"`
+	PageSize     string `form:"page_size"`
+}
+
+// MaterialMovementDTO представляет движение материала. Delta - изменение остатка со знаком,
+// UnitCost и TotalCost - себестоимость движения (null, если не рассчитана)
+type MaterialMovementDTO struct {
+	ID                 int       `json:"id"`
+	MaterialID         int       `json:"material_id"`
+	MovementType       string    `json:"movement_type"`
+	MovementTypeLabel  string    `json:"movement_type_label"`
+	Quantity           float64   `json:"quantity"`
+	Delta              float64   `json:"delta"`
+	RemainingQuantity  float64   `json:"remaining_quantity"`
+	ReferenceType      string    `json:"reference_type,omitempty"`
+	ReferenceTypeLabel string    `json:"reference_type_label,omitempty"`
+	ReferenceID        *int      `json:"reference_id"`
+	Note               *string   `json:"note"`
+	UnitCost           *float64  `json:"unit_cost"`
+	TotalCost          *float64  `json:"total_cost"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// ToEntity преобразует DTO в движение материала
+func (dto *MaterialMovementRequest) ToEntity(materialID int) *entities.MaterialMovement {
+	movement := &entities.MaterialMovement{
+		MaterialID:    materialID,
+		Type:          entities.MovementType(strings.TrimSpace(dto.MovementType)),
+		ReferenceType: entities.MovementReference(strings.TrimSpace(dto.ReferenceType)),
+		Note:          trimOptional(dto.Note),
+	}
+	if dto.Quantity != nil {
+		movement.Quantity = *dto.Quantity
+	}
+	if dto.ReferenceID != nil && *dto.ReferenceID > 0 {
+		movement.ReferenceID = dto.ReferenceID
+	}
+	return movement
+}
+
+// ToCriteria преобразует параметры запроса в критерии выборки движений материала
+func (q *MovementListQuery) ToCriteria(materialID int) (entities.MovementCriteria, error) {
+	criteria := entities.MovementCriteria{
+		MaterialID: materialID,
+		Type:       entities.MovementType(strings.TrimSpace(q.MovementType)),
+	}
+
+	var err error
+	if criteria.Pagination, err = parsePagination(q.Page, q.PageSize); err != nil {
+		return criteria, err
+	}
+	return criteria, nil
+}
+
+// Values возвращает заданные фильтры без номера страницы для построения ссылок
+func (q *MovementListQuery) Values() url.Values {
+	values := url.Values{}
+	setIfNotEmpty(values, "movement_type", q.MovementType)
+	setIfNotEmpty(values, "page_size", q.PageSize)
+	return values
+}
+
+// FromMaterialMovement преобразует движение материала в DTO
+func FromMaterialMovement(movement *entities.MaterialMovement) MaterialMovementDTO {
+	item := MaterialMovementDTO{
+		ID:                movement.ID,
+		MaterialID:        movement.MaterialID,
+		MovementType:      string(movement.Type),
+		MovementTypeLabel: movement.Type.Label(),
+		Quantity:          movement.Quantity,
+		Delta:             movement.Delta(),
+		RemainingQuantity: movement.RemainingQuantity,
+		ReferenceID:       movement.ReferenceID,
+		Note:              movement.Note,
+		UnitCost:          movement.UnitCost,
+		TotalCost:         movement.TotalCost,
+		CreatedAt:         movement.CreatedAt,
+	}
+	if movement.ReferenceType != "" {
+		item.ReferenceType = string(movement.ReferenceType)
+		item.ReferenceTypeLabel = movement.ReferenceType.Label()
+	}
+	return item
+}
+
+// FromMaterialMovements преобразует список движений материала в DTO
+func FromMaterialMovements(movements []entities.MaterialMovement) []MaterialMovementDTO {
+	result := make([]MaterialMovementDTO, len(movements))
+	for i := range movements {
+		result[i] = FromMaterialMovement(&movements[i])
+	}
+	return result
+}
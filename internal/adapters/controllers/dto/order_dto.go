@@ -0,0 +1,106 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// OrderStatusRequest представляет запрос на смену статуса заказа: confirmed, prepaid,
+// in_production, ready, completed или cancelled
+type OrderStatusRequest struct {
+	Status string `json:"status" binding:"required"`
+}
+
+// OrderDTO представляет заказ партнера
+type OrderDTO struct {
+	ID               int       `json:"id"`
+	PartnerID        int       `json:"partner_id"`
+	ManagerID        *int      `json:"manager_id"`
+	Status           string    `json:"status"`
+	StatusLabel      string    `json:"status_label"`
+	TotalAmount      float64   `json:"total_amount"`
+	PrepaymentAmount float64   `json:"prepayment_amount"`
+	DeliveryRequired bool      `json:"delivery_required"`
+	DeliveryAddress  *string   `json:"delivery_address"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// OrderStatusResultDTO представляет заказ после смены статуса вместе с предупреждениями
+// о продаже в нем продукции без действующего сертификата
+type OrderStatusResultDTO struct {
+	OrderDTO
+	CertificateWarnings []CertificateWarningDTO `json:"certificate_warnings"`
+}
+
+// MaterialReservationDTO представляет резерв материала под заказ
+type MaterialReservationDTO struct {
+	ID          int        `json:"id"`
+	OrderID     int        `json:"order_id"`
+	MaterialID  int        `json:"material_id"`
+	Article     string     `json:"article"`
+	Name        string     `json:"name"`
+	Unit        string     `json:"unit,omitempty"`
+	Quantity    float64    `json:"quantity"`
+	Status      string     `json:"status"`
+	StatusLabel string     `json:"status_label"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ClosedAt    *time.Time `json:"closed_at"`
+}
+
+// ToStatus преобразует запрос в статус заказа
+func (r *OrderStatusRequest) ToStatus() entities.OrderStatus {
+	return entities.OrderStatus(strings.TrimSpace(r.Status))
+}
+
+// FromOrder преобразует заказ в DTO
+func FromOrder(order *entities.Order) OrderDTO {
+	return OrderDTO{
+		ID:               order.ID,
+		PartnerID:        order.PartnerID,
+		ManagerID:        order.ManagerID,
+		Status:           string(order.Status),
+		StatusLabel:      order.Status.Label(),
+		TotalAmount:      order.TotalAmount,
+		PrepaymentAmount: order.PrepaymentAmount,
+		DeliveryRequired: order.DeliveryRequired,
+		DeliveryAddress:  order.DeliveryAddress,
+		CreatedAt:        order.CreatedAt,
+		UpdatedAt:        order.UpdatedAt,
+	}
+}
+
+// FromOrderStatusResult преобразует заказ после смены статуса и предупреждения о сертификации в DTO
+func FromOrderStatusResult(order *entities.Order, warnings []entities.CertificateWarning) OrderStatusResultDTO {
+	return OrderStatusResultDTO{
+		OrderDTO:            FromOrder(order),
+		CertificateWarnings: FromCertificateWarnings(warnings),
+	}
+}
+
+// FromMaterialReservations преобразует резервы материалов в DTO
+func FromMaterialReservations(reservations []entities.MaterialReservation) []MaterialReservationDTO {
+	result := make([]MaterialReservationDTO, len(reservations))
+	for i, reservation := range reservations {
+		result[i] = MaterialReservationDTO{
+			ID:          reservation.ID,
+			OrderID:     reservation.OrderID,
+			MaterialID:  reservation.MaterialID,
+			Quantity:    reservation.Quantity,
+			Status:      string(reservation.Status),
+			StatusLabel: reservation.Status.Label(),
+			CreatedAt:   reservation.CreatedAt,
+			ClosedAt:    reservation.ClosedAt,
+		}
+		if material := reservation.Material; material != nil {
+			result[i].Article = material.Article
+			result[i].Name = material.Name
+			if material.MeasurementUnit != nil {
+				result[i].Unit = material.MeasurementUnit.Abbreviation
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,211 @@
+package dto
+
+import (
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// DateLayout - формат дат в запросах и ответах API
+const DateLayout = "2006-01-02"
+
+// PricingRuleDTO представляет правило ценообразования для API
+type PricingRuleDTO struct {
+	ID              int     `json:"id"`
+	Name            string  `json:"name"`
+	ProductTypeID   *int    `json:"product_type_id"`
+	ProductTypeName string  `json:"product_type_name,omitempty"`
+	PartnerTypeID   *int    `json:"partner_type_id"`
+	PartnerTypeName string  `json:"partner_type_name,omitempty"`
+	ValidFrom       *string `json:"valid_from"`
+	ValidTo         *string `json:"valid_to"`
+	MarkupPercent   float64 `json:"markup_percent"`
+	FixedSurcharge  float64 `json:"fixed_surcharge"`
+	RoundingMode    string  `json:"rounding_mode"`
+	RoundingStep    float64 `json:"rounding_step"`
+	Priority        int     `json:"priority"`
+	IsActive        bool    `json:"is_active"`
+}
+
+// AppliedPricingRuleDTO представляет правило, по которому рассчитана цена
+type AppliedPricingRuleDTO struct {
+	ID             int     `json:"id"`
+	Name           string  `json:"name"`
+	MarkupPercent  float64 `json:"markup_percent"`
+	FixedSurcharge float64 `json:"fixed_surcharge"`
+	RoundingMode   string  `json:"rounding_mode"`
+	RoundingStep   float64 `json:"rounding_step"`
+}
+
+// PriceCalculationDTO представляет результат расчета цены продукции
+type PriceCalculationDTO struct {
+	ProductID     int                    `json:"product_id"`
+	PartnerTypeID *int                   `json:"partner_type_id"`
+	Date          string                 `json:"date"`
+	Cost          float64                `json:"cost"`
+	Price         float64                `json:"price"`
+	PricingRule   *AppliedPricingRuleDTO `json:"pricing_rule"`
+}
+
+// PartnerTypeDTO представляет тип партнера
+type PartnerTypeDTO struct {
+	ID          int     `json:"id"`
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+}
+
+// PricingRuleRequest представляет запрос на создание или изменение правила ценообразования
+type PricingRuleRequest struct {
+	Name           string  `json:"name" binding:"required"`
+	ProductTypeID  *int    `json:"product_type_id" binding:"omitempty,gt=0"`
+	PartnerTypeID  *int    `json:"partner_type_id" binding:"omitempty,gt=0"`
+	ValidFrom      *string `json:"valid_from"`
+	ValidTo        *string `json:"valid_to"`
+	MarkupPercent  float64 `json:"markup_percent" binding:"gt=-100"`
+	FixedSurcharge float64 `json:"fixed_surcharge" binding:"min=0"`
+	RoundingMode   string  `json:"rounding_mode" binding:"omitempty,oneof=nearest up down"`
+	RoundingStep   float64 `json:"rounding_step" binding:"omitempty,gt=0"`
+	Priority       int     `json:"priority"`
+	IsActive       *bool   `json:"is_active"`
+}
+
+// ToEntity преобразует DTO в доменную сущность
+func (dto *PricingRuleRequest) ToEntity() (*entities.PricingRule, error) {
+	validFrom, err := parseOptionalDate("valid_from", dto.ValidFrom)
+	if err != nil {
+		return nil, err
+	}
+	validTo, err := parseOptionalDate("valid_to", dto.ValidTo)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &entities.PricingRule{
+		Name:           dto.Name,
+		ProductTypeID:  dto.ProductTypeID,
+		PartnerTypeID:  dto.PartnerTypeID,
+		ValidFrom:      validFrom,
+		ValidTo:        validTo,
+		MarkupPercent:  dto.MarkupPercent,
+		FixedSurcharge: dto.FixedSurcharge,
+		RoundingMode:   entities.RoundingMode(dto.RoundingMode),
+		RoundingStep:   dto.RoundingStep,
+		Priority:       dto.Priority,
+		IsActive:       true,
+	}
+
+	// Значения по умолчанию: округление до копеек, правило активно
+	if rule.RoundingMode == "" {
+		rule.RoundingMode = entities.RoundingNearest
+	}
+	if rule.RoundingStep == 0 {
+		rule.RoundingStep = 0.01
+	}
+	if dto.IsActive != nil {
+		rule.IsActive = *dto.IsActive
+	}
+
+	return rule, nil
+}
+
+// FromPricingRuleEntity преобразует доменную сущность в DTO
+func FromPricingRuleEntity(rule *entities.PricingRule) PricingRuleDTO {
+	dto := PricingRuleDTO{
+		ID:             rule.ID,
+		Name:           rule.Name,
+		ProductTypeID:  rule.ProductTypeID,
+		PartnerTypeID:  rule.PartnerTypeID,
+		ValidFrom:      formatOptionalDate(rule.ValidFrom),
+		ValidTo:        formatOptionalDate(rule.ValidTo),
+		MarkupPercent:  rule.MarkupPercent,
+		FixedSurcharge: rule.FixedSurcharge,
+		RoundingMode:   string(rule.RoundingMode),
+		RoundingStep:   rule.RoundingStep,
+		Priority:       rule.Priority,
+		IsActive:       rule.IsActive,
+	}
+
+	if rule.ProductType != nil {
+		dto.ProductTypeName = rule.ProductType.Name
+	}
+	if rule.PartnerType != nil {
+		dto.PartnerTypeName = rule.PartnerType.Name
+	}
+
+	return dto
+}
+
+// FromPricingRuleEntities преобразует список правил в DTO
+func FromPricingRuleEntities(rules []entities.PricingRule) []PricingRuleDTO {
+	result := make([]PricingRuleDTO, len(rules))
+	for i := range rules {
+		result[i] = FromPricingRuleEntity(&rules[i])
+	}
+	return result
+}
+
+// FromAppliedPricingRule преобразует примененное правило в DTO
+func FromAppliedPricingRule(rule *entities.PricingRule) *AppliedPricingRuleDTO {
+	return &AppliedPricingRuleDTO{
+		ID:             rule.ID,
+		Name:           rule.Name,
+		MarkupPercent:  rule.MarkupPercent,
+		FixedSurcharge: rule.FixedSurcharge,
+		RoundingMode:   string(rule.RoundingMode),
+		RoundingStep:   rule.RoundingStep,
+	}
+}
+
+// FromPriceCalculation преобразует результат расчета цены в DTO
+func FromPriceCalculation(productID int, partnerTypeID *int, date time.Time, calculation *entities.PriceCalculation) PriceCalculationDTO {
+	dto := PriceCalculationDTO{
+		ProductID:     productID,
+		PartnerTypeID: partnerTypeID,
+		Date:          date.Format(DateLayout),
+		Cost:          calculation.Cost,
+		Price:         calculation.Price,
+	}
+
+	if calculation.Rule != nil {
+		dto.PricingRule = FromAppliedPricingRule(calculation.Rule)
+	}
+
+	return dto
+}
+
+// FromPartnerTypeEntities преобразует типы партнеров в DTO
+func FromPartnerTypeEntities(partnerTypes []entities.PartnerType) []PartnerTypeDTO {
+	result := make([]PartnerTypeDTO, len(partnerTypes))
+	for i, pt := range partnerTypes {
+		result[i] = PartnerTypeDTO{
+			ID:          pt.ID,
+			Name:        pt.Name,
+			Description: pt.Description,
+		}
+	}
+	return result
+}
+
+// parseOptionalDate разбирает необязательную дату в формате ГГГГ-ММ-ДД
+func parseOptionalDate(field string, value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse(DateLayout, *value)
+	if err != nil {
+		return nil, entities.NewValidationError(field, "дата должна быть в формате ГГГГ-ММ-ДД")
+	}
+
+	return &date, nil
+}
+
+// formatOptionalDate форматирует необязательную дату в формате ГГГГ-ММ-ДД
+func formatOptionalDate(value *time.Time) *string {
+	if value == nil {
+		return nil
+	}
+
+	formatted := value.Format(DateLayout)
+	return &formatted
+}
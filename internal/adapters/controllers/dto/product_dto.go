@@ -1,37 +1,54 @@
 package dto
 
 import (
+	"net/url"
+	"strings"
+	"time"
+
 	"wallpaper-system/internal/domain/entities"
 )
 
 // ProductListItemDTO представляет элемент списка продукции для API
 type ProductListItemDTO struct {
-	ID              int      `json:"id"`
-	Article         string   `json:"article"`
-	TypeName        string   `json:"type_name"`
-	Name            string   `json:"name"`
-	MinPartnerPrice float64  `json:"min_partner_price"`
-	RollWidth       *float64 `json:"roll_width"`
-	CalculatedPrice *float64 `json:"calculated_price"`
+	ID              int                    `json:"id"`
+	Article         string                 `json:"article"`
+	TypeName        string                 `json:"type_name"`
+	Name            string                 `json:"name"`
+	MinPartnerPrice float64                `json:"min_partner_price"`
+	RollWidth       *float64               `json:"roll_width"`
+	ThumbnailPath   *string                `json:"thumbnail_path"`
+	CalculatedPrice *float64               `json:"calculated_price"`
+	PricingRule     *AppliedPricingRuleDTO `json:"pricing_rule,omitempty"`
+	ArchivedAt      *time.Time             `json:"archived_at,omitempty"`
+	// Variants - варианты (расцветки) при группировке списка по базовому артикулу
+	Variants []VariantSummaryDTO `json:"variants,omitempty"`
 }
 
 // ProductDetailDTO представляет детальную информацию о продукции
 type ProductDetailDTO struct {
 	ProductListItemDTO
-	Description            *string              `json:"description"`
-	ImagePath              *string              `json:"image_path"`
-	PackageLength          *float64             `json:"package_length"`
-	PackageWidth           *float64             `json:"package_width"`
-	PackageHeight          *float64             `json:"package_height"`
-	WeightWithoutPackage   *float64             `json:"weight_without_package"`
-	WeightWithPackage      *float64             `json:"weight_with_package"`
-	QualityCertificatePath *string              `json:"quality_certificate_path"`
-	StandardNumber         *string              `json:"standard_number"`
-	ProductionTimeHours    *float64             `json:"production_time_hours"`
-	CostPrice              *float64             `json:"cost_price"`
-	WorkshopNumber         *string              `json:"workshop_number"`
-	RequiredWorkers        *int                 `json:"required_workers"`
-	Materials              []ProductMaterialDTO `json:"materials"`
+	ProductTypeID          int                   `json:"product_type_id"`
+	Description            *string               `json:"description"`
+	ImagePath              *string               `json:"image_path"`
+	PreviewPath            *string               `json:"preview_path"`
+	RollLength             *float64              `json:"roll_length"`
+	PatternRepeat          *float64              `json:"pattern_repeat"`
+	PackageLength          *float64              `json:"package_length"`
+	PackageWidth           *float64              `json:"package_width"`
+	PackageHeight          *float64              `json:"package_height"`
+	WeightWithoutPackage   *float64              `json:"weight_without_package"`
+	WeightWithPackage      *float64              `json:"weight_with_package"`
+	QualityCertificatePath *string               `json:"quality_certificate_path"`
+	StandardNumber         *string               `json:"standard_number"`
+	ProductionTimeHours    *float64              `json:"production_time_hours"`
+	CostPrice              *float64              `json:"cost_price"`
+	CalculatedCost         *float64              `json:"calculated_cost"`
+	CostCalculatedAt       *time.Time            `json:"cost_calculated_at"`
+	EffectiveCost          *float64              `json:"effective_cost"`
+	WorkshopNumber         *string               `json:"workshop_number"`
+	RequiredWorkers        *int                  `json:"required_workers"`
+	Materials              []ProductMaterialDTO  `json:"materials"`
+	Components             []ProductComponentDTO `json:"components"`
 }
 
 // ProductMaterialDTO представляет материал в контексте продукции
@@ -45,7 +62,31 @@ type ProductMaterialDTO struct {
 	UnitAbbreviation string  `json:"unit_abbreviation"`
 }
 
-// CreateProductRequest представляет запрос на создание продукции
+// ProductComponentDTO представляет полуфабрикат в контексте продукции
+type ProductComponentDTO struct {
+	ID              int     `json:"id"`
+	Article         string  `json:"article"`
+	Name            string  `json:"name"`
+	TypeName        string  `json:"type_name"`
+	QuantityPerUnit float64 `json:"quantity_per_unit"`
+	UnitCost        float64 `json:"unit_cost"`
+	TotalCost       float64 `json:"total_cost"`
+}
+
+// MaterialRequirementDTO представляет потребность в сырье после разузлования рецептуры
+type MaterialRequirementDTO struct {
+	MaterialID       int     `json:"material_id"`
+	Article          string  `json:"article"`
+	Name             string  `json:"name"`
+	Quantity         float64 `json:"quantity"`
+	UnitAbbreviation string  `json:"unit_abbreviation"`
+	CostPerUnit      float64 `json:"cost_per_unit"`
+	TotalCost        float64 `json:"total_cost"`
+}
+
+// CreateProductRequest представляет запрос на создание продукции. Размеры упаковки
+// задаются в метрах, вес - в килограммах; нулевые значения означают «не указано»,
+// так как пустое поле веб-формы приходит как ноль
 type CreateProductRequest struct {
 	Article                string   `form:"article" json:"article" binding:"required"`
 	ProductTypeID          int      `form:"product_type_id" json:"product_type_id" binding:"required"`
@@ -53,12 +94,19 @@ type CreateProductRequest struct {
 	Description            string   `form:"description" json:"description"`
 	MinPartnerPrice        float64  `form:"min_partner_price" json:"min_partner_price" binding:"required,min=0"`
 	RollWidth              *float64 `form:"roll_width" json:"roll_width" binding:"omitempty,min=0"`
+	RollLength             *float64 `form:"roll_length" json:"roll_length" binding:"omitempty,min=0"`
+	PatternRepeat          *float64 `form:"pattern_repeat" json:"pattern_repeat" binding:"omitempty,min=0"`
 	PackageLength          *float64 `form:"package_length" json:"package_length" binding:"omitempty,min=0"`
 	PackageWidth           *float64 `form:"package_width" json:"package_width" binding:"omitempty,min=0"`
 	PackageHeight          *float64 `form:"package_height" json:"package_height" binding:"omitempty,min=0"`
+	WeightWithoutPackage   *float64 `form:"weight_without_package" json:"weight_without_package" binding:"omitempty,min=0"`
+	WeightWithPackage      *float64 `form:"weight_with_package" json:"weight_with_package" binding:"omitempty,min=0"`
+	QualityCertificatePath string   `form:"quality_certificate_path" json:"quality_certificate_path"`
+	StandardNumber         string   `form:"standard_number" json:"standard_number"`
 	ProductionTimeHours    *float64 `form:"production_time_hours" json:"production_time_hours" binding:"omitempty,min=0"`
 	CostPrice              *float64 `form:"cost_price" json:"cost_price" binding:"omitempty,min=0"`
 	WorkshopNumber         string   `form:"workshop_number" json:"workshop_number"`
+	RequiredWorkers        *int     `form:"required_workers" json:"required_workers" binding:"omitempty,min=0"`
 }
 
 // UpdateProductRequest представляет запрос на обновление продукции
@@ -69,12 +117,19 @@ type UpdateProductRequest struct {
 	Description            string   `form:"description" json:"description"`
 	MinPartnerPrice        float64  `form:"min_partner_price" json:"min_partner_price" binding:"required,min=0"`
 	RollWidth              *float64 `form:"roll_width" json:"roll_width" binding:"omitempty,min=0"`
+	RollLength             *float64 `form:"roll_length" json:"roll_length" binding:"omitempty,min=0"`
+	PatternRepeat          *float64 `form:"pattern_repeat" json:"pattern_repeat" binding:"omitempty,min=0"`
 	PackageLength          *float64 `form:"package_length" json:"package_length" binding:"omitempty,min=0"`
 	PackageWidth           *float64 `form:"package_width" json:"package_width" binding:"omitempty,min=0"`
 	PackageHeight          *float64 `form:"package_height" json:"package_height" binding:"omitempty,min=0"`
+	WeightWithoutPackage   *float64 `form:"weight_without_package" json:"weight_without_package" binding:"omitempty,min=0"`
+	WeightWithPackage      *float64 `form:"weight_with_package" json:"weight_with_package" binding:"omitempty,min=0"`
+	QualityCertificatePath string   `form:"quality_certificate_path" json:"quality_certificate_path"`
+	StandardNumber         string   `form:"standard_number" json:"standard_number"`
 	ProductionTimeHours    *float64 `form:"production_time_hours" json:"production_time_hours" binding:"omitempty,min=0"`
 	CostPrice              *float64 `form:"cost_price" json:"cost_price" binding:"omitempty,min=0"`
 	WorkshopNumber         string   `form:"workshop_number" json:"workshop_number"`
+	RequiredWorkers        *int     `form:"required_workers" json:"required_workers" binding:"omitempty,min=0"`
 }
 
 // FromProductEntity преобразует доменную сущность в DTO для списка
@@ -85,13 +140,23 @@ func FromProductEntity(product *entities.Product) ProductListItemDTO {
 		Name:            product.Name,
 		MinPartnerPrice: product.MinPartnerPrice,
 		RollWidth:       product.RollWidth,
+		ThumbnailPath:   product.Images().ThumbnailOrImage(),
 		CalculatedPrice: product.CalculatedPrice,
+		ArchivedAt:      product.ArchivedAt,
 	}
 
 	if product.ProductType != nil {
 		dto.TypeName = product.ProductType.Name
 	}
 
+	if product.AppliedPricingRule != nil {
+		dto.PricingRule = FromAppliedPricingRule(product.AppliedPricingRule)
+	}
+
+	for i := range product.Variants {
+		dto.Variants = append(dto.Variants, FromVariantSummary(product, &product.Variants[i]))
+	}
+
 	return dto
 }
 
@@ -99,8 +164,12 @@ func FromProductEntity(product *entities.Product) ProductListItemDTO {
 func FromProductEntityWithMaterials(product *entities.Product, materials []entities.Material) ProductDetailDTO {
 	dto := ProductDetailDTO{
 		ProductListItemDTO:     FromProductEntity(product),
+		ProductTypeID:          product.ProductTypeID,
 		Description:            product.Description,
 		ImagePath:              product.ImagePath,
+		PreviewPath:            product.Images().PreviewOrImage(),
+		RollLength:             product.RollLength,
+		PatternRepeat:          product.PatternRepeat,
 		PackageLength:          product.PackageLength,
 		PackageWidth:           product.PackageWidth,
 		PackageHeight:          product.PackageHeight,
@@ -110,14 +179,138 @@ func FromProductEntityWithMaterials(product *entities.Product, materials []entit
 		StandardNumber:         product.StandardNumber,
 		ProductionTimeHours:    product.ProductionTimeHours,
 		CostPrice:              product.CostPrice,
+		CalculatedCost:         product.CalculatedCost,
+		CostCalculatedAt:       product.CostCalculatedAt,
+		EffectiveCost:          product.EffectiveCost(),
 		WorkshopNumber:         product.WorkshopNumber,
 		RequiredWorkers:        product.RequiredWorkers,
 	}
 
-	// Преобразуем материалы
-	dto.Materials = make([]ProductMaterialDTO, len(product.Materials))
-	for i, pm := range product.Materials {
-		dto.Materials[i] = ProductMaterialDTO{
+	// Преобразуем материалы и полуфабрикаты
+	dto.Materials = FromProductMaterialEntities(product.Materials)
+	dto.Components = FromProductComponentEntities(product.Components)
+
+	return dto
+}
+
+// ToEntity преобразует DTO в доменную сущность
+func (dto *CreateProductRequest) ToEntity() *entities.Product {
+	return &entities.Product{
+		Article:                dto.Article,
+		ProductTypeID:          dto.ProductTypeID,
+		Name:                   dto.Name,
+		Description:            optionalText(dto.Description),
+		MinPartnerPrice:        dto.MinPartnerPrice,
+		RollWidth:              dto.RollWidth,
+		RollLength:             optionalMeasure(dto.RollLength),
+		PatternRepeat:          optionalMeasure(dto.PatternRepeat),
+		PackageLength:          optionalMeasure(dto.PackageLength),
+		PackageWidth:           optionalMeasure(dto.PackageWidth),
+		PackageHeight:          optionalMeasure(dto.PackageHeight),
+		WeightWithoutPackage:   optionalMeasure(dto.WeightWithoutPackage),
+		WeightWithPackage:      optionalMeasure(dto.WeightWithPackage),
+		QualityCertificatePath: optionalText(dto.QualityCertificatePath),
+		StandardNumber:         optionalText(dto.StandardNumber),
+		ProductionTimeHours:    optionalMeasure(dto.ProductionTimeHours),
+		CostPrice:              optionalMeasure(dto.CostPrice),
+		WorkshopNumber:         optionalText(dto.WorkshopNumber),
+		RequiredWorkers:        optionalCount(dto.RequiredWorkers),
+	}
+}
+
+// ToEntity преобразует DTO в доменную сущность
+func (dto *UpdateProductRequest) ToEntity(id int) *entities.Product {
+	product := (*CreateProductRequest)(dto).ToEntity()
+	product.ID = id
+	return product
+}
+
+// optionalText обрезает пробелы и заменяет пустую строку на nil
+func optionalText(value string) *string {
+	return trimOptional(&value)
+}
+
+// optionalMeasure заменяет нулевое значение необязательной величины на nil
+func optionalMeasure(value *float64) *float64 {
+	if value == nil || *value == 0 {
+		return nil
+	}
+	return value
+}
+
+// optionalCount заменяет нулевое значение необязательного количества на nil
+func optionalCount(value *int) *int {
+	if value == nil || *value == 0 {
+		return nil
+	}
+	return value
+}
+
+// CloneProductRequest представляет запрос на копирование продукции вместе с рецептурой
+type CloneProductRequest struct {
+	Article               string  `form:"article" json:"article" binding:"required"`
+	Name                  string  `form:"name" json:"name"`
+	QuantityChangePercent float64 `form:"quantity_change_percent" json:"quantity_change_percent"`
+}
+
+// ToEntity преобразует DTO в параметры копирования
+func (dto *CloneProductRequest) ToEntity() entities.ProductCloneRequest {
+	return entities.ProductCloneRequest{
+		Article:               strings.TrimSpace(dto.Article),
+		Name:                  strings.TrimSpace(dto.Name),
+		QuantityChangePercent: dto.QuantityChangePercent,
+	}
+}
+
+// ProductMaterialRequest представляет строку рецептуры в запросе
+type ProductMaterialRequest struct {
+	MaterialID      int     `form:"material_id" json:"material_id" binding:"required"`
+	QuantityPerUnit float64 `form:"quantity_per_unit" json:"quantity_per_unit" binding:"required,gt=0"`
+}
+
+// UpdateProductMaterialRequest представляет запрос на изменение расхода материала
+type UpdateProductMaterialRequest struct {
+	QuantityPerUnit float64 `form:"quantity_per_unit" json:"quantity_per_unit" binding:"required,gt=0"`
+}
+
+// ReplaceProductMaterialsRequest представляет запрос на замену всей рецептуры продукции
+type ReplaceProductMaterialsRequest struct {
+	Materials []ProductMaterialRequest `json:"materials" binding:"required,dive"`
+}
+
+// ToEntity преобразует DTO в доменную сущность
+func (dto *ProductMaterialRequest) ToEntity() *entities.ProductMaterial {
+	return &entities.ProductMaterial{
+		MaterialID:      dto.MaterialID,
+		QuantityPerUnit: dto.QuantityPerUnit,
+	}
+}
+
+// ToEntity преобразует DTO в доменную сущность
+func (dto *UpdateProductMaterialRequest) ToEntity(materialID int) *entities.ProductMaterial {
+	return &entities.ProductMaterial{
+		MaterialID:      materialID,
+		QuantityPerUnit: dto.QuantityPerUnit,
+	}
+}
+
+// ToEntities преобразует DTO в набор строк рецептуры
+func (dto *ReplaceProductMaterialsRequest) ToEntities() []entities.ProductMaterial {
+	materials := make([]entities.ProductMaterial, len(dto.Materials))
+	for i, item := range dto.Materials {
+		materials[i] = *item.ToEntity()
+	}
+	return materials
+}
+
+// FromProductMaterialEntities преобразует строки рецептуры в DTO
+func FromProductMaterialEntities(productMaterials []entities.ProductMaterial) []ProductMaterialDTO {
+	result := make([]ProductMaterialDTO, 0, len(productMaterials))
+	for _, pm := range productMaterials {
+		if pm.Material == nil {
+			continue
+		}
+		item := ProductMaterialDTO{
 			ID:              pm.Material.ID,
 			Name:            pm.Material.Name,
 			Article:         pm.Material.Article,
@@ -126,68 +319,141 @@ func FromProductEntityWithMaterials(product *entities.Product, materials []entit
 			TotalCost:       pm.QuantityPerUnit * pm.Material.CostPerUnit,
 		}
 		if pm.Material.MeasurementUnit != nil {
-			dto.Materials[i].UnitAbbreviation = pm.Material.MeasurementUnit.Abbreviation
+			item.UnitAbbreviation = pm.Material.MeasurementUnit.Abbreviation
 		}
+		result = append(result, item)
 	}
+	return result
+}
 
-	return dto
+// ProductComponentRequest представляет строку полуфабриката в запросе
+type ProductComponentRequest struct {
+	ComponentProductID int     `form:"component_product_id" json:"component_product_id" binding:"required"`
+	QuantityPerUnit    float64 `form:"quantity_per_unit" json:"quantity_per_unit" binding:"required,gt=0"`
+}
+
+// UpdateProductComponentRequest представляет запрос на изменение расхода полуфабриката
+type UpdateProductComponentRequest struct {
+	QuantityPerUnit float64 `form:"quantity_per_unit" json:"quantity_per_unit" binding:"required,gt=0"`
 }
 
 // ToEntity преобразует DTO в доменную сущность
-func (dto *CreateProductRequest) ToEntity() *entities.Product {
-	var description *string
-	if dto.Description != "" {
-		description = &dto.Description
+func (dto *ProductComponentRequest) ToEntity() *entities.ProductComponent {
+	return &entities.ProductComponent{
+		ComponentProductID: dto.ComponentProductID,
+		QuantityPerUnit:    dto.QuantityPerUnit,
+	}
+}
+
+// ToEntity преобразует DTO в доменную сущность
+func (dto *UpdateProductComponentRequest) ToEntity(componentProductID int) *entities.ProductComponent {
+	return &entities.ProductComponent{
+		ComponentProductID: componentProductID,
+		QuantityPerUnit:    dto.QuantityPerUnit,
 	}
-	
-	var workshopNumber *string
-	if dto.WorkshopNumber != "" {
-		workshopNumber = &dto.WorkshopNumber
+}
+
+// FromProductComponentEntities преобразует строки полуфабрикатов в DTO
+func FromProductComponentEntities(components []entities.ProductComponent) []ProductComponentDTO {
+	result := make([]ProductComponentDTO, 0, len(components))
+	for _, pc := range components {
+		if pc.Component == nil {
+			continue
+		}
+		unitCost, _ := pc.Component.CalculateCost()
+		item := ProductComponentDTO{
+			ID:              pc.Component.ID,
+			Article:         pc.Component.Article,
+			Name:            pc.Component.Name,
+			QuantityPerUnit: pc.QuantityPerUnit,
+			UnitCost:        unitCost,
+			TotalCost:       pc.QuantityPerUnit * unitCost,
+		}
+		if pc.Component.ProductType != nil {
+			item.TypeName = pc.Component.ProductType.Name
+		}
+		result = append(result, item)
 	}
+	return result
+}
 
-	return &entities.Product{
-		Article:             dto.Article,
-		ProductTypeID:       dto.ProductTypeID,
-		Name:                dto.Name,
-		Description:         description,
-		MinPartnerPrice:     dto.MinPartnerPrice,
-		RollWidth:           dto.RollWidth,
-		PackageLength:       dto.PackageLength,
-		PackageWidth:        dto.PackageWidth,
-		PackageHeight:       dto.PackageHeight,
-		ProductionTimeHours: dto.ProductionTimeHours,
-		CostPrice:           dto.CostPrice,
-		WorkshopNumber:      workshopNumber,
+// FromMaterialRequirementEntities преобразует потребности в сырье в DTO
+func FromMaterialRequirementEntities(requirements []entities.MaterialRequirement) []MaterialRequirementDTO {
+	result := make([]MaterialRequirementDTO, len(requirements))
+	for i, req := range requirements {
+		result[i] = MaterialRequirementDTO{
+			MaterialID: req.MaterialID,
+			Quantity:   req.Quantity,
+		}
+		if req.Material != nil {
+			result[i].Article = req.Material.Article
+			result[i].Name = req.Material.Name
+			result[i].CostPerUnit = req.Material.CostPerUnit
+			result[i].TotalCost = req.Quantity * req.Material.CostPerUnit
+			if req.Material.MeasurementUnit != nil {
+				result[i].UnitAbbreviation = req.Material.MeasurementUnit.Abbreviation
+			}
+		}
 	}
+	return result
 }
 
-// ToEntity преобразует DTO в доменную сущность
-func (dto *UpdateProductRequest) ToEntity(id int) *entities.Product {
-	var description *string
-	if dto.Description != "" {
-		description = &dto.Description
-	}
-	
-	var workshopNumber *string
-	if dto.WorkshopNumber != "" {
-		workshopNumber = &dto.WorkshopNumber
-	}
-
-	product := &entities.Product{
-		ID:                  id,
-		Article:             dto.Article,
-		ProductTypeID:       dto.ProductTypeID,
-		Name:                dto.Name,
-		Description:         description,
-		MinPartnerPrice:     dto.MinPartnerPrice,
-		RollWidth:           dto.RollWidth,
-		PackageLength:       dto.PackageLength,
-		PackageWidth:        dto.PackageWidth,
-		PackageHeight:       dto.PackageHeight,
-		ProductionTimeHours: dto.ProductionTimeHours,
-		CostPrice:           dto.CostPrice,
-		WorkshopNumber:      workshopNumber,
+// ProductListQuery представляет параметры фильтрации, сортировки и пагинации списка продукции
+type ProductListQuery struct {
+	ProductTypeID   string `form:"product_type_id"`
+	MinPrice        string `form:"min_price"`
+	MaxPrice        string `form:"max_price"`
+	Article         string `form:"article"`
+	IncludeArchived string `form:"include_archived"`
+	IncludeVariants string `form:"include_variants"`
+	Sort            string `form:"sort"`
+	Order           string `form:"order"`
+	Page            string `form:"page"`
+	PageSize        string `form:"page_size"`
+}
+
+// ToCriteria преобразует параметры запроса в критерии выборки продукции
+func (q *ProductListQuery) ToCriteria() (entities.ProductCriteria, error) {
+	var criteria entities.ProductCriteria
+	var err error
+
+	if criteria.ProductTypeID, err = parseQueryInt("product_type_id", q.ProductTypeID); err != nil {
+		return criteria, err
+	}
+	if criteria.MinPrice, err = parseQueryFloat("min_price", q.MinPrice); err != nil {
+		return criteria, err
+	}
+	if criteria.MaxPrice, err = parseQueryFloat("max_price", q.MaxPrice); err != nil {
+		return criteria, err
+	}
+	if criteria.Pagination, err = parsePagination(q.Page, q.PageSize); err != nil {
+		return criteria, err
+	}
+	if criteria.IncludeArchived, err = parseQueryBool("include_archived", q.IncludeArchived); err != nil {
+		return criteria, err
+	}
+	if criteria.IncludeVariants, err = parseQueryBool("include_variants", q.IncludeVariants); err != nil {
+		return criteria, err
 	}
 
-	return product
+	criteria.ArticlePrefix = strings.TrimSpace(q.Article)
+	criteria.SortBy = strings.TrimSpace(q.Sort)
+	criteria.SortDirection = entities.SortDirection(strings.ToLower(strings.TrimSpace(q.Order)))
+
+	return criteria, nil
+}
+
+// Values возвращает заданные фильтры без номера страницы для построения ссылок
+func (q *ProductListQuery) Values() url.Values {
+	values := url.Values{}
+	setIfNotEmpty(values, "product_type_id", q.ProductTypeID)
+	setIfNotEmpty(values, "min_price", q.MinPrice)
+	setIfNotEmpty(values, "max_price", q.MaxPrice)
+	setIfNotEmpty(values, "article", q.Article)
+	setIfNotEmpty(values, "include_archived", q.IncludeArchived)
+	setIfNotEmpty(values, "include_variants", q.IncludeVariants)
+	setIfNotEmpty(values, "sort", q.Sort)
+	setIfNotEmpty(values, "order", q.Order)
+	setIfNotEmpty(values, "page_size", q.PageSize)
+	return values
 }
@@ -0,0 +1,112 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// ProductTypeDTO представляет тип продукции для API
+type ProductTypeDTO struct {
+	ID          int     `json:"id"`
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+	Coefficient float64 `json:"coefficient"`
+	Archived    bool    `json:"archived"`
+	ArchivedAt  *string `json:"archived_at"`
+}
+
+// CoefficientChangeDTO представляет запись истории коэффициента типа продукции
+type CoefficientChangeDTO struct {
+	ID             int       `json:"id"`
+	OldCoefficient *float64  `json:"old_coefficient"`
+	NewCoefficient float64   `json:"new_coefficient"`
+	EffectiveFrom  string    `json:"effective_from"`
+	ChangedBy      string    `json:"changed_by"`
+	Comment        *string   `json:"comment"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ProductTypeRequest представляет запрос на создание или изменение типа продукции (JSON или форма).
+// Автор и дата вступления в силу записываются в историю, если коэффициент новый или изменился;
+// без даты изменение действует с сегодняшнего дня
+type ProductTypeRequest struct {
+	Name          string  `json:"name" form:"name" binding:"required"`
+	Description   *string `json:"description" form:"description"`
+	Coefficient   float64 `json:"coefficient" form:"coefficient" binding:"required,gt=0"`
+	ChangedBy     string  `json:"changed_by" form:"changed_by"`
+	EffectiveFrom *string `json:"effective_from" form:"effective_from"`
+	Comment       *string `json:"comment" form:"comment"`
+}
+
+// ProductTypesQuery представляет параметры списка типов продукции
+type ProductTypesQuery struct {
+	IncludeArchived string `form:"include_archived"`
+}
+
+// Parse возвращает признак включения выведенных из обращения типов
+func (q *ProductTypesQuery) Parse() (bool, error) {
+	return parseQueryBool("include_archived", q.IncludeArchived)
+}
+
+// ToEntity преобразует DTO в тип продукции и сведения об изменении коэффициента
+func (dto *ProductTypeRequest) ToEntity() (*entities.ProductType, *entities.CoefficientChange, error) {
+	effectiveFrom, err := parseOptionalDate("effective_from", trimOptional(dto.EffectiveFrom))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	change := &entities.CoefficientChange{
+		ChangedBy: strings.TrimSpace(dto.ChangedBy),
+		Comment:   trimOptional(dto.Comment),
+	}
+	if effectiveFrom != nil {
+		change.EffectiveFrom = *effectiveFrom
+	}
+
+	productType := &entities.ProductType{
+		Name:        strings.TrimSpace(dto.Name),
+		Description: trimOptional(dto.Description),
+		Coefficient: dto.Coefficient,
+	}
+	return productType, change, nil
+}
+
+// FromProductTypeEntity преобразует тип продукции в DTO
+func FromProductTypeEntity(productType *entities.ProductType) ProductTypeDTO {
+	return ProductTypeDTO{
+		ID:          productType.ID,
+		Name:        productType.Name,
+		Description: productType.Description,
+		Coefficient: productType.Coefficient,
+		Archived:    productType.IsArchived(),
+		ArchivedAt:  formatOptionalDate(productType.ArchivedAt),
+	}
+}
+
+// FromProductTypeEntities преобразует список типов продукции в DTO
+func FromProductTypeEntities(productTypes []entities.ProductType) []ProductTypeDTO {
+	result := make([]ProductTypeDTO, len(productTypes))
+	for i := range productTypes {
+		result[i] = FromProductTypeEntity(&productTypes[i])
+	}
+	return result
+}
+
+// FromCoefficientChanges преобразует историю коэффициента в DTO
+func FromCoefficientChanges(history []entities.CoefficientChange) []CoefficientChangeDTO {
+	result := make([]CoefficientChangeDTO, len(history))
+	for i, change := range history {
+		result[i] = CoefficientChangeDTO{
+			ID:             change.ID,
+			OldCoefficient: change.OldCoefficient,
+			NewCoefficient: change.NewCoefficient,
+			EffectiveFrom:  change.EffectiveFrom.Format(DateLayout),
+			ChangedBy:      change.ChangedBy,
+			Comment:        change.Comment,
+			CreatedAt:      change.CreatedAt,
+		}
+	}
+	return result
+}
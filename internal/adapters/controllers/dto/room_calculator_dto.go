@@ -0,0 +1,110 @@
+package dto
+
+import "wallpaper-system/internal/domain/entities"
+
+// RoomOpeningDTO представляет проем (окно или дверь), размеры в метрах
+type RoomOpeningDTO struct {
+	Width  float64 `json:"width" binding:"gt=0"`
+	Height float64 `json:"height" binding:"gt=0"`
+}
+
+// RoomRollRequestDTO представляет запрос на расчет рулонов для комнаты. Через API проемы
+// передаются списком openings, веб-форма присылает их парами полей opening_width/opening_height;
+// пустая строка проема пропускается
+type RoomRollRequestDTO struct {
+	ProductID      int              `form:"product_id" json:"product_id" binding:"required"`
+	PartnerTypeID  *int             `form:"partner_type_id" json:"partner_type_id"`
+	Perimeter      float64          `form:"perimeter" json:"perimeter" binding:"required,gt=0"`
+	Height         float64          `form:"height" json:"height" binding:"required,gt=0"`
+	ReservePercent *float64         `form:"reserve_percent" json:"reserve_percent" binding:"omitempty,min=0,max=100"`
+	Openings       []RoomOpeningDTO `form:"-" json:"openings" binding:"dive"`
+	OpeningWidths  []float64        `form:"opening_width" json:"-"`
+	OpeningHeights []float64        `form:"opening_height" json:"-"`
+}
+
+// RoomRollCalculationDTO представляет результат расчета рулонов для комнаты
+type RoomRollCalculationDTO struct {
+	ProductID     int                    `json:"product_id"`
+	Article       string                 `json:"article"`
+	Name          string                 `json:"name"`
+	RollWidth     float64                `json:"roll_width"`
+	RollLength    float64                `json:"roll_length"`
+	PatternRepeat *float64               `json:"pattern_repeat"`
+	WallArea      float64                `json:"wall_area"`
+	OpeningsArea  float64                `json:"openings_area"`
+	StripLength   float64                `json:"strip_length"`
+	StripsPerRoll int                    `json:"strips_per_roll"`
+	Strips        int                    `json:"strips"`
+	Rolls         int                    `json:"rolls"`
+	ReserveRolls  int                    `json:"reserve_rolls"`
+	TotalRolls    int                    `json:"total_rolls"`
+	RollPrice     float64                `json:"roll_price"`
+	TotalCost     float64                `json:"total_cost"`
+	PricingRule   *AppliedPricingRuleDTO `json:"pricing_rule"`
+}
+
+// ToEntity преобразует DTO в доменную сущность. Без запаса применяется запас по умолчанию,
+// нулевой тип партнера означает базовую цену
+func (dto *RoomRollRequestDTO) ToEntity() *entities.RoomRollRequest {
+	request := &entities.RoomRollRequest{
+		ProductID:      dto.ProductID,
+		Perimeter:      dto.Perimeter,
+		Height:         dto.Height,
+		ReservePercent: entities.DefaultRoomReservePercent,
+		Openings:       make([]entities.RoomOpening, 0, len(dto.Openings)+len(dto.OpeningWidths)),
+	}
+	if dto.PartnerTypeID != nil && *dto.PartnerTypeID > 0 {
+		request.PartnerTypeID = dto.PartnerTypeID
+	}
+	if dto.ReservePercent != nil {
+		request.ReservePercent = *dto.ReservePercent
+	}
+
+	for _, opening := range dto.Openings {
+		request.Openings = append(request.Openings, entities.RoomOpening{Width: opening.Width, Height: opening.Height})
+	}
+	for i, width := range dto.OpeningWidths {
+		var height float64
+		if i < len(dto.OpeningHeights) {
+			height = dto.OpeningHeights[i]
+		}
+		if width == 0 && height == 0 {
+			continue
+		}
+		request.Openings = append(request.Openings, entities.RoomOpening{Width: width, Height: height})
+	}
+
+	return request
+}
+
+// FromRoomRollCalculation преобразует результат расчета в DTO
+func FromRoomRollCalculation(calculation *entities.RoomRollCalculation) RoomRollCalculationDTO {
+	dto := RoomRollCalculationDTO{
+		WallArea:      calculation.WallArea,
+		OpeningsArea:  calculation.OpeningsArea,
+		StripLength:   calculation.StripLength,
+		StripsPerRoll: calculation.StripsPerRoll,
+		Strips:        calculation.Strips,
+		Rolls:         calculation.Rolls,
+		ReserveRolls:  calculation.ReserveRolls,
+		TotalRolls:    calculation.TotalRolls,
+		RollPrice:     calculation.RollPrice,
+		TotalCost:     calculation.TotalCost,
+	}
+
+	if product := calculation.Product; product != nil {
+		dto.ProductID = product.ID
+		dto.Article = product.Article
+		dto.Name = product.Name
+		dto.PatternRepeat = product.PatternRepeat
+		if product.HasRollSize() {
+			dto.RollWidth = *product.RollWidth
+			dto.RollLength = *product.RollLength
+		}
+	}
+	if calculation.PricingRule != nil {
+		dto.PricingRule = FromAppliedPricingRule(calculation.PricingRule)
+	}
+
+	return dto
+}
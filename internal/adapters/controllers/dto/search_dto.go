@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"strconv"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// SearchResultDTO представляет найденную запись
+type SearchResultDTO struct {
+	Type     string  `json:"type"`
+	ID       int     `json:"id"`
+	Title    string  `json:"title"`
+	Code     string  `json:"code"`
+	Category string  `json:"category"`
+	Snippet  string  `json:"snippet"`
+	Rank     float64 `json:"rank"`
+	URL      string  `json:"url,omitempty"`
+}
+
+// SearchResponseDTO представляет результаты поиска, сгруппированные по типу сущности
+type SearchResponseDTO struct {
+	Query     string            `json:"query"`
+	Total     int               `json:"total"`
+	Products  []SearchResultDTO `json:"products"`
+	Materials []SearchResultDTO `json:"materials"`
+	Partners  []SearchResultDTO `json:"partners"`
+}
+
+// FromSearchResults преобразует результаты поиска в DTO
+func FromSearchResults(results *entities.SearchResults) SearchResponseDTO {
+	return SearchResponseDTO{
+		Query:     results.Query,
+		Total:     results.Total(),
+		Products:  fromSearchResultEntities(results.Products),
+		Materials: fromSearchResultEntities(results.Materials),
+		Partners:  fromSearchResultEntities(results.Partners),
+	}
+}
+
+func fromSearchResultEntities(results []entities.SearchResult) []SearchResultDTO {
+	dtos := make([]SearchResultDTO, len(results))
+	for i, result := range results {
+		dtos[i] = SearchResultDTO{
+			Type:     string(result.EntityType),
+			ID:       result.ID,
+			Title:    result.Title,
+			Code:     result.Code,
+			Category: result.Category,
+			Snippet:  result.Snippet,
+			Rank:     result.Rank,
+			URL:      searchResultURL(result),
+		}
+	}
+	return dtos
+}
+
+// searchResultURL возвращает ссылку на карточку найденной записи, если она есть в веб-интерфейсе
+func searchResultURL(result entities.SearchResult) string {
+	switch result.EntityType {
+	case entities.SearchEntityProduct:
+		return "/products/" + strconv.Itoa(result.ID)
+	case entities.SearchEntityMaterial:
+		return "/materials/" + strconv.Itoa(result.ID)
+	default:
+		return ""
+	}
+}
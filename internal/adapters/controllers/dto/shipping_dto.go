@@ -0,0 +1,149 @@
+package dto
+
+import "wallpaper-system/internal/domain/entities"
+
+// PalletSpecDTO задает поддон для расчета отгрузки: размеры в метрах, вес в килограммах.
+// В JSON передается объектом pallet, в GET-запросе - параметрами pallet_*; незаданные
+// параметры берутся от европоддона
+type PalletSpecDTO struct {
+	Length    *float64 `json:"length" form:"pallet_length" binding:"omitempty,gt=0"`
+	Width     *float64 `json:"width" form:"pallet_width" binding:"omitempty,gt=0"`
+	Height    *float64 `json:"height" form:"pallet_height" binding:"omitempty,min=0"`
+	Weight    *float64 `json:"weight" form:"pallet_weight" binding:"omitempty,min=0"`
+	MaxHeight *float64 `json:"max_height" form:"pallet_max_height" binding:"omitempty,gt=0"`
+	MaxLoad   *float64 `json:"max_load" form:"pallet_max_load" binding:"omitempty,gt=0"`
+}
+
+// ShipmentLineDTO представляет позицию отгрузки: количество единиц продукции (упаковок)
+type ShipmentLineDTO struct {
+	ProductID int `json:"product_id" binding:"required"`
+	Quantity  int `json:"quantity" binding:"required,gt=0"`
+}
+
+// ShipmentRequestDTO представляет запрос на расчет отгрузки списка продукции
+type ShipmentRequestDTO struct {
+	Lines  []ShipmentLineDTO `json:"lines" binding:"required,min=1,dive"`
+	Pallet PalletSpecDTO     `json:"pallet"`
+}
+
+// ShipmentLineLayoutDTO представляет раскладку одной продукции по поддонам
+type ShipmentLineLayoutDTO struct {
+	ProductID          int     `json:"product_id"`
+	Article            string  `json:"article"`
+	Name               string  `json:"name"`
+	Quantity           int     `json:"quantity"`
+	PackageLength      float64 `json:"package_length"`
+	PackageWidth       float64 `json:"package_width"`
+	PackageHeight      float64 `json:"package_height"`
+	PackagesPerLayer   int     `json:"packages_per_layer"`
+	Layers             int     `json:"layers"`
+	PackagesPerPallet  int     `json:"packages_per_pallet"`
+	Pallets            int     `json:"pallets"`
+	LastPalletPackages int     `json:"last_pallet_packages"`
+	StackHeight        float64 `json:"stack_height"`
+	Volume             float64 `json:"volume"`
+	Weight             float64 `json:"weight"`
+}
+
+// PalletDTO представляет поддон, использованный в расчете
+type PalletDTO struct {
+	Length    float64 `json:"length"`
+	Width     float64 `json:"width"`
+	Height    float64 `json:"height"`
+	Weight    float64 `json:"weight"`
+	MaxHeight float64 `json:"max_height"`
+	MaxLoad   float64 `json:"max_load"`
+}
+
+// ShipmentPlanDTO представляет результат расчета отгрузки
+type ShipmentPlanDTO struct {
+	Pallet        PalletDTO               `json:"pallet"`
+	Lines         []ShipmentLineLayoutDTO `json:"lines"`
+	Packages      int                     `json:"packages"`
+	Pallets       int                     `json:"pallets"`
+	CargoVolume   float64                 `json:"cargo_volume"`
+	LoadedVolume  float64                 `json:"loaded_volume"`
+	FloorArea     float64                 `json:"floor_area"`
+	CargoWeight   float64                 `json:"cargo_weight"`
+	PalletsWeight float64                 `json:"pallets_weight"`
+	GrossWeight   float64                 `json:"gross_weight"`
+}
+
+// ToEntity преобразует DTO в параметры поддона, подставляя параметры европоддона
+func (dto *PalletSpecDTO) ToEntity() entities.PalletSpec {
+	pallet := entities.DefaultPalletSpec()
+	if dto.Length != nil {
+		pallet.Length = *dto.Length
+	}
+	if dto.Width != nil {
+		pallet.Width = *dto.Width
+	}
+	if dto.Height != nil {
+		pallet.Height = *dto.Height
+	}
+	if dto.Weight != nil {
+		pallet.Weight = *dto.Weight
+	}
+	if dto.MaxHeight != nil {
+		pallet.MaxHeight = *dto.MaxHeight
+	}
+	if dto.MaxLoad != nil {
+		pallet.MaxLoad = *dto.MaxLoad
+	}
+	return pallet
+}
+
+// ToEntity преобразует DTO в доменную сущность
+func (dto *ShipmentRequestDTO) ToEntity() *entities.ShipmentRequest {
+	request := &entities.ShipmentRequest{
+		Lines:  make([]entities.ShipmentLine, len(dto.Lines)),
+		Pallet: dto.Pallet.ToEntity(),
+	}
+	for i, line := range dto.Lines {
+		request.Lines[i] = entities.ShipmentLine{ProductID: line.ProductID, Quantity: line.Quantity}
+	}
+	return request
+}
+
+// FromShipmentPlan преобразует результат расчета отгрузки в DTO
+func FromShipmentPlan(plan *entities.ShipmentPlan) ShipmentPlanDTO {
+	result := ShipmentPlanDTO{
+		Pallet: PalletDTO{
+			Length:    plan.Pallet.Length,
+			Width:     plan.Pallet.Width,
+			Height:    plan.Pallet.Height,
+			Weight:    plan.Pallet.Weight,
+			MaxHeight: plan.Pallet.MaxHeight,
+			MaxLoad:   plan.Pallet.MaxLoad,
+		},
+		Lines:         make([]ShipmentLineLayoutDTO, len(plan.Lines)),
+		Packages:      plan.Packages,
+		Pallets:       plan.Pallets,
+		CargoVolume:   plan.CargoVolume,
+		LoadedVolume:  plan.LoadedVolume,
+		FloorArea:     plan.FloorArea,
+		CargoWeight:   plan.CargoWeight,
+		PalletsWeight: plan.PalletsWeight,
+		GrossWeight:   plan.GrossWeight,
+	}
+	for i, line := range plan.Lines {
+		result.Lines[i] = ShipmentLineLayoutDTO{
+			ProductID:          line.Product.ID,
+			Article:            line.Product.Article,
+			Name:               line.Product.Name,
+			Quantity:           line.Quantity,
+			PackageLength:      *line.Product.PackageLength,
+			PackageWidth:       *line.Product.PackageWidth,
+			PackageHeight:      *line.Product.PackageHeight,
+			PackagesPerLayer:   line.PackagesPerLayer,
+			Layers:             line.Layers,
+			PackagesPerPallet:  line.PackagesPerPallet,
+			Pallets:            line.Pallets,
+			LastPalletPackages: line.LastPalletPackages,
+			StackHeight:        line.StackHeight,
+			Volume:             line.Volume,
+			Weight:             line.Weight,
+		}
+	}
+	return result
+}
@@ -0,0 +1,115 @@
+package dto
+
+import (
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// StockNotificationQuery представляет фильтр уведомлений о низком остатке: open=true
+// оставляет только уведомления, остаток по которым еще не восстановлен
+type StockNotificationQuery struct {
+	Open string `form:"open"`
+}
+
+// IsOpenOnly сообщает, нужно ли вернуть только открытые уведомления
+func (q StockNotificationQuery) IsOpenOnly() (bool, error) {
+	return parseQueryBool("open", q.Open)
+}
+
+// LowStockItemDTO представляет материал с остатком не выше минимального и рекомендацию по закупке
+type LowStockItemDTO struct {
+	MaterialID        int     `json:"material_id"`
+	Article           string  `json:"article"`
+	Name              string  `json:"name"`
+	MaterialType      string  `json:"material_type,omitempty"`
+	Unit              string  `json:"unit,omitempty"`
+	StockQuantity     float64 `json:"stock_quantity"`
+	MinStockQuantity  float64 `json:"min_stock_quantity"`
+	Shortage          float64 `json:"shortage"`
+	PackageQuantity   float64 `json:"package_quantity"`
+	SuggestedPackages int     `json:"suggested_packages"`
+	SuggestedQuantity float64 `json:"suggested_quantity"`
+	CostPerUnit       float64 `json:"cost_per_unit"`
+	SuggestedCost     float64 `json:"suggested_cost"`
+}
+
+// StockNotificationDTO представляет уведомление о снижении остатка материала до минимального
+type StockNotificationDTO struct {
+	ID               int        `json:"id"`
+	MaterialID       int        `json:"material_id"`
+	Article          string     `json:"article"`
+	Name             string     `json:"name"`
+	Unit             string     `json:"unit,omitempty"`
+	StockQuantity    float64    `json:"stock_quantity"`
+	MinStockQuantity float64    `json:"min_stock_quantity"`
+	Open             bool       `json:"open"`
+	CreatedAt        time.Time  `json:"created_at"`
+	AcknowledgedAt   *time.Time `json:"acknowledged_at"`
+	ResolvedAt       *time.Time `json:"resolved_at"`
+}
+
+// FromLowStockItem преобразует рекомендацию по закупке в DTO
+func FromLowStockItem(item entities.LowStockItem) LowStockItemDTO {
+	material := item.Material
+	result := LowStockItemDTO{
+		MaterialID:        material.ID,
+		Article:           material.Article,
+		Name:              material.Name,
+		StockQuantity:     material.StockQuantity,
+		MinStockQuantity:  material.MinStockQuantity,
+		Shortage:          item.Shortage,
+		PackageQuantity:   material.PackageQuantity,
+		SuggestedPackages: item.SuggestedPackages,
+		SuggestedQuantity: item.SuggestedQuantity,
+		CostPerUnit:       material.CostPerUnit,
+		SuggestedCost:     item.SuggestedCost,
+	}
+	if material.MaterialType != nil {
+		result.MaterialType = material.MaterialType.Name
+	}
+	if material.MeasurementUnit != nil {
+		result.Unit = material.MeasurementUnit.Abbreviation
+	}
+	return result
+}
+
+// FromLowStockItems преобразует список рекомендаций по закупке в DTO
+func FromLowStockItems(items []entities.LowStockItem) []LowStockItemDTO {
+	result := make([]LowStockItemDTO, len(items))
+	for i, item := range items {
+		result[i] = FromLowStockItem(item)
+	}
+	return result
+}
+
+// FromStockNotification преобразует уведомление об остатке в DTO
+func FromStockNotification(notification entities.StockNotification) StockNotificationDTO {
+	result := StockNotificationDTO{
+		ID:               notification.ID,
+		MaterialID:       notification.MaterialID,
+		StockQuantity:    notification.StockQuantity,
+		MinStockQuantity: notification.MinStockQuantity,
+		Open:             notification.IsOpen(),
+		CreatedAt:        notification.CreatedAt,
+		AcknowledgedAt:   notification.AcknowledgedAt,
+		ResolvedAt:       notification.ResolvedAt,
+	}
+	if material := notification.Material; material != nil {
+		result.Article = material.Article
+		result.Name = material.Name
+		if material.MeasurementUnit != nil {
+			result.Unit = material.MeasurementUnit.Abbreviation
+		}
+	}
+	return result
+}
+
+// FromStockNotifications преобразует список уведомлений об остатках в DTO
+func FromStockNotifications(notifications []entities.StockNotification) []StockNotificationDTO {
+	result := make([]StockNotificationDTO, len(notifications))
+	for i, notification := range notifications {
+		result[i] = FromStockNotification(notification)
+	}
+	return result
+}
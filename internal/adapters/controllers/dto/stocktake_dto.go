@@ -0,0 +1,163 @@
+package dto
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// StocktakeRequest представляет запрос на открытие инвентаризации (JSON или форма)
+type StocktakeRequest struct {
+	Note *string `json:"note" form:"note"`
+}
+
+// StocktakeCountRequest представляет фактический остаток материала в единице учета;
+// counted_quantity null отменяет ранее введенный пересчет
+type StocktakeCountRequest struct {
+	MaterialID      int      `json:"material_id" binding:"required"`
+	CountedQuantity *float64 `json:"counted_quantity"`
+}
+
+// StocktakeCountsRequest представляет запрос на ввод фактических остатков. Можно передавать
+// только пересчитанные материалы: остальные строки инвентаризации не меняются
+type StocktakeCountsRequest struct {
+	Counts []StocktakeCountRequest `json:"counts" binding:"required,min=1,dive"`
+}
+
+// StocktakeDTO представляет инвентаризацию без строк
+type StocktakeDTO struct {
+	ID          int        `json:"id"`
+	Status      string     `json:"status"`
+	StatusLabel string     `json:"status_label"`
+	Note        *string    `json:"note"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ClosedAt    *time.Time `json:"closed_at"`
+}
+
+// StocktakeItemDTO представляет строку инвентаризации. StockQuantity - текущий остаток
+// материала, ExpectedQuantity - учетный остаток на момент открытия
+type StocktakeItemDTO struct {
+	MaterialID       int        `json:"material_id"`
+	Article          string     `json:"article"`
+	Name             string     `json:"name"`
+	Unit             string     `json:"unit,omitempty"`
+	ExpectedQuantity float64    `json:"expected_quantity"`
+	CountedQuantity  *float64   `json:"counted_quantity"`
+	StockQuantity    float64    `json:"stock_quantity"`
+	UnitCost         float64    `json:"unit_cost"`
+	Variance         float64    `json:"variance"`
+	VarianceValue    float64    `json:"variance_value"`
+	CountedAt        *time.Time `json:"counted_at"`
+	MovementID       *int       `json:"movement_id"`
+}
+
+// StocktakeReportDTO представляет инвентаризацию со строками и ведомость расхождений
+type StocktakeReportDTO struct {
+	StocktakeDTO
+	ItemsTotal    int                `json:"items_total"`
+	ItemsCounted  int                `json:"items_counted"`
+	SurplusValue  float64            `json:"surplus_value"`
+	ShortageValue float64            `json:"shortage_value"`
+	NetValue      float64            `json:"net_value"`
+	Variances     []StocktakeItemDTO `json:"variances"`
+	Items         []StocktakeItemDTO `json:"items"`
+}
+
+// ToNote возвращает комментарий к инвентаризации без пробелов по краям
+func (r *StocktakeRequest) ToNote() *string {
+	return trimOptional(r.Note)
+}
+
+// ToEntities преобразует запрос в фактические остатки
+func (r *StocktakeCountsRequest) ToEntities() []entities.StocktakeCount {
+	counts := make([]entities.StocktakeCount, len(r.Counts))
+	for i, count := range r.Counts {
+		counts[i] = entities.StocktakeCount{MaterialID: count.MaterialID, Quantity: count.CountedQuantity}
+	}
+	return counts
+}
+
+// ParseStocktakeCountsForm разбирает поля формы пересчета counted[<ID материала>].
+// Пустое поле означает, что материал не пересчитан
+func ParseStocktakeCountsForm(values map[string]string) ([]entities.StocktakeCount, error) {
+	counts := make([]entities.StocktakeCount, 0, len(values))
+	for key, value := range values {
+		materialID, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, entities.NewValidationError("material_id", "некорректный ID материала")
+		}
+		quantity, err := parseQueryFloat("counted_quantity", value)
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, entities.StocktakeCount{MaterialID: materialID, Quantity: quantity})
+	}
+
+	sort.Slice(counts, func(i, j int) bool {
+		return counts[i].MaterialID < counts[j].MaterialID
+	})
+	return counts, nil
+}
+
+// FromStocktake преобразует инвентаризацию в DTO без строк
+func FromStocktake(stocktake *entities.Stocktake) StocktakeDTO {
+	return StocktakeDTO{
+		ID:          stocktake.ID,
+		Status:      string(stocktake.Status),
+		StatusLabel: stocktake.Status.Label(),
+		Note:        stocktake.Note,
+		CreatedAt:   stocktake.CreatedAt,
+		ClosedAt:    stocktake.ClosedAt,
+	}
+}
+
+// FromStocktakes преобразует список инвентаризаций в DTO
+func FromStocktakes(stocktakes []entities.Stocktake) []StocktakeDTO {
+	result := make([]StocktakeDTO, len(stocktakes))
+	for i := range stocktakes {
+		result[i] = FromStocktake(&stocktakes[i])
+	}
+	return result
+}
+
+// FromStocktakeReport преобразует ведомость расхождений в DTO
+func FromStocktakeReport(report *entities.StocktakeReport) StocktakeReportDTO {
+	return StocktakeReportDTO{
+		StocktakeDTO:  FromStocktake(report.Stocktake),
+		ItemsTotal:    report.ItemsTotal,
+		ItemsCounted:  report.ItemsCounted,
+		SurplusValue:  report.SurplusValue,
+		ShortageValue: report.ShortageValue,
+		NetValue:      report.NetValue,
+		Variances:     fromStocktakeItems(report.Variances),
+		Items:         fromStocktakeItems(report.Stocktake.Items),
+	}
+}
+
+func fromStocktakeItems(items []entities.StocktakeItem) []StocktakeItemDTO {
+	result := make([]StocktakeItemDTO, len(items))
+	for i := range items {
+		item := &items[i]
+		result[i] = StocktakeItemDTO{
+			MaterialID:       item.MaterialID,
+			ExpectedQuantity: item.ExpectedQuantity,
+			CountedQuantity:  item.CountedQuantity,
+			UnitCost:         item.UnitCost,
+			Variance:         item.Variance(),
+			VarianceValue:    item.VarianceValue(),
+			CountedAt:        item.CountedAt,
+			MovementID:       item.MovementID,
+		}
+		if material := item.Material; material != nil {
+			result[i].Article = material.Article
+			result[i].Name = material.Name
+			result[i].StockQuantity = material.StockQuantity
+			if material.MeasurementUnit != nil {
+				result[i].Unit = material.MeasurementUnit.Abbreviation
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,174 @@
+package dto
+
+import (
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// MeasurementUnitDTO представляет единицу измерения для API. BaseFactor - множитель
+// к базовой единице категории (для грамма 0.001 кг)
+type MeasurementUnitDTO struct {
+	ID            int     `json:"id"`
+	Name          string  `json:"name"`
+	Abbreviation  string  `json:"abbreviation"`
+	Category      string  `json:"category"`
+	CategoryLabel string  `json:"category_label"`
+	BaseFactor    float64 `json:"base_factor"`
+}
+
+// UnitConversionRequest представляет запрос на пересчет количества. Единицы задаются
+// обозначением (кг, м²) или ID; material_id нужен для пересчета между категориями
+type UnitConversionRequest struct {
+	Quantity   *float64 `json:"quantity" binding:"required"`
+	From       string   `json:"from" binding:"required"`
+	To         string   `json:"to" binding:"required"`
+	MaterialID *int     `json:"material_id"`
+}
+
+// UnitConversionDTO представляет результат пересчета количества
+type UnitConversionDTO struct {
+	MaterialID *int    `json:"material_id"`
+	Quantity   float64 `json:"quantity"`
+	From       string  `json:"from"`
+	To         string  `json:"to"`
+	Result     float64 `json:"result"`
+}
+
+// UnitEquivalentDTO показывает, сколько единиц учета материала содержится в одной единице
+type UnitEquivalentDTO struct {
+	UnitID           int     `json:"unit_id"`
+	UnitName         string  `json:"unit_name"`
+	UnitAbbreviation string  `json:"unit_abbreviation"`
+	Factor           float64 `json:"factor"`
+}
+
+// MaterialUnitConversionDTO представляет пересчет единицы в единицу учета материала
+type MaterialUnitConversionDTO struct {
+	ID               int       `json:"id"`
+	UnitID           int       `json:"unit_id"`
+	UnitName         string    `json:"unit_name"`
+	UnitAbbreviation string    `json:"unit_abbreviation"`
+	Factor           float64   `json:"factor"`
+	RollWidth        *float64  `json:"roll_width"`
+	RollLength       *float64  `json:"roll_length"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// MaterialUnitConversionRequest представляет запрос на создание или замену пересчета материала
+// (JSON или форма): 1 единица unit_id = factor единиц материала. Для рулонов вместо множителя
+// можно указать ширину и длину рулона в метрах; пустые размеры в форме приходят нулями
+type MaterialUnitConversionRequest struct {
+	UnitID     int      `json:"unit_id" form:"unit_id" binding:"required"`
+	Factor     *float64 `json:"factor" form:"factor" binding:"omitempty,min=0"`
+	RollWidth  *float64 `json:"roll_width" form:"roll_width" binding:"omitempty,min=0"`
+	RollLength *float64 `json:"roll_length" form:"roll_length" binding:"omitempty,min=0"`
+}
+
+// StockInUnitDTO представляет остаток материала в выбранной единице
+type StockInUnitDTO struct {
+	MaterialID        int     `json:"material_id"`
+	StockQuantity     float64 `json:"stock_quantity"`
+	ReservedQuantity  float64 `json:"reserved_quantity"`
+	AvailableQuantity float64 `json:"available_quantity"`
+	MinStockQuantity  float64 `json:"min_stock_quantity"`
+	UnitAbbreviation  string  `json:"unit_abbreviation"`
+}
+
+// ToEntity преобразует DTO в пересчет материала
+func (dto *MaterialUnitConversionRequest) ToEntity(materialID int) *entities.MaterialUnitConversion {
+	conversion := &entities.MaterialUnitConversion{
+		MaterialID: materialID,
+		UnitID:     dto.UnitID,
+		RollWidth:  optionalMeasure(dto.RollWidth),
+		RollLength: optionalMeasure(dto.RollLength),
+	}
+	if dto.Factor != nil {
+		conversion.Factor = *dto.Factor
+	}
+	return conversion
+}
+
+// FromMeasurementUnitEntity преобразует единицу измерения в DTO
+func FromMeasurementUnitEntity(unit *entities.MeasurementUnit) MeasurementUnitDTO {
+	return MeasurementUnitDTO{
+		ID:            unit.ID,
+		Name:          unit.Name,
+		Abbreviation:  unit.Abbreviation,
+		Category:      string(unit.Category),
+		CategoryLabel: unit.Category.Label(),
+		BaseFactor:    unit.BaseFactor,
+	}
+}
+
+// FromMeasurementUnitEntities преобразует список единиц измерения в DTO
+func FromMeasurementUnitEntities(units []entities.MeasurementUnit) []MeasurementUnitDTO {
+	result := make([]MeasurementUnitDTO, len(units))
+	for i := range units {
+		result[i] = FromMeasurementUnitEntity(&units[i])
+	}
+	return result
+}
+
+// FromUnitConversion преобразует результат пересчета в DTO
+func FromUnitConversion(conversion *entities.UnitConversion) UnitConversionDTO {
+	return UnitConversionDTO{
+		MaterialID: conversion.MaterialID,
+		Quantity:   conversion.Quantity,
+		From:       conversion.From.Abbreviation,
+		To:         conversion.To.Abbreviation,
+		Result:     conversion.Result,
+	}
+}
+
+// FromUnitEquivalents преобразует единицы материала в DTO
+func FromUnitEquivalents(equivalents []entities.UnitEquivalent) []UnitEquivalentDTO {
+	result := make([]UnitEquivalentDTO, len(equivalents))
+	for i, equivalent := range equivalents {
+		result[i] = UnitEquivalentDTO{
+			UnitID:           equivalent.Unit.ID,
+			UnitName:         equivalent.Unit.Name,
+			UnitAbbreviation: equivalent.Unit.Abbreviation,
+			Factor:           equivalent.Factor,
+		}
+	}
+	return result
+}
+
+// FromMaterialUnitConversions преобразует пересчеты материала в DTO
+func FromMaterialUnitConversions(conversions []entities.MaterialUnitConversion) []MaterialUnitConversionDTO {
+	result := make([]MaterialUnitConversionDTO, len(conversions))
+	for i, conversion := range conversions {
+		result[i] = FromMaterialUnitConversion(&conversion)
+	}
+	return result
+}
+
+// FromMaterialUnitConversion преобразует пересчет материала в DTO
+func FromMaterialUnitConversion(conversion *entities.MaterialUnitConversion) MaterialUnitConversionDTO {
+	item := MaterialUnitConversionDTO{
+		ID:         conversion.ID,
+		UnitID:     conversion.UnitID,
+		Factor:     conversion.Factor,
+		RollWidth:  conversion.RollWidth,
+		RollLength: conversion.RollLength,
+		UpdatedAt:  conversion.UpdatedAt,
+	}
+	if conversion.Unit != nil {
+		item.UnitName = conversion.Unit.Name
+		item.UnitAbbreviation = conversion.Unit.Abbreviation
+	}
+	return item
+}
+
+// FromStockInUnit преобразует остаток материала в DTO
+func FromStockInUnit(stock *entities.StockInUnit) StockInUnitDTO {
+	return StockInUnitDTO{
+		MaterialID:        stock.MaterialID,
+		StockQuantity:     stock.StockQuantity,
+		ReservedQuantity:  stock.ReservedQuantity,
+		AvailableQuantity: stock.AvailableQuantity,
+		MinStockQuantity:  stock.MinStockQuantity,
+		UnitAbbreviation:  stock.Unit.Abbreviation,
+	}
+}
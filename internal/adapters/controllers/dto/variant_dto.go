@@ -0,0 +1,165 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// VariantSummaryDTO представляет вариант продукции в списке под базовым артикулом
+type VariantSummaryDTO struct {
+	ID            int     `json:"id"`
+	Article       string  `json:"article"`
+	ColorName     string  `json:"color_name"`
+	ThumbnailPath *string `json:"thumbnail_path"`
+}
+
+// ProductVariantDTO представляет вариант продукции с заменами рецептуры
+type ProductVariantDTO struct {
+	VariantSummaryDTO
+	ProductID     int                  `json:"product_id"`
+	ArticleSuffix string               `json:"article_suffix"`
+	ImagePath     *string              `json:"image_path"`
+	PreviewPath   *string              `json:"preview_path"`
+	Overrides     []VariantOverrideDTO `json:"overrides"`
+	CreatedAt     time.Time            `json:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+	// Price - цена по действующей рецептуре для страницы вариантов
+	Price *PriceCalculationDTO `json:"price,omitempty"`
+}
+
+// VariantOverrideDTO представляет замену расхода материала в варианте
+type VariantOverrideDTO struct {
+	MaterialID      int     `json:"material_id"`
+	MaterialArticle string  `json:"material_article"`
+	MaterialName    string  `json:"material_name"`
+	UnitName        string  `json:"unit_name"`
+	QuantityPerUnit float64 `json:"quantity_per_unit"`
+	// BaseQuantity - расход в базовой рецептуре; nil, если материал добавлен вариантом
+	BaseQuantity *float64 `json:"base_quantity"`
+	Excluded     bool     `json:"excluded"`
+}
+
+// CreateVariantRequest представляет запрос на создание варианта продукции
+type CreateVariantRequest struct {
+	ArticleSuffix string                   `form:"article_suffix" json:"article_suffix" binding:"required"`
+	ColorName     string                   `form:"color_name" json:"color_name" binding:"required"`
+	Overrides     []VariantOverrideRequest `json:"overrides" binding:"omitempty,dive"`
+}
+
+// UpdateVariantRequest представляет запрос на изменение суффикса артикула и цвета варианта
+type UpdateVariantRequest struct {
+	ArticleSuffix string `form:"article_suffix" json:"article_suffix" binding:"required"`
+	ColorName     string `form:"color_name" json:"color_name" binding:"required"`
+}
+
+// VariantOverrideRequest представляет запрос на замену расхода материала в варианте.
+// Нулевой расход исключает материал из рецептуры варианта
+type VariantOverrideRequest struct {
+	MaterialID      int     `form:"material_id" json:"material_id" binding:"required"`
+	QuantityPerUnit float64 `form:"quantity_per_unit" json:"quantity_per_unit" binding:"min=0"`
+}
+
+// ToEntity преобразует DTO в доменную сущность
+func (dto *CreateVariantRequest) ToEntity(productID int) *entities.ProductVariant {
+	variant := &entities.ProductVariant{
+		ProductID:     productID,
+		ArticleSuffix: strings.TrimSpace(dto.ArticleSuffix),
+		ColorName:     strings.TrimSpace(dto.ColorName),
+	}
+	for _, override := range dto.Overrides {
+		variant.Overrides = append(variant.Overrides, override.ToEntity())
+	}
+	return variant
+}
+
+// ToEntity преобразует DTO в доменную сущность
+func (dto *UpdateVariantRequest) ToEntity(id int) *entities.ProductVariant {
+	return &entities.ProductVariant{
+		ID:            id,
+		ArticleSuffix: strings.TrimSpace(dto.ArticleSuffix),
+		ColorName:     strings.TrimSpace(dto.ColorName),
+	}
+}
+
+// ToEntity преобразует DTO в доменную сущность
+func (dto *VariantOverrideRequest) ToEntity() entities.VariantMaterialOverride {
+	return entities.VariantMaterialOverride{
+		MaterialID:      dto.MaterialID,
+		QuantityPerUnit: dto.QuantityPerUnit,
+	}
+}
+
+// FromVariantSummary преобразует вариант в DTO для списка продукции
+func FromVariantSummary(product *entities.Product, variant *entities.ProductVariant) VariantSummaryDTO {
+	return VariantSummaryDTO{
+		ID:            variant.ID,
+		Article:       entities.VariantArticle(product.Article, variant.ArticleSuffix),
+		ColorName:     variant.ColorName,
+		ThumbnailPath: variant.Images().ThumbnailOrImage(),
+	}
+}
+
+// FromVariantEntity преобразует вариант в DTO. Базовая продукция нужна для артикула
+// и сравнения замен с базовой рецептурой
+func FromVariantEntity(product *entities.Product, variant *entities.ProductVariant) ProductVariantDTO {
+	dto := ProductVariantDTO{
+		VariantSummaryDTO: FromVariantSummary(product, variant),
+		ProductID:         variant.ProductID,
+		ArticleSuffix:     variant.ArticleSuffix,
+		ImagePath:         variant.ImagePath,
+		PreviewPath:       variant.PreviewPath,
+		Overrides:         make([]VariantOverrideDTO, 0, len(variant.Overrides)),
+		CreatedAt:         variant.CreatedAt,
+		UpdatedAt:         variant.UpdatedAt,
+	}
+
+	for _, override := range variant.Overrides {
+		item := VariantOverrideDTO{
+			MaterialID:      override.MaterialID,
+			QuantityPerUnit: override.QuantityPerUnit,
+			Excluded:        override.QuantityPerUnit == 0,
+		}
+		if override.Material != nil {
+			item.MaterialArticle = override.Material.Article
+			item.MaterialName = override.Material.Name
+			if override.Material.MeasurementUnit != nil {
+				item.UnitName = override.Material.MeasurementUnit.Abbreviation
+			}
+		}
+		for _, pm := range product.Materials {
+			if pm.MaterialID == override.MaterialID {
+				quantity := pm.QuantityPerUnit
+				item.BaseQuantity = &quantity
+				break
+			}
+		}
+		dto.Overrides = append(dto.Overrides, item)
+	}
+
+	return dto
+}
+
+// FromVariantEntities преобразует варианты продукции в DTO
+func FromVariantEntities(product *entities.Product, variants []entities.ProductVariant) []ProductVariantDTO {
+	result := make([]ProductVariantDTO, len(variants))
+	for i := range variants {
+		result[i] = FromVariantEntity(product, &variants[i])
+	}
+	return result
+}
+
+// VariantPriceDTO представляет цену варианта, рассчитанную по его действующей рецептуре
+type VariantPriceDTO struct {
+	VariantID int `json:"variant_id"`
+	PriceCalculationDTO
+}
+
+// FromVariantPrice преобразует расчет цены варианта в DTO
+func FromVariantPrice(variant *entities.ProductVariant, partnerTypeID *int, date time.Time, calculation *entities.PriceCalculation) VariantPriceDTO {
+	return VariantPriceDTO{
+		VariantID:           variant.ID,
+		PriceCalculationDTO: FromPriceCalculation(variant.ProductID, partnerTypeID, date, calculation),
+	}
+}
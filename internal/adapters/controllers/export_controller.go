@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"wallpaper-system/internal/adapters/controllers/dto"
+	"wallpaper-system/internal/adapters/spreadsheet"
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportController обрабатывает выгрузку каталогов и прайс-листов в CSV, XLSX и HTML для печати
+type ExportController struct {
+	productUseCase  usecases.ProductUseCaseInterface
+	materialUseCase usecases.MaterialUseCaseInterface
+}
+
+// NewExportController создает новый контроллер выгрузки
+func NewExportController(
+	productUseCase usecases.ProductUseCaseInterface,
+	materialUseCase usecases.MaterialUseCaseInterface,
+) *ExportController {
+	return &ExportController{
+		productUseCase:  productUseCase,
+		materialUseCase: materialUseCase,
+	}
+}
+
+// ExportProducts выгружает прайс-лист продукции:
+// GET /api/v1/products/export?format=xlsx&columns=article,name,price&partner_type_id=2
+func (c *ExportController) ExportProducts(ctx *gin.Context) {
+	var query dto.ProductExportQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные параметры запроса: "+err.Error()))
+		return
+	}
+
+	request, format, err := query.ToRequest()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	table, err := c.productUseCase.ExportProducts(request)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	writeExport(ctx, table, format, "price-list")
+}
+
+// ExportMaterials выгружает каталог материалов:
+// GET /api/v1/materials/export?format=csv&below_min_stock=true
+func (c *ExportController) ExportMaterials(ctx *gin.Context) {
+	var query dto.MaterialExportQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные параметры запроса: "+err.Error()))
+		return
+	}
+
+	request, format, err := query.ToRequest()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	table, err := c.materialUseCase.ExportMaterials(request)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	writeExport(ctx, table, format, "materials")
+}
+
+// writeExport отдает выгрузку в запрошенном формате: HTML - страницей для печати,
+// CSV и XLSX - файлом с датой выгрузки в имени
+func writeExport(ctx *gin.Context, table *entities.ExportTable, format entities.ExportFormat, name string) {
+	if format == entities.ExportFormatHTML {
+		ctx.HTML(http.StatusOK, "price_list.html", gin.H{
+			"title": table.Title,
+			"table": table,
+		})
+		return
+	}
+
+	var buf bytes.Buffer
+	var contentType string
+	var err error
+
+	switch format {
+	case entities.ExportFormatXLSX:
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+		err = spreadsheet.WriteXLSX(&buf, table)
+	default:
+		contentType = "text/csv; charset=utf-8"
+		err = spreadsheet.WriteCSV(&buf, table)
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.NewErrorResponse("Ошибка формирования файла: "+err.Error()))
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("2006-01-02"), format)
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	ctx.Data(http.StatusOK, contentType, buf.Bytes())
+}
@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExportControllerTestSuite struct {
+	suite.Suite
+	productUseCase  *mocks.MockProductUseCase
+	materialUseCase *mocks.MockMaterialUseCase
+	controller      *ExportController
+	router          *gin.Engine
+}
+
+func (suite *ExportControllerTestSuite) SetupTest() {
+	suite.productUseCase = new(mocks.MockProductUseCase)
+	suite.materialUseCase = new(mocks.MockMaterialUseCase)
+	suite.controller = NewExportController(suite.productUseCase, suite.materialUseCase)
+
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+	suite.router.GET("/api/v1/products/export", suite.controller.ExportProducts)
+	suite.router.GET("/api/v1/materials/export", suite.controller.ExportMaterials)
+}
+
+func (suite *ExportControllerTestSuite) TestExportProducts_CSV() {
+	// Подготовка данных
+	price := 240.0
+	table := &entities.ExportTable{
+		Title:       "Прайс-лист продукции",
+		GeneratedAt: time.Now(),
+		Columns:     []entities.ExportColumn{{Key: "article", Title: "Артикул"}, {Key: "price", Title: "Цена", Numeric: true}},
+		Groups: []entities.ExportGroup{
+			{Name: "Виниловые обои", Rows: [][]entities.ExportCell{{entities.TextCell("WP-001"), entities.NumberCell(&price)}}},
+		},
+	}
+	partnerTypeID := 2
+
+	// Настройка мока
+	suite.productUseCase.On("ExportProducts", mock.MatchedBy(func(request entities.ProductExportRequest) bool {
+		return request.PartnerTypeID != nil && *request.PartnerTypeID == partnerTypeID &&
+			assert.ObjectsAreEqual([]string{"article", "price"}, request.Columns) &&
+			request.Criteria.ProductTypeID != nil && *request.Criteria.ProductTypeID == 1
+	})).Return(table, nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/export?product_type_id=1&columns=article,price&partner_type_id=2", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(suite.T(), w.Header().Get("Content-Disposition"), `filename="price-list-`)
+	assert.True(suite.T(), strings.HasSuffix(w.Body.String(), "Артикул;Цена\nWP-001;240\n"))
+	suite.productUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *ExportControllerTestSuite) TestExportProducts_UnknownFormat() {
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/export?format=pdf", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.productUseCase.AssertNotCalled(suite.T(), "ExportProducts", mock.Anything)
+}
+
+func (suite *ExportControllerTestSuite) TestExportMaterials_XLSX() {
+	// Настройка мока
+	table := &entities.ExportTable{
+		Title:   "Каталог материалов",
+		Columns: []entities.ExportColumn{{Key: "article", Title: "Артикул"}},
+	}
+	suite.materialUseCase.On("ExportMaterials", mock.Anything).Return(table, nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/materials/export?format=xlsx", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", w.Header().Get("Content-Type"))
+	assert.Contains(suite.T(), w.Header().Get("Content-Disposition"), ".xlsx")
+	assert.Equal(suite.T(), "PK", w.Body.String()[:2])
+}
+
+func TestExportControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(ExportControllerTestSuite))
+}
@@ -0,0 +1,268 @@
+package controllers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"wallpaper-system/internal/adapters/controllers/dto"
+	"wallpaper-system/internal/adapters/imaging"
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImageController обрабатывает загрузку изображений продукции, ее вариантов и материалов
+type ImageController struct {
+	imageUseCase usecases.ImageUseCaseInterface
+}
+
+// NewImageController создает новый контроллер изображений
+func NewImageController(imageUseCase usecases.ImageUseCaseInterface) *ImageController {
+	return &ImageController{
+		imageUseCase: imageUseCase,
+	}
+}
+
+// UploadProductImage загружает изображение продукции:
+// POST /api/v1/products/:id/image (multipart, поле image)
+func (c *ImageController) UploadProductImage(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID продукции"))
+		return
+	}
+
+	upload, err := readImageUpload(ctx)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	product, err := c.imageUseCase.UploadProductImage(id, upload)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Изображение загружено", dto.FromImagePaths(product.Images())))
+}
+
+// DeleteProductImage удаляет изображение продукции: DELETE /api/v1/products/:id/image
+func (c *ImageController) DeleteProductImage(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID продукции"))
+		return
+	}
+
+	if _, err := c.imageUseCase.DeleteProductImage(id); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Изображение удалено", nil))
+}
+
+// UploadMaterialImage загружает изображение материала:
+// POST /api/v1/materials/:id/image (multipart, поле image)
+func (c *ImageController) UploadMaterialImage(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID материала"))
+		return
+	}
+
+	upload, err := readImageUpload(ctx)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	material, err := c.imageUseCase.UploadMaterialImage(id, upload)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Изображение загружено", dto.FromImagePaths(material.Images())))
+}
+
+// DeleteMaterialImage удаляет изображение материала: DELETE /api/v1/materials/:id/image
+func (c *ImageController) DeleteMaterialImage(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID материала"))
+		return
+	}
+
+	if _, err := c.imageUseCase.DeleteMaterialImage(id); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Изображение удалено", nil))
+}
+
+// UploadVariantImage загружает изображение варианта продукции:
+// POST /api/v1/variants/:id/image (multipart, поле image)
+func (c *ImageController) UploadVariantImage(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID варианта"))
+		return
+	}
+
+	upload, err := readImageUpload(ctx)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	variant, err := c.imageUseCase.UploadVariantImage(id, upload)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Изображение загружено", dto.FromImagePaths(variant.Images())))
+}
+
+// DeleteVariantImage удаляет изображение варианта продукции: DELETE /api/v1/variants/:id/image
+func (c *ImageController) DeleteVariantImage(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID варианта"))
+		return
+	}
+
+	if _, err := c.imageUseCase.DeleteVariantImage(id); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Изображение удалено", nil))
+}
+
+// UploadProductImageWeb загружает изображение продукции из формы на странице продукции
+func (c *ImageController) UploadProductImageWeb(ctx *gin.Context) {
+	c.imageWeb(ctx, "/products/", "Некорректный ID продукции", func(id int) error {
+		upload, err := readImageUpload(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = c.imageUseCase.UploadProductImage(id, upload)
+		return err
+	})
+}
+
+// DeleteProductImageWeb удаляет изображение продукции из формы на странице продукции
+func (c *ImageController) DeleteProductImageWeb(ctx *gin.Context) {
+	c.imageWeb(ctx, "/products/", "Некорректный ID продукции", func(id int) error {
+		_, err := c.imageUseCase.DeleteProductImage(id)
+		return err
+	})
+}
+
+// UploadMaterialImageWeb загружает изображение материала из формы на странице материала
+func (c *ImageController) UploadMaterialImageWeb(ctx *gin.Context) {
+	c.imageWeb(ctx, "/materials/", "Некорректный ID материала", func(id int) error {
+		upload, err := readImageUpload(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = c.imageUseCase.UploadMaterialImage(id, upload)
+		return err
+	})
+}
+
+// DeleteMaterialImageWeb удаляет изображение материала из формы на странице материала
+func (c *ImageController) DeleteMaterialImageWeb(ctx *gin.Context) {
+	c.imageWeb(ctx, "/materials/", "Некорректный ID материала", func(id int) error {
+		_, err := c.imageUseCase.DeleteMaterialImage(id)
+		return err
+	})
+}
+
+// UploadVariantImageWeb загружает изображение варианта из формы на странице вариантов продукции
+func (c *ImageController) UploadVariantImageWeb(ctx *gin.Context) {
+	c.variantImageWeb(ctx, func(id int) (*entities.ProductVariant, error) {
+		upload, err := readImageUpload(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return c.imageUseCase.UploadVariantImage(id, upload)
+	})
+}
+
+// DeleteVariantImageWeb удаляет изображение варианта из формы на странице вариантов продукции
+func (c *ImageController) DeleteVariantImageWeb(ctx *gin.Context) {
+	c.variantImageWeb(ctx, c.imageUseCase.DeleteVariantImage)
+}
+
+// variantImageWeb возвращает на страницу вариантов базовой продукции, а не на страницу варианта
+func (c *ImageController) variantImageWeb(ctx *gin.Context, action func(id int) (*entities.ProductVariant, error)) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID варианта",
+		})
+		return
+	}
+
+	variant, err := action(id)
+	if err != nil {
+		ctx.HTML(errorStatus(err), "error.html", gin.H{
+			"error": "Ошибка изображения: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, variantsPagePath(variant.ProductID))
+}
+
+func (c *ImageController) imageWeb(ctx *gin.Context, pagePrefix, invalidIDMessage string, action func(id int) error) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": invalidIDMessage,
+		})
+		return
+	}
+
+	if err := action(id); err != nil {
+		ctx.HTML(errorStatus(err), "error.html", gin.H{
+			"error": "Ошибка изображения: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, pagePrefix+strconv.Itoa(id))
+}
+
+// readImageUpload читает изображение из поля image, проверяет его и готовит уменьшенные копии
+func readImageUpload(ctx *gin.Context) (*entities.ImageUpload, error) {
+	header, err := ctx.FormFile("image")
+	if err != nil {
+		return nil, entities.NewValidationError("image", "файл изображения не передан")
+	}
+	if header.Size > entities.MaxImageFileSize {
+		return nil, entities.NewValidationError("image",
+			fmt.Sprintf("размер изображения превышает %d МБ", entities.MaxImageFileSize>>20))
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка открытия файла: %w", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, entities.MaxImageFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения файла: %w", err)
+	}
+
+	return imaging.Prepare(data)
+}
@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImageControllerTestSuite struct {
+	suite.Suite
+	imageUseCase *mocks.MockImageUseCase
+	controller   *ImageController
+	router       *gin.Engine
+}
+
+func (suite *ImageControllerTestSuite) SetupTest() {
+	suite.imageUseCase = new(mocks.MockImageUseCase)
+	suite.controller = NewImageController(suite.imageUseCase)
+
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+	suite.router.POST("/api/v1/products/:id/image", suite.controller.UploadProductImage)
+}
+
+// newImageRequest создает multipart-запрос с файлом в поле image
+func newImageRequest(t *testing.T, target, filename string, content []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("image", filename)
+	assert.NoError(t, err)
+	_, err = part.Write(content)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, target, &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func (suite *ImageControllerTestSuite) TestUploadProductImage_Success() {
+	// Подготовка данных
+	var content bytes.Buffer
+	assert.NoError(suite.T(), png.Encode(&content, image.NewRGBA(image.Rect(0, 0, 20, 10))))
+	imagePath, thumbnailPath := "/uploads/products/1/a.png", "/uploads/products/1/a_thumb.jpg"
+
+	// Настройка мока: формат определяется по содержимому, а не по имени файла
+	suite.imageUseCase.On("UploadProductImage", 1, mock.MatchedBy(func(upload *entities.ImageUpload) bool {
+		return upload.Original.Extension == ".png" && upload.Width == 20 && len(upload.Thumbnail.Data) > 0
+	})).Return(&entities.Product{ID: 1, ImagePath: &imagePath, ThumbnailPath: &thumbnailPath}, nil)
+
+	// Выполнение запроса
+	req := newImageRequest(suite.T(), "/api/v1/products/1/image", "photo.jpg", content.Bytes())
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response struct {
+		Data struct {
+			ImagePath     string `json:"image_path"`
+			ThumbnailPath string `json:"thumbnail_path"`
+		} `json:"data"`
+	}
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(suite.T(), imagePath, response.Data.ImagePath)
+	assert.Equal(suite.T(), thumbnailPath, response.Data.ThumbnailPath)
+	suite.imageUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *ImageControllerTestSuite) TestUploadProductImage_NotImage() {
+	// Выполнение запроса
+	req := newImageRequest(suite.T(), "/api/v1/products/1/image", "photo.png", []byte("<html>не изображение</html>"))
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), "JPEG, PNG и GIF")
+	suite.imageUseCase.AssertNotCalled(suite.T(), "UploadProductImage", mock.Anything, mock.Anything)
+}
+
+func TestImageControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(ImageControllerTestSuite))
+}
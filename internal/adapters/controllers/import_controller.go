@@ -0,0 +1,168 @@
+package controllers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"wallpaper-system/internal/adapters/controllers/dto"
+	"wallpaper-system/internal/adapters/spreadsheet"
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImportController обрабатывает загрузку файлов CSV и XLSX для массового импорта
+type ImportController struct {
+	importUseCase usecases.ImportUseCaseInterface
+}
+
+// NewImportController создает новый контроллер импорта
+func NewImportController(importUseCase usecases.ImportUseCaseInterface) *ImportController {
+	return &ImportController{
+		importUseCase: importUseCase,
+	}
+}
+
+// ImportProducts импортирует продукцию из файла через API:
+// POST /api/v1/products/import (multipart, поле file; dry_run=true - только проверка)
+func (c *ImportController) ImportProducts(ctx *gin.Context) {
+	c.importAPI(ctx, c.importUseCase.ImportProducts)
+}
+
+// ImportMaterials импортирует материалы из файла через API:
+// POST /api/v1/materials/import (multipart, поле file; dry_run=true - только проверка)
+func (c *ImportController) ImportMaterials(ctx *gin.Context) {
+	c.importAPI(ctx, c.importUseCase.ImportMaterials)
+}
+
+type importFunc func(table *entities.ImportTable, dryRun bool) (*entities.ImportReport, error)
+
+func (c *ImportController) importAPI(ctx *gin.Context, run importFunc) {
+	var request dto.ImportRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса: "+err.Error()))
+		return
+	}
+	// В multipart-запросе привязываются только поля формы, dry_run удобнее передавать в строке запроса
+	if request.DryRun == "" {
+		request.DryRun = ctx.Query("dry_run")
+	}
+
+	dryRun, err := request.IsDryRun()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	table, err := readImportTable(ctx)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	report, err := run(table, dryRun)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse(importMessage(report), dto.FromImportReport(report))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetImportPage отображает страницу загрузки файла импорта
+func (c *ImportController) GetImportPage(ctx *gin.Context) {
+	ctx.HTML(http.StatusOK, "import.html", gin.H{
+		"title":  "Импорт из файла",
+		"entity": dto.ImportEntityProducts,
+		"dryRun": true,
+	})
+}
+
+// ImportWeb обрабатывает загрузку файла импорта из веб-формы и показывает отчет по строкам
+func (c *ImportController) ImportWeb(ctx *gin.Context) {
+	var request dto.ImportRequest
+	_ = ctx.ShouldBind(&request)
+	dryRun, _ := request.IsDryRun()
+
+	data := gin.H{
+		"title":  "Импорт из файла",
+		"entity": request.Entity,
+		"dryRun": dryRun,
+	}
+
+	run := c.importUseCase.ImportProducts
+	switch request.Entity {
+	case dto.ImportEntityProducts:
+	case dto.ImportEntityMaterials:
+		run = c.importUseCase.ImportMaterials
+	default:
+		data["error"] = "Выберите, что импортировать: продукцию или материалы"
+		ctx.HTML(http.StatusBadRequest, "import.html", data)
+		return
+	}
+
+	table, err := readImportTable(ctx)
+	if err != nil {
+		data["error"] = err.Error()
+		ctx.HTML(listErrorStatus(err), "import.html", data)
+		return
+	}
+
+	report, err := run(table, dryRun)
+	if err != nil {
+		data["error"] = err.Error()
+		ctx.HTML(listErrorStatus(err), "import.html", data)
+		return
+	}
+
+	data["message"] = importMessage(report)
+	data["report"] = dto.FromImportReport(report)
+	ctx.HTML(http.StatusOK, "import.html", data)
+}
+
+// readImportTable читает загруженный файл из поля file и разбирает его по расширению
+func readImportTable(ctx *gin.Context) (*entities.ImportTable, error) {
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		return nil, entities.NewValidationError("file", "файл не передан")
+	}
+	if header.Size > entities.MaxImportFileSize {
+		return nil, entities.NewValidationError("file", fmt.Sprintf("размер файла превышает %d МБ", entities.MaxImportFileSize>>20))
+	}
+
+	format, err := entities.ImportFormatFromFilename(header.Filename)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка открытия файла: %w", err)
+	}
+	defer file.Close()
+
+	// Заявленный размер может быть занижен: читаем на байт больше лимита, чтобы не импортировать
+	// обрезанный файл частично
+	data, err := io.ReadAll(io.LimitReader(file, entities.MaxImportFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения файла: %w", err)
+	}
+	if len(data) > entities.MaxImportFileSize {
+		return nil, entities.NewValidationError("file", fmt.Sprintf("размер файла превышает %d МБ", entities.MaxImportFileSize>>20))
+	}
+
+	return spreadsheet.ReadTable(format, data)
+}
+
+// importMessage формирует итоговое сообщение по отчету импорта
+func importMessage(report *entities.ImportReport) string {
+	if report.DryRun {
+		return fmt.Sprintf("Проверка файла: будет создано %d, обновлено %d, строк с ошибками %d",
+			report.Created(), report.Updated(), report.Skipped())
+	}
+	return fmt.Sprintf("Импорт выполнен: создано %d, обновлено %d, строк с ошибками %d",
+		report.Created(), report.Updated(), report.Skipped())
+}
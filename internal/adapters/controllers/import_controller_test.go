@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"wallpaper-system/internal/adapters/controllers/dto"
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImportControllerTestSuite struct {
+	suite.Suite
+	importUseCase *mocks.MockImportUseCase
+	controller    *ImportController
+	router        *gin.Engine
+}
+
+func (suite *ImportControllerTestSuite) SetupTest() {
+	suite.importUseCase = new(mocks.MockImportUseCase)
+	suite.controller = NewImportController(suite.importUseCase)
+
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+	suite.router.POST("/api/v1/products/import", suite.controller.ImportProducts)
+}
+
+// newImportRequest создает multipart-запрос с файлом в поле file
+func newImportRequest(t *testing.T, target, filename, content string) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filename)
+	assert.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, target, &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func (suite *ImportControllerTestSuite) TestImportProducts_DryRun() {
+	// Подготовка данных
+	expectedTable := &entities.ImportTable{
+		Header: []string{"Артикул", "Наименование"},
+		Rows:   []entities.ImportRow{{Number: 2, Values: []string{"WP-001", "Обои белые"}}},
+	}
+	report := &entities.ImportReport{
+		DryRun:  true,
+		Columns: []string{"article", "name"},
+		Rows: []entities.ImportRowResult{
+			{Row: 2, Article: "WP-001", Action: entities.ImportActionSkip, Errors: []entities.ImportRowError{
+				{Row: 2, Column: "product_type", Message: "обязательное поле не заполнено"},
+			}},
+		},
+	}
+
+	// Настройка мока
+	suite.importUseCase.On("ImportProducts", expectedTable, true).Return(report, nil)
+
+	// Выполнение запроса
+	req := newImportRequest(suite.T(), "/api/v1/products/import?dry_run=true", "catalog.csv", "Артикул;Наименование\nWP-001;Обои белые\n")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response struct {
+		Success bool                `json:"success"`
+		Data    dto.ImportReportDTO `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), response.Data.DryRun)
+	assert.Equal(suite.T(), 1, response.Data.Skipped)
+	assert.Equal(suite.T(), "skip", response.Data.Rows[0].Action)
+	assert.Equal(suite.T(), "product_type", response.Data.Rows[0].Errors[0].Column)
+
+	suite.importUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *ImportControllerTestSuite) TestImportProducts_UnsupportedFormat() {
+	// Выполнение запроса
+	req := newImportRequest(suite.T(), "/api/v1/products/import", "catalog.xls", "binary")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.importUseCase.AssertNotCalled(suite.T(), "ImportProducts", mock.Anything, mock.Anything)
+}
+
+func TestImportControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportControllerTestSuite))
+}
@@ -0,0 +1,238 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"wallpaper-system/internal/adapters/controllers/dto"
+	"wallpaper-system/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LotController обрабатывает HTTP запросы поставок и партий материалов
+type LotController struct {
+	lotUseCase      usecases.MaterialLotUseCaseInterface
+	materialUseCase usecases.MaterialUseCaseInterface
+}
+
+// NewLotController создает новый контроллер партий материалов
+func NewLotController(
+	lotUseCase usecases.MaterialLotUseCaseInterface,
+	materialUseCase usecases.MaterialUseCaseInterface,
+) *LotController {
+	return &LotController{
+		lotUseCase:      lotUseCase,
+		materialUseCase: materialUseCase,
+	}
+}
+
+// GetSuppliers возвращает список поставщиков
+func (c *LotController) GetSuppliers(ctx *gin.Context) {
+	suppliers, err := c.lotUseCase.GetSuppliers()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Поставщики получены", dto.FromSuppliers(suppliers)))
+}
+
+// GetMaterialLots возвращает партии материала в порядке расхода (FIFO)
+func (c *LotController) GetMaterialLots(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID материала"))
+		return
+	}
+
+	if _, err := c.materialUseCase.GetMaterialByID(id); err != nil {
+		ctx.JSON(listErrorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	lots, err := c.lotUseCase.GetMaterialLots(id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Партии материала получены", dto.FromMaterialLots(lots)))
+}
+
+// ReceiveSupply принимает поставку материала и создает партию через API
+func (c *LotController) ReceiveSupply(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID материала"))
+		return
+	}
+
+	var request dto.SupplyRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса: "+err.Error()))
+		return
+	}
+
+	supply, batchNumber, err := request.ToEntity(id, time.Now())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	lot, err := c.lotUseCase.ReceiveSupply(supply, batchNumber)
+	if warning, ok := costRecalculationWarning(err); ok {
+		ctx.JSON(http.StatusCreated, dto.NewWarningResponse("Поставка принята", warning, dto.FromMaterialLot(lot)))
+		return
+	}
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.NewSuccessResponse("Поставка принята", dto.FromMaterialLot(lot)))
+}
+
+// GetLotTrace возвращает прослеживаемость партии: расход из нее, заказы и продукцию
+func (c *LotController) GetLotTrace(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID партии"))
+		return
+	}
+
+	trace, err := c.lotUseCase.GetLotTrace(id)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Партия получена", dto.FromLotTrace(trace)))
+}
+
+// GetOrderLots возвращает партии материалов, списанные в расход по заказу
+func (c *LotController) GetOrderLots(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID заказа"))
+		return
+	}
+
+	consumptions, err := c.lotUseCase.GetOrderLots(id)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Партии заказа получены", dto.FromLotConsumptions(consumptions)))
+}
+
+// GetMaterialLotsPage отображает партии материала и форму приема поставки
+func (c *LotController) GetMaterialLotsPage(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID материала",
+		})
+		return
+	}
+
+	c.renderMaterialLotsPage(ctx, id, http.StatusOK, "")
+}
+
+// ReceiveSupplyWeb принимает поставку материала из формы
+func (c *LotController) ReceiveSupplyWeb(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID материала",
+		})
+		return
+	}
+
+	var request dto.SupplyRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		c.renderMaterialLotsPage(ctx, id, http.StatusBadRequest, "Некорректные данные формы: "+err.Error())
+		return
+	}
+
+	supply, batchNumber, err := request.ToEntity(id, time.Now())
+	if err != nil {
+		c.renderMaterialLotsPage(ctx, id, http.StatusBadRequest, "Некорректные данные формы: "+err.Error())
+		return
+	}
+
+	_, err = c.lotUseCase.ReceiveSupply(supply, batchNumber)
+	if _, ok := costRecalculationWarning(err); ok {
+		// Поставка принята, предупреждение о пересчете показывается на странице партий
+		ctx.Redirect(http.StatusFound, "/materials/"+strconv.Itoa(id)+"/lots?cost_warning=1")
+		return
+	}
+	if err != nil {
+		c.renderMaterialLotsPage(ctx, id, errorStatus(err), "Ошибка приема поставки: "+err.Error())
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/materials/"+strconv.Itoa(id)+"/lots")
+}
+
+// GetLotPage отображает прослеживаемость партии
+func (c *LotController) GetLotPage(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID партии",
+		})
+		return
+	}
+
+	trace, err := c.lotUseCase.GetLotTrace(id)
+	if err != nil {
+		ctx.HTML(listErrorStatus(err), "error.html", gin.H{
+			"error": "Партия не найдена: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.HTML(http.StatusOK, "lot.html", gin.H{
+		"title": trace.Lot.Label(),
+		"trace": dto.FromLotTrace(trace),
+	})
+}
+
+func (c *LotController) renderMaterialLotsPage(ctx *gin.Context, id int, status int, formError string) {
+	material, err := c.materialUseCase.GetMaterialByID(id)
+	if err != nil {
+		ctx.HTML(errorStatus(err), "error.html", gin.H{
+			"error": "Материал не найден: " + err.Error(),
+		})
+		return
+	}
+
+	lots, err := c.lotUseCase.GetMaterialLots(id)
+	if err != nil {
+		ctx.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "Ошибка получения партий материала: " + err.Error(),
+		})
+		return
+	}
+
+	suppliers, err := c.lotUseCase.GetSuppliers()
+	if err != nil {
+		ctx.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "Ошибка получения поставщиков: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.HTML(status, "material_lots.html", gin.H{
+		"title":       "Партии материала " + material.Name,
+		"material":    material,
+		"lots":        dto.FromMaterialLots(lots),
+		"suppliers":   dto.FromSuppliers(suppliers),
+		"today":       time.Now().Format(dto.DateLayout),
+		"error":       formError,
+		"costWarning": ctx.Query("cost_warning") != "",
+	})
+}
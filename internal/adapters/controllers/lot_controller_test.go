@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type LotControllerTestSuite struct {
+	suite.Suite
+	lotUseCase      *mocks.MockMaterialLotUseCase
+	materialUseCase *mocks.MockMaterialUseCase
+	controller      *LotController
+	router          *gin.Engine
+}
+
+func (suite *LotControllerTestSuite) SetupTest() {
+	suite.lotUseCase = new(mocks.MockMaterialLotUseCase)
+	suite.materialUseCase = new(mocks.MockMaterialUseCase)
+	suite.controller = NewLotController(suite.lotUseCase, suite.materialUseCase)
+
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+
+	v1 := suite.router.Group("/api/v1")
+	{
+		v1.POST("/materials/:id/lots", suite.controller.ReceiveSupply)
+		v1.GET("/lots/:id", suite.controller.GetLotTrace)
+		v1.GET("/orders/:id/lots", suite.controller.GetOrderLots)
+	}
+}
+
+func (suite *LotControllerTestSuite) TestReceiveSupply() {
+	// Настройка мока
+	batch := "B-17"
+	suite.lotUseCase.On("ReceiveSupply", mock.MatchedBy(func(supply *entities.MaterialSupply) bool {
+		return supply.MaterialID == 7 && supply.SupplierID == 2 && supply.Quantity == 120.5 && supply.UnitPrice == 310 &&
+			supply.SupplyDate.Equal(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC))
+	}), mock.MatchedBy(func(batchNumber *string) bool {
+		return batchNumber != nil && *batchNumber == "B-17"
+	})).Return(&entities.MaterialLot{
+		ID: 11, MaterialID: 7, BatchNumber: &batch, ReceivedAt: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		InitialQuantity: 120.5, RemainingQuantity: 120.5,
+	}, nil)
+
+	// Выполнение запроса
+	body := `{"supplier_id": 2, "quantity": 120.5, "unit_price": 310, "supply_date": "2024-03-15", "batch_number": " B-17 "}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/materials/7/lots", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"label":"Партия №11 (B-17)"`)
+	assert.Contains(suite.T(), w.Body.String(), `"received_at":"2024-03-15"`)
+	suite.lotUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *LotControllerTestSuite) TestReceiveSupply_CostWarning() {
+	// Настройка мока
+	suite.lotUseCase.On("ReceiveSupply", mock.Anything, mock.Anything).Return(
+		&entities.MaterialLot{ID: 11, MaterialID: 7, InitialQuantity: 10, RemainingQuantity: 10},
+		entities.NewCostRecalculationError("поставка принята", errors.New("database error")))
+
+	// Выполнение запроса
+	body := `{"supplier_id": 2, "quantity": 10, "unit_price": 310, "supply_date": "2024-03-15"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/materials/7/lots", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки: поставка принята, пересчет продукции показан предупреждением
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"warning":"поставка принята, но себестоимость продукции не пересчитана`)
+	assert.Contains(suite.T(), w.Body.String(), `"label":"Партия №11"`)
+}
+
+func (suite *LotControllerTestSuite) TestReceiveSupply_InvalidDate() {
+	// Выполнение запроса
+	body := `{"supplier_id": 2, "quantity": 10, "unit_price": 310, "supply_date": "15.03.2024"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/materials/7/lots", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.lotUseCase.AssertNotCalled(suite.T(), "ReceiveSupply", mock.Anything, mock.Anything)
+}
+
+func (suite *LotControllerTestSuite) TestReceiveSupply_SupplierNotFound() {
+	// Настройка мока
+	suite.lotUseCase.On("ReceiveSupply", mock.Anything, mock.Anything).
+		Return(nil, entities.NewNotFoundError("поставщик", "99"))
+
+	// Выполнение запроса
+	body := `{"supplier_id": 99, "quantity": 10, "unit_price": 310}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/materials/7/lots", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+func (suite *LotControllerTestSuite) TestGetLotTrace() {
+	// Настройка мока
+	orderID := 5
+	suite.lotUseCase.On("GetLotTrace", 11).Return(&entities.LotTrace{
+		Lot: &entities.MaterialLot{ID: 11, MaterialID: 7, InitialQuantity: 100, RemainingQuantity: 70,
+			Supplier: &entities.Supplier{ID: 2, Name: "ООО Винил", INN: "7701234567"}},
+		Consumptions: []entities.LotConsumption{{ID: 1, LotID: 11, Quantity: 30,
+			Movement: &entities.MaterialMovement{ID: 40, MaterialID: 7, Type: entities.MovementConsumption, Quantity: 30,
+				ReferenceType: entities.ReferenceOrder, ReferenceID: &orderID}}},
+		Products: []entities.LotProductUsage{{OrderID: 5, ProductID: 10, ProductArticle: "WP-10", ProductName: "Обои Флора", Quantity: 20}},
+	}, nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/lots/11", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Contains(suite.T(), body, `"order_ids":[5]`)
+	assert.Contains(suite.T(), body, `"consumed_quantity":30`)
+	assert.Contains(suite.T(), body, `"product_article":"WP-10"`)
+	assert.Contains(suite.T(), body, `"name":"ООО Винил"`)
+}
+
+func (suite *LotControllerTestSuite) TestGetOrderLots_NotFound() {
+	// Настройка мока
+	suite.lotUseCase.On("GetOrderLots", 8).Return(nil, entities.NewNotFoundError("заказ", "8"))
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/8/lots", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+func TestLotControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(LotControllerTestSuite))
+}
@@ -22,34 +22,72 @@ func NewMaterialController(materialUseCase *usecases.MaterialUseCase) *MaterialC
 	}
 }
 
-// GetMaterialsPage отображает страницу со списком материалов
+// GetMaterialsPage отображает страницу со списком материалов с фильтрами и пагинацией
 func (mc *MaterialController) GetMaterialsPage(c *gin.Context) {
-	materials, err := mc.materialUseCase.GetAllMaterials()
+	var query dto.MaterialListQuery
+	_ = c.ShouldBindQuery(&query)
+
+	criteria, err := query.ToCriteria()
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+		c.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректные параметры фильтра: " + err.Error(),
+		})
+		return
+	}
+
+	list, err := mc.materialUseCase.FindMaterials(criteria)
+	if err != nil {
+		c.HTML(listErrorStatus(err), "error.html", gin.H{
 			"error": "Ошибка загрузки материалов: " + err.Error(),
 		})
 		return
 	}
 
+	materialTypes, err := mc.materialUseCase.GetMaterialTypes()
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "Ошибка загрузки типов материалов: " + err.Error(),
+		})
+		return
+	}
+
 	c.HTML(http.StatusOK, "materials.html", gin.H{
-		"title":     "Материалы",
-		"materials": materials,
+		"title":         "Материалы",
+		"materials":     list.Items,
+		"materialTypes": materialTypes,
+		"filter":        query,
+		"pagination":    dto.NewPageLinks("/materials", query.Values(), list.PageInfo),
+		"exportLinks":   dto.ExportLinks("/api/v1/materials/export", query.Values()),
 	})
 }
 
-// GetMaterialsAPI возвращает список материалов в формате JSON
+// GetMaterials возвращает страницу списка материалов в формате JSON.
+// Поддерживает фильтры material_type_id, min_cost, max_cost, article, below_min_stock,
+// сортировку sort/order и пагинацию page/page_size.
 func (mc *MaterialController) GetMaterials(c *gin.Context) {
-	materials, err := mc.materialUseCase.GetAllMaterials()
+	var query dto.MaterialListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректные параметры запроса: " + err.Error()})
+		return
+	}
+
+	criteria, err := query.ToCriteria()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	list, err := mc.materialUseCase.FindMaterials(criteria)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(listErrorStatus(err), gin.H{
 			"error": "Ошибка загрузки материалов: " + err.Error(),
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"materials": materials,
+		"materials":  list.Items,
+		"pagination": dto.FromPageInfo(list.PageInfo),
 	})
 }
 
@@ -95,8 +133,9 @@ func (mc *MaterialController) GetMaterialDetailsPage(c *gin.Context) {
 	}
 
 	c.HTML(http.StatusOK, "material_detail.html", gin.H{
-		"title":    "Детали материала",
-		"material": material,
+		"title":       "Детали материала",
+		"material":    material,
+		"costWarning": c.Query("cost_warning") != "",
 	})
 }
 
@@ -232,6 +271,11 @@ func (mc *MaterialController) UpdateMaterialWeb(c *gin.Context) {
 	material.ID = materialID
 
 	err = mc.materialUseCase.UpdateMaterial(material)
+	if _, ok := costRecalculationWarning(err); ok {
+		// Материал сохранен, предупреждение о пересчете показывается на странице материала
+		c.Redirect(http.StatusFound, "/materials/"+strconv.Itoa(materialID)+"?cost_warning=1")
+		return
+	}
 	if err != nil {
 		// Получаем данные для повторного отображения формы
 		materialTypes, _ := mc.materialUseCase.GetMaterialTypes()
@@ -306,8 +350,16 @@ func (mc *MaterialController) UpdateMaterial(c *gin.Context) {
 	material.ID = materialID
 
 	err = mc.materialUseCase.UpdateMaterial(material)
+	if warning, ok := costRecalculationWarning(err); ok {
+		c.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"message": "Материал успешно обновлен",
+			"warning": warning,
+		})
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
+		c.JSON(errorStatus(err), gin.H{
 			"success": false,
 			"error":   err.Error(),
 		})
@@ -320,11 +372,9 @@ func (mc *MaterialController) UpdateMaterial(c *gin.Context) {
 	})
 }
 
-// DeleteMaterial удаляет материал через API
-func (mc *MaterialController) DeleteMaterial(c *gin.Context) {
-	id := c.Param("id")
-	
-	materialID, err := parseIDParam(id)
+// ArchiveMaterial переносит материал в архив через API (DELETE /api/v1/materials/:id)
+func (mc *MaterialController) ArchiveMaterial(c *gin.Context) {
+	materialID, err := parseIDParam(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
@@ -333,49 +383,72 @@ func (mc *MaterialController) DeleteMaterial(c *gin.Context) {
 		return
 	}
 
-	err = mc.materialUseCase.DeleteMaterial(materialID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
+	if err := mc.materialUseCase.ArchiveMaterial(materialID); err != nil {
+		c.JSON(errorStatus(err), gin.H{
 			"success": false,
-			"error":   "Ошибка удаления материала: " + err.Error(),
+			"error":   "Ошибка переноса материала в архив: " + err.Error(),
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"message": "Материал успешно удален",
+		"message": "Материал перенесен в архив",
 	})
 }
 
-// GetMaterialTypes возвращает список типов материалов через API
-func (mc *MaterialController) GetMaterialTypes(c *gin.Context) {
-	materialTypes, err := mc.materialUseCase.GetMaterialTypes()
+// RestoreMaterial возвращает материал из архива через API
+func (mc *MaterialController) RestoreMaterial(c *gin.Context) {
+	materialID, err := parseIDParam(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Ошибка получения типов материалов: " + err.Error(),
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Некорректный ID материала",
+		})
+		return
+	}
+
+	if err := mc.materialUseCase.RestoreMaterial(materialID); err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"success": false,
+			"error":   "Ошибка восстановления материала: " + err.Error(),
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"data": materialTypes,
+		"success": true,
+		"message": "Материал восстановлен из архива",
 	})
 }
 
-// GetMeasurementUnits возвращает список единиц измерения через API
-func (mc *MaterialController) GetMeasurementUnits(c *gin.Context) {
-	measurementUnits, err := mc.materialUseCase.GetMeasurementUnits()
+// ArchiveMaterialWeb переносит материал в архив через веб-форму
+func (mc *MaterialController) ArchiveMaterialWeb(c *gin.Context) {
+	mc.changeArchiveStateWeb(c, mc.materialUseCase.ArchiveMaterial, "Ошибка переноса материала в архив: ")
+}
+
+// RestoreMaterialWeb возвращает материал из архива через веб-форму
+func (mc *MaterialController) RestoreMaterialWeb(c *gin.Context) {
+	mc.changeArchiveStateWeb(c, mc.materialUseCase.RestoreMaterial, "Ошибка восстановления материала: ")
+}
+
+func (mc *MaterialController) changeArchiveStateWeb(c *gin.Context, action func(id int) error, errorPrefix string) {
+	materialID, err := parseIDParam(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Ошибка получения единиц измерения: " + err.Error(),
+		c.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID материала",
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data": measurementUnits,
-	})
+	if err := action(materialID); err != nil {
+		c.HTML(errorStatus(err), "error.html", gin.H{
+			"error": errorPrefix + err.Error(),
+		})
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/materials/"+strconv.Itoa(materialID))
 }
 
 // parseIDParam парсит ID из строкового параметра
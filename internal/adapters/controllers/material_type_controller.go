@@ -0,0 +1,252 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"wallpaper-system/internal/adapters/controllers/dto"
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaterialTypeController обрабатывает HTTP запросы для типов материалов
+type MaterialTypeController struct {
+	materialTypeUseCase usecases.MaterialTypeUseCaseInterface
+}
+
+// NewMaterialTypeController создает новый контроллер типов материалов
+func NewMaterialTypeController(materialTypeUseCase usecases.MaterialTypeUseCaseInterface) *MaterialTypeController {
+	return &MaterialTypeController{materialTypeUseCase: materialTypeUseCase}
+}
+
+// GetMaterialTypes возвращает список типов материалов через API
+func (c *MaterialTypeController) GetMaterialTypes(ctx *gin.Context) {
+	materialTypes, err := c.materialTypeUseCase.GetMaterialTypes()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.NewErrorResponse("Ошибка получения типов материалов: "+err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Типы материалов получены", dto.FromMaterialTypeEntities(materialTypes)))
+}
+
+// GetMaterialTypeByID возвращает тип материала по ID через API
+func (c *MaterialTypeController) GetMaterialTypeByID(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID типа материала"))
+		return
+	}
+
+	materialType, err := c.materialTypeUseCase.GetMaterialTypeByID(id)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Тип материала получен", dto.FromMaterialTypeEntity(materialType)))
+}
+
+// CreateMaterialType создает тип материала через API
+func (c *MaterialTypeController) CreateMaterialType(ctx *gin.Context) {
+	var request dto.MaterialTypeRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса: "+err.Error()))
+		return
+	}
+
+	materialType, change := request.ToEntity()
+	if err := c.materialTypeUseCase.CreateMaterialType(materialType, change); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.NewSuccessResponse("Тип материала создан", dto.FromMaterialTypeEntity(materialType)))
+}
+
+// UpdateMaterialType обновляет тип материала через API. При изменении процента брака
+// обязателен changed_by, изменение записывается в историю
+func (c *MaterialTypeController) UpdateMaterialType(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID типа материала"))
+		return
+	}
+
+	var request dto.MaterialTypeRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса: "+err.Error()))
+		return
+	}
+
+	materialType, change := request.ToEntity()
+	materialType.ID = id
+
+	if err := c.materialTypeUseCase.UpdateMaterialType(materialType, change); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Тип материала обновлен", dto.FromMaterialTypeEntity(materialType)))
+}
+
+// DeleteMaterialType удаляет неиспользуемый тип материала через API
+func (c *MaterialTypeController) DeleteMaterialType(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID типа материала"))
+		return
+	}
+
+	if err := c.materialTypeUseCase.DeleteMaterialType(id); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Тип материала удален", nil))
+}
+
+// GetDefectRateHistory возвращает историю процента брака типа материала:
+// GET /api/v1/material-types/:id/defect-rate-history
+func (c *MaterialTypeController) GetDefectRateHistory(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID типа материала"))
+		return
+	}
+
+	history, err := c.materialTypeUseCase.GetDefectRateHistory(id)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("История процента брака получена", dto.FromDefectRateChanges(history)))
+}
+
+// GetMaterialTypesPage отображает список типов материалов и форму добавления
+func (c *MaterialTypeController) GetMaterialTypesPage(ctx *gin.Context) {
+	c.renderMaterialTypesPage(ctx, http.StatusOK, "")
+}
+
+// CreateMaterialTypeWeb создает тип материала из формы
+func (c *MaterialTypeController) CreateMaterialTypeWeb(ctx *gin.Context) {
+	var request dto.MaterialTypeRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		c.renderMaterialTypesPage(ctx, http.StatusBadRequest, "Некорректные данные формы: "+err.Error())
+		return
+	}
+
+	materialType, change := request.ToEntity()
+	if err := c.materialTypeUseCase.CreateMaterialType(materialType, change); err != nil {
+		c.renderMaterialTypesPage(ctx, errorStatus(err), "Ошибка создания типа материала: "+err.Error())
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/material-types")
+}
+
+// GetMaterialTypeEditPage отображает форму изменения типа материала и историю процента брака
+func (c *MaterialTypeController) GetMaterialTypeEditPage(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID типа материала",
+		})
+		return
+	}
+
+	c.renderMaterialTypeEditPage(ctx, id, http.StatusOK, "")
+}
+
+// UpdateMaterialTypeWeb обновляет тип материала из формы
+func (c *MaterialTypeController) UpdateMaterialTypeWeb(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID типа материала",
+		})
+		return
+	}
+
+	var request dto.MaterialTypeRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		c.renderMaterialTypeEditPage(ctx, id, http.StatusBadRequest, "Некорректные данные формы: "+err.Error())
+		return
+	}
+
+	materialType, change := request.ToEntity()
+	materialType.ID = id
+
+	if err := c.materialTypeUseCase.UpdateMaterialType(materialType, change); err != nil {
+		c.renderMaterialTypeEditPage(ctx, id, errorStatus(err), "Ошибка изменения типа материала: "+err.Error())
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/material-types/"+strconv.Itoa(id))
+}
+
+// DeleteMaterialTypeWeb удаляет неиспользуемый тип материала через веб-форму
+func (c *MaterialTypeController) DeleteMaterialTypeWeb(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID типа материала",
+		})
+		return
+	}
+
+	if err := c.materialTypeUseCase.DeleteMaterialType(id); err != nil {
+		ctx.HTML(errorStatus(err), "error.html", gin.H{
+			"error": "Ошибка удаления типа материала: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/material-types")
+}
+
+func (c *MaterialTypeController) renderMaterialTypesPage(ctx *gin.Context, status int, formError string) {
+	materialTypes, err := c.materialTypeUseCase.GetMaterialTypes()
+	if err != nil {
+		ctx.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "Ошибка получения типов материалов: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.HTML(status, "material_types.html", gin.H{
+		"title":         "Типы материалов",
+		"materialTypes": dto.FromMaterialTypeEntities(materialTypes),
+		"maxPercentage": entities.MaxWastePercentage,
+		"error":         formError,
+	})
+}
+
+func (c *MaterialTypeController) renderMaterialTypeEditPage(ctx *gin.Context, id int, status int, formError string) {
+	materialType, err := c.materialTypeUseCase.GetMaterialTypeByID(id)
+	if err != nil {
+		ctx.HTML(errorStatus(err), "error.html", gin.H{
+			"error": "Тип материала не найден: " + err.Error(),
+		})
+		return
+	}
+
+	history, err := c.materialTypeUseCase.GetDefectRateHistory(id)
+	if err != nil {
+		ctx.HTML(listErrorStatus(err), "error.html", gin.H{
+			"error": "Ошибка получения истории процента брака: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.HTML(status, "material_type_edit.html", gin.H{
+		"title":         "Тип материала " + materialType.Name,
+		"materialType":  dto.FromMaterialTypeEntity(materialType),
+		"history":       dto.FromDefectRateChanges(history),
+		"maxPercentage": entities.MaxWastePercentage,
+		"error":         formError,
+	})
+}
@@ -0,0 +1,121 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MaterialTypeControllerTestSuite struct {
+	suite.Suite
+	materialTypeUseCase *mocks.MockMaterialTypeUseCase
+	controller          *MaterialTypeController
+	router              *gin.Engine
+}
+
+func (suite *MaterialTypeControllerTestSuite) SetupTest() {
+	suite.materialTypeUseCase = new(mocks.MockMaterialTypeUseCase)
+	suite.controller = NewMaterialTypeController(suite.materialTypeUseCase)
+
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+
+	materialTypes := suite.router.Group("/api/v1/material-types")
+	{
+		materialTypes.GET("", suite.controller.GetMaterialTypes)
+		materialTypes.POST("", suite.controller.CreateMaterialType)
+		materialTypes.DELETE("/:id", suite.controller.DeleteMaterialType)
+	}
+}
+
+func (suite *MaterialTypeControllerTestSuite) TestGetMaterialTypes_Success() {
+	// Настройка мока
+	suite.materialTypeUseCase.On("GetMaterialTypes").
+		Return([]entities.MaterialType{{ID: 1, Name: "Основа", WastePercentage: 3}}, nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/material-types", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response struct {
+		Data []struct {
+			ID              int     `json:"id"`
+			Name            string  `json:"name"`
+			WastePercentage float64 `json:"waste_percentage"`
+		} `json:"data"`
+	}
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(suite.T(), response.Data, 1)
+	assert.Equal(suite.T(), "Основа", response.Data[0].Name)
+	assert.Equal(suite.T(), 3.0, response.Data[0].WastePercentage)
+}
+
+func (suite *MaterialTypeControllerTestSuite) TestCreateMaterialType_ZeroPercentageAccepted() {
+	// Настройка мока
+	suite.materialTypeUseCase.On("CreateMaterialType",
+		mock.MatchedBy(func(mt *entities.MaterialType) bool {
+			return mt.Name == "Упаковка" && mt.WastePercentage == 0
+		}),
+		mock.MatchedBy(func(c *entities.DefectRateChange) bool { return c.ChangedBy == "Иванов" }),
+	).Return(nil)
+
+	// Выполнение запроса
+	body := `{"name": "Упаковка", "waste_percentage": 0, "changed_by": "Иванов"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/material-types", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	suite.materialTypeUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *MaterialTypeControllerTestSuite) TestCreateMaterialType_MissingPercentage() {
+	// Выполнение запроса
+	body := `{"name": "Упаковка", "changed_by": "Иванов"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/material-types", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.materialTypeUseCase.AssertNotCalled(suite.T(), "CreateMaterialType", mock.Anything, mock.Anything)
+}
+
+func (suite *MaterialTypeControllerTestSuite) TestDeleteMaterialType_InUse() {
+	// Настройка мока
+	suite.materialTypeUseCase.On("DeleteMaterialType", 1).
+		Return(entities.NewBusinessError("MATERIAL_TYPE_IN_USE", "тип материала используется в 4 материалах"))
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/material-types/1", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	// Нарушение бизнес-правила - конфликт с состоянием данных, а не ошибка запроса
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"success":false`)
+	assert.Contains(suite.T(), w.Body.String(), "используется в 4 материалах")
+	suite.materialTypeUseCase.AssertExpectations(suite.T())
+}
+
+func TestMaterialTypeControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(MaterialTypeControllerTestSuite))
+}
@@ -0,0 +1,175 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"wallpaper-system/internal/adapters/controllers/dto"
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MovementController обрабатывает HTTP запросы складского журнала материалов
+type MovementController struct {
+	movementUseCase usecases.MaterialMovementUseCaseInterface
+	materialUseCase usecases.MaterialUseCaseInterface
+}
+
+// NewMovementController создает новый контроллер складского журнала
+func NewMovementController(
+	movementUseCase usecases.MaterialMovementUseCaseInterface,
+	materialUseCase usecases.MaterialUseCaseInterface,
+) *MovementController {
+	return &MovementController{
+		movementUseCase: movementUseCase,
+		materialUseCase: materialUseCase,
+	}
+}
+
+// GetMaterialMovements возвращает страницу журнала движений материала через API.
+// Поддерживает фильтр movement_type и пагинацию page/page_size
+func (c *MovementController) GetMaterialMovements(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID материала"))
+		return
+	}
+
+	var query dto.MovementListQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные параметры запроса: "+err.Error()))
+		return
+	}
+
+	criteria, err := query.ToCriteria(id)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	list, err := c.movementUseCase.GetMovements(criteria)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewPagedResponse("Движения материала получены",
+		dto.FromMaterialMovements(list.Items), list.PageInfo))
+}
+
+// PostMaterialMovement проводит приход, расход или списание материала через API
+func (c *MovementController) PostMaterialMovement(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID материала"))
+		return
+	}
+
+	var request dto.MaterialMovementRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса: "+err.Error()))
+		return
+	}
+
+	movement := request.ToEntity(id)
+	err = c.movementUseCase.PostMovement(movement)
+	if warning, ok := costRecalculationWarning(err); ok {
+		ctx.JSON(http.StatusCreated, dto.NewWarningResponse("Движение материала проведено", warning, dto.FromMaterialMovement(movement)))
+		return
+	}
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.NewSuccessResponse("Движение материала проведено", dto.FromMaterialMovement(movement)))
+}
+
+// GetMaterialHistoryPage отображает историю движения материала и форму проведения движения
+func (c *MovementController) GetMaterialHistoryPage(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID материала",
+		})
+		return
+	}
+
+	var query dto.MovementListQuery
+	_ = ctx.ShouldBindQuery(&query)
+
+	c.renderMaterialHistoryPage(ctx, id, query, http.StatusOK, "")
+}
+
+// PostMaterialMovementWeb проводит движение материала из формы
+func (c *MovementController) PostMaterialMovementWeb(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID материала",
+		})
+		return
+	}
+
+	var request dto.MaterialMovementRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		c.renderMaterialHistoryPage(ctx, id, dto.MovementListQuery{}, http.StatusBadRequest,
+			"Некорректные данные формы: "+err.Error())
+		return
+	}
+
+	err = c.movementUseCase.PostMovement(request.ToEntity(id))
+	if _, ok := costRecalculationWarning(err); ok {
+		// Движение проведено, предупреждение о пересчете показывается на странице истории
+		ctx.Redirect(http.StatusFound, "/materials/"+strconv.Itoa(id)+"/history?cost_warning=1")
+		return
+	}
+	if err != nil {
+		c.renderMaterialHistoryPage(ctx, id, dto.MovementListQuery{}, errorStatus(err),
+			"Ошибка проведения движения: "+err.Error())
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/materials/"+strconv.Itoa(id)+"/history")
+}
+
+func (c *MovementController) renderMaterialHistoryPage(ctx *gin.Context, id int, query dto.MovementListQuery, status int, formError string) {
+	material, err := c.materialUseCase.GetMaterialByID(id)
+	if err != nil {
+		ctx.HTML(errorStatus(err), "error.html", gin.H{
+			"error": "Материал не найден: " + err.Error(),
+		})
+		return
+	}
+
+	criteria, err := query.ToCriteria(id)
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректные параметры фильтра: " + err.Error(),
+		})
+		return
+	}
+
+	list, err := c.movementUseCase.GetMovements(criteria)
+	if err != nil {
+		ctx.HTML(listErrorStatus(err), "error.html", gin.H{
+			"error": "Ошибка получения движений материала: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.HTML(status, "material_history.html", gin.H{
+		"title":         "История движения материала " + material.Name,
+		"material":      material,
+		"movements":     dto.FromMaterialMovements(list.Items),
+		"filter":        query,
+		"pagination":    dto.NewPageLinks("/materials/"+strconv.Itoa(id)+"/history", query.Values(), list.PageInfo),
+		"movementTypes": []entities.MovementType{entities.MovementIncome, entities.MovementConsumption, entities.MovementWriteOff, entities.MovementReserve, entities.MovementRelease},
+		"postableTypes": []entities.MovementType{entities.MovementIncome, entities.MovementConsumption, entities.MovementWriteOff},
+		"references":    []entities.MovementReference{entities.ReferenceSupply, entities.ReferenceOrder, entities.ReferenceWriteOff},
+		"error":         formError,
+		"costWarning":   ctx.Query("cost_warning") != "",
+	})
+}
@@ -0,0 +1,195 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MovementControllerTestSuite struct {
+	suite.Suite
+	movementUseCase *mocks.MockMaterialMovementUseCase
+	materialUseCase *mocks.MockMaterialUseCase
+	controller      *MovementController
+	router          *gin.Engine
+}
+
+func (suite *MovementControllerTestSuite) SetupTest() {
+	suite.movementUseCase = new(mocks.MockMaterialMovementUseCase)
+	suite.materialUseCase = new(mocks.MockMaterialUseCase)
+	suite.controller = NewMovementController(suite.movementUseCase, suite.materialUseCase)
+
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+
+	v1 := suite.router.Group("/api/v1")
+	{
+		v1.GET("/materials/:id/movements", suite.controller.GetMaterialMovements)
+		v1.POST("/materials/:id/movements", suite.controller.PostMaterialMovement)
+	}
+	suite.router.POST("/materials/:id/history", suite.controller.PostMaterialMovementWeb)
+}
+
+func (suite *MovementControllerTestSuite) TestGetMaterialMovements_Paged() {
+	// Настройка мока
+	suite.movementUseCase.On("GetMovements", entities.MovementCriteria{
+		MaterialID: 3,
+		Type:       entities.MovementIncome,
+		Pagination: entities.Pagination{Page: 2, PageSize: 10},
+	}).Return(&entities.MovementList{
+		Items: []entities.MaterialMovement{
+			{ID: 7, MaterialID: 3, Type: entities.MovementIncome, Quantity: 2.5, RemainingQuantity: 12.5},
+		},
+		PageInfo: entities.PageInfo{Page: 2, PageSize: 10, Total: 11},
+	}, nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/materials/3/movements?movement_type=income&page=2&page_size=10", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response struct {
+		Data []struct {
+			MovementTypeLabel string  `json:"movement_type_label"`
+			Delta             float64 `json:"delta"`
+			RemainingQuantity float64 `json:"remaining_quantity"`
+		} `json:"data"`
+		Pagination struct {
+			TotalPages int `json:"total_pages"`
+		} `json:"pagination"`
+	}
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(suite.T(), response.Data, 1)
+	assert.Equal(suite.T(), "Приход", response.Data[0].MovementTypeLabel)
+	assert.Equal(suite.T(), 2.5, response.Data[0].Delta)
+	assert.Equal(suite.T(), 12.5, response.Data[0].RemainingQuantity)
+	assert.Equal(suite.T(), 2, response.Pagination.TotalPages)
+}
+
+func (suite *MovementControllerTestSuite) TestPostMaterialMovement_WriteOff() {
+	// Настройка мока
+	suite.movementUseCase.On("PostMovement", mock.MatchedBy(func(m *entities.MaterialMovement) bool {
+		return m.MaterialID == 3 && m.Type == entities.MovementWriteOff && m.Quantity == 1.5 &&
+			m.ReferenceType == entities.ReferenceWriteOff && m.ReferenceID != nil && *m.ReferenceID == 17 &&
+			m.Note != nil && *m.Note == "Брак партии"
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*entities.MaterialMovement).RemainingQuantity = 8.5
+	}).Return(nil)
+
+	// Выполнение запроса
+	body := `{"movement_type": "write_off", "quantity": 1.5, "reference_type": "write_off", "reference_id": 17, "note": " Брак партии "}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/materials/3/movements", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"delta":-1.5`)
+	assert.Contains(suite.T(), w.Body.String(), `"remaining_quantity":8.5`)
+	suite.movementUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *MovementControllerTestSuite) TestPostMaterialMovement_InsufficientStock() {
+	// Настройка мока
+	suite.movementUseCase.On("PostMovement", mock.Anything).
+		Return(entities.NewBusinessError("INSUFFICIENT_STOCK", "недостаточно материала на складе: остаток 1, требуется 5"))
+
+	// Выполнение запроса
+	body := `{"movement_type": "consumption", "quantity": 5}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/materials/3/movements", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	// Нарушение бизнес-правила - конфликт с состоянием данных, а не ошибка запроса
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"success":false`)
+	assert.Contains(suite.T(), w.Body.String(), "недостаточно материала")
+	suite.movementUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *MovementControllerTestSuite) TestPostMaterialMovement_MissingQuantity() {
+	// Выполнение запроса
+	body := `{"movement_type": "income"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/materials/3/movements", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.movementUseCase.AssertNotCalled(suite.T(), "PostMovement", mock.Anything)
+}
+
+func (suite *MovementControllerTestSuite) TestPostMaterialMovementWeb_EmptyReference() {
+	// Настройка мока: пустой номер документа в форме означает движение без документа
+	suite.movementUseCase.On("PostMovement", mock.MatchedBy(func(m *entities.MaterialMovement) bool {
+		return m.Type == entities.MovementIncome && m.Quantity == 4 && m.ReferenceID == nil && m.Note == nil
+	})).Return(nil)
+
+	// Выполнение запроса
+	form := "movement_type=income&quantity=4&reference_type=&reference_id=&note="
+	req := httptest.NewRequest(http.MethodPost, "/materials/3/history", strings.NewReader(form))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusFound, w.Code)
+	assert.Equal(suite.T(), "/materials/3/history", w.Header().Get("Location"))
+	suite.movementUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *MovementControllerTestSuite) TestPostMaterialMovement_CostWarning() {
+	// Настройка мока: движение проведено, но пересчет себестоимости продукции не удался
+	suite.movementUseCase.On("PostMovement", mock.Anything).Return(
+		entities.NewCostRecalculationError("движение проведено", errors.New("рецептура не загружена")))
+
+	// Выполнение запроса
+	body := `{"movement_type": "consumption", "quantity": 2}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/materials/3/movements", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"warning":"движение проведено, но себестоимость продукции не пересчитана`)
+}
+
+func (suite *MovementControllerTestSuite) TestPostMaterialMovementWeb_CostWarning() {
+	// Настройка мока
+	suite.movementUseCase.On("PostMovement", mock.Anything).Return(
+		entities.NewCostRecalculationError("движение проведено", errors.New("рецептура не загружена")))
+
+	// Выполнение запроса
+	form := "movement_type=write_off&quantity=1"
+	req := httptest.NewRequest(http.MethodPost, "/materials/3/history", strings.NewReader(form))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusFound, w.Code)
+	assert.Equal(suite.T(), "/materials/3/history?cost_warning=1", w.Header().Get("Location"))
+}
+
+func TestMovementControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(MovementControllerTestSuite))
+}
@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+
+	"wallpaper-system/internal/adapters/controllers/dto"
+	"wallpaper-system/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrderController обрабатывает HTTP запросы смены статусов заказов и резервов материалов
+type OrderController struct {
+	orderUseCase       usecases.OrderUseCaseInterface
+	certificateUseCase usecases.CertificateUseCaseInterface
+}
+
+// NewOrderController создает новый контроллер заказов
+func NewOrderController(
+	orderUseCase usecases.OrderUseCaseInterface,
+	certificateUseCase usecases.CertificateUseCaseInterface,
+) *OrderController {
+	return &OrderController{
+		orderUseCase:       orderUseCase,
+		certificateUseCase: certificateUseCase,
+	}
+}
+
+// GetOrder возвращает заказ по ID
+func (c *OrderController) GetOrder(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID заказа"))
+		return
+	}
+
+	order, err := c.orderUseCase.GetOrder(id)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Заказ получен", dto.FromOrder(order)))
+}
+
+// ChangeOrderStatus переводит заказ в новый статус: при подтверждении материалы
+// резервируются, при отмене резерв снимается, при запуске в производство списывается
+func (c *OrderController) ChangeOrderStatus(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID заказа"))
+		return
+	}
+
+	var request dto.OrderStatusRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса: "+err.Error()))
+		return
+	}
+
+	order, err := c.orderUseCase.ChangeStatus(id, request.ToStatus())
+	costWarning, hasCostWarning := costRecalculationWarning(err)
+	if err != nil && !hasCostWarning {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	warnings := []string{}
+	if hasCostWarning {
+		warnings = append(warnings, costWarning)
+	}
+	certificateWarnings, certificateWarning := c.checkCertificates(order)
+	if certificateWarning != "" {
+		warnings = append(warnings, certificateWarning)
+	}
+
+	data := dto.FromOrderStatusResult(order, certificateWarnings)
+	if len(warnings) > 0 {
+		ctx.JSON(http.StatusOK, dto.NewWarningResponse("Статус заказа изменен", strings.Join(warnings, "; "), data))
+		return
+	}
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Статус заказа изменен", data))
+}
+
+// checkCertificates проверяет сертификацию продукции заказа после смены статуса и
+// возвращает предупреждения с их сводкой. Для отмененного заказа продажи нет и проверка
+// не выполняется. Ошибка проверки не отменяет смену статуса и попадает в сводку
+func (c *OrderController) checkCertificates(order *entities.Order) ([]entities.CertificateWarning, string) {
+	if order.Status == entities.OrderCancelled {
+		return []entities.CertificateWarning{}, ""
+	}
+
+	warnings, err := c.certificateUseCase.CheckOrder(order.ID, time.Now())
+	if err != nil {
+		return []entities.CertificateWarning{}, "не удалось проверить сертификаты продукции: " + err.Error()
+	}
+	if len(warnings) == 0 {
+		return warnings, ""
+	}
+	return warnings, certificateCheckMessage(warnings)
+}
+
+// GetOrderReservations возвращает резервы материалов заказа
+func (c *OrderController) GetOrderReservations(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID заказа"))
+		return
+	}
+
+	reservations, err := c.orderUseCase.GetReservations(id)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Резервы заказа получены", dto.FromMaterialReservations(reservations)))
+}
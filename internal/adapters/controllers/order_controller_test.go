@@ -0,0 +1,200 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type OrderControllerTestSuite struct {
+	suite.Suite
+	orderUseCase       *mocks.MockOrderUseCase
+	certificateUseCase *mocks.MockCertificateUseCase
+	controller         *OrderController
+	router             *gin.Engine
+}
+
+func (suite *OrderControllerTestSuite) SetupTest() {
+	suite.orderUseCase = new(mocks.MockOrderUseCase)
+	suite.certificateUseCase = new(mocks.MockCertificateUseCase)
+	suite.controller = NewOrderController(suite.orderUseCase, suite.certificateUseCase)
+
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+
+	v1 := suite.router.Group("/api/v1")
+	{
+		v1.GET("/orders/:id", suite.controller.GetOrder)
+		v1.PUT("/orders/:id/status", suite.controller.ChangeOrderStatus)
+		v1.GET("/orders/:id/reservations", suite.controller.GetOrderReservations)
+	}
+}
+
+func (suite *OrderControllerTestSuite) TestChangeOrderStatus_Confirmed() {
+	// Настройка мока
+	suite.orderUseCase.On("ChangeStatus", 5, entities.OrderConfirmed).
+		Return(&entities.Order{ID: 5, PartnerID: 1, Status: entities.OrderConfirmed, TotalAmount: 1000}, nil)
+	suite.certificateUseCase.On("CheckOrder", 5, mock.AnythingOfType("time.Time")).
+		Return([]entities.CertificateWarning{}, nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/orders/5/status", strings.NewReader(`{"status": "confirmed"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"status":"confirmed"`)
+	assert.Contains(suite.T(), w.Body.String(), `"status_label":"Подтвержден"`)
+	assert.Contains(suite.T(), w.Body.String(), `"certificate_warnings":[]`)
+	assert.NotContains(suite.T(), w.Body.String(), `"warning"`)
+	suite.orderUseCase.AssertExpectations(suite.T())
+	suite.certificateUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *OrderControllerTestSuite) TestChangeOrderStatus_CertificateWarnings() {
+	// Настройка мока: в подтвержденном заказе есть продукция без действующего сертификата
+	suite.orderUseCase.On("ChangeStatus", 5, entities.OrderConfirmed).
+		Return(&entities.Order{ID: 5, PartnerID: 1, Status: entities.OrderConfirmed, TotalAmount: 1000}, nil)
+	suite.certificateUseCase.On("CheckOrder", 5, mock.AnythingOfType("time.Time")).
+		Return([]entities.CertificateWarning{{
+			Product: entities.CertificateProduct{ID: 1, Article: "WP-001", Name: "Обои"},
+			Reason:  entities.CertificateWarningMissing,
+			Message: "на продукцию WP-001 нет сертификата",
+		}}, nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/orders/5/status", strings.NewReader(`{"status": "confirmed"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"status":"confirmed"`)
+	assert.Contains(suite.T(), w.Body.String(), `"warning":"Продукция без действующего сертификата: 1"`)
+	assert.Contains(suite.T(), w.Body.String(), `"reason":"missing"`)
+	assert.Contains(suite.T(), w.Body.String(), `"message":"на продукцию WP-001 нет сертификата"`)
+}
+
+func (suite *OrderControllerTestSuite) TestChangeOrderStatus_CancelledSkipsCertificateCheck() {
+	// Настройка мока
+	suite.orderUseCase.On("ChangeStatus", 5, entities.OrderCancelled).
+		Return(&entities.Order{ID: 5, PartnerID: 1, Status: entities.OrderCancelled, TotalAmount: 1000}, nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/orders/5/status", strings.NewReader(`{"status": "cancelled"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"status":"cancelled"`)
+	suite.certificateUseCase.AssertNotCalled(suite.T(), "CheckOrder", mock.Anything, mock.Anything)
+}
+
+func (suite *OrderControllerTestSuite) TestChangeOrderStatus_InsufficientAvailable() {
+	// Настройка мока
+	suite.orderUseCase.On("ChangeStatus", 5, entities.OrderConfirmed).Return(nil,
+		entities.NewInsufficientAvailableError([]entities.ReservationShortage{{Article: "VIN-01", Required: 12.5, Available: 3}}))
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/orders/5/status", strings.NewReader(`{"status": "confirmed"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	// Нарушение бизнес-правила - конфликт с состоянием данных, а не ошибка запроса
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"success":false`)
+	assert.Contains(suite.T(), w.Body.String(), "VIN-01")
+	suite.orderUseCase.AssertExpectations(suite.T())
+	suite.certificateUseCase.AssertNotCalled(suite.T(), "CheckOrder", mock.Anything, mock.Anything)
+}
+
+func (suite *OrderControllerTestSuite) TestChangeOrderStatus_CostWarning() {
+	// Настройка мока: материалы списаны в производство, но пересчет себестоимости продукции не удался
+	suite.orderUseCase.On("ChangeStatus", 5, entities.OrderInProduction).
+		Return(&entities.Order{ID: 5, PartnerID: 1, Status: entities.OrderInProduction, TotalAmount: 1000},
+			entities.NewCostRecalculationError("статус заказа изменен", errors.New("рецептура не загружена")))
+	suite.certificateUseCase.On("CheckOrder", 5, mock.AnythingOfType("time.Time")).
+		Return([]entities.CertificateWarning{}, nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/orders/5/status", strings.NewReader(`{"status": "in_production"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"status":"in_production"`)
+	assert.Contains(suite.T(), w.Body.String(), `"warning":"статус заказа изменен, но себестоимость продукции не пересчитана`)
+}
+
+func (suite *OrderControllerTestSuite) TestChangeOrderStatus_MissingStatus() {
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/orders/5/status", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.orderUseCase.AssertNotCalled(suite.T(), "ChangeStatus", mock.Anything, mock.Anything)
+}
+
+func (suite *OrderControllerTestSuite) TestGetOrderReservations() {
+	// Подготовка данных
+	reservations := []entities.MaterialReservation{{
+		ID: 1, OrderID: 5, MaterialID: 7, Quantity: 14.75, Status: entities.ReservationActive,
+		CreatedAt: time.Date(2026, time.October, 1, 9, 0, 0, 0, time.UTC),
+		Material: &entities.Material{ID: 7, Article: "VIN-01", Name: "Винил",
+			MeasurementUnit: &entities.MeasurementUnit{Abbreviation: "м²"}},
+	}}
+
+	// Настройка мока
+	suite.orderUseCase.On("GetReservations", 5).Return(reservations, nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/5/reservations", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"article":"VIN-01"`)
+	assert.Contains(suite.T(), w.Body.String(), `"quantity":14.75`)
+	assert.Contains(suite.T(), w.Body.String(), `"status_label":"Активен"`)
+}
+
+func (suite *OrderControllerTestSuite) TestGetOrder_NotFound() {
+	// Настройка мока
+	suite.orderUseCase.On("GetOrder", 99).Return(nil, entities.NewNotFoundError("заказ", "99"))
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/99", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+func TestOrderControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(OrderControllerTestSuite))
+}
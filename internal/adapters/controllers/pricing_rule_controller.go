@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"wallpaper-system/internal/adapters/controllers/dto"
+	"wallpaper-system/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PricingRuleController обрабатывает HTTP запросы для правил ценообразования
+type PricingRuleController struct {
+	pricingRuleUseCase usecases.PricingRuleUseCaseInterface
+}
+
+// NewPricingRuleController создает новый контроллер правил ценообразования
+func NewPricingRuleController(pricingRuleUseCase usecases.PricingRuleUseCaseInterface) *PricingRuleController {
+	return &PricingRuleController{
+		pricingRuleUseCase: pricingRuleUseCase,
+	}
+}
+
+// GetPricingRules возвращает список правил ценообразования через API
+func (c *PricingRuleController) GetPricingRules(ctx *gin.Context) {
+	rules, err := c.pricingRuleUseCase.GetAllRules()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Правила ценообразования получены", dto.FromPricingRuleEntities(rules))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetPricingRuleByID возвращает правило ценообразования по ID через API
+func (c *PricingRuleController) GetPricingRuleByID(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID правила"))
+		return
+	}
+
+	rule, err := c.pricingRuleUseCase.GetRuleByID(id)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Правило ценообразования получено", dto.FromPricingRuleEntity(rule))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// CreatePricingRule создает правило ценообразования через API
+func (c *PricingRuleController) CreatePricingRule(ctx *gin.Context) {
+	var request dto.PricingRuleRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса: "+err.Error()))
+		return
+	}
+
+	rule, err := request.ToEntity()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	if err := c.pricingRuleUseCase.CreateRule(rule); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Правило ценообразования создано", dto.FromPricingRuleEntity(rule))
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// UpdatePricingRule обновляет правило ценообразования через API
+func (c *PricingRuleController) UpdatePricingRule(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID правила"))
+		return
+	}
+
+	var request dto.PricingRuleRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса: "+err.Error()))
+		return
+	}
+
+	rule, err := request.ToEntity()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(err.Error()))
+		return
+	}
+	rule.ID = id
+
+	if err := c.pricingRuleUseCase.UpdateRule(rule); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Правило ценообразования обновлено", dto.FromPricingRuleEntity(rule))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// DeletePricingRule удаляет правило ценообразования через API
+func (c *PricingRuleController) DeletePricingRule(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID правила"))
+		return
+	}
+
+	if err := c.pricingRuleUseCase.DeleteRule(id); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Правило ценообразования удалено", nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetPartnerTypes возвращает типы партнеров через API
+func (c *PricingRuleController) GetPartnerTypes(ctx *gin.Context) {
+	partnerTypes, err := c.pricingRuleUseCase.GetPartnerTypes()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": dto.FromPartnerTypeEntities(partnerTypes),
+	})
+}
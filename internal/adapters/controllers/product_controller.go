@@ -1,10 +1,13 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"wallpaper-system/internal/adapters/controllers/dto"
+	"wallpaper-system/internal/domain/entities"
 	"wallpaper-system/internal/usecases"
 
 	"github.com/gin-gonic/gin"
@@ -14,38 +17,64 @@ import (
 type ProductController struct {
 	productUseCase  usecases.ProductUseCaseInterface
 	materialUseCase usecases.MaterialUseCaseInterface
+	unitUseCase     usecases.UnitConversionUseCaseInterface
 }
 
 // NewProductController создает новый контроллер продукции
 func NewProductController(
 	productUseCase usecases.ProductUseCaseInterface,
 	materialUseCase usecases.MaterialUseCaseInterface,
+	unitUseCase usecases.UnitConversionUseCaseInterface,
 ) *ProductController {
 	return &ProductController{
 		productUseCase:  productUseCase,
 		materialUseCase: materialUseCase,
+		unitUseCase:     unitUseCase,
 	}
 }
 
-// GetProductsPage отображает страницу со списком продукции
+// GetProductsPage отображает страницу со списком продукции с фильтрами и пагинацией
 func (c *ProductController) GetProductsPage(ctx *gin.Context) {
-	products, err := c.productUseCase.GetAllProducts()
+	var query dto.ProductListQuery
+	_ = ctx.ShouldBindQuery(&query)
+
+	criteria, err := query.ToCriteria()
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректные параметры фильтра: " + err.Error(),
+		})
+		return
+	}
+
+	list, err := c.productUseCase.FindProducts(criteria)
+	if err != nil {
+		ctx.HTML(listErrorStatus(err), "error.html", gin.H{
+			"error": "Ошибка получения списка продукции: " + err.Error(),
+		})
+		return
+	}
+
+	productTypes, err := c.productUseCase.GetProductTypes()
 	if err != nil {
 		ctx.HTML(http.StatusInternalServerError, "error.html", gin.H{
-			"error": "Ошибка получения списка продукции",
+			"error": "Ошибка получения типов продукции",
 		})
 		return
 	}
 
 	// Преобразуем в DTO для отображения
-	productDTOs := make([]dto.ProductListItemDTO, len(products))
-	for i, product := range products {
+	productDTOs := make([]dto.ProductListItemDTO, len(list.Items))
+	for i, product := range list.Items {
 		productDTOs[i] = dto.FromProductEntity(&product)
 	}
 
 	ctx.HTML(http.StatusOK, "products.html", gin.H{
-		"title":    "Список продукции",
-		"products": productDTOs,
+		"title":        "Список продукции",
+		"products":     productDTOs,
+		"productTypes": productTypes,
+		"filter":       query,
+		"pagination":   dto.NewPageLinks("/products", query.Values(), list.PageInfo),
+		"exportLinks":  dto.ExportLinks("/api/v1/products/export", query.Values()),
 	})
 }
 
@@ -79,28 +108,42 @@ func (c *ProductController) GetProductDetailsPage(ctx *gin.Context) {
 	productDTO := dto.FromProductEntityWithMaterials(product, materials)
 
 	ctx.HTML(http.StatusOK, "product_detail.html", gin.H{
-		"title":     "Детали продукции",
-		"product":   productDTO,
-		"materials": materials,
+		"title":       "Детали продукции",
+		"product":     productDTO,
+		"materials":   materials,
+		"costWarning": ctx.Query("cost_warning") != "",
 	})
 }
 
-// GetProducts возвращает список продукции в JSON
+// GetProducts возвращает страницу списка продукции в JSON.
+// Поддерживает фильтры product_type_id, min_price, max_price, article,
+// сортировку sort/order и пагинацию page/page_size.
 func (c *ProductController) GetProducts(ctx *gin.Context) {
-	products, err := c.productUseCase.GetAllProducts()
+	var query dto.ProductListQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные параметры запроса: "+err.Error()))
+		return
+	}
+
+	criteria, err := query.ToCriteria()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	list, err := c.productUseCase.FindProducts(criteria)
 	if err != nil {
-		response := dto.NewErrorResponse("Ошибка получения списка продукции")
-		ctx.JSON(http.StatusInternalServerError, response)
+		ctx.JSON(listErrorStatus(err), dto.NewErrorResponse(err.Error()))
 		return
 	}
 
 	// Преобразуем в DTO
-	productDTOs := make([]dto.ProductListItemDTO, len(products))
-	for i, product := range products {
+	productDTOs := make([]dto.ProductListItemDTO, len(list.Items))
+	for i, product := range list.Items {
 		productDTOs[i] = dto.FromProductEntity(&product)
 	}
 
-	response := dto.NewSuccessResponse("Список продукции получен", productDTOs)
+	response := dto.NewPagedResponse("Список продукции получен", productDTOs, list.PageInfo)
 	ctx.JSON(http.StatusOK, response)
 }
 
@@ -170,6 +213,13 @@ func (c *ProductController) UpdateProduct(ctx *gin.Context) {
 	product := request.ToEntity(id)
 
 	err = c.productUseCase.UpdateProduct(product)
+	if warning, ok := costRecalculationWarning(err); ok {
+		ctx.JSON(http.StatusOK, gin.H{
+			"message": "Продукция успешно обновлена",
+			"warning": warning,
+		})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
@@ -182,8 +232,36 @@ func (c *ProductController) UpdateProduct(ctx *gin.Context) {
 	})
 }
 
-// DeleteProduct удаляет продукцию
-func (c *ProductController) DeleteProduct(ctx *gin.Context) {
+// CloneProduct копирует продукцию вместе с рецептурой под новым артикулом
+func (c *ProductController) CloneProduct(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID продукции"))
+		return
+	}
+
+	var request dto.CloneProductRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса"))
+		return
+	}
+
+	clone, err := c.productUseCase.CloneProduct(id, request.ToEntity())
+	if warning, ok := costRecalculationWarning(err); ok {
+		ctx.JSON(http.StatusCreated, dto.NewWarningResponse("Продукция скопирована", warning, dto.FromProductEntityWithMaterials(clone, nil)))
+		return
+	}
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Продукция скопирована", dto.FromProductEntityWithMaterials(clone, nil))
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// ArchiveProduct переносит продукцию в архив (DELETE /api/v1/products/:id)
+func (c *ProductController) ArchiveProduct(ctx *gin.Context) {
 	id, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
 		response := dto.NewErrorResponse("Некорректный ID продукции")
@@ -191,17 +269,95 @@ func (c *ProductController) DeleteProduct(ctx *gin.Context) {
 		return
 	}
 
-	err = c.productUseCase.DeleteProduct(id)
+	if err := c.productUseCase.ArchiveProduct(id); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Продукция перенесена в архив", nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+// RestoreProduct возвращает продукцию из архива
+func (c *ProductController) RestoreProduct(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
-		response := dto.NewErrorResponse(err.Error())
-		ctx.JSON(http.StatusNotFound, response) // Изменил на 404 если продукция не найдена
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID продукции"))
+		return
+	}
+
+	if err := c.productUseCase.RestoreProduct(id); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
 		return
 	}
 
-	response := dto.NewSuccessResponse("Продукция успешно удалена", nil)
+	response := dto.NewSuccessResponse("Продукция восстановлена из архива", nil)
 	ctx.JSON(http.StatusOK, response)
 }
 
+// CloneProductWeb копирует продукцию через веб-форму и открывает карточку копии
+func (c *ProductController) CloneProductWeb(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID продукции",
+		})
+		return
+	}
+
+	var request dto.CloneProductRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректные данные формы: " + err.Error(),
+		})
+		return
+	}
+
+	clone, err := c.productUseCase.CloneProduct(id, request.ToEntity())
+	if _, ok := costRecalculationWarning(err); ok {
+		// Копия создана, предупреждение о пересчете показывается на ее карточке
+		ctx.Redirect(http.StatusFound, "/products/"+strconv.Itoa(clone.ID)+"?cost_warning=1")
+		return
+	}
+	if err != nil {
+		ctx.HTML(errorStatus(err), "error.html", gin.H{
+			"error": "Ошибка копирования продукции: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/products/"+strconv.Itoa(clone.ID))
+}
+
+// ArchiveProductWeb переносит продукцию в архив через веб-форму
+func (c *ProductController) ArchiveProductWeb(ctx *gin.Context) {
+	c.changeArchiveStateWeb(ctx, c.productUseCase.ArchiveProduct, "Ошибка переноса в архив: ")
+}
+
+// RestoreProductWeb возвращает продукцию из архива через веб-форму
+func (c *ProductController) RestoreProductWeb(ctx *gin.Context) {
+	c.changeArchiveStateWeb(ctx, c.productUseCase.RestoreProduct, "Ошибка восстановления из архива: ")
+}
+
+func (c *ProductController) changeArchiveStateWeb(ctx *gin.Context, action func(id int) error, errorPrefix string) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID продукции",
+		})
+		return
+	}
+
+	if err := action(id); err != nil {
+		ctx.HTML(errorStatus(err), "error.html", gin.H{
+			"error": errorPrefix + err.Error(),
+		})
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/products/"+strconv.Itoa(id))
+}
+
 // GetCreateProductPage отображает форму создания новой продукции
 func (c *ProductController) GetCreateProductPage(ctx *gin.Context) {
 	// Получаем типы продукции для выпадающего списка
@@ -291,8 +447,34 @@ func (c *ProductController) GetEditProductPage(ctx *gin.Context) {
 		return
 	}
 
+	// Получаем материалы для формы рецептуры
+	materials, err := c.materialUseCase.GetAllMaterials()
+	if err != nil {
+		ctx.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "Ошибка загрузки материалов: " + err.Error(),
+		})
+		return
+	}
+
+	// Получаем продукцию для выбора полуфабрикатов
+	allProducts, err := c.productUseCase.GetAllProducts()
+	if err != nil {
+		ctx.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "Ошибка загрузки продукции: " + err.Error(),
+		})
+		return
+	}
+
+	// Исключаем саму продукцию из списка возможных полуфабрикатов
+	componentCandidates := make([]entities.Product, 0, len(allProducts))
+	for _, candidate := range allProducts {
+		if candidate.ID != id {
+			componentCandidates = append(componentCandidates, candidate)
+		}
+	}
+
 	// Преобразуем в DTO для отображения в форме
-	productDTO := dto.FromProductEntity(product)
+	productDTO := dto.FromProductEntityWithMaterials(product, nil)
 
 	ctx.HTML(http.StatusOK, "product_form.html", gin.H{
 		"title":        "Редактирование продукции",
@@ -300,6 +482,11 @@ func (c *ProductController) GetEditProductPage(ctx *gin.Context) {
 		"formAction":   "/products/" + strconv.Itoa(id),
 		"product":      productDTO,
 		"productTypes": productTypes,
+		"recipe":       dto.FromProductMaterialEntities(product.Materials),
+		"materials":    materials,
+		"components":   dto.FromProductComponentEntities(product.Components),
+		"candidates":   componentCandidates,
+		"costWarning":  ctx.Query("cost_warning") != "",
 	})
 }
 
@@ -315,17 +502,15 @@ func (c *ProductController) UpdateProductWeb(ctx *gin.Context) {
 
 	var request dto.UpdateProductRequest
 	if err := ctx.ShouldBind(&request); err != nil {
-		// Получаем продукцию и типы продукции для формы в случае ошибки
-		product, _ := c.productUseCase.GetProductByID(id)
+		// Получаем типы продукции для формы в случае ошибки; введенные данные возвращаем в форму
 		productTypes, _ := c.productUseCase.GetProductTypes()
-		productDTO := dto.FromProductEntity(product)
-		
+
 		ctx.HTML(http.StatusBadRequest, "product_form.html", gin.H{
 			"title":        "Редактирование продукции",
 			"isEdit":       true,
 			"formAction":   "/products/" + strconv.Itoa(id),
 			"error":        "Некорректные данные формы: " + err.Error(),
-			"product":      productDTO,
+			"product":      request,
 			"productTypes": productTypes,
 		})
 		return
@@ -335,18 +520,21 @@ func (c *ProductController) UpdateProductWeb(ctx *gin.Context) {
 	product := request.ToEntity(id)
 
 	err = c.productUseCase.UpdateProduct(product)
+	if _, ok := costRecalculationWarning(err); ok {
+		// Продукция сохранена, предупреждение о пересчете показывается на странице продукции
+		ctx.Redirect(http.StatusFound, "/products/"+strconv.Itoa(id)+"?cost_warning=1")
+		return
+	}
 	if err != nil {
-		// Получаем продукцию и типы продукции для формы в случае ошибки
-		existingProduct, _ := c.productUseCase.GetProductByID(id)
+		// Получаем типы продукции для формы в случае ошибки; введенные данные возвращаем в форму
 		productTypes, _ := c.productUseCase.GetProductTypes()
-		productDTO := dto.FromProductEntity(existingProduct)
-		
+
 		ctx.HTML(http.StatusBadRequest, "product_form.html", gin.H{
 			"title":        "Редактирование продукции",
 			"isEdit":       true,
 			"formAction":   "/products/" + strconv.Itoa(id),
 			"error":        "Ошибка обновления продукции: " + err.Error(),
-			"product":      productDTO,
+			"product":      request,
 			"productTypes": productTypes,
 		})
 		return
@@ -356,17 +544,635 @@ func (c *ProductController) UpdateProductWeb(ctx *gin.Context) {
 	ctx.Redirect(http.StatusFound, "/products/"+strconv.Itoa(id))
 }
 
-// GetProductTypes возвращает список типов продукции через API
-func (c *ProductController) GetProductTypes(ctx *gin.Context) {
-	productTypes, err := c.productUseCase.GetProductTypes()
+// GetProductMaterials возвращает рецептуру продукции через API. С параметром ?unit= расход
+// материалов, которые можно выразить в этой единице, пересчитывается в нее
+func (c *ProductController) GetProductMaterials(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID продукции"))
+		return
+	}
+
+	materials, err := c.productUseCase.GetProductMaterials(id)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	materials, err = c.unitUseCase.ExpressProductMaterials(materials, ctx.Query("unit"))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Ошибка получения типов продукции: " + err.Error(),
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Рецептура получена", dto.FromProductMaterialEntities(materials))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// AddProductMaterial добавляет материал в рецептуру продукции через API
+func (c *ProductController) AddProductMaterial(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID продукции"))
+		return
+	}
+
+	var request dto.ProductMaterialRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса"))
+		return
+	}
+
+	product, err := c.productUseCase.AddProductMaterial(id, request.ToEntity())
+	if warning, ok := costRecalculationWarning(err); ok {
+		ctx.JSON(http.StatusCreated, dto.NewWarningResponse("Материал добавлен в рецептуру", warning, dto.FromProductEntityWithMaterials(product, nil)))
+		return
+	}
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Материал добавлен в рецептуру", dto.FromProductEntityWithMaterials(product, nil))
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// UpdateProductMaterial изменяет расход материала в рецептуре через API
+func (c *ProductController) UpdateProductMaterial(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID продукции"))
+		return
+	}
+
+	materialID, err := strconv.Atoi(ctx.Param("material_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID материала"))
+		return
+	}
+
+	var request dto.UpdateProductMaterialRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса"))
+		return
+	}
+
+	product, err := c.productUseCase.UpdateProductMaterial(id, request.ToEntity(materialID))
+	if warning, ok := costRecalculationWarning(err); ok {
+		ctx.JSON(http.StatusOK, dto.NewWarningResponse("Рецептура обновлена", warning, dto.FromProductEntityWithMaterials(product, nil)))
+		return
+	}
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Рецептура обновлена", dto.FromProductEntityWithMaterials(product, nil))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// RemoveProductMaterial удаляет материал из рецептуры через API
+func (c *ProductController) RemoveProductMaterial(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID продукции"))
+		return
+	}
+
+	materialID, err := strconv.Atoi(ctx.Param("material_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID материала"))
+		return
+	}
+
+	product, err := c.productUseCase.RemoveProductMaterial(id, materialID)
+	if warning, ok := costRecalculationWarning(err); ok {
+		ctx.JSON(http.StatusOK, dto.NewWarningResponse("Материал удален из рецептуры", warning, dto.FromProductEntityWithMaterials(product, nil)))
+		return
+	}
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Материал удален из рецептуры", dto.FromProductEntityWithMaterials(product, nil))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// ReplaceProductMaterials заменяет всю рецептуру продукции через API
+func (c *ProductController) ReplaceProductMaterials(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID продукции"))
+		return
+	}
+
+	var request dto.ReplaceProductMaterialsRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса"))
+		return
+	}
+
+	product, err := c.productUseCase.ReplaceProductMaterials(id, request.ToEntities())
+	if warning, ok := costRecalculationWarning(err); ok {
+		ctx.JSON(http.StatusOK, dto.NewWarningResponse("Рецептура заменена", warning, dto.FromProductEntityWithMaterials(product, nil)))
+		return
+	}
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Рецептура заменена", dto.FromProductEntityWithMaterials(product, nil))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// AddProductMaterialWeb добавляет материал в рецептуру через веб-форму
+func (c *ProductController) AddProductMaterialWeb(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID продукции",
 		})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"data": productTypes,
-	})
+	var request dto.ProductMaterialRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректные данные формы: " + err.Error(),
+		})
+		return
+	}
+
+	_, err = c.productUseCase.AddProductMaterial(id, request.ToEntity())
+	if _, ok := costRecalculationWarning(err); ok {
+		// Рецептура изменена, предупреждение о пересчете показывается в форме продукции
+		ctx.Redirect(http.StatusFound, "/products/"+strconv.Itoa(id)+"/edit?cost_warning=1#recipe")
+		return
+	}
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Ошибка добавления материала: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/products/"+strconv.Itoa(id)+"/edit#recipe")
+}
+
+// UpdateProductMaterialWeb изменяет расход материала через веб-форму
+func (c *ProductController) UpdateProductMaterialWeb(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID продукции",
+		})
+		return
+	}
+
+	materialID, err := strconv.Atoi(ctx.Param("material_id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID материала",
+		})
+		return
+	}
+
+	var request dto.UpdateProductMaterialRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректные данные формы: " + err.Error(),
+		})
+		return
+	}
+
+	_, err = c.productUseCase.UpdateProductMaterial(id, request.ToEntity(materialID))
+	if _, ok := costRecalculationWarning(err); ok {
+		// Рецептура изменена, предупреждение о пересчете показывается в форме продукции
+		ctx.Redirect(http.StatusFound, "/products/"+strconv.Itoa(id)+"/edit?cost_warning=1#recipe")
+		return
+	}
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Ошибка обновления рецептуры: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/products/"+strconv.Itoa(id)+"/edit#recipe")
+}
+
+// RemoveProductMaterialWeb удаляет материал из рецептуры через веб-форму
+func (c *ProductController) RemoveProductMaterialWeb(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID продукции",
+		})
+		return
+	}
+
+	materialID, err := strconv.Atoi(ctx.Param("material_id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID материала",
+		})
+		return
+	}
+
+	_, err = c.productUseCase.RemoveProductMaterial(id, materialID)
+	if _, ok := costRecalculationWarning(err); ok {
+		// Рецептура изменена, предупреждение о пересчете показывается в форме продукции
+		ctx.Redirect(http.StatusFound, "/products/"+strconv.Itoa(id)+"/edit?cost_warning=1#recipe")
+		return
+	}
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Ошибка удаления материала из рецептуры: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/products/"+strconv.Itoa(id)+"/edit#recipe")
+}
+
+// GetProductComponents возвращает полуфабрикаты в составе продукции через API
+func (c *ProductController) GetProductComponents(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID продукции"))
+		return
+	}
+
+	components, err := c.productUseCase.GetProductComponents(id)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Полуфабрикаты получены", dto.FromProductComponentEntities(components))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// AddProductComponent добавляет полуфабрикат в состав продукции через API
+func (c *ProductController) AddProductComponent(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID продукции"))
+		return
+	}
+
+	var request dto.ProductComponentRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса"))
+		return
+	}
+
+	product, err := c.productUseCase.AddProductComponent(id, request.ToEntity())
+	if warning, ok := costRecalculationWarning(err); ok {
+		ctx.JSON(http.StatusCreated, dto.NewWarningResponse("Полуфабрикат добавлен в состав", warning, dto.FromProductEntityWithMaterials(product, nil)))
+		return
+	}
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Полуфабрикат добавлен в состав", dto.FromProductEntityWithMaterials(product, nil))
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// UpdateProductComponent изменяет расход полуфабриката через API
+func (c *ProductController) UpdateProductComponent(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID продукции"))
+		return
+	}
+
+	componentID, err := strconv.Atoi(ctx.Param("component_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID полуфабриката"))
+		return
+	}
+
+	var request dto.UpdateProductComponentRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса"))
+		return
+	}
+
+	product, err := c.productUseCase.UpdateProductComponent(id, request.ToEntity(componentID))
+	if warning, ok := costRecalculationWarning(err); ok {
+		ctx.JSON(http.StatusOK, dto.NewWarningResponse("Состав обновлен", warning, dto.FromProductEntityWithMaterials(product, nil)))
+		return
+	}
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Состав обновлен", dto.FromProductEntityWithMaterials(product, nil))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// RemoveProductComponent удаляет полуфабрикат из состава продукции через API
+func (c *ProductController) RemoveProductComponent(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID продукции"))
+		return
+	}
+
+	componentID, err := strconv.Atoi(ctx.Param("component_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID полуфабриката"))
+		return
+	}
+
+	product, err := c.productUseCase.RemoveProductComponent(id, componentID)
+	if warning, ok := costRecalculationWarning(err); ok {
+		ctx.JSON(http.StatusOK, dto.NewWarningResponse("Полуфабрикат удален из состава", warning, dto.FromProductEntityWithMaterials(product, nil)))
+		return
+	}
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Полуфабрикат удален из состава", dto.FromProductEntityWithMaterials(product, nil))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetMaterialExplosion возвращает потребность в сырье на заданное количество продукции через API;
+// с параметром ?unit= потребность выражается в этой единице, где это возможно
+func (c *ProductController) GetMaterialExplosion(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID продукции"))
+		return
+	}
+
+	quantity, err := strconv.ParseFloat(ctx.DefaultQuery("quantity", "1"), 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректное количество продукции"))
+		return
+	}
+
+	requirements, err := c.productUseCase.ExplodeMaterials(id, quantity)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	requirements, err = c.unitUseCase.ExpressRequirements(requirements, ctx.Query("unit"))
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Потребность в сырье рассчитана", dto.FromMaterialRequirementEntities(requirements))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetProductPrice рассчитывает цену продукции по правилам ценообразования через API
+func (c *ProductController) GetProductPrice(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID продукции"))
+		return
+	}
+
+	var partnerTypeID *int
+	if value := ctx.Query("partner_type_id"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID типа партнера"))
+			return
+		}
+		partnerTypeID = &parsed
+	}
+
+	date := time.Now()
+	if value := ctx.Query("date"); value != "" {
+		date, err = time.Parse(dto.DateLayout, value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Дата должна быть в формате ГГГГ-ММ-ДД"))
+			return
+		}
+	}
+
+	calculation, err := c.productUseCase.CalculateProductPrice(id, partnerTypeID, date)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Цена рассчитана", dto.FromPriceCalculation(id, partnerTypeID, date, calculation))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// RecalculateCost пересчитывает и сохраняет себестоимость продукции через API
+func (c *ProductController) RecalculateCost(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID продукции"))
+		return
+	}
+
+	product, err := c.productUseCase.RecalculateCost(id)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Себестоимость пересчитана", dto.FromProductEntityWithMaterials(product, nil))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// RecalculateAllCosts пересчитывает себестоимость всей продукции через API
+func (c *ProductController) RecalculateAllCosts(ctx *gin.Context) {
+	count, err := c.productUseCase.RecalculateAllCosts()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Себестоимость пересчитана", gin.H{"recalculated": count})
+	ctx.JSON(http.StatusOK, response)
+}
+
+// RecalculateCostWeb пересчитывает себестоимость продукции через веб-форму
+func (c *ProductController) RecalculateCostWeb(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID продукции",
+		})
+		return
+	}
+
+	if _, err := c.productUseCase.RecalculateCost(id); err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Ошибка пересчета себестоимости: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/products/"+strconv.Itoa(id))
+}
+
+// AddProductComponentWeb добавляет полуфабрикат в состав через веб-форму
+func (c *ProductController) AddProductComponentWeb(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID продукции",
+		})
+		return
+	}
+
+	var request dto.ProductComponentRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректные данные формы: " + err.Error(),
+		})
+		return
+	}
+
+	_, err = c.productUseCase.AddProductComponent(id, request.ToEntity())
+	if _, ok := costRecalculationWarning(err); ok {
+		// Рецептура изменена, предупреждение о пересчете показывается в форме продукции
+		ctx.Redirect(http.StatusFound, "/products/"+strconv.Itoa(id)+"/edit?cost_warning=1#components")
+		return
+	}
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Ошибка добавления полуфабриката: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/products/"+strconv.Itoa(id)+"/edit#components")
+}
+
+// UpdateProductComponentWeb изменяет расход полуфабриката через веб-форму
+func (c *ProductController) UpdateProductComponentWeb(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID продукции",
+		})
+		return
+	}
+
+	componentID, err := strconv.Atoi(ctx.Param("component_id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID полуфабриката",
+		})
+		return
+	}
+
+	var request dto.UpdateProductComponentRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректные данные формы: " + err.Error(),
+		})
+		return
+	}
+
+	_, err = c.productUseCase.UpdateProductComponent(id, request.ToEntity(componentID))
+	if _, ok := costRecalculationWarning(err); ok {
+		// Рецептура изменена, предупреждение о пересчете показывается в форме продукции
+		ctx.Redirect(http.StatusFound, "/products/"+strconv.Itoa(id)+"/edit?cost_warning=1#components")
+		return
+	}
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Ошибка обновления состава: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/products/"+strconv.Itoa(id)+"/edit#components")
+}
+
+// RemoveProductComponentWeb удаляет полуфабрикат из состава через веб-форму
+func (c *ProductController) RemoveProductComponentWeb(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID продукции",
+		})
+		return
+	}
+
+	componentID, err := strconv.Atoi(ctx.Param("component_id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID полуфабриката",
+		})
+		return
+	}
+
+	_, err = c.productUseCase.RemoveProductComponent(id, componentID)
+	if _, ok := costRecalculationWarning(err); ok {
+		// Рецептура изменена, предупреждение о пересчете показывается в форме продукции
+		ctx.Redirect(http.StatusFound, "/products/"+strconv.Itoa(id)+"/edit?cost_warning=1#components")
+		return
+	}
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Ошибка удаления полуфабриката: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/products/"+strconv.Itoa(id)+"/edit#components")
+}
+
+// errorStatus подбирает HTTP статус для ошибки варианта использования: некорректные
+// данные - 400, не найдено - 404, нарушение бизнес-правила при текущем состоянии - 409,
+// остальное (ошибки БД и транзакций) - ошибка сервера
+func errorStatus(err error) int {
+	var validationErr *entities.ValidationError
+	if errors.As(err, &validationErr) {
+		return http.StatusBadRequest
+	}
+	var notFoundErr *entities.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return http.StatusNotFound
+	}
+	var businessErr *entities.BusinessError
+	if errors.As(err, &businessErr) {
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// costRecalculationWarning возвращает предупреждение, если изменение сохранено, но себестоимость
+// продукции не пересчитана: такой результат считается успешным
+func costRecalculationWarning(err error) (string, bool) {
+	var recalcErr *entities.CostRecalculationError
+	if errors.As(err, &recalcErr) {
+		return recalcErr.Error(), true
+	}
+	return "", false
+}
+
+// listErrorStatus возвращает HTTP статус для ошибки получения списка:
+// некорректные параметры выборки - 400, не найден справочник из фильтра - 404,
+// остальное - ошибка сервера
+func listErrorStatus(err error) int {
+	var validationErr *entities.ValidationError
+	if errors.As(err, &validationErr) {
+		return http.StatusBadRequest
+	}
+	var notFoundErr *entities.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
 }
@@ -3,10 +3,12 @@ package controllers
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
 	"testing"
+	"time"
 
 	"wallpaper-system/internal/adapters/controllers/dto"
 	"wallpaper-system/internal/domain/entities"
@@ -22,6 +24,7 @@ type ProductControllerTestSuite struct {
 	suite.Suite
 	productUseCase  *mocks.MockProductUseCase
 	materialUseCase *mocks.MockMaterialUseCase
+	unitUseCase     *mocks.MockUnitConversionUseCase
 	controller      *ProductController
 	router          *gin.Engine
 }
@@ -29,7 +32,8 @@ type ProductControllerTestSuite struct {
 func (suite *ProductControllerTestSuite) SetupTest() {
 	suite.productUseCase = new(mocks.MockProductUseCase)
 	suite.materialUseCase = new(mocks.MockMaterialUseCase)
-	suite.controller = NewProductController(suite.productUseCase, suite.materialUseCase)
+	suite.unitUseCase = new(mocks.MockUnitConversionUseCase)
+	suite.controller = NewProductController(suite.productUseCase, suite.materialUseCase, suite.unitUseCase)
 
 	// Настройка Gin в тестовом режиме
 	gin.SetMode(gin.TestMode)
@@ -44,7 +48,14 @@ func (suite *ProductControllerTestSuite) SetupTest() {
 			products.GET("/:id", suite.controller.GetProductByID)
 			products.POST("/", suite.controller.CreateProduct)
 			products.PUT("/:id", suite.controller.UpdateProduct)
-			products.DELETE("/:id", suite.controller.DeleteProduct)
+			products.DELETE("/:id", suite.controller.ArchiveProduct)
+			products.POST("/:id/restore", suite.controller.RestoreProduct)
+			products.POST("/:id/clone", suite.controller.CloneProduct)
+			products.POST("/:id/materials", suite.controller.AddProductMaterial)
+			products.PUT("/:id/materials", suite.controller.ReplaceProductMaterials)
+			products.POST("/:id/components", suite.controller.AddProductComponent)
+			products.GET("/:id/explosion", suite.controller.GetMaterialExplosion)
+			products.GET("/:id/price", suite.controller.GetProductPrice)
 		}
 	}
 }
@@ -72,8 +83,13 @@ func (suite *ProductControllerTestSuite) TestGetProducts_Success() {
 		},
 	}
 
+	list := &entities.ProductList{
+		Items:    products,
+		PageInfo: entities.PageInfo{Page: 1, PageSize: entities.DefaultPageSize, Total: 2},
+	}
+
 	// Настройка мока
-	suite.productUseCase.On("GetAllProducts").Return(products, nil)
+	suite.productUseCase.On("FindProducts", entities.ProductCriteria{}).Return(list, nil)
 
 	// Выполнение запроса
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/", nil)
@@ -83,18 +99,85 @@ func (suite *ProductControllerTestSuite) TestGetProducts_Success() {
 	// Проверки
 	assert.Equal(suite.T(), http.StatusOK, w.Code)
 
-	var response dto.SuccessResponse
+	var response dto.PagedResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(suite.T(), err)
 	assert.True(suite.T(), response.Success)
 	assert.NotNil(suite.T(), response.Data)
+	assert.Equal(suite.T(), 2, response.Pagination.Total)
+	assert.Equal(suite.T(), 1, response.Pagination.TotalPages)
+
+	suite.productUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *ProductControllerTestSuite) TestGetProducts_WithFilters() {
+	// Ожидаемые критерии, разобранные из строки запроса
+	typeID := 2
+	minPrice := 100.0
+	maxPrice := 500.5
+	criteria := entities.ProductCriteria{
+		ProductTypeID: &typeID,
+		MinPrice:      &minPrice,
+		MaxPrice:      &maxPrice,
+		ArticlePrefix: "ART",
+		SortBy:        entities.ProductSortPrice,
+		SortDirection: entities.SortDesc,
+		Pagination:    entities.Pagination{Page: 3, PageSize: 10},
+	}
+	list := &entities.ProductList{
+		Items:    []entities.Product{},
+		PageInfo: entities.PageInfo{Page: 3, PageSize: 10, Total: 25},
+	}
+
+	// Настройка мока
+	suite.productUseCase.On("FindProducts", criteria).Return(list, nil)
+
+	// Выполнение запроса
+	url := "/api/v1/products/?product_type_id=2&min_price=100&max_price=500.5&article=ART&sort=price&order=DESC&page=3&page_size=10"
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response dto.PagedResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), dto.PaginationDTO{Page: 3, PageSize: 10, Total: 25, TotalPages: 3}, response.Pagination)
 
 	suite.productUseCase.AssertExpectations(suite.T())
 }
 
+func (suite *ProductControllerTestSuite) TestGetProducts_InvalidFilter() {
+	// Выполнение запроса с нечисловой ценой
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/?min_price=abc", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.productUseCase.AssertNotCalled(suite.T(), "FindProducts", mock.Anything)
+}
+
+func (suite *ProductControllerTestSuite) TestGetProducts_InvalidSort() {
+	// Настройка мока: use case отклоняет неизвестное поле сортировки
+	criteria := entities.ProductCriteria{SortBy: "weight"}
+	suite.productUseCase.On("FindProducts", criteria).
+		Return(nil, entities.NewValidationError("sort", "недопустимое поле сортировки продукции"))
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/?sort=weight", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
 func (suite *ProductControllerTestSuite) TestGetProducts_InternalError() {
 	// Настройка мока для возврата ошибки
-	suite.productUseCase.On("GetAllProducts").Return([]entities.Product{}, assert.AnError)
+	suite.productUseCase.On("FindProducts", entities.ProductCriteria{}).Return(nil, assert.AnError)
 
 	// Выполнение запроса
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/", nil)
@@ -223,6 +306,35 @@ func (suite *ProductControllerTestSuite) TestCreateProduct_Success() {
 	suite.productUseCase.AssertExpectations(suite.T())
 }
 
+func (suite *ProductControllerTestSuite) TestCreateProduct_Passport() {
+	// Подготовка данных: нулевые величины означают «не указано»
+	requestData := `{
+		"article": "ART003", "product_type_id": 1, "name": "Новые обои", "min_partner_price": 150.0,
+		"package_length": 1.1, "package_width": 0.15, "package_height": 0.15,
+		"weight_without_package": 1.5, "weight_with_package": 1.6, "cost_price": 0,
+		"standard_number": " ГОСТ 6810-2002 ", "workshop_number": "", "required_workers": 3
+	}`
+
+	// Настройка мока
+	suite.productUseCase.On("CreateProduct", mock.MatchedBy(func(product *entities.Product) bool {
+		return *product.PackageLength == 1.1 && *product.WeightWithPackage == 1.6 &&
+			*product.StandardNumber == "ГОСТ 6810-2002" && *product.RequiredWorkers == 3 &&
+			product.CostPrice == nil && product.WorkshopNumber == nil && product.QualityCertificatePath == nil
+	})).Return(nil)
+
+	// Подготовка запроса
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products/", bytes.NewBufferString(requestData))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Выполнение
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	suite.productUseCase.AssertExpectations(suite.T())
+}
+
 func (suite *ProductControllerTestSuite) TestCreateProduct_ValidationError() {
 	// Подготовка данных с ошибкой валидации (отсутствующий обязательный article)
 	requestData := `{"product_type_id": 1, "name": "Новые обои", "min_partner_price": 150.0}`
@@ -270,12 +382,73 @@ func (suite *ProductControllerTestSuite) TestCreateProduct_InvalidJSON() {
 	suite.productUseCase.AssertNotCalled(suite.T(), "CreateProduct")
 }
 
-func (suite *ProductControllerTestSuite) TestDeleteProduct_Success() {
+func (suite *ProductControllerTestSuite) TestCloneProduct_Success() {
+	// Подготовка данных
+	clone := &entities.Product{
+		ID:      5,
+		Article: "ART002",
+		Name:    "Обои с лилиями",
+		Materials: []entities.ProductMaterial{
+			{ID: 11, ProductID: 5, MaterialID: 3, QuantityPerUnit: 2.2, Material: &entities.Material{ID: 3, Name: "Бумага"}},
+		},
+	}
+
+	// Настройка мока
+	suite.productUseCase.On("CloneProduct", 1, entities.ProductCloneRequest{
+		Article: "ART002", Name: "Обои с лилиями", QuantityChangePercent: 10,
+	}).Return(clone, nil)
+
+	// Выполнение запроса
+	body := `{"article": " ART002 ", "name": "Обои с лилиями", "quantity_change_percent": 10}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products/1/clone", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"article":"ART002"`)
+	assert.Contains(suite.T(), w.Body.String(), `"quantity_per_unit":2.2`)
+	suite.productUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *ProductControllerTestSuite) TestCloneProduct_CostWarning() {
+	// Настройка мока: копия создана, но себестоимость не рассчитана
+	suite.productUseCase.On("CloneProduct", 1, entities.ProductCloneRequest{Article: "ART002", Name: "Обои с лилиями"}).
+		Return(&entities.Product{ID: 5, Article: "ART002", Name: "Обои с лилиями"},
+			entities.NewCostRecalculationError("продукция скопирована", errors.New("ошибка базы данных")))
+
+	// Выполнение запроса
+	body := `{"article": "ART002", "name": "Обои с лилиями"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products/1/clone", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"id":5`)
+	assert.Contains(suite.T(), w.Body.String(), `"warning":"продукция скопирована, но себестоимость продукции не пересчитана`)
+}
+
+func (suite *ProductControllerTestSuite) TestCloneProduct_MissingArticle() {
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products/1/clone", bytes.NewBufferString(`{"quantity_change_percent": 10}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.productUseCase.AssertNotCalled(suite.T(), "CloneProduct", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductControllerTestSuite) TestArchiveProduct_Success() {
 	// Подготовка данных
 	productID := 1
 
 	// Настройка мока
-	suite.productUseCase.On("DeleteProduct", productID).Return(nil)
+	suite.productUseCase.On("ArchiveProduct", productID).Return(nil)
 
 	// Выполнение запроса
 	req := httptest.NewRequest(http.MethodDelete, "/api/v1/products/"+strconv.Itoa(productID), nil)
@@ -293,12 +466,12 @@ func (suite *ProductControllerTestSuite) TestDeleteProduct_Success() {
 	suite.productUseCase.AssertExpectations(suite.T())
 }
 
-func (suite *ProductControllerTestSuite) TestDeleteProduct_NotFound() {
+func (suite *ProductControllerTestSuite) TestArchiveProduct_NotFound() {
 	// Подготовка данных
 	productID := 999
 
 	// Настройка мока
-	suite.productUseCase.On("DeleteProduct", productID).Return(entities.NewNotFoundError("product", "999"))
+	suite.productUseCase.On("ArchiveProduct", productID).Return(entities.NewNotFoundError("product", "999"))
 
 	// Выполнение запроса
 	req := httptest.NewRequest(http.MethodDelete, "/api/v1/products/"+strconv.Itoa(productID), nil)
@@ -317,6 +490,311 @@ func (suite *ProductControllerTestSuite) TestDeleteProduct_NotFound() {
 	suite.productUseCase.AssertExpectations(suite.T())
 }
 
+func (suite *ProductControllerTestSuite) TestRestoreProduct_NotArchived() {
+	// Настройка мока
+	suite.productUseCase.On("RestoreProduct", 1).
+		Return(entities.NewBusinessError("NOT_ARCHIVED", "продукция не находится в архиве"))
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products/1/restore", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	suite.productUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *ProductControllerTestSuite) TestGetProducts_IncludeArchived() {
+	// Настройка мока
+	criteria := entities.ProductCriteria{IncludeArchived: true}
+	suite.productUseCase.On("FindProducts", criteria).Return(&entities.ProductList{Items: []entities.Product{}}, nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/?include_archived=true", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	suite.productUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *ProductControllerTestSuite) TestAddProductMaterial_Success() {
+	// Подготовка данных
+	product := &entities.Product{
+		ID:          1,
+		Article:     "ART001",
+		Name:        "Обои винил",
+		ProductType: &entities.ProductType{ID: 1, Name: "Винил"},
+		Materials: []entities.ProductMaterial{
+			{MaterialID: 2, QuantityPerUnit: 0.5, Material: &entities.Material{ID: 2, Name: "Краска", CostPerUnit: 200.0}},
+		},
+	}
+
+	// Настройка мока
+	suite.productUseCase.On("AddProductMaterial", 1, &entities.ProductMaterial{MaterialID: 2, QuantityPerUnit: 0.5}).Return(product, nil)
+
+	// Выполнение запроса
+	body := `{"material_id": 2, "quantity_per_unit": 0.5}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products/1/materials", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+
+	var response dto.SuccessResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), response.Success)
+
+	suite.productUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *ProductControllerTestSuite) TestAddProductMaterial_CostWarning() {
+	// Подготовка данных
+	product := &entities.Product{ID: 1, Article: "ART001", Name: "Обои винил"}
+
+	// Настройка мока: материал добавлен, но себестоимость не пересчитана
+	suite.productUseCase.On("AddProductMaterial", 1, &entities.ProductMaterial{MaterialID: 2, QuantityPerUnit: 0.5}).
+		Return(product, entities.NewCostRecalculationError("рецептура изменена", errors.New("ошибка базы данных")))
+
+	// Выполнение запроса
+	body := `{"material_id": 2, "quantity_per_unit": 0.5}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products/1/materials", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"warning":"рецептура изменена, но себестоимость продукции не пересчитана`)
+	assert.Contains(suite.T(), w.Body.String(), `"article":"ART001"`)
+}
+
+func (suite *ProductControllerTestSuite) TestAddProductMaterial_NonPositiveQuantity() {
+	// Выполнение запроса с нулевым расходом
+	body := `{"material_id": 2, "quantity_per_unit": 0}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products/1/materials", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+
+	// Мок не должен вызываться при некорректных данных
+	suite.productUseCase.AssertNotCalled(suite.T(), "AddProductMaterial")
+}
+
+func (suite *ProductControllerTestSuite) TestReplaceProductMaterials_ProductNotFound() {
+	// Настройка мока
+	suite.productUseCase.On("ReplaceProductMaterials", 999, mock.Anything).
+		Return(nil, entities.NewNotFoundError("продукция", "999"))
+
+	// Выполнение запроса
+	body := `{"materials": [{"material_id": 1, "quantity_per_unit": 1.5}]}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/products/999/materials", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+
+	suite.productUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *ProductControllerTestSuite) TestAddProductComponent_Cycle() {
+	// Настройка мока
+	suite.productUseCase.On("AddProductComponent", 1, mock.Anything).
+		Return(nil, entities.NewBOMCycleError(1))
+
+	// Выполнение запроса
+	body := `{"component_product_id": 2, "quantity_per_unit": 1.5}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products/1/components", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+
+	suite.productUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *ProductControllerTestSuite) TestAddProductComponent_DatabaseError() {
+	// Настройка мока
+	suite.productUseCase.On("AddProductComponent", 1, mock.Anything).
+		Return(nil, errors.New("ошибка начала транзакции: connection refused"))
+
+	// Выполнение запроса
+	body := `{"component_product_id": 2, "quantity_per_unit": 1.5}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products/1/components", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
+
+	suite.productUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *ProductControllerTestSuite) TestGetMaterialExplosion_Success() {
+	// Подготовка данных
+	requirements := []entities.MaterialRequirement{
+		{
+			MaterialID: 1,
+			Material:   &entities.Material{ID: 1, Article: "MAT001", Name: "Бумага", CostPerUnit: 10.0},
+			Quantity:   25.0,
+		},
+	}
+
+	// Настройка мока
+	suite.productUseCase.On("ExplodeMaterials", 1, 5.0).Return(requirements, nil)
+	suite.unitUseCase.On("ExpressRequirements", requirements, "").Return(requirements, nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/1/explosion?quantity=5", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response dto.SuccessResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+
+	data := response.Data.([]interface{})
+	assert.Len(suite.T(), data, 1)
+	item := data[0].(map[string]interface{})
+	assert.Equal(suite.T(), 250.0, item["total_cost"])
+
+	suite.productUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *ProductControllerTestSuite) TestGetMaterialExplosion_InUnit() {
+	// Подготовка данных: клей учитывается в килограммах, потребность запрошена в граммах
+	requirements := []entities.MaterialRequirement{
+		{
+			MaterialID: 2,
+			Material: &entities.Material{ID: 2, Article: "GLUE", Name: "Клей", CostPerUnit: 300.0,
+				MeasurementUnit: &entities.MeasurementUnit{ID: 2, Abbreviation: "кг"}},
+			Quantity: 1.5,
+		},
+	}
+	expressed := []entities.MaterialRequirement{
+		{
+			MaterialID: 2,
+			Material: &entities.Material{ID: 2, Article: "GLUE", Name: "Клей", CostPerUnit: 0.3,
+				MeasurementUnit: &entities.MeasurementUnit{ID: 8, Abbreviation: "г"}},
+			Quantity: 1500,
+		},
+	}
+
+	// Настройка мока
+	suite.productUseCase.On("ExplodeMaterials", 1, 1.0).Return(requirements, nil)
+	suite.unitUseCase.On("ExpressRequirements", requirements, "г").Return(expressed, nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/1/explosion?unit=%D0%B3", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response dto.SuccessResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+
+	item := response.Data.([]interface{})[0].(map[string]interface{})
+	assert.Equal(suite.T(), 1500.0, item["quantity"])
+	assert.Equal(suite.T(), "г", item["unit_abbreviation"])
+	assert.InDelta(suite.T(), 450.0, item["total_cost"], 1e-9)
+
+	suite.productUseCase.AssertExpectations(suite.T())
+	suite.unitUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *ProductControllerTestSuite) TestGetMaterialExplosion_IncompatibleUnit() {
+	requirements := []entities.MaterialRequirement{{MaterialID: 1, Quantity: 2}}
+
+	// Настройка мока
+	suite.productUseCase.On("ExplodeMaterials", 1, 1.0).Return(requirements, nil)
+	suite.unitUseCase.On("ExpressRequirements", requirements, "парсек").
+		Return(nil, entities.NewValidationError("unit", "единица измерения парсек не найдена"))
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/1/explosion?unit=%D0%BF%D0%B0%D1%80%D1%81%D0%B5%D0%BA", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.unitUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *ProductControllerTestSuite) TestGetMaterialExplosion_InvalidQuantity() {
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/1/explosion?quantity=abc", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.productUseCase.AssertNotCalled(suite.T(), "ExplodeMaterials")
+}
+
+func (suite *ProductControllerTestSuite) TestGetProductPrice_Success() {
+	// Подготовка данных
+	partnerTypeID := 2
+	date := time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)
+	calculation := &entities.PriceCalculation{
+		Cost:  200.0,
+		Price: 240.0,
+		Rule:  &entities.PricingRule{ID: 3, Name: "Опт", MarkupPercent: 10, FixedSurcharge: 15},
+	}
+
+	// Настройка мока
+	suite.productUseCase.On("CalculateProductPrice", 1, &partnerTypeID, date).Return(calculation, nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/1/price?partner_type_id=2&date=2026-03-15", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response dto.SuccessResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+
+	data := response.Data.(map[string]interface{})
+	assert.Equal(suite.T(), 240.0, data["price"])
+	assert.Equal(suite.T(), "2026-03-15", data["date"])
+	rule := data["pricing_rule"].(map[string]interface{})
+	assert.Equal(suite.T(), 3.0, rule["id"])
+
+	suite.productUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *ProductControllerTestSuite) TestGetProductPrice_InvalidDate() {
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/1/price?date=15.03.2026", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.productUseCase.AssertNotCalled(suite.T(), "CalculateProductPrice")
+}
+
 func TestProductControllerTestSuite(t *testing.T) {
 	suite.Run(t, new(ProductControllerTestSuite))
 }
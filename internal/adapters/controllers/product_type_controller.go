@@ -0,0 +1,311 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"wallpaper-system/internal/adapters/controllers/dto"
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProductTypeController обрабатывает HTTP запросы для типов продукции
+type ProductTypeController struct {
+	productTypeUseCase usecases.ProductTypeUseCaseInterface
+}
+
+// NewProductTypeController создает новый контроллер типов продукции
+func NewProductTypeController(productTypeUseCase usecases.ProductTypeUseCaseInterface) *ProductTypeController {
+	return &ProductTypeController{productTypeUseCase: productTypeUseCase}
+}
+
+// GetProductTypes возвращает список типов продукции через API;
+// выведенные из обращения - при ?include_archived=true
+func (c *ProductTypeController) GetProductTypes(ctx *gin.Context) {
+	var query dto.ProductTypesQuery
+	_ = ctx.ShouldBindQuery(&query)
+
+	includeArchived, err := query.Parse()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	productTypes, err := c.productTypeUseCase.GetProductTypes(includeArchived)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.NewErrorResponse("Ошибка получения типов продукции: "+err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Типы продукции получены", dto.FromProductTypeEntities(productTypes)))
+}
+
+// GetProductTypeByID возвращает тип продукции по ID через API
+func (c *ProductTypeController) GetProductTypeByID(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID типа продукции"))
+		return
+	}
+
+	productType, err := c.productTypeUseCase.GetProductTypeByID(id)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Тип продукции получен", dto.FromProductTypeEntity(productType)))
+}
+
+// CreateProductType создает тип продукции через API
+func (c *ProductTypeController) CreateProductType(ctx *gin.Context) {
+	var request dto.ProductTypeRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса: "+err.Error()))
+		return
+	}
+
+	productType, change, err := request.ToEntity()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	if err := c.productTypeUseCase.CreateProductType(productType, change); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.NewSuccessResponse("Тип продукции создан", dto.FromProductTypeEntity(productType)))
+}
+
+// UpdateProductType обновляет тип продукции через API. При изменении коэффициента
+// обязателен changed_by, изменение записывается в историю
+func (c *ProductTypeController) UpdateProductType(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID типа продукции"))
+		return
+	}
+
+	var request dto.ProductTypeRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса: "+err.Error()))
+		return
+	}
+
+	productType, change, err := request.ToEntity()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(err.Error()))
+		return
+	}
+	productType.ID = id
+
+	err = c.productTypeUseCase.UpdateProductType(productType, change)
+	if warning, ok := costRecalculationWarning(err); ok {
+		ctx.JSON(http.StatusOK, dto.NewWarningResponse("Тип продукции обновлен", warning, dto.FromProductTypeEntity(productType)))
+		return
+	}
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Тип продукции обновлен", dto.FromProductTypeEntity(productType)))
+}
+
+// ArchiveProductType выводит тип продукции из обращения (DELETE /api/v1/product-types/:id)
+func (c *ProductTypeController) ArchiveProductType(ctx *gin.Context) {
+	c.changeArchiveState(ctx, c.productTypeUseCase.ArchiveProductType, "Тип продукции выведен из обращения")
+}
+
+// RestoreProductType возвращает тип продукции в обращение через API
+func (c *ProductTypeController) RestoreProductType(ctx *gin.Context) {
+	c.changeArchiveState(ctx, c.productTypeUseCase.RestoreProductType, "Тип продукции возвращен в обращение")
+}
+
+func (c *ProductTypeController) changeArchiveState(ctx *gin.Context, action func(id int) error, message string) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID типа продукции"))
+		return
+	}
+
+	if err := action(id); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse(message, nil))
+}
+
+// GetCoefficientHistory возвращает историю коэффициента типа продукции:
+// GET /api/v1/product-types/:id/coefficient-history
+func (c *ProductTypeController) GetCoefficientHistory(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID типа продукции"))
+		return
+	}
+
+	history, err := c.productTypeUseCase.GetCoefficientHistory(id)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("История коэффициента получена", dto.FromCoefficientChanges(history)))
+}
+
+// GetProductTypesPage отображает список типов продукции, включая выведенные из обращения,
+// и форму добавления
+func (c *ProductTypeController) GetProductTypesPage(ctx *gin.Context) {
+	c.renderProductTypesPage(ctx, http.StatusOK, "")
+}
+
+// CreateProductTypeWeb создает тип продукции из формы
+func (c *ProductTypeController) CreateProductTypeWeb(ctx *gin.Context) {
+	var request dto.ProductTypeRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		c.renderProductTypesPage(ctx, http.StatusBadRequest, "Некорректные данные формы: "+err.Error())
+		return
+	}
+
+	productType, change, err := request.ToEntity()
+	if err != nil {
+		c.renderProductTypesPage(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.productTypeUseCase.CreateProductType(productType, change); err != nil {
+		c.renderProductTypesPage(ctx, errorStatus(err), "Ошибка создания типа продукции: "+err.Error())
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/product-types")
+}
+
+// GetProductTypeEditPage отображает форму изменения типа продукции и историю коэффициента
+func (c *ProductTypeController) GetProductTypeEditPage(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID типа продукции",
+		})
+		return
+	}
+
+	c.renderProductTypeEditPage(ctx, id, http.StatusOK, "")
+}
+
+// UpdateProductTypeWeb обновляет тип продукции из формы
+func (c *ProductTypeController) UpdateProductTypeWeb(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID типа продукции",
+		})
+		return
+	}
+
+	var request dto.ProductTypeRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		c.renderProductTypeEditPage(ctx, id, http.StatusBadRequest, "Некорректные данные формы: "+err.Error())
+		return
+	}
+
+	productType, change, err := request.ToEntity()
+	if err != nil {
+		c.renderProductTypeEditPage(ctx, id, http.StatusBadRequest, err.Error())
+		return
+	}
+	productType.ID = id
+
+	err = c.productTypeUseCase.UpdateProductType(productType, change)
+	if _, ok := costRecalculationWarning(err); ok {
+		// Тип продукции сохранен, предупреждение о пересчете показывается на его странице
+		ctx.Redirect(http.StatusFound, "/product-types/"+strconv.Itoa(id)+"?cost_warning=1")
+		return
+	}
+	if err != nil {
+		c.renderProductTypeEditPage(ctx, id, errorStatus(err), "Ошибка изменения типа продукции: "+err.Error())
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/product-types/"+strconv.Itoa(id))
+}
+
+// ArchiveProductTypeWeb выводит тип продукции из обращения через веб-форму
+func (c *ProductTypeController) ArchiveProductTypeWeb(ctx *gin.Context) {
+	c.productTypeWeb(ctx, c.productTypeUseCase.ArchiveProductType)
+}
+
+// RestoreProductTypeWeb возвращает тип продукции в обращение через веб-форму
+func (c *ProductTypeController) RestoreProductTypeWeb(ctx *gin.Context) {
+	c.productTypeWeb(ctx, c.productTypeUseCase.RestoreProductType)
+}
+
+func (c *ProductTypeController) productTypeWeb(ctx *gin.Context, action func(id int) error) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID типа продукции",
+		})
+		return
+	}
+
+	if err := action(id); err != nil {
+		ctx.HTML(errorStatus(err), "error.html", gin.H{
+			"error": "Ошибка типа продукции: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/product-types/"+strconv.Itoa(id))
+}
+
+func (c *ProductTypeController) renderProductTypesPage(ctx *gin.Context, status int, formError string) {
+	productTypes, err := c.productTypeUseCase.GetProductTypes(true)
+	if err != nil {
+		ctx.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "Ошибка получения типов продукции: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.HTML(status, "product_types.html", gin.H{
+		"title":          "Типы продукции",
+		"productTypes":   dto.FromProductTypeEntities(productTypes),
+		"maxCoefficient": entities.MaxProductTypeCoefficient,
+		"error":          formError,
+		"costWarning":    ctx.Query("cost_warning") != "",
+	})
+}
+
+func (c *ProductTypeController) renderProductTypeEditPage(ctx *gin.Context, id int, status int, formError string) {
+	productType, err := c.productTypeUseCase.GetProductTypeByID(id)
+	if err != nil {
+		ctx.HTML(errorStatus(err), "error.html", gin.H{
+			"error": "Тип продукции не найден: " + err.Error(),
+		})
+		return
+	}
+
+	history, err := c.productTypeUseCase.GetCoefficientHistory(id)
+	if err != nil {
+		ctx.HTML(listErrorStatus(err), "error.html", gin.H{
+			"error": "Ошибка получения истории коэффициента: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.HTML(status, "product_type_edit.html", gin.H{
+		"title":          "Тип продукции " + productType.Name,
+		"productType":    dto.FromProductTypeEntity(productType),
+		"history":        dto.FromCoefficientChanges(history),
+		"maxCoefficient": entities.MaxProductTypeCoefficient,
+		"error":          formError,
+	})
+}
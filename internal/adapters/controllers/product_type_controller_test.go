@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProductTypeControllerTestSuite struct {
+	suite.Suite
+	productTypeUseCase *mocks.MockProductTypeUseCase
+	controller         *ProductTypeController
+	router             *gin.Engine
+}
+
+func (suite *ProductTypeControllerTestSuite) SetupTest() {
+	suite.productTypeUseCase = new(mocks.MockProductTypeUseCase)
+	suite.controller = NewProductTypeController(suite.productTypeUseCase)
+
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+
+	productTypes := suite.router.Group("/api/v1/product-types")
+	{
+		productTypes.GET("", suite.controller.GetProductTypes)
+		productTypes.PUT("/:id", suite.controller.UpdateProductType)
+		productTypes.GET("/:id/coefficient-history", suite.controller.GetCoefficientHistory)
+	}
+}
+
+func (suite *ProductTypeControllerTestSuite) TestGetProductTypes_IncludeArchived() {
+	// Подготовка данных
+	archivedAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	productTypes := []entities.ProductType{
+		{ID: 1, Name: "Флизелин", Coefficient: 1.2},
+		{ID: 2, Name: "Бумажные", Coefficient: 1.0, ArchivedAt: &archivedAt},
+	}
+
+	// Настройка мока
+	suite.productTypeUseCase.On("GetProductTypes", true).Return(productTypes, nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/product-types?include_archived=true", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response struct {
+		Data []struct {
+			ID         int     `json:"id"`
+			Name       string  `json:"name"`
+			Archived   bool    `json:"archived"`
+			ArchivedAt *string `json:"archived_at"`
+		} `json:"data"`
+	}
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(suite.T(), response.Data, 2)
+	assert.Equal(suite.T(), "Флизелин", response.Data[0].Name)
+	assert.True(suite.T(), response.Data[1].Archived)
+	assert.Equal(suite.T(), "2024-02-01", *response.Data[1].ArchivedAt)
+}
+
+func (suite *ProductTypeControllerTestSuite) TestUpdateProductType_PassesChangeAuthor() {
+	// Настройка мока
+	suite.productTypeUseCase.On("UpdateProductType",
+		mock.MatchedBy(func(pt *entities.ProductType) bool {
+			return pt.ID == 1 && pt.Name == "Флизелин" && pt.Coefficient == 1.35
+		}),
+		mock.MatchedBy(func(c *entities.CoefficientChange) bool {
+			return c.ChangedBy == "Петрова" && c.EffectiveFrom.Format("2006-01-02") == "2024-03-01" &&
+				c.Comment != nil && *c.Comment == "Подорожание основы"
+		}),
+	).Return(nil)
+
+	// Выполнение запроса
+	body := `{"name": " Флизелин ", "coefficient": 1.35, "changed_by": "Петрова",
+		"effective_from": "2024-03-01", "comment": "Подорожание основы"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/product-types/1", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	suite.productTypeUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *ProductTypeControllerTestSuite) TestUpdateProductType_RecalculationWarning() {
+	// Настройка мока: коэффициент сохранен, себестоимость продукции не пересчитана
+	suite.productTypeUseCase.On("UpdateProductType", mock.Anything, mock.Anything).
+		Return(entities.NewCostRecalculationError("коэффициент изменен", errors.New("connection refused")))
+
+	// Выполнение запроса
+	body := `{"name": "Флизелин", "coefficient": 1.35, "changed_by": "Петрова"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/product-types/1", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"success":true`)
+	assert.Contains(suite.T(), w.Body.String(), `"warning":"коэффициент изменен, но себестоимость продукции не пересчитана: connection refused"`)
+}
+
+func (suite *ProductTypeControllerTestSuite) TestUpdateProductType_InvalidDate() {
+	// Выполнение запроса
+	body := `{"name": "Флизелин", "coefficient": 1.35, "changed_by": "Петрова", "effective_from": "01.03.2024"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/product-types/1", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.productTypeUseCase.AssertNotCalled(suite.T(), "UpdateProductType", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductTypeControllerTestSuite) TestGetCoefficientHistory_NotFound() {
+	// Настройка мока
+	suite.productTypeUseCase.On("GetCoefficientHistory", 99).
+		Return(nil, entities.NewNotFoundError("тип продукции", "99"))
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/product-types/99/coefficient-history", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+func TestProductTypeControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(ProductTypeControllerTestSuite))
+}
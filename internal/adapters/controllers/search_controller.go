@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"wallpaper-system/internal/adapters/controllers/dto"
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchController обрабатывает HTTP запросы полнотекстового поиска
+type SearchController struct {
+	searchUseCase usecases.SearchUseCaseInterface
+}
+
+// NewSearchController создает новый контроллер поиска
+func NewSearchController(searchUseCase usecases.SearchUseCaseInterface) *SearchController {
+	return &SearchController{
+		searchUseCase: searchUseCase,
+	}
+}
+
+// Search выполняет поиск через API: GET /api/v1/search?q=флизелин белый&limit=10
+func (c *SearchController) Search(ctx *gin.Context) {
+	query, err := searchQueryFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	results, err := c.searchUseCase.Search(query)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Поиск выполнен", dto.FromSearchResults(results))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetSearchPage отображает страницу результатов поиска
+func (c *SearchController) GetSearchPage(ctx *gin.Context) {
+	query, err := searchQueryFromRequest(ctx)
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	data := gin.H{
+		"title": "Поиск",
+		"query": query.Text,
+	}
+
+	// Пустая строка поиска - просто показываем форму
+	if strings.TrimSpace(query.Text) == "" {
+		ctx.HTML(http.StatusOK, "search.html", data)
+		return
+	}
+
+	results, err := c.searchUseCase.Search(query)
+	if err != nil {
+		data["error"] = err.Error()
+		ctx.HTML(listErrorStatus(err), "search.html", data)
+		return
+	}
+
+	data["results"] = dto.FromSearchResults(results)
+	ctx.HTML(http.StatusOK, "search.html", data)
+}
+
+// searchQueryFromRequest разбирает параметры q и limit
+func searchQueryFromRequest(ctx *gin.Context) (entities.SearchQuery, error) {
+	query := entities.SearchQuery{Text: ctx.Query("q")}
+
+	if limit := ctx.Query("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			return query, entities.NewValidationError("limit", "ожидается целое число")
+		}
+		query.Limit = value
+	}
+
+	return query, nil
+}
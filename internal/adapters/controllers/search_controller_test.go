@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"wallpaper-system/internal/adapters/controllers/dto"
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type SearchControllerTestSuite struct {
+	suite.Suite
+	searchUseCase *mocks.MockSearchUseCase
+	controller    *SearchController
+	router        *gin.Engine
+}
+
+func (suite *SearchControllerTestSuite) SetupTest() {
+	suite.searchUseCase = new(mocks.MockSearchUseCase)
+	suite.controller = NewSearchController(suite.searchUseCase)
+
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+	suite.router.GET("/api/v1/search", suite.controller.Search)
+}
+
+func (suite *SearchControllerTestSuite) TestSearch_Success() {
+	// Подготовка данных
+	results := &entities.SearchResults{
+		Query: "флизелин белый",
+		Products: []entities.SearchResult{
+			{EntityType: entities.SearchEntityProduct, ID: 7, Title: "Обои флизелиновые белые", Code: "FL-001"},
+		},
+		Partners: []entities.SearchResult{
+			{EntityType: entities.SearchEntityPartner, ID: 3, Title: "ООО Белый дом", Code: "7701234567"},
+		},
+	}
+
+	// Настройка мока
+	suite.searchUseCase.On("Search", entities.SearchQuery{Text: "флизелин белый", Limit: 5}).Return(results, nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/search?q="+url.QueryEscape("флизелин белый")+"&limit=5", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response struct {
+		Success bool                  `json:"success"`
+		Data    dto.SearchResponseDTO `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), response.Success)
+	assert.Equal(suite.T(), 2, response.Data.Total)
+	assert.Equal(suite.T(), "/products/7", response.Data.Products[0].URL)
+	assert.Empty(suite.T(), response.Data.Materials)
+	assert.Equal(suite.T(), "partner", response.Data.Partners[0].Type)
+	assert.Empty(suite.T(), response.Data.Partners[0].URL)
+
+	suite.searchUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *SearchControllerTestSuite) TestSearch_ValidationError() {
+	// Настройка мока
+	suite.searchUseCase.On("Search", mock.Anything).
+		Return(nil, entities.NewValidationError("q", "поисковый запрос должен содержать не менее 2 символов"))
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/search?q=a", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *SearchControllerTestSuite) TestSearch_InvalidLimit() {
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/search?q=обои&limit=много", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.searchUseCase.AssertNotCalled(suite.T(), "Search", mock.Anything)
+}
+
+func TestSearchControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchControllerTestSuite))
+}
@@ -0,0 +1,65 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"wallpaper-system/internal/adapters/controllers/dto"
+	"wallpaper-system/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ShippingController обрабатывает HTTP запросы расчета отгрузки на поддонах
+type ShippingController struct {
+	shippingUseCase usecases.ShippingUseCaseInterface
+}
+
+// NewShippingController создает новый контроллер расчета отгрузки
+func NewShippingController(shippingUseCase usecases.ShippingUseCaseInterface) *ShippingController {
+	return &ShippingController{
+		shippingUseCase: shippingUseCase,
+	}
+}
+
+// CalculateShipment рассчитывает отгрузку списка продукции через API:
+// POST /api/v1/calculator/shipping
+func (c *ShippingController) CalculateShipment(ctx *gin.Context) {
+	var request dto.ShipmentRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса: "+err.Error()))
+		return
+	}
+
+	plan, err := c.shippingUseCase.CalculateShipment(request.ToEntity())
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Отгрузка рассчитана", dto.FromShipmentPlan(plan)))
+}
+
+// GetOrderShipment рассчитывает отгрузку позиций заказа через API; поддон задается
+// параметрами pallet_*: GET /api/v1/orders/:id/shipping?pallet_max_height=1.6
+func (c *ShippingController) GetOrderShipment(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID заказа"))
+		return
+	}
+
+	var pallet dto.PalletSpecDTO
+	if err := ctx.ShouldBindQuery(&pallet); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные параметры поддона: "+err.Error()))
+		return
+	}
+
+	plan, err := c.shippingUseCase.CalculateOrderShipment(id, pallet.ToEntity())
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Отгрузка заказа рассчитана", dto.FromShipmentPlan(plan)))
+}
@@ -0,0 +1,170 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ShippingControllerTestSuite struct {
+	suite.Suite
+	shippingUseCase *mocks.MockShippingUseCase
+	controller      *ShippingController
+	router          *gin.Engine
+}
+
+func (suite *ShippingControllerTestSuite) SetupTest() {
+	suite.shippingUseCase = new(mocks.MockShippingUseCase)
+	suite.controller = NewShippingController(suite.shippingUseCase)
+
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+
+	v1 := suite.router.Group("/api/v1")
+	{
+		v1.POST("/calculator/shipping", suite.controller.CalculateShipment)
+		v1.GET("/orders/:id/shipping", suite.controller.GetOrderShipment)
+	}
+}
+
+// newTestShipmentPlan возвращает расчет отгрузки 300 упаковок на двух европоддонах
+func newTestShipmentPlan() *entities.ShipmentPlan {
+	length, width, height := 0.55, 0.12, 0.12
+	product := &entities.Product{ID: 1, Article: "WP-001", Name: "Обои флизелиновые",
+		PackageLength: &length, PackageWidth: &width, PackageHeight: &height}
+	return &entities.ShipmentPlan{
+		Pallet: entities.DefaultPalletSpec(),
+		Lines: []entities.ShipmentLineLayout{{
+			Product: product, Quantity: 300, PackagesPerLayer: 12, Layers: 13, PackagesPerPallet: 156,
+			Pallets: 2, LastPalletPackages: 144, StackHeight: 1.704, Volume: 2.376, Weight: 480,
+		}},
+		Packages: 300, Pallets: 2, CargoVolume: 2.376, LoadedVolume: 3.156, FloorArea: 1.92,
+		CargoWeight: 480, PalletsWeight: 50, GrossWeight: 530,
+	}
+}
+
+func (suite *ShippingControllerTestSuite) TestCalculateShipment_Success() {
+	// Настройка мока: незаданные параметры поддона берутся от европоддона
+	suite.shippingUseCase.On("CalculateShipment", mock.MatchedBy(func(r *entities.ShipmentRequest) bool {
+		return len(r.Lines) == 1 && r.Lines[0].ProductID == 1 && r.Lines[0].Quantity == 300 &&
+			r.Pallet.MaxHeight == 1.6 && r.Pallet.Length == entities.DefaultPalletLength
+	})).Return(newTestShipmentPlan(), nil)
+
+	// Выполнение запроса
+	body := `{"lines": [{"product_id": 1, "quantity": 300}], "pallet": {"max_height": 1.6}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/calculator/shipping", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response struct {
+		Data struct {
+			Pallets     int     `json:"pallets"`
+			GrossWeight float64 `json:"gross_weight"`
+			Lines       []struct {
+				Article           string `json:"article"`
+				PackagesPerPallet int    `json:"packages_per_pallet"`
+			} `json:"lines"`
+		} `json:"data"`
+	}
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(suite.T(), 2, response.Data.Pallets)
+	assert.Equal(suite.T(), 530.0, response.Data.GrossWeight)
+	assert.Equal(suite.T(), "WP-001", response.Data.Lines[0].Article)
+	assert.Equal(suite.T(), 156, response.Data.Lines[0].PackagesPerPallet)
+	suite.shippingUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *ShippingControllerTestSuite) TestCalculateShipment_EmptyLines() {
+	// Выполнение запроса
+	body := `{"lines": []}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/calculator/shipping", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.shippingUseCase.AssertNotCalled(suite.T(), "CalculateShipment", mock.Anything)
+}
+
+func (suite *ShippingControllerTestSuite) TestCalculateShipment_PackageDataMissing() {
+	// Настройка мока
+	suite.shippingUseCase.On("CalculateShipment", mock.Anything).Return(nil,
+		entities.NewBusinessError("PACKAGE_DATA_MISSING", "не заданы габариты или вес упаковки продукции: WP-002"))
+
+	// Выполнение запроса
+	body := `{"lines": [{"product_id": 2, "quantity": 1}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/calculator/shipping", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	// Нарушение бизнес-правила - конфликт с состоянием данных, а не ошибка запроса
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"success":false`)
+	assert.Contains(suite.T(), w.Body.String(), "WP-002")
+	suite.shippingUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *ShippingControllerTestSuite) TestGetOrderShipment_PalletFromQuery() {
+	// Подготовка данных
+	pallet := entities.DefaultPalletSpec()
+	pallet.Length, pallet.Width = 1.2, 1.0
+
+	// Настройка мока
+	suite.shippingUseCase.On("CalculateOrderShipment", 7, pallet).Return(newTestShipmentPlan(), nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/7/shipping?pallet_width=1.0", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"loaded_volume":3.156`)
+	suite.shippingUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *ShippingControllerTestSuite) TestGetOrderShipment_OrderNotFound() {
+	// Настройка мока
+	suite.shippingUseCase.On("CalculateOrderShipment", 99, entities.DefaultPalletSpec()).
+		Return(nil, entities.NewNotFoundError("заказ", "99"))
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/99/shipping", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+func (suite *ShippingControllerTestSuite) TestGetOrderShipment_InvalidPallet() {
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/7/shipping?pallet_length=-1", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.shippingUseCase.AssertNotCalled(suite.T(), "CalculateOrderShipment", mock.Anything, mock.Anything)
+}
+
+func TestShippingControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(ShippingControllerTestSuite))
+}
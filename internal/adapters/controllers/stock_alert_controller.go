@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"wallpaper-system/internal/adapters/controllers/dto"
+	"wallpaper-system/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StockAlertController обрабатывает HTTP запросы контроля минимальных остатков материалов
+type StockAlertController struct {
+	alertUseCase usecases.StockAlertUseCaseInterface
+}
+
+// NewStockAlertController создает новый контроллер контроля остатков
+func NewStockAlertController(alertUseCase usecases.StockAlertUseCaseInterface) *StockAlertController {
+	return &StockAlertController{alertUseCase: alertUseCase}
+}
+
+// GetLowStock возвращает материалы с остатком не выше минимального и рекомендуемую закупку
+func (c *StockAlertController) GetLowStock(ctx *gin.Context) {
+	items, err := c.alertUseCase.GetLowStock()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Материалы с низким остатком получены", dto.FromLowStockItems(items)))
+}
+
+// GetNotifications возвращает уведомления о низком остатке; open=true оставляет только открытые
+func (c *StockAlertController) GetNotifications(ctx *gin.Context) {
+	var query dto.StockNotificationQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные параметры запроса: "+err.Error()))
+		return
+	}
+
+	openOnly, err := query.IsOpenOnly()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	notifications, err := c.alertUseCase.GetNotifications(openOnly)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Уведомления об остатках получены", dto.FromStockNotifications(notifications)))
+}
+
+// CheckLowStock запускает проверку остатков вне расписания и возвращает новые уведомления
+func (c *StockAlertController) CheckLowStock(ctx *gin.Context) {
+	result, err := c.alertUseCase.CheckLowStock()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Остатки проверены", gin.H{
+		"created":  dto.FromStockNotifications(result.Created),
+		"resolved": result.Resolved,
+	}))
+}
+
+// AcknowledgeNotification отмечает уведомление просмотренным
+func (c *StockAlertController) AcknowledgeNotification(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID уведомления"))
+		return
+	}
+
+	if err := c.alertUseCase.AcknowledgeNotification(id); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Уведомление отмечено просмотренным", nil))
+}
+
+// GetLowStockPage отображает панель пополнения склада: материалы с низким остатком,
+// рекомендуемую закупку и открытые уведомления
+func (c *StockAlertController) GetLowStockPage(ctx *gin.Context) {
+	items, err := c.alertUseCase.GetLowStock()
+	if err != nil {
+		ctx.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "Ошибка получения материалов с низким остатком: " + err.Error(),
+		})
+		return
+	}
+
+	notifications, err := c.alertUseCase.GetNotifications(true)
+	if err != nil {
+		ctx.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "Ошибка получения уведомлений об остатках: " + err.Error(),
+		})
+		return
+	}
+
+	var totalCost float64
+	for _, item := range items {
+		totalCost += item.SuggestedCost
+	}
+
+	ctx.HTML(http.StatusOK, "low_stock.html", gin.H{
+		"title":         "Пополнение склада",
+		"items":         dto.FromLowStockItems(items),
+		"totalCost":     totalCost,
+		"notifications": dto.FromStockNotifications(notifications),
+	})
+}
+
+// CheckLowStockWeb запускает проверку остатков со страницы пополнения склада
+func (c *StockAlertController) CheckLowStockWeb(ctx *gin.Context) {
+	if _, err := c.alertUseCase.CheckLowStock(); err != nil {
+		ctx.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "Ошибка проверки остатков: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/materials/low-stock")
+}
+
+// AcknowledgeNotificationWeb отмечает уведомление просмотренным со страницы пополнения склада
+func (c *StockAlertController) AcknowledgeNotificationWeb(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID уведомления",
+		})
+		return
+	}
+
+	if err := c.alertUseCase.AcknowledgeNotification(id); err != nil {
+		ctx.HTML(errorStatus(err), "error.html", gin.H{
+			"error": "Ошибка отметки уведомления: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/materials/low-stock")
+}
@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type StockAlertControllerTestSuite struct {
+	suite.Suite
+	alertUseCase *mocks.MockStockAlertUseCase
+	controller   *StockAlertController
+	router       *gin.Engine
+}
+
+func (suite *StockAlertControllerTestSuite) SetupTest() {
+	suite.alertUseCase = new(mocks.MockStockAlertUseCase)
+	suite.controller = NewStockAlertController(suite.alertUseCase)
+
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+
+	v1 := suite.router.Group("/api/v1")
+	{
+		v1.GET("/materials/low-stock", suite.controller.GetLowStock)
+		v1.GET("/stock-notifications", suite.controller.GetNotifications)
+		v1.POST("/stock-notifications/:id/acknowledge", suite.controller.AcknowledgeNotification)
+	}
+}
+
+func (suite *StockAlertControllerTestSuite) TestGetLowStock_Success() {
+	// Подготовка данных
+	material := &entities.Material{ID: 4, Article: "MAT-004", Name: "Клей обойный", StockQuantity: 3,
+		MinStockQuantity: 10, PackageQuantity: 5, CostPerUnit: 80,
+		MeasurementUnit: &entities.MeasurementUnit{Abbreviation: "кг"}}
+
+	// Настройка мока
+	suite.alertUseCase.On("GetLowStock").Return([]entities.LowStockItem{entities.NewLowStockItem(material)}, nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/materials/low-stock", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response struct {
+		Data []struct {
+			Article           string  `json:"article"`
+			Unit              string  `json:"unit"`
+			Shortage          float64 `json:"shortage"`
+			SuggestedPackages int     `json:"suggested_packages"`
+			SuggestedQuantity float64 `json:"suggested_quantity"`
+			SuggestedCost     float64 `json:"suggested_cost"`
+		} `json:"data"`
+	}
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(suite.T(), response.Data, 1)
+	assert.Equal(suite.T(), "MAT-004", response.Data[0].Article)
+	assert.Equal(suite.T(), "кг", response.Data[0].Unit)
+	assert.Equal(suite.T(), 7.0, response.Data[0].Shortage)
+	assert.Equal(suite.T(), 2, response.Data[0].SuggestedPackages)
+	assert.Equal(suite.T(), 10.0, response.Data[0].SuggestedQuantity)
+	assert.Equal(suite.T(), 800.0, response.Data[0].SuggestedCost)
+}
+
+func (suite *StockAlertControllerTestSuite) TestGetNotifications_OpenOnly() {
+	// Подготовка данных
+	notifications := []entities.StockNotification{{
+		ID: 1, MaterialID: 4, StockQuantity: 3, MinStockQuantity: 10,
+		CreatedAt: time.Date(2026, time.October, 1, 9, 0, 0, 0, time.UTC),
+		Material:  &entities.Material{ID: 4, Article: "MAT-004", Name: "Клей обойный"},
+	}}
+
+	// Настройка мока
+	suite.alertUseCase.On("GetNotifications", true).Return(notifications, nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stock-notifications?open=true", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"article":"MAT-004"`)
+	assert.Contains(suite.T(), w.Body.String(), `"open":true`)
+	suite.alertUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *StockAlertControllerTestSuite) TestGetNotifications_InvalidFlag() {
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stock-notifications?open=maybe", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.alertUseCase.AssertNotCalled(suite.T(), "GetNotifications", true)
+	suite.alertUseCase.AssertNotCalled(suite.T(), "GetNotifications", false)
+}
+
+func (suite *StockAlertControllerTestSuite) TestAcknowledgeNotification_NotFound() {
+	// Настройка мока
+	suite.alertUseCase.On("AcknowledgeNotification", 99).Return(entities.NewNotFoundError("уведомление", "99"))
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/stock-notifications/99/acknowledge", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+func TestStockAlertControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(StockAlertControllerTestSuite))
+}
@@ -0,0 +1,257 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"wallpaper-system/internal/adapters/controllers/dto"
+	"wallpaper-system/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StocktakeController обрабатывает HTTP запросы инвентаризации материалов
+type StocktakeController struct {
+	stocktakeUseCase usecases.StocktakeUseCaseInterface
+}
+
+// NewStocktakeController создает новый контроллер инвентаризации
+func NewStocktakeController(stocktakeUseCase usecases.StocktakeUseCaseInterface) *StocktakeController {
+	return &StocktakeController{stocktakeUseCase: stocktakeUseCase}
+}
+
+// GetStocktakes возвращает список инвентаризаций
+func (c *StocktakeController) GetStocktakes(ctx *gin.Context) {
+	stocktakes, err := c.stocktakeUseCase.GetStocktakes()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Инвентаризации получены", dto.FromStocktakes(stocktakes)))
+}
+
+// OpenStocktake открывает инвентаризацию и фиксирует учетные остатки материалов
+func (c *StocktakeController) OpenStocktake(ctx *gin.Context) {
+	var request dto.StocktakeRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса: "+err.Error()))
+		return
+	}
+
+	stocktake, err := c.stocktakeUseCase.OpenStocktake(request.ToNote())
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.NewSuccessResponse("Инвентаризация открыта", dto.FromStocktake(stocktake)))
+}
+
+// GetStocktake возвращает инвентаризацию со строками и ведомость расхождений
+func (c *StocktakeController) GetStocktake(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID инвентаризации"))
+		return
+	}
+
+	report, err := c.stocktakeUseCase.GetReport(id)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Инвентаризация получена", dto.FromStocktakeReport(report)))
+}
+
+// SaveStocktakeCounts сохраняет фактические остатки пересчитанных материалов
+func (c *StocktakeController) SaveStocktakeCounts(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID инвентаризации"))
+		return
+	}
+
+	var request dto.StocktakeCountsRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса: "+err.Error()))
+		return
+	}
+
+	report, err := c.stocktakeUseCase.SaveCounts(id, request.ToEntities())
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Фактические остатки сохранены", dto.FromStocktakeReport(report)))
+}
+
+// CloseStocktake проводит расхождения в складском журнале и закрывает инвентаризацию
+func (c *StocktakeController) CloseStocktake(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID инвентаризации"))
+		return
+	}
+
+	report, err := c.stocktakeUseCase.CloseStocktake(id)
+	if warning, ok := costRecalculationWarning(err); ok {
+		ctx.JSON(http.StatusOK, dto.NewWarningResponse("Инвентаризация закрыта", warning, dto.FromStocktakeReport(report)))
+		return
+	}
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Инвентаризация закрыта", dto.FromStocktakeReport(report)))
+}
+
+// CancelStocktake отменяет инвентаризацию без изменения остатков
+func (c *StocktakeController) CancelStocktake(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID инвентаризации"))
+		return
+	}
+
+	if err := c.stocktakeUseCase.CancelStocktake(id); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Инвентаризация отменена", nil))
+}
+
+// GetStocktakesPage отображает список инвентаризаций и форму открытия новой
+func (c *StocktakeController) GetStocktakesPage(ctx *gin.Context) {
+	c.renderStocktakesPage(ctx, http.StatusOK, "")
+}
+
+// OpenStocktakeWeb открывает инвентаризацию из формы и переходит к листу пересчета
+func (c *StocktakeController) OpenStocktakeWeb(ctx *gin.Context) {
+	var request dto.StocktakeRequest
+	_ = ctx.ShouldBind(&request)
+
+	stocktake, err := c.stocktakeUseCase.OpenStocktake(request.ToNote())
+	if err != nil {
+		c.renderStocktakesPage(ctx, errorStatus(err), "Ошибка открытия инвентаризации: "+err.Error())
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/stocktakes/"+strconv.Itoa(stocktake.ID))
+}
+
+// GetStocktakePage отображает лист пересчета и ведомость расхождений
+func (c *StocktakeController) GetStocktakePage(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID инвентаризации",
+		})
+		return
+	}
+
+	c.renderStocktakePage(ctx, id, http.StatusOK, "")
+}
+
+// SaveStocktakeCountsWeb сохраняет фактические остатки из листа пересчета
+func (c *StocktakeController) SaveStocktakeCountsWeb(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID инвентаризации",
+		})
+		return
+	}
+
+	counts, err := dto.ParseStocktakeCountsForm(ctx.PostFormMap("counted"))
+	if err != nil {
+		c.renderStocktakePage(ctx, id, http.StatusBadRequest, "Некорректные данные формы: "+err.Error())
+		return
+	}
+
+	if _, err := c.stocktakeUseCase.SaveCounts(id, counts); err != nil {
+		c.renderStocktakePage(ctx, id, errorStatus(err), "Ошибка сохранения остатков: "+err.Error())
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/stocktakes/"+strconv.Itoa(id))
+}
+
+// CloseStocktakeWeb проводит расхождения и закрывает инвентаризацию со страницы пересчета
+func (c *StocktakeController) CloseStocktakeWeb(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID инвентаризации",
+		})
+		return
+	}
+
+	_, err = c.stocktakeUseCase.CloseStocktake(id)
+	if _, ok := costRecalculationWarning(err); ok {
+		// Инвентаризация закрыта, предупреждение о пересчете показывается на ее странице
+		ctx.Redirect(http.StatusFound, "/stocktakes/"+strconv.Itoa(id)+"?cost_warning=1")
+		return
+	}
+	if err != nil {
+		c.renderStocktakePage(ctx, id, errorStatus(err), "Ошибка закрытия инвентаризации: "+err.Error())
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/stocktakes/"+strconv.Itoa(id))
+}
+
+// CancelStocktakeWeb отменяет инвентаризацию со страницы пересчета
+func (c *StocktakeController) CancelStocktakeWeb(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID инвентаризации",
+		})
+		return
+	}
+
+	if err := c.stocktakeUseCase.CancelStocktake(id); err != nil {
+		c.renderStocktakePage(ctx, id, errorStatus(err), "Ошибка отмены инвентаризации: "+err.Error())
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/stocktakes")
+}
+
+func (c *StocktakeController) renderStocktakesPage(ctx *gin.Context, status int, formError string) {
+	stocktakes, err := c.stocktakeUseCase.GetStocktakes()
+	if err != nil {
+		ctx.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "Ошибка получения инвентаризаций: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.HTML(status, "stocktakes.html", gin.H{
+		"title":      "Инвентаризация",
+		"stocktakes": dto.FromStocktakes(stocktakes),
+		"error":      formError,
+	})
+}
+
+func (c *StocktakeController) renderStocktakePage(ctx *gin.Context, id int, status int, formError string) {
+	report, err := c.stocktakeUseCase.GetReport(id)
+	if err != nil {
+		ctx.HTML(listErrorStatus(err), "error.html", gin.H{
+			"error": "Инвентаризация не найдена: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.HTML(status, "stocktake.html", gin.H{
+		"title":       "Инвентаризация №" + strconv.Itoa(id),
+		"report":      dto.FromStocktakeReport(report),
+		"error":       formError,
+		"costWarning": ctx.Query("cost_warning") != "",
+	})
+}
@@ -0,0 +1,193 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type StocktakeControllerTestSuite struct {
+	suite.Suite
+	stocktakeUseCase *mocks.MockStocktakeUseCase
+	controller       *StocktakeController
+	router           *gin.Engine
+}
+
+func (suite *StocktakeControllerTestSuite) SetupTest() {
+	suite.stocktakeUseCase = new(mocks.MockStocktakeUseCase)
+	suite.controller = NewStocktakeController(suite.stocktakeUseCase)
+
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+
+	suite.router.POST("/stocktakes/:id/counts", suite.controller.SaveStocktakeCountsWeb)
+
+	v1 := suite.router.Group("/api/v1")
+	{
+		v1.POST("/stocktakes", suite.controller.OpenStocktake)
+		v1.GET("/stocktakes/:id", suite.controller.GetStocktake)
+		v1.PUT("/stocktakes/:id/counts", suite.controller.SaveStocktakeCounts)
+		v1.POST("/stocktakes/:id/close", suite.controller.CloseStocktake)
+	}
+}
+
+func stocktakeReport(status entities.StocktakeStatus) *entities.StocktakeReport {
+	counted := 3.5
+	stocktake := &entities.Stocktake{ID: 3, Status: status, Items: []entities.StocktakeItem{
+		{StocktakeID: 3, MaterialID: 7, ExpectedQuantity: 4, CountedQuantity: &counted, UnitCost: 300,
+			Material: &entities.Material{ID: 7, Article: "VIN-01", Name: "Винил"}},
+		{StocktakeID: 3, MaterialID: 8, ExpectedQuantity: 10, UnitCost: 20},
+	}}
+	return entities.NewStocktakeReport(stocktake)
+}
+
+func (suite *StocktakeControllerTestSuite) TestOpenStocktake_AlreadyOpen() {
+	// Настройка мока
+	suite.stocktakeUseCase.On("OpenStocktake", mock.Anything).
+		Return(nil, entities.NewBusinessError("STOCKTAKE_ALREADY_OPEN", "инвентаризация №2 еще не закрыта"))
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/stocktakes", strings.NewReader(`{"note": "Квартал"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	// Нарушение бизнес-правила - конфликт с состоянием данных, а не ошибка запроса
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"success":false`)
+	assert.Contains(suite.T(), w.Body.String(), "№2")
+	suite.stocktakeUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *StocktakeControllerTestSuite) TestGetStocktake_Report() {
+	// Настройка мока
+	suite.stocktakeUseCase.On("GetReport", 3).Return(stocktakeReport(entities.StocktakeOpen), nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stocktakes/3", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Contains(suite.T(), body, `"items_counted":1`)
+	assert.Contains(suite.T(), body, `"shortage_value":150`)
+	assert.Contains(suite.T(), body, `"variance":-0.5`)
+	assert.Contains(suite.T(), body, `"article":"VIN-01"`)
+}
+
+func (suite *StocktakeControllerTestSuite) TestGetStocktake_NotFound() {
+	// Настройка мока
+	suite.stocktakeUseCase.On("GetReport", 9).Return(nil, entities.NewNotFoundError("инвентаризация", "9"))
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stocktakes/9", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+func (suite *StocktakeControllerTestSuite) TestSaveStocktakeCounts_ClearsCount() {
+	// Настройка мока: null отменяет пересчет материала
+	suite.stocktakeUseCase.On("SaveCounts", 3, mock.MatchedBy(func(counts []entities.StocktakeCount) bool {
+		return len(counts) == 2 &&
+			counts[0].MaterialID == 7 && counts[0].Quantity != nil && *counts[0].Quantity == 3.5 &&
+			counts[1].MaterialID == 8 && counts[1].Quantity == nil
+	})).Return(stocktakeReport(entities.StocktakeOpen), nil)
+
+	// Выполнение запроса
+	body := `{"counts": [{"material_id": 7, "counted_quantity": 3.5}, {"material_id": 8, "counted_quantity": null}]}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/stocktakes/3/counts", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	suite.stocktakeUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *StocktakeControllerTestSuite) TestSaveStocktakeCounts_EmptyCounts() {
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/stocktakes/3/counts", strings.NewReader(`{"counts": []}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.stocktakeUseCase.AssertNotCalled(suite.T(), "SaveCounts", mock.Anything, mock.Anything)
+}
+
+func (suite *StocktakeControllerTestSuite) TestSaveStocktakeCountsWeb_PartialCount() {
+	// Настройка мока: пустое поле означает, что материал не пересчитан
+	suite.stocktakeUseCase.On("SaveCounts", 3, mock.MatchedBy(func(counts []entities.StocktakeCount) bool {
+		return len(counts) == 2 &&
+			counts[0].MaterialID == 7 && counts[0].Quantity != nil && *counts[0].Quantity == 3.5 &&
+			counts[1].MaterialID == 8 && counts[1].Quantity == nil
+	})).Return(stocktakeReport(entities.StocktakeOpen), nil)
+
+	// Выполнение запроса
+	form := "counted%5B8%5D=&counted%5B7%5D=3%2C5"
+	req := httptest.NewRequest(http.MethodPost, "/stocktakes/3/counts", strings.NewReader(form))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusFound, w.Code)
+	assert.Equal(suite.T(), "/stocktakes/3", w.Header().Get("Location"))
+	suite.stocktakeUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *StocktakeControllerTestSuite) TestCloseStocktake_InsufficientStock() {
+	// Настройка мока: после открытия материал израсходован, недостачу списать нельзя
+	suite.stocktakeUseCase.On("CloseStocktake", 3).Return(nil,
+		entities.NewBusinessError("INSUFFICIENT_STOCK", "недостаточно материала на складе: остаток 0.2, требуется 0.5"))
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/stocktakes/3/close", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	// Нарушение бизнес-правила - конфликт с состоянием данных, а не ошибка запроса
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"success":false`)
+	assert.Contains(suite.T(), w.Body.String(), "недостаточно материала")
+	suite.stocktakeUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *StocktakeControllerTestSuite) TestCloseStocktake_CostWarning() {
+	// Настройка мока: инвентаризация закрыта, но пересчет себестоимости продукции не удался
+	suite.stocktakeUseCase.On("CloseStocktake", 3).Return(stocktakeReport(entities.StocktakeClosed),
+		entities.NewCostRecalculationError("инвентаризация закрыта", errors.New("рецептура не загружена")))
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/stocktakes/3/close", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"warning":"инвентаризация закрыта, но себестоимость продукции не пересчитана`)
+	assert.Contains(suite.T(), w.Body.String(), `"VIN-01"`)
+}
+
+func TestStocktakeControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(StocktakeControllerTestSuite))
+}
@@ -0,0 +1,261 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"wallpaper-system/internal/adapters/controllers/dto"
+	"wallpaper-system/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UnitController обрабатывает HTTP запросы для единиц измерения и их пересчета
+type UnitController struct {
+	unitUseCase     usecases.UnitConversionUseCaseInterface
+	materialUseCase usecases.MaterialUseCaseInterface
+}
+
+// NewUnitController создает новый контроллер единиц измерения
+func NewUnitController(
+	unitUseCase usecases.UnitConversionUseCaseInterface,
+	materialUseCase usecases.MaterialUseCaseInterface,
+) *UnitController {
+	return &UnitController{
+		unitUseCase:     unitUseCase,
+		materialUseCase: materialUseCase,
+	}
+}
+
+// GetMeasurementUnits возвращает список единиц измерения с категориями через API
+func (c *UnitController) GetMeasurementUnits(ctx *gin.Context) {
+	units, err := c.unitUseCase.GetUnits()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.NewErrorResponse("Ошибка получения единиц измерения: "+err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Единицы измерения получены", dto.FromMeasurementUnitEntities(units)))
+}
+
+// ConvertQuantity пересчитывает количество между единицами через API:
+// POST /api/v1/measurement-units/convert
+func (c *UnitController) ConvertQuantity(ctx *gin.Context) {
+	var request dto.UnitConversionRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса: "+err.Error()))
+		return
+	}
+
+	conversion, err := c.unitUseCase.Convert(request.MaterialID, *request.Quantity, request.From, request.To)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Количество пересчитано", dto.FromUnitConversion(conversion)))
+}
+
+// GetMaterialUnits возвращает единицы, в которых можно выразить материал, через API
+func (c *UnitController) GetMaterialUnits(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID материала"))
+		return
+	}
+
+	equivalents, err := c.unitUseCase.GetMaterialEquivalents(id)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Единицы материала получены", dto.FromUnitEquivalents(equivalents)))
+}
+
+// GetMaterialConversions возвращает пересчеты материала через API
+func (c *UnitController) GetMaterialConversions(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID материала"))
+		return
+	}
+
+	conversions, err := c.unitUseCase.GetMaterialConversions(id)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Пересчеты материала получены", dto.FromMaterialUnitConversions(conversions)))
+}
+
+// SaveMaterialConversion создает или заменяет пересчет материала через API
+func (c *UnitController) SaveMaterialConversion(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID материала"))
+		return
+	}
+
+	var request dto.MaterialUnitConversionRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные запроса: "+err.Error()))
+		return
+	}
+
+	conversion := request.ToEntity(id)
+	if err := c.unitUseCase.SaveMaterialConversion(conversion); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Пересчет материала сохранен", dto.FromMaterialUnitConversion(conversion)))
+}
+
+// DeleteMaterialConversion удаляет пересчет материала через API
+func (c *UnitController) DeleteMaterialConversion(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID материала"))
+		return
+	}
+
+	unitID, err := strconv.Atoi(ctx.Param("unit_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID единицы измерения"))
+		return
+	}
+
+	if err := c.unitUseCase.DeleteMaterialConversion(id, unitID); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Пересчет материала удален", nil))
+}
+
+// GetMaterialStock возвращает остаток материала в единице ?unit= (по умолчанию - в единице учета)
+func (c *UnitController) GetMaterialStock(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID материала"))
+		return
+	}
+
+	stock, err := c.unitUseCase.GetMaterialStock(id, ctx.Query("unit"))
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Остаток материала получен", dto.FromStockInUnit(stock)))
+}
+
+// GetMaterialUnitsPage отображает единицы материала, его пересчеты и форму добавления пересчета
+func (c *UnitController) GetMaterialUnitsPage(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID материала",
+		})
+		return
+	}
+
+	c.renderMaterialUnitsPage(ctx, id, http.StatusOK, "")
+}
+
+// SaveMaterialConversionWeb создает или заменяет пересчет материала из формы
+func (c *UnitController) SaveMaterialConversionWeb(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID материала",
+		})
+		return
+	}
+
+	var request dto.MaterialUnitConversionRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		c.renderMaterialUnitsPage(ctx, id, http.StatusBadRequest, "Некорректные данные формы: "+err.Error())
+		return
+	}
+
+	if err := c.unitUseCase.SaveMaterialConversion(request.ToEntity(id)); err != nil {
+		c.renderMaterialUnitsPage(ctx, id, errorStatus(err), "Ошибка сохранения пересчета: "+err.Error())
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/materials/"+strconv.Itoa(id)+"/units")
+}
+
+// DeleteMaterialConversionWeb удаляет пересчет материала через веб-форму
+func (c *UnitController) DeleteMaterialConversionWeb(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID материала",
+		})
+		return
+	}
+
+	unitID, err := strconv.Atoi(ctx.Param("unit_id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID единицы измерения",
+		})
+		return
+	}
+
+	if err := c.unitUseCase.DeleteMaterialConversion(id, unitID); err != nil {
+		ctx.HTML(errorStatus(err), "error.html", gin.H{
+			"error": "Ошибка удаления пересчета: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, "/materials/"+strconv.Itoa(id)+"/units")
+}
+
+func (c *UnitController) renderMaterialUnitsPage(ctx *gin.Context, id int, status int, formError string) {
+	material, err := c.materialUseCase.GetMaterialByID(id)
+	if err != nil {
+		ctx.HTML(errorStatus(err), "error.html", gin.H{
+			"error": "Материал не найден: " + err.Error(),
+		})
+		return
+	}
+
+	conversions, err := c.unitUseCase.GetMaterialConversions(id)
+	if err != nil {
+		ctx.HTML(listErrorStatus(err), "error.html", gin.H{
+			"error": "Ошибка получения пересчетов материала: " + err.Error(),
+		})
+		return
+	}
+
+	equivalents, err := c.unitUseCase.GetMaterialEquivalents(id)
+	if err != nil {
+		ctx.HTML(errorStatus(err), "error.html", gin.H{
+			"error": "Ошибка получения единиц материала: " + err.Error(),
+		})
+		return
+	}
+
+	units, err := c.unitUseCase.GetUnits()
+	if err != nil {
+		ctx.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "Ошибка получения единиц измерения: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.HTML(status, "material_units.html", gin.H{
+		"title":       "Единицы измерения материала " + material.Name,
+		"material":    material,
+		"conversions": dto.FromMaterialUnitConversions(conversions),
+		"equivalents": dto.FromUnitEquivalents(equivalents),
+		"units":       dto.FromMeasurementUnitEntities(units),
+		"error":       formError,
+	})
+}
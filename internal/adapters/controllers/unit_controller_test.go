@@ -0,0 +1,183 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type UnitControllerTestSuite struct {
+	suite.Suite
+	unitUseCase     *mocks.MockUnitConversionUseCase
+	materialUseCase *mocks.MockMaterialUseCase
+	controller      *UnitController
+	router          *gin.Engine
+}
+
+func (suite *UnitControllerTestSuite) SetupTest() {
+	suite.unitUseCase = new(mocks.MockUnitConversionUseCase)
+	suite.materialUseCase = new(mocks.MockMaterialUseCase)
+	suite.controller = NewUnitController(suite.unitUseCase, suite.materialUseCase)
+
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+
+	v1 := suite.router.Group("/api/v1")
+	{
+		v1.GET("/measurement-units", suite.controller.GetMeasurementUnits)
+		v1.POST("/measurement-units/convert", suite.controller.ConvertQuantity)
+		v1.GET("/materials/:id/stock", suite.controller.GetMaterialStock)
+		v1.POST("/materials/:id/unit-conversions", suite.controller.SaveMaterialConversion)
+	}
+}
+
+func (suite *UnitControllerTestSuite) TestGetMeasurementUnits_Success() {
+	// Настройка мока
+	suite.unitUseCase.On("GetUnits").Return([]entities.MeasurementUnit{
+		{ID: 8, Name: "грамм", Abbreviation: "г", Category: entities.UnitCategoryMass, BaseFactor: 0.001},
+	}, nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/measurement-units", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response struct {
+		Data []struct {
+			Abbreviation  string  `json:"abbreviation"`
+			Category      string  `json:"category"`
+			CategoryLabel string  `json:"category_label"`
+			BaseFactor    float64 `json:"base_factor"`
+		} `json:"data"`
+	}
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(suite.T(), response.Data, 1)
+	assert.Equal(suite.T(), "г", response.Data[0].Abbreviation)
+	assert.Equal(suite.T(), "mass", response.Data[0].Category)
+	assert.Equal(suite.T(), "Масса", response.Data[0].CategoryLabel)
+	assert.Equal(suite.T(), 0.001, response.Data[0].BaseFactor)
+}
+
+func (suite *UnitControllerTestSuite) TestConvertQuantity_Success() {
+	// Настройка мока
+	materialID := 10
+	suite.unitUseCase.On("Convert", &materialID, 3.0, "рул", "м²").Return(&entities.UnitConversion{
+		MaterialID: &materialID,
+		Quantity:   3,
+		From:       entities.MeasurementUnit{Abbreviation: "рул"},
+		To:         entities.MeasurementUnit{Abbreviation: "м²"},
+		Result:     31.959,
+	}, nil)
+
+	// Выполнение запроса
+	body := `{"quantity": 3, "from": "рул", "to": "м²", "material_id": 10}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/measurement-units/convert", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response struct {
+		Data struct {
+			Result float64 `json:"result"`
+			To     string  `json:"to"`
+		} `json:"data"`
+	}
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(suite.T(), 31.959, response.Data.Result)
+	assert.Equal(suite.T(), "м²", response.Data.To)
+}
+
+func (suite *UnitControllerTestSuite) TestConvertQuantity_Incompatible() {
+	// Настройка мока
+	suite.unitUseCase.On("Convert", (*int)(nil), 1.0, "кг", "м").Return(nil,
+		entities.NewIncompatibleUnitsError(&entities.MeasurementUnit{Abbreviation: "кг"}, &entities.MeasurementUnit{Abbreviation: "м"}))
+
+	// Выполнение запроса
+	body := `{"quantity": 1, "from": "кг", "to": "м"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/measurement-units/convert", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	// Нарушение бизнес-правила - конфликт с состоянием данных, а не ошибка запроса
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"success":false`)
+	assert.Contains(suite.T(), w.Body.String(), "несовместимы")
+	suite.unitUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *UnitControllerTestSuite) TestConvertQuantity_MissingQuantity() {
+	// Выполнение запроса
+	body := `{"from": "кг", "to": "г"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/measurement-units/convert", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.unitUseCase.AssertNotCalled(suite.T(), "Convert", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *UnitControllerTestSuite) TestGetMaterialStock_InUnit() {
+	// Настройка мока
+	suite.unitUseCase.On("GetMaterialStock", 10, "рул").Return(&entities.StockInUnit{
+		MaterialID:       10,
+		StockQuantity:    10,
+		MinStockQuantity: 2,
+		Unit:             entities.MeasurementUnit{Abbreviation: "рул"},
+	}, nil)
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/materials/10/stock?unit=%D1%80%D1%83%D0%BB", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"stock_quantity":10`)
+	assert.Contains(suite.T(), w.Body.String(), `"unit_abbreviation":"рул"`)
+}
+
+func (suite *UnitControllerTestSuite) TestSaveMaterialConversion_RollDimensions() {
+	// Настройка мока
+	suite.unitUseCase.On("SaveMaterialConversion", mock.MatchedBy(func(c *entities.MaterialUnitConversion) bool {
+		return c.MaterialID == 10 && c.UnitID == 6 && c.Factor == 0 &&
+			c.RollWidth != nil && *c.RollWidth == 1.06 && c.RollLength != nil && *c.RollLength == 10.05
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*entities.MaterialUnitConversion).Factor = 10.653
+	}).Return(nil)
+
+	// Выполнение запроса
+	body := `{"unit_id": 6, "roll_width": 1.06, "roll_length": 10.05}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/materials/10/unit-conversions", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"factor":10.653`)
+	suite.unitUseCase.AssertExpectations(suite.T())
+}
+
+func TestUnitControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(UnitControllerTestSuite))
+}
@@ -0,0 +1,404 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"wallpaper-system/internal/adapters/controllers/dto"
+	"wallpaper-system/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VariantController обрабатывает HTTP запросы для вариантов (расцветок) продукции
+type VariantController struct {
+	variantUseCase  usecases.ProductVariantUseCaseInterface
+	materialUseCase usecases.MaterialUseCaseInterface
+	unitUseCase     usecases.UnitConversionUseCaseInterface
+}
+
+// NewVariantController создает новый контроллер вариантов продукции
+func NewVariantController(
+	variantUseCase usecases.ProductVariantUseCaseInterface,
+	materialUseCase usecases.MaterialUseCaseInterface,
+	unitUseCase usecases.UnitConversionUseCaseInterface,
+) *VariantController {
+	return &VariantController{
+		variantUseCase:  variantUseCase,
+		materialUseCase: materialUseCase,
+		unitUseCase:     unitUseCase,
+	}
+}
+
+// GetProductVariants возвращает варианты продукции через API
+func (c *VariantController) GetProductVariants(ctx *gin.Context) {
+	productID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID продукции"))
+		return
+	}
+
+	product, variants, err := c.variantUseCase.GetProductVariants(productID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Варианты продукции получены", dto.FromVariantEntities(product, variants)))
+}
+
+// CreateVariant создает вариант продукции через API
+func (c *VariantController) CreateVariant(ctx *gin.Context) {
+	productID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID продукции"))
+		return
+	}
+
+	var request dto.CreateVariantRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные: "+err.Error()))
+		return
+	}
+
+	variant := request.ToEntity(productID)
+	if err := c.variantUseCase.CreateVariant(variant); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.respondWithVariant(ctx, http.StatusCreated, "Вариант продукции создан", variant.ID)
+}
+
+// GetVariantByID возвращает вариант продукции через API
+func (c *VariantController) GetVariantByID(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID варианта"))
+		return
+	}
+
+	c.respondWithVariant(ctx, http.StatusOK, "Вариант продукции получен", id)
+}
+
+// UpdateVariant изменяет суффикс артикула и цвет варианта через API
+func (c *VariantController) UpdateVariant(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID варианта"))
+		return
+	}
+
+	var request dto.UpdateVariantRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные: "+err.Error()))
+		return
+	}
+
+	if err := c.variantUseCase.UpdateVariant(request.ToEntity(id)); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.respondWithVariant(ctx, http.StatusOK, "Вариант продукции обновлен", id)
+}
+
+// DeleteVariant удаляет вариант продукции через API
+func (c *VariantController) DeleteVariant(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID варианта"))
+		return
+	}
+
+	if err := c.variantUseCase.DeleteVariant(id); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewSuccessResponse("Вариант продукции удален", nil))
+}
+
+// SetVariantOverride задает расход материала в варианте через API
+func (c *VariantController) SetVariantOverride(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID варианта"))
+		return
+	}
+
+	var request dto.VariantOverrideRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректные данные: "+err.Error()))
+		return
+	}
+
+	if _, err := c.variantUseCase.SetVariantOverride(id, request.ToEntity()); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.respondWithVariant(ctx, http.StatusOK, "Расход материала в варианте изменен", id)
+}
+
+// RemoveVariantOverride возвращает материалу расход из базовой рецептуры через API
+func (c *VariantController) RemoveVariantOverride(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID варианта"))
+		return
+	}
+
+	materialID, err := strconv.Atoi(ctx.Param("material_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID материала"))
+		return
+	}
+
+	if _, err := c.variantUseCase.RemoveVariantOverride(id, materialID); err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.respondWithVariant(ctx, http.StatusOK, "Замена материала удалена", id)
+}
+
+// GetVariantPrice рассчитывает цену варианта по его действующей рецептуре через API
+func (c *VariantController) GetVariantPrice(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID варианта"))
+		return
+	}
+
+	var partnerTypeID *int
+	if value := ctx.Query("partner_type_id"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID типа партнера"))
+			return
+		}
+		partnerTypeID = &parsed
+	}
+
+	date := time.Now()
+	if value := ctx.Query("date"); value != "" {
+		date, err = time.Parse(dto.DateLayout, value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Дата должна быть в формате ГГГГ-ММ-ДД"))
+			return
+		}
+	}
+
+	variant, err := c.variantUseCase.GetVariantByID(id)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	calculation, err := c.variantUseCase.CalculateVariantPrice(id, partnerTypeID, date)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Цена варианта рассчитана", dto.FromVariantPrice(variant, partnerTypeID, date, calculation))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetVariantExplosion возвращает потребность в сырье на заданное количество варианта через API;
+// с параметром ?unit= потребность выражается в этой единице, где это возможно
+func (c *VariantController) GetVariantExplosion(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректный ID варианта"))
+		return
+	}
+
+	quantity, err := strconv.ParseFloat(ctx.DefaultQuery("quantity", "1"), 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse("Некорректное количество продукции"))
+		return
+	}
+
+	requirements, err := c.variantUseCase.ExplodeVariantMaterials(id, quantity)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	requirements, err = c.unitUseCase.ExpressRequirements(requirements, ctx.Query("unit"))
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := dto.NewSuccessResponse("Потребность в сырье рассчитана", dto.FromMaterialRequirementEntities(requirements))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetVariantsPage отображает страницу вариантов продукции
+func (c *VariantController) GetVariantsPage(ctx *gin.Context) {
+	productID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID продукции",
+		})
+		return
+	}
+
+	product, variants, err := c.variantUseCase.GetProductVariants(productID)
+	if err != nil {
+		ctx.HTML(errorStatus(err), "error.html", gin.H{
+			"error": "Продукция не найдена: " + err.Error(),
+		})
+		return
+	}
+
+	materials, err := c.materialUseCase.GetAllMaterials()
+	if err != nil {
+		ctx.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "Ошибка загрузки материалов: " + err.Error(),
+		})
+		return
+	}
+
+	// Цена каждого варианта рассчитывается по его рецептуре; ошибка расчета не мешает показать страницу
+	date := time.Now()
+	items := dto.FromVariantEntities(product, variants)
+	for i := range items {
+		if calculation, err := c.variantUseCase.CalculateVariantPrice(items[i].ID, nil, date); err == nil {
+			price := dto.FromPriceCalculation(product.ID, nil, date, calculation)
+			items[i].Price = &price
+		}
+	}
+
+	ctx.HTML(http.StatusOK, "product_variants.html", gin.H{
+		"title":     "Варианты продукции " + product.Article,
+		"product":   dto.FromProductEntityWithMaterials(product, nil),
+		"recipe":    dto.FromProductMaterialEntities(product.Materials),
+		"variants":  items,
+		"materials": materials,
+	})
+}
+
+// CreateVariantWeb создает вариант продукции через веб-форму
+func (c *VariantController) CreateVariantWeb(ctx *gin.Context) {
+	productID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID продукции",
+		})
+		return
+	}
+
+	var request dto.CreateVariantRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректные данные формы: " + err.Error(),
+		})
+		return
+	}
+
+	if err := c.variantUseCase.CreateVariant(request.ToEntity(productID)); err != nil {
+		ctx.HTML(errorStatus(err), "error.html", gin.H{
+			"error": "Ошибка создания варианта: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, variantsPagePath(productID))
+}
+
+// UpdateVariantWeb изменяет суффикс артикула и цвет варианта через веб-форму
+func (c *VariantController) UpdateVariantWeb(ctx *gin.Context) {
+	c.variantWeb(ctx, func(id int) error {
+		var request dto.UpdateVariantRequest
+		if err := ctx.ShouldBind(&request); err != nil {
+			return err
+		}
+		return c.variantUseCase.UpdateVariant(request.ToEntity(id))
+	})
+}
+
+// DeleteVariantWeb удаляет вариант продукции через веб-форму
+func (c *VariantController) DeleteVariantWeb(ctx *gin.Context) {
+	c.variantWeb(ctx, c.variantUseCase.DeleteVariant)
+}
+
+// SetVariantOverrideWeb задает расход материала в варианте через веб-форму
+func (c *VariantController) SetVariantOverrideWeb(ctx *gin.Context) {
+	c.variantWeb(ctx, func(id int) error {
+		var request dto.VariantOverrideRequest
+		if err := ctx.ShouldBind(&request); err != nil {
+			return err
+		}
+		_, err := c.variantUseCase.SetVariantOverride(id, request.ToEntity())
+		return err
+	})
+}
+
+// RemoveVariantOverrideWeb удаляет замену материала через веб-форму
+func (c *VariantController) RemoveVariantOverrideWeb(ctx *gin.Context) {
+	c.variantWeb(ctx, func(id int) error {
+		materialID, err := strconv.Atoi(ctx.Param("material_id"))
+		if err != nil {
+			return err
+		}
+		_, err = c.variantUseCase.RemoveVariantOverride(id, materialID)
+		return err
+	})
+}
+
+// variantWeb выполняет действие над вариантом из веб-формы и возвращает на страницу
+// вариантов базовой продукции. Вариант читается до действия, так как после удаления
+// узнать продукцию уже нельзя
+func (c *VariantController) variantWeb(ctx *gin.Context, action func(id int) error) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "Некорректный ID варианта",
+		})
+		return
+	}
+
+	variant, err := c.variantUseCase.GetVariantByID(id)
+	if err != nil {
+		ctx.HTML(errorStatus(err), "error.html", gin.H{
+			"error": "Вариант не найден: " + err.Error(),
+		})
+		return
+	}
+
+	if err := action(id); err != nil {
+		ctx.HTML(errorStatus(err), "error.html", gin.H{
+			"error": "Ошибка варианта: " + err.Error(),
+		})
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, variantsPagePath(variant.ProductID))
+}
+
+// respondWithVariant отвечает вариантом вместе с данными базовой продукции
+func (c *VariantController) respondWithVariant(ctx *gin.Context, status int, message string, id int) {
+	variant, err := c.variantUseCase.GetVariantByID(id)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	product, _, err := c.variantUseCase.GetProductVariants(variant.ProductID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), dto.NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(status, dto.NewSuccessResponse(message, dto.FromVariantEntity(product, variant)))
+}
+
+// variantsPagePath возвращает адрес страницы вариантов продукции
+func variantsPagePath(productID int) string {
+	return "/products/" + strconv.Itoa(productID) + "/variants"
+}
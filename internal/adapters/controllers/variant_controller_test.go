@@ -0,0 +1,130 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type VariantControllerTestSuite struct {
+	suite.Suite
+	variantUseCase  *mocks.MockProductVariantUseCase
+	materialUseCase *mocks.MockMaterialUseCase
+	unitUseCase     *mocks.MockUnitConversionUseCase
+	controller      *VariantController
+	router          *gin.Engine
+}
+
+func (suite *VariantControllerTestSuite) SetupTest() {
+	suite.variantUseCase = new(mocks.MockProductVariantUseCase)
+	suite.materialUseCase = new(mocks.MockMaterialUseCase)
+	suite.unitUseCase = new(mocks.MockUnitConversionUseCase)
+	suite.controller = NewVariantController(suite.variantUseCase, suite.materialUseCase, suite.unitUseCase)
+
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+	v1 := suite.router.Group("/api/v1")
+	{
+		v1.POST("/products/:id/variants", suite.controller.CreateVariant)
+		v1.GET("/variants/:id/price", suite.controller.GetVariantPrice)
+	}
+}
+
+func (suite *VariantControllerTestSuite) TestCreateVariant_Success() {
+	// Подготовка данных
+	product := &entities.Product{
+		ID:        1,
+		Article:   "ART001",
+		Materials: []entities.ProductMaterial{{MaterialID: 4, QuantityPerUnit: 0.5}},
+	}
+	created := &entities.ProductVariant{
+		ID:            5,
+		ProductID:     1,
+		ArticleSuffix: "BL",
+		ColorName:     "Синий",
+		Overrides:     []entities.VariantMaterialOverride{{MaterialID: 4, QuantityPerUnit: 0.7}},
+	}
+
+	// Настройка мока
+	suite.variantUseCase.On("CreateVariant", mock.MatchedBy(func(v *entities.ProductVariant) bool {
+		return v.ProductID == 1 && v.ArticleSuffix == "BL" && len(v.Overrides) == 1 && v.Overrides[0].QuantityPerUnit == 0.7
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*entities.ProductVariant).ID = 5
+	}).Return(nil)
+	suite.variantUseCase.On("GetVariantByID", 5).Return(created, nil)
+	suite.variantUseCase.On("GetProductVariants", 1).Return(product, []entities.ProductVariant{*created}, nil)
+
+	// Выполнение запроса
+	body := `{"article_suffix": " BL ", "color_name": "Синий", "overrides": [{"material_id": 4, "quantity_per_unit": 0.7}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products/1/variants", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+
+	var response struct {
+		Data struct {
+			ID        int    `json:"id"`
+			Article   string `json:"article"`
+			Overrides []struct {
+				QuantityPerUnit float64  `json:"quantity_per_unit"`
+				BaseQuantity    *float64 `json:"base_quantity"`
+			} `json:"overrides"`
+		} `json:"data"`
+	}
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(suite.T(), "ART001-BL", response.Data.Article)
+	if assert.Len(suite.T(), response.Data.Overrides, 1) && assert.NotNil(suite.T(), response.Data.Overrides[0].BaseQuantity) {
+		assert.Equal(suite.T(), 0.5, *response.Data.Overrides[0].BaseQuantity)
+	}
+}
+
+func (suite *VariantControllerTestSuite) TestCreateVariant_DuplicateSuffix() {
+	// Настройка мока
+	suite.variantUseCase.On("CreateVariant", mock.Anything).
+		Return(entities.NewBusinessError("DUPLICATE_VARIANT", "у продукции ART001 уже есть вариант с суффиксом BL"))
+
+	// Выполнение запроса
+	body := `{"article_suffix": "BL", "color_name": "Синий"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products/1/variants", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	// Нарушение бизнес-правила - конфликт с состоянием данных, а не ошибка запроса
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"success":false`)
+	assert.Contains(suite.T(), w.Body.String(), "уже есть вариант")
+	suite.variantUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *VariantControllerTestSuite) TestGetVariantPrice_NotFound() {
+	// Настройка мока
+	suite.variantUseCase.On("GetVariantByID", 99).Return(nil, entities.NewNotFoundError("вариант продукции", "99"))
+
+	// Выполнение запроса
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/variants/99/price", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Проверки
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+	suite.variantUseCase.AssertNotCalled(suite.T(), "CalculateVariantPrice", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestVariantControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(VariantControllerTestSuite))
+}
@@ -0,0 +1,152 @@
+// Package imaging проверяет загруженные изображения и готовит их уменьшенные копии
+package imaging
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // регистрация декодера GIF
+	"image/jpeg"
+	_ "image/png" // регистрация декодера PNG
+	"net/http"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// jpegQuality - качество JPEG для уменьшенных копий
+const jpegQuality = 85
+
+// Prepare определяет формат изображения по содержимому, а не по имени файла,
+// проверяет размеры и готовит миниатюру и превью в JPEG
+func Prepare(data []byte) (*entities.ImageUpload, error) {
+	if len(data) == 0 {
+		return nil, entities.NewValidationError("image", "файл пуст")
+	}
+	if len(data) > entities.MaxImageFileSize {
+		return nil, entities.NewValidationError("image",
+			fmt.Sprintf("размер изображения превышает %d МБ", entities.MaxImageFileSize>>20))
+	}
+
+	extension, err := entities.ImageExtension(http.DetectContentType(data))
+	if err != nil {
+		return nil, err
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, entities.NewValidationError("image", "не удалось прочитать изображение")
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > entities.MaxImagePixels {
+		return nil, entities.NewValidationError("image",
+			fmt.Sprintf("размер изображения %dx%d пикселей слишком велик", config.Width, config.Height))
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, entities.NewValidationError("image", "не удалось прочитать изображение")
+	}
+
+	// Прозрачные области PNG и GIF в JPEG заливаются белым
+	flat := flatten(img)
+
+	thumbnail, err := encodeJPEG(resize(flat, entities.ThumbnailSize))
+	if err != nil {
+		return nil, err
+	}
+	preview, err := encodeJPEG(resize(flat, entities.PreviewSize))
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.ImageUpload{
+		Original:  entities.ImageFile{Data: data, Extension: extension},
+		Thumbnail: entities.ImageFile{Data: thumbnail, Extension: ".jpg"},
+		Preview:   entities.ImageFile{Data: preview, Extension: ".jpg"},
+		Width:     config.Width,
+		Height:    config.Height,
+	}, nil
+}
+
+// flatten переносит изображение на белый фон
+func flatten(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+	flat := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), img, bounds.Min, draw.Over)
+	return flat
+}
+
+// fitSize вписывает размеры изображения в ImageSize с сохранением пропорций; изображение не увеличивается
+func fitSize(width, height int, size entities.ImageSize) (int, int) {
+	if width <= size.MaxWidth && height <= size.MaxHeight {
+		return width, height
+	}
+
+	scale := float64(size.MaxWidth) / float64(width)
+	if heightScale := float64(size.MaxHeight) / float64(height); heightScale < scale {
+		scale = heightScale
+	}
+
+	w, h := int(float64(width)*scale+0.5), int(float64(height)*scale+0.5)
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	return w, h
+}
+
+// resize уменьшает изображение усреднением по площади: каждый пиксель результата -
+// среднее всех исходных пикселей, которые на него приходятся
+func resize(src *image.RGBA, size entities.ImageSize) *image.RGBA {
+	srcW, srcH := src.Bounds().Dx(), src.Bounds().Dy()
+	dstW, dstH := fitSize(srcW, srcH, size)
+	if dstW == srcW && dstH == srcH {
+		return src
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		y0, y1 := y*srcH/dstH, (y+1)*srcH/dstH
+		if y1 == y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < dstW; x++ {
+			x0, x1 := x*srcW/dstW, (x+1)*srcW/dstW
+			if x1 == x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, b, a, count uint64
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					pixel := row[sx*4 : sx*4+4]
+					r += uint64(pixel[0])
+					g += uint64(pixel[1])
+					b += uint64(pixel[2])
+					a += uint64(pixel[3])
+					count++
+				}
+			}
+
+			offset := y*dst.Stride + x*4
+			dst.Pix[offset] = uint8(r / count)
+			dst.Pix[offset+1] = uint8(g / count)
+			dst.Pix[offset+2] = uint8(b / count)
+			dst.Pix[offset+3] = uint8(a / count)
+		}
+	}
+	return dst
+}
+
+func encodeJPEG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, fmt.Errorf("ошибка сжатия изображения: %w", err)
+	}
+	return buf.Bytes(), nil
+}
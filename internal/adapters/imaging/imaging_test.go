@@ -0,0 +1,100 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			// Левая половина - красная, правая - прозрачная
+			if x < width/2 {
+				img.Set(x, y, color.NRGBA{R: 255, A: 255})
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func decodeJPEG(t *testing.T, data []byte) image.Image {
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	return img
+}
+
+func TestPrepare_PNG(t *testing.T) {
+	data := encodePNG(t, 400, 200)
+
+	upload, err := Prepare(data)
+
+	require.NoError(t, err)
+	assert.Equal(t, ".png", upload.Original.Extension)
+	assert.Equal(t, data, upload.Original.Data)
+	assert.Equal(t, 400, upload.Width)
+	assert.Equal(t, 200, upload.Height)
+
+	// Миниатюра вписана в 160x160 с сохранением пропорций
+	thumbnail := decodeJPEG(t, upload.Thumbnail.Data)
+	assert.Equal(t, ".jpg", upload.Thumbnail.Extension)
+	assert.Equal(t, image.Rect(0, 0, 160, 80), thumbnail.Bounds())
+
+	// Прозрачная часть залита белым
+	r, g, b, _ := thumbnail.At(150, 40).RGBA()
+	assert.Greater(t, r>>8, uint32(240))
+	assert.Greater(t, g>>8, uint32(240))
+	assert.Greater(t, b>>8, uint32(240))
+	r, g, _, _ = thumbnail.At(10, 40).RGBA()
+	assert.Greater(t, r>>8, uint32(200))
+	assert.Less(t, g>>8, uint32(60))
+
+	// Изображение меньше размера превью не увеличивается
+	preview := decodeJPEG(t, upload.Preview.Data)
+	assert.Equal(t, image.Rect(0, 0, 400, 200), preview.Bounds())
+}
+
+func TestPrepare_NotImage(t *testing.T) {
+	_, err := Prepare([]byte("%PDF-1.4 сертификат"))
+
+	var validationErr *entities.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+func TestPrepare_ContentTypeSniffedNotTrusted(t *testing.T) {
+	// Заголовок PNG без корректных данных изображения
+	data := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 32)...)
+
+	_, err := Prepare(data)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не удалось прочитать изображение")
+}
+
+func TestFitSize(t *testing.T) {
+	size := entities.ImageSize{MaxWidth: 160, MaxHeight: 160}
+
+	w, h := fitSize(1000, 250, size)
+	assert.Equal(t, 160, w)
+	assert.Equal(t, 40, h)
+
+	w, h = fitSize(300, 3000, size)
+	assert.Equal(t, 16, w)
+	assert.Equal(t, 160, h)
+
+	w, h = fitSize(100, 50, size)
+	assert.Equal(t, 100, w)
+	assert.Equal(t, 50, h)
+}
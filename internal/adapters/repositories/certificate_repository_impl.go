@@ -0,0 +1,369 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+
+	"github.com/lib/pq"
+)
+
+// certificateRepositoryImpl реализует интерфейс CertificateRepository
+type certificateRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewCertificateRepository создает новую реализацию репозитория сертификатов качества
+func NewCertificateRepository(db *sql.DB) repositories.CertificateRepository {
+	return &certificateRepositoryImpl{db: db}
+}
+
+// certificatesQuery выбирает сертификаты качества без связанной продукции
+const certificatesQuery = `
+	SELECT
+		c.id, c.number, c.issuing_body, c.standard_number, c.valid_from, c.valid_to,
+		c.file_path, c.notes, c.created_at, c.updated_at
+	FROM quality_certificates c
+`
+
+// GetAll возвращает все сертификаты вместе с продукцией, на которую они распространяются
+func (r *certificateRepositoryImpl) GetAll() ([]entities.QualityCertificate, error) {
+	rows, err := r.db.Query(certificatesQuery + " ORDER BY c.valid_to, c.number")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса сертификатов: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanWithProducts(rows)
+}
+
+// GetByID возвращает сертификат по ID
+func (r *certificateRepositoryImpl) GetByID(id int) (*entities.QualityCertificate, error) {
+	rows, err := r.db.Query(certificatesQuery+" WHERE c.id = $1", id)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения сертификата: %w", err)
+	}
+	defer rows.Close()
+
+	certificates, err := r.scanWithProducts(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(certificates) == 0 {
+		return nil, entities.NewNotFoundError("сертификат", strconv.Itoa(id))
+	}
+
+	return &certificates[0], nil
+}
+
+// GetByProduct возвращает сертификаты, распространяющиеся на продукцию
+func (r *certificateRepositoryImpl) GetByProduct(productID int) ([]entities.QualityCertificate, error) {
+	query := certificatesQuery + `
+	WHERE c.id IN (SELECT certificate_id FROM product_certificates WHERE product_id = $1)
+	ORDER BY c.valid_to DESC, c.number
+	`
+
+	rows, err := r.db.Query(query, productID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения сертификатов продукции: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanWithProducts(rows)
+}
+
+// Create создает сертификат и его связи с продукцией в одной транзакции
+func (r *certificateRepositoryImpl) Create(certificate *entities.QualityCertificate) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO quality_certificates (number, issuing_body, standard_number, valid_from, valid_to, file_path, notes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at
+	`
+
+	err = tx.QueryRow(query, certificate.Number, certificate.IssuingBody, certificate.StandardNumber,
+		certificate.ValidFrom, certificate.ValidTo, certificate.FilePath, certificate.Notes).Scan(
+		&certificate.ID, &certificate.CreatedAt, &certificate.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка создания сертификата: %w", err)
+	}
+
+	if err := insertCertificateProducts(tx, certificate.ID, certificate.ProductIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %w", err)
+	}
+
+	return nil
+}
+
+// Update обновляет сертификат и заменяет его связи с продукцией в одной транзакции.
+// Ссылка на скан меняется только через UpdateFilePath
+func (r *certificateRepositoryImpl) Update(certificate *entities.QualityCertificate) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE quality_certificates
+		SET number = $1, issuing_body = $2, standard_number = $3, valid_from = $4, valid_to = $5,
+		    notes = $6, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $7
+		RETURNING file_path, created_at, updated_at
+	`
+
+	err = tx.QueryRow(query, certificate.Number, certificate.IssuingBody, certificate.StandardNumber,
+		certificate.ValidFrom, certificate.ValidTo, certificate.Notes, certificate.ID).Scan(
+		&certificate.FilePath, &certificate.CreatedAt, &certificate.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return entities.NewNotFoundError("сертификат", strconv.Itoa(certificate.ID))
+	}
+	if err != nil {
+		return fmt.Errorf("ошибка обновления сертификата: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM product_certificates WHERE certificate_id = $1", certificate.ID); err != nil {
+		return fmt.Errorf("ошибка очистки продукции сертификата: %w", err)
+	}
+	if err := insertCertificateProducts(tx, certificate.ID, certificate.ProductIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %w", err)
+	}
+
+	return nil
+}
+
+// Delete удаляет сертификат; связи с продукцией удаляются каскадно
+func (r *certificateRepositoryImpl) Delete(id int) error {
+	result, err := r.db.Exec("DELETE FROM quality_certificates WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления сертификата: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества удаленных строк: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.NewNotFoundError("сертификат", strconv.Itoa(id))
+	}
+
+	return nil
+}
+
+// UpdateFilePath сохраняет ссылку на скан сертификата
+func (r *certificateRepositoryImpl) UpdateFilePath(id int, filePath *string) error {
+	query := "UPDATE quality_certificates SET file_path = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1"
+
+	result, err := r.db.Exec(query, id, filePath)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения скана сертификата: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества обновленных строк: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.NewNotFoundError("сертификат", strconv.Itoa(id))
+	}
+
+	return nil
+}
+
+// GetExpiring возвращает неархивную продукцию, у которой самый поздний сертификат
+// действует не дольше указанной даты (включая уже истекшие), по возрастанию срока
+func (r *certificateRepositoryImpl) GetExpiring(until time.Time) ([]entities.ExpiringCertificate, error) {
+	query := `
+		SELECT product_id, article, name, certificate_id, number, issuing_body, valid_to
+		FROM (
+			SELECT DISTINCT ON (p.id)
+				p.id AS product_id, p.article, p.name,
+				c.id AS certificate_id, c.number, c.issuing_body, c.valid_to
+			FROM product_certificates pc
+			JOIN products p ON pc.product_id = p.id
+			JOIN quality_certificates c ON pc.certificate_id = c.id
+			WHERE p.archived_at IS NULL
+			ORDER BY p.id, c.valid_to DESC, c.id DESC
+		) latest
+		WHERE valid_to <= $1::date
+		ORDER BY valid_to, article
+	`
+
+	rows, err := r.db.Query(query, until)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения истекающих сертификатов: %w", err)
+	}
+	defer rows.Close()
+
+	var result []entities.ExpiringCertificate
+	for rows.Next() {
+		var item entities.ExpiringCertificate
+		err := rows.Scan(
+			&item.Product.ID, &item.Product.Article, &item.Product.Name,
+			&item.CertificateID, &item.CertificateNumber, &item.IssuingBody, &item.ValidTo,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования истекающего сертификата: %w", err)
+		}
+		result = append(result, item)
+	}
+
+	return result, rows.Err()
+}
+
+// GetCertification возвращает состояние сертификации продукции на дату
+func (r *certificateRepositoryImpl) GetCertification(productIDs []int, date time.Time) ([]entities.ProductCertification, error) {
+	query := `
+		SELECT
+			p.id, p.article, p.name,
+			MAX(c.valid_to) FILTER (WHERE c.valid_from <= $2::date AND c.valid_to >= $2::date),
+			MAX(c.valid_to)
+		FROM products p
+		LEFT JOIN product_certificates pc ON pc.product_id = p.id
+		LEFT JOIN quality_certificates c ON pc.certificate_id = c.id
+		WHERE p.id = ANY($1)
+		GROUP BY p.id, p.article, p.name
+		ORDER BY p.article
+	`
+
+	rows, err := r.db.Query(query, pq.Array(productIDs), date)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения сертификации продукции: %w", err)
+	}
+	defer rows.Close()
+
+	var result []entities.ProductCertification
+	for rows.Next() {
+		var item entities.ProductCertification
+		var validTo, lastValidTo sql.NullTime
+		if err := rows.Scan(&item.Product.ID, &item.Product.Article, &item.Product.Name, &validTo, &lastValidTo); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования сертификации продукции: %w", err)
+		}
+		if validTo.Valid {
+			item.ValidTo = &validTo.Time
+		}
+		if lastValidTo.Valid {
+			item.LastValidTo = &lastValidTo.Time
+		}
+		result = append(result, item)
+	}
+
+	return result, rows.Err()
+}
+
+// GetOrderProductIDs возвращает ID продукции в позициях заказа
+func (r *certificateRepositoryImpl) GetOrderProductIDs(orderID int) ([]int, error) {
+	var exists bool
+	if err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM orders WHERE id = $1)", orderID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("ошибка получения заказа: %w", err)
+	}
+	if !exists {
+		return nil, entities.NewNotFoundError("заказ", strconv.Itoa(orderID))
+	}
+
+	rows, err := r.db.Query("SELECT DISTINCT product_id FROM order_items WHERE order_id = $1 ORDER BY product_id", orderID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения позиций заказа: %w", err)
+	}
+	defer rows.Close()
+
+	var productIDs []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования позиции заказа: %w", err)
+		}
+		productIDs = append(productIDs, id)
+	}
+
+	return productIDs, rows.Err()
+}
+
+// scanWithProducts сканирует сертификаты и загружает продукцию всех сертификатов одним запросом
+func (r *certificateRepositoryImpl) scanWithProducts(rows *sql.Rows) ([]entities.QualityCertificate, error) {
+	var certificates []entities.QualityCertificate
+	for rows.Next() {
+		var certificate entities.QualityCertificate
+		err := rows.Scan(
+			&certificate.ID, &certificate.Number, &certificate.IssuingBody, &certificate.StandardNumber,
+			&certificate.ValidFrom, &certificate.ValidTo, &certificate.FilePath, &certificate.Notes,
+			&certificate.CreatedAt, &certificate.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования сертификата: %w", err)
+		}
+		certificates = append(certificates, certificate)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(certificates) == 0 {
+		return certificates, nil
+	}
+
+	index := make(map[int]int, len(certificates))
+	ids := make([]int, len(certificates))
+	for i, certificate := range certificates {
+		index[certificate.ID] = i
+		ids[i] = certificate.ID
+	}
+
+	query := `
+		SELECT pc.certificate_id, p.id, p.article, p.name
+		FROM product_certificates pc
+		JOIN products p ON pc.product_id = p.id
+		WHERE pc.certificate_id = ANY($1)
+		ORDER BY p.article
+	`
+
+	productRows, err := r.db.Query(query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения продукции сертификатов: %w", err)
+	}
+	defer productRows.Close()
+
+	for productRows.Next() {
+		var certificateID int
+		var product entities.CertificateProduct
+		if err := productRows.Scan(&certificateID, &product.ID, &product.Article, &product.Name); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования продукции сертификата: %w", err)
+		}
+		certificate := &certificates[index[certificateID]]
+		certificate.Products = append(certificate.Products, product)
+		certificate.ProductIDs = append(certificate.ProductIDs, product.ID)
+	}
+
+	return certificates, productRows.Err()
+}
+
+func insertCertificateProducts(tx *sql.Tx, certificateID int, productIDs []int) error {
+	for _, productID := range productIDs {
+		_, err := tx.Exec("INSERT INTO product_certificates (certificate_id, product_id) VALUES ($1, $2)",
+			certificateID, productID)
+		if err != nil {
+			return fmt.Errorf("ошибка привязки продукции %d к сертификату: %w", productID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// materialCostingRepositoryImpl реализует интерфейс MaterialCostingRepository
+type materialCostingRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewMaterialCostingRepository создает новую реализацию репозитория себестоимости материалов
+func NewMaterialCostingRepository(db *sql.DB) repositories.MaterialCostingRepository {
+	return &materialCostingRepositoryImpl{db: db}
+}
+
+// materialCostingColumns выбирает себестоимость материала; порядок столбцов соответствует
+// scanMaterialCosting
+const materialCostingColumns = `
+	SELECT id, stock_quantity, costing_method, cost_locked, actual_cost, cost_per_unit
+	FROM materials
+	WHERE id = $1`
+
+func scanMaterialCosting(row rowScanner, materialID int) (*entities.MaterialCosting, error) {
+	var costing entities.MaterialCosting
+	err := row.Scan(&costing.MaterialID, &costing.StockQuantity, &costing.Method, &costing.Locked,
+		&costing.ActualCost, &costing.CostPerUnit)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.NewNotFoundError("материал", strconv.Itoa(materialID))
+		}
+		return nil, fmt.Errorf("ошибка получения себестоимости материала: %w", err)
+	}
+	return &costing, nil
+}
+
+// GetByMaterialID возвращает себестоимость материала вместе с материалом
+func (r *materialCostingRepositoryImpl) GetByMaterialID(materialID int) (*entities.MaterialCosting, error) {
+	costing, err := scanMaterialCosting(r.db.QueryRow(materialCostingColumns, materialID), materialID)
+	if err != nil {
+		return nil, err
+	}
+
+	var material entities.Material
+	var unitAbbr string
+	err = r.db.QueryRow(`
+		SELECT m.id, m.article, m.name, mu.symbol
+		FROM materials m
+		JOIN measurement_units mu ON m.measurement_unit_id = mu.id
+		WHERE m.id = $1`, materialID).Scan(&material.ID, &material.Article, &material.Name, &unitAbbr)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения материала: %w", err)
+	}
+	material.StockQuantity = costing.StockQuantity
+	material.CostPerUnit = costing.CostPerUnit
+	material.MeasurementUnit = &entities.MeasurementUnit{Abbreviation: unitAbbr}
+	costing.Material = &material
+
+	return costing, nil
+}
+
+// Update сохраняет настройки себестоимости и пересчитывает ее по журналу
+func (r *materialCostingRepositoryImpl) Update(costing *entities.MaterialCosting) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	locked, err := lockMaterialCosting(tx, costing.MaterialID)
+	if err != nil {
+		return err
+	}
+	costing.StockQuantity = locked.StockQuantity
+
+	if err := recostMaterial(tx, costing); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("UPDATE materials SET costing_method = $2, cost_locked = $3, updated_at = CURRENT_TIMESTAMP WHERE id = $1",
+		costing.MaterialID, costing.Method, costing.Locked)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения метода расчета себестоимости: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %w", err)
+	}
+
+	return nil
+}
+
+// Recalculate пересчитывает себестоимость материала по журналу текущим методом
+func (r *materialCostingRepositoryImpl) Recalculate(materialID int) (*entities.MaterialCosting, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	costing, err := lockMaterialCosting(tx, materialID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recostMaterial(tx, costing); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка подтверждения транзакции: %w", err)
+	}
+
+	return costing, nil
+}
+
+// lockMaterialCosting блокирует строку материала до конца транзакции и возвращает его остаток
+// и себестоимость
+func lockMaterialCosting(db dbExecutor, materialID int) (*entities.MaterialCosting, error) {
+	return scanMaterialCosting(db.QueryRow(materialCostingColumns+" FOR UPDATE", materialID), materialID)
+}
+
+// saveMovementCost сохраняет себестоимость проведенного движения, пересчитывает по остаткам
+// партий себестоимость материала при FIFO и переносит ее в cost_per_unit, если она не зафиксирована.
+// Изменение cost_per_unit отмечается в movement.CostChanged
+func saveMovementCost(db dbExecutor, costing *entities.MaterialCosting, movement *entities.MaterialMovement) error {
+	_, err := db.Exec("UPDATE material_movements SET unit_cost = $2, total_cost = $3 WHERE id = $1",
+		movement.ID, movement.UnitCost, movement.TotalCost)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения себестоимости движения: %w", err)
+	}
+
+	if costing.Method == entities.CostingFIFO {
+		var quantity, value float64
+		err := db.QueryRow(`
+			SELECT COALESCE(SUM(remaining_quantity), 0), COALESCE(SUM(remaining_quantity * unit_cost), 0)
+			FROM material_lots
+			WHERE material_id = $1`, costing.MaterialID).Scan(&quantity, &value)
+		if err != nil {
+			return fmt.Errorf("ошибка получения стоимости остатков партий: %w", err)
+		}
+		costing.RevalueLots(quantity, value)
+	}
+
+	movement.CostChanged, err = saveActualCost(db, costing)
+	return err
+}
+
+// saveActualCost сохраняет фактическую себестоимость материала и cost_per_unit.
+// Возвращает true, если cost_per_unit изменилась
+func saveActualCost(db dbExecutor, costing *entities.MaterialCosting) (bool, error) {
+	changed := costing.SyncCostPerUnit()
+	_, err := db.Exec("UPDATE materials SET actual_cost = $2, cost_per_unit = $3 WHERE id = $1",
+		costing.MaterialID, costing.ActualCost, costing.CostPerUnit)
+	if err != nil {
+		return false, fmt.Errorf("ошибка сохранения себестоимости материала: %w", err)
+	}
+	return changed, nil
+}
+
+// recostMaterial загружает журнал заблокированного материала, заново рассчитывает
+// себестоимость и сохраняет цены партий приходов без поставки, себестоимость движений
+// и материала
+func recostMaterial(db dbExecutor, costing *entities.MaterialCosting) error {
+	ledger, err := loadCostLedger(db, costing.MaterialID)
+	if err != nil {
+		return err
+	}
+
+	costing.Recost(ledger)
+
+	for _, lot := range ledger.Lots {
+		if lot.MovementID == nil || lot.SupplyID != nil {
+			continue
+		}
+		if _, err := db.Exec("UPDATE material_lots SET unit_cost = $2 WHERE id = $1", lot.ID, lot.UnitCost); err != nil {
+			return fmt.Errorf("ошибка сохранения цены партии: %w", err)
+		}
+	}
+	for _, movement := range ledger.Movements {
+		_, err := db.Exec("UPDATE material_movements SET unit_cost = $2, total_cost = $3 WHERE id = $1",
+			movement.ID, movement.UnitCost, movement.TotalCost)
+		if err != nil {
+			return fmt.Errorf("ошибка сохранения себестоимости движения: %w", err)
+		}
+	}
+
+	_, err = saveActualCost(db, costing)
+	return err
+}
+
+// loadCostLedger загружает партии материала, движения, которые их создали или забрали из них
+// материал, в порядке проведения, и расход из партий. Движения до начала учета партий
+// в расчет не входят: их остаток учтен партиями без движения прихода
+func loadCostLedger(db dbExecutor, materialID int) (*entities.CostLedger, error) {
+	ledger := &entities.CostLedger{}
+
+	rows, err := db.Query(`
+		SELECT id, supply_id, initial_quantity, remaining_quantity, unit_cost, movement_id
+		FROM material_lots
+		WHERE material_id = $1
+		ORDER BY received_at, id`, materialID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения партий материала: %w", err)
+	}
+	for rows.Next() {
+		lot := entities.MaterialLot{MaterialID: materialID}
+		var supplyID, movementID sql.NullInt64
+		if err := rows.Scan(&lot.ID, &supplyID, &lot.InitialQuantity, &lot.RemainingQuantity, &lot.UnitCost, &movementID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("ошибка сканирования партии материала: %w", err)
+		}
+		if supplyID.Valid {
+			id := int(supplyID.Int64)
+			lot.SupplyID = &id
+		}
+		if movementID.Valid {
+			id := int(movementID.Int64)
+			lot.MovementID = &id
+		}
+		ledger.Lots = append(ledger.Lots, lot)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения партий материала: %w", err)
+	}
+
+	rows, err = db.Query(`
+		SELECT c.lot_id, c.movement_id, c.quantity
+		FROM material_lot_consumptions c
+		JOIN material_lots l ON c.lot_id = l.id
+		WHERE l.material_id = $1
+		ORDER BY c.id`, materialID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения расхода из партий: %w", err)
+	}
+	for rows.Next() {
+		var consumption entities.LotConsumption
+		if err := rows.Scan(&consumption.LotID, &consumption.MovementID, &consumption.Quantity); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("ошибка сканирования расхода из партии: %w", err)
+		}
+		ledger.Consumptions = append(ledger.Consumptions, consumption)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения расхода из партий: %w", err)
+	}
+
+	rows, err = db.Query(`
+		SELECT id, movement_type, quantity
+		FROM material_movements mv
+		WHERE mv.material_id = $1 AND (
+			EXISTS (SELECT 1 FROM material_lots l WHERE l.movement_id = mv.id)
+			OR EXISTS (SELECT 1 FROM material_lot_consumptions c WHERE c.movement_id = mv.id)
+		)
+		ORDER BY id`, materialID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения движений материала: %w", err)
+	}
+	for rows.Next() {
+		movement := entities.MaterialMovement{MaterialID: materialID}
+		if err := rows.Scan(&movement.ID, &movement.Type, &movement.Quantity); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("ошибка сканирования движения материала: %w", err)
+		}
+		ledger.Movements = append(ledger.Movements, movement)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения движений материала: %w", err)
+	}
+
+	return ledger, nil
+}
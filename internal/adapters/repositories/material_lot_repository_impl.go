@@ -0,0 +1,307 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// materialLotRepositoryImpl реализует интерфейс MaterialLotRepository
+type materialLotRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewMaterialLotRepository создает новую реализацию репозитория партий материалов
+func NewMaterialLotRepository(db *sql.DB) repositories.MaterialLotRepository {
+	return &materialLotRepositoryImpl{db: db}
+}
+
+// materialLotColumns и materialLotJoins выбирают партию вместе с материалом и поставщиком;
+// порядок столбцов соответствует scanMaterialLot
+const (
+	materialLotColumns = `
+		l.id, l.material_id, l.supply_id, l.batch_number, l.received_at,
+		l.initial_quantity, l.remaining_quantity, l.unit_cost, l.movement_id, l.created_at,
+		m.article, m.name, mu.symbol as abbreviation, sp.id, sp.name, sp.inn`
+	materialLotJoins = `
+		JOIN materials m ON l.material_id = m.id
+		JOIN measurement_units mu ON m.measurement_unit_id = mu.id
+		LEFT JOIN material_supplies ms ON l.supply_id = ms.id
+		LEFT JOIN suppliers sp ON ms.supplier_id = sp.id`
+)
+
+// scanMaterialLot считывает столбцы materialLotColumns; extra - дополнительные столбцы,
+// выбранные перед ними
+func scanMaterialLot(row rowScanner, extra ...interface{}) (*entities.MaterialLot, error) {
+	var lot entities.MaterialLot
+	var material entities.Material
+	var supplyID, movementID, supplierID sql.NullInt64
+	var unitAbbr string
+	var supplierName, supplierINN sql.NullString
+
+	dest := append(extra,
+		&lot.ID, &lot.MaterialID, &supplyID, &lot.BatchNumber, &lot.ReceivedAt,
+		&lot.InitialQuantity, &lot.RemainingQuantity, &lot.UnitCost, &movementID, &lot.CreatedAt,
+		&material.Article, &material.Name, &unitAbbr, &supplierID, &supplierName, &supplierINN,
+	)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	if supplyID.Valid {
+		id := int(supplyID.Int64)
+		lot.SupplyID = &id
+	}
+	if movementID.Valid {
+		id := int(movementID.Int64)
+		lot.MovementID = &id
+	}
+	if supplierID.Valid {
+		lot.Supplier = &entities.Supplier{ID: int(supplierID.Int64), Name: supplierName.String, INN: supplierINN.String}
+	}
+	material.ID = lot.MaterialID
+	material.MeasurementUnit = &entities.MeasurementUnit{Abbreviation: unitAbbr}
+	lot.Material = &material
+
+	return &lot, nil
+}
+
+// GetSuppliers возвращает поставщиков по названию
+func (r *materialLotRepositoryImpl) GetSuppliers() ([]entities.Supplier, error) {
+	rows, err := r.db.Query("SELECT id, name, inn FROM suppliers ORDER BY name, id")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения поставщиков: %w", err)
+	}
+	defer rows.Close()
+
+	suppliers := []entities.Supplier{}
+	for rows.Next() {
+		var supplier entities.Supplier
+		if err := rows.Scan(&supplier.ID, &supplier.Name, &supplier.INN); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования поставщика: %w", err)
+		}
+		suppliers = append(suppliers, supplier)
+	}
+
+	return suppliers, rows.Err()
+}
+
+// ReceiveSupply сохраняет поставку и проводит приход в партию поставки
+func (r *materialLotRepositoryImpl) ReceiveSupply(supply *entities.MaterialSupply, lot *entities.MaterialLot) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	var supplierExists bool
+	if err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM suppliers WHERE id = $1)", supply.SupplierID).Scan(&supplierExists); err != nil {
+		return fmt.Errorf("ошибка получения поставщика: %w", err)
+	}
+	if !supplierExists {
+		return entities.NewNotFoundError("поставщик", strconv.Itoa(supply.SupplierID))
+	}
+
+	var materialExists bool
+	if err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM materials WHERE id = $1)", supply.MaterialID).Scan(&materialExists); err != nil {
+		return fmt.Errorf("ошибка получения материала: %w", err)
+	}
+	if !materialExists {
+		return entities.NewNotFoundError("материал", strconv.Itoa(supply.MaterialID))
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO material_supplies (supplier_id, material_id, quantity, unit_price, total_amount, supply_date)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`,
+		supply.SupplierID, supply.MaterialID, supply.Quantity, supply.UnitPrice, supply.TotalAmount, supply.SupplyDate,
+	).Scan(&supply.ID, &supply.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения поставки: %w", err)
+	}
+
+	supplyID := supply.ID
+	note := "Поставка"
+	if lot.BatchNumber != nil {
+		note += ", партия поставщика " + *lot.BatchNumber
+	}
+	movement := &entities.MaterialMovement{
+		MaterialID:    supply.MaterialID,
+		Type:          entities.MovementIncome,
+		Quantity:      supply.Quantity,
+		ReferenceID:   &supplyID,
+		ReferenceType: entities.ReferenceSupply,
+		Note:          &note,
+	}
+	lot.SupplyID = &supplyID
+	if err := postReceipt(tx, movement, lot); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %w", err)
+	}
+
+	return nil
+}
+
+// GetMaterialLots возвращает партии материала, начиная с тех, что будут израсходованы первыми
+func (r *materialLotRepositoryImpl) GetMaterialLots(materialID int) ([]entities.MaterialLot, error) {
+	rows, err := r.db.Query("SELECT"+materialLotColumns+" FROM material_lots l"+materialLotJoins+`
+		WHERE l.material_id = $1
+		ORDER BY l.received_at, l.id`, materialID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения партий материала: %w", err)
+	}
+	defer rows.Close()
+
+	lots := []entities.MaterialLot{}
+	for rows.Next() {
+		lot, err := scanMaterialLot(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования партии материала: %w", err)
+		}
+		lots = append(lots, *lot)
+	}
+
+	return lots, rows.Err()
+}
+
+// GetByID возвращает партию по ID
+func (r *materialLotRepositoryImpl) GetByID(id int) (*entities.MaterialLot, error) {
+	row := r.db.QueryRow("SELECT"+materialLotColumns+" FROM material_lots l"+materialLotJoins+" WHERE l.id = $1", id)
+	lot, err := scanMaterialLot(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.NewNotFoundError("партия материала", strconv.Itoa(id))
+		}
+		return nil, fmt.Errorf("ошибка получения партии материала: %w", err)
+	}
+	return lot, nil
+}
+
+// lotConsumptionQuery выбирает расход из партий вместе с движением и партией
+const lotConsumptionQuery = `
+	SELECT c.id, c.quantity, mv.id, mv.movement_type, mv.quantity, mv.remaining_quantity,
+		mv.reference_id, mv.reference_type, mv.note, mv.unit_cost, mv.total_cost, mv.created_at,` + materialLotColumns + `
+	FROM material_lot_consumptions c
+	JOIN material_movements mv ON c.movement_id = mv.id
+	JOIN material_lots l ON c.lot_id = l.id` + materialLotJoins
+
+// GetLotConsumptions возвращает расход из партии в порядке проведения
+func (r *materialLotRepositoryImpl) GetLotConsumptions(lotID int) ([]entities.LotConsumption, error) {
+	return r.queryConsumptions(lotConsumptionQuery+" WHERE c.lot_id = $1 ORDER BY mv.created_at, c.id", lotID)
+}
+
+// GetOrderConsumptions возвращает расход из партий по заказу в порядке материалов
+func (r *materialLotRepositoryImpl) GetOrderConsumptions(orderID int) ([]entities.LotConsumption, error) {
+	return r.queryConsumptions(lotConsumptionQuery+`
+		WHERE mv.reference_type = 'order' AND mv.reference_id = $1
+		ORDER BY m.article, l.received_at, l.id`, orderID)
+}
+
+func (r *materialLotRepositoryImpl) queryConsumptions(query string, args ...interface{}) ([]entities.LotConsumption, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения расхода из партий: %w", err)
+	}
+	defer rows.Close()
+
+	consumptions := []entities.LotConsumption{}
+	for rows.Next() {
+		var consumption entities.LotConsumption
+		var movement entities.MaterialMovement
+		var referenceID sql.NullInt64
+		var referenceType sql.NullString
+		lot, err := scanMaterialLot(rows,
+			&consumption.ID, &consumption.Quantity, &movement.ID, &movement.Type, &movement.Quantity,
+			&movement.RemainingQuantity, &referenceID, &referenceType, &movement.Note,
+			&movement.UnitCost, &movement.TotalCost, &movement.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования расхода из партии: %w", err)
+		}
+		if referenceID.Valid {
+			id := int(referenceID.Int64)
+			movement.ReferenceID = &id
+		}
+		movement.ReferenceType = entities.MovementReference(referenceType.String)
+		movement.MaterialID = lot.MaterialID
+
+		consumption.LotID = lot.ID
+		consumption.MovementID = movement.ID
+		consumption.Lot = lot
+		consumption.Movement = &movement
+		consumptions = append(consumptions, consumption)
+	}
+
+	return consumptions, rows.Err()
+}
+
+// insertMaterialLot сохраняет новую партию материала
+func insertMaterialLot(db dbExecutor, lot *entities.MaterialLot) error {
+	err := db.QueryRow(`
+		INSERT INTO material_lots (
+			material_id, supply_id, batch_number, received_at, initial_quantity, remaining_quantity, unit_cost, movement_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at`,
+		lot.MaterialID, lot.SupplyID, lot.BatchNumber, lot.ReceivedAt,
+		lot.InitialQuantity, lot.RemainingQuantity, lot.UnitCost, lot.MovementID,
+	).Scan(&lot.ID, &lot.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка создания партии материала: %w", err)
+	}
+	return nil
+}
+
+// drawFromLots забирает количество проведенного расхода или списания из партий материала
+// по FIFO, записывает, сколько взято из каждой партии, и возвращает взятое с ценами партий
+func drawFromLots(db dbExecutor, movement *entities.MaterialMovement) ([]entities.LotConsumption, error) {
+	rows, err := db.Query(`
+		SELECT id, received_at, remaining_quantity, unit_cost
+		FROM material_lots
+		WHERE material_id = $1 AND remaining_quantity > 0
+		ORDER BY received_at, id
+		FOR UPDATE`, movement.MaterialID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения партий материала: %w", err)
+	}
+
+	var lots []entities.MaterialLot
+	for rows.Next() {
+		lot := entities.MaterialLot{MaterialID: movement.MaterialID}
+		if err := rows.Scan(&lot.ID, &lot.ReceivedAt, &lot.RemainingQuantity, &lot.UnitCost); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("ошибка сканирования партии материала: %w", err)
+		}
+		lots = append(lots, lot)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения партий материала: %w", err)
+	}
+
+	consumptions, err := entities.AllocateFIFO(lots, movement.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range consumptions {
+		consumption := &consumptions[i]
+		_, err := db.Exec("UPDATE material_lots SET remaining_quantity = $2 WHERE id = $1",
+			consumption.LotID, consumption.Lot.RemainingQuantity)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка изменения остатка партии: %w", err)
+		}
+		err = db.QueryRow("INSERT INTO material_lot_consumptions (lot_id, movement_id, quantity) VALUES ($1, $2, $3) RETURNING id",
+			consumption.LotID, movement.ID, consumption.Quantity).Scan(&consumption.ID)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка записи расхода из партии: %w", err)
+		}
+		consumption.MovementID = movement.ID
+	}
+
+	return consumptions, nil
+}
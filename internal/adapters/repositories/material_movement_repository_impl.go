@@ -0,0 +1,222 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// materialMovementRepositoryImpl реализует интерфейс MaterialMovementRepository
+type materialMovementRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewMaterialMovementRepository создает новую реализацию складского журнала материалов
+func NewMaterialMovementRepository(db *sql.DB) repositories.MaterialMovementRepository {
+	return &materialMovementRepositoryImpl{db: db}
+}
+
+// Post проводит движение в отдельной транзакции
+func (r *materialMovementRepositoryImpl) Post(movement *entities.MaterialMovement) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := postMovement(tx, movement); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %w", err)
+	}
+
+	return nil
+}
+
+// PostBatch проводит движения по порядку в одной транзакции: если хотя бы одно движение
+// не проходит, остатки не меняются
+func (r *materialMovementRepositoryImpl) PostBatch(movements []entities.MaterialMovement) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i := range movements {
+		if err := postMovement(tx, &movements[i]); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %w", err)
+	}
+
+	return nil
+}
+
+// FindByCriteria возвращает страницу журнала движений материала
+func (r *materialMovementRepositoryImpl) FindByCriteria(criteria entities.MovementCriteria) ([]entities.MaterialMovement, int, error) {
+	where := &whereClause{}
+	where.add("material_id = ?", criteria.MaterialID)
+	if criteria.Type != "" {
+		where.add("movement_type = ?", string(criteria.Type))
+	}
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM material_movements"+where.String(), where.args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("ошибка подсчета движений материала: %w", err)
+	}
+
+	query := `
+		SELECT id, material_id, movement_type, quantity, remaining_quantity,
+			reference_id, reference_type, note, unit_cost, total_cost, created_at
+		FROM material_movements` + where.String() + " ORDER BY created_at DESC, id DESC"
+	query += fmt.Sprintf(" LIMIT %s OFFSET %s", where.nextArg(criteria.PageSize), where.nextArg(criteria.Offset()))
+
+	rows, err := r.db.Query(query, where.args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка получения движений материала: %w", err)
+	}
+	defer rows.Close()
+
+	movements := []entities.MaterialMovement{}
+	for rows.Next() {
+		var movement entities.MaterialMovement
+		var referenceID sql.NullInt64
+		var referenceType sql.NullString
+		if err := rows.Scan(&movement.ID, &movement.MaterialID, &movement.Type, &movement.Quantity,
+			&movement.RemainingQuantity, &referenceID, &referenceType, &movement.Note,
+			&movement.UnitCost, &movement.TotalCost, &movement.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("ошибка сканирования движения материала: %w", err)
+		}
+		if referenceID.Valid {
+			id := int(referenceID.Int64)
+			movement.ReferenceID = &id
+		}
+		movement.ReferenceType = entities.MovementReference(referenceType.String)
+		movements = append(movements, movement)
+	}
+
+	return movements, total, rows.Err()
+}
+
+// postMovement проводит движение, ведет партии материала и себестоимость: приход создает
+// партию без поставки по текущей себестоимости, расход и списание забирают материал из партий
+// по FIFO и оцениваются методом материала. Вызывается внутри транзакции
+func postMovement(db dbExecutor, movement *entities.MaterialMovement) error {
+	costing, err := applyMovement(db, movement)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case movement.Delta() > 0:
+		return receiveLot(db, costing, movement, entities.NewMaterialLot(movement))
+	case movement.Delta() < 0:
+		consumptions, err := drawFromLots(db, movement)
+		if err != nil {
+			return err
+		}
+		costing.Issue(movement, consumptions)
+		return saveMovementCost(db, costing, movement)
+	}
+	return nil
+}
+
+// postReceipt проводит приход материала в партию lot, например партию поставки по ее цене
+func postReceipt(db dbExecutor, movement *entities.MaterialMovement, lot *entities.MaterialLot) error {
+	costing, err := applyMovement(db, movement)
+	if err != nil {
+		return err
+	}
+
+	movementID := movement.ID
+	lot.MovementID = &movementID
+	return receiveLot(db, costing, movement, lot)
+}
+
+// receiveLot оценивает проведенный приход, создает его партию и сохраняет себестоимость
+func receiveLot(db dbExecutor, costing *entities.MaterialCosting, movement *entities.MaterialMovement, lot *entities.MaterialLot) error {
+	costing.PriceLot(lot)
+	costing.Receive(movement, movement.RemainingQuantity-movement.Quantity, lot.UnitCost)
+	if err := insertMaterialLot(db, lot); err != nil {
+		return err
+	}
+	return saveMovementCost(db, costing, movement)
+}
+
+// applyMovement блокирует строку материала, рассчитывает остаток после движения, не давая расходу
+// без заказа забрать зарезервированный материал, сохраняет остаток в материале и записывает
+// движение в журнал. Возвращает себестоимость материала до движения
+func applyMovement(db dbExecutor, movement *entities.MaterialMovement) (*entities.MaterialCosting, error) {
+	costing, err := lockMaterialCosting(db, movement.MaterialID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := movement.ApplyTo(costing.StockQuantity); err != nil {
+		return nil, err
+	}
+	if movement.Delta() < 0 {
+		var reserved float64
+		err := db.QueryRow(`
+			SELECT COALESCE(SUM(quantity), 0) FROM material_reservations
+			WHERE material_id = $1 AND status = 'active'`, movement.MaterialID).Scan(&reserved)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка получения резерва материала: %w", err)
+		}
+		if err := movement.CheckAvailable(costing.StockQuantity, reserved); err != nil {
+			return nil, err
+		}
+	}
+
+	if movement.Delta() != 0 {
+		_, err = db.Exec("UPDATE materials SET stock_quantity = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1",
+			movement.MaterialID, movement.RemainingQuantity)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка изменения остатка материала: %w", err)
+		}
+	}
+
+	query := `
+		INSERT INTO material_movements (
+			material_id, movement_type, quantity, remaining_quantity, reference_id, reference_type, note
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+	referenceType := sql.NullString{String: string(movement.ReferenceType), Valid: movement.ReferenceType != ""}
+	err = db.QueryRow(query,
+		movement.MaterialID, movement.Type, movement.Quantity, movement.RemainingQuantity,
+		movement.ReferenceID, referenceType, movement.Note,
+	).Scan(&movement.ID, &movement.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка записи движения материала: %w", err)
+	}
+
+	return costing, nil
+}
+
+// postStockAdjustment проводит движение, приводящее остаток материала к target. Остаток
+// и себестоимость материала после движения переносятся в material
+func postStockAdjustment(db dbExecutor, material *entities.Material, target float64, note string) error {
+	adjustment := entities.NewStockAdjustment(material.ID, material.StockQuantity, target, note)
+	if adjustment == nil {
+		return nil
+	}
+	if err := postMovement(db, adjustment); err != nil {
+		return err
+	}
+	material.StockQuantity = adjustment.RemainingQuantity
+	if adjustment.CostChanged {
+		err := db.QueryRow("SELECT cost_per_unit FROM materials WHERE id = $1", material.ID).Scan(&material.CostPerUnit)
+		if err != nil {
+			return fmt.Errorf("ошибка получения себестоимости материала: %w", err)
+		}
+	}
+	return nil
+}
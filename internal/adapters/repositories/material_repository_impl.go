@@ -4,9 +4,12 @@ import (
 	"database/sql"
 	"fmt"
 	"strconv"
+	"time"
 
 	"wallpaper-system/internal/domain/entities"
 	"wallpaper-system/internal/domain/repositories"
+
+	"github.com/lib/pq"
 )
 
 // materialRepositoryImpl реализует интерфейс MaterialRepository
@@ -19,106 +22,62 @@ func NewMaterialRepository(db *sql.DB) repositories.MaterialRepository {
 	return &materialRepositoryImpl{db: db}
 }
 
-// GetAll возвращает список всех материалов
-func (r *materialRepositoryImpl) GetAll() ([]entities.Material, error) {
-	query := `
-		SELECT 
-			m.id, m.article, m.material_type_id, m.name, m.description,
-			m.measurement_unit_id, m.package_quantity, m.cost_per_unit,
-					m.stock_quantity, m.min_stock_quantity, m.image_path,
-		m.created_at, m.updated_at,
+// materialSelectQuery выбирает материалы вместе с типом и единицей измерения; порядок столбцов соответствует scanMaterial
+const materialSelectQuery = `
+	SELECT
+		m.id, m.article, m.material_type_id, m.name, m.description,
+		m.measurement_unit_id, m.package_quantity, m.cost_per_unit, m.cost_locked,
+		m.stock_quantity, m.min_stock_quantity, m.image_path,
+		m.thumbnail_path, m.preview_path, m.archived_at, m.created_at, m.updated_at,
 		mt.name as type_name, mt.defect_rate,
-		mu.name as unit_name, mu.symbol as abbreviation
+		mu.name as unit_name, mu.symbol as abbreviation,
+		COALESCE(mr.reserved_quantity, 0) as reserved_quantity
 	FROM materials m
 	JOIN material_types mt ON m.material_type_id = mt.id
 	JOIN measurement_units mu ON m.measurement_unit_id = mu.id
-	ORDER BY m.name
-	`
-
-	rows, err := r.db.Query(query)
-	if err != nil {
-		return nil, fmt.Errorf("ошибка выполнения запроса материалов: %w", err)
-	}
-	defer rows.Close()
-
-	var materials []entities.Material
-	for rows.Next() {
-		var material entities.Material
-		var typeName string
-		var defectRate float64
-		var unitName, unitAbbr string
-
-		err := rows.Scan(
-			&material.ID, &material.Article, &material.MaterialTypeID, &material.Name,
-			&material.Description, &material.MeasurementUnitID, &material.PackageQuantity,
-			&material.CostPerUnit, &material.StockQuantity, &material.MinStockQuantity,
-			&material.ImagePath, &material.CreatedAt, &material.UpdatedAt,
-			&typeName, &defectRate, &unitName, &unitAbbr,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("ошибка сканирования материала: %w", err)
-		}
-
-		// Заполняем связанные данные
-		material.MaterialType = &entities.MaterialType{
-			ID:              material.MaterialTypeID,
-			Name:            typeName,
-			WastePercentage: defectRate,
-		}
-
-		material.MeasurementUnit = &entities.MeasurementUnit{
-			ID:           material.MeasurementUnitID,
-			Name:         unitName,
-			Abbreviation: unitAbbr,
-		}
-
-		materials = append(materials, material)
-	}
-
-	return materials, nil
+	LEFT JOIN (
+		SELECT material_id, SUM(quantity) as reserved_quantity
+		FROM material_reservations
+		WHERE status = 'active'
+		GROUP BY material_id
+	) mr ON mr.material_id = m.id
+`
+
+// materialSortColumns сопоставляет поля сортировки списка материалов со столбцами
+var materialSortColumns = map[string]string{
+	entities.MaterialSortArticle:   "m.article",
+	entities.MaterialSortName:      "m.name",
+	entities.MaterialSortType:      "mt.name",
+	entities.MaterialSortCost:      "m.cost_per_unit",
+	entities.MaterialSortStock:     "m.stock_quantity",
+	entities.MaterialSortCreatedAt: "m.created_at",
 }
 
-// GetByID возвращает материал по ID
-func (r *materialRepositoryImpl) GetByID(id int) (*entities.Material, error) {
-	query := `
-		SELECT 
-			m.id, m.article, m.material_type_id, m.name, m.description,
-			m.measurement_unit_id, m.package_quantity, m.cost_per_unit,
-					m.stock_quantity, m.min_stock_quantity, m.image_path,
-		m.created_at, m.updated_at,
-		mt.name as type_name, mt.defect_rate,
-		mu.name as unit_name, mu.symbol as abbreviation
-	FROM materials m
-	JOIN material_types mt ON m.material_type_id = mt.id
-	JOIN measurement_units mu ON m.measurement_unit_id = mu.id
-	WHERE m.id = $1
-	`
-
+// scanMaterial считывает строку materialSelectQuery
+func scanMaterial(row rowScanner) (*entities.Material, error) {
 	var material entities.Material
 	var typeName string
 	var defectRate float64
 	var unitName, unitAbbr string
+	var reserved float64
 
-	err := r.db.QueryRow(query, id).Scan(
+	err := row.Scan(
 		&material.ID, &material.Article, &material.MaterialTypeID, &material.Name,
 		&material.Description, &material.MeasurementUnitID, &material.PackageQuantity,
-		&material.CostPerUnit, &material.StockQuantity, &material.MinStockQuantity,
-		&material.ImagePath, &material.CreatedAt, &material.UpdatedAt,
-		&typeName, &defectRate, &unitName, &unitAbbr,
+		&material.CostPerUnit, &material.CostLocked, &material.StockQuantity, &material.MinStockQuantity,
+		&material.ImagePath, &material.ThumbnailPath, &material.PreviewPath,
+		&material.ArchivedAt, &material.CreatedAt, &material.UpdatedAt,
+		&typeName, &defectRate, &unitName, &unitAbbr, &reserved,
 	)
-
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, entities.NewNotFoundError("материал", strconv.Itoa(id))
-		}
-		return nil, fmt.Errorf("ошибка получения материала: %w", err)
+		return nil, err
 	}
 
 	// Заполняем связанные данные
 	material.MaterialType = &entities.MaterialType{
 		ID:              material.MaterialTypeID,
 		Name:            typeName,
-		WastePercentage: defectRate,
+		WastePercentage: defectRateToPercentage(defectRate),
 	}
 
 	material.MeasurementUnit = &entities.MeasurementUnit{
@@ -126,32 +85,100 @@ func (r *materialRepositoryImpl) GetByID(id int) (*entities.Material, error) {
 		Name:         unitName,
 		Abbreviation: unitAbbr,
 	}
+	material.SetReservedQuantity(reserved)
 
 	return &material, nil
 }
 
-// GetMaterialTypeByID возвращает тип материала по ID
-func (r *materialRepositoryImpl) GetMaterialTypeByID(id int) (*entities.MaterialType, error) {
-	query := `
-		SELECT id, name, waste_percentage, created_at, updated_at 
-		FROM material_types 
-		WHERE id = $1
-	`
+// GetAll возвращает список всех материалов, кроме архивных
+func (r *materialRepositoryImpl) GetAll() ([]entities.Material, error) {
+	rows, err := r.db.Query(materialSelectQuery + " WHERE m.archived_at IS NULL ORDER BY m.name")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса материалов: %w", err)
+	}
+	defer rows.Close()
 
-	var materialType entities.MaterialType
-	err := r.db.QueryRow(query, id).Scan(
-		&materialType.ID, &materialType.Name, &materialType.WastePercentage,
-		&materialType.CreatedAt, &materialType.UpdatedAt,
-	)
+	var materials []entities.Material
+	for rows.Next() {
+		material, err := scanMaterial(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования материала: %w", err)
+		}
+		materials = append(materials, *material)
+	}
+
+	return materials, nil
+}
+
+// FindByCriteria возвращает страницу материалов по фильтрам и общее количество найденных записей
+func (r *materialRepositoryImpl) FindByCriteria(criteria entities.MaterialCriteria) ([]entities.Material, int, error) {
+	where := &whereClause{}
+	if criteria.MaterialTypeID != nil {
+		where.add("m.material_type_id = ?", *criteria.MaterialTypeID)
+	}
+	if criteria.MinCost != nil {
+		where.add("m.cost_per_unit >= ?", *criteria.MinCost)
+	}
+	if criteria.MaxCost != nil {
+		where.add("m.cost_per_unit <= ?", *criteria.MaxCost)
+	}
+	if criteria.ArticlePrefix != "" {
+		where.add("m.article ILIKE ?", likePrefix(criteria.ArticlePrefix))
+	}
+	if criteria.BelowMinStock {
+		where.add("m.stock_quantity < m.min_stock_quantity")
+	}
+	if !criteria.IncludeArchived {
+		where.add("m.archived_at IS NULL")
+	}
+
+	var total int
+	countQuery := "SELECT COUNT(*) FROM materials m JOIN material_types mt ON m.material_type_id = mt.id" + where.String()
+	if err := r.db.QueryRow(countQuery, where.args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("ошибка подсчета материалов: %w", err)
+	}
+
+	query := materialSelectQuery + where.String() +
+		orderBy(materialSortColumns, criteria.SortBy, criteria.SortDirection, "m.id")
+	query += fmt.Sprintf(" LIMIT %s OFFSET %s", where.nextArg(criteria.PageSize), where.nextArg(criteria.Offset()))
+
+	rows, err := r.db.Query(query, where.args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка выполнения запроса материалов: %w", err)
+	}
+	defer rows.Close()
 
+	materials := []entities.Material{}
+	for rows.Next() {
+		material, err := scanMaterial(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("ошибка сканирования материала: %w", err)
+		}
+		materials = append(materials, *material)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("ошибка чтения материалов: %w", err)
+	}
+
+	return materials, total, nil
+}
+
+// GetByID возвращает материал по ID
+func (r *materialRepositoryImpl) GetByID(id int) (*entities.Material, error) {
+	material, err := scanMaterial(r.db.QueryRow(materialSelectQuery+" WHERE m.id = $1", id))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, entities.NewNotFoundError("тип материала", strconv.Itoa(id))
+			return nil, entities.NewNotFoundError("материал", strconv.Itoa(id))
 		}
-		return nil, fmt.Errorf("ошибка получения типа материала: %w", err)
+		return nil, fmt.Errorf("ошибка получения материала: %w", err)
 	}
 
-	return &materialType, nil
+	return material, nil
+}
+
+// GetMaterialTypeByID возвращает тип материала по ID
+func (r *materialRepositoryImpl) GetMaterialTypeByID(id int) (*entities.MaterialType, error) {
+	return queryMaterialTypeByID(r.db, id)
 }
 
 // GetProductTypeByID возвращает тип продукции по ID
@@ -180,26 +207,7 @@ func (r *materialRepositoryImpl) GetProductTypeByID(id int) (*entities.ProductTy
 
 // GetMaterialTypes возвращает все типы материалов
 func (r *materialRepositoryImpl) GetMaterialTypes() ([]entities.MaterialType, error) {
-	query := "SELECT id, name, defect_rate, created_at, updated_at FROM material_types ORDER BY name"
-
-	rows, err := r.db.Query(query)
-	if err != nil {
-		return nil, fmt.Errorf("ошибка выполнения запроса типов материалов: %w", err)
-	}
-	defer rows.Close()
-
-	var types []entities.MaterialType
-	for rows.Next() {
-		var materialType entities.MaterialType
-		err := rows.Scan(&materialType.ID, &materialType.Name, &materialType.WastePercentage,
-			&materialType.CreatedAt, &materialType.UpdatedAt)
-		if err != nil {
-			return nil, fmt.Errorf("ошибка сканирования типа материала: %w", err)
-		}
-		types = append(types, materialType)
-	}
-
-	return types, nil
+	return queryMaterialTypes(r.db)
 }
 
 // GetMaterialsForProduct возвращает материалы для конкретной продукции
@@ -248,7 +256,7 @@ func (r *materialRepositoryImpl) GetMaterialsForProduct(productID int) ([]entiti
 		material.MaterialType = &entities.MaterialType{
 			ID:              material.MaterialTypeID,
 			Name:            typeName,
-			WastePercentage: defectRate,
+			WastePercentage: defectRateToPercentage(defectRate),
 		}
 
 		material.MeasurementUnit = &entities.MeasurementUnit{
@@ -263,47 +271,128 @@ func (r *materialRepositoryImpl) GetMaterialsForProduct(productID int) ([]entiti
 	return materials, nil
 }
 
-// Create создает новый материал
+// Create создает новый материал; начальный остаток проводится приходом в складском журнале
 func (r *materialRepositoryImpl) Create(material *entities.Material) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertMaterial(tx, material); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %w", err)
+	}
+
+	return nil
+}
+
+// Update обновляет существующий материал. Остаток на складе не меняется - он изменяется
+// только движениями складского журнала; себестоимость меняется, только если зафиксирована
+func (r *materialRepositoryImpl) Update(material *entities.Material) error {
+	return updateMaterial(r.db, material)
+}
+
+// GetByArticles возвращает материалы, включая архивные, с указанными артикулами
+func (r *materialRepositoryImpl) GetByArticles(articles []string) ([]entities.Material, error) {
+	rows, err := r.db.Query(materialSelectQuery+" WHERE m.article = ANY($1)", pq.Array(articles))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения материалов по артикулам: %w", err)
+	}
+	defer rows.Close()
+
+	var materials []entities.Material
+	for rows.Next() {
+		material, err := scanMaterial(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования материала: %w", err)
+		}
+		materials = append(materials, *material)
+	}
+
+	return materials, rows.Err()
+}
+
+// SaveBatch создает материалы без ID и обновляет материалы с ID в одной транзакции.
+// Отличие остатка из файла от текущего проводится движением складского журнала; остаток
+// и себестоимость после проведения переносятся в materials
+func (r *materialRepositoryImpl) SaveBatch(materials []entities.Material) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i := range materials {
+		if materials[i].ID == 0 {
+			err = insertMaterial(tx, &materials[i])
+		} else {
+			err = updateMaterialStock(tx, &materials[i], "Остаток из файла импорта")
+		}
+		if err != nil {
+			return fmt.Errorf("артикул %s: %w", materials[i].Article, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %w", err)
+	}
+
+	return nil
+}
+
+// insertMaterial создает материал с нулевым остатком и проводит начальный остаток приходом
+func insertMaterial(db dbExecutor, material *entities.Material) error {
 	query := `
 		INSERT INTO materials (
 			article, material_type_id, name, description, measurement_unit_id,
 			package_quantity, cost_per_unit, stock_quantity, min_stock_quantity, image_path
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, 0, $8, $9)
 		RETURNING id, created_at, updated_at
 	`
 
-	err := r.db.QueryRow(query,
+	initialStock := material.StockQuantity
+	err := db.QueryRow(query,
 		material.Article, material.MaterialTypeID, material.Name, material.Description,
 		material.MeasurementUnitID, material.PackageQuantity, material.CostPerUnit,
-		material.StockQuantity, material.MinStockQuantity, material.ImagePath,
+		material.MinStockQuantity, material.ImagePath,
 	).Scan(&material.ID, &material.CreatedAt, &material.UpdatedAt)
 
 	if err != nil {
 		return fmt.Errorf("ошибка создания материала: %w", err)
 	}
 
-	return nil
+	material.StockQuantity = 0
+	return postStockAdjustment(db, material, initialStock, "Начальный остаток")
 }
 
-// Update обновляет существующий материал
-func (r *materialRepositoryImpl) Update(material *entities.Material) error {
+// updateMaterial обновляет материал без остатка на складе и возвращает в material текущие
+// остаток и себестоимость. Себестоимость записывается только зафиксированная: незафиксированную
+// ведет проведение движений, и проверка фиксации в том же UPDATE не дает разойтись с ним.
+// Уменьшенные копии относятся к прежнему изображению, поэтому при замене пути
+// к изображению вручную они сбрасываются
+func updateMaterial(db dbExecutor, material *entities.Material) error {
 	query := `
 		UPDATE materials SET
 			article = $2, material_type_id = $3, name = $4, description = $5,
-			measurement_unit_id = $6, package_quantity = $7, cost_per_unit = $8,
-			stock_quantity = $9, min_stock_quantity = $10, image_path = $11,
+			measurement_unit_id = $6, package_quantity = $7,
+			cost_per_unit = CASE WHEN cost_locked THEN $8 ELSE cost_per_unit END,
+			min_stock_quantity = $9, image_path = $10,
+			thumbnail_path = CASE WHEN image_path IS NOT DISTINCT FROM $10 THEN thumbnail_path END,
+			preview_path = CASE WHEN image_path IS NOT DISTINCT FROM $10 THEN preview_path END,
 			updated_at = CURRENT_TIMESTAMP
 		WHERE id = $1
-		RETURNING updated_at
+		RETURNING updated_at, stock_quantity, cost_per_unit
 	`
 
-	err := r.db.QueryRow(query,
+	err := db.QueryRow(query,
 		material.ID, material.Article, material.MaterialTypeID, material.Name,
 		material.Description, material.MeasurementUnitID, material.PackageQuantity,
-		material.CostPerUnit, material.StockQuantity, material.MinStockQuantity,
-		material.ImagePath,
-	).Scan(&material.UpdatedAt)
+		material.CostPerUnit, material.MinStockQuantity, material.ImagePath,
+	).Scan(&material.UpdatedAt, &material.StockQuantity, &material.CostPerUnit)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -315,13 +404,32 @@ func (r *materialRepositoryImpl) Update(material *entities.Material) error {
 	return nil
 }
 
-// Delete удаляет материал по ID
-func (r *materialRepositoryImpl) Delete(id int) error {
-	query := "DELETE FROM materials WHERE id = $1"
+// updateMaterialStock обновляет материал и проводит движение, приводящее остаток
+// к material.StockQuantity
+func updateMaterialStock(db dbExecutor, material *entities.Material, note string) error {
+	target := material.StockQuantity
+	if err := updateMaterial(db, material); err != nil {
+		return err
+	}
+	return postStockAdjustment(db, material, target, note)
+}
+
+// Archive переносит материал в архив. Строки рецептур, ссылающиеся на материал, сохраняются
+func (r *materialRepositoryImpl) Archive(id int, archivedAt time.Time) error {
+	return r.setArchivedAt(id, &archivedAt)
+}
+
+// Restore возвращает материал из архива
+func (r *materialRepositoryImpl) Restore(id int) error {
+	return r.setArchivedAt(id, nil)
+}
 
-	result, err := r.db.Exec(query, id)
+func (r *materialRepositoryImpl) setArchivedAt(id int, archivedAt *time.Time) error {
+	query := "UPDATE materials SET archived_at = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1"
+
+	result, err := r.db.Exec(query, id, archivedAt)
 	if err != nil {
-		return fmt.Errorf("ошибка удаления материала: %w", err)
+		return fmt.Errorf("ошибка изменения архивного статуса материала: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -336,28 +444,32 @@ func (r *materialRepositoryImpl) Delete(id int) error {
 	return nil
 }
 
-// GetMeasurementUnits возвращает все единицы измерения
-func (r *materialRepositoryImpl) GetMeasurementUnits() ([]entities.MeasurementUnit, error) {
-	query := "SELECT id, name, symbol, created_at FROM measurement_units ORDER BY name"
+// UpdateImages сохраняет ссылки на изображение материала и его уменьшенные копии
+func (r *materialRepositoryImpl) UpdateImages(id int, images entities.ImagePaths) error {
+	query := `
+		UPDATE materials
+		SET image_path = $2, thumbnail_path = $3, preview_path = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
 
-	rows, err := r.db.Query(query)
+	result, err := r.db.Exec(query, id, images.ImagePath, images.ThumbnailPath, images.PreviewPath)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка выполнения запроса единиц измерения: %w", err)
+		return fmt.Errorf("ошибка сохранения изображения материала: %w", err)
 	}
-	defer rows.Close()
 
-	var units []entities.MeasurementUnit
-	for rows.Next() {
-		var unit entities.MeasurementUnit
-		var symbol string
-		err := rows.Scan(&unit.ID, &unit.Name, &symbol, &unit.CreatedAt)
-		if err != nil {
-			return nil, fmt.Errorf("ошибка сканирования единицы измерения: %w", err)
-		}
-		// Устанавливаем Abbreviation из поля symbol
-		unit.Abbreviation = symbol
-		units = append(units, unit)
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества обновленных строк: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.NewNotFoundError("материал", strconv.Itoa(id))
 	}
 
-	return units, nil
+	return nil
+}
+
+// GetMeasurementUnits возвращает все единицы измерения
+func (r *materialRepositoryImpl) GetMeasurementUnits() ([]entities.MeasurementUnit, error) {
+	return queryMeasurementUnits(r.db)
 }
@@ -0,0 +1,259 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"strconv"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// materialTypeRepositoryImpl реализует интерфейс MaterialTypeRepository
+type materialTypeRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewMaterialTypeRepository создает новую реализацию репозитория типов материалов
+func NewMaterialTypeRepository(db *sql.DB) repositories.MaterialTypeRepository {
+	return &materialTypeRepositoryImpl{db: db}
+}
+
+// materialTypeSelectQuery выбирает типы материалов со всеми полями; порядок столбцов соответствует scanMaterialType
+const materialTypeSelectQuery = `
+	SELECT id, name, description, defect_rate, created_at, updated_at
+	FROM material_types
+`
+
+// defectRatePercentScale переводит долю брака из столбца defect_rate (0.0500) в проценты (5)
+const defectRatePercentScale = 100
+
+// defectRateToPercentage переводит долю брака в проценты. Значение округляется до точности
+// хранения DECIMAL(5,4), чтобы 0.0300 читалось как 3, а не 3.0000000000000004
+func defectRateToPercentage(defectRate float64) float64 {
+	return math.Round(defectRate*defectRatePercentScale*100) / 100
+}
+
+// percentageToDefectRate переводит процент брака в долю для столбца defect_rate
+func percentageToDefectRate(percentage float64) float64 {
+	return percentage / defectRatePercentScale
+}
+
+// scanMaterialType сканирует строку materialTypeSelectQuery
+func scanMaterialType(row rowScanner) (*entities.MaterialType, error) {
+	var materialType entities.MaterialType
+	var defectRate float64
+	err := row.Scan(
+		&materialType.ID, &materialType.Name, &materialType.Description, &defectRate,
+		&materialType.CreatedAt, &materialType.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	materialType.WastePercentage = defectRateToPercentage(defectRate)
+	return &materialType, nil
+}
+
+// queryMaterialTypes возвращает все типы материалов по названию
+func queryMaterialTypes(db *sql.DB) ([]entities.MaterialType, error) {
+	rows, err := db.Query(materialTypeSelectQuery + " ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса типов материалов: %w", err)
+	}
+	defer rows.Close()
+
+	types := []entities.MaterialType{}
+	for rows.Next() {
+		materialType, err := scanMaterialType(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования типа материала: %w", err)
+		}
+		types = append(types, *materialType)
+	}
+
+	return types, rows.Err()
+}
+
+// queryMaterialTypeByID возвращает тип материала по ID
+func queryMaterialTypeByID(db *sql.DB, id int) (*entities.MaterialType, error) {
+	materialType, err := scanMaterialType(db.QueryRow(materialTypeSelectQuery+" WHERE id = $1", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.NewNotFoundError("тип материала", strconv.Itoa(id))
+		}
+		return nil, fmt.Errorf("ошибка получения типа материала: %w", err)
+	}
+	return materialType, nil
+}
+
+// GetAll возвращает все типы материалов
+func (r *materialTypeRepositoryImpl) GetAll() ([]entities.MaterialType, error) {
+	return queryMaterialTypes(r.db)
+}
+
+// GetByID возвращает тип материала по ID
+func (r *materialTypeRepositoryImpl) GetByID(id int) (*entities.MaterialType, error) {
+	return queryMaterialTypeByID(r.db, id)
+}
+
+// Create создает тип материала и записывает начальный процент брака в историю в одной транзакции
+func (r *materialTypeRepositoryImpl) Create(materialType *entities.MaterialType, change *entities.DefectRateChange) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO material_types (name, description, defect_rate)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at
+	`
+
+	err = tx.QueryRow(query, materialType.Name, materialType.Description,
+		percentageToDefectRate(materialType.WastePercentage)).Scan(
+		&materialType.ID, &materialType.CreatedAt, &materialType.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка создания типа материала: %w", err)
+	}
+
+	change.MaterialTypeID = materialType.ID
+	if err := insertDefectRateChange(tx, change); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %w", err)
+	}
+
+	return nil
+}
+
+// Update обновляет тип материала; при изменении процента брака change записывается в историю
+// в той же транзакции
+func (r *materialTypeRepositoryImpl) Update(materialType *entities.MaterialType, change *entities.DefectRateChange) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE material_types
+		SET name = $1, description = $2, defect_rate = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $4
+		RETURNING updated_at
+	`
+
+	err = tx.QueryRow(query, materialType.Name, materialType.Description,
+		percentageToDefectRate(materialType.WastePercentage), materialType.ID).Scan(&materialType.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entities.NewNotFoundError("тип материала", strconv.Itoa(materialType.ID))
+		}
+		return fmt.Errorf("ошибка обновления типа материала: %w", err)
+	}
+
+	if change != nil {
+		change.MaterialTypeID = materialType.ID
+		if err := insertDefectRateChange(tx, change); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %w", err)
+	}
+
+	return nil
+}
+
+// insertDefectRateChange записывает изменение процента брака в историю
+func insertDefectRateChange(tx *sql.Tx, change *entities.DefectRateChange) error {
+	query := `
+		INSERT INTO material_type_defect_rate_history
+			(material_type_id, old_defect_rate, new_defect_rate, changed_by, comment)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+
+	var oldDefectRate *float64
+	if change.OldWastePercentage != nil {
+		rate := percentageToDefectRate(*change.OldWastePercentage)
+		oldDefectRate = &rate
+	}
+
+	err := tx.QueryRow(query, change.MaterialTypeID, oldDefectRate, percentageToDefectRate(change.NewWastePercentage),
+		change.ChangedBy, change.Comment).Scan(&change.ID, &change.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка записи истории процента брака: %w", err)
+	}
+	return nil
+}
+
+// Delete удаляет тип материала вместе с историей
+func (r *materialTypeRepositoryImpl) Delete(id int) error {
+	result, err := r.db.Exec("DELETE FROM material_types WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления типа материала: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества удаленных строк: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.NewNotFoundError("тип материала", strconv.Itoa(id))
+	}
+
+	return nil
+}
+
+// CountMaterials возвращает количество материалов типа, включая архивные
+func (r *materialTypeRepositoryImpl) CountMaterials(materialTypeID int) (int, error) {
+	var count int
+	err := r.db.QueryRow("SELECT COUNT(*) FROM materials WHERE material_type_id = $1", materialTypeID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка подсчета материалов типа: %w", err)
+	}
+	return count, nil
+}
+
+// GetDefectRateHistory возвращает историю процента брака, начиная с последнего изменения
+func (r *materialTypeRepositoryImpl) GetDefectRateHistory(materialTypeID int) ([]entities.DefectRateChange, error) {
+	query := `
+		SELECT id, material_type_id, old_defect_rate, new_defect_rate, changed_by, comment, created_at
+		FROM material_type_defect_rate_history
+		WHERE material_type_id = $1
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := r.db.Query(query, materialTypeID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения истории процента брака: %w", err)
+	}
+	defer rows.Close()
+
+	history := []entities.DefectRateChange{}
+	for rows.Next() {
+		var change entities.DefectRateChange
+		var oldDefectRate sql.NullFloat64
+		var newDefectRate float64
+		err := rows.Scan(&change.ID, &change.MaterialTypeID, &oldDefectRate, &newDefectRate,
+			&change.ChangedBy, &change.Comment, &change.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования истории процента брака: %w", err)
+		}
+		if oldDefectRate.Valid {
+			old := defectRateToPercentage(oldDefectRate.Float64)
+			change.OldWastePercentage = &old
+		}
+		change.NewWastePercentage = defectRateToPercentage(newDefectRate)
+		history = append(history, change)
+	}
+
+	return history, rows.Err()
+}
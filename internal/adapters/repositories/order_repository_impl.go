@@ -0,0 +1,282 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// orderRepositoryImpl реализует интерфейс OrderRepository
+type orderRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewOrderRepository создает новую реализацию репозитория заказов
+func NewOrderRepository(db *sql.DB) repositories.OrderRepository {
+	return &orderRepositoryImpl{db: db}
+}
+
+// GetByID возвращает заказ по ID
+func (r *orderRepositoryImpl) GetByID(id int) (*entities.Order, error) {
+	query := `
+		SELECT id, partner_id, manager_id, status, total_amount, COALESCE(prepayment_amount, 0),
+			COALESCE(delivery_required, FALSE), delivery_address, created_at, updated_at
+		FROM orders
+		WHERE id = $1
+	`
+
+	var order entities.Order
+	var managerID sql.NullInt64
+	err := r.db.QueryRow(query, id).Scan(&order.ID, &order.PartnerID, &managerID, &order.Status,
+		&order.TotalAmount, &order.PrepaymentAmount, &order.DeliveryRequired, &order.DeliveryAddress,
+		&order.CreatedAt, &order.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.NewNotFoundError("заказ", strconv.Itoa(id))
+		}
+		return nil, fmt.Errorf("ошибка получения заказа: %w", err)
+	}
+	if managerID.Valid {
+		manager := int(managerID.Int64)
+		order.ManagerID = &manager
+	}
+
+	return &order, nil
+}
+
+// GetItems возвращает позиции заказа в порядке добавления
+func (r *orderRepositoryImpl) GetItems(orderID int) ([]entities.OrderItem, error) {
+	var exists bool
+	if err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM orders WHERE id = $1)", orderID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("ошибка получения заказа: %w", err)
+	}
+	if !exists {
+		return nil, entities.NewNotFoundError("заказ", strconv.Itoa(orderID))
+	}
+
+	rows, err := r.db.Query(`
+		SELECT id, order_id, product_id, quantity, unit_price, total_price, production_deadline, created_at
+		FROM order_items
+		WHERE order_id = $1
+		ORDER BY id`, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения позиций заказа: %w", err)
+	}
+	defer rows.Close()
+
+	var items []entities.OrderItem
+	for rows.Next() {
+		var item entities.OrderItem
+		var deadline sql.NullTime
+		if err := rows.Scan(&item.ID, &item.OrderID, &item.ProductID, &item.Quantity,
+			&item.UnitPrice, &item.TotalPrice, &deadline, &item.CreatedAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования позиции заказа: %w", err)
+		}
+		if deadline.Valid {
+			item.ProductionDeadline = &deadline.Time
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// GetReservations возвращает резервы материалов заказа по порядку материалов
+func (r *orderRepositoryImpl) GetReservations(orderID int) ([]entities.MaterialReservation, error) {
+	rows, err := r.db.Query(`
+		SELECT r.id, r.order_id, r.material_id, r.quantity, r.status, r.created_at, r.closed_at,
+			m.article, m.name, mu.symbol as abbreviation
+		FROM material_reservations r
+		JOIN materials m ON r.material_id = m.id
+		JOIN measurement_units mu ON m.measurement_unit_id = mu.id
+		WHERE r.order_id = $1
+		ORDER BY r.material_id, r.id`, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения резервов заказа: %w", err)
+	}
+	defer rows.Close()
+
+	reservations := []entities.MaterialReservation{}
+	for rows.Next() {
+		var reservation entities.MaterialReservation
+		var material entities.Material
+		var unitAbbr string
+		if err := rows.Scan(&reservation.ID, &reservation.OrderID, &reservation.MaterialID,
+			&reservation.Quantity, &reservation.Status, &reservation.CreatedAt, &reservation.ClosedAt,
+			&material.Article, &material.Name, &unitAbbr); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования резерва заказа: %w", err)
+		}
+		material.ID = reservation.MaterialID
+		material.MeasurementUnit = &entities.MeasurementUnit{Abbreviation: unitAbbr}
+		reservation.Material = &material
+		reservations = append(reservations, reservation)
+	}
+
+	return reservations, rows.Err()
+}
+
+// ChangeStatus меняет статус заказа и выполняет действия с резервами в одной транзакции
+func (r *orderRepositoryImpl) ChangeStatus(change *entities.OrderStatusChange) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Статус меняется только из ожидаемого, чтобы параллельная смена статуса не прошла дважды
+	result, err := tx.Exec("UPDATE orders SET status = $3, updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND status = $2",
+		change.OrderID, change.From, change.To)
+	if err != nil {
+		return fmt.Errorf("ошибка изменения статуса заказа: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества обновленных строк: %w", err)
+	}
+	if rowsAffected == 0 {
+		return entities.NewBusinessError("ORDER_STATUS_CHANGED", "статус заказа уже изменился, обновите данные заказа")
+	}
+
+	if len(change.Reservations) > 0 {
+		if err := reserveMaterials(tx, change.Reservations); err != nil {
+			return err
+		}
+	}
+	if change.ReleaseReservations {
+		if _, err := closeReservations(tx, change.OrderID, entities.ReservationReleased, entities.MovementRelease, "Снятие резерва при отмене заказа"); err != nil {
+			return err
+		}
+	}
+	if change.ConsumeReservations {
+		change.CostChangedMaterialIDs, err = closeReservations(tx, change.OrderID, entities.ReservationConsumed, entities.MovementConsumption, "Расход по заказу")
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %w", err)
+	}
+
+	return nil
+}
+
+// reserveMaterials блокирует материалы, проверяет свободный остаток по всем резервам и только
+// затем записывает резервы и движения reserve. Резервы должны быть упорядочены по материалу
+func reserveMaterials(tx *sql.Tx, reservations []entities.MaterialReservation) error {
+	var shortages []entities.ReservationShortage
+	for _, reservation := range reservations {
+		var article string
+		var stock, reserved float64
+		err := tx.QueryRow(`
+			SELECT m.article, m.stock_quantity,
+				COALESCE((SELECT SUM(quantity) FROM material_reservations
+					WHERE material_id = m.id AND status = 'active'), 0)
+			FROM materials m
+			WHERE m.id = $1
+			FOR UPDATE OF m`, reservation.MaterialID).Scan(&article, &stock, &reserved)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return entities.NewNotFoundError("материал", strconv.Itoa(reservation.MaterialID))
+			}
+			return fmt.Errorf("ошибка получения свободного остатка материала: %w", err)
+		}
+
+		material := entities.Material{StockQuantity: stock}
+		material.SetReservedQuantity(reserved)
+		if material.AvailableQuantity < reservation.Quantity {
+			shortages = append(shortages, entities.ReservationShortage{
+				Article:   article,
+				Required:  reservation.Quantity,
+				Available: material.AvailableQuantity,
+			})
+		}
+	}
+	if len(shortages) > 0 {
+		return entities.NewInsufficientAvailableError(shortages)
+	}
+
+	for i := range reservations {
+		reservation := &reservations[i]
+		err := tx.QueryRow(`
+			INSERT INTO material_reservations (order_id, material_id, quantity, status)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id, created_at`,
+			reservation.OrderID, reservation.MaterialID, reservation.Quantity, entities.ReservationActive,
+		).Scan(&reservation.ID, &reservation.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("ошибка создания резерва материала: %w", err)
+		}
+
+		if err := postMovement(tx, orderMovement(reservation, entities.MovementReserve, "Резерв под заказ")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// closeReservations закрывает активные резервы заказа со статусом status и проводит по каждому
+// движение movementType: снятие резерва или расход. Возвращает материалы, себестоимость
+// которых изменилась при проведении
+func closeReservations(tx *sql.Tx, orderID int, status entities.ReservationStatus, movementType entities.MovementType, note string) ([]int, error) {
+	rows, err := tx.Query(`
+		SELECT id, order_id, material_id, quantity
+		FROM material_reservations
+		WHERE order_id = $1 AND status = 'active'
+		ORDER BY material_id
+		FOR UPDATE`, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения резервов заказа: %w", err)
+	}
+
+	var reservations []entities.MaterialReservation
+	for rows.Next() {
+		var reservation entities.MaterialReservation
+		if err := rows.Scan(&reservation.ID, &reservation.OrderID, &reservation.MaterialID, &reservation.Quantity); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("ошибка сканирования резерва заказа: %w", err)
+		}
+		reservations = append(reservations, reservation)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения резервов заказа: %w", err)
+	}
+
+	var costChangedIDs []int
+	for i := range reservations {
+		movement := orderMovement(&reservations[i], movementType, note)
+		if err := postMovement(tx, movement); err != nil {
+			return nil, err
+		}
+		if movement.CostChanged {
+			costChangedIDs = append(costChangedIDs, movement.MaterialID)
+		}
+	}
+
+	_, err = tx.Exec(`
+		UPDATE material_reservations SET status = $2, closed_at = CURRENT_TIMESTAMP
+		WHERE order_id = $1 AND status = 'active'`, orderID, status)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка закрытия резервов заказа: %w", err)
+	}
+
+	return costChangedIDs, nil
+}
+
+// orderMovement создает движение материала по резерву заказа
+func orderMovement(reservation *entities.MaterialReservation, movementType entities.MovementType, note string) *entities.MaterialMovement {
+	orderID := reservation.OrderID
+	return &entities.MaterialMovement{
+		MaterialID:    reservation.MaterialID,
+		Type:          movementType,
+		Quantity:      reservation.Quantity,
+		ReferenceID:   &orderID,
+		ReferenceType: entities.ReferenceOrder,
+		Note:          &note,
+	}
+}
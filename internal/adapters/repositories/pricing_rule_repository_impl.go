@@ -0,0 +1,223 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// pricingRuleRepositoryImpl реализует интерфейс PricingRuleRepository
+type pricingRuleRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewPricingRuleRepository создает новую реализацию репозитория правил ценообразования
+func NewPricingRuleRepository(db *sql.DB) repositories.PricingRuleRepository {
+	return &pricingRuleRepositoryImpl{db: db}
+}
+
+// pricingRulesQuery выбирает правила ценообразования вместе с названиями типов
+const pricingRulesQuery = `
+	SELECT
+		r.id, r.name, r.product_type_id, r.partner_type_id, r.valid_from, r.valid_to,
+		r.markup_percent, r.fixed_surcharge, r.rounding_mode, r.rounding_step,
+		r.priority, r.is_active, r.created_at, r.updated_at,
+		pt.name as product_type_name, pa.name as partner_type_name
+	FROM pricing_rules r
+	LEFT JOIN product_types pt ON r.product_type_id = pt.id
+	LEFT JOIN partner_types pa ON r.partner_type_id = pa.id
+`
+
+// GetAll возвращает список всех правил ценообразования
+func (r *pricingRuleRepositoryImpl) GetAll() ([]entities.PricingRule, error) {
+	rows, err := r.db.Query(pricingRulesQuery + " ORDER BY r.priority DESC, r.id")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса правил ценообразования: %w", err)
+	}
+	defer rows.Close()
+
+	return scanPricingRules(rows)
+}
+
+// GetByID возвращает правило ценообразования по ID
+func (r *pricingRuleRepositoryImpl) GetByID(id int) (*entities.PricingRule, error) {
+	rows, err := r.db.Query(pricingRulesQuery+" WHERE r.id = $1", id)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения правила ценообразования: %w", err)
+	}
+	defer rows.Close()
+
+	rules, err := scanPricingRules(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, entities.NewNotFoundError("правило ценообразования", strconv.Itoa(id))
+	}
+
+	return &rules[0], nil
+}
+
+// GetActiveRules возвращает активные правила, действующие на указанную дату
+func (r *pricingRuleRepositoryImpl) GetActiveRules(date time.Time) ([]entities.PricingRule, error) {
+	query := pricingRulesQuery + `
+	WHERE r.is_active = TRUE
+	  AND (r.valid_from IS NULL OR r.valid_from <= $1::date)
+	  AND (r.valid_to IS NULL OR r.valid_to >= $1::date)
+	ORDER BY r.priority DESC, r.id
+	`
+
+	rows, err := r.db.Query(query, date)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения действующих правил ценообразования: %w", err)
+	}
+	defer rows.Close()
+
+	return scanPricingRules(rows)
+}
+
+// Create создает новое правило ценообразования
+func (r *pricingRuleRepositoryImpl) Create(rule *entities.PricingRule) error {
+	query := `
+		INSERT INTO pricing_rules (name, product_type_id, partner_type_id, valid_from, valid_to,
+			markup_percent, fixed_surcharge, rounding_mode, rounding_step, priority, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(query, rule.Name, rule.ProductTypeID, rule.PartnerTypeID,
+		rule.ValidFrom, rule.ValidTo, rule.MarkupPercent, rule.FixedSurcharge,
+		string(rule.RoundingMode), rule.RoundingStep, rule.Priority, rule.IsActive).Scan(
+		&rule.ID, &rule.CreatedAt, &rule.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка создания правила ценообразования: %w", err)
+	}
+
+	return nil
+}
+
+// Update обновляет правило ценообразования
+func (r *pricingRuleRepositoryImpl) Update(rule *entities.PricingRule) error {
+	query := `
+		UPDATE pricing_rules
+		SET name = $1, product_type_id = $2, partner_type_id = $3, valid_from = $4, valid_to = $5,
+		    markup_percent = $6, fixed_surcharge = $7, rounding_mode = $8, rounding_step = $9,
+		    priority = $10, is_active = $11, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $12
+	`
+
+	result, err := r.db.Exec(query, rule.Name, rule.ProductTypeID, rule.PartnerTypeID,
+		rule.ValidFrom, rule.ValidTo, rule.MarkupPercent, rule.FixedSurcharge,
+		string(rule.RoundingMode), rule.RoundingStep, rule.Priority, rule.IsActive, rule.ID)
+	if err != nil {
+		return fmt.Errorf("ошибка обновления правила ценообразования: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества обновленных строк: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.NewNotFoundError("правило ценообразования", strconv.Itoa(rule.ID))
+	}
+
+	return nil
+}
+
+// Delete удаляет правило ценообразования
+func (r *pricingRuleRepositoryImpl) Delete(id int) error {
+	result, err := r.db.Exec("DELETE FROM pricing_rules WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления правила ценообразования: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества удаленных строк: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.NewNotFoundError("правило ценообразования", strconv.Itoa(id))
+	}
+
+	return nil
+}
+
+// GetPartnerTypes возвращает все типы партнеров
+func (r *pricingRuleRepositoryImpl) GetPartnerTypes() ([]entities.PartnerType, error) {
+	rows, err := r.db.Query("SELECT id, name, description FROM partner_types ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения типов партнеров: %w", err)
+	}
+	defer rows.Close()
+
+	var partnerTypes []entities.PartnerType
+	for rows.Next() {
+		var partnerType entities.PartnerType
+		if err := rows.Scan(&partnerType.ID, &partnerType.Name, &partnerType.Description); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования типа партнера: %w", err)
+		}
+		partnerTypes = append(partnerTypes, partnerType)
+	}
+
+	return partnerTypes, rows.Err()
+}
+
+// GetPartnerTypeByID возвращает тип партнера по ID
+func (r *pricingRuleRepositoryImpl) GetPartnerTypeByID(id int) (*entities.PartnerType, error) {
+	var partnerType entities.PartnerType
+	err := r.db.QueryRow("SELECT id, name, description FROM partner_types WHERE id = $1", id).Scan(
+		&partnerType.ID, &partnerType.Name, &partnerType.Description,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.NewNotFoundError("тип партнера", strconv.Itoa(id))
+		}
+		return nil, fmt.Errorf("ошибка получения типа партнера: %w", err)
+	}
+
+	return &partnerType, nil
+}
+
+// scanPricingRules читает строки pricingRulesQuery в сущности
+func scanPricingRules(rows *sql.Rows) ([]entities.PricingRule, error) {
+	rules := []entities.PricingRule{}
+	for rows.Next() {
+		var rule entities.PricingRule
+		var roundingMode string
+		var productTypeName, partnerTypeName *string
+
+		err := rows.Scan(
+			&rule.ID, &rule.Name, &rule.ProductTypeID, &rule.PartnerTypeID,
+			&rule.ValidFrom, &rule.ValidTo, &rule.MarkupPercent, &rule.FixedSurcharge,
+			&roundingMode, &rule.RoundingStep, &rule.Priority, &rule.IsActive,
+			&rule.CreatedAt, &rule.UpdatedAt, &productTypeName, &partnerTypeName,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования правила ценообразования: %w", err)
+		}
+		rule.RoundingMode = entities.RoundingMode(roundingMode)
+
+		// Заполняем связанные данные
+		if rule.ProductTypeID != nil && productTypeName != nil {
+			rule.ProductType = &entities.ProductType{ID: *rule.ProductTypeID, Name: *productTypeName}
+		}
+		if rule.PartnerTypeID != nil && partnerTypeName != nil {
+			rule.PartnerType = &entities.PartnerType{ID: *rule.PartnerTypeID, Name: *partnerTypeName}
+		}
+
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения правил ценообразования: %w", err)
+	}
+
+	return rules, nil
+}
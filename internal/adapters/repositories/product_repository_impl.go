@@ -4,9 +4,12 @@ import (
 	"database/sql"
 	"fmt"
 	"strconv"
+	"time"
 
 	"wallpaper-system/internal/domain/entities"
 	"wallpaper-system/internal/domain/repositories"
+
+	"github.com/lib/pq"
 )
 
 // productRepositoryImpl реализует интерфейс ProductRepository
@@ -19,23 +22,79 @@ func NewProductRepository(db *sql.DB) repositories.ProductRepository {
 	return &productRepositoryImpl{db: db}
 }
 
-// GetAll возвращает список всей продукции
-func (r *productRepositoryImpl) GetAll() ([]entities.Product, error) {
-	query := `
-		SELECT 
-			p.id, p.article, p.product_type_id, p.name, p.description,
-			p.image_path, p.min_partner_price, p.package_length, p.package_width,
-			p.package_height, p.weight_without_package, p.weight_with_package,
-			p.quality_certificate_path, p.standard_number, p.production_time_hours,
-			p.cost_price, p.workshop_number, p.required_workers, p.roll_width,
-			p.created_at, p.updated_at,
-			pt.name as type_name, pt.coefficient as type_coefficient
-		FROM products p
-		JOIN product_types pt ON p.product_type_id = pt.id
-		ORDER BY p.created_at DESC
-	`
+// productSelectQuery выбирает продукцию вместе с типом; порядок столбцов соответствует scanProduct
+const productSelectQuery = `
+	SELECT
+		p.id, p.article, p.product_type_id, p.name, p.description,
+		p.image_path, p.thumbnail_path, p.preview_path,
+		p.min_partner_price, p.package_length, p.package_width,
+		p.package_height, p.weight_without_package, p.weight_with_package,
+		p.quality_certificate_path, p.standard_number, p.production_time_hours,
+		p.cost_price, p.workshop_number, p.required_workers, p.roll_width,
+		p.roll_length, p.pattern_repeat, p.calculated_cost, p.cost_calculated_at, p.archived_at, p.created_at, p.updated_at,
+		pt.name as type_name, pt.coefficient as type_coefficient
+	FROM products p
+	JOIN product_types pt ON p.product_type_id = pt.id
+`
+
+// productSortColumns сопоставляет поля сортировки списка продукции со столбцами
+var productSortColumns = map[string]string{
+	entities.ProductSortArticle:   "p.article",
+	entities.ProductSortName:      "p.name",
+	entities.ProductSortType:      "pt.name",
+	entities.ProductSortPrice:     "p.min_partner_price",
+	entities.ProductSortCreatedAt: "p.created_at",
+}
 
-	rows, err := r.db.Query(query)
+// rowScanner - общий интерфейс *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// dbExecutor - общий интерфейс *sql.DB и *sql.Tx, чтобы одни и те же запросы
+// выполнялись как отдельно, так и внутри транзакции
+type dbExecutor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// scanProduct считывает строку productSelectQuery
+func scanProduct(row rowScanner) (*entities.Product, error) {
+	var product entities.Product
+	var typeName string
+	var typeCoefficient float64
+
+	err := row.Scan(
+		&product.ID, &product.Article, &product.ProductTypeID, &product.Name,
+		&product.Description, &product.ImagePath, &product.ThumbnailPath,
+		&product.PreviewPath, &product.MinPartnerPrice,
+		&product.PackageLength, &product.PackageWidth, &product.PackageHeight,
+		&product.WeightWithoutPackage, &product.WeightWithPackage,
+		&product.QualityCertificatePath, &product.StandardNumber,
+		&product.ProductionTimeHours, &product.CostPrice, &product.WorkshopNumber,
+		&product.RequiredWorkers, &product.RollWidth, &product.RollLength, &product.PatternRepeat,
+		&product.CalculatedCost,
+		&product.CostCalculatedAt, &product.ArchivedAt, &product.CreatedAt, &product.UpdatedAt,
+		&typeName, &typeCoefficient,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Заполняем тип продукции
+	product.ProductType = &entities.ProductType{
+		ID:          product.ProductTypeID,
+		Name:        typeName,
+		Coefficient: typeCoefficient,
+	}
+
+	return &product, nil
+}
+
+// GetAll возвращает список всей продукции, кроме архивной
+func (r *productRepositoryImpl) GetAll() ([]entities.Product, error) {
+	rows, err := r.db.Query(productSelectQuery + " WHERE p.archived_at IS NULL ORDER BY p.created_at DESC")
 	if err != nil {
 		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
 	}
@@ -43,68 +102,99 @@ func (r *productRepositoryImpl) GetAll() ([]entities.Product, error) {
 
 	var products []entities.Product
 	for rows.Next() {
-		var product entities.Product
-		var typeName string
-		var typeCoefficient float64
-
-		err := rows.Scan(
-			&product.ID, &product.Article, &product.ProductTypeID, &product.Name,
-			&product.Description, &product.ImagePath, &product.MinPartnerPrice,
-			&product.PackageLength, &product.PackageWidth, &product.PackageHeight,
-			&product.WeightWithoutPackage, &product.WeightWithPackage,
-			&product.QualityCertificatePath, &product.StandardNumber,
-			&product.ProductionTimeHours, &product.CostPrice, &product.WorkshopNumber,
-			&product.RequiredWorkers, &product.RollWidth, &product.CreatedAt,
-			&product.UpdatedAt, &typeName, &typeCoefficient,
-		)
+		product, err := scanProduct(rows)
 		if err != nil {
 			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
 		}
+		products = append(products, *product)
+	}
 
-		// Заполняем тип продукции
-		product.ProductType = &entities.ProductType{
-			ID:          product.ProductTypeID,
-			Name:        typeName,
-			Coefficient: typeCoefficient,
-		}
-
-		products = append(products, product)
+	// Получаем полуфабрикаты одним запросом для всего списка
+	components, err := r.getAllComponents()
+	if err != nil {
+		return nil, err
+	}
+	for i := range products {
+		products[i].Components = components[products[i].ID]
 	}
 
 	return products, nil
 }
 
-// GetByID возвращает продукцию по ID
-func (r *productRepositoryImpl) GetByID(id int) (*entities.Product, error) {
-	query := `
-		SELECT 
-			p.id, p.article, p.product_type_id, p.name, p.description,
-			p.image_path, p.min_partner_price, p.package_length, p.package_width,
-			p.package_height, p.weight_without_package, p.weight_with_package,
-			p.quality_certificate_path, p.standard_number, p.production_time_hours,
-			p.cost_price, p.workshop_number, p.required_workers, p.roll_width,
-			p.created_at, p.updated_at,
-			pt.name as type_name, pt.coefficient as type_coefficient
-		FROM products p
-		JOIN product_types pt ON p.product_type_id = pt.id
-		WHERE p.id = $1
-	`
+// FindByCriteria возвращает страницу продукции по фильтрам и общее количество найденных записей
+func (r *productRepositoryImpl) FindByCriteria(criteria entities.ProductCriteria) ([]entities.Product, int, error) {
+	where := &whereClause{}
+	if criteria.ProductTypeID != nil {
+		where.add("p.product_type_id = ?", *criteria.ProductTypeID)
+	}
+	if criteria.MinPrice != nil {
+		where.add("p.min_partner_price >= ?", *criteria.MinPrice)
+	}
+	if criteria.MaxPrice != nil {
+		where.add("p.min_partner_price <= ?", *criteria.MaxPrice)
+	}
+	if criteria.ArticlePrefix != "" {
+		where.add("p.article ILIKE ?", likePrefix(criteria.ArticlePrefix))
+	}
+	if !criteria.IncludeArchived {
+		where.add("p.archived_at IS NULL")
+	}
 
-	var product entities.Product
-	var typeName string
-	var typeCoefficient float64
+	var total int
+	countQuery := "SELECT COUNT(*) FROM products p JOIN product_types pt ON p.product_type_id = pt.id" + where.String()
+	if err := r.db.QueryRow(countQuery, where.args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("ошибка подсчета продукции: %w", err)
+	}
 
-	err := r.db.QueryRow(query, id).Scan(
-		&product.ID, &product.Article, &product.ProductTypeID, &product.Name,
-		&product.Description, &product.ImagePath, &product.MinPartnerPrice,
-		&product.PackageLength, &product.PackageWidth, &product.PackageHeight,
-		&product.WeightWithoutPackage, &product.WeightWithPackage,
-		&product.QualityCertificatePath, &product.StandardNumber,
-		&product.ProductionTimeHours, &product.CostPrice, &product.WorkshopNumber,
-		&product.RequiredWorkers, &product.RollWidth, &product.CreatedAt,
-		&product.UpdatedAt, &typeName, &typeCoefficient,
-	)
+	query := productSelectQuery + where.String() +
+		orderBy(productSortColumns, criteria.SortBy, criteria.SortDirection, "p.id")
+	query += fmt.Sprintf(" LIMIT %s OFFSET %s", where.nextArg(criteria.PageSize), where.nextArg(criteria.Offset()))
+
+	rows, err := r.db.Query(query, where.args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+	defer rows.Close()
+
+	products := []entities.Product{}
+	productIDs := []int{}
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("ошибка сканирования строки: %w", err)
+		}
+		products = append(products, *product)
+		productIDs = append(productIDs, product.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("ошибка чтения продукции: %w", err)
+	}
 
+	// Получаем полуфабрикаты только для продукции текущей страницы
+	components, err := r.getComponentsForProducts(productIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range products {
+		products[i].Components = components[products[i].ID]
+	}
+
+	if criteria.IncludeVariants {
+		variants, err := getVariantsForProducts(r.db, productIDs)
+		if err != nil {
+			return nil, 0, err
+		}
+		for i := range products {
+			products[i].Variants = variants[products[i].ID]
+		}
+	}
+
+	return products, total, nil
+}
+
+// GetByID возвращает продукцию по ID
+func (r *productRepositoryImpl) GetByID(id int) (*entities.Product, error) {
+	product, err := scanProduct(r.db.QueryRow(productSelectQuery+" WHERE p.id = $1", id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, entities.NewNotFoundError("продукция", strconv.Itoa(id))
@@ -112,13 +202,6 @@ func (r *productRepositoryImpl) GetByID(id int) (*entities.Product, error) {
 		return nil, fmt.Errorf("ошибка получения продукции: %w", err)
 	}
 
-	// Заполняем тип продукции
-	product.ProductType = &entities.ProductType{
-		ID:          product.ProductTypeID,
-		Name:        typeName,
-		Coefficient: typeCoefficient,
-	}
-
 	// Получаем материалы
 	materials, err := r.GetMaterialsForProduct(id)
 	if err != nil {
@@ -126,19 +209,132 @@ func (r *productRepositoryImpl) GetByID(id int) (*entities.Product, error) {
 	}
 	product.Materials = materials
 
-	return &product, nil
+	// Получаем полуфабрикаты
+	components, err := r.GetComponentsForProduct(id)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения полуфабрикатов: %w", err)
+	}
+	product.Components = components
+
+	return product, nil
 }
 
 // Create создает новую продукцию
 func (r *productRepositoryImpl) Create(product *entities.Product) error {
+	return insertProduct(r.db, product)
+}
+
+// Update обновляет продукцию
+func (r *productRepositoryImpl) Update(product *entities.Product) error {
+	return updateProduct(r.db, product)
+}
+
+// CreateWithRecipe создает продукцию вместе с рецептурой в одной транзакции
+func (r *productRepositoryImpl) CreateWithRecipe(product *entities.Product) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertProduct(tx, product); err != nil {
+		return err
+	}
+
+	materialQuery := `
+		INSERT INTO product_materials (product_id, material_id, quantity_per_unit)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+	for i := range product.Materials {
+		pm := &product.Materials[i]
+		pm.ProductID = product.ID
+		if err := tx.QueryRow(materialQuery, product.ID, pm.MaterialID, pm.QuantityPerUnit).Scan(&pm.ID, &pm.CreatedAt); err != nil {
+			return fmt.Errorf("ошибка добавления материала %d в рецептуру: %w", pm.MaterialID, err)
+		}
+	}
+
+	componentQuery := `
+		INSERT INTO product_components (product_id, component_product_id, quantity_per_unit)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+	for i := range product.Components {
+		pc := &product.Components[i]
+		pc.ProductID = product.ID
+		if err := tx.QueryRow(componentQuery, product.ID, pc.ComponentProductID, pc.QuantityPerUnit).Scan(&pc.ID, &pc.CreatedAt); err != nil {
+			return fmt.Errorf("ошибка добавления полуфабриката %d в рецептуру: %w", pc.ComponentProductID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %w", err)
+	}
+
+	return nil
+}
+
+// GetByArticles возвращает продукцию, включая архивную, с указанными артикулами
+func (r *productRepositoryImpl) GetByArticles(articles []string) ([]entities.Product, error) {
+	rows, err := r.db.Query(productSelectQuery+" WHERE p.article = ANY($1)", pq.Array(articles))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения продукции по артикулам: %w", err)
+	}
+	defer rows.Close()
+
+	var products []entities.Product
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования продукции: %w", err)
+		}
+		products = append(products, *product)
+	}
+
+	return products, rows.Err()
+}
+
+// SaveBatch создает продукцию без ID и обновляет продукцию с ID в одной транзакции
+func (r *productRepositoryImpl) SaveBatch(products []entities.Product) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i := range products {
+		if products[i].ID == 0 {
+			err = insertProduct(tx, &products[i])
+		} else {
+			err = updateProduct(tx, &products[i])
+		}
+		if err != nil {
+			return fmt.Errorf("артикул %s: %w", products[i].Article, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %w", err)
+	}
+
+	return nil
+}
+
+func insertProduct(db dbExecutor, product *entities.Product) error {
 	query := `
-		INSERT INTO products (article, product_type_id, name, description, min_partner_price, roll_width)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO products (
+			article, product_type_id, name, description, min_partner_price,
+			package_length, package_width, package_height,
+			weight_without_package, weight_with_package,
+			quality_certificate_path, standard_number,
+			production_time_hours, cost_price, workshop_number, required_workers,
+			roll_width, roll_length, pattern_repeat
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
 		RETURNING id, created_at, updated_at
 	`
 
-	err := r.db.QueryRow(query, product.Article, product.ProductTypeID, product.Name,
-		product.Description, product.MinPartnerPrice, product.RollWidth).Scan(
+	err := db.QueryRow(query, productPassportArgs(product)...).Scan(
 		&product.ID, &product.CreatedAt, &product.UpdatedAt,
 	)
 
@@ -149,18 +345,23 @@ func (r *productRepositoryImpl) Create(product *entities.Product) error {
 	return nil
 }
 
-// Update обновляет продукцию
-func (r *productRepositoryImpl) Update(product *entities.Product) error {
+// updateProduct сохраняет паспорт продукции. Изображения и рассчитанная себестоимость
+// меняются отдельными операциями и здесь не затрагиваются
+func updateProduct(db dbExecutor, product *entities.Product) error {
 	query := `
 		UPDATE products 
 		SET article = $1, product_type_id = $2, name = $3, description = $4, 
-		    min_partner_price = $5, roll_width = $6, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $7
+		    min_partner_price = $5,
+		    package_length = $6, package_width = $7, package_height = $8,
+		    weight_without_package = $9, weight_with_package = $10,
+		    quality_certificate_path = $11, standard_number = $12,
+		    production_time_hours = $13, cost_price = $14, workshop_number = $15,
+		    required_workers = $16, roll_width = $17, roll_length = $18, pattern_repeat = $19,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = $20
 	`
 
-	result, err := r.db.Exec(query, product.Article, product.ProductTypeID,
-		product.Name, product.Description, product.MinPartnerPrice,
-		product.RollWidth, product.ID)
+	result, err := db.Exec(query, append(productPassportArgs(product), product.ID)...)
 	if err != nil {
 		return fmt.Errorf("ошибка обновления продукции: %w", err)
 	}
@@ -177,43 +378,65 @@ func (r *productRepositoryImpl) Update(product *entities.Product) error {
 	return nil
 }
 
-// Delete удаляет продукцию
-func (r *productRepositoryImpl) Delete(id int) error {
-	// Сначала проверяем, нет ли связанных записей в order_items
-	var orderItemsCount int
-	checkOrderItemsQuery := "SELECT COUNT(*) FROM order_items WHERE product_id = $1"
-	err := r.db.QueryRow(checkOrderItemsQuery, id).Scan(&orderItemsCount)
-	if err != nil {
-		return fmt.Errorf("ошибка проверки связанных заказов: %w", err)
+// productPassportArgs возвращает значения паспорта продукции в порядке столбцов insertProduct и updateProduct
+func productPassportArgs(product *entities.Product) []interface{} {
+	return []interface{}{
+		product.Article, product.ProductTypeID, product.Name, product.Description, product.MinPartnerPrice,
+		product.PackageLength, product.PackageWidth, product.PackageHeight,
+		product.WeightWithoutPackage, product.WeightWithPackage,
+		product.QualityCertificatePath, product.StandardNumber,
+		product.ProductionTimeHours, product.CostPrice, product.WorkshopNumber, product.RequiredWorkers,
+		product.RollWidth, product.RollLength, product.PatternRepeat,
 	}
+}
+
+// Archive переносит продукцию в архив. Продукция остается в заказах,
+// истории продаж и рецептурах, но скрывается из списков и калькуляторов
+func (r *productRepositoryImpl) Archive(id int, archivedAt time.Time) error {
+	return r.setArchivedAt(id, &archivedAt)
+}
+
+// Restore возвращает продукцию из архива
+func (r *productRepositoryImpl) Restore(id int) error {
+	return r.setArchivedAt(id, nil)
+}
+
+func (r *productRepositoryImpl) setArchivedAt(id int, archivedAt *time.Time) error {
+	query := "UPDATE products SET archived_at = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1"
 
-	if orderItemsCount > 0 {
-		return fmt.Errorf("невозможно удалить продукцию: она используется в %d заказе(ах). Сначала удалите связанные заказы или исключите продукцию из них", orderItemsCount)
+	result, err := r.db.Exec(query, id, archivedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка изменения архивного статуса продукции: %w", err)
 	}
 
-	// Проверяем наличие в истории продаж
-	var salesHistoryCount int
-	checkSalesQuery := "SELECT COUNT(*) FROM sales_history WHERE product_id = $1"
-	err = r.db.QueryRow(checkSalesQuery, id).Scan(&salesHistoryCount)
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("ошибка проверки истории продаж: %w", err)
+		return fmt.Errorf("ошибка получения количества обновленных строк: %w", err)
 	}
 
-	if salesHistoryCount > 0 {
-		return fmt.Errorf("невозможно удалить продукцию: есть история продаж (%d записей). Данная продукция имеет коммерческую историю и не может быть удалена", salesHistoryCount)
+	if rowsAffected == 0 {
+		return entities.NewNotFoundError("продукция", strconv.Itoa(id))
 	}
 
-	// Если никаких связей нет, удаляем продукцию
-	query := "DELETE FROM products WHERE id = $1"
+	return nil
+}
+
+// UpdateImages сохраняет ссылки на изображение продукции и его уменьшенные копии
+func (r *productRepositoryImpl) UpdateImages(id int, images entities.ImagePaths) error {
+	query := `
+		UPDATE products
+		SET image_path = $2, thumbnail_path = $3, preview_path = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
 
-	result, err := r.db.Exec(query, id)
+	result, err := r.db.Exec(query, id, images.ImagePath, images.ThumbnailPath, images.PreviewPath)
 	if err != nil {
-		return fmt.Errorf("ошибка удаления продукции: %w", err)
+		return fmt.Errorf("ошибка сохранения изображения продукции: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("ошибка получения количества удаленных строк: %w", err)
+		return fmt.Errorf("ошибка получения количества обновленных строк: %w", err)
 	}
 
 	if rowsAffected == 0 {
@@ -223,9 +446,9 @@ func (r *productRepositoryImpl) Delete(id int) error {
 	return nil
 }
 
-// GetProductTypes возвращает все типы продукции
+// GetProductTypes возвращает типы продукции, находящиеся в обращении
 func (r *productRepositoryImpl) GetProductTypes() ([]entities.ProductType, error) {
-	query := "SELECT id, name, coefficient, created_at, updated_at FROM product_types ORDER BY name"
+	query := "SELECT id, name, coefficient, created_at, updated_at FROM product_types WHERE archived_at IS NULL ORDER BY name"
 
 	rows, err := r.db.Query(query)
 	if err != nil {
@@ -250,14 +473,14 @@ func (r *productRepositoryImpl) GetProductTypes() ([]entities.ProductType, error
 // GetProductTypeByID возвращает тип продукции по ID
 func (r *productRepositoryImpl) GetProductTypeByID(id int) (*entities.ProductType, error) {
 	query := `
-		SELECT id, name, coefficient, created_at, updated_at 
+		SELECT id, name, coefficient, archived_at, created_at, updated_at 
 		FROM product_types 
 		WHERE id = $1
 	`
 
 	var productType entities.ProductType
 	err := r.db.QueryRow(query, id).Scan(
-		&productType.ID, &productType.Name, &productType.Coefficient,
+		&productType.ID, &productType.Name, &productType.Coefficient, &productType.ArchivedAt,
 		&productType.CreatedAt, &productType.UpdatedAt,
 	)
 
@@ -280,9 +503,11 @@ func (r *productRepositoryImpl) GetMaterialsForProduct(productID int) ([]entitie
 			m.measurement_unit_id, m.package_quantity, m.cost_per_unit,
 			m.stock_quantity, m.min_stock_quantity, m.image_path,
 			m.created_at, m.updated_at,
+			mt.name as type_name, mt.defect_rate,
 			mu.id, mu.name, mu.symbol as abbreviation, mu.created_at
 		FROM product_materials pm
 		JOIN materials m ON pm.material_id = m.id
+		JOIN material_types mt ON m.material_type_id = mt.id
 		JOIN measurement_units mu ON m.measurement_unit_id = mu.id
 		WHERE pm.product_id = $1
 		ORDER BY m.name
@@ -299,6 +524,8 @@ func (r *productRepositoryImpl) GetMaterialsForProduct(productID int) ([]entitie
 		var pm entities.ProductMaterial
 		var material entities.Material
 		var unit entities.MeasurementUnit
+		var typeName string
+		var defectRate float64
 
 		err := rows.Scan(
 			&pm.ID, &pm.ProductID, &pm.MaterialID, &pm.QuantityPerUnit, &pm.CreatedAt,
@@ -306,12 +533,18 @@ func (r *productRepositoryImpl) GetMaterialsForProduct(productID int) ([]entitie
 			&material.Description, &material.MeasurementUnitID, &material.PackageQuantity,
 			&material.CostPerUnit, &material.StockQuantity, &material.MinStockQuantity,
 			&material.ImagePath, &material.CreatedAt, &material.UpdatedAt,
+			&typeName, &defectRate,
 			&unit.ID, &unit.Name, &unit.Abbreviation, &unit.CreatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("ошибка сканирования материала: %w", err)
 		}
 
+		material.MaterialType = &entities.MaterialType{
+			ID:              material.MaterialTypeID,
+			Name:            typeName,
+			WastePercentage: defectRateToPercentage(defectRate),
+		}
 		material.MeasurementUnit = &unit
 		pm.Material = &material
 
@@ -320,3 +553,352 @@ func (r *productRepositoryImpl) GetMaterialsForProduct(productID int) ([]entitie
 
 	return materials, nil
 }
+
+// AddProductMaterial добавляет материал в рецептуру продукции
+func (r *productRepositoryImpl) AddProductMaterial(productMaterial *entities.ProductMaterial) error {
+	query := `
+		INSERT INTO product_materials (product_id, material_id, quantity_per_unit)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(query, productMaterial.ProductID, productMaterial.MaterialID,
+		productMaterial.QuantityPerUnit).Scan(&productMaterial.ID, &productMaterial.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка добавления материала в рецептуру: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateProductMaterial изменяет расход материала в рецептуре продукции
+func (r *productRepositoryImpl) UpdateProductMaterial(productMaterial *entities.ProductMaterial) error {
+	query := `
+		UPDATE product_materials
+		SET quantity_per_unit = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE product_id = $1 AND material_id = $2
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(query, productMaterial.ProductID, productMaterial.MaterialID,
+		productMaterial.QuantityPerUnit).Scan(&productMaterial.ID, &productMaterial.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entities.NewNotFoundError("материал рецептуры", strconv.Itoa(productMaterial.MaterialID))
+		}
+		return fmt.Errorf("ошибка обновления рецептуры: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveProductMaterial удаляет материал из рецептуры продукции
+func (r *productRepositoryImpl) RemoveProductMaterial(productID, materialID int) error {
+	query := "DELETE FROM product_materials WHERE product_id = $1 AND material_id = $2"
+
+	result, err := r.db.Exec(query, productID, materialID)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления материала из рецептуры: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества удаленных строк: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.NewNotFoundError("материал рецептуры", strconv.Itoa(materialID))
+	}
+
+	return nil
+}
+
+// ReplaceProductMaterials заменяет всю рецептуру продукции в одной транзакции
+func (r *productRepositoryImpl) ReplaceProductMaterials(productID int, materials []entities.ProductMaterial) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM product_materials WHERE product_id = $1", productID); err != nil {
+		return fmt.Errorf("ошибка очистки рецептуры: %w", err)
+	}
+
+	insertQuery := `
+		INSERT INTO product_materials (product_id, material_id, quantity_per_unit)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+
+	for i := range materials {
+		materials[i].ProductID = productID
+		err := tx.QueryRow(insertQuery, productID, materials[i].MaterialID, materials[i].QuantityPerUnit).Scan(
+			&materials[i].ID, &materials[i].CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("ошибка добавления материала %d в рецептуру: %w", materials[i].MaterialID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %w", err)
+	}
+
+	return nil
+}
+
+// productComponentsQuery выбирает строки полуфабрикатов вместе с краткими данными продукции-компонента
+const productComponentsQuery = `
+	SELECT
+		pc.id, pc.product_id, pc.component_product_id, pc.quantity_per_unit, pc.created_at,
+		p.id, p.article, p.product_type_id, p.name, p.min_partner_price, p.roll_width,
+		pt.name as type_name, pt.coefficient as type_coefficient
+	FROM product_components pc
+	JOIN products p ON pc.component_product_id = p.id
+	JOIN product_types pt ON p.product_type_id = pt.id
+`
+
+// scanProductComponents считывает строки полуфабрикатов из результата запроса
+func scanProductComponents(rows *sql.Rows) ([]entities.ProductComponent, error) {
+	components := []entities.ProductComponent{}
+	for rows.Next() {
+		var pc entities.ProductComponent
+		var component entities.Product
+		var typeName string
+		var typeCoefficient float64
+
+		err := rows.Scan(
+			&pc.ID, &pc.ProductID, &pc.ComponentProductID, &pc.QuantityPerUnit, &pc.CreatedAt,
+			&component.ID, &component.Article, &component.ProductTypeID, &component.Name,
+			&component.MinPartnerPrice, &component.RollWidth,
+			&typeName, &typeCoefficient,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования полуфабриката: %w", err)
+		}
+
+		component.ProductType = &entities.ProductType{
+			ID:          component.ProductTypeID,
+			Name:        typeName,
+			Coefficient: typeCoefficient,
+		}
+		pc.Component = &component
+
+		components = append(components, pc)
+	}
+
+	return components, nil
+}
+
+// GetComponentsForProduct возвращает полуфабрикаты, входящие в рецептуру продукции
+func (r *productRepositoryImpl) GetComponentsForProduct(productID int) ([]entities.ProductComponent, error) {
+	rows, err := r.db.Query(productComponentsQuery+" WHERE pc.product_id = $1 ORDER BY p.name", productID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса полуфабрикатов: %w", err)
+	}
+	defer rows.Close()
+
+	return scanProductComponents(rows)
+}
+
+// getAllComponents возвращает полуфабрикаты всей продукции, сгруппированные по ID продукции
+func (r *productRepositoryImpl) getAllComponents() (map[int][]entities.ProductComponent, error) {
+	rows, err := r.db.Query(productComponentsQuery + " ORDER BY pc.product_id, p.name")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса полуфабрикатов: %w", err)
+	}
+	defer rows.Close()
+
+	return groupComponents(rows)
+}
+
+// getComponentsForProducts возвращает полуфабрикаты указанной продукции, сгруппированные по ID продукции
+func (r *productRepositoryImpl) getComponentsForProducts(productIDs []int) (map[int][]entities.ProductComponent, error) {
+	if len(productIDs) == 0 {
+		return map[int][]entities.ProductComponent{}, nil
+	}
+
+	rows, err := r.db.Query(productComponentsQuery+" WHERE pc.product_id = ANY($1) ORDER BY pc.product_id, p.name", pq.Array(productIDs))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса полуфабрикатов: %w", err)
+	}
+	defer rows.Close()
+
+	return groupComponents(rows)
+}
+
+// groupComponents считывает строки полуфабрикатов и группирует их по ID продукции
+func groupComponents(rows *sql.Rows) (map[int][]entities.ProductComponent, error) {
+	components, err := scanProductComponents(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int][]entities.ProductComponent)
+	for _, pc := range components {
+		result[pc.ProductID] = append(result[pc.ProductID], pc)
+	}
+
+	return result, nil
+}
+
+// AddProductComponent добавляет полуфабрикат в рецептуру продукции
+func (r *productRepositoryImpl) AddProductComponent(component *entities.ProductComponent) error {
+	query := `
+		INSERT INTO product_components (product_id, component_product_id, quantity_per_unit)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(query, component.ProductID, component.ComponentProductID,
+		component.QuantityPerUnit).Scan(&component.ID, &component.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка добавления полуфабриката в рецептуру: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateProductComponent изменяет расход полуфабриката в рецептуре продукции
+func (r *productRepositoryImpl) UpdateProductComponent(component *entities.ProductComponent) error {
+	query := `
+		UPDATE product_components
+		SET quantity_per_unit = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE product_id = $1 AND component_product_id = $2
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(query, component.ProductID, component.ComponentProductID,
+		component.QuantityPerUnit).Scan(&component.ID, &component.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entities.NewNotFoundError("полуфабрикат рецептуры", strconv.Itoa(component.ComponentProductID))
+		}
+		return fmt.Errorf("ошибка обновления полуфабриката в рецептуре: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveProductComponent удаляет полуфабрикат из рецептуры продукции
+func (r *productRepositoryImpl) RemoveProductComponent(productID, componentProductID int) error {
+	query := "DELETE FROM product_components WHERE product_id = $1 AND component_product_id = $2"
+
+	result, err := r.db.Exec(query, productID, componentProductID)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления полуфабриката из рецептуры: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества удаленных строк: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.NewNotFoundError("полуфабрикат рецептуры", strconv.Itoa(componentProductID))
+	}
+
+	return nil
+}
+
+// UpdateCalculatedCost сохраняет себестоимость, рассчитанную по рецептуре
+func (r *productRepositoryImpl) UpdateCalculatedCost(productID int, cost float64, calculatedAt time.Time) error {
+	query := `
+		UPDATE products
+		SET calculated_cost = $1, cost_calculated_at = $2
+		WHERE id = $3
+	`
+
+	result, err := r.db.Exec(query, cost, calculatedAt, productID)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения себестоимости: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества обновленных строк: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.NewNotFoundError("продукция", strconv.Itoa(productID))
+	}
+
+	return nil
+}
+
+// GetProductIDsUsingMaterial возвращает ID продукции, в рецептуру которой материал входит
+// напрямую или через полуфабрикаты
+func (r *productRepositoryImpl) GetProductIDsUsingMaterial(materialID int) ([]int, error) {
+	query := `
+		WITH RECURSIVE affected(product_id) AS (
+			SELECT product_id FROM product_materials WHERE material_id = $1
+			UNION
+			SELECT pc.product_id
+			FROM product_components pc
+			JOIN affected a ON pc.component_product_id = a.product_id
+		)
+		SELECT product_id FROM affected ORDER BY product_id
+	`
+
+	return r.queryProductIDs(query, materialID)
+}
+
+// GetProductIDsUsingProduct возвращает ID продукции, в которую продукция входит
+// полуфабрикатом на любом уровне вложенности
+func (r *productRepositoryImpl) GetProductIDsUsingProduct(productID int) ([]int, error) {
+	query := `
+		WITH RECURSIVE affected(product_id) AS (
+			SELECT product_id FROM product_components WHERE component_product_id = $1
+			UNION
+			SELECT pc.product_id
+			FROM product_components pc
+			JOIN affected a ON pc.component_product_id = a.product_id
+		)
+		SELECT product_id FROM affected ORDER BY product_id
+	`
+
+	return r.queryProductIDs(query, productID)
+}
+
+// GetProductIDsOfType возвращает ID продукции указанного типа и продукции, в которую она входит
+// полуфабрикатом на любом уровне вложенности
+func (r *productRepositoryImpl) GetProductIDsOfType(productTypeID int) ([]int, error) {
+	query := `
+		WITH RECURSIVE affected(product_id) AS (
+			SELECT id FROM products WHERE product_type_id = $1
+			UNION
+			SELECT pc.product_id
+			FROM product_components pc
+			JOIN affected a ON pc.component_product_id = a.product_id
+		)
+		SELECT product_id FROM affected ORDER BY product_id
+	`
+
+	return r.queryProductIDs(query, productTypeID)
+}
+
+// GetAllProductIDs возвращает ID всей продукции
+func (r *productRepositoryImpl) GetAllProductIDs() ([]int, error) {
+	return r.queryProductIDs("SELECT id FROM products ORDER BY id")
+}
+
+// queryProductIDs выполняет запрос, возвращающий столбец ID продукции
+func (r *productRepositoryImpl) queryProductIDs(query string, args ...interface{}) ([]int, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения списка продукции: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования ID продукции: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
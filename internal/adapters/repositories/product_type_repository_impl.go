@@ -0,0 +1,226 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// productTypeRepositoryImpl реализует интерфейс ProductTypeRepository
+type productTypeRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewProductTypeRepository создает новую реализацию репозитория типов продукции
+func NewProductTypeRepository(db *sql.DB) repositories.ProductTypeRepository {
+	return &productTypeRepositoryImpl{db: db}
+}
+
+// productTypeSelectQuery выбирает типы продукции со всеми полями
+const productTypeSelectQuery = `
+	SELECT id, name, description, coefficient, archived_at, created_at, updated_at
+	FROM product_types
+`
+
+// scanProductType сканирует строку типа продукции
+func scanProductType(row rowScanner) (*entities.ProductType, error) {
+	var productType entities.ProductType
+	err := row.Scan(
+		&productType.ID, &productType.Name, &productType.Description, &productType.Coefficient,
+		&productType.ArchivedAt, &productType.CreatedAt, &productType.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &productType, nil
+}
+
+// GetAll возвращает типы продукции; архивные - только при includeArchived
+func (r *productTypeRepositoryImpl) GetAll(includeArchived bool) ([]entities.ProductType, error) {
+	query := productTypeSelectQuery
+	if !includeArchived {
+		query += " WHERE archived_at IS NULL"
+	}
+	query += " ORDER BY name"
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса типов продукции: %w", err)
+	}
+	defer rows.Close()
+
+	types := []entities.ProductType{}
+	for rows.Next() {
+		productType, err := scanProductType(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования типа продукции: %w", err)
+		}
+		types = append(types, *productType)
+	}
+
+	return types, rows.Err()
+}
+
+// GetByID возвращает тип продукции по ID, в том числе архивный
+func (r *productTypeRepositoryImpl) GetByID(id int) (*entities.ProductType, error) {
+	productType, err := scanProductType(r.db.QueryRow(productTypeSelectQuery+" WHERE id = $1", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.NewNotFoundError("тип продукции", strconv.Itoa(id))
+		}
+		return nil, fmt.Errorf("ошибка получения типа продукции: %w", err)
+	}
+	return productType, nil
+}
+
+// Create создает тип продукции и записывает начальный коэффициент в историю в одной транзакции
+func (r *productTypeRepositoryImpl) Create(productType *entities.ProductType, change *entities.CoefficientChange) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO product_types (name, description, coefficient)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at
+	`
+
+	err = tx.QueryRow(query, productType.Name, productType.Description, productType.Coefficient).Scan(
+		&productType.ID, &productType.CreatedAt, &productType.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка создания типа продукции: %w", err)
+	}
+
+	change.ProductTypeID = productType.ID
+	if err := insertCoefficientChange(tx, change); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %w", err)
+	}
+
+	return nil
+}
+
+// Update обновляет тип продукции; при изменении коэффициента change записывается в историю
+// в той же транзакции
+func (r *productTypeRepositoryImpl) Update(productType *entities.ProductType, change *entities.CoefficientChange) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE product_types
+		SET name = $1, description = $2, coefficient = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $4
+		RETURNING updated_at
+	`
+
+	err = tx.QueryRow(query, productType.Name, productType.Description, productType.Coefficient, productType.ID).
+		Scan(&productType.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entities.NewNotFoundError("тип продукции", strconv.Itoa(productType.ID))
+		}
+		return fmt.Errorf("ошибка обновления типа продукции: %w", err)
+	}
+
+	if change != nil {
+		change.ProductTypeID = productType.ID
+		if err := insertCoefficientChange(tx, change); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %w", err)
+	}
+
+	return nil
+}
+
+// insertCoefficientChange записывает изменение коэффициента в историю
+func insertCoefficientChange(tx *sql.Tx, change *entities.CoefficientChange) error {
+	query := `
+		INSERT INTO product_type_coefficient_history
+			(product_type_id, old_coefficient, new_coefficient, effective_from, changed_by, comment)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+
+	err := tx.QueryRow(query, change.ProductTypeID, change.OldCoefficient, change.NewCoefficient,
+		change.EffectiveFrom, change.ChangedBy, change.Comment).Scan(&change.ID, &change.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка записи истории коэффициента: %w", err)
+	}
+	return nil
+}
+
+// Archive выводит тип продукции из обращения
+func (r *productTypeRepositoryImpl) Archive(id int, archivedAt time.Time) error {
+	return r.setArchivedAt(id, &archivedAt)
+}
+
+// Restore возвращает тип продукции в обращение
+func (r *productTypeRepositoryImpl) Restore(id int) error {
+	return r.setArchivedAt(id, nil)
+}
+
+func (r *productTypeRepositoryImpl) setArchivedAt(id int, archivedAt *time.Time) error {
+	query := "UPDATE product_types SET archived_at = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1"
+
+	result, err := r.db.Exec(query, id, archivedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка изменения архивного статуса типа продукции: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества затронутых строк: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.NewNotFoundError("тип продукции", strconv.Itoa(id))
+	}
+
+	return nil
+}
+
+// GetCoefficientHistory возвращает историю коэффициента, начиная с последнего изменения
+func (r *productTypeRepositoryImpl) GetCoefficientHistory(productTypeID int) ([]entities.CoefficientChange, error) {
+	query := `
+		SELECT id, product_type_id, old_coefficient, new_coefficient, effective_from, changed_by, comment, created_at
+		FROM product_type_coefficient_history
+		WHERE product_type_id = $1
+		ORDER BY effective_from DESC, id DESC
+	`
+
+	rows, err := r.db.Query(query, productTypeID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения истории коэффициента: %w", err)
+	}
+	defer rows.Close()
+
+	history := []entities.CoefficientChange{}
+	for rows.Next() {
+		var change entities.CoefficientChange
+		err := rows.Scan(&change.ID, &change.ProductTypeID, &change.OldCoefficient, &change.NewCoefficient,
+			&change.EffectiveFrom, &change.ChangedBy, &change.Comment, &change.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования истории коэффициента: %w", err)
+		}
+		history = append(history, change)
+	}
+
+	return history, rows.Err()
+}
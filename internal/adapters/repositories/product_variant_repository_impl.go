@@ -0,0 +1,289 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+
+	"github.com/lib/pq"
+)
+
+// productVariantRepositoryImpl реализует интерфейс ProductVariantRepository
+type productVariantRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewProductVariantRepository создает новую реализацию репозитория вариантов продукции
+func NewProductVariantRepository(db *sql.DB) repositories.ProductVariantRepository {
+	return &productVariantRepositoryImpl{db: db}
+}
+
+// productVariantsQuery выбирает варианты продукции без замен рецептуры
+const productVariantsQuery = `
+	SELECT
+		v.id, v.product_id, v.article_suffix, v.color_name,
+		v.image_path, v.thumbnail_path, v.preview_path, v.created_at, v.updated_at
+	FROM product_variants v
+`
+
+// variantOverridesQuery выбирает замены рецептуры вариантов вместе с данными материала
+const variantOverridesQuery = `
+	SELECT
+		vm.id, vm.variant_id, vm.material_id, vm.quantity_per_unit,
+		m.id, m.article, m.material_type_id, m.name, m.measurement_unit_id,
+		m.package_quantity, m.cost_per_unit, m.stock_quantity, m.min_stock_quantity, m.archived_at,
+		mu.id, mu.name, mu.symbol
+	FROM product_variant_materials vm
+	JOIN materials m ON vm.material_id = m.id
+	JOIN measurement_units mu ON m.measurement_unit_id = mu.id
+	WHERE vm.variant_id = ANY($1)
+	ORDER BY m.name
+`
+
+// GetByProduct возвращает варианты продукции вместе с заменами рецептуры
+func (r *productVariantRepositoryImpl) GetByProduct(productID int) ([]entities.ProductVariant, error) {
+	variants, err := queryVariants(r.db, productVariantsQuery+" WHERE v.product_id = $1 ORDER BY v.article_suffix", productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.loadOverrides(variants); err != nil {
+		return nil, err
+	}
+
+	return variants, nil
+}
+
+// GetByID возвращает вариант по ID вместе с заменами рецептуры
+func (r *productVariantRepositoryImpl) GetByID(id int) (*entities.ProductVariant, error) {
+	variants, err := queryVariants(r.db, productVariantsQuery+" WHERE v.id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(variants) == 0 {
+		return nil, entities.NewNotFoundError("вариант продукции", strconv.Itoa(id))
+	}
+
+	if err := r.loadOverrides(variants); err != nil {
+		return nil, err
+	}
+
+	return &variants[0], nil
+}
+
+// Create создает вариант вместе с заменами рецептуры в одной транзакции
+func (r *productVariantRepositoryImpl) Create(variant *entities.ProductVariant) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO product_variants (product_id, article_suffix, color_name)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at
+	`
+	err = tx.QueryRow(query, variant.ProductID, variant.ArticleSuffix, variant.ColorName).Scan(
+		&variant.ID, &variant.CreatedAt, &variant.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка создания варианта продукции: %w", err)
+	}
+
+	if err := insertVariantOverrides(tx, variant); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %w", err)
+	}
+
+	return nil
+}
+
+// Update обновляет вариант и заменяет все замены рецептуры в одной транзакции
+func (r *productVariantRepositoryImpl) Update(variant *entities.ProductVariant) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE product_variants
+		SET article_suffix = $2, color_name = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING updated_at
+	`
+	if err := tx.QueryRow(query, variant.ID, variant.ArticleSuffix, variant.ColorName).Scan(&variant.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return entities.NewNotFoundError("вариант продукции", strconv.Itoa(variant.ID))
+		}
+		return fmt.Errorf("ошибка обновления варианта продукции: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM product_variant_materials WHERE variant_id = $1", variant.ID); err != nil {
+		return fmt.Errorf("ошибка очистки замен рецептуры: %w", err)
+	}
+	if err := insertVariantOverrides(tx, variant); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %w", err)
+	}
+
+	return nil
+}
+
+// Delete удаляет вариант; замены рецептуры удаляются каскадно
+func (r *productVariantRepositoryImpl) Delete(id int) error {
+	result, err := r.db.Exec("DELETE FROM product_variants WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления варианта продукции: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества удаленных строк: %w", err)
+	}
+	if rowsAffected == 0 {
+		return entities.NewNotFoundError("вариант продукции", strconv.Itoa(id))
+	}
+
+	return nil
+}
+
+// UpdateImages сохраняет ссылки на изображение варианта и его уменьшенные копии
+func (r *productVariantRepositoryImpl) UpdateImages(id int, images entities.ImagePaths) error {
+	query := `
+		UPDATE product_variants
+		SET image_path = $2, thumbnail_path = $3, preview_path = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+	result, err := r.db.Exec(query, id, images.ImagePath, images.ThumbnailPath, images.PreviewPath)
+	if err != nil {
+		return fmt.Errorf("ошибка обновления изображения варианта продукции: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества обновленных строк: %w", err)
+	}
+	if rowsAffected == 0 {
+		return entities.NewNotFoundError("вариант продукции", strconv.Itoa(id))
+	}
+
+	return nil
+}
+
+// loadOverrides загружает замены рецептуры для списка вариантов одним запросом
+func (r *productVariantRepositoryImpl) loadOverrides(variants []entities.ProductVariant) error {
+	if len(variants) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(variants))
+	index := make(map[int]int, len(variants))
+	for i, variant := range variants {
+		ids[i] = variant.ID
+		index[variant.ID] = i
+	}
+
+	rows, err := r.db.Query(variantOverridesQuery, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("ошибка получения замен рецептуры: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var override entities.VariantMaterialOverride
+		var material entities.Material
+		var unit entities.MeasurementUnit
+
+		err := rows.Scan(
+			&override.ID, &override.VariantID, &override.MaterialID, &override.QuantityPerUnit,
+			&material.ID, &material.Article, &material.MaterialTypeID, &material.Name, &material.MeasurementUnitID,
+			&material.PackageQuantity, &material.CostPerUnit, &material.StockQuantity, &material.MinStockQuantity,
+			&material.ArchivedAt, &unit.ID, &unit.Name, &unit.Abbreviation,
+		)
+		if err != nil {
+			return fmt.Errorf("ошибка сканирования замены рецептуры: %w", err)
+		}
+
+		material.MeasurementUnit = &unit
+		override.Material = &material
+
+		variant := &variants[index[override.VariantID]]
+		variant.Overrides = append(variant.Overrides, override)
+	}
+
+	return rows.Err()
+}
+
+// getVariantsForProducts возвращает варианты продукции без замен рецептуры, сгруппированные по ID продукции
+func getVariantsForProducts(db *sql.DB, productIDs []int) (map[int][]entities.ProductVariant, error) {
+	result := make(map[int][]entities.ProductVariant)
+	if len(productIDs) == 0 {
+		return result, nil
+	}
+
+	variants, err := queryVariants(db, productVariantsQuery+" WHERE v.product_id = ANY($1) ORDER BY v.article_suffix", pq.Array(productIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, variant := range variants {
+		result[variant.ProductID] = append(result[variant.ProductID], variant)
+	}
+
+	return result, nil
+}
+
+// queryVariants выполняет запрос productVariantsQuery и считывает варианты
+func queryVariants(db *sql.DB, query string, args ...interface{}) ([]entities.ProductVariant, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения вариантов продукции: %w", err)
+	}
+	defer rows.Close()
+
+	variants := []entities.ProductVariant{}
+	for rows.Next() {
+		var variant entities.ProductVariant
+		err := rows.Scan(
+			&variant.ID, &variant.ProductID, &variant.ArticleSuffix, &variant.ColorName,
+			&variant.ImagePath, &variant.ThumbnailPath, &variant.PreviewPath, &variant.CreatedAt, &variant.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования варианта продукции: %w", err)
+		}
+		variants = append(variants, variant)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения вариантов продукции: %w", err)
+	}
+
+	return variants, nil
+}
+
+// insertVariantOverrides сохраняет замены рецептуры варианта
+func insertVariantOverrides(tx *sql.Tx, variant *entities.ProductVariant) error {
+	query := `
+		INSERT INTO product_variant_materials (variant_id, material_id, quantity_per_unit)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`
+	for i := range variant.Overrides {
+		override := &variant.Overrides[i]
+		override.VariantID = variant.ID
+		if err := tx.QueryRow(query, variant.ID, override.MaterialID, override.QuantityPerUnit).Scan(&override.ID); err != nil {
+			return fmt.Errorf("ошибка сохранения замены материала %d: %w", override.MaterialID, err)
+		}
+	}
+	return nil
+}
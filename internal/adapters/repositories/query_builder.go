@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// whereClause накапливает условия WHERE и их параметры.
+// В условиях вместо номеров параметров используется "?", который заменяется на $N.
+type whereClause struct {
+	conditions []string
+	args       []interface{}
+}
+
+// add добавляет условие; каждый "?" в условии соответствует очередному значению
+func (w *whereClause) add(condition string, values ...interface{}) {
+	for _, value := range values {
+		w.args = append(w.args, value)
+		condition = strings.Replace(condition, "?", fmt.Sprintf("$%d", len(w.args)), 1)
+	}
+	w.conditions = append(w.conditions, condition)
+}
+
+// nextArg добавляет параметр без условия и возвращает его номер в виде $N
+func (w *whereClause) nextArg(value interface{}) string {
+	w.args = append(w.args, value)
+	return fmt.Sprintf("$%d", len(w.args))
+}
+
+// String возвращает фрагмент WHERE или пустую строку, если условий нет
+func (w *whereClause) String() string {
+	if len(w.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(w.conditions, " AND ")
+}
+
+// likePrefix экранирует спецсимволы LIKE и превращает строку в шаблон поиска по префиксу
+func likePrefix(prefix string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(prefix) + "%"
+}
+
+// orderBy строит фрагмент ORDER BY по разрешенному полю сортировки.
+// Последним ключом всегда идет idColumn, чтобы порядок страниц был стабильным.
+func orderBy(columns map[string]string, sortBy string, direction entities.SortDirection, idColumn string) string {
+	column, ok := columns[sortBy]
+	if !ok {
+		column = idColumn
+	}
+
+	dir := "ASC"
+	if direction == entities.SortDesc {
+		dir = "DESC"
+	}
+
+	return fmt.Sprintf(" ORDER BY %s %s, %s %s", column, dir, idColumn, dir)
+}
@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// headlineOptions настраивает фрагмент текста с найденными словами.
+// Маркеры « » безопасны для вывода в шаблонах без дополнительного экранирования.
+const headlineOptions = "StartSel=«, StopSel=», MaxWords=20, MinWords=5, MaxFragments=1"
+
+// searchRepositoryImpl реализует интерфейс SearchRepository на полнотекстовом поиске PostgreSQL
+type searchRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewSearchRepository создает новую реализацию репозитория поиска
+func NewSearchRepository(db *sql.DB) repositories.SearchRepository {
+	return &searchRepositoryImpl{db: db}
+}
+
+// SearchProducts ищет продукцию по названию, описанию и артикулу; архивная продукция не ищется
+func (r *searchRepositoryImpl) SearchProducts(query entities.SearchQuery) ([]entities.SearchResult, error) {
+	sqlQuery := `
+		SELECT p.id, p.name, p.article, pt.name,
+			ts_headline('russian', coalesce(nullif(p.description, ''), p.name), q, '` + headlineOptions + `'),
+			ts_rank(p.search_vector, q) + CASE WHEN p.article ILIKE $2 THEN 1 ELSE 0 END AS rank
+		FROM products p
+		JOIN product_types pt ON p.product_type_id = pt.id,
+			to_tsquery('russian', $1) q
+		WHERE p.archived_at IS NULL AND (p.search_vector @@ q OR p.article ILIKE $2)
+		ORDER BY rank DESC, p.name, p.id
+		LIMIT $3
+	`
+
+	return r.search(entities.SearchEntityProduct, sqlQuery, query)
+}
+
+// SearchMaterials ищет материалы по названию, описанию и артикулу; архивные материалы не ищутся
+func (r *searchRepositoryImpl) SearchMaterials(query entities.SearchQuery) ([]entities.SearchResult, error) {
+	sqlQuery := `
+		SELECT m.id, m.name, m.article, mt.name,
+			ts_headline('russian', coalesce(nullif(m.description, ''), m.name), q, '` + headlineOptions + `'),
+			ts_rank(m.search_vector, q) + CASE WHEN m.article ILIKE $2 THEN 1 ELSE 0 END AS rank
+		FROM materials m
+		JOIN material_types mt ON m.material_type_id = mt.id,
+			to_tsquery('russian', $1) q
+		WHERE m.archived_at IS NULL AND (m.search_vector @@ q OR m.article ILIKE $2)
+		ORDER BY rank DESC, m.name, m.id
+		LIMIT $3
+	`
+
+	return r.search(entities.SearchEntityMaterial, sqlQuery, query)
+}
+
+// SearchPartners ищет партнеров по наименованию, ИНН, директору и адресу
+func (r *searchRepositoryImpl) SearchPartners(query entities.SearchQuery) ([]entities.SearchResult, error) {
+	sqlQuery := `
+		SELECT pr.id, pr.company_name, pr.inn, pt.name,
+			ts_headline('russian', pr.director_name || ', ' || pr.legal_address, q, '` + headlineOptions + `'),
+			ts_rank(pr.search_vector, q) + CASE WHEN pr.inn LIKE $2 THEN 1 ELSE 0 END AS rank
+		FROM partners pr
+		JOIN partner_types pt ON pr.partner_type_id = pt.id,
+			to_tsquery('russian', $1) q
+		WHERE pr.search_vector @@ q OR pr.inn LIKE $2
+		ORDER BY rank DESC, pr.company_name, pr.id
+		LIMIT $3
+	`
+
+	return r.search(entities.SearchEntityPartner, sqlQuery, query)
+}
+
+// search выполняет поисковый запрос; параметры: $1 - tsquery, $2 - префикс кода, $3 - лимит
+func (r *searchRepositoryImpl) search(entityType entities.SearchEntityType, sqlQuery string, query entities.SearchQuery) ([]entities.SearchResult, error) {
+	rows, err := r.db.Query(sqlQuery, query.TSQuery(), likePrefix(query.Text), query.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения поиска: %w", err)
+	}
+	defer rows.Close()
+
+	results := []entities.SearchResult{}
+	for rows.Next() {
+		result := entities.SearchResult{EntityType: entityType}
+		err := rows.Scan(
+			&result.ID, &result.Title, &result.Code, &result.Category,
+			&result.Snippet, &result.Rank,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования результата поиска: %w", err)
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения результатов поиска: %w", err)
+	}
+
+	return results, nil
+}
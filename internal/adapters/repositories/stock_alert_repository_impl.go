@@ -0,0 +1,169 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+
+	"github.com/lib/pq"
+)
+
+// stockAlertRepositoryImpl реализует интерфейс StockAlertRepository
+type stockAlertRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewStockAlertRepository создает новую реализацию репозитория контроля остатков
+func NewStockAlertRepository(db *sql.DB) repositories.StockAlertRepository {
+	return &stockAlertRepositoryImpl{db: db}
+}
+
+// GetLowStockMaterials возвращает материалы с остатком не выше минимального, начиная с
+// материалов, остаток которых ниже всего относительно минимума
+func (r *stockAlertRepositoryImpl) GetLowStockMaterials() ([]entities.Material, error) {
+	query := materialSelectQuery + `
+		WHERE m.archived_at IS NULL AND m.min_stock_quantity > 0
+			AND m.stock_quantity <= m.min_stock_quantity
+		ORDER BY m.stock_quantity / m.min_stock_quantity, m.name`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса материалов с низким остатком: %w", err)
+	}
+	defer rows.Close()
+
+	var materials []entities.Material
+	for rows.Next() {
+		material, err := scanMaterial(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования материала: %w", err)
+		}
+		materials = append(materials, *material)
+	}
+
+	return materials, nil
+}
+
+// stockNotificationSelectQuery выбирает уведомления вместе с материалом; порядок столбцов
+// соответствует scanStockNotification
+const stockNotificationSelectQuery = `
+	SELECT
+		n.id, n.material_id, n.stock_quantity, n.min_stock_quantity,
+		n.created_at, n.acknowledged_at, n.resolved_at,
+		m.article, m.name, mu.symbol as abbreviation
+	FROM stock_notifications n
+	JOIN materials m ON n.material_id = m.id
+	JOIN measurement_units mu ON m.measurement_unit_id = mu.id
+`
+
+// scanStockNotification считывает строку stockNotificationSelectQuery
+func scanStockNotification(row rowScanner) (*entities.StockNotification, error) {
+	var notification entities.StockNotification
+	var material entities.Material
+	var unitAbbr string
+
+	err := row.Scan(
+		&notification.ID, &notification.MaterialID, &notification.StockQuantity,
+		&notification.MinStockQuantity, &notification.CreatedAt,
+		&notification.AcknowledgedAt, &notification.ResolvedAt,
+		&material.Article, &material.Name, &unitAbbr,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	material.ID = notification.MaterialID
+	material.MeasurementUnit = &entities.MeasurementUnit{Abbreviation: unitAbbr}
+	notification.Material = &material
+
+	return &notification, nil
+}
+
+// GetOpenNotifications возвращает уведомления, остаток по которым еще не восстановлен
+func (r *stockAlertRepositoryImpl) GetOpenNotifications() ([]entities.StockNotification, error) {
+	return r.queryNotifications(stockNotificationSelectQuery + " WHERE n.resolved_at IS NULL ORDER BY n.id")
+}
+
+// FindNotifications возвращает уведомления, новые первыми
+func (r *stockAlertRepositoryImpl) FindNotifications(openOnly bool) ([]entities.StockNotification, error) {
+	query := stockNotificationSelectQuery
+	if openOnly {
+		query += " WHERE n.resolved_at IS NULL"
+	}
+	return r.queryNotifications(query + " ORDER BY n.created_at DESC, n.id DESC")
+}
+
+func (r *stockAlertRepositoryImpl) queryNotifications(query string) ([]entities.StockNotification, error) {
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса уведомлений об остатках: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []entities.StockNotification
+	for rows.Next() {
+		notification, err := scanStockNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования уведомления об остатке: %w", err)
+		}
+		notifications = append(notifications, *notification)
+	}
+
+	return notifications, nil
+}
+
+// CreateNotification сохраняет новое уведомление
+func (r *stockAlertRepositoryImpl) CreateNotification(notification *entities.StockNotification) error {
+	query := `
+		INSERT INTO stock_notifications (material_id, stock_quantity, min_stock_quantity)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(query, notification.MaterialID, notification.StockQuantity, notification.MinStockQuantity).
+		Scan(&notification.ID, &notification.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка создания уведомления об остатке: %w", err)
+	}
+
+	return nil
+}
+
+// ResolveNotifications закрывает уведомления
+func (r *stockAlertRepositoryImpl) ResolveNotifications(ids []int, resolvedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := "UPDATE stock_notifications SET resolved_at = $2 WHERE id = ANY($1) AND resolved_at IS NULL"
+	if _, err := r.db.Exec(query, pq.Array(ids), resolvedAt); err != nil {
+		return fmt.Errorf("ошибка закрытия уведомлений об остатках: %w", err)
+	}
+
+	return nil
+}
+
+// AcknowledgeNotification отмечает уведомление просмотренным; повторная отметка не меняет время
+func (r *stockAlertRepositoryImpl) AcknowledgeNotification(id int, acknowledgedAt time.Time) error {
+	query := "UPDATE stock_notifications SET acknowledged_at = COALESCE(acknowledged_at, $2) WHERE id = $1"
+
+	result, err := r.db.Exec(query, id, acknowledgedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка отметки уведомления об остатке: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества обновленных строк: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.NewNotFoundError("уведомление", strconv.Itoa(id))
+	}
+
+	return nil
+}
@@ -0,0 +1,285 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// stocktakeRepositoryImpl реализует интерфейс StocktakeRepository
+type stocktakeRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewStocktakeRepository создает новую реализацию репозитория инвентаризаций
+func NewStocktakeRepository(db *sql.DB) repositories.StocktakeRepository {
+	return &stocktakeRepositoryImpl{db: db}
+}
+
+// GetAll возвращает инвентаризации, новые первыми
+func (r *stocktakeRepositoryImpl) GetAll() ([]entities.Stocktake, error) {
+	rows, err := r.db.Query("SELECT id, status, note, created_at, closed_at FROM stocktakes ORDER BY created_at DESC, id DESC")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения инвентаризаций: %w", err)
+	}
+	defer rows.Close()
+
+	stocktakes := []entities.Stocktake{}
+	for rows.Next() {
+		var stocktake entities.Stocktake
+		if err := rows.Scan(&stocktake.ID, &stocktake.Status, &stocktake.Note, &stocktake.CreatedAt, &stocktake.ClosedAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования инвентаризации: %w", err)
+		}
+		stocktakes = append(stocktakes, stocktake)
+	}
+
+	return stocktakes, rows.Err()
+}
+
+// GetByID возвращает инвентаризацию со строками, упорядоченными по артикулу материала.
+// У материала строки заполнен текущий остаток, чтобы видеть движения после открытия
+func (r *stocktakeRepositoryImpl) GetByID(id int) (*entities.Stocktake, error) {
+	var stocktake entities.Stocktake
+	err := r.db.QueryRow("SELECT id, status, note, created_at, closed_at FROM stocktakes WHERE id = $1", id).
+		Scan(&stocktake.ID, &stocktake.Status, &stocktake.Note, &stocktake.CreatedAt, &stocktake.ClosedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.NewNotFoundError("инвентаризация", strconv.Itoa(id))
+		}
+		return nil, fmt.Errorf("ошибка получения инвентаризации: %w", err)
+	}
+
+	rows, err := r.db.Query(`
+		SELECT i.id, i.stocktake_id, i.material_id, i.expected_quantity, i.counted_quantity,
+			i.unit_cost, i.counted_at, i.movement_id,
+			m.article, m.name, m.stock_quantity, mu.symbol as abbreviation
+		FROM stocktake_items i
+		JOIN materials m ON i.material_id = m.id
+		JOIN measurement_units mu ON m.measurement_unit_id = mu.id
+		WHERE i.stocktake_id = $1
+		ORDER BY m.article, i.id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения строк инвентаризации: %w", err)
+	}
+	defer rows.Close()
+
+	stocktake.Items = []entities.StocktakeItem{}
+	for rows.Next() {
+		var item entities.StocktakeItem
+		var material entities.Material
+		var movementID sql.NullInt64
+		var unitAbbr string
+		if err := rows.Scan(&item.ID, &item.StocktakeID, &item.MaterialID, &item.ExpectedQuantity,
+			&item.CountedQuantity, &item.UnitCost, &item.CountedAt, &movementID,
+			&material.Article, &material.Name, &material.StockQuantity, &unitAbbr); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования строки инвентаризации: %w", err)
+		}
+		if movementID.Valid {
+			movement := int(movementID.Int64)
+			item.MovementID = &movement
+		}
+		material.ID = item.MaterialID
+		material.MeasurementUnit = &entities.MeasurementUnit{Abbreviation: unitAbbr}
+		item.Material = &material
+		stocktake.Items = append(stocktake.Items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения строк инвентаризации: %w", err)
+	}
+
+	return &stocktake, nil
+}
+
+// Create открывает инвентаризацию; учетные остатки фиксируются одним запросом, поэтому
+// снимок согласован по всем материалам
+func (r *stocktakeRepositoryImpl) Create(stocktake *entities.Stocktake) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	var openID int
+	err = tx.QueryRow("SELECT id FROM stocktakes WHERE status = 'open'").Scan(&openID)
+	if err == nil {
+		return entities.NewBusinessError("STOCKTAKE_ALREADY_OPEN",
+			fmt.Sprintf("инвентаризация №%d еще не закрыта", openID))
+	}
+	if err != sql.ErrNoRows {
+		return fmt.Errorf("ошибка проверки открытой инвентаризации: %w", err)
+	}
+
+	err = tx.QueryRow("INSERT INTO stocktakes (status, note) VALUES ($1, $2) RETURNING id, status, created_at",
+		entities.StocktakeOpen, stocktake.Note).Scan(&stocktake.ID, &stocktake.Status, &stocktake.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка создания инвентаризации: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO stocktake_items (stocktake_id, material_id, expected_quantity, unit_cost)
+		SELECT $1, id, stock_quantity, cost_per_unit
+		FROM materials
+		WHERE archived_at IS NULL`, stocktake.ID)
+	if err != nil {
+		return fmt.Errorf("ошибка фиксации учетных остатков: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %w", err)
+	}
+
+	return nil
+}
+
+// SaveCounts сохраняет фактические остатки в одной транзакции
+func (r *stocktakeRepositoryImpl) SaveCounts(stocktakeID int, counts []entities.StocktakeCount, countedAt time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockOpenStocktake(tx, stocktakeID); err != nil {
+		return err
+	}
+
+	for _, count := range counts {
+		var counted *time.Time
+		if count.Quantity != nil {
+			counted = &countedAt
+		}
+
+		result, err := tx.Exec(`
+			UPDATE stocktake_items SET counted_quantity = $3, counted_at = $4
+			WHERE stocktake_id = $1 AND material_id = $2`,
+			stocktakeID, count.MaterialID, count.Quantity, counted)
+		if err != nil {
+			return fmt.Errorf("ошибка сохранения фактического остатка: %w", err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("ошибка получения количества обновленных строк: %w", err)
+		}
+		if rowsAffected == 0 {
+			return entities.NewValidationError("material_id",
+				fmt.Sprintf("материал с ID %d не входит в инвентаризацию", count.MaterialID))
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %w", err)
+	}
+
+	return nil
+}
+
+// Close проводит расхождения пересчитанных материалов и закрывает инвентаризацию.
+// Строки читаются после блокировки инвентаризации, поэтому проводятся ровно те остатки,
+// которые были введены к моменту закрытия
+func (r *stocktakeRepositoryImpl) Close(stocktakeID int, closedAt time.Time) ([]int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockOpenStocktake(tx, stocktakeID); err != nil {
+		return nil, err
+	}
+
+	// Материалы блокируются по порядку ID, как и при резервировании под заказы
+	rows, err := tx.Query(`
+		SELECT id, stocktake_id, material_id, expected_quantity, counted_quantity
+		FROM stocktake_items
+		WHERE stocktake_id = $1 AND counted_quantity IS NOT NULL
+		ORDER BY material_id`, stocktakeID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения строк инвентаризации: %w", err)
+	}
+
+	var items []entities.StocktakeItem
+	for rows.Next() {
+		var item entities.StocktakeItem
+		if err := rows.Scan(&item.ID, &item.StocktakeID, &item.MaterialID, &item.ExpectedQuantity, &item.CountedQuantity); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("ошибка сканирования строки инвентаризации: %w", err)
+		}
+		items = append(items, item)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения строк инвентаризации: %w", err)
+	}
+
+	var costChangedIDs []int
+	for _, item := range items {
+		movement := item.Adjustment()
+		if movement == nil {
+			continue
+		}
+		if err := postMovement(tx, movement); err != nil {
+			return nil, err
+		}
+		if movement.CostChanged {
+			costChangedIDs = append(costChangedIDs, movement.MaterialID)
+		}
+		if _, err := tx.Exec("UPDATE stocktake_items SET movement_id = $2 WHERE id = $1", item.ID, movement.ID); err != nil {
+			return nil, fmt.Errorf("ошибка сохранения движения инвентаризации: %w", err)
+		}
+	}
+
+	_, err = tx.Exec("UPDATE stocktakes SET status = $2, closed_at = $3 WHERE id = $1",
+		stocktakeID, entities.StocktakeClosed, closedAt)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка закрытия инвентаризации: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка подтверждения транзакции: %w", err)
+	}
+
+	return costChangedIDs, nil
+}
+
+// Cancel отменяет открытую инвентаризацию
+func (r *stocktakeRepositoryImpl) Cancel(stocktakeID int, cancelledAt time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockOpenStocktake(tx, stocktakeID); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("UPDATE stocktakes SET status = $2, closed_at = $3 WHERE id = $1",
+		stocktakeID, entities.StocktakeCancelled, cancelledAt)
+	if err != nil {
+		return fmt.Errorf("ошибка отмены инвентаризации: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %w", err)
+	}
+
+	return nil
+}
+
+// lockOpenStocktake блокирует инвентаризацию до конца транзакции и проверяет, что она открыта:
+// параллельные ввод остатков, закрытие и отмена выполняются по очереди
+func lockOpenStocktake(db dbExecutor, stocktakeID int) error {
+	stocktake := entities.Stocktake{ID: stocktakeID}
+	err := db.QueryRow("SELECT status FROM stocktakes WHERE id = $1 FOR UPDATE", stocktakeID).Scan(&stocktake.Status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entities.NewNotFoundError("инвентаризация", strconv.Itoa(stocktakeID))
+		}
+		return fmt.Errorf("ошибка получения инвентаризации: %w", err)
+	}
+	return stocktake.EnsureOpen()
+}
@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+
+	"github.com/lib/pq"
+)
+
+// unitRepositoryImpl реализует интерфейс UnitRepository
+type unitRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewUnitRepository создает новую реализацию репозитория единиц измерения
+func NewUnitRepository(db *sql.DB) repositories.UnitRepository {
+	return &unitRepositoryImpl{db: db}
+}
+
+// materialConversionSelectQuery выбирает пересчеты материалов вместе с единицей измерения;
+// порядок столбцов соответствует scanMaterialConversion
+const materialConversionSelectQuery = `
+	SELECT c.id, c.material_id, c.unit_id, c.factor, c.roll_width, c.roll_length, c.created_at, c.updated_at,
+		u.id, u.name, u.symbol, u.category, u.base_factor, u.created_at
+	FROM material_unit_conversions c
+	JOIN measurement_units u ON u.id = c.unit_id
+`
+
+// scanMeasurementUnit сканирует столбцы id, name, symbol, category, base_factor, created_at
+func scanMeasurementUnit(row rowScanner) (*entities.MeasurementUnit, error) {
+	var unit entities.MeasurementUnit
+	var category sql.NullString
+	err := row.Scan(&unit.ID, &unit.Name, &unit.Abbreviation, &category, &unit.BaseFactor, &unit.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	unit.Category = entities.UnitCategory(category.String)
+	return &unit, nil
+}
+
+// queryMeasurementUnits возвращает все единицы измерения по названию
+func queryMeasurementUnits(db *sql.DB) ([]entities.MeasurementUnit, error) {
+	query := "SELECT id, name, symbol, category, base_factor, created_at FROM measurement_units ORDER BY name"
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса единиц измерения: %w", err)
+	}
+	defer rows.Close()
+
+	units := []entities.MeasurementUnit{}
+	for rows.Next() {
+		unit, err := scanMeasurementUnit(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования единицы измерения: %w", err)
+		}
+		units = append(units, *unit)
+	}
+
+	return units, rows.Err()
+}
+
+// scanMaterialConversion сканирует строку materialConversionSelectQuery
+func scanMaterialConversion(rows *sql.Rows) (*entities.MaterialUnitConversion, error) {
+	var conversion entities.MaterialUnitConversion
+	var unit entities.MeasurementUnit
+	var category sql.NullString
+	err := rows.Scan(
+		&conversion.ID, &conversion.MaterialID, &conversion.UnitID, &conversion.Factor,
+		&conversion.RollWidth, &conversion.RollLength, &conversion.CreatedAt, &conversion.UpdatedAt,
+		&unit.ID, &unit.Name, &unit.Abbreviation, &category, &unit.BaseFactor, &unit.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	unit.Category = entities.UnitCategory(category.String)
+	conversion.Unit = &unit
+	return &conversion, nil
+}
+
+// GetAll возвращает все единицы измерения
+func (r *unitRepositoryImpl) GetAll() ([]entities.MeasurementUnit, error) {
+	return queryMeasurementUnits(r.db)
+}
+
+// GetMaterialConversions возвращает пересчеты материала вместе с единицами измерения
+func (r *unitRepositoryImpl) GetMaterialConversions(materialID int) ([]entities.MaterialUnitConversion, error) {
+	conversions, err := r.GetConversionsForMaterials([]int{materialID})
+	if err != nil {
+		return nil, err
+	}
+	if conversions[materialID] == nil {
+		return []entities.MaterialUnitConversion{}, nil
+	}
+	return conversions[materialID], nil
+}
+
+// GetConversionsForMaterials возвращает пересчеты нескольких материалов одним запросом
+func (r *unitRepositoryImpl) GetConversionsForMaterials(materialIDs []int) (map[int][]entities.MaterialUnitConversion, error) {
+	result := make(map[int][]entities.MaterialUnitConversion)
+	if len(materialIDs) == 0 {
+		return result, nil
+	}
+
+	rows, err := r.db.Query(materialConversionSelectQuery+" WHERE c.material_id = ANY($1) ORDER BY u.name",
+		pq.Array(materialIDs))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения пересчетов единиц материалов: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		conversion, err := scanMaterialConversion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования пересчета единиц материала: %w", err)
+		}
+		result[conversion.MaterialID] = append(result[conversion.MaterialID], *conversion)
+	}
+
+	return result, rows.Err()
+}
+
+// SaveMaterialConversion создает или заменяет пересчет материала в единицу conversion.UnitID
+func (r *unitRepositoryImpl) SaveMaterialConversion(conversion *entities.MaterialUnitConversion) error {
+	query := `
+		INSERT INTO material_unit_conversions (material_id, unit_id, factor, roll_width, roll_length)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (material_id, unit_id) DO UPDATE
+		SET factor = EXCLUDED.factor, roll_width = EXCLUDED.roll_width, roll_length = EXCLUDED.roll_length,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(query, conversion.MaterialID, conversion.UnitID, conversion.Factor,
+		conversion.RollWidth, conversion.RollLength).Scan(&conversion.ID, &conversion.CreatedAt, &conversion.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения пересчета единиц материала: %w", err)
+	}
+	return nil
+}
+
+// DeleteMaterialConversion удаляет пересчет материала в единицу unitID
+func (r *unitRepositoryImpl) DeleteMaterialConversion(materialID, unitID int) error {
+	result, err := r.db.Exec("DELETE FROM material_unit_conversions WHERE material_id = $1 AND unit_id = $2",
+		materialID, unitID)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления пересчета единиц материала: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества удаленных строк: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.NewNotFoundError("пересчет единицы материала", strconv.Itoa(unitID))
+	}
+
+	return nil
+}
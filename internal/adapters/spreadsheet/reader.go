@@ -0,0 +1,322 @@
+// Package spreadsheet читает и записывает табличные файлы (CSV и XLSX),
+// которыми обмениваются с конструкторским отделом и партнерами
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// maxXLSXPartSize ограничивает размер распакованной части книги, чтобы сжатый файл
+// не развернулся в память целиком
+const maxXLSXPartSize = 64 << 20
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// ReadTable читает файл в заданном формате. Первая непустая строка считается заголовком,
+// пустые строки пропускаются, номера строк соответствуют исходному файлу
+func ReadTable(format entities.ImportFormat, data []byte) (*entities.ImportTable, error) {
+	var rows []entities.ImportRow
+	var err error
+
+	switch format {
+	case entities.ImportFormatCSV:
+		rows, err = readCSV(data)
+	case entities.ImportFormatXLSX:
+		rows, err = readXLSX(data)
+	default:
+		return nil, entities.NewValidationError("format", "неподдерживаемый формат файла")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	table := &entities.ImportTable{}
+	for _, row := range rows {
+		if isEmptyRow(row.Values) {
+			continue
+		}
+		if table.Header == nil {
+			table.Header = row.Values
+			continue
+		}
+		table.Rows = append(table.Rows, row)
+	}
+
+	return table, nil
+}
+
+func readCSV(data []byte) ([]entities.ImportRow, error) {
+	data = bytes.TrimPrefix(data, utf8BOM)
+	if !utf8.Valid(data) {
+		return nil, entities.NewValidationError("file", "файл CSV должен быть в кодировке UTF-8")
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = detectDelimiter(data)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	var rows []entities.ImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, entities.NewValidationError("file", fmt.Sprintf("ошибка чтения CSV: %v", err))
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, entities.ImportRow{Number: line, Values: trimValues(record)})
+	}
+
+	return rows, nil
+}
+
+// detectDelimiter выбирает разделитель по первой строке: Excel с русской локалью
+// сохраняет CSV через точку с запятой
+func detectDelimiter(data []byte) rune {
+	firstLine := data
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		firstLine = data[:i]
+	}
+
+	delimiter, best := ',', bytes.Count(firstLine, []byte{','})
+	for _, candidate := range []rune{';', '\t'} {
+		if count := bytes.Count(firstLine, []byte(string(candidate))); count > best {
+			delimiter, best = candidate, count
+		}
+	}
+	return delimiter
+}
+
+// Структуры частей книги XLSX (Office Open XML), которые нужны для чтения первого листа
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RelationID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxRichText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxRichText) String() string {
+	var sb strings.Builder
+	sb.WriteString(t.Text)
+	for _, run := range t.Runs {
+		sb.WriteString(run.Text)
+	}
+	return sb.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxRichText `xml:"si"`
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Number int `xml:"r,attr"`
+		Cells  []struct {
+			Ref    string       `xml:"r,attr"`
+			Type   string       `xml:"t,attr"`
+			Value  string       `xml:"v"`
+			Inline xlsxRichText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func readXLSX(data []byte) ([]entities.ImportRow, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, entities.NewValidationError("file", "файл не является книгой XLSX")
+	}
+
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var sharedStrings xlsxSharedStrings
+	if file, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeXLSXPart(file, &sharedStrings); err != nil {
+			return nil, err
+		}
+	}
+
+	sheetFile, ok := files[sheetPath]
+	if !ok {
+		return nil, entities.NewValidationError("file", "в книге XLSX не найден лист "+sheetPath)
+	}
+	var sheet xlsxWorksheet
+	if err := decodeXLSXPart(sheetFile, &sheet); err != nil {
+		return nil, err
+	}
+
+	rows := make([]entities.ImportRow, 0, len(sheet.Rows))
+	for i, sheetRow := range sheet.Rows {
+		number := sheetRow.Number
+		if number == 0 {
+			number = i + 1
+		}
+
+		var values []string
+		for j, cell := range sheetRow.Cells {
+			column := columnIndex(cell.Ref)
+			if column < 0 {
+				column = j
+			}
+
+			var value string
+			switch cell.Type {
+			case "s":
+				index, err := strconv.Atoi(cell.Value)
+				if err != nil || index < 0 || index >= len(sharedStrings.Items) {
+					return nil, entities.NewValidationError("file", fmt.Sprintf("некорректная ссылка на строку в ячейке %s", cell.Ref))
+				}
+				value = sharedStrings.Items[index].String()
+			case "inlineStr":
+				value = cell.Inline.String()
+			case "", "n":
+				value = normalizeNumber(cell.Value)
+			default:
+				value = cell.Value
+			}
+
+			for len(values) <= column {
+				values = append(values, "")
+			}
+			values[column] = strings.TrimSpace(value)
+		}
+
+		rows = append(rows, entities.ImportRow{Number: number, Values: values})
+	}
+
+	return rows, nil
+}
+
+// firstSheetPath находит путь к первому листу книги через workbook.xml и его связи
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	workbookFile, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", entities.NewValidationError("file", "файл не является книгой XLSX")
+	}
+	var workbook xlsxWorkbook
+	if err := decodeXLSXPart(workbookFile, &workbook); err != nil {
+		return "", err
+	}
+	if len(workbook.Sheets) == 0 {
+		return "", entities.NewValidationError("file", "книга XLSX не содержит листов")
+	}
+
+	relsFile, ok := files["xl/_rels/workbook.xml.rels"]
+	if !ok {
+		return "xl/worksheets/sheet1.xml", nil
+	}
+	var rels xlsxRelationships
+	if err := decodeXLSXPart(relsFile, &rels); err != nil {
+		return "", err
+	}
+
+	for _, rel := range rels.Relationships {
+		if rel.ID != workbook.Sheets[0].RelationID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+
+	return "", entities.NewValidationError("file", "в книге XLSX не найден первый лист")
+}
+
+func decodeXLSXPart(file *zip.File, target interface{}) error {
+	reader, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("ошибка чтения %s: %w", file.Name, err)
+	}
+	defer reader.Close()
+
+	limited := &io.LimitedReader{R: reader, N: maxXLSXPartSize}
+	if err := xml.NewDecoder(limited).Decode(target); err != nil {
+		if limited.N <= 0 {
+			return entities.NewValidationError("file", "книга XLSX слишком большая")
+		}
+		if errors.Is(err, io.EOF) {
+			return entities.NewValidationError("file", fmt.Sprintf("часть книги %s пуста", file.Name))
+		}
+		return entities.NewValidationError("file", fmt.Sprintf("ошибка разбора %s: %v", file.Name, err))
+	}
+	return nil
+}
+
+// columnIndex переводит ссылку на ячейку (например, "AB12") в номер столбца, начиная с нуля
+func columnIndex(ref string) int {
+	index := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		index = index*26 + int(r-'A'+1)
+	}
+	return index - 1
+}
+
+// normalizeNumber убирает погрешность двоичного представления, с которой Excel
+// сохраняет дробные числа (0.30000000000000004 -> 0.3)
+func normalizeNumber(value string) string {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return value
+	}
+	rounded, err := strconv.ParseFloat(strconv.FormatFloat(number, 'g', 15, 64), 64)
+	if err != nil {
+		return value
+	}
+	return strconv.FormatFloat(rounded, 'f', -1, 64)
+}
+
+func trimValues(values []string) []string {
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+	}
+	return values
+}
+
+func isEmptyRow(values []string) bool {
+	for _, value := range values {
+		if value != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,83 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadTable_CSV(t *testing.T) {
+	data := append([]byte{0xEF, 0xBB, 0xBF}, []byte(
+		"Артикул;Наименование;Мин. цена (руб)\n"+
+			"\n"+
+			"WP-001;\"Обои \"\"Классика\"\"\";1 250,50\n"+
+			"WP-002;Обои флизелиновые;900\n")...)
+
+	table, err := ReadTable(entities.ImportFormatCSV, data)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Артикул", "Наименование", "Мин. цена (руб)"}, table.Header)
+	require.Len(t, table.Rows, 2)
+	assert.Equal(t, 3, table.Rows[0].Number)
+	assert.Equal(t, []string{"WP-001", `Обои "Классика"`, "1 250,50"}, table.Rows[0].Values)
+	assert.Equal(t, 4, table.Rows[1].Number)
+}
+
+func TestReadTable_CSVNotUTF8(t *testing.T) {
+	// "Артикул" в кодировке Windows-1251
+	data := []byte{0xC0, 0xF0, 0xF2, 0xE8, 0xEA, 0xF3, 0xEB, '\n', 'A', '\n'}
+
+	_, err := ReadTable(entities.ImportFormatCSV, data)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "UTF-8")
+}
+
+func TestReadTable_XLSX(t *testing.T) {
+	data := buildXLSX(t, map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Каталог" sheetId="1" r:id="rId3"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId3" Target="worksheets/catalog.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<si><t>Артикул</t></si><si><t>Цена</t></si><si><r><t>Обои </t></r><r><t>белые</t></r></si></sst>`,
+		"xl/worksheets/catalog.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` +
+			`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="s"><v>1</v></c></row>` +
+			`<row r="2"><c r="A2" t="inlineStr"><is><t>WP-001</t></is></c><c r="B2" t="s"><v>2</v></c><c r="C2"><v>0.30000000000000004</v></c></row>` +
+			`<row r="5"><c r="A5" t="str"><v>WP-002</v></c></row>` +
+			`</sheetData></worksheet>`,
+	})
+
+	table, err := ReadTable(entities.ImportFormatXLSX, data)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Артикул", "", "Цена"}, table.Header)
+	require.Len(t, table.Rows, 2)
+	assert.Equal(t, entities.ImportRow{Number: 2, Values: []string{"WP-001", "Обои белые", "0.3"}}, table.Rows[0])
+	assert.Equal(t, entities.ImportRow{Number: 5, Values: []string{"WP-002"}}, table.Rows[1])
+}
+
+func TestReadTable_XLSXInvalidArchive(t *testing.T) {
+	_, err := ReadTable(entities.ImportFormatXLSX, []byte("not a zip"))
+
+	assert.Error(t, err)
+}
+
+func buildXLSX(t *testing.T, parts map[string]string) []byte {
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, content := range parts {
+		part, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = part.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buf.Bytes()
+}
@@ -0,0 +1,237 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// maxColumnWidth ограничивает ширину столбца листа XLSX (в символах)
+const maxColumnWidth = 60
+
+// WriteCSV записывает выгрузку в CSV для Excel с русской локалью: UTF-8 с BOM,
+// разделитель "точка с запятой", дробная часть через запятую. Группы выводятся подряд
+func WriteCSV(w io.Writer, table *entities.ExportTable) error {
+	if _, err := w.Write(utf8BOM); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = ';'
+
+	header := make([]string, len(table.Columns))
+	for i, column := range table.Columns {
+		header[i] = column.Title
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, group := range table.Groups {
+		for _, row := range group.Rows {
+			record := make([]string, len(row))
+			for i, cell := range row {
+				if cell.Number != nil {
+					record[i] = strings.Replace(formatNumber(*cell.Number), ".", ",", 1)
+				} else {
+					record[i] = escapeFormula(cell.Text)
+				}
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// escapeFormula экранирует текст, который Excel принял бы за формулу: значение, начинающееся
+// с =, +, -, @, табуляции или перевода каретки, получает префикс-апостроф
+func escapeFormula(text string) string {
+	if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
+
+// WriteXLSX записывает выгрузку в книгу XLSX с одним листом: жирный заголовок,
+// числа хранятся как числа с форматом "# ##0,00"
+func WriteXLSX(w io.Writer, table *entities.ExportTable) error {
+	archive := zip.NewWriter(w)
+
+	parts := []struct {
+		name    string
+		content []byte
+	}{
+		{"[Content_Types].xml", []byte(xlsxContentTypes)},
+		{"_rels/.rels", []byte(xlsxRootRels)},
+		{"xl/workbook.xml", []byte(fmt.Sprintf(xlsxWorkbookTemplate, escapeXML(sheetName(table.Title))))},
+		{"xl/_rels/workbook.xml.rels", []byte(xlsxWorkbookRels)},
+		{"xl/styles.xml", []byte(xlsxStyles)},
+		{"xl/worksheets/sheet1.xml", buildSheet(table)},
+	}
+
+	for _, part := range parts {
+		writer, err := archive.Create(part.name)
+		if err != nil {
+			return fmt.Errorf("ошибка записи %s: %w", part.name, err)
+		}
+		if _, err := writer.Write(part.content); err != nil {
+			return fmt.Errorf("ошибка записи %s: %w", part.name, err)
+		}
+	}
+
+	return archive.Close()
+}
+
+// Стили листа: 0 - обычная ячейка, 1 - заголовок, 2 - число с двумя знаками после запятой
+const (
+	xlsxStyleHeader = 1
+	xlsxStyleNumber = 2
+)
+
+func buildSheet(table *entities.ExportTable) []byte {
+	widths := make([]int, len(table.Columns))
+	for i, column := range table.Columns {
+		widths[i] = utf8.RuneCountInString(column.Title)
+	}
+
+	var rows bytes.Buffer
+	writeSheetRow(&rows, 1, headerCells(table), xlsxStyleHeader)
+	number := 2
+	for _, group := range table.Groups {
+		for _, row := range group.Rows {
+			for i, cell := range row {
+				if width := utf8.RuneCountInString(cell.String()); i < len(widths) && width > widths[i] {
+					widths[i] = width
+				}
+			}
+			writeSheetRow(&rows, number, row, 0)
+			number++
+		}
+	}
+
+	var sheet bytes.Buffer
+	sheet.WriteString(xml.Header)
+	sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if len(widths) > 0 {
+		sheet.WriteString("<cols>")
+		for i, width := range widths {
+			if width > maxColumnWidth {
+				width = maxColumnWidth
+			}
+			fmt.Fprintf(&sheet, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, i+1, i+1, width+2)
+		}
+		sheet.WriteString("</cols>")
+	}
+	sheet.WriteString("<sheetData>")
+	sheet.Write(rows.Bytes())
+	sheet.WriteString("</sheetData></worksheet>")
+
+	return sheet.Bytes()
+}
+
+func headerCells(table *entities.ExportTable) []entities.ExportCell {
+	cells := make([]entities.ExportCell, len(table.Columns))
+	for i, column := range table.Columns {
+		cells[i] = entities.TextCell(column.Title)
+	}
+	return cells
+}
+
+func writeSheetRow(buf *bytes.Buffer, number int, cells []entities.ExportCell, style int) {
+	fmt.Fprintf(buf, `<row r="%d">`, number)
+	for i, cell := range cells {
+		ref := columnName(i) + strconv.Itoa(number)
+		switch {
+		case cell.Number != nil:
+			fmt.Fprintf(buf, `<c r="%s" s="%d"><v>%s</v></c>`, ref, xlsxStyleNumber, formatNumber(*cell.Number))
+		case cell.Text != "":
+			styleAttr := ""
+			if style != 0 {
+				styleAttr = fmt.Sprintf(` s="%d"`, style)
+			}
+			fmt.Fprintf(buf, `<c r="%s" t="inlineStr"%s><is><t xml:space="preserve">%s</t></is></c>`, ref, styleAttr, escapeXML(cell.Text))
+		}
+	}
+	buf.WriteString("</row>")
+}
+
+// columnName переводит номер столбца, начиная с нуля, в буквенное обозначение (0 -> A, 27 -> AB)
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// sheetName приводит заголовок к допустимому имени листа Excel: до 31 символа, без []:*?/\
+func sheetName(title string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return -1
+		}
+		return r
+	}, title)
+	if name == "" {
+		return "Лист1"
+	}
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	return name
+}
+
+func formatNumber(number float64) string {
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
+
+func escapeXML(text string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(text))
+	return buf.String()
+}
+
+const xlsxContentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+	`</Types>`
+
+const xlsxRootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const xlsxWorkbookTemplate = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+	`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+const xlsxWorkbookRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+	`</Relationships>`
+
+const xlsxStyles = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="3">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`</cellXfs></styleSheet>`
@@ -0,0 +1,101 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newExportTable() *entities.ExportTable {
+	price := 1250.5
+	cost := 900.0
+	return &entities.ExportTable{
+		Title:       "Прайс-лист продукции",
+		GeneratedAt: time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC),
+		Columns: []entities.ExportColumn{
+			{Key: "article", Title: "Артикул"},
+			{Key: "name", Title: "Наименование"},
+			{Key: "price", Title: "Цена", Numeric: true},
+		},
+		Groups: []entities.ExportGroup{
+			{Name: "Бумажные обои", Rows: [][]entities.ExportCell{
+				{entities.TextCell("WP-002"), entities.TextCell(`Обои "Классика"`), entities.NumberCell(&price)},
+			}},
+			{Name: "Виниловые обои", Rows: [][]entities.ExportCell{
+				{entities.TextCell("WP-001"), entities.TextCell("Винил & флизелин"), entities.NumberCell(&cost)},
+				{entities.TextCell("WP-003"), entities.TextCell("Без цены"), entities.NumberCell(nil)},
+			}},
+		},
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WriteCSV(&buf, newExportTable())
+
+	require.NoError(t, err)
+	assert.Equal(t, "\xEF\xBB\xBF"+
+		"Артикул;Наименование;Цена\n"+
+		"WP-002;\"Обои \"\"Классика\"\"\";1250,5\n"+
+		"WP-001;Винил & флизелин;900\n"+
+		"WP-003;Без цены;\n", buf.String())
+}
+
+func TestWriteCSV_EscapesFormulas(t *testing.T) {
+	var buf bytes.Buffer
+	table := &entities.ExportTable{
+		Columns: []entities.ExportColumn{{Key: "name", Title: "Наименование"}},
+		Groups: []entities.ExportGroup{{Rows: [][]entities.ExportCell{
+			{entities.TextCell("=HYPERLINK(\"http://example.com\")")},
+			{entities.TextCell("+7 900")},
+			{entities.TextCell("-скидка")},
+			{entities.TextCell("@SUM(A1)")},
+			{entities.TextCell("\tвкладка")},
+			{entities.TextCell("\rстрока")},
+			{entities.TextCell("Обои 1+1")},
+		}}},
+	}
+
+	err := WriteCSV(&buf, table)
+
+	require.NoError(t, err)
+	assert.Equal(t, "\xEF\xBB\xBF"+
+		"Наименование\n"+
+		"\"'=HYPERLINK(\"\"http://example.com\"\")\"\n"+
+		"'+7 900\n"+
+		"'-скидка\n"+
+		"'@SUM(A1)\n"+
+		"'\tвкладка\n"+
+		"\"'\rстрока\"\n"+
+		"Обои 1+1\n", buf.String())
+}
+
+func TestWriteXLSX_ReadBack(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WriteXLSX(&buf, newExportTable())
+	require.NoError(t, err)
+
+	// Выгруженную книгу можно загрузить обратно импортом
+	table, err := ReadTable(entities.ImportFormatXLSX, buf.Bytes())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Артикул", "Наименование", "Цена"}, table.Header)
+	require.Len(t, table.Rows, 3)
+	assert.Equal(t, []string{"WP-002", `Обои "Классика"`, "1250.5"}, table.Rows[0].Values)
+	assert.Equal(t, []string{"WP-001", "Винил & флизелин", "900"}, table.Rows[1].Values)
+	assert.Equal(t, "WP-003", table.Rows[2].Values[0])
+}
+
+func TestColumnName(t *testing.T) {
+	assert.Equal(t, "A", columnName(0))
+	assert.Equal(t, "Z", columnName(25))
+	assert.Equal(t, "AA", columnName(26))
+	assert.Equal(t, "AB", columnName(27))
+}
@@ -0,0 +1,89 @@
+// Package storage содержит реализации хранилища загруженных файлов
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// localStorage хранит файлы в каталоге на диске сервера и отдает их по префиксу URL
+type localStorage struct {
+	root      string
+	urlPrefix string
+}
+
+// NewLocalStorage создает хранилище в каталоге root; файлы доступны по ссылкам
+// вида urlPrefix/ключ, раздачу каталога по этому префиксу настраивает роутер
+func NewLocalStorage(root, urlPrefix string) repositories.FileStorage {
+	return &localStorage{
+		root:      root,
+		urlPrefix: "/" + strings.Trim(urlPrefix, "/"),
+	}
+}
+
+// Save атомарно записывает файл: сначала во временный файл, затем переименовывает
+func (s *localStorage) Save(key string, data []byte) (string, error) {
+	clean, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	target := filepath.Join(s.root, filepath.FromSlash(clean))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return "", fmt.Errorf("ошибка создания каталога: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return "", fmt.Errorf("ошибка создания файла: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("ошибка записи файла: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("ошибка записи файла: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return "", fmt.Errorf("ошибка записи файла: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return "", fmt.Errorf("ошибка сохранения файла: %w", err)
+	}
+
+	return s.urlPrefix + "/" + clean, nil
+}
+
+// Delete удаляет файл по ссылке; ссылки вне префикса хранилища пропускаются
+func (s *localStorage) Delete(url string) error {
+	key, ok := strings.CutPrefix(url, s.urlPrefix+"/")
+	if !ok {
+		return nil
+	}
+	clean, err := cleanKey(key)
+	if err != nil {
+		return nil
+	}
+
+	err = os.Remove(filepath.Join(s.root, filepath.FromSlash(clean)))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("ошибка удаления файла: %w", err)
+	}
+	return nil
+}
+
+// cleanKey нормализует ключ файла и не дает выйти за пределы каталога хранилища
+func cleanKey(key string) (string, error) {
+	clean := path.Clean("/" + key)[1:]
+	if clean == "" || strings.Contains(key, "\\") || strings.Contains(key, "..") {
+		return "", fmt.Errorf("недопустимое имя файла %q", key)
+	}
+	return clean, nil
+}
@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage_SaveAndDelete(t *testing.T) {
+	root := t.TempDir()
+	storage := NewLocalStorage(root, "/uploads/")
+
+	url, err := storage.Save("products/12/a1b2.jpg", []byte("image"))
+
+	require.NoError(t, err)
+	assert.Equal(t, "/uploads/products/12/a1b2.jpg", url)
+	data, err := os.ReadFile(filepath.Join(root, "products", "12", "a1b2.jpg"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("image"), data)
+
+	require.NoError(t, storage.Delete(url))
+	_, err = os.Stat(filepath.Join(root, "products", "12", "a1b2.jpg"))
+	assert.True(t, os.IsNotExist(err))
+
+	// Повторное удаление не считается ошибкой
+	assert.NoError(t, storage.Delete(url))
+}
+
+func TestLocalStorage_RejectsPathTraversal(t *testing.T) {
+	storage := NewLocalStorage(t.TempDir(), "/uploads")
+
+	_, err := storage.Save("../outside.jpg", []byte("image"))
+
+	assert.Error(t, err)
+}
+
+func TestLocalStorage_DeleteIgnoresForeignPaths(t *testing.T) {
+	root := t.TempDir()
+	outside := filepath.Join(t.TempDir(), "logo.png")
+	require.NoError(t, os.WriteFile(outside, []byte("logo"), 0o644))
+	storage := NewLocalStorage(root, "/uploads")
+
+	assert.NoError(t, storage.Delete("/static/images/logo.png"))
+	assert.NoError(t, storage.Delete("/uploads/../../logo.png"))
+
+	_, err := os.Stat(outside)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,170 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// ProductComponent представляет полуфабрикат (другую продукцию) в рецептуре продукции
+type ProductComponent struct {
+	ID                 int
+	ProductID          int
+	ComponentProductID int
+	QuantityPerUnit    float64
+	CreatedAt          time.Time
+	Component          *Product
+}
+
+// MaterialRequirement представляет потребность в сырье, полученную разузлованием рецептуры
+type MaterialRequirement struct {
+	MaterialID int
+	Material   *Material
+	Quantity   float64
+}
+
+// Validate проверяет корректность строки полуфабриката в рецептуре
+func (pc *ProductComponent) Validate() error {
+	if pc.ComponentProductID <= 0 {
+		return NewValidationError("component_product_id", "ID полуфабриката должен быть больше нуля")
+	}
+	if pc.ProductID != 0 && pc.ProductID == pc.ComponentProductID {
+		return NewValidationError("component_product_id", "продукция не может быть полуфабрикатом самой себя")
+	}
+	if pc.QuantityPerUnit <= 0 {
+		return NewValidationError("quantity_per_unit", "количество полуфабриката на единицу продукции должно быть больше нуля")
+	}
+	return nil
+}
+
+// NewBOMCycleError создает ошибку циклической ссылки в рецептуре
+func NewBOMCycleError(productID int) *BusinessError {
+	return NewBusinessError("BOM_CYCLE", fmt.Sprintf("рецептура содержит циклическую ссылку на продукцию с ID %d", productID))
+}
+
+// newComponentNotLoadedError создает ошибку расчета по рецептуре, в которой не загружен полуфабрикат
+func newComponentNotLoadedError(componentProductID int) *BusinessError {
+	return NewBusinessError("BOM_INCOMPLETE", fmt.Sprintf("рецептура полуфабриката с ID %d не загружена", componentProductID))
+}
+
+// CalculateCost рассчитывает себестоимость продукции, рекурсивно обходя полуфабрикаты.
+// На каждом уровне применяется коэффициент типа соответствующей продукции. Рецептура должна
+// быть загружена полностью: без материала или полуфабриката себестоимость была бы занижена
+func (p *Product) CalculateCost() (float64, error) {
+	return p.calculateCost(make(map[int]bool))
+}
+
+func (p *Product) calculateCost(path map[int]bool) (float64, error) {
+	coefficient, err := p.typeCoefficient()
+	if err != nil {
+		return 0, err
+	}
+	if len(p.Materials) == 0 && len(p.Components) == 0 {
+		return 0, nil
+	}
+
+	if path[p.ID] {
+		return 0, NewBOMCycleError(p.ID)
+	}
+	path[p.ID] = true
+	defer delete(path, p.ID)
+
+	cost := 0.0
+	for _, pm := range p.Materials {
+		if pm.Material == nil {
+			return 0, NewBusinessError("BOM_INCOMPLETE", fmt.Sprintf("материал с ID %d в рецептуре не загружен", pm.MaterialID))
+		}
+		cost += pm.QuantityPerUnit * pm.Material.CostPerUnit
+	}
+
+	for _, pc := range p.Components {
+		if pc.Component == nil {
+			return 0, newComponentNotLoadedError(pc.ComponentProductID)
+		}
+		componentCost, err := pc.Component.calculateCost(path)
+		if err != nil {
+			return 0, err
+		}
+		cost += pc.QuantityPerUnit * componentCost
+	}
+
+	// Применяем коэффициент типа продукции
+	return cost * coefficient, nil
+}
+
+// ExplodeMaterials раскладывает многоуровневую рецептуру до сырья на заданное количество продукции.
+// Расход на каждом уровне умножается на коэффициент типа продукции этого уровня, а расход
+// сырья - еще и на процент брака его типа материала, как в расчете потребности в материале.
+func (p *Product) ExplodeMaterials(quantity float64) ([]MaterialRequirement, error) {
+	if quantity <= 0 {
+		return nil, NewValidationError("quantity", "количество продукции должно быть больше нуля")
+	}
+
+	requirements := []MaterialRequirement{}
+	index := make(map[int]int)
+	if err := p.explode(quantity, make(map[int]bool), &requirements, index); err != nil {
+		return nil, err
+	}
+
+	return requirements, nil
+}
+
+func (p *Product) explode(quantity float64, path map[int]bool, requirements *[]MaterialRequirement, index map[int]int) error {
+	if path[p.ID] {
+		return NewBOMCycleError(p.ID)
+	}
+	path[p.ID] = true
+	defer delete(path, p.ID)
+
+	coefficient, err := p.typeCoefficient()
+	if err != nil {
+		return err
+	}
+	quantity *= coefficient
+
+	for _, pm := range p.Materials {
+		required := pm.QuantityPerUnit * quantity * pm.Material.WasteFactor()
+		if i, ok := index[pm.MaterialID]; ok {
+			(*requirements)[i].Quantity += required
+			continue
+		}
+		index[pm.MaterialID] = len(*requirements)
+		*requirements = append(*requirements, MaterialRequirement{
+			MaterialID: pm.MaterialID,
+			Material:   pm.Material,
+			Quantity:   required,
+		})
+	}
+
+	for _, pc := range p.Components {
+		if pc.Component == nil {
+			return newComponentNotLoadedError(pc.ComponentProductID)
+		}
+		if err := pc.Component.explode(pc.QuantityPerUnit*quantity, path, requirements, index); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// typeCoefficient возвращает коэффициент типа продукции. Без загруженного типа ни себестоимость,
+// ни потребность в сырье не рассчитываются, чтобы они не расходились между собой
+func (p *Product) typeCoefficient() (float64, error) {
+	if p.ProductType == nil {
+		return 0, NewValidationError("product_type_id", fmt.Sprintf("тип продукции с ID %d не загружен", p.ID))
+	}
+	return p.ProductType.Coefficient, nil
+}
+
+// UsesProduct проверяет, входит ли продукция с указанным ID в загруженное дерево полуфабрикатов
+func (p *Product) UsesProduct(productID int) bool {
+	for _, pc := range p.Components {
+		if pc.ComponentProductID == productID {
+			return true
+		}
+		if pc.Component != nil && pc.Component.UsesProduct(productID) {
+			return true
+		}
+	}
+	return false
+}
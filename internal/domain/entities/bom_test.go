@@ -0,0 +1,211 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBOMFixture собирает двухуровневую рецептуру: обои -> грунтованная основа -> сырье
+func newBOMFixture() *Product {
+	paper := &Material{ID: 1, Name: "Бумага", CostPerUnit: 10.0}
+	primer := &Material{ID: 2, Name: "Грунт", CostPerUnit: 20.0}
+	paint := &Material{ID: 3, Name: "Краска", CostPerUnit: 50.0}
+
+	base := &Product{
+		ID:          2,
+		Name:        "Грунтованная основа",
+		ProductType: &ProductType{ID: 2, Name: "Полуфабрикат", Coefficient: 1.1},
+		Materials: []ProductMaterial{
+			{MaterialID: 1, QuantityPerUnit: 2.0, Material: paper},
+			{MaterialID: 2, QuantityPerUnit: 0.5, Material: primer},
+		},
+	}
+
+	return &Product{
+		ID:          1,
+		Name:        "Обои",
+		ProductType: &ProductType{ID: 1, Name: "Флизелин", Coefficient: 2.0},
+		Materials: []ProductMaterial{
+			{MaterialID: 3, QuantityPerUnit: 1.0, Material: paint},
+			{MaterialID: 1, QuantityPerUnit: 1.0, Material: paper},
+		},
+		Components: []ProductComponent{
+			{ProductID: 1, ComponentProductID: 2, QuantityPerUnit: 3.0, Component: base},
+		},
+	}
+}
+
+func TestProduct_CalculateCost_MultiLevel(t *testing.T) {
+	product := newBOMFixture()
+
+	cost, err := product.CalculateCost()
+
+	require.NoError(t, err)
+	// Основа: (2*10 + 0.5*20) * 1.1 = 33; обои: (1*50 + 1*10 + 3*33) * 2.0 = 318
+	assert.InDelta(t, 318.0, cost, 0.0001)
+	assert.InDelta(t, 318.0*1.2, product.CalculatePrice(), 0.0001)
+}
+
+func TestProduct_CalculateCost_Cycle(t *testing.T) {
+	product := newBOMFixture()
+	base := product.Components[0].Component
+	base.Components = []ProductComponent{
+		{ProductID: 2, ComponentProductID: 1, QuantityPerUnit: 1.0, Component: product},
+	}
+
+	_, err := product.CalculateCost()
+
+	require.Error(t, err)
+	var businessErr *BusinessError
+	require.ErrorAs(t, err, &businessErr)
+	assert.Equal(t, "BOM_CYCLE", businessErr.Code)
+	assert.Equal(t, 0.0, product.CalculatePrice())
+}
+
+func TestProduct_CalculateCost_Incomplete(t *testing.T) {
+	t.Run("Незагруженный полуфабрикат", func(t *testing.T) {
+		product := newBOMFixture()
+		product.Components[0].Component = nil
+
+		_, err := product.CalculateCost()
+
+		var businessErr *BusinessError
+		require.ErrorAs(t, err, &businessErr)
+		assert.Equal(t, "BOM_INCOMPLETE", businessErr.Code)
+	})
+
+	t.Run("Незагруженный материал полуфабриката", func(t *testing.T) {
+		product := newBOMFixture()
+		product.Components[0].Component.Materials[1].Material = nil
+
+		_, err := product.CalculateCost()
+
+		var businessErr *BusinessError
+		require.ErrorAs(t, err, &businessErr)
+		assert.Equal(t, "BOM_INCOMPLETE", businessErr.Code)
+		assert.Contains(t, err.Error(), "материал с ID 2")
+	})
+}
+
+func TestProduct_ExplodeMaterials(t *testing.T) {
+	product := newBOMFixture()
+
+	requirements, err := product.ExplodeMaterials(10)
+
+	require.NoError(t, err)
+	require.Len(t, requirements, 3)
+
+	// На 10 единиц обоев с коэффициентом 2.0 приходится 20 единиц расхода,
+	// на 60 единиц основы с коэффициентом 1.1 - 66 единиц расхода
+	quantities := make(map[int]float64)
+	for _, req := range requirements {
+		quantities[req.MaterialID] = req.Quantity
+	}
+	assert.InDelta(t, 20.0, quantities[3], 0.0001)
+	assert.InDelta(t, 20.0+132.0, quantities[1], 0.0001)
+	assert.InDelta(t, 33.0, quantities[2], 0.0001)
+	assert.Equal(t, 3, requirements[0].MaterialID)
+}
+
+func TestProduct_ExplodeMaterials_DefectRate(t *testing.T) {
+	// Брак типа материала увеличивает расход так же, как в расчете потребности в материале
+	product := newBOMFixture()
+	paint := product.Materials[0].Material
+	paint.MaterialType = &MaterialType{ID: 1, Name: "Краски", WastePercentage: 5}
+	primer := product.Components[0].Component.Materials[1].Material
+	primer.MaterialType = &MaterialType{ID: 2, Name: "Грунты", WastePercentage: 10}
+
+	requirements, err := product.ExplodeMaterials(10)
+
+	require.NoError(t, err)
+	quantities := make(map[int]float64)
+	for _, req := range requirements {
+		quantities[req.MaterialID] = req.Quantity
+	}
+	assert.InDelta(t, 21.0, quantities[3], 0.0001)
+	assert.InDelta(t, 20.0+132.0, quantities[1], 0.0001)
+	assert.InDelta(t, 36.3, quantities[2], 0.0001)
+}
+
+func TestProduct_ExplodeMaterials_Errors(t *testing.T) {
+	t.Run("Неположительное количество", func(t *testing.T) {
+		_, err := newBOMFixture().ExplodeMaterials(0)
+		assert.Error(t, err)
+	})
+
+	t.Run("Незагруженный полуфабрикат", func(t *testing.T) {
+		product := newBOMFixture()
+		product.Components[0].Component = nil
+
+		_, err := product.ExplodeMaterials(1)
+
+		var businessErr *BusinessError
+		require.ErrorAs(t, err, &businessErr)
+		assert.Equal(t, "BOM_INCOMPLETE", businessErr.Code)
+	})
+}
+
+func TestProduct_MissingProductType(t *testing.T) {
+	// Без типа полуфабриката не считаются ни себестоимость, ни потребность в сырье
+	product := newBOMFixture()
+	product.Components[0].Component.ProductType = nil
+
+	_, costErr := product.CalculateCost()
+	_, explodeErr := product.ExplodeMaterials(1)
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, costErr, &validationErr)
+	assert.Equal(t, "product_type_id", validationErr.Field)
+	require.ErrorAs(t, explodeErr, &validationErr)
+	assert.Equal(t, "product_type_id", validationErr.Field)
+	assert.Equal(t, 0.0, product.CalculatePrice())
+}
+
+func TestProduct_UsesProduct(t *testing.T) {
+	product := newBOMFixture()
+
+	assert.True(t, product.UsesProduct(2))
+	assert.False(t, product.UsesProduct(3))
+}
+
+func TestProductComponent_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		component ProductComponent
+		wantErr   bool
+	}{
+		{
+			name:      "Корректный полуфабрикат",
+			component: ProductComponent{ProductID: 1, ComponentProductID: 2, QuantityPerUnit: 1.5},
+			wantErr:   false,
+		},
+		{
+			name:      "Без ID полуфабриката",
+			component: ProductComponent{ProductID: 1, QuantityPerUnit: 1.5},
+			wantErr:   true,
+		},
+		{
+			name:      "Ссылка на саму себя",
+			component: ProductComponent{ProductID: 1, ComponentProductID: 1, QuantityPerUnit: 1.5},
+			wantErr:   true,
+		},
+		{
+			name:      "Нулевой расход",
+			component: ProductComponent{ProductID: 1, ComponentProductID: 2},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.component.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
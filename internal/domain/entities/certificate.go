@@ -0,0 +1,172 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultCertificateExpiryDays - горизонт отчета об истекающих сертификатах по умолчанию
+const DefaultCertificateExpiryDays = 30
+
+// MaxCertificateExpiryDays ограничивает горизонт отчета об истекающих сертификатах
+const MaxCertificateExpiryDays = 3650
+
+// MaxCertificateFileSize - максимальный размер скана сертификата
+const MaxCertificateFileSize = 10 << 20
+
+// certificateFileExtensions сопоставляет допустимые форматы сканов сертификатов с расширениями
+var certificateFileExtensions = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+}
+
+// CertificateFileExtension возвращает расширение файла для типа содержимого скана сертификата
+func CertificateFileExtension(contentType string) (string, error) {
+	extension, ok := certificateFileExtensions[contentType]
+	if !ok {
+		return "", NewValidationError("file", "скан сертификата должен быть в формате PDF, JPEG или PNG")
+	}
+	return extension, nil
+}
+
+// CertificateStatus определяет состояние сертификата на дату
+type CertificateStatus string
+
+const (
+	// CertificateValid - сертификат действует
+	CertificateValid CertificateStatus = "valid"
+	// CertificateNotYetValid - срок действия сертификата еще не начался
+	CertificateNotYetValid CertificateStatus = "not_yet_valid"
+	// CertificateExpired - срок действия сертификата истек
+	CertificateExpired CertificateStatus = "expired"
+)
+
+// CertificateProduct представляет продукцию, на которую распространяется сертификат
+type CertificateProduct struct {
+	ID      int
+	Article string
+	Name    string
+}
+
+// QualityCertificate представляет сертификат качества (соответствия) на продукцию
+type QualityCertificate struct {
+	ID             int
+	Number         string
+	IssuingBody    string
+	StandardNumber *string
+	ValidFrom      time.Time
+	ValidTo        time.Time
+	FilePath       *string
+	Notes          *string
+	ProductIDs     []int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+
+	// Связанные данные
+	Products []CertificateProduct
+}
+
+// Validate проверяет корректность сертификата
+func (c *QualityCertificate) Validate() error {
+	if c.Number == "" {
+		return NewValidationError("number", "номер сертификата не может быть пустым")
+	}
+	if c.IssuingBody == "" {
+		return NewValidationError("issuing_body", "орган сертификации не может быть пустым")
+	}
+	if c.ValidFrom.IsZero() || c.ValidTo.IsZero() {
+		return NewValidationError("valid_to", "укажите срок действия сертификата")
+	}
+	if truncateToDay(c.ValidTo).Before(truncateToDay(c.ValidFrom)) {
+		return NewValidationError("valid_to", "дата окончания действия не может быть раньше даты начала")
+	}
+	if len(c.ProductIDs) == 0 {
+		return NewValidationError("product_ids", "сертификат должен распространяться хотя бы на одну продукцию")
+	}
+
+	seen := make(map[int]bool, len(c.ProductIDs))
+	for _, id := range c.ProductIDs {
+		if id <= 0 {
+			return NewValidationError("product_ids", "некорректный ID продукции")
+		}
+		if seen[id] {
+			return NewValidationError("product_ids", fmt.Sprintf("продукция с ID %d указана несколько раз", id))
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// StatusOn возвращает состояние сертификата на дату; дата окончания включается в срок действия
+func (c *QualityCertificate) StatusOn(date time.Time) CertificateStatus {
+	day := truncateToDay(date)
+	switch {
+	case day.Before(truncateToDay(c.ValidFrom)):
+		return CertificateNotYetValid
+	case day.After(truncateToDay(c.ValidTo)):
+		return CertificateExpired
+	default:
+		return CertificateValid
+	}
+}
+
+// DaysUntil возвращает число календарных дней от date до даты; для прошедших дат - отрицательное
+func DaysUntil(date, until time.Time) int {
+	return int(truncateToDay(until).Sub(truncateToDay(date)).Hours() / 24)
+}
+
+// ExpiringCertificate представляет строку отчета: продукция и сертификат с наиболее поздним
+// сроком действия из вступивших в силу. DaysLeft < 0 - сертификат уже истек
+type ExpiringCertificate struct {
+	Product           CertificateProduct
+	CertificateID     int
+	CertificateNumber string
+	IssuingBody       string
+	ValidTo           time.Time
+	DaysLeft          int
+}
+
+// ProductCertification описывает сертификацию продукции на дату: ValidTo - окончание
+// действующего сертификата, LastValidTo - окончание последнего сертификата вообще
+type ProductCertification struct {
+	Product     CertificateProduct
+	ValidTo     *time.Time
+	LastValidTo *time.Time
+}
+
+// CertificateWarningReason определяет причину предупреждения о сертификации
+type CertificateWarningReason string
+
+const (
+	// CertificateWarningMissing - на продукцию нет ни одного сертификата
+	CertificateWarningMissing CertificateWarningReason = "missing"
+	// CertificateWarningExpired - сертификаты есть, но ни один не действует на дату
+	CertificateWarningExpired CertificateWarningReason = "expired"
+)
+
+// CertificateWarning представляет предупреждение о продаже несертифицированной продукции
+type CertificateWarning struct {
+	Product     CertificateProduct
+	Reason      CertificateWarningReason
+	LastValidTo *time.Time
+	Message     string
+}
+
+// Warning возвращает предупреждение, если на продукцию нет действующего сертификата
+func (pc *ProductCertification) Warning() *CertificateWarning {
+	if pc.ValidTo != nil {
+		return nil
+	}
+
+	warning := &CertificateWarning{Product: pc.Product, LastValidTo: pc.LastValidTo}
+	if pc.LastValidTo == nil {
+		warning.Reason = CertificateWarningMissing
+		warning.Message = fmt.Sprintf("на продукцию %s нет сертификата качества", pc.Product.Article)
+	} else {
+		warning.Reason = CertificateWarningExpired
+		warning.Message = fmt.Sprintf("у продукции %s нет действующего сертификата качества (последний действовал до %s)",
+			pc.Product.Article, pc.LastValidTo.Format("02.01.2006"))
+	}
+	return warning
+}
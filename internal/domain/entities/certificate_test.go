@@ -0,0 +1,100 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validCertificate() QualityCertificate {
+	return QualityCertificate{
+		Number:      "РОСС RU.АГ17.Н01234",
+		IssuingBody: "ООО «Сертификационный центр»",
+		ValidFrom:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		ValidTo:     time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+		ProductIDs:  []int{1, 2},
+	}
+}
+
+func TestQualityCertificate_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *QualityCertificate)
+		wantErr string
+	}{
+		{name: "Корректный сертификат", modify: func(c *QualityCertificate) {}},
+		{name: "Сертификат на один день", modify: func(c *QualityCertificate) { c.ValidTo = c.ValidFrom }},
+		{name: "Без номера", modify: func(c *QualityCertificate) { c.Number = "" }, wantErr: "номер сертификата"},
+		{name: "Без органа сертификации", modify: func(c *QualityCertificate) { c.IssuingBody = "" }, wantErr: "орган сертификации"},
+		{name: "Без срока действия", modify: func(c *QualityCertificate) { c.ValidTo = time.Time{} }, wantErr: "срок действия"},
+		{
+			name:    "Окончание раньше начала",
+			modify:  func(c *QualityCertificate) { c.ValidTo = c.ValidFrom.AddDate(0, 0, -1) },
+			wantErr: "раньше даты начала",
+		},
+		{name: "Без продукции", modify: func(c *QualityCertificate) { c.ProductIDs = nil }, wantErr: "хотя бы на одну"},
+		{name: "Повтор продукции", modify: func(c *QualityCertificate) { c.ProductIDs = []int{1, 1} }, wantErr: "несколько раз"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			certificate := validCertificate()
+			tt.modify(&certificate)
+
+			err := certificate.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestQualityCertificate_StatusOn(t *testing.T) {
+	certificate := validCertificate()
+
+	assert.Equal(t, CertificateNotYetValid, certificate.StatusOn(time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC)))
+	assert.Equal(t, CertificateValid, certificate.StatusOn(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	// Дата окончания включается в срок действия
+	assert.Equal(t, CertificateValid, certificate.StatusOn(time.Date(2026, 12, 31, 18, 30, 0, 0, time.UTC)))
+	assert.Equal(t, CertificateExpired, certificate.StatusOn(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestDaysUntil(t *testing.T) {
+	today := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 0, DaysUntil(today, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 21, DaysUntil(today, time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, -10, DaysUntil(today, time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestProductCertification_Warning(t *testing.T) {
+	product := CertificateProduct{ID: 1, Article: "WP-001", Name: "Обои флизелиновые"}
+
+	// Действующий сертификат - предупреждения нет
+	certified := ProductCertification{Product: product, ValidTo: datePtr(2026, 12, 31), LastValidTo: datePtr(2026, 12, 31)}
+	assert.Nil(t, certified.Warning())
+
+	// Сертификатов нет
+	missing := ProductCertification{Product: product}
+	warning := missing.Warning()
+	assert.Equal(t, CertificateWarningMissing, warning.Reason)
+	assert.Contains(t, warning.Message, "WP-001")
+
+	// Сертификаты истекли
+	expired := ProductCertification{Product: product, LastValidTo: datePtr(2025, 6, 30)}
+	warning = expired.Warning()
+	assert.Equal(t, CertificateWarningExpired, warning.Reason)
+	assert.Contains(t, warning.Message, "30.06.2025")
+}
+
+func TestCertificateFileExtension(t *testing.T) {
+	extension, err := CertificateFileExtension("application/pdf")
+	assert.NoError(t, err)
+	assert.Equal(t, ".pdf", extension)
+
+	_, err = CertificateFileExtension("application/zip")
+	assert.Error(t, err)
+}
@@ -0,0 +1,198 @@
+package entities
+
+// SortDirection определяет направление сортировки списков
+type SortDirection string
+
+const (
+	// SortAsc сортирует по возрастанию
+	SortAsc SortDirection = "asc"
+	// SortDesc сортирует по убыванию
+	SortDesc SortDirection = "desc"
+)
+
+const (
+	// DefaultPageSize - размер страницы по умолчанию
+	DefaultPageSize = 20
+	// MaxPageSize - максимальный размер страницы
+	MaxPageSize = 100
+)
+
+// Поля сортировки списка продукции
+const (
+	ProductSortArticle   = "article"
+	ProductSortName      = "name"
+	ProductSortType      = "type"
+	ProductSortPrice     = "price"
+	ProductSortCreatedAt = "created_at"
+)
+
+// Поля сортировки списка материалов
+const (
+	MaterialSortArticle   = "article"
+	MaterialSortName      = "name"
+	MaterialSortType      = "type"
+	MaterialSortCost      = "cost"
+	MaterialSortStock     = "stock"
+	MaterialSortCreatedAt = "created_at"
+)
+
+// Pagination описывает запрошенную страницу списка
+type Pagination struct {
+	Page     int
+	PageSize int
+}
+
+// Normalize подставляет значения по умолчанию и ограничивает размер страницы
+func (p *Pagination) Normalize() {
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	if p.PageSize < 1 {
+		p.PageSize = DefaultPageSize
+	}
+	if p.PageSize > MaxPageSize {
+		p.PageSize = MaxPageSize
+	}
+}
+
+// Offset возвращает количество пропускаемых записей
+func (p Pagination) Offset() int {
+	return (p.Page - 1) * p.PageSize
+}
+
+// PageInfo описывает полученную страницу списка
+type PageInfo struct {
+	Page     int
+	PageSize int
+	Total    int
+}
+
+// TotalPages возвращает общее количество страниц
+func (p PageInfo) TotalPages() int {
+	if p.PageSize <= 0 || p.Total == 0 {
+		return 0
+	}
+	return (p.Total + p.PageSize - 1) / p.PageSize
+}
+
+// HasPrev проверяет наличие предыдущей страницы
+func (p PageInfo) HasPrev() bool {
+	return p.Page > 1
+}
+
+// HasNext проверяет наличие следующей страницы
+func (p PageInfo) HasNext() bool {
+	return p.Page < p.TotalPages()
+}
+
+// ProductCriteria описывает фильтры, сортировку и страницу списка продукции
+type ProductCriteria struct {
+	ProductTypeID   *int
+	MinPrice        *float64
+	MaxPrice        *float64
+	ArticlePrefix   string
+	IncludeArchived bool
+	// IncludeVariants - загрузить варианты (расцветки) для группировки в списке
+	IncludeVariants bool
+	SortBy          string
+	SortDirection   SortDirection
+	Pagination
+}
+
+// Normalize подставляет значения по умолчанию: новые позиции первыми
+func (c *ProductCriteria) Normalize() {
+	if c.SortBy == "" {
+		c.SortBy = ProductSortCreatedAt
+		if c.SortDirection == "" {
+			c.SortDirection = SortDesc
+		}
+	}
+	if c.SortDirection == "" {
+		c.SortDirection = SortAsc
+	}
+	c.Pagination.Normalize()
+}
+
+// Validate проверяет корректность критериев выборки продукции
+func (c *ProductCriteria) Validate() error {
+	switch c.SortBy {
+	case "", ProductSortArticle, ProductSortName, ProductSortType, ProductSortPrice, ProductSortCreatedAt:
+	default:
+		return NewValidationError("sort", "недопустимое поле сортировки продукции")
+	}
+	if err := validateSortDirection(c.SortDirection); err != nil {
+		return err
+	}
+	return validateRange("price", c.MinPrice, c.MaxPrice)
+}
+
+// ProductList представляет страницу списка продукции
+type ProductList struct {
+	Items []Product
+	PageInfo
+}
+
+// MaterialCriteria описывает фильтры, сортировку и страницу списка материалов
+type MaterialCriteria struct {
+	MaterialTypeID  *int
+	MinCost         *float64
+	MaxCost         *float64
+	ArticlePrefix   string
+	BelowMinStock   bool
+	IncludeArchived bool
+	SortBy          string
+	SortDirection   SortDirection
+	Pagination
+}
+
+// Normalize подставляет значения по умолчанию: сортировка по названию
+func (c *MaterialCriteria) Normalize() {
+	if c.SortBy == "" {
+		c.SortBy = MaterialSortName
+	}
+	if c.SortDirection == "" {
+		c.SortDirection = SortAsc
+	}
+	c.Pagination.Normalize()
+}
+
+// Validate проверяет корректность критериев выборки материалов
+func (c *MaterialCriteria) Validate() error {
+	switch c.SortBy {
+	case "", MaterialSortArticle, MaterialSortName, MaterialSortType, MaterialSortCost, MaterialSortStock, MaterialSortCreatedAt:
+	default:
+		return NewValidationError("sort", "недопустимое поле сортировки материалов")
+	}
+	if err := validateSortDirection(c.SortDirection); err != nil {
+		return err
+	}
+	return validateRange("cost", c.MinCost, c.MaxCost)
+}
+
+// MaterialList представляет страницу списка материалов
+type MaterialList struct {
+	Items []Material
+	PageInfo
+}
+
+func validateSortDirection(direction SortDirection) error {
+	switch direction {
+	case "", SortAsc, SortDesc:
+		return nil
+	default:
+		return NewValidationError("order", "направление сортировки должно быть asc или desc")
+	}
+}
+
+func validateRange(field string, min, max *float64) error {
+	if min != nil && *min < 0 {
+		return NewValidationError("min_"+field, "нижняя граница не может быть отрицательной")
+	}
+	if max != nil && *max < 0 {
+		return NewValidationError("max_"+field, "верхняя граница не может быть отрицательной")
+	}
+	if min != nil && max != nil && *min > *max {
+		return NewValidationError("max_"+field, "верхняя граница не может быть меньше нижней")
+	}
+	return nil
+}
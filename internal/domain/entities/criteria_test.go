@@ -0,0 +1,91 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageInfo(t *testing.T) {
+	tests := []struct {
+		name       string
+		info       PageInfo
+		totalPages int
+		hasPrev    bool
+		hasNext    bool
+	}{
+		{name: "Пустой список", info: PageInfo{Page: 1, PageSize: 20, Total: 0}, totalPages: 0},
+		{name: "Одна неполная страница", info: PageInfo{Page: 1, PageSize: 20, Total: 7}, totalPages: 1},
+		{name: "Первая из трех", info: PageInfo{Page: 1, PageSize: 10, Total: 25}, totalPages: 3, hasNext: true},
+		{name: "Середина", info: PageInfo{Page: 2, PageSize: 10, Total: 25}, totalPages: 3, hasPrev: true, hasNext: true},
+		{name: "Последняя ровная страница", info: PageInfo{Page: 2, PageSize: 10, Total: 20}, totalPages: 2, hasPrev: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.totalPages, tt.info.TotalPages())
+			assert.Equal(t, tt.hasPrev, tt.info.HasPrev())
+			assert.Equal(t, tt.hasNext, tt.info.HasNext())
+		})
+	}
+}
+
+func TestProductCriteria_Normalize(t *testing.T) {
+	t.Run("По умолчанию новые позиции первыми", func(t *testing.T) {
+		criteria := ProductCriteria{}
+		criteria.Normalize()
+
+		assert.Equal(t, ProductSortCreatedAt, criteria.SortBy)
+		assert.Equal(t, SortDesc, criteria.SortDirection)
+		assert.Equal(t, Pagination{Page: 1, PageSize: DefaultPageSize}, criteria.Pagination)
+	})
+
+	t.Run("Явное поле сортировки по возрастанию", func(t *testing.T) {
+		criteria := ProductCriteria{SortBy: ProductSortName, Pagination: Pagination{Page: 3, PageSize: 1000}}
+		criteria.Normalize()
+
+		assert.Equal(t, SortAsc, criteria.SortDirection)
+		assert.Equal(t, MaxPageSize, criteria.PageSize)
+		assert.Equal(t, 2*MaxPageSize, criteria.Offset())
+	})
+}
+
+func TestProductCriteria_Validate(t *testing.T) {
+	low, high := 100.0, 50.0
+	negative := -1.0
+
+	tests := []struct {
+		name     string
+		criteria ProductCriteria
+		wantErr  bool
+	}{
+		{name: "Пустые критерии", criteria: ProductCriteria{}, wantErr: false},
+		{name: "Сортировка по цене по убыванию", criteria: ProductCriteria{SortBy: ProductSortPrice, SortDirection: SortDesc}, wantErr: false},
+		{name: "Неизвестное поле сортировки", criteria: ProductCriteria{SortBy: "p.name; DROP TABLE products"}, wantErr: true},
+		{name: "Неизвестное направление", criteria: ProductCriteria{SortDirection: "up"}, wantErr: true},
+		{name: "Отрицательная цена", criteria: ProductCriteria{MinPrice: &negative}, wantErr: true},
+		{name: "Нижняя граница больше верхней", criteria: ProductCriteria{MinPrice: &low, MaxPrice: &high}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.criteria.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMaterialCriteria_Normalize(t *testing.T) {
+	criteria := MaterialCriteria{BelowMinStock: true}
+	criteria.Normalize()
+
+	assert.Equal(t, MaterialSortName, criteria.SortBy)
+	assert.Equal(t, SortAsc, criteria.SortDirection)
+	assert.True(t, criteria.BelowMinStock)
+	assert.NoError(t, criteria.Validate())
+	assert.Error(t, (&MaterialCriteria{SortBy: ProductSortPrice}).Validate())
+}
@@ -61,3 +61,28 @@ func NewNotFoundError(entity, id string) *NotFoundError {
 		},
 	}
 }
+
+// CostRecalculationError сообщает, что изменение сохранено, но себестоимость продукции,
+// на которую оно влияет, пересчитать не удалось. Запрос при этом выполнен: вызывающий
+// получает результат вместе с ошибкой и сообщает о ней как о предупреждении
+type CostRecalculationError struct {
+	DomainError
+	Err error
+}
+
+// NewCostRecalculationError создает ошибку пересчета себестоимости продукции после
+// сохраненного изменения; action описывает само изменение
+func NewCostRecalculationError(action string, err error) *CostRecalculationError {
+	return &CostRecalculationError{
+		DomainError: DomainError{
+			Code:    "COST_NOT_RECALCULATED",
+			Message: fmt.Sprintf("%s, но себестоимость продукции не пересчитана: %v", action, err),
+		},
+		Err: err,
+	}
+}
+
+// Unwrap возвращает причину ошибки пересчета
+func (e *CostRecalculationError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,103 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ExportFormat определяет формат выгрузки каталога
+type ExportFormat string
+
+const (
+	// ExportFormatCSV - текст с разделителем "точка с запятой" для Excel с русской локалью
+	ExportFormatCSV ExportFormat = "csv"
+	// ExportFormatXLSX - книга Excel с одним листом
+	ExportFormatXLSX ExportFormat = "xlsx"
+	// ExportFormatHTML - страница для печати, сгруппированная по типам
+	ExportFormatHTML ExportFormat = "html"
+)
+
+// MaxExportRows - максимальное количество строк в одной выгрузке
+const MaxExportRows = 10000
+
+// ParseExportFormat разбирает формат выгрузки; по умолчанию - CSV
+func ParseExportFormat(value string) (ExportFormat, error) {
+	switch format := ExportFormat(strings.ToLower(strings.TrimSpace(value))); format {
+	case "":
+		return ExportFormatCSV, nil
+	case ExportFormatCSV, ExportFormatXLSX, ExportFormatHTML:
+		return format, nil
+	default:
+		return "", NewValidationError("format", "поддерживаются форматы csv, xlsx и html")
+	}
+}
+
+// ProductExportRequest описывает выгрузку каталога продукции: фильтры, столбцы
+// и тип партнера, для которого рассчитываются цены
+type ProductExportRequest struct {
+	Criteria      ProductCriteria
+	Columns       []string
+	PartnerTypeID *int
+}
+
+// MaterialExportRequest описывает выгрузку каталога материалов
+type MaterialExportRequest struct {
+	Criteria MaterialCriteria
+	Columns  []string
+}
+
+// ExportColumn описывает столбец выгрузки
+type ExportColumn struct {
+	Key     string
+	Title   string
+	Numeric bool
+}
+
+// ExportCell представляет значение ячейки: число или текст.
+// Пустая ячейка не содержит ни того, ни другого
+type ExportCell struct {
+	Text   string
+	Number *float64
+}
+
+// TextCell создает текстовую ячейку
+func TextCell(text string) ExportCell {
+	return ExportCell{Text: text}
+}
+
+// NumberCell создает числовую ячейку; nil дает пустую ячейку
+func NumberCell(number *float64) ExportCell {
+	return ExportCell{Number: number}
+}
+
+// String возвращает значение ячейки для текстовых форматов
+func (c ExportCell) String() string {
+	if c.Number != nil {
+		return fmt.Sprintf("%.2f", *c.Number)
+	}
+	return c.Text
+}
+
+// ExportGroup представляет группу строк выгрузки (например, продукцию одного типа)
+type ExportGroup struct {
+	Name string
+	Rows [][]ExportCell
+}
+
+// ExportTable представляет подготовленную выгрузку каталога
+type ExportTable struct {
+	Title       string
+	GeneratedAt time.Time
+	Columns     []ExportColumn
+	Groups      []ExportGroup
+}
+
+// RowCount возвращает количество строк во всех группах
+func (t *ExportTable) RowCount() int {
+	count := 0
+	for _, group := range t.Groups {
+		count += len(group.Rows)
+	}
+	return count
+}
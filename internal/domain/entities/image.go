@@ -0,0 +1,88 @@
+package entities
+
+// MaxImageFileSize - максимальный размер загружаемого изображения
+const MaxImageFileSize = 5 << 20
+
+// MaxImagePixels ограничивает размер изображения в пикселях, чтобы небольшой
+// сжатый файл не занял при распаковке всю память
+const MaxImagePixels = 25_000_000
+
+// ImageSize описывает размер уменьшенной копии изображения: копия вписывается
+// в прямоугольник MaxWidth x MaxHeight с сохранением пропорций
+type ImageSize struct {
+	Name      string
+	MaxWidth  int
+	MaxHeight int
+}
+
+var (
+	// ThumbnailSize - миниатюра для списков
+	ThumbnailSize = ImageSize{Name: "thumb", MaxWidth: 160, MaxHeight: 160}
+	// PreviewSize - превью для карточки продукции
+	PreviewSize = ImageSize{Name: "preview", MaxWidth: 800, MaxHeight: 800}
+)
+
+// imageExtensions сопоставляет поддерживаемые форматы изображений с расширениями файлов
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// ImageExtension возвращает расширение файла для типа содержимого изображения
+func ImageExtension(contentType string) (string, error) {
+	extension, ok := imageExtensions[contentType]
+	if !ok {
+		return "", NewValidationError("image", "поддерживаются изображения JPEG, PNG и GIF")
+	}
+	return extension, nil
+}
+
+// ImageFile представляет файл изображения, готовый к сохранению
+type ImageFile struct {
+	Data      []byte
+	Extension string
+}
+
+// ImageUpload представляет проверенное загруженное изображение вместе с уменьшенными копиями
+type ImageUpload struct {
+	Original  ImageFile
+	Thumbnail ImageFile
+	Preview   ImageFile
+	Width     int
+	Height    int
+}
+
+// ImagePaths описывает ссылки на сохраненное изображение и его уменьшенные копии
+type ImagePaths struct {
+	ImagePath     *string
+	ThumbnailPath *string
+	PreviewPath   *string
+}
+
+// ThumbnailOrImage возвращает ссылку на миниатюру, а если ее нет - на исходное изображение
+func (p ImagePaths) ThumbnailOrImage() *string {
+	if p.ThumbnailPath != nil {
+		return p.ThumbnailPath
+	}
+	return p.ImagePath
+}
+
+// PreviewOrImage возвращает ссылку на превью, а если его нет - на исходное изображение
+func (p ImagePaths) PreviewOrImage() *string {
+	if p.PreviewPath != nil {
+		return p.PreviewPath
+	}
+	return p.ImagePath
+}
+
+// Files возвращает ссылки на все сохраненные файлы изображения
+func (p ImagePaths) Files() []string {
+	var files []string
+	for _, path := range []*string{p.ImagePath, p.ThumbnailPath, p.PreviewPath} {
+		if path != nil && *path != "" {
+			files = append(files, *path)
+		}
+	}
+	return files
+}
@@ -0,0 +1,140 @@
+package entities
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ImportFormat определяет формат файла импорта
+type ImportFormat string
+
+const (
+	// ImportFormatCSV - текст с разделителями (запятая или точка с запятой)
+	ImportFormatCSV ImportFormat = "csv"
+	// ImportFormatXLSX - книга Excel, читается первый лист
+	ImportFormatXLSX ImportFormat = "xlsx"
+)
+
+// ImportAction описывает, что произойдет со строкой файла при импорте
+type ImportAction string
+
+const (
+	// ImportActionCreate - позиции с таким артикулом нет, она будет создана
+	ImportActionCreate ImportAction = "create"
+	// ImportActionUpdate - позиция с таким артикулом есть, она будет обновлена
+	ImportActionUpdate ImportAction = "update"
+	// ImportActionSkip - строка содержит ошибки и не импортируется
+	ImportActionSkip ImportAction = "skip"
+)
+
+const (
+	// MaxImportRows - максимальное количество строк данных в одном файле
+	MaxImportRows = 5000
+	// MaxImportFileSize - максимальный размер загружаемого файла (10 МБ)
+	MaxImportFileSize = 10 << 20
+)
+
+// ImportFormatFromFilename определяет формат файла по расширению
+func ImportFormatFromFilename(filename string) (ImportFormat, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return ImportFormatCSV, nil
+	case ".xlsx":
+		return ImportFormatXLSX, nil
+	default:
+		return "", NewValidationError("file", "поддерживаются только файлы CSV и XLSX")
+	}
+}
+
+// ImportRow представляет строку данных файла с ее номером в исходном файле
+type ImportRow struct {
+	Number int
+	Values []string
+}
+
+// ImportTable представляет прочитанный файл: заголовок и строки данных
+type ImportTable struct {
+	Header []string
+	Rows   []ImportRow
+}
+
+// Validate проверяет, что в файле есть заголовок и допустимое количество строк
+func (t *ImportTable) Validate() error {
+	if len(t.Header) == 0 {
+		return NewValidationError("file", "файл не содержит строку заголовка")
+	}
+	if len(t.Rows) == 0 {
+		return NewValidationError("file", "файл не содержит строк данных")
+	}
+	if len(t.Rows) > MaxImportRows {
+		return NewValidationError("file", fmt.Sprintf("файл содержит больше %d строк", MaxImportRows))
+	}
+	return nil
+}
+
+// ImportRowError описывает ошибку в строке файла
+type ImportRowError struct {
+	Row     int
+	Column  string
+	Message string
+}
+
+func (e ImportRowError) Error() string {
+	if e.Column != "" {
+		return fmt.Sprintf("строка %d, столбец %s: %s", e.Row, e.Column, e.Message)
+	}
+	return fmt.Sprintf("строка %d: %s", e.Row, e.Message)
+}
+
+// ImportRowResult описывает результат обработки строки файла
+type ImportRowResult struct {
+	Row     int
+	Article string
+	Action  ImportAction
+	Errors  []ImportRowError
+}
+
+// ImportReport представляет отчет об импорте с результатом по каждой строке. CostWarning
+// заполняется, если позиции сохранены, но себестоимость продукции не пересчитана
+type ImportReport struct {
+	DryRun         bool
+	Columns        []string
+	IgnoredColumns []string
+	Rows           []ImportRowResult
+	CostWarning    string
+}
+
+// Created возвращает количество создаваемых позиций
+func (r *ImportReport) Created() int {
+	return r.count(ImportActionCreate)
+}
+
+// Updated возвращает количество обновляемых позиций
+func (r *ImportReport) Updated() int {
+	return r.count(ImportActionUpdate)
+}
+
+// Skipped возвращает количество строк, пропущенных из-за ошибок
+func (r *ImportReport) Skipped() int {
+	return r.count(ImportActionSkip)
+}
+
+// Errors возвращает ошибки всех строк в порядке следования строк
+func (r *ImportReport) Errors() []ImportRowError {
+	var errs []ImportRowError
+	for _, row := range r.Rows {
+		errs = append(errs, row.Errors...)
+	}
+	return errs
+}
+
+func (r *ImportReport) count(action ImportAction) int {
+	count := 0
+	for _, row := range r.Rows {
+		if row.Action == action {
+			count++
+		}
+	}
+	return count
+}
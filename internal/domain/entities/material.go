@@ -4,24 +4,32 @@ import (
 	"time"
 )
 
-// MaterialType представляет тип материала в предметной области
+// MaterialType представляет тип материала в предметной области.
+// WastePercentage - процент брака (5 = 5%), в базе хранится долей в столбце defect_rate
 type MaterialType struct {
 	ID              int
 	Name            string
+	Description     *string
 	WastePercentage float64
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 }
 
-// MeasurementUnit представляет единицу измерения
+// MeasurementUnit представляет единицу измерения. BaseFactor - множитель к базовой единице
+// категории (для грамма 0.001 кг); единица без категории не пересчитывается
 type MeasurementUnit struct {
 	ID           int
 	Name         string
 	Abbreviation string
+	Category     UnitCategory
+	BaseFactor   float64
 	CreatedAt    time.Time
 }
 
-// Material представляет материал в предметной области
+// Material представляет материал в предметной области. ReservedQuantity - количество
+// в активных резервах заказов, AvailableQuantity - свободный остаток за вычетом резервов.
+// CostLocked - себестоимость зафиксирована и задается вручную; незафиксированная
+// рассчитывается по складскому журналу (см. MaterialCosting)
 type Material struct {
 	ID                int
 	Article           string
@@ -31,9 +39,15 @@ type Material struct {
 	MeasurementUnitID int
 	PackageQuantity   float64
 	CostPerUnit       float64
+	CostLocked        bool
 	StockQuantity     float64
 	MinStockQuantity  float64
+	ReservedQuantity  float64
+	AvailableQuantity float64
 	ImagePath         *string
+	ThumbnailPath     *string
+	PreviewPath       *string
+	ArchivedAt        *time.Time
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
 
@@ -81,6 +95,16 @@ func (mcr *MaterialCalculationRequest) CalculateRequiredQuantity(
 	return int(requiredMaterial + 0.9999999)
 }
 
+// IsArchived проверяет, перенесен ли материал в архив
+func (m *Material) IsArchived() bool {
+	return m.ArchivedAt != nil
+}
+
+// Images возвращает ссылки на изображение материала и его уменьшенные копии
+func (m *Material) Images() ImagePaths {
+	return ImagePaths{ImagePath: m.ImagePath, ThumbnailPath: m.ThumbnailPath, PreviewPath: m.PreviewPath}
+}
+
 // Validate проверяет корректность данных материала
 func (m *Material) Validate() error {
 	if m.Article == "" {
@@ -101,6 +125,16 @@ func (m *Material) Validate() error {
 	return nil
 }
 
+// ValidateCostChange проверяет замену себестоимости сохраненного материала на cost: вручную
+// ее можно менять только после фиксации, иначе она рассчитывается по складскому журналу
+func (m *Material) ValidateCostChange(cost float64) error {
+	if m.CostLocked || cost == m.CostPerUnit {
+		return nil
+	}
+	return NewValidationError("cost_per_unit",
+		"себестоимость рассчитывается по складскому журналу; задать ее вручную можно только после фиксации")
+}
+
 // CalculateRequiredQuantity рассчитывает необходимое количество материала с учетом отходов
 func (m *Material) CalculateRequiredQuantity(baseQuantity, wastePercentage float64) (int, error) {
 	if baseQuantity < 0 {
@@ -122,6 +156,15 @@ func (m *Material) CalculateRequiredQuantity(baseQuantity, wastePercentage float
 	return int(requiredQuantity + 0.9999999), nil
 }
 
+// WasteFactor возвращает множитель расхода с учетом процента брака типа материала.
+// Без загруженного материала или типа брак не учитывается
+func (m *Material) WasteFactor() float64 {
+	if m == nil || m.MaterialType == nil {
+		return 1
+	}
+	return 1 + m.MaterialType.WastePercentage/100
+}
+
 // Validate проверяет валидность запроса на расчет материала
 func (r *MaterialCalculationRequest) Validate() error {
 	if r.ProductTypeID <= 0 {
@@ -0,0 +1,261 @@
+package entities
+
+import "math"
+
+// CostingMethod определяет метод расчета себестоимости материала
+type CostingMethod string
+
+const (
+	// CostingWeightedAverage - скользящая средневзвешенная: приход пересчитывает среднюю цену
+	// остатка, расход и списание оцениваются по ней
+	CostingWeightedAverage CostingMethod = "weighted_average"
+	// CostingFIFO - расход и списание оцениваются по ценам партий, из которых взят материал
+	CostingFIFO CostingMethod = "fifo"
+)
+
+// IsValid проверяет, что метод расчета себестоимости известен
+func (m CostingMethod) IsValid() bool {
+	return m == CostingWeightedAverage || m == CostingFIFO
+}
+
+// Label возвращает название метода расчета себестоимости для интерфейса
+func (m CostingMethod) Label() string {
+	switch m {
+	case CostingWeightedAverage:
+		return "Средневзвешенная"
+	case CostingFIFO:
+		return "FIFO"
+	}
+	return string(m)
+}
+
+// costPrecision - точность себестоимости единицы, соответствует DECIMAL(12,4)
+const costPrecision = 10000
+
+// MaterialCosting представляет себестоимость материала. ActualCost - фактическая себестоимость
+// единицы остатка, рассчитанная методом Method по ценам поставок и движениям журнала.
+// CostPerUnit - себестоимость, по которой считается продукция: она следует за фактической,
+// пока не зафиксирована (Locked), зафиксированную можно задать вручную
+type MaterialCosting struct {
+	MaterialID    int
+	Method        CostingMethod
+	Locked        bool
+	ActualCost    float64
+	CostPerUnit   float64
+	StockQuantity float64
+
+	// Связанные данные
+	Material *Material
+}
+
+// CostLedger содержит данные для пересчета себестоимости материала по журналу: все партии
+// материала, движения, которые создали партии или забрали из них материал, в порядке
+// проведения, и расход из партий
+type CostLedger struct {
+	Lots         []MaterialLot
+	Movements    []MaterialMovement
+	Consumptions []LotConsumption
+}
+
+// ChangeSettings меняет метод и фиксацию себестоимости. Себестоимость задается вручную
+// (manualCost) только вместе с фиксацией; без фиксации CostPerUnit снова следует за фактической
+func (c *MaterialCosting) ChangeSettings(method CostingMethod, locked bool, manualCost *float64) error {
+	if !method.IsValid() {
+		return NewValidationError("costing_method", "неизвестный метод расчета себестоимости")
+	}
+	if manualCost != nil {
+		if !locked {
+			return NewValidationError("cost_per_unit", "себестоимость задается вручную только с фиксацией")
+		}
+		if *manualCost < 0 {
+			return NewValidationError("cost_per_unit", "себестоимость не может быть отрицательной")
+		}
+		c.CostPerUnit = roundMoney(*manualCost)
+	}
+
+	c.Method = method
+	c.Locked = locked
+	return nil
+}
+
+// ReceiptUnitCost возвращает цену прихода без поставки (начальный остаток, излишек
+// инвентаризации): фактическую себестоимость, а пока она не рассчитана - CostPerUnit
+func (c *MaterialCosting) ReceiptUnitCost() float64 {
+	if c.ActualCost > 0 {
+		return c.ActualCost
+	}
+	return c.CostPerUnit
+}
+
+// PriceLot задает цену партии прихода без поставки по текущей себестоимости;
+// у партии поставки остается цена поставки
+func (c *MaterialCosting) PriceLot(lot *MaterialLot) {
+	if lot.SupplyID == nil {
+		lot.UnitCost = roundCost(c.ReceiptUnitCost())
+	}
+}
+
+// Receive оценивает приход по цене unitCost; stock - остаток до прихода. По средневзвешенной
+// приход пересчитывает среднюю цену остатка
+func (c *MaterialCosting) Receive(movement *MaterialMovement, stock, unitCost float64) {
+	if c.Method == CostingWeightedAverage {
+		c.ActualCost = movingAverage(stock, c.ActualCost, movement.Quantity, unitCost)
+	}
+	movement.SetCost(unitCost)
+}
+
+// Issue оценивает расход или списание: по средневзвешенной - по средней цене остатка,
+// по FIFO - по ценам партий, из которых взят материал (consumptions)
+func (c *MaterialCosting) Issue(movement *MaterialMovement, consumptions []LotConsumption) {
+	if c.Method != CostingFIFO {
+		movement.SetCost(c.ActualCost)
+		return
+	}
+
+	var total float64
+	for _, consumption := range consumptions {
+		total += consumption.Quantity * consumption.Lot.UnitCost
+	}
+	movement.SetTotalCost(total)
+}
+
+// RevalueLots пересчитывает фактическую себестоимость по FIFO как среднюю цену остатков
+// партий: quantity - их количество, value - стоимость. Без остатков себестоимость не меняется
+func (c *MaterialCosting) RevalueLots(quantity, value float64) {
+	if c.Method == CostingFIFO && quantity > 0 {
+		c.ActualCost = roundCost(value / quantity)
+	}
+}
+
+// StockValue возвращает стоимость остатка материала по фактической себестоимости
+func (c *MaterialCosting) StockValue() float64 {
+	return roundMoney(c.StockQuantity * c.ActualCost)
+}
+
+// SyncCostPerUnit переносит фактическую себестоимость в CostPerUnit, если себестоимость
+// не зафиксирована и уже рассчитана. Возвращает true, если CostPerUnit изменилась
+func (c *MaterialCosting) SyncCostPerUnit() bool {
+	if c.Locked || c.ActualCost <= 0 {
+		return false
+	}
+
+	cost := roundMoney(c.ActualCost)
+	changed := cost != c.CostPerUnit
+	c.CostPerUnit = cost
+	return changed
+}
+
+// Recost заново рассчитывает себестоимость по журналу текущим методом. Начальный остаток
+// составляют партии без движения прихода (остатки на момент начала учета партий) по их ценам:
+// на начало учета в такой партии было ее текущее количество плюс израсходованное из нее по журналу,
+// а не количество партии до начала учета. Затем движения проводятся по порядку: партиям приходов
+// без поставки задается цена, движениям - себестоимость. Остатки партий в ledger пересчитываются
+// по расходу из них
+func (c *MaterialCosting) Recost(ledger *CostLedger) {
+	consumed := make(map[int]float64)
+	for _, consumption := range ledger.Consumptions {
+		consumed[consumption.LotID] += consumption.Quantity
+	}
+
+	lots := make(map[int]*MaterialLot, len(ledger.Lots))
+	lotsByMovement := make(map[int]*MaterialLot)
+	var received []*MaterialLot
+	var stock float64
+	for i := range ledger.Lots {
+		lot := &ledger.Lots[i]
+		lots[lot.ID] = lot
+		if lot.MovementID != nil {
+			lot.RemainingQuantity = lot.InitialQuantity
+			lotsByMovement[*lot.MovementID] = lot
+			continue
+		}
+		lot.RemainingQuantity = roundMovement(lot.RemainingQuantity + consumed[lot.ID])
+		received = append(received, lot)
+		stock += lot.RemainingQuantity
+	}
+
+	consumptionsByMovement := make(map[int][]LotConsumption)
+	for _, consumption := range ledger.Consumptions {
+		if lot, ok := lots[consumption.LotID]; ok {
+			consumption.Lot = lot
+			consumptionsByMovement[consumption.MovementID] = append(consumptionsByMovement[consumption.MovementID], consumption)
+		}
+	}
+
+	c.ActualCost = 0
+	if quantity, value := lotsValue(received); quantity > 0 {
+		c.ActualCost = roundCost(value / quantity)
+	}
+	stock = roundMovement(stock)
+
+	for i := range ledger.Movements {
+		movement := &ledger.Movements[i]
+		switch {
+		case movement.Delta() > 0:
+			unitCost := c.ReceiptUnitCost()
+			if lot, ok := lotsByMovement[movement.ID]; ok {
+				c.PriceLot(lot)
+				unitCost = lot.UnitCost
+				received = append(received, lot)
+			}
+			c.Receive(movement, stock, unitCost)
+		case movement.Delta() < 0:
+			consumptions := consumptionsByMovement[movement.ID]
+			for _, consumption := range consumptions {
+				consumption.Lot.RemainingQuantity = roundMovement(consumption.Lot.RemainingQuantity - consumption.Quantity)
+			}
+			c.Issue(movement, consumptions)
+		default:
+			continue
+		}
+
+		stock = roundMovement(stock + movement.Delta())
+		c.RevalueLots(lotsValue(received))
+	}
+
+	c.StockQuantity = stock
+	c.SyncCostPerUnit()
+}
+
+// SetCost задает себестоимость движения по цене единицы
+func (m *MaterialMovement) SetCost(unitCost float64) {
+	unit := roundCost(unitCost)
+	total := roundMoney(unit * m.Quantity)
+	m.UnitCost = &unit
+	m.TotalCost = &total
+}
+
+// SetTotalCost задает себестоимость движения по общей стоимости
+func (m *MaterialMovement) SetTotalCost(totalCost float64) {
+	total := roundMoney(totalCost)
+	var unit float64
+	if m.Quantity > 0 {
+		unit = roundCost(totalCost / m.Quantity)
+	}
+	m.UnitCost = &unit
+	m.TotalCost = &total
+}
+
+// movingAverage рассчитывает среднюю цену остатка stock по цене cost после прихода
+// quantity по цене unitCost
+func movingAverage(stock, cost, quantity, unitCost float64) float64 {
+	if stock <= 0 {
+		return roundCost(unitCost)
+	}
+	return roundCost((stock*cost + quantity*unitCost) / (stock + quantity))
+}
+
+// lotsValue возвращает количество и стоимость остатков партий
+func lotsValue(lots []*MaterialLot) (float64, float64) {
+	var quantity, value float64
+	for _, lot := range lots {
+		quantity += lot.RemainingQuantity
+		value += lot.RemainingQuantity * lot.UnitCost
+	}
+	return quantity, value
+}
+
+// roundCost округляет себестоимость единицы до точности хранения
+func roundCost(value float64) float64 {
+	return math.Round(value*costPrecision) / costPrecision
+}
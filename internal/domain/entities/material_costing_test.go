@@ -0,0 +1,147 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// costLedger - начальный остаток 10 по 100, поставка 10 по 130, расход 15, излишек 1
+func costLedger() *CostLedger {
+	return &CostLedger{
+		Lots: []MaterialLot{
+			{ID: 1, InitialQuantity: 10, UnitCost: 100},
+			{ID: 2, SupplyID: intPtr(5), InitialQuantity: 10, UnitCost: 130, MovementID: intPtr(21)},
+			{ID: 3, InitialQuantity: 1, MovementID: intPtr(23)},
+		},
+		Movements: []MaterialMovement{
+			{ID: 21, Type: MovementIncome, Quantity: 10},
+			{ID: 22, Type: MovementConsumption, Quantity: 15},
+			{ID: 23, Type: MovementIncome, Quantity: 1},
+		},
+		Consumptions: []LotConsumption{
+			{LotID: 1, MovementID: 22, Quantity: 10},
+			{LotID: 2, MovementID: 22, Quantity: 5},
+		},
+	}
+}
+
+func TestMaterialCosting_RecostWeightedAverage(t *testing.T) {
+	costing := &MaterialCosting{Method: CostingWeightedAverage, CostPerUnit: 90}
+	ledger := costLedger()
+
+	costing.Recost(ledger)
+
+	assert.Equal(t, 130.0, *ledger.Movements[0].UnitCost)
+	assert.Equal(t, 115.0, *ledger.Movements[1].UnitCost)
+	assert.Equal(t, 1725.0, *ledger.Movements[1].TotalCost)
+	assert.Equal(t, 115.0, ledger.Lots[2].UnitCost)
+	assert.Equal(t, 115.0, costing.ActualCost)
+	assert.Equal(t, 115.0, costing.CostPerUnit)
+	assert.Equal(t, 6.0, costing.StockQuantity)
+	assert.Equal(t, 690.0, costing.StockValue())
+}
+
+func TestMaterialCosting_RecostFIFO(t *testing.T) {
+	costing := &MaterialCosting{Method: CostingFIFO, CostPerUnit: 90}
+	ledger := costLedger()
+
+	costing.Recost(ledger)
+
+	// Расход: 10 по 100 из начального остатка и 5 по 130 из поставки
+	assert.Equal(t, 1650.0, *ledger.Movements[1].TotalCost)
+	assert.Equal(t, 110.0, *ledger.Movements[1].UnitCost)
+	// Излишек оценивается по остатку поставки
+	assert.Equal(t, 130.0, ledger.Lots[2].UnitCost)
+	assert.Equal(t, 5.0, ledger.Lots[1].RemainingQuantity)
+	assert.Equal(t, 130.0, costing.ActualCost)
+	assert.Equal(t, 130.0, costing.CostPerUnit)
+}
+
+// migratedLedger собирает журнал с партией начального остатка, из которой до начала учета
+// партий израсходовали 80 из 100: на начало учета в ней 20 по 10, затем поставка 50 по 20
+// и расход 30, забравший остаток партии начального остатка
+func migratedLedger() *CostLedger {
+	return &CostLedger{
+		Lots: []MaterialLot{
+			{ID: 1, InitialQuantity: 100, RemainingQuantity: 0, UnitCost: 10},
+			{ID: 2, SupplyID: intPtr(5), InitialQuantity: 50, RemainingQuantity: 40, UnitCost: 20, MovementID: intPtr(31)},
+		},
+		Movements: []MaterialMovement{
+			{ID: 31, Type: MovementIncome, Quantity: 50},
+			{ID: 32, Type: MovementConsumption, Quantity: 30},
+		},
+		Consumptions: []LotConsumption{
+			{LotID: 1, MovementID: 32, Quantity: 20},
+			{LotID: 2, MovementID: 32, Quantity: 10},
+		},
+	}
+}
+
+func TestMaterialCosting_RecostPartiallyConsumedMigratedLot(t *testing.T) {
+	for _, method := range []CostingMethod{CostingWeightedAverage, CostingFIFO} {
+		t.Run(string(method), func(t *testing.T) {
+			// Проведение в момент движений: на начало учета партий остаток 20 по 10
+			opening := &MaterialLot{ID: 1, UnitCost: 10}
+			delivery := &MaterialLot{ID: 2, UnitCost: 20}
+			live := &MaterialCosting{Method: method, StockQuantity: 20, ActualCost: 10}
+			supply := MaterialMovement{ID: 31, Type: MovementIncome, Quantity: 50}
+			live.Receive(&supply, 20, 20)
+			live.RevalueLots(70, 20*10+50*20)
+			assert.Equal(t, 17.1429, live.ActualCost)
+
+			consumption := MaterialMovement{ID: 32, Type: MovementConsumption, Quantity: 30}
+			live.Issue(&consumption, []LotConsumption{{Quantity: 20, Lot: opening}, {Quantity: 10, Lot: delivery}})
+			live.RevalueLots(40, 40*20)
+			live.SyncCostPerUnit()
+
+			costing := &MaterialCosting{Method: method, CostPerUnit: 10}
+			ledger := migratedLedger()
+
+			costing.Recost(ledger)
+
+			assert.Equal(t, *supply.UnitCost, *ledger.Movements[0].UnitCost)
+			assert.Equal(t, *consumption.TotalCost, *ledger.Movements[1].TotalCost)
+			assert.Equal(t, live.ActualCost, costing.ActualCost)
+			assert.Equal(t, live.CostPerUnit, costing.CostPerUnit)
+			assert.Equal(t, 40.0, costing.StockQuantity)
+			assert.Equal(t, 0.0, ledger.Lots[0].RemainingQuantity)
+			assert.Equal(t, 40.0, ledger.Lots[1].RemainingQuantity)
+		})
+	}
+}
+
+func TestMaterialCosting_LockedCostNotFed(t *testing.T) {
+	costing := &MaterialCosting{Method: CostingWeightedAverage, CostPerUnit: 90}
+	require.NoError(t, costing.ChangeSettings(CostingWeightedAverage, true, floatPtr(95.555)))
+
+	costing.Recost(costLedger())
+
+	assert.Equal(t, 115.0, costing.ActualCost)
+	assert.Equal(t, 95.56, costing.CostPerUnit)
+	assert.False(t, costing.SyncCostPerUnit())
+}
+
+func TestMaterialCosting_ChangeSettings_Validation(t *testing.T) {
+	costing := &MaterialCosting{Method: CostingWeightedAverage, CostPerUnit: 90}
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, costing.ChangeSettings("lifo", false, nil), &validationErr)
+	assert.Equal(t, "costing_method", validationErr.Field)
+
+	require.ErrorAs(t, costing.ChangeSettings(CostingFIFO, false, floatPtr(80)), &validationErr)
+	assert.Equal(t, "cost_per_unit", validationErr.Field)
+	assert.Equal(t, 90.0, costing.CostPerUnit)
+	assert.Equal(t, CostingWeightedAverage, costing.Method)
+}
+
+func TestMaterialCosting_ReceiveWithoutStock(t *testing.T) {
+	costing := &MaterialCosting{Method: CostingWeightedAverage, ActualCost: 70}
+	movement := &MaterialMovement{Type: MovementIncome, Quantity: 3}
+
+	costing.Receive(movement, 0, 120.12345)
+
+	assert.Equal(t, 120.1235, costing.ActualCost)
+	assert.Equal(t, 360.37, *movement.TotalCost)
+}
@@ -0,0 +1,196 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supplier представляет поставщика материалов
+type Supplier struct {
+	ID   int
+	Name string
+	INN  string
+}
+
+// MaterialSupply представляет поставку материала от поставщика. Quantity - в единице учета
+// материала, TotalAmount - стоимость поставки
+type MaterialSupply struct {
+	ID          int
+	SupplierID  int
+	MaterialID  int
+	Quantity    float64
+	UnitPrice   float64
+	TotalAmount float64
+	SupplyDate  time.Time
+	CreatedAt   time.Time
+}
+
+// Validate проверяет поставку, округляет количество до точности складского журнала
+// и рассчитывает стоимость поставки
+func (s *MaterialSupply) Validate() error {
+	if s.SupplierID <= 0 {
+		return NewValidationError("supplier_id", "не указан поставщик")
+	}
+	if s.MaterialID <= 0 {
+		return NewValidationError("material_id", "не указан материал")
+	}
+	s.Quantity = roundMovement(s.Quantity)
+	if s.Quantity <= 0 {
+		return NewValidationError("quantity", "количество должно быть больше нуля")
+	}
+	if s.UnitPrice < 0 {
+		return NewValidationError("unit_price", "цена не может быть отрицательной")
+	}
+	if s.SupplyDate.IsZero() {
+		return NewValidationError("supply_date", "не указана дата поставки")
+	}
+	s.TotalAmount = roundMoney(s.Quantity * s.UnitPrice)
+	return nil
+}
+
+// MaterialLot представляет партию материала. Партия создается каждым приходом: по поставке
+// она связана с поставщиком, остальные приходы (начальный остаток, излишек инвентаризации)
+// дают партию без поставщика. Расход и списание забирают материал из партий по FIFO.
+// UnitCost - цена единицы партии: цена поставки или себестоимость на момент прихода
+type MaterialLot struct {
+	ID                int
+	MaterialID        int
+	SupplyID          *int
+	BatchNumber       *string
+	ReceivedAt        time.Time
+	InitialQuantity   float64
+	RemainingQuantity float64
+	UnitCost          float64
+	MovementID        *int
+	CreatedAt         time.Time
+
+	// Связанные данные
+	Material *Material
+	Supplier *Supplier
+}
+
+// IsExhausted проверяет, что материал партии полностью израсходован
+func (l *MaterialLot) IsExhausted() bool {
+	return l.RemainingQuantity <= 0
+}
+
+// ConsumedQuantity возвращает количество, взятое из партии расходом и списаниями
+func (l *MaterialLot) ConsumedQuantity() float64 {
+	return roundMovement(l.InitialQuantity - l.RemainingQuantity)
+}
+
+// Label возвращает обозначение партии для интерфейса: номер партии и номер партии поставщика
+func (l *MaterialLot) Label() string {
+	label := "Партия №" + strconv.Itoa(l.ID)
+	if l.BatchNumber != nil {
+		label += " (" + *l.BatchNumber + ")"
+	}
+	return label
+}
+
+// NewMaterialLot создает партию по проведенному приходу материала
+func NewMaterialLot(movement *MaterialMovement) *MaterialLot {
+	movementID := movement.ID
+	return &MaterialLot{
+		MaterialID:        movement.MaterialID,
+		ReceivedAt:        movement.CreatedAt,
+		InitialQuantity:   movement.Quantity,
+		RemainingQuantity: movement.Quantity,
+		MovementID:        &movementID,
+	}
+}
+
+// NewSupplyLot создает партию по поставке с ценой поставки; связь с поставкой задается
+// при ее сохранении. Номер партии поставщика необязателен
+func NewSupplyLot(supply *MaterialSupply, batchNumber *string) *MaterialLot {
+	lot := &MaterialLot{
+		MaterialID:        supply.MaterialID,
+		ReceivedAt:        supply.SupplyDate,
+		InitialQuantity:   supply.Quantity,
+		RemainingQuantity: supply.Quantity,
+		UnitCost:          supply.UnitPrice,
+	}
+	if batchNumber != nil {
+		if trimmed := strings.TrimSpace(*batchNumber); trimmed != "" {
+			lot.BatchNumber = &trimmed
+		}
+	}
+	return lot
+}
+
+// LotConsumption представляет количество материала, взятое из партии движением расхода
+// или списания
+type LotConsumption struct {
+	ID         int
+	LotID      int
+	MovementID int
+	Quantity   float64
+
+	// Связанные данные
+	Lot      *MaterialLot
+	Movement *MaterialMovement
+}
+
+// AllocateFIFO распределяет количество расхода по партиям: сначала из партий, поступивших
+// раньше. Партии должны быть упорядочены по дате поступления; остаток партий уменьшается.
+// Если в партиях материала меньше, чем требуется, возвращает ошибку LOT_STOCK_MISMATCH
+func AllocateFIFO(lots []MaterialLot, quantity float64) ([]LotConsumption, error) {
+	var consumptions []LotConsumption
+	rest := roundMovement(quantity)
+	for i := range lots {
+		if rest <= 0 {
+			break
+		}
+		lot := &lots[i]
+		if lot.IsExhausted() {
+			continue
+		}
+
+		taken := math.Min(lot.RemainingQuantity, rest)
+		lot.RemainingQuantity = roundMovement(lot.RemainingQuantity - taken)
+		rest = roundMovement(rest - taken)
+		consumptions = append(consumptions, LotConsumption{LotID: lot.ID, Quantity: taken, Lot: lot})
+	}
+
+	if rest > 0 {
+		return nil, NewBusinessError("LOT_STOCK_MISMATCH",
+			fmt.Sprintf("остаток по партиям материала меньше требуемого на %g", rest))
+	}
+	return consumptions, nil
+}
+
+// LotProductUsage представляет продукцию заказа, в производство которой ушел материал партии
+type LotProductUsage struct {
+	OrderID        int
+	ProductID      int
+	ProductArticle string
+	ProductName    string
+	Quantity       int
+}
+
+// LotTrace представляет прослеживаемость партии: куда ушел ее материал и в какую продукцию
+type LotTrace struct {
+	Lot          *MaterialLot
+	Consumptions []LotConsumption
+	Products     []LotProductUsage
+}
+
+// OrderIDs возвращает заказы, в расход по которым ушел материал партии, в порядке расхода
+func (t *LotTrace) OrderIDs() []int {
+	var orderIDs []int
+	seen := make(map[int]bool)
+	for _, consumption := range t.Consumptions {
+		movement := consumption.Movement
+		if movement == nil || movement.ReferenceType != ReferenceOrder || movement.ReferenceID == nil {
+			continue
+		}
+		if orderID := *movement.ReferenceID; !seen[orderID] {
+			seen[orderID] = true
+			orderIDs = append(orderIDs, orderID)
+		}
+	}
+	return orderIDs
+}
@@ -0,0 +1,80 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocateFIFO(t *testing.T) {
+	lots := []MaterialLot{
+		{ID: 1, RemainingQuantity: 0},
+		{ID: 2, RemainingQuantity: 2.5},
+		{ID: 3, RemainingQuantity: 10},
+		{ID: 4, RemainingQuantity: 7},
+	}
+
+	consumptions, err := AllocateFIFO(lots, 4.2)
+
+	require.NoError(t, err)
+	require.Len(t, consumptions, 2)
+	assert.Equal(t, 2, consumptions[0].LotID)
+	assert.Equal(t, 2.5, consumptions[0].Quantity)
+	assert.Equal(t, 3, consumptions[1].LotID)
+	assert.Equal(t, 1.7, consumptions[1].Quantity)
+	assert.True(t, lots[1].IsExhausted())
+	assert.Equal(t, 8.3, lots[2].RemainingQuantity)
+	assert.Equal(t, 7.0, lots[3].RemainingQuantity)
+}
+
+func TestAllocateFIFO_NotEnoughInLots(t *testing.T) {
+	lots := []MaterialLot{{ID: 1, RemainingQuantity: 1}, {ID: 2, RemainingQuantity: 0.5}}
+
+	consumptions, err := AllocateFIFO(lots, 2)
+
+	var businessErr *BusinessError
+	require.ErrorAs(t, err, &businessErr)
+	assert.Equal(t, "LOT_STOCK_MISMATCH", businessErr.Code)
+	assert.Nil(t, consumptions)
+}
+
+func TestMaterialSupply_Validate(t *testing.T) {
+	supply := &MaterialSupply{
+		SupplierID: 1, MaterialID: 7, Quantity: 12.3456, UnitPrice: 99.99,
+		SupplyDate: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+	}
+
+	require.NoError(t, supply.Validate())
+	assert.Equal(t, 12.346, supply.Quantity)
+	assert.Equal(t, 1234.48, supply.TotalAmount)
+
+	supply.SupplierID = 0
+	var validationErr *ValidationError
+	require.ErrorAs(t, supply.Validate(), &validationErr)
+	assert.Equal(t, "supplier_id", validationErr.Field)
+}
+
+func TestNewSupplyLot_BatchNumber(t *testing.T) {
+	supply := &MaterialSupply{MaterialID: 7, Quantity: 50, SupplyDate: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)}
+	blank := "   "
+
+	lot := NewSupplyLot(supply, &blank)
+
+	assert.Nil(t, lot.BatchNumber)
+	assert.Equal(t, 50.0, lot.RemainingQuantity)
+	assert.Equal(t, "Партия №0", lot.Label())
+}
+
+func TestLotTrace_OrderIDs(t *testing.T) {
+	orderA, orderB := 5, 3
+	trace := &LotTrace{Consumptions: []LotConsumption{
+		{Movement: &MaterialMovement{ReferenceType: ReferenceOrder, ReferenceID: &orderA}},
+		{Movement: &MaterialMovement{ReferenceType: ReferenceStocktake}},
+		{Movement: &MaterialMovement{ReferenceType: ReferenceOrder, ReferenceID: &orderB}},
+		{Movement: &MaterialMovement{ReferenceType: ReferenceOrder, ReferenceID: &orderA}},
+	}}
+
+	assert.Equal(t, []int{5, 3}, trace.OrderIDs())
+}
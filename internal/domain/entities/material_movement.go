@@ -0,0 +1,220 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// MovementType определяет вид движения материала на складе
+type MovementType string
+
+const (
+	// MovementIncome - приход материала (поставка, излишек)
+	MovementIncome MovementType = "income"
+	// MovementConsumption - расход материала на производство
+	MovementConsumption MovementType = "consumption"
+	// MovementWriteOff - списание материала (брак, недостача)
+	MovementWriteOff MovementType = "write_off"
+	// MovementReserve - резерв материала под заказ; остаток на складе не меняет
+	MovementReserve MovementType = "reserve"
+	// MovementRelease - снятие резерва при отмене заказа; остаток на складе не меняет
+	MovementRelease MovementType = "release"
+)
+
+// IsValid проверяет, что вид движения известен
+func (t MovementType) IsValid() bool {
+	switch t {
+	case MovementIncome, MovementConsumption, MovementWriteOff, MovementReserve, MovementRelease:
+		return true
+	}
+	return false
+}
+
+// Label возвращает название вида движения для интерфейса
+func (t MovementType) Label() string {
+	switch t {
+	case MovementIncome:
+		return "Приход"
+	case MovementConsumption:
+		return "Расход"
+	case MovementWriteOff:
+		return "Списание"
+	case MovementReserve:
+		return "Резерв"
+	case MovementRelease:
+		return "Снятие резерва"
+	}
+	return string(t)
+}
+
+// Sign возвращает знак, с которым количество движения входит в остаток на складе
+func (t MovementType) Sign() float64 {
+	switch t {
+	case MovementIncome:
+		return 1
+	case MovementConsumption, MovementWriteOff:
+		return -1
+	}
+	return 0
+}
+
+// MovementReference определяет вид документа-основания движения
+type MovementReference string
+
+const (
+	// ReferenceOrder - заказ партнера
+	ReferenceOrder MovementReference = "order"
+	// ReferenceSupply - поставка материала
+	ReferenceSupply MovementReference = "supply"
+	// ReferenceWriteOff - акт списания
+	ReferenceWriteOff MovementReference = "write_off"
+	// ReferenceStocktake - инвентаризация
+	ReferenceStocktake MovementReference = "stocktake"
+)
+
+// IsValid проверяет, что вид документа известен
+func (r MovementReference) IsValid() bool {
+	switch r {
+	case ReferenceOrder, ReferenceSupply, ReferenceWriteOff, ReferenceStocktake:
+		return true
+	}
+	return false
+}
+
+// Label возвращает название вида документа для интерфейса
+func (r MovementReference) Label() string {
+	switch r {
+	case ReferenceOrder:
+		return "Заказ"
+	case ReferenceSupply:
+		return "Поставка"
+	case ReferenceWriteOff:
+		return "Акт списания"
+	case ReferenceStocktake:
+		return "Инвентаризация"
+	}
+	return string(r)
+}
+
+// movementPrecision - точность количества движения, соответствует DECIMAL(10,3)
+const movementPrecision = 1000
+
+// MaterialMovement представляет запись складского журнала материала. Quantity всегда
+// положительное, направление задает Type; RemainingQuantity - остаток после движения.
+// UnitCost и TotalCost - себестоимость движения; у резервов и движений до ведения
+// себестоимости они не заданы. CostChanged - проведение движения изменило себестоимость
+// материала (cost_per_unit), и себестоимость продукции с ним нужно пересчитать
+type MaterialMovement struct {
+	ID                int
+	MaterialID        int
+	Type              MovementType
+	Quantity          float64
+	RemainingQuantity float64
+	ReferenceID       *int
+	ReferenceType     MovementReference
+	Note              *string
+	UnitCost          *float64
+	TotalCost         *float64
+	CostChanged       bool
+	CreatedAt         time.Time
+}
+
+// Validate проверяет движение и округляет количество до точности журнала
+func (m *MaterialMovement) Validate() error {
+	if m.MaterialID <= 0 {
+		return NewValidationError("material_id", "не указан материал")
+	}
+	if !m.Type.IsValid() {
+		return NewValidationError("movement_type", "неизвестный вид движения")
+	}
+	m.Quantity = roundMovement(m.Quantity)
+	if m.Quantity <= 0 {
+		return NewValidationError("quantity", "количество должно быть больше нуля")
+	}
+	if m.ReferenceType != "" && !m.ReferenceType.IsValid() {
+		return NewValidationError("reference_type", "неизвестный вид документа")
+	}
+	if m.ReferenceID != nil && m.ReferenceType == "" {
+		return NewValidationError("reference_type", "укажите вид документа-основания")
+	}
+	return nil
+}
+
+// Delta возвращает изменение остатка на складе
+func (m *MaterialMovement) Delta() float64 {
+	return m.Type.Sign() * m.Quantity
+}
+
+// ApplyTo рассчитывает остаток после движения и сохраняет его в RemainingQuantity.
+// Остаток не может стать отрицательным
+func (m *MaterialMovement) ApplyTo(stock float64) error {
+	remaining := roundMovement(stock + m.Delta())
+	if remaining < 0 {
+		return NewBusinessError("INSUFFICIENT_STOCK",
+			fmt.Sprintf("недостаточно материала на складе: остаток %g, требуется %g", stock, m.Quantity))
+	}
+	m.RemainingQuantity = remaining
+	return nil
+}
+
+// CheckAvailable проверяет, что расход или списание не забирает материал, зарезервированный
+// под заказы. Расход по заказу забирает собственный резерв заказа, а инвентаризация фиксирует
+// фактический остаток, поэтому они проверяются только по остатку на складе
+func (m *MaterialMovement) CheckAvailable(stock, reserved float64) error {
+	if m.Delta() >= 0 || m.ReferenceType == ReferenceOrder || m.ReferenceType == ReferenceStocktake {
+		return nil
+	}
+	available := roundMovement(stock - reserved)
+	if m.Quantity > available {
+		return NewBusinessError("INSUFFICIENT_AVAILABLE",
+			fmt.Sprintf("недостаточно свободного остатка материала: свободно %g (в резерве %g), требуется %g",
+				math.Max(available, 0), roundMovement(reserved), m.Quantity))
+	}
+	return nil
+}
+
+// NewStockAdjustment создает движение, которое приводит остаток stock к target: приход
+// при увеличении и списание при уменьшении. Если остаток не меняется, возвращает nil
+func NewStockAdjustment(materialID int, stock, target float64, note string) *MaterialMovement {
+	difference := roundMovement(target - stock)
+	if difference == 0 {
+		return nil
+	}
+
+	movement := &MaterialMovement{MaterialID: materialID, Type: MovementIncome, Quantity: difference}
+	if difference < 0 {
+		movement.Type = MovementWriteOff
+		movement.Quantity = -difference
+	}
+	if note != "" {
+		movement.Note = &note
+	}
+	return movement
+}
+
+// MovementCriteria описывает фильтры и страницу журнала движений материала
+type MovementCriteria struct {
+	MaterialID int
+	Type       MovementType
+	Pagination
+}
+
+// Validate проверяет фильтр по виду движения
+func (c *MovementCriteria) Validate() error {
+	if c.Type != "" && !c.Type.IsValid() {
+		return NewValidationError("movement_type", "неизвестный вид движения")
+	}
+	return nil
+}
+
+// MovementList представляет страницу журнала движений материала
+type MovementList struct {
+	Items []MaterialMovement
+	PageInfo
+}
+
+// roundMovement округляет количество до точности журнала движений
+func roundMovement(value float64) float64 {
+	return math.Round(value*movementPrecision) / movementPrecision
+}
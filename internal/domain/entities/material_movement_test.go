@@ -0,0 +1,116 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaterialMovement_ApplyTo(t *testing.T) {
+	income := MaterialMovement{MaterialID: 1, Type: MovementIncome, Quantity: 2.5}
+	require.NoError(t, income.ApplyTo(10))
+	assert.Equal(t, 12.5, income.RemainingQuantity)
+	assert.Equal(t, 2.5, income.Delta())
+
+	consumption := MaterialMovement{MaterialID: 1, Type: MovementConsumption, Quantity: 0.3}
+	require.NoError(t, consumption.ApplyTo(0.3))
+	assert.Equal(t, 0.0, consumption.RemainingQuantity)
+
+	reserve := MaterialMovement{MaterialID: 1, Type: MovementReserve, Quantity: 4}
+	require.NoError(t, reserve.ApplyTo(10))
+	assert.Equal(t, 10.0, reserve.RemainingQuantity)
+}
+
+func TestMaterialMovement_ApplyTo_InsufficientStock(t *testing.T) {
+	writeOff := MaterialMovement{MaterialID: 1, Type: MovementWriteOff, Quantity: 5}
+
+	err := writeOff.ApplyTo(4.999)
+
+	var businessErr *BusinessError
+	require.ErrorAs(t, err, &businessErr)
+	assert.Equal(t, "INSUFFICIENT_STOCK", businessErr.Code)
+	assert.Equal(t, 0.0, writeOff.RemainingQuantity)
+}
+
+func TestMaterialMovement_CheckAvailable(t *testing.T) {
+	orderID := 7
+
+	tests := []struct {
+		name     string
+		movement MaterialMovement
+		wantErr  bool
+	}{
+		{"Расход в пределах свободного остатка", MaterialMovement{Type: MovementConsumption, Quantity: 4}, false},
+		{"Расход из резерва", MaterialMovement{Type: MovementConsumption, Quantity: 4.001}, true},
+		{"Списание из резерва", MaterialMovement{Type: MovementWriteOff, Quantity: 6}, true},
+		{"Расход по заказу", MaterialMovement{Type: MovementConsumption, Quantity: 10,
+			ReferenceID: &orderID, ReferenceType: ReferenceOrder}, false},
+		{"Списание по инвентаризации", MaterialMovement{Type: MovementWriteOff, Quantity: 10,
+			ReferenceType: ReferenceStocktake}, false},
+		{"Приход", MaterialMovement{Type: MovementIncome, Quantity: 10}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.movement.CheckAvailable(10, 6)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			var businessErr *BusinessError
+			require.ErrorAs(t, err, &businessErr)
+			assert.Equal(t, "INSUFFICIENT_AVAILABLE", businessErr.Code)
+		})
+	}
+}
+
+func TestMaterialMovement_Validate(t *testing.T) {
+	referenceID := 5
+
+	tests := []struct {
+		name     string
+		movement MaterialMovement
+		field    string
+	}{
+		{"без материала", MaterialMovement{Type: MovementIncome, Quantity: 1}, "material_id"},
+		{"неизвестный вид", MaterialMovement{MaterialID: 1, Type: "transfer", Quantity: 1}, "movement_type"},
+		{"нулевое количество", MaterialMovement{MaterialID: 1, Type: MovementIncome}, "quantity"},
+		{"меньше точности журнала", MaterialMovement{MaterialID: 1, Type: MovementIncome, Quantity: 0.0004}, "quantity"},
+		{"неизвестный документ", MaterialMovement{MaterialID: 1, Type: MovementIncome, Quantity: 1, ReferenceType: "invoice"}, "reference_type"},
+		{"номер без вида документа", MaterialMovement{MaterialID: 1, Type: MovementIncome, Quantity: 1, ReferenceID: &referenceID}, "reference_type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.movement.Validate()
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+}
+
+func TestMaterialMovement_ValidateRoundsQuantity(t *testing.T) {
+	movement := MaterialMovement{MaterialID: 1, Type: MovementIncome, Quantity: 1.23456, ReferenceType: ReferenceSupply}
+
+	require.NoError(t, movement.Validate())
+	assert.Equal(t, 1.235, movement.Quantity)
+}
+
+func TestNewStockAdjustment(t *testing.T) {
+	increase := NewStockAdjustment(1, 10, 12.5, "Начальный остаток")
+	require.NotNil(t, increase)
+	assert.Equal(t, MovementIncome, increase.Type)
+	assert.Equal(t, 2.5, increase.Quantity)
+	assert.Equal(t, "Начальный остаток", *increase.Note)
+
+	decrease := NewStockAdjustment(1, 10, 7.75, "")
+	require.NotNil(t, decrease)
+	assert.Equal(t, MovementWriteOff, decrease.Type)
+	assert.Equal(t, 2.25, decrease.Quantity)
+	assert.Nil(t, decrease.Note)
+
+	assert.Nil(t, NewStockAdjustment(1, 10, 10.0001, ""))
+}
@@ -0,0 +1,106 @@
+package entities
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReservationStatus определяет состояние резерва материала под заказ
+type ReservationStatus string
+
+const (
+	// ReservationActive - материал зарезервирован и не входит в свободный остаток
+	ReservationActive ReservationStatus = "active"
+	// ReservationReleased - резерв снят при отмене заказа
+	ReservationReleased ReservationStatus = "released"
+	// ReservationConsumed - резерв списан в расход при запуске заказа в производство
+	ReservationConsumed ReservationStatus = "consumed"
+)
+
+// Label возвращает название состояния резерва для интерфейса
+func (s ReservationStatus) Label() string {
+	switch s {
+	case ReservationActive:
+		return "Активен"
+	case ReservationReleased:
+		return "Снят"
+	case ReservationConsumed:
+		return "Израсходован"
+	}
+	return string(s)
+}
+
+// MaterialReservation представляет резерв материала под заказ в единице учета материала
+type MaterialReservation struct {
+	ID         int
+	OrderID    int
+	MaterialID int
+	Quantity   float64
+	Status     ReservationStatus
+	CreatedAt  time.Time
+	ClosedAt   *time.Time
+
+	// Связанные данные
+	Material *Material
+}
+
+// NewOrderReservations создает резервы заказа по потребности в сырье: потребность по каждому
+// материалу суммируется и округляется до точности складского журнала. Резервы упорядочены
+// по ID материала, чтобы параллельные подтверждения блокировали материалы в одном порядке
+func NewOrderReservations(orderID int, requirements []MaterialRequirement) []MaterialReservation {
+	quantities := make(map[int]float64)
+	materials := make(map[int]*Material)
+	for _, requirement := range requirements {
+		quantities[requirement.MaterialID] += requirement.Quantity
+		if requirement.Material != nil {
+			materials[requirement.MaterialID] = requirement.Material
+		}
+	}
+
+	reservations := make([]MaterialReservation, 0, len(quantities))
+	for materialID, quantity := range quantities {
+		quantity = roundMovement(quantity)
+		if quantity <= 0 {
+			continue
+		}
+		reservations = append(reservations, MaterialReservation{
+			OrderID:    orderID,
+			MaterialID: materialID,
+			Quantity:   quantity,
+			Status:     ReservationActive,
+			Material:   materials[materialID],
+		})
+	}
+
+	sort.Slice(reservations, func(i, j int) bool {
+		return reservations[i].MaterialID < reservations[j].MaterialID
+	})
+	return reservations
+}
+
+// SetReservedQuantity задает количество материала в активных резервах и пересчитывает
+// свободный остаток. Свободный остаток отрицателен, если остаток на складе после
+// списаний меньше зарезервированного
+func (m *Material) SetReservedQuantity(reserved float64) {
+	m.ReservedQuantity = roundMovement(reserved)
+	m.AvailableQuantity = roundMovement(m.StockQuantity - m.ReservedQuantity)
+}
+
+// ReservationShortage описывает нехватку свободного остатка материала для резерва
+type ReservationShortage struct {
+	Article   string
+	Required  float64
+	Available float64
+}
+
+// NewInsufficientAvailableError создает ошибку нехватки свободного остатка для резерва заказа
+func NewInsufficientAvailableError(shortages []ReservationShortage) *BusinessError {
+	parts := make([]string, len(shortages))
+	for i, shortage := range shortages {
+		parts[i] = fmt.Sprintf("%s (требуется %g, свободно %g)", shortage.Article, shortage.Required, shortage.Available)
+	}
+	return NewBusinessError("INSUFFICIENT_AVAILABLE",
+		"недостаточно свободного остатка материалов: "+strings.Join(parts, ", "))
+}
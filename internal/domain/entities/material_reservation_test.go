@@ -0,0 +1,101 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOrderReservations_MergesMaterials(t *testing.T) {
+	// Подготовка данных: винил нужен двум позициям заказа
+	vinyl := &Material{ID: 7, Article: "VIN-01"}
+	requirements := []MaterialRequirement{
+		{MaterialID: 7, Material: vinyl, Quantity: 10.25},
+		{MaterialID: 3, Quantity: 0.1 + 0.2},
+		{MaterialID: 7, Material: vinyl, Quantity: 4.5},
+		{MaterialID: 9, Quantity: 0.0004},
+	}
+
+	// Выполнение
+	reservations := NewOrderReservations(15, requirements)
+
+	// Проверки: материалы упорядочены по ID, количество округлено до точности журнала,
+	// нулевая после округления потребность не резервируется
+	require.Len(t, reservations, 2)
+	assert.Equal(t, 3, reservations[0].MaterialID)
+	assert.Equal(t, 0.3, reservations[0].Quantity)
+	assert.Equal(t, 7, reservations[1].MaterialID)
+	assert.Equal(t, 14.75, reservations[1].Quantity)
+	assert.Equal(t, vinyl, reservations[1].Material)
+	assert.Equal(t, 15, reservations[1].OrderID)
+	assert.Equal(t, ReservationActive, reservations[1].Status)
+}
+
+func TestMaterial_SetReservedQuantity(t *testing.T) {
+	material := Material{StockQuantity: 12.5}
+
+	material.SetReservedQuantity(10.2)
+	assert.Equal(t, 10.2, material.ReservedQuantity)
+	assert.Equal(t, 2.3, material.AvailableQuantity)
+
+	// Списание после резерва делает свободный остаток отрицательным
+	material.StockQuantity = 8
+	material.SetReservedQuantity(10.2)
+	assert.Equal(t, -2.2, material.AvailableQuantity)
+}
+
+func TestNewInsufficientAvailableError(t *testing.T) {
+	err := NewInsufficientAvailableError([]ReservationShortage{
+		{Article: "VIN-01", Required: 14.75, Available: 6},
+		{Article: "GLU-02", Required: 2, Available: 0},
+	})
+
+	assert.Equal(t, "INSUFFICIENT_AVAILABLE", err.Code)
+	assert.Contains(t, err.Error(), "VIN-01 (требуется 14.75, свободно 6)")
+	assert.Contains(t, err.Error(), "GLU-02 (требуется 2, свободно 0)")
+}
+
+func TestNewOrderStatusChange(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    OrderStatus
+		to      OrderStatus
+		release bool
+		consume bool
+	}{
+		{"подтверждение", OrderCreated, OrderConfirmed, false, false},
+		{"отмена подтвержденного", OrderConfirmed, OrderCancelled, true, false},
+		{"отмена предоплаченного", OrderPrepaid, OrderCancelled, true, false},
+		{"запуск в производство", OrderPrepaid, OrderInProduction, false, true},
+		{"готовность", OrderInProduction, OrderReady, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change, err := NewOrderStatusChange(&Order{ID: 5, Status: tt.from}, tt.to)
+
+			require.NoError(t, err)
+			assert.Equal(t, 5, change.OrderID)
+			assert.Equal(t, tt.from, change.From)
+			assert.Equal(t, tt.to, change.To)
+			assert.Equal(t, tt.release, change.ReleaseReservations)
+			assert.Equal(t, tt.consume, change.ConsumeReservations)
+		})
+	}
+}
+
+func TestNewOrderStatusChange_InvalidTransition(t *testing.T) {
+	// Запущенный в производство заказ отменить нельзя: материалы уже израсходованы
+	_, err := NewOrderStatusChange(&Order{ID: 5, Status: OrderInProduction}, OrderCancelled)
+
+	var businessErr *BusinessError
+	require.ErrorAs(t, err, &businessErr)
+	assert.Equal(t, "INVALID_STATUS_TRANSITION", businessErr.Code)
+	assert.Contains(t, businessErr.Error(), "«В производстве»")
+
+	_, err = NewOrderStatusChange(&Order{ID: 5, Status: OrderCreated}, "shipped")
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "status", validationErr.Field)
+}
@@ -251,3 +251,41 @@ func TestMaterialCalculationRequest_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestMaterial_ValidateCostChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		material *Material
+		cost     float64
+		wantErr  bool
+	}{
+		{
+			name:     "Себестоимость не меняется",
+			material: &Material{CostPerUnit: 100},
+			cost:     100,
+		},
+		{
+			name:     "Незафиксированная себестоимость рассчитывается по журналу",
+			material: &Material{CostPerUnit: 100},
+			cost:     120,
+			wantErr:  true,
+		},
+		{
+			name:     "Зафиксированная себестоимость задается вручную",
+			material: &Material{CostPerUnit: 100, CostLocked: true},
+			cost:     120,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.material.ValidateCostChange(tt.cost)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
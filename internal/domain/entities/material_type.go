@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxWastePercentage ограничивает процент брака типа материала
+const MaxWastePercentage = 100
+
+// Validate проверяет корректность типа материала
+func (t *MaterialType) Validate() error {
+	if t.Name == "" {
+		return NewValidationError("name", "название типа материала не может быть пустым")
+	}
+	if len([]rune(t.Name)) > 100 {
+		return NewValidationError("name", "название типа материала не может быть длиннее 100 символов")
+	}
+	if t.WastePercentage < 0 || t.WastePercentage > MaxWastePercentage {
+		return NewValidationError("waste_percentage",
+			fmt.Sprintf("процент брака должен быть от 0 до %d", MaxWastePercentage))
+	}
+	return nil
+}
+
+// DefectRateChange описывает изменение процента брака типа материала: кто и когда его изменил.
+// OldWastePercentage пуст для начального значения
+type DefectRateChange struct {
+	ID                 int
+	MaterialTypeID     int
+	OldWastePercentage *float64
+	NewWastePercentage float64
+	ChangedBy          string
+	Comment            *string
+	CreatedAt          time.Time
+}
+
+// Validate проверяет сведения об авторе изменения процента брака
+func (c *DefectRateChange) Validate() error {
+	if c.ChangedBy == "" {
+		return NewValidationError("changed_by", "укажите, кто изменяет процент брака")
+	}
+	if len([]rune(c.ChangedBy)) > 100 {
+		return NewValidationError("changed_by", "имя автора изменения не может быть длиннее 100 символов")
+	}
+	return nil
+}
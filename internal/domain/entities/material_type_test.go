@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaterialType_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		materialType MaterialType
+		field        string
+	}{
+		{"корректный тип", MaterialType{Name: "Основа", WastePercentage: 3}, ""},
+		{"без брака", MaterialType{Name: "Основа"}, ""},
+		{"весь материал в брак", MaterialType{Name: "Основа", WastePercentage: 100}, ""},
+		{"пустое название", MaterialType{WastePercentage: 3}, "name"},
+		{"длинное название", MaterialType{Name: strings.Repeat("я", 101), WastePercentage: 3}, "name"},
+		{"отрицательный брак", MaterialType{Name: "Основа", WastePercentage: -1}, "waste_percentage"},
+		{"брак больше 100%", MaterialType{Name: "Основа", WastePercentage: 100.5}, "waste_percentage"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.materialType.Validate()
+			if tt.field == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+}
+
+func TestDefectRateChange_Validate(t *testing.T) {
+	assert.NoError(t, (&DefectRateChange{ChangedBy: "Иванов"}).Validate())
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, (&DefectRateChange{}).Validate(), &validationErr)
+	assert.Equal(t, "changed_by", validationErr.Field)
+}
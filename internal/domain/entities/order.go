@@ -0,0 +1,141 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// OrderStatus определяет статус заказа партнера
+type OrderStatus string
+
+const (
+	// OrderCreated - заказ создан и еще не подтвержден
+	OrderCreated OrderStatus = "created"
+	// OrderConfirmed - заказ подтвержден, материалы зарезервированы
+	OrderConfirmed OrderStatus = "confirmed"
+	// OrderPrepaid - по заказу получена предоплата
+	OrderPrepaid OrderStatus = "prepaid"
+	// OrderInProduction - заказ запущен в производство, резерв списан в расход
+	OrderInProduction OrderStatus = "in_production"
+	// OrderReady - продукция по заказу изготовлена
+	OrderReady OrderStatus = "ready"
+	// OrderCompleted - заказ выполнен
+	OrderCompleted OrderStatus = "completed"
+	// OrderCancelled - заказ отменен, резерв снят
+	OrderCancelled OrderStatus = "cancelled"
+)
+
+// orderTransitions перечисляет допустимые переходы между статусами заказа. Запущенный
+// в производство заказ отменить нельзя: материалы уже израсходованы
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderCreated:      {OrderConfirmed, OrderCancelled},
+	OrderConfirmed:    {OrderPrepaid, OrderInProduction, OrderCancelled},
+	OrderPrepaid:      {OrderInProduction, OrderCancelled},
+	OrderInProduction: {OrderReady},
+	OrderReady:        {OrderCompleted},
+}
+
+// IsValid проверяет, что статус заказа известен
+func (s OrderStatus) IsValid() bool {
+	switch s {
+	case OrderCreated, OrderConfirmed, OrderPrepaid, OrderInProduction, OrderReady, OrderCompleted, OrderCancelled:
+		return true
+	}
+	return false
+}
+
+// Label возвращает название статуса заказа для интерфейса
+func (s OrderStatus) Label() string {
+	switch s {
+	case OrderCreated:
+		return "Создан"
+	case OrderConfirmed:
+		return "Подтвержден"
+	case OrderPrepaid:
+		return "Предоплачен"
+	case OrderInProduction:
+		return "В производстве"
+	case OrderReady:
+		return "Готов"
+	case OrderCompleted:
+		return "Выполнен"
+	case OrderCancelled:
+		return "Отменен"
+	}
+	return string(s)
+}
+
+// CanTransitionTo проверяет, допустим ли переход заказа в статус next
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Order представляет заказ партнера
+type Order struct {
+	ID               int
+	PartnerID        int
+	ManagerID        *int
+	Status           OrderStatus
+	TotalAmount      float64
+	PrepaymentAmount float64
+	DeliveryRequired bool
+	DeliveryAddress  *string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// OrderItem представляет позицию заказа партнера. Quantity - количество единиц продукции
+type OrderItem struct {
+	ID                 int
+	OrderID            int
+	ProductID          int
+	Quantity           int
+	UnitPrice          float64
+	TotalPrice         float64
+	ProductionDeadline *time.Time
+	CreatedAt          time.Time
+}
+
+// OrderStatusChange описывает смену статуса заказа и связанные с ней действия с резервами
+// материалов; все действия выполняются вместе со сменой статуса или не выполняются вовсе
+type OrderStatusChange struct {
+	OrderID int
+	From    OrderStatus
+	To      OrderStatus
+	// Reservations - резервы, создаваемые при подтверждении заказа, а у заказа без резервов -
+	// при запуске в производство перед списанием
+	Reservations []MaterialReservation
+	// ReleaseReservations - снять активные резервы заказа
+	ReleaseReservations bool
+	// ConsumeReservations - списать активные резервы заказа в расход
+	ConsumeReservations bool
+	// CostChangedMaterialIDs - материалы, себестоимость которых изменилась при проведении
+	// расхода; заполняется при сохранении смены статуса
+	CostChangedMaterialIDs []int
+}
+
+// NewOrderStatusChange проверяет переход заказа в статус next и определяет, что происходит
+// с резервами: при отмене они снимаются, при запуске в производство списываются в расход.
+// Резервы для подтверждения заказа добавляет вызывающий код по рецептуре позиций
+func NewOrderStatusChange(order *Order, next OrderStatus) (*OrderStatusChange, error) {
+	if !next.IsValid() {
+		return nil, NewValidationError("status", "неизвестный статус заказа")
+	}
+	if !order.Status.CanTransitionTo(next) {
+		return nil, NewBusinessError("INVALID_STATUS_TRANSITION",
+			fmt.Sprintf("заказ в статусе «%s» нельзя перевести в статус «%s»", order.Status.Label(), next.Label()))
+	}
+
+	return &OrderStatusChange{
+		OrderID:             order.ID,
+		From:                order.Status,
+		To:                  next,
+		ReleaseReservations: next == OrderCancelled,
+		ConsumeReservations: next == OrderInProduction,
+	}, nil
+}
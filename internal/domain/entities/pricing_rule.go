@@ -0,0 +1,199 @@
+package entities
+
+import (
+	"math"
+	"time"
+)
+
+// RoundingMode определяет способ округления итоговой цены
+type RoundingMode string
+
+const (
+	// RoundingNearest округляет до ближайшего кратного шагу значения
+	RoundingNearest RoundingMode = "nearest"
+	// RoundingUp округляет вверх до кратного шагу значения
+	RoundingUp RoundingMode = "up"
+	// RoundingDown округляет вниз до кратного шагу значения
+	RoundingDown RoundingMode = "down"
+)
+
+// DefaultMarkupPercent - наценка, применяемая при отсутствии подходящих правил ценообразования
+const DefaultMarkupPercent = 20.0
+
+// PartnerType представляет тип партнера
+type PartnerType struct {
+	ID          int
+	Name        string
+	Description *string
+}
+
+// PricingRule представляет правило ценообразования.
+// Пустые ProductTypeID, PartnerTypeID и границы периода означают "любое значение".
+type PricingRule struct {
+	ID             int
+	Name           string
+	ProductTypeID  *int
+	PartnerTypeID  *int
+	ValidFrom      *time.Time
+	ValidTo        *time.Time
+	MarkupPercent  float64
+	FixedSurcharge float64
+	RoundingMode   RoundingMode
+	RoundingStep   float64
+	Priority       int
+	IsActive       bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+
+	// Связанные данные
+	ProductType *ProductType
+	PartnerType *PartnerType
+}
+
+// PricingContext описывает условия, для которых рассчитывается цена
+type PricingContext struct {
+	ProductTypeID int
+	PartnerTypeID *int
+	Date          time.Time
+}
+
+// PriceCalculation представляет результат расчета цены продукции
+type PriceCalculation struct {
+	Cost  float64
+	Price float64
+	Rule  *PricingRule
+}
+
+// DefaultPricingRule возвращает правило по умолчанию: наценка 20% с округлением до копеек
+func DefaultPricingRule() *PricingRule {
+	return &PricingRule{
+		Name:          "Базовая наценка",
+		MarkupPercent: DefaultMarkupPercent,
+		RoundingMode:  RoundingNearest,
+		RoundingStep:  0.01,
+		IsActive:      true,
+	}
+}
+
+// Validate проверяет корректность правила ценообразования
+func (r *PricingRule) Validate() error {
+	if r.Name == "" {
+		return NewValidationError("name", "название правила не может быть пустым")
+	}
+	if r.MarkupPercent <= -100 {
+		return NewValidationError("markup_percent", "наценка должна быть больше -100%")
+	}
+	if r.FixedSurcharge < 0 {
+		return NewValidationError("fixed_surcharge", "фиксированная надбавка не может быть отрицательной")
+	}
+	switch r.RoundingMode {
+	case RoundingNearest, RoundingUp, RoundingDown:
+	default:
+		return NewValidationError("rounding_mode", "неизвестный способ округления")
+	}
+	if r.RoundingStep <= 0 {
+		return NewValidationError("rounding_step", "шаг округления должен быть больше нуля")
+	}
+	// Шаг хранится с точностью до копейки (DECIMAL(10,2)): более мелкий обратился бы в ноль
+	if r.RoundingStep < 0.01 || roundMoney(r.RoundingStep) != r.RoundingStep {
+		return NewValidationError("rounding_step", "шаг округления задается в копейках: не меньше 0.01 и не больше двух знаков после точки")
+	}
+	if r.ValidFrom != nil && r.ValidTo != nil && r.ValidTo.Before(*r.ValidFrom) {
+		return NewValidationError("valid_to", "дата окончания действия не может быть раньше даты начала")
+	}
+	return nil
+}
+
+// Matches проверяет, применимо ли правило в заданных условиях
+func (r *PricingRule) Matches(ctx PricingContext) bool {
+	if !r.IsActive {
+		return false
+	}
+	if r.ProductTypeID != nil && *r.ProductTypeID != ctx.ProductTypeID {
+		return false
+	}
+	if r.PartnerTypeID != nil && (ctx.PartnerTypeID == nil || *r.PartnerTypeID != *ctx.PartnerTypeID) {
+		return false
+	}
+
+	date := truncateToDay(ctx.Date)
+	if r.ValidFrom != nil && date.Before(truncateToDay(*r.ValidFrom)) {
+		return false
+	}
+	if r.ValidTo != nil && date.After(truncateToDay(*r.ValidTo)) {
+		return false
+	}
+	return true
+}
+
+// Specificity возвращает число заданных условий правила; более конкретное правило важнее общего
+func (r *PricingRule) Specificity() int {
+	specificity := 0
+	if r.ProductTypeID != nil {
+		specificity++
+	}
+	if r.PartnerTypeID != nil {
+		specificity++
+	}
+	if r.ValidFrom != nil || r.ValidTo != nil {
+		specificity++
+	}
+	return specificity
+}
+
+// Apply рассчитывает цену по себестоимости: наценка, фиксированная надбавка и округление
+func (r *PricingRule) Apply(cost float64) float64 {
+	price := cost*(1+r.MarkupPercent/100) + r.FixedSurcharge
+
+	step := r.RoundingStep
+	if step <= 0 {
+		step = 0.01
+	}
+
+	steps := price / step
+	// Гасим погрешность представления чисел с плавающей точкой перед округлением вверх/вниз
+	steps = math.Round(steps*1e6) / 1e6
+	switch r.RoundingMode {
+	case RoundingUp:
+		steps = math.Ceil(steps)
+	case RoundingDown:
+		steps = math.Floor(steps)
+	default:
+		steps = math.Round(steps)
+	}
+
+	// Округляем до копеек, чтобы не тащить погрешность умножения на шаг
+	return math.Round(steps*step*100) / 100
+}
+
+// SelectPricingRule выбирает правило для заданных условий.
+// Побеждает правило с наибольшим приоритетом, затем более конкретное, затем более новое.
+// Если ни одно правило не подходит, возвращается nil.
+func SelectPricingRule(rules []PricingRule, ctx PricingContext) *PricingRule {
+	var selected *PricingRule
+	for i := range rules {
+		rule := &rules[i]
+		if !rule.Matches(ctx) {
+			continue
+		}
+		if selected == nil || rule.outranks(selected) {
+			selected = rule
+		}
+	}
+	return selected
+}
+
+func (r *PricingRule) outranks(other *PricingRule) bool {
+	if r.Priority != other.Priority {
+		return r.Priority > other.Priority
+	}
+	if r.Specificity() != other.Specificity() {
+		return r.Specificity() > other.Specificity()
+	}
+	return r.ID > other.ID
+}
+
+func truncateToDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,207 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func datePtr(year int, month time.Month, day int) *time.Time {
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &date
+}
+
+func TestPricingRule_Apply(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     PricingRule
+		cost     float64
+		expected float64
+	}{
+		{
+			name:     "Базовая наценка 20% до копеек",
+			rule:     *DefaultPricingRule(),
+			cost:     123.456,
+			expected: 148.15,
+		},
+		{
+			name:     "Наценка и фиксированная надбавка",
+			rule:     PricingRule{MarkupPercent: 35, FixedSurcharge: 50, RoundingMode: RoundingNearest, RoundingStep: 0.01},
+			cost:     200,
+			expected: 320,
+		},
+		{
+			name:     "Округление вверх до 10 рублей",
+			rule:     PricingRule{MarkupPercent: 10, RoundingMode: RoundingUp, RoundingStep: 10},
+			cost:     100,
+			expected: 110,
+		},
+		{
+			name:     "Округление вверх с остатком",
+			rule:     PricingRule{MarkupPercent: 10, RoundingMode: RoundingUp, RoundingStep: 10},
+			cost:     101,
+			expected: 120,
+		},
+		{
+			name:     "Округление вниз до 5 рублей",
+			rule:     PricingRule{MarkupPercent: 0, RoundingMode: RoundingDown, RoundingStep: 5},
+			cost:     99.99,
+			expected: 95,
+		},
+		{
+			name:     "Скидка для оптового канала",
+			rule:     PricingRule{MarkupPercent: -10, RoundingMode: RoundingNearest, RoundingStep: 1},
+			cost:     1000.4,
+			expected: 900,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.rule.Apply(tt.cost))
+		})
+	}
+}
+
+func TestPricingRule_Matches(t *testing.T) {
+	date := time.Date(2026, time.March, 15, 14, 30, 0, 0, time.UTC)
+	wholesale := intPtr(2)
+
+	tests := []struct {
+		name     string
+		rule     PricingRule
+		ctx      PricingContext
+		expected bool
+	}{
+		{
+			name:     "Общее правило подходит всем",
+			rule:     PricingRule{IsActive: true},
+			ctx:      PricingContext{ProductTypeID: 1, Date: date},
+			expected: true,
+		},
+		{
+			name:     "Неактивное правило",
+			rule:     PricingRule{IsActive: false},
+			ctx:      PricingContext{ProductTypeID: 1, Date: date},
+			expected: false,
+		},
+		{
+			name:     "Другой тип продукции",
+			rule:     PricingRule{IsActive: true, ProductTypeID: intPtr(3)},
+			ctx:      PricingContext{ProductTypeID: 1, Date: date},
+			expected: false,
+		},
+		{
+			name:     "Правило для типа партнера без партнера в запросе",
+			rule:     PricingRule{IsActive: true, PartnerTypeID: wholesale},
+			ctx:      PricingContext{ProductTypeID: 1, Date: date},
+			expected: false,
+		},
+		{
+			name:     "Совпадает тип партнера",
+			rule:     PricingRule{IsActive: true, PartnerTypeID: wholesale},
+			ctx:      PricingContext{ProductTypeID: 1, PartnerTypeID: intPtr(2), Date: date},
+			expected: true,
+		},
+		{
+			name:     "Последний день периода включительно",
+			rule:     PricingRule{IsActive: true, ValidFrom: datePtr(2026, time.March, 1), ValidTo: datePtr(2026, time.March, 15)},
+			ctx:      PricingContext{ProductTypeID: 1, Date: date},
+			expected: true,
+		},
+		{
+			name:     "Период еще не начался",
+			rule:     PricingRule{IsActive: true, ValidFrom: datePtr(2026, time.March, 16)},
+			ctx:      PricingContext{ProductTypeID: 1, Date: date},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.rule.Matches(tt.ctx))
+		})
+	}
+}
+
+func TestSelectPricingRule(t *testing.T) {
+	date := time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)
+	rules := []PricingRule{
+		{ID: 1, Name: "Базовая", IsActive: true, MarkupPercent: 20},
+		{ID: 2, Name: "Текстиль", IsActive: true, ProductTypeID: intPtr(4), MarkupPercent: 35},
+		{ID: 3, Name: "Текстиль опт", IsActive: true, ProductTypeID: intPtr(4), PartnerTypeID: intPtr(2), MarkupPercent: 25},
+		{ID: 4, Name: "Акция", IsActive: true, Priority: 10, ValidTo: datePtr(2026, time.March, 1), MarkupPercent: 5},
+	}
+
+	t.Run("Более конкретное правило важнее общего", func(t *testing.T) {
+		rule := SelectPricingRule(rules, PricingContext{ProductTypeID: 4, PartnerTypeID: intPtr(2), Date: date})
+		require.NotNil(t, rule)
+		assert.Equal(t, 3, rule.ID)
+	})
+
+	t.Run("Без типа партнера выбирается правило по типу продукции", func(t *testing.T) {
+		rule := SelectPricingRule(rules, PricingContext{ProductTypeID: 4, Date: date})
+		require.NotNil(t, rule)
+		assert.Equal(t, 2, rule.ID)
+	})
+
+	t.Run("Приоритет важнее конкретности", func(t *testing.T) {
+		rule := SelectPricingRule(rules, PricingContext{ProductTypeID: 4, Date: *datePtr(2026, time.February, 20)})
+		require.NotNil(t, rule)
+		assert.Equal(t, 4, rule.ID)
+	})
+
+	t.Run("Нет подходящих правил", func(t *testing.T) {
+		rule := SelectPricingRule(rules[1:3], PricingContext{ProductTypeID: 1, Date: date})
+		assert.Nil(t, rule)
+	})
+}
+
+func TestPricingRule_Validate(t *testing.T) {
+	valid := func() PricingRule {
+		return PricingRule{Name: "Правило", MarkupPercent: 20, RoundingMode: RoundingNearest, RoundingStep: 0.01}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(r *PricingRule)
+		wantErr bool
+	}{
+		{name: "Корректное правило", modify: func(r *PricingRule) {}, wantErr: false},
+		{name: "Пустое название", modify: func(r *PricingRule) { r.Name = "" }, wantErr: true},
+		{name: "Наценка -100%", modify: func(r *PricingRule) { r.MarkupPercent = -100 }, wantErr: true},
+		{name: "Отрицательная надбавка", modify: func(r *PricingRule) { r.FixedSurcharge = -1 }, wantErr: true},
+		{name: "Неизвестное округление", modify: func(r *PricingRule) { r.RoundingMode = "bank" }, wantErr: true},
+		{name: "Нулевой шаг округления", modify: func(r *PricingRule) { r.RoundingStep = 0 }, wantErr: true},
+		{name: "Шаг округления меньше копейки", modify: func(r *PricingRule) { r.RoundingStep = 0.001 }, wantErr: true},
+		{name: "Шаг округления с долями копейки", modify: func(r *PricingRule) { r.RoundingStep = 0.015 }, wantErr: true},
+		{name: "Шаг округления в рублях", modify: func(r *PricingRule) { r.RoundingStep = 0.1 }, wantErr: false},
+		{
+			name: "Окончание раньше начала",
+			modify: func(r *PricingRule) {
+				r.ValidFrom = datePtr(2026, time.March, 10)
+				r.ValidTo = datePtr(2026, time.March, 1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := valid()
+			tt.modify(&rule)
+			err := rule.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
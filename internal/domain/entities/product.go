@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -8,7 +9,9 @@ import (
 type ProductType struct {
 	ID          int
 	Name        string
+	Description *string
 	Coefficient float64
+	ArchivedAt  *time.Time
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
@@ -21,6 +24,8 @@ type Product struct {
 	Name                   string
 	Description            *string
 	ImagePath              *string
+	ThumbnailPath          *string
+	PreviewPath            *string
 	MinPartnerPrice        float64
 	PackageLength          *float64
 	PackageWidth           *float64
@@ -34,13 +39,21 @@ type Product struct {
 	WorkshopNumber         *string
 	RequiredWorkers        *int
 	RollWidth              *float64
+	RollLength             *float64
+	PatternRepeat          *float64
+	CalculatedCost         *float64
+	CostCalculatedAt       *time.Time
+	ArchivedAt             *time.Time
 	CreatedAt              time.Time
 	UpdatedAt              time.Time
 
 	// Связанные данные
-	ProductType     *ProductType
-	Materials       []ProductMaterial
-	CalculatedPrice *float64
+	ProductType        *ProductType
+	Materials          []ProductMaterial
+	Components         []ProductComponent
+	Variants           []ProductVariant
+	CalculatedPrice    *float64
+	AppliedPricingRule *PricingRule
 }
 
 // ProductMaterial представляет связь продукции с материалами
@@ -53,24 +66,39 @@ type ProductMaterial struct {
 	Material        *Material
 }
 
-// CalculatePrice рассчитывает стоимость продукции на основе материалов
+// CalculatePrice рассчитывает стоимость продукции по правилу ценообразования по умолчанию
 func (p *Product) CalculatePrice() float64 {
-	if len(p.Materials) == 0 || p.ProductType == nil {
+	return p.CalculatePriceWithRule(DefaultPricingRule())
+}
+
+// CalculatePriceWithRule рассчитывает стоимость продукции на основе многоуровневой рецептуры
+// и заданного правила ценообразования
+func (p *Product) CalculatePriceWithRule(rule *PricingRule) float64 {
+	cost, err := p.CalculateCost()
+	if err != nil || cost <= 0 {
 		return 0
 	}
 
-	materialsCost := 0.0
-	for _, pm := range p.Materials {
-		if pm.Material != nil {
-			materialsCost += pm.QuantityPerUnit * pm.Material.CostPerUnit
-		}
+	return rule.Apply(cost)
+}
+
+// EffectiveCost возвращает себестоимость с учетом ручной корректировки:
+// заданная вручную себестоимость важнее рассчитанной по рецептуре
+func (p *Product) EffectiveCost() *float64 {
+	if p.CostPrice != nil {
+		return p.CostPrice
 	}
+	return p.CalculatedCost
+}
 
-	// Применяем коэффициент типа продукции
-	materialsCost *= p.ProductType.Coefficient
+// IsArchived проверяет, перенесена ли продукция в архив
+func (p *Product) IsArchived() bool {
+	return p.ArchivedAt != nil
+}
 
-	// Добавляем 20% наценку
-	return materialsCost * 1.2
+// Images возвращает ссылки на изображение продукции и его уменьшенные копии
+func (p *Product) Images() ImagePaths {
+	return ImagePaths{ImagePath: p.ImagePath, ThumbnailPath: p.ThumbnailPath, PreviewPath: p.PreviewPath}
 }
 
 // Validate проверяет корректность данных продукции
@@ -87,5 +115,87 @@ func (p *Product) Validate() error {
 	if p.RollWidth != nil && *p.RollWidth < 0 {
 		return NewValidationError("roll_width", "ширина рулона не может быть отрицательной")
 	}
+	if p.RollLength != nil && *p.RollLength < 0 {
+		return NewValidationError("roll_length", "длина рулона не может быть отрицательной")
+	}
+	if p.PatternRepeat != nil && *p.PatternRepeat < 0 {
+		return NewValidationError("pattern_repeat", "раппорт не может быть отрицательным")
+	}
+	if err := p.validatePackage(); err != nil {
+		return err
+	}
+	if p.ProductionTimeHours != nil && *p.ProductionTimeHours < 0 {
+		return NewValidationError("production_time_hours", "время производства не может быть отрицательным")
+	}
+	if p.CostPrice != nil && *p.CostPrice < 0 {
+		return NewValidationError("cost_price", "себестоимость не может быть отрицательной")
+	}
+	if p.RequiredWorkers != nil && *p.RequiredWorkers <= 0 {
+		return NewValidationError("required_workers", "количество рабочих должно быть больше нуля")
+	}
+	return nil
+}
+
+// validatePackage проверяет габариты и вес упаковки: габариты задаются все три сразу,
+// все размеры и веса положительны, а вес с упаковкой не меньше веса без нее
+func (p *Product) validatePackage() error {
+	dimensions := []struct {
+		field string
+		value *float64
+	}{
+		{"package_length", p.PackageLength},
+		{"package_width", p.PackageWidth},
+		{"package_height", p.PackageHeight},
+	}
+
+	specified := 0
+	for _, dimension := range dimensions {
+		if dimension.value == nil {
+			continue
+		}
+		if *dimension.value <= 0 {
+			return NewValidationError(dimension.field, "размер упаковки должен быть больше нуля")
+		}
+		specified++
+	}
+	if specified != 0 && specified != len(dimensions) {
+		return NewValidationError("package", "укажите длину, ширину и высоту упаковки либо не указывайте габариты совсем")
+	}
+
+	if p.WeightWithoutPackage != nil && *p.WeightWithoutPackage <= 0 {
+		return NewValidationError("weight_without_package", "вес без упаковки должен быть больше нуля")
+	}
+	if p.WeightWithPackage != nil && *p.WeightWithPackage <= 0 {
+		return NewValidationError("weight_with_package", "вес с упаковкой должен быть больше нуля")
+	}
+	if p.WeightWithoutPackage != nil && p.WeightWithPackage != nil && *p.WeightWithPackage < *p.WeightWithoutPackage {
+		return NewValidationError("weight_with_package", "вес с упаковкой не может быть меньше веса без упаковки")
+	}
+	return nil
+}
+
+// Validate проверяет корректность строки рецептуры
+func (pm *ProductMaterial) Validate() error {
+	if pm.MaterialID <= 0 {
+		return NewValidationError("material_id", "ID материала должен быть больше нуля")
+	}
+	if pm.QuantityPerUnit <= 0 {
+		return NewValidationError("quantity_per_unit", "количество материала на единицу продукции должно быть больше нуля")
+	}
+	return nil
+}
+
+// ValidateRecipe проверяет полный набор строк рецептуры: корректность каждой строки и отсутствие повторяющихся материалов
+func ValidateRecipe(materials []ProductMaterial) error {
+	seen := make(map[int]bool, len(materials))
+	for i := range materials {
+		if err := materials[i].Validate(); err != nil {
+			return err
+		}
+		if seen[materials[i].MaterialID] {
+			return NewValidationError("material_id", fmt.Sprintf("материал с ID %d указан в рецептуре повторно", materials[i].MaterialID))
+		}
+		seen[materials[i].MaterialID] = true
+	}
 	return nil
 }
@@ -0,0 +1,95 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+)
+
+// MaxCloneQuantityChangePercent - наибольшее увеличение расхода при копировании рецептуры, %
+const MaxCloneQuantityChangePercent = 1000
+
+// ProductCloneRequest описывает копирование продукции вместе с рецептурой, например
+// для новой коллекции с другим рисунком
+type ProductCloneRequest struct {
+	Article string
+	// Name - наименование копии; пустое значение сохраняет наименование исходной продукции
+	Name string
+	// QuantityChangePercent - изменение расхода материалов и полуфабрикатов в процентах
+	QuantityChangePercent float64
+}
+
+// Validate проверяет параметры копирования
+func (r *ProductCloneRequest) Validate() error {
+	if r.Article == "" {
+		return NewValidationError("article", "артикул копии не может быть пустым")
+	}
+	if r.QuantityChangePercent <= -100 || r.QuantityChangePercent > MaxCloneQuantityChangePercent {
+		return NewValidationError("quantity_change_percent",
+			fmt.Sprintf("изменение расхода должно быть больше -100%% и не больше %d%%", MaxCloneQuantityChangePercent))
+	}
+	return nil
+}
+
+// Clone возвращает копию продукции с новым артикулом и рецептурой, расход в которой изменен
+// на QuantityChangePercent процентов. Изображения, рассчитанная себестоимость и признак архива
+// не копируются: у новой продукции свой рисунок, а себестоимость пересчитывается после сохранения
+func (p *Product) Clone(request ProductCloneRequest) (*Product, error) {
+	clone := &Product{
+		Article:                request.Article,
+		ProductTypeID:          p.ProductTypeID,
+		Name:                   p.Name,
+		Description:            p.Description,
+		MinPartnerPrice:        p.MinPartnerPrice,
+		PackageLength:          p.PackageLength,
+		PackageWidth:           p.PackageWidth,
+		PackageHeight:          p.PackageHeight,
+		WeightWithoutPackage:   p.WeightWithoutPackage,
+		WeightWithPackage:      p.WeightWithPackage,
+		QualityCertificatePath: p.QualityCertificatePath,
+		StandardNumber:         p.StandardNumber,
+		ProductionTimeHours:    p.ProductionTimeHours,
+		CostPrice:              p.CostPrice,
+		WorkshopNumber:         p.WorkshopNumber,
+		RequiredWorkers:        p.RequiredWorkers,
+		RollWidth:              p.RollWidth,
+		RollLength:             p.RollLength,
+		PatternRepeat:          p.PatternRepeat,
+		ProductType:            p.ProductType,
+	}
+	if request.Name != "" {
+		clone.Name = request.Name
+	}
+
+	factor := 1 + request.QuantityChangePercent/100
+
+	clone.Materials = make([]ProductMaterial, len(p.Materials))
+	for i, pm := range p.Materials {
+		clone.Materials[i] = ProductMaterial{
+			MaterialID:      pm.MaterialID,
+			QuantityPerUnit: scaleRecipeQuantity(pm.QuantityPerUnit, factor),
+			Material:        pm.Material,
+		}
+	}
+	if err := ValidateRecipe(clone.Materials); err != nil {
+		return nil, err
+	}
+
+	clone.Components = make([]ProductComponent, len(p.Components))
+	for i, pc := range p.Components {
+		clone.Components[i] = ProductComponent{
+			ComponentProductID: pc.ComponentProductID,
+			QuantityPerUnit:    scaleRecipeQuantity(pc.QuantityPerUnit, factor),
+			Component:          pc.Component,
+		}
+		if err := clone.Components[i].Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return clone, clone.Validate()
+}
+
+// scaleRecipeQuantity изменяет расход с точностью хранения в рецептуре (6 знаков)
+func scaleRecipeQuantity(quantity, factor float64) float64 {
+	return math.Round(quantity*factor*1e6) / 1e6
+}
@@ -0,0 +1,91 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProduct_Clone(t *testing.T) {
+	imagePath := "/uploads/products/1/image.jpg"
+	cost := 120.0
+	source := &Product{
+		ID:              1,
+		Article:         "ART001",
+		ProductTypeID:   2,
+		Name:            "Обои с розами",
+		MinPartnerPrice: 150.0,
+		ImagePath:       &imagePath,
+		CalculatedCost:  &cost,
+		Materials: []ProductMaterial{
+			{ID: 10, ProductID: 1, MaterialID: 3, QuantityPerUnit: 2.0},
+			{ID: 11, ProductID: 1, MaterialID: 4, QuantityPerUnit: 0.333333},
+		},
+		Components: []ProductComponent{
+			{ID: 20, ProductID: 1, ComponentProductID: 7, QuantityPerUnit: 1.5},
+		},
+	}
+
+	clone, err := source.Clone(ProductCloneRequest{Article: "ART002", QuantityChangePercent: 10})
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, clone.ID)
+	assert.Equal(t, "ART002", clone.Article)
+	assert.Equal(t, "Обои с розами", clone.Name)
+	assert.Equal(t, 2, clone.ProductTypeID)
+	assert.Nil(t, clone.ImagePath)
+	assert.Nil(t, clone.CalculatedCost)
+	require.Len(t, clone.Materials, 2)
+	assert.Equal(t, ProductMaterial{MaterialID: 3, QuantityPerUnit: 2.2}, clone.Materials[0])
+	assert.Equal(t, 0.366666, clone.Materials[1].QuantityPerUnit)
+	require.Len(t, clone.Components, 1)
+	assert.Equal(t, ProductComponent{ComponentProductID: 7, QuantityPerUnit: 1.65}, clone.Components[0])
+
+	// Исходная рецептура не меняется
+	assert.Equal(t, 2.0, source.Materials[0].QuantityPerUnit)
+}
+
+func TestProduct_Clone_NewNameAndNoChange(t *testing.T) {
+	source := &Product{Article: "ART001", Name: "Обои", Materials: []ProductMaterial{{MaterialID: 3, QuantityPerUnit: 2.0}}}
+
+	clone, err := source.Clone(ProductCloneRequest{Article: "ART002", Name: "Обои с лилиями"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Обои с лилиями", clone.Name)
+	assert.Equal(t, 2.0, clone.Materials[0].QuantityPerUnit)
+}
+
+func TestProduct_Clone_QuantityRoundedToZero(t *testing.T) {
+	source := &Product{Article: "ART001", Name: "Обои", Materials: []ProductMaterial{{MaterialID: 3, QuantityPerUnit: 0.000001}}}
+
+	_, err := source.Clone(ProductCloneRequest{Article: "ART002", QuantityChangePercent: -90})
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+func TestProductCloneRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		request ProductCloneRequest
+		wantErr bool
+	}{
+		{"Без изменения расхода", ProductCloneRequest{Article: "ART002"}, false},
+		{"Уменьшение расхода", ProductCloneRequest{Article: "ART002", QuantityChangePercent: -50}, false},
+		{"Пустой артикул", ProductCloneRequest{QuantityChangePercent: 10}, true},
+		{"Расход обнуляется", ProductCloneRequest{Article: "ART002", QuantityChangePercent: -100}, true},
+		{"Слишком большое увеличение", ProductCloneRequest{Article: "ART002", QuantityChangePercent: 1001}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
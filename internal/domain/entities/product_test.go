@@ -177,6 +177,92 @@ func TestProduct_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Полный паспорт продукции",
+			product: &Product{
+				Article:              "ART001",
+				Name:                 "Тестовые обои",
+				MinPartnerPrice:      100.0,
+				PackageLength:        func() *float64 { f := 1.1; return &f }(),
+				PackageWidth:         func() *float64 { f := 0.15; return &f }(),
+				PackageHeight:        func() *float64 { f := 0.15; return &f }(),
+				WeightWithoutPackage: func() *float64 { f := 1.5; return &f }(),
+				WeightWithPackage:    func() *float64 { f := 1.6; return &f }(),
+				ProductionTimeHours:  func() *float64 { f := 2.5; return &f }(),
+				CostPrice:            func() *float64 { f := 80.0; return &f }(),
+				RequiredWorkers:      func() *int { n := 3; return &n }(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Вес с упаковкой меньше веса без упаковки",
+			product: &Product{
+				Article:              "ART001",
+				Name:                 "Тестовые обои",
+				MinPartnerPrice:      100.0,
+				WeightWithoutPackage: func() *float64 { f := 1.6; return &f }(),
+				WeightWithPackage:    func() *float64 { f := 1.5; return &f }(),
+			},
+			wantErr: true,
+			errType: "VALIDATION_ERROR",
+		},
+		{
+			name: "Нулевой вес",
+			product: &Product{
+				Article:              "ART001",
+				Name:                 "Тестовые обои",
+				MinPartnerPrice:      100.0,
+				WeightWithoutPackage: func() *float64 { f := 0.0; return &f }(),
+			},
+			wantErr: true,
+			errType: "VALIDATION_ERROR",
+		},
+		{
+			name: "Отрицательный размер упаковки",
+			product: &Product{
+				Article:         "ART001",
+				Name:            "Тестовые обои",
+				MinPartnerPrice: 100.0,
+				PackageLength:   func() *float64 { f := -1.1; return &f }(),
+				PackageWidth:    func() *float64 { f := 0.15; return &f }(),
+				PackageHeight:   func() *float64 { f := 0.15; return &f }(),
+			},
+			wantErr: true,
+			errType: "VALIDATION_ERROR",
+		},
+		{
+			name: "Габариты упаковки указаны не полностью",
+			product: &Product{
+				Article:         "ART001",
+				Name:            "Тестовые обои",
+				MinPartnerPrice: 100.0,
+				PackageLength:   func() *float64 { f := 1.1; return &f }(),
+			},
+			wantErr: true,
+			errType: "VALIDATION_ERROR",
+		},
+		{
+			name: "Отрицательное время производства",
+			product: &Product{
+				Article:             "ART001",
+				Name:                "Тестовые обои",
+				MinPartnerPrice:     100.0,
+				ProductionTimeHours: func() *float64 { f := -1.0; return &f }(),
+			},
+			wantErr: true,
+			errType: "VALIDATION_ERROR",
+		},
+		{
+			name: "Нулевое количество рабочих",
+			product: &Product{
+				Article:         "ART001",
+				Name:            "Тестовые обои",
+				MinPartnerPrice: 100.0,
+				RequiredWorkers: func() *int { n := 0; return &n }(),
+			},
+			wantErr: true,
+			errType: "VALIDATION_ERROR",
+		},
 	}
 
 	for _, tt := range tests {
@@ -196,3 +282,68 @@ func TestProduct_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRecipe(t *testing.T) {
+	tests := []struct {
+		name      string
+		materials []ProductMaterial
+		wantErr   bool
+	}{
+		{
+			name: "Валидная рецептура",
+			materials: []ProductMaterial{
+				{MaterialID: 1, QuantityPerUnit: 2.0},
+				{MaterialID: 2, QuantityPerUnit: 0.5},
+			},
+			wantErr: false,
+		},
+		{
+			name:      "Пустая рецептура допустима",
+			materials: []ProductMaterial{},
+			wantErr:   false,
+		},
+		{
+			name: "Нулевой расход",
+			materials: []ProductMaterial{
+				{MaterialID: 1, QuantityPerUnit: 0},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Отрицательный расход",
+			materials: []ProductMaterial{
+				{MaterialID: 1, QuantityPerUnit: -1.0},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Не указан материал",
+			materials: []ProductMaterial{
+				{MaterialID: 0, QuantityPerUnit: 1.0},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Повторяющийся материал",
+			materials: []ProductMaterial{
+				{MaterialID: 1, QuantityPerUnit: 1.0},
+				{MaterialID: 1, QuantityPerUnit: 2.0},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRecipe(tt.materials)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				_, ok := err.(*ValidationError)
+				assert.True(t, ok, "Ожидалась ValidationError")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxProductTypeCoefficient ограничивает коэффициент типа продукции: он умножает и себестоимость,
+// и расход материалов, поэтому опечатка на порядок сразу искажает все расчеты
+const MaxProductTypeCoefficient = 100
+
+// IsArchived проверяет, выведен ли тип продукции из обращения
+func (t *ProductType) IsArchived() bool {
+	return t.ArchivedAt != nil
+}
+
+// Validate проверяет корректность типа продукции
+func (t *ProductType) Validate() error {
+	if t.Name == "" {
+		return NewValidationError("name", "название типа продукции не может быть пустым")
+	}
+	if len([]rune(t.Name)) > 100 {
+		return NewValidationError("name", "название типа продукции не может быть длиннее 100 символов")
+	}
+	if t.Coefficient <= 0 || t.Coefficient > MaxProductTypeCoefficient {
+		return NewValidationError("coefficient",
+			fmt.Sprintf("коэффициент должен быть больше 0 и не больше %d", MaxProductTypeCoefficient))
+	}
+	return nil
+}
+
+// CoefficientChange описывает изменение коэффициента типа продукции: кто и с какой даты его
+// изменил. Запись хранится в истории, чтобы по прошлым расчетам можно было понять, какой
+// коэффициент тогда действовал. OldCoefficient пуст для начального значения
+type CoefficientChange struct {
+	ID             int
+	ProductTypeID  int
+	OldCoefficient *float64
+	NewCoefficient float64
+	EffectiveFrom  time.Time
+	ChangedBy      string
+	Comment        *string
+	CreatedAt      time.Time
+}
+
+// Validate проверяет сведения об изменении коэффициента на дату today: изменение применяется
+// сразу, поэтому дата вступления в силу не может быть в будущем
+func (c *CoefficientChange) Validate(today time.Time) error {
+	if c.ChangedBy == "" {
+		return NewValidationError("changed_by", "укажите, кто изменяет коэффициент")
+	}
+	if len([]rune(c.ChangedBy)) > 100 {
+		return NewValidationError("changed_by", "имя автора изменения не может быть длиннее 100 символов")
+	}
+	if c.EffectiveFrom.IsZero() {
+		return NewValidationError("effective_from", "укажите дату вступления коэффициента в силу")
+	}
+	if truncateToDay(c.EffectiveFrom).After(truncateToDay(today)) {
+		return NewValidationError("effective_from", "коэффициент применяется сразу, дата не может быть в будущем")
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductType_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		productType ProductType
+		field       string
+	}{
+		{"корректный тип", ProductType{Name: "Флизелин", Coefficient: 1.5}, ""},
+		{"пустое название", ProductType{Coefficient: 1.5}, "name"},
+		{"длинное название", ProductType{Name: strings.Repeat("я", 101), Coefficient: 1.5}, "name"},
+		{"нулевой коэффициент", ProductType{Name: "Флизелин"}, "coefficient"},
+		{"коэффициент больше предела", ProductType{Name: "Флизелин", Coefficient: 150}, "coefficient"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.productType.Validate()
+			if tt.field == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+}
+
+func TestCoefficientChange_Validate(t *testing.T) {
+	today := time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		change CoefficientChange
+		field  string
+	}{
+		{"сегодня", CoefficientChange{ChangedBy: "Иванов", EffectiveFrom: today.Add(5 * time.Hour)}, ""},
+		{"задним числом", CoefficientChange{ChangedBy: "Иванов", EffectiveFrom: today.AddDate(0, -1, 0)}, ""},
+		{"без автора", CoefficientChange{EffectiveFrom: today}, "changed_by"},
+		{"без даты", CoefficientChange{ChangedBy: "Иванов"}, "effective_from"},
+		{"в будущем", CoefficientChange{ChangedBy: "Иванов", EffectiveFrom: today.AddDate(0, 0, 1)}, "effective_from"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.change.Validate(today)
+			if tt.field == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+}
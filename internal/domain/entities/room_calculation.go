@@ -0,0 +1,157 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// DefaultRoomReservePercent - запас рулонов по умолчанию, %
+	DefaultRoomReservePercent = 10
+	// MaxRoomReservePercent - наибольший допустимый запас рулонов, %
+	MaxRoomReservePercent = 100
+	// StripTrimAllowance - припуск полосы на подрезку у пола и потолка, м
+	StripTrimAllowance = 0.1
+)
+
+// roomEpsilon гасит погрешность вещественной арифметики при округлении вверх
+const roomEpsilon = 1e-9
+
+// RoomOpening описывает проем в стене (окно или дверь), который не оклеивается, размеры в метрах
+type RoomOpening struct {
+	Width  float64
+	Height float64
+}
+
+// Area возвращает площадь проема
+func (o RoomOpening) Area() float64 {
+	return o.Width * o.Height
+}
+
+// RoomRollRequest представляет запрос на расчет количества рулонов обоев для комнаты.
+// Периметр и высота задаются в метрах
+type RoomRollRequest struct {
+	ProductID     int
+	PartnerTypeID *int
+	Perimeter     float64
+	Height        float64
+	Openings      []RoomOpening
+	// ReservePercent - запас рулонов на брак и подгонку, %
+	ReservePercent float64
+}
+
+// WallArea возвращает площадь стен без учета проемов
+func (r *RoomRollRequest) WallArea() float64 {
+	return r.Perimeter * r.Height
+}
+
+// OpeningsArea возвращает суммарную площадь проемов
+func (r *RoomRollRequest) OpeningsArea() float64 {
+	var area float64
+	for _, opening := range r.Openings {
+		area += opening.Area()
+	}
+	return area
+}
+
+// Validate проверяет корректность размеров комнаты и проемов
+func (r *RoomRollRequest) Validate() error {
+	if r.ProductID <= 0 {
+		return NewValidationError("product_id", "выберите продукцию")
+	}
+	if r.Perimeter <= 0 {
+		return NewValidationError("perimeter", "периметр комнаты должен быть больше нуля")
+	}
+	if r.Height <= 0 {
+		return NewValidationError("height", "высота комнаты должна быть больше нуля")
+	}
+	for i, opening := range r.Openings {
+		if opening.Width <= 0 || opening.Height <= 0 {
+			return NewValidationError("openings", fmt.Sprintf("размеры проема %d должны быть больше нуля", i+1))
+		}
+		if opening.Height > r.Height {
+			return NewValidationError("openings", fmt.Sprintf("проем %d выше комнаты", i+1))
+		}
+	}
+	if r.OpeningsArea() >= r.WallArea() {
+		return NewValidationError("openings", "площадь проемов должна быть меньше площади стен")
+	}
+	if r.ReservePercent < 0 || r.ReservePercent > MaxRoomReservePercent {
+		return NewValidationError("reserve_percent",
+			fmt.Sprintf("запас должен быть от 0 до %d%%", MaxRoomReservePercent))
+	}
+	return nil
+}
+
+// RoomRollCalculation - результат расчета обоев для комнаты
+type RoomRollCalculation struct {
+	Product      *Product
+	WallArea     float64
+	OpeningsArea float64
+	// StripLength - длина полосы с припуском, кратная раппорту
+	StripLength   float64
+	StripsPerRoll int
+	Strips        int
+	Rolls         int
+	ReserveRolls  int
+	TotalRolls    int
+	// RollPrice - цена рулона для партнера по правилам ценообразования
+	RollPrice   float64
+	TotalCost   float64
+	PricingRule *PricingRule
+}
+
+// HasRollSize проверяет, заданы ли ширина и длина рулона, без которых расчет комнаты невозможен
+func (p *Product) HasRollSize() bool {
+	return p.RollWidth != nil && *p.RollWidth > 0 && p.RollLength != nil && *p.RollLength > 0
+}
+
+// CalculateRoomRolls рассчитывает раскрой рулонов для комнаты. Полоса режется на высоту
+// стены с припуском и удлиняется до целого числа раппортов, чтобы рисунок совпал на стыках.
+// Проемы уменьшают число полос пропорционально своей площади, запас округляется до рулона вверх
+func CalculateRoomRolls(request *RoomRollRequest, rollWidth, rollLength, patternRepeat float64) (*RoomRollCalculation, error) {
+	if rollWidth <= 0 || rollLength <= 0 {
+		return nil, NewValidationError("roll_width", "ширина и длина рулона должны быть больше нуля")
+	}
+	if patternRepeat < 0 {
+		return nil, NewValidationError("pattern_repeat", "раппорт не может быть отрицательным")
+	}
+
+	stripLength := request.Height + StripTrimAllowance
+	if patternRepeat > 0 {
+		stripLength = ceilRoom(stripLength/patternRepeat) * patternRepeat
+	}
+
+	stripsPerRoll := int(math.Floor(rollLength/stripLength + roomEpsilon))
+	if stripsPerRoll == 0 {
+		return nil, NewBusinessError("ROLL_TOO_SHORT",
+			fmt.Sprintf("из рулона длиной %.2f м не выходит ни одной полосы длиной %.2f м", rollLength, stripLength))
+	}
+
+	calculation := &RoomRollCalculation{
+		WallArea:      request.WallArea(),
+		OpeningsArea:  request.OpeningsArea(),
+		StripLength:   stripLength,
+		StripsPerRoll: stripsPerRoll,
+	}
+
+	coveredWidth := request.Perimeter - calculation.OpeningsArea/request.Height
+	calculation.Strips = int(ceilRoom(coveredWidth / rollWidth))
+	calculation.Rolls = int(ceilRoom(float64(calculation.Strips) / float64(stripsPerRoll)))
+	calculation.ReserveRolls = int(ceilRoom(float64(calculation.Rolls) * request.ReservePercent / 100))
+	calculation.TotalRolls = calculation.Rolls + calculation.ReserveRolls
+
+	return calculation, nil
+}
+
+// ApplyPrice заполняет стоимость рулонов по цене для партнера
+func (c *RoomRollCalculation) ApplyPrice(price *PriceCalculation) {
+	c.RollPrice = price.Price
+	c.TotalCost = math.Round(price.Price*float64(c.TotalRolls)*100) / 100
+	c.PricingRule = price.Rule
+}
+
+// ceilRoom округляет вверх, не добавляя лишней единицы из-за погрешности вычислений
+func ceilRoom(value float64) float64 {
+	return math.Ceil(value - roomEpsilon)
+}
@@ -0,0 +1,100 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateRoomRolls(t *testing.T) {
+	request := &RoomRollRequest{
+		ProductID:      1,
+		Perimeter:      14,
+		Height:         2.7,
+		Openings:       []RoomOpening{{Width: 1.5, Height: 1.4}},
+		ReservePercent: 10,
+	}
+
+	calculation, err := CalculateRoomRolls(request, 0.53, 10.05, 0.64)
+
+	// Полоса 2.8 м удлиняется до 5 раппортов = 3.2 м, из рулона выходит 3 полосы;
+	// проем 2.1 м² на высоте 2.7 м убирает 0.78 м периметра: 13.22 / 0.53 = 25 полос
+	require.NoError(t, err)
+	assert.InDelta(t, 37.8, calculation.WallArea, 1e-9)
+	assert.InDelta(t, 2.1, calculation.OpeningsArea, 1e-9)
+	assert.InDelta(t, 3.2, calculation.StripLength, 1e-9)
+	assert.Equal(t, 3, calculation.StripsPerRoll)
+	assert.Equal(t, 25, calculation.Strips)
+	assert.Equal(t, 9, calculation.Rolls)
+	assert.Equal(t, 1, calculation.ReserveRolls)
+	assert.Equal(t, 10, calculation.TotalRolls)
+}
+
+func TestCalculateRoomRolls_ExactRepeatNotRoundedUp(t *testing.T) {
+	request := &RoomRollRequest{ProductID: 1, Perimeter: 5.3, Height: 2.5}
+
+	calculation, err := CalculateRoomRolls(request, 0.53, 10.4, 0.65)
+
+	// 2.6 м - ровно 4 раппорта, лишний раппорт и лишняя полоса не добавляются
+	require.NoError(t, err)
+	assert.InDelta(t, 2.6, calculation.StripLength, 1e-9)
+	assert.Equal(t, 4, calculation.StripsPerRoll)
+	assert.Equal(t, 10, calculation.Strips)
+	assert.Equal(t, 3, calculation.Rolls)
+	assert.Equal(t, 0, calculation.ReserveRolls)
+}
+
+func TestCalculateRoomRolls_RollTooShort(t *testing.T) {
+	request := &RoomRollRequest{ProductID: 1, Perimeter: 10, Height: 2.7}
+
+	calculation, err := CalculateRoomRolls(request, 0.53, 2.5, 0)
+
+	assert.Nil(t, calculation)
+	var businessErr *BusinessError
+	require.ErrorAs(t, err, &businessErr)
+	assert.Equal(t, "ROLL_TOO_SHORT", businessErr.Code)
+}
+
+func TestRoomRollCalculation_ApplyPrice(t *testing.T) {
+	rule := &PricingRule{Name: "Дилеры"}
+	calculation := &RoomRollCalculation{TotalRolls: 3}
+
+	calculation.ApplyPrice(&PriceCalculation{Cost: 800, Price: 1000.1, Rule: rule})
+
+	assert.Equal(t, 1000.1, calculation.RollPrice)
+	assert.Equal(t, 3000.3, calculation.TotalCost)
+	assert.Equal(t, rule, calculation.PricingRule)
+}
+
+func TestRoomRollRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		request RoomRollRequest
+		field   string
+	}{
+		{"без продукции", RoomRollRequest{Perimeter: 10, Height: 2.5}, "product_id"},
+		{"нулевой периметр", RoomRollRequest{ProductID: 1, Height: 2.5}, "perimeter"},
+		{"нулевая высота", RoomRollRequest{ProductID: 1, Perimeter: 10}, "height"},
+		{"проем без ширины", RoomRollRequest{ProductID: 1, Perimeter: 10, Height: 2.5,
+			Openings: []RoomOpening{{Height: 2}}}, "openings"},
+		{"проем выше комнаты", RoomRollRequest{ProductID: 1, Perimeter: 10, Height: 2.5,
+			Openings: []RoomOpening{{Width: 1, Height: 2.6}}}, "openings"},
+		{"проемы больше стен", RoomRollRequest{ProductID: 1, Perimeter: 2, Height: 2.5,
+			Openings: []RoomOpening{{Width: 2, Height: 2.5}}}, "openings"},
+		{"отрицательный запас", RoomRollRequest{ProductID: 1, Perimeter: 10, Height: 2.5, ReservePercent: -5}, "reserve_percent"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+
+	valid := RoomRollRequest{ProductID: 1, Perimeter: 10, Height: 2.5, Openings: []RoomOpening{{Width: 0.9, Height: 2}}}
+	assert.NoError(t, valid.Validate())
+}
@@ -0,0 +1,96 @@
+package entities
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SearchEntityType определяет тип сущности в результатах поиска
+type SearchEntityType string
+
+const (
+	// SearchEntityProduct - продукция
+	SearchEntityProduct SearchEntityType = "product"
+	// SearchEntityMaterial - материал
+	SearchEntityMaterial SearchEntityType = "material"
+	// SearchEntityPartner - партнер
+	SearchEntityPartner SearchEntityType = "partner"
+)
+
+const (
+	// MinSearchQueryLength - минимальная длина поискового запроса в символах
+	MinSearchQueryLength = 2
+	// DefaultSearchLimit - количество результатов каждого типа по умолчанию
+	DefaultSearchLimit = 10
+	// MaxSearchLimit - максимальное количество результатов каждого типа
+	MaxSearchLimit = 50
+)
+
+// SearchQuery представляет поисковый запрос оператора
+type SearchQuery struct {
+	Text  string
+	Limit int
+}
+
+// Normalize обрезает пробелы и ограничивает количество результатов
+func (q *SearchQuery) Normalize() {
+	q.Text = strings.TrimSpace(q.Text)
+	if q.Limit < 1 {
+		q.Limit = DefaultSearchLimit
+	}
+	if q.Limit > MaxSearchLimit {
+		q.Limit = MaxSearchLimit
+	}
+}
+
+// Validate проверяет, что в запросе есть по чему искать
+func (q *SearchQuery) Validate() error {
+	if len([]rune(strings.TrimSpace(q.Text))) < MinSearchQueryLength {
+		return NewValidationError("q", "поисковый запрос должен содержать не менее 2 символов")
+	}
+	if len(q.Terms()) == 0 {
+		return NewValidationError("q", "поисковый запрос должен содержать буквы или цифры")
+	}
+	return nil
+}
+
+// Terms разбивает запрос на слова из букв и цифр в нижнем регистре
+func (q *SearchQuery) Terms() []string {
+	return strings.FieldsFunc(strings.ToLower(q.Text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// TSQuery строит выражение для to_tsquery: все слова обязательны и ищутся по префиксу,
+// поэтому фрагменты вроде "флизелин бел" находят "Флизелиновые обои белые"
+func (q *SearchQuery) TSQuery() string {
+	terms := q.Terms()
+	for i, term := range terms {
+		terms[i] = term + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+// SearchResult представляет найденную запись
+type SearchResult struct {
+	EntityType SearchEntityType
+	ID         int
+	Title      string
+	Code       string // артикул или ИНН
+	Category   string // тип продукции, материала или партнера
+	Snippet    string // фрагмент текста с найденными словами
+	Rank       float64
+}
+
+// SearchResults представляет результаты поиска, сгруппированные по типу сущности
+type SearchResults struct {
+	Query     string
+	Products  []SearchResult
+	Materials []SearchResult
+	Partners  []SearchResult
+}
+
+// Total возвращает общее количество найденных записей
+func (r *SearchResults) Total() int {
+	return len(r.Products) + len(r.Materials) + len(r.Partners)
+}
@@ -0,0 +1,45 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchQuery_TSQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "Фрагменты слов", text: "флизелин бел", expected: "флизелин:* & бел:*"},
+		{name: "Регистр и лишние пробелы", text: "  Флизелин   БЕЛЫЙ ", expected: "флизелин:* & белый:*"},
+		{name: "Спецсимволы tsquery отбрасываются", text: "винил & !(обои):*", expected: "винил:* & обои:*"},
+		{name: "Артикул с дефисом", text: "ART-001", expected: "art:* & 001:*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := SearchQuery{Text: tt.text}
+			assert.Equal(t, tt.expected, query.TSQuery())
+		})
+	}
+}
+
+func TestSearchQuery_Validate(t *testing.T) {
+	assert.NoError(t, (&SearchQuery{Text: "обои"}).Validate())
+	assert.Error(t, (&SearchQuery{Text: " а "}).Validate())
+	assert.Error(t, (&SearchQuery{Text: "&& !!"}).Validate())
+}
+
+func TestSearchQuery_Normalize(t *testing.T) {
+	query := SearchQuery{Text: "  обои ", Limit: 1000}
+	query.Normalize()
+
+	assert.Equal(t, "обои", query.Text)
+	assert.Equal(t, MaxSearchLimit, query.Limit)
+
+	query = SearchQuery{Text: "обои"}
+	query.Normalize()
+	assert.Equal(t, DefaultSearchLimit, query.Limit)
+}
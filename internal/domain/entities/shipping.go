@@ -0,0 +1,286 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Параметры европоддона, которые используются, если размеры поддона не заданы
+const (
+	// DefaultPalletLength - длина поддона, м
+	DefaultPalletLength = 1.2
+	// DefaultPalletWidth - ширина поддона, м
+	DefaultPalletWidth = 0.8
+	// DefaultPalletHeight - собственная высота поддона, м
+	DefaultPalletHeight = 0.144
+	// DefaultPalletWeight - собственный вес поддона, кг
+	DefaultPalletWeight = 25
+	// DefaultPalletMaxHeight - наибольшая высота поддона вместе с грузом, м
+	DefaultPalletMaxHeight = 1.8
+	// DefaultPalletMaxLoad - наибольший вес груза на поддоне, кг
+	DefaultPalletMaxLoad = 1000
+)
+
+// shippingEpsilon гасит погрешность вещественной арифметики при раскладке упаковок
+const shippingEpsilon = 1e-9
+
+// PalletSpec описывает поддон: размеры в метрах, вес в килограммах
+type PalletSpec struct {
+	Length float64
+	Width  float64
+	// Height - собственная высота поддона
+	Height float64
+	// Weight - собственный вес поддона
+	Weight float64
+	// MaxHeight - наибольшая высота поддона вместе с грузом
+	MaxHeight float64
+	// MaxLoad - наибольший вес груза без учета веса поддона
+	MaxLoad float64
+}
+
+// DefaultPalletSpec возвращает параметры европоддона 1200 × 800 мм
+func DefaultPalletSpec() PalletSpec {
+	return PalletSpec{
+		Length:    DefaultPalletLength,
+		Width:     DefaultPalletWidth,
+		Height:    DefaultPalletHeight,
+		Weight:    DefaultPalletWeight,
+		MaxHeight: DefaultPalletMaxHeight,
+		MaxLoad:   DefaultPalletMaxLoad,
+	}
+}
+
+// Validate проверяет корректность размеров и ограничений поддона
+func (p PalletSpec) Validate() error {
+	if p.Length <= 0 || p.Width <= 0 {
+		return NewValidationError("pallet_length", "длина и ширина поддона должны быть больше нуля")
+	}
+	if p.Height < 0 {
+		return NewValidationError("pallet_height", "высота поддона не может быть отрицательной")
+	}
+	if p.Weight < 0 {
+		return NewValidationError("pallet_weight", "вес поддона не может быть отрицательным")
+	}
+	if p.MaxHeight <= p.Height {
+		return NewValidationError("pallet_max_height", "наибольшая высота с грузом должна быть больше высоты поддона")
+	}
+	if p.MaxLoad <= 0 {
+		return NewValidationError("pallet_max_load", "наибольший вес груза должен быть больше нуля")
+	}
+	return nil
+}
+
+// FloorArea возвращает площадь, которую поддон занимает в кузове
+func (p PalletSpec) FloorArea() float64 {
+	return p.Length * p.Width
+}
+
+// ShipmentLine представляет позицию отгрузки: одна единица продукции - одна упаковка
+type ShipmentLine struct {
+	ProductID int
+	Quantity  int
+}
+
+// ShipmentRequest представляет запрос на расчет отгрузки списка продукции на поддонах
+type ShipmentRequest struct {
+	Lines  []ShipmentLine
+	Pallet PalletSpec
+}
+
+// Validate проверяет позиции отгрузки и параметры поддона
+func (r *ShipmentRequest) Validate() error {
+	if len(r.Lines) == 0 {
+		return NewValidationError("lines", "добавьте хотя бы одну позицию")
+	}
+	for i, line := range r.Lines {
+		if line.ProductID <= 0 {
+			return NewValidationError("lines", fmt.Sprintf("в позиции %d не выбрана продукция", i+1))
+		}
+		if line.Quantity <= 0 {
+			return NewValidationError("lines", fmt.Sprintf("количество в позиции %d должно быть больше нуля", i+1))
+		}
+	}
+	return r.Pallet.Validate()
+}
+
+// MergedLines объединяет позиции одной продукции, сохраняя порядок первого появления
+func (r *ShipmentRequest) MergedLines() []ShipmentLine {
+	merged := make([]ShipmentLine, 0, len(r.Lines))
+	index := make(map[int]int, len(r.Lines))
+	for _, line := range r.Lines {
+		if i, ok := index[line.ProductID]; ok {
+			merged[i].Quantity += line.Quantity
+			continue
+		}
+		index[line.ProductID] = len(merged)
+		merged = append(merged, line)
+	}
+	return merged
+}
+
+// ShipmentItem - продукция отгрузки с количеством упаковок
+type ShipmentItem struct {
+	Product  *Product
+	Quantity int
+}
+
+// ShipmentLineLayout - раскладка упаковок одной продукции по поддонам
+type ShipmentLineLayout struct {
+	Product  *Product
+	Quantity int
+	// PackagesPerLayer - упаковок в одном ярусе при лучшем повороте упаковки
+	PackagesPerLayer int
+	// Layers - наибольшее число ярусов по высоте
+	Layers int
+	// PackagesPerPallet - упаковок на полном поддоне с учетом ограничения по весу
+	PackagesPerPallet  int
+	Pallets            int
+	LastPalletPackages int
+	// StackHeight - высота самого высокого поддона вместе с грузом, м
+	StackHeight float64
+	// Volume - объем упаковок, м³
+	Volume float64
+	// Weight - вес упаковок, кг
+	Weight float64
+}
+
+// ShipmentPlan - результат расчета отгрузки
+type ShipmentPlan struct {
+	Pallet   PalletSpec
+	Lines    []ShipmentLineLayout
+	Packages int
+	Pallets  int
+	// CargoVolume - объем упаковок, м³
+	CargoVolume float64
+	// LoadedVolume - объем, занимаемый поддонами вместе с грузом, м³
+	LoadedVolume float64
+	// FloorArea - площадь пола кузова под поддоны, м²
+	FloorArea   float64
+	CargoWeight float64
+	// PalletsWeight - собственный вес поддонов, кг
+	PalletsWeight float64
+	// GrossWeight - вес брутто: упаковки вместе с поддонами, кг
+	GrossWeight float64
+}
+
+// HasPackageData проверяет, заданы ли габариты упаковки и вес, без которых продукцию
+// нельзя разложить по поддонам
+func (p *Product) HasPackageData() bool {
+	return p.PackageLength != nil && p.PackageWidth != nil && p.PackageHeight != nil && p.packageWeight() > 0
+}
+
+// packageWeight возвращает вес единицы продукции в упаковке; если он не задан -
+// вес без упаковки
+func (p *Product) packageWeight() float64 {
+	if p.WeightWithPackage != nil {
+		return *p.WeightWithPackage
+	}
+	if p.WeightWithoutPackage != nil {
+		return *p.WeightWithoutPackage
+	}
+	return 0
+}
+
+// PlanShipment раскладывает упаковки по поддонам. Каждая продукция укладывается на отдельные
+// поддоны: упаковка стоит на основании и может поворачиваться на 90°, число ярусов ограничено
+// наибольшей высотой, число упаковок - грузоподъемностью поддона
+func PlanShipment(pallet PalletSpec, items []ShipmentItem) (*ShipmentPlan, error) {
+	var missing []string
+	for _, item := range items {
+		if !item.Product.HasPackageData() {
+			missing = append(missing, item.Product.Article)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, NewBusinessError("PACKAGE_DATA_MISSING",
+			"не заданы габариты или вес упаковки продукции: "+strings.Join(missing, ", "))
+	}
+
+	plan := &ShipmentPlan{Pallet: pallet, Lines: make([]ShipmentLineLayout, 0, len(items))}
+	for _, item := range items {
+		layout, err := layoutShipmentItem(pallet, item)
+		if err != nil {
+			return nil, err
+		}
+		plan.Lines = append(plan.Lines, *layout)
+		plan.Packages += layout.Quantity
+		plan.Pallets += layout.Pallets
+		plan.CargoVolume += layout.Volume
+		plan.CargoWeight += layout.Weight
+		plan.LoadedVolume += loadedVolume(pallet, layout)
+	}
+
+	plan.FloorArea = roundShipping(float64(plan.Pallets) * pallet.FloorArea())
+	plan.PalletsWeight = roundShipping(float64(plan.Pallets) * pallet.Weight)
+	plan.GrossWeight = roundShipping(plan.CargoWeight + plan.PalletsWeight)
+	plan.CargoVolume = roundShipping(plan.CargoVolume)
+	plan.CargoWeight = roundShipping(plan.CargoWeight)
+	plan.LoadedVolume = roundShipping(plan.LoadedVolume)
+	return plan, nil
+}
+
+// layoutShipmentItem рассчитывает раскладку упаковок одной продукции
+func layoutShipmentItem(pallet PalletSpec, item ShipmentItem) (*ShipmentLineLayout, error) {
+	product := item.Product
+	length, width, height := *product.PackageLength, *product.PackageWidth, *product.PackageHeight
+	weight := product.packageWeight()
+
+	perLayer := fitCount(pallet.Length, length) * fitCount(pallet.Width, width)
+	if rotated := fitCount(pallet.Length, width) * fitCount(pallet.Width, length); rotated > perLayer {
+		perLayer = rotated
+	}
+	layers := fitCount(pallet.MaxHeight-pallet.Height, height)
+
+	perPallet := perLayer * layers
+	if byWeight := fitCount(pallet.MaxLoad, weight); byWeight < perPallet {
+		perPallet = byWeight
+	}
+	if perPallet == 0 {
+		return nil, NewBusinessError("PACKAGE_DOES_NOT_FIT",
+			fmt.Sprintf("упаковка продукции %s (%.3f × %.3f × %.3f м, %.2f кг) не помещается на поддон",
+				product.Article, length, width, height, weight))
+	}
+
+	pallets := (item.Quantity + perPallet - 1) / perPallet
+	layout := &ShipmentLineLayout{
+		Product:            product,
+		Quantity:           item.Quantity,
+		PackagesPerLayer:   perLayer,
+		Layers:             layers,
+		PackagesPerPallet:  perPallet,
+		Pallets:            pallets,
+		LastPalletPackages: item.Quantity - (pallets-1)*perPallet,
+		Volume:             roundShipping(float64(item.Quantity) * length * width * height),
+		Weight:             roundShipping(float64(item.Quantity) * weight),
+	}
+	layout.StackHeight = roundShipping(stackHeight(pallet, layout, min(item.Quantity, perPallet)))
+	return layout, nil
+}
+
+// stackHeight возвращает высоту поддона, на который уложено packages упаковок
+func stackHeight(pallet PalletSpec, layout *ShipmentLineLayout, packages int) float64 {
+	layers := (packages + layout.PackagesPerLayer - 1) / layout.PackagesPerLayer
+	return pallet.Height + float64(layers)*(*layout.Product.PackageHeight)
+}
+
+// loadedVolume возвращает объем поддонов продукции вместе с грузом: полные поддоны
+// и последний, возможно неполный
+func loadedVolume(pallet PalletSpec, layout *ShipmentLineLayout) float64 {
+	full := float64(layout.Pallets-1) * stackHeight(pallet, layout, layout.PackagesPerPallet)
+	last := stackHeight(pallet, layout, layout.LastPalletPackages)
+	return pallet.FloorArea() * (full + last)
+}
+
+// fitCount возвращает, сколько отрезков size помещается в length
+func fitCount(length, size float64) int {
+	if size <= 0 {
+		return 0
+	}
+	return int(math.Floor(length/size + shippingEpsilon))
+}
+
+// roundShipping округляет объемы и веса отгрузки до третьего знака
+func roundShipping(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
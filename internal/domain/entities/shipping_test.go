@@ -0,0 +1,136 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestPackedProduct возвращает продукцию с упаковкой 0.55 × 0.12 × 0.12 м весом 1.6 кг
+func newTestPackedProduct() *Product {
+	length, width, height, weight := 0.55, 0.12, 0.12, 1.6
+	return &Product{ID: 1, Article: "WP-001", Name: "Обои флизелиновые",
+		PackageLength: &length, PackageWidth: &width, PackageHeight: &height, WeightWithPackage: &weight}
+}
+
+func TestPlanShipment_EuroPallet(t *testing.T) {
+	product := newTestPackedProduct()
+
+	plan, err := PlanShipment(DefaultPalletSpec(), []ShipmentItem{{Product: product, Quantity: 300}})
+	require.NoError(t, err)
+	require.Len(t, plan.Lines, 1)
+
+	// Повернутая упаковка: 1.2/0.12 × 0.8/0.55 = 10 × 1 уступает 1.2/0.55 × 0.8/0.12 = 2 × 6
+	line := plan.Lines[0]
+	assert.Equal(t, 12, line.PackagesPerLayer)
+	assert.Equal(t, 13, line.Layers)
+	assert.Equal(t, 156, line.PackagesPerPallet)
+	assert.Equal(t, 2, line.Pallets)
+	assert.Equal(t, 144, line.LastPalletPackages)
+	assert.Equal(t, 1.704, line.StackHeight)
+
+	assert.Equal(t, 300, plan.Packages)
+	assert.Equal(t, 2, plan.Pallets)
+	assert.Equal(t, 2.376, plan.CargoVolume)
+	assert.Equal(t, 480.0, plan.CargoWeight)
+	assert.Equal(t, 50.0, plan.PalletsWeight)
+	assert.Equal(t, 530.0, plan.GrossWeight)
+	assert.Equal(t, 1.92, plan.FloorArea)
+	// 0.96 м² × (1.704 м + 1.584 м)
+	assert.Equal(t, 3.156, plan.LoadedVolume)
+}
+
+func TestPlanShipment_LimitedByWeight(t *testing.T) {
+	product := newTestPackedProduct()
+	pallet := DefaultPalletSpec()
+	pallet.MaxLoad = 100
+
+	plan, err := PlanShipment(pallet, []ShipmentItem{{Product: product, Quantity: 100}})
+	require.NoError(t, err)
+
+	assert.Equal(t, 62, plan.Lines[0].PackagesPerPallet)
+	assert.Equal(t, 2, plan.Pallets)
+	assert.Equal(t, 38, plan.Lines[0].LastPalletPackages)
+}
+
+func TestPlanShipment_SeparatePalletsPerProduct(t *testing.T) {
+	first := newTestPackedProduct()
+	second := newTestPackedProduct()
+	second.ID, second.Article = 2, "WP-002"
+
+	plan, err := PlanShipment(DefaultPalletSpec(), []ShipmentItem{
+		{Product: first, Quantity: 10},
+		{Product: second, Quantity: 10},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, plan.Pallets)
+	assert.Equal(t, 20, plan.Packages)
+	assert.Equal(t, 82.0, plan.GrossWeight)
+}
+
+func TestPlanShipment_PackageDataMissing(t *testing.T) {
+	product := newTestPackedProduct()
+	incomplete := &Product{ID: 2, Article: "WP-002"}
+
+	_, err := PlanShipment(DefaultPalletSpec(), []ShipmentItem{
+		{Product: product, Quantity: 1},
+		{Product: incomplete, Quantity: 1},
+	})
+
+	var businessErr *BusinessError
+	require.ErrorAs(t, err, &businessErr)
+	assert.Equal(t, "PACKAGE_DATA_MISSING", businessErr.Code)
+	assert.Contains(t, businessErr.Message, "WP-002")
+	assert.NotContains(t, businessErr.Message, "WP-001")
+}
+
+func TestPlanShipment_PackageDoesNotFit(t *testing.T) {
+	product := newTestPackedProduct()
+	length := 1.5
+	product.PackageLength = &length
+
+	_, err := PlanShipment(DefaultPalletSpec(), []ShipmentItem{{Product: product, Quantity: 1}})
+
+	var businessErr *BusinessError
+	require.ErrorAs(t, err, &businessErr)
+	assert.Equal(t, "PACKAGE_DOES_NOT_FIT", businessErr.Code)
+}
+
+func TestShipmentRequest_Validate(t *testing.T) {
+	pallet := DefaultPalletSpec()
+	lowPallet := DefaultPalletSpec()
+	lowPallet.MaxHeight = 0.1
+
+	tests := []struct {
+		name    string
+		request ShipmentRequest
+		field   string
+	}{
+		{"без позиций", ShipmentRequest{Pallet: pallet}, "lines"},
+		{"без продукции", ShipmentRequest{Lines: []ShipmentLine{{Quantity: 1}}, Pallet: pallet}, "lines"},
+		{"нулевое количество", ShipmentRequest{Lines: []ShipmentLine{{ProductID: 1}}, Pallet: pallet}, "lines"},
+		{"низкий поддон", ShipmentRequest{Lines: []ShipmentLine{{ProductID: 1, Quantity: 1}}, Pallet: lowPallet}, "pallet_max_height"},
+		{"без размеров поддона", ShipmentRequest{Lines: []ShipmentLine{{ProductID: 1, Quantity: 1}}}, "pallet_length"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+}
+
+func TestShipmentRequest_MergedLines(t *testing.T) {
+	request := ShipmentRequest{Lines: []ShipmentLine{
+		{ProductID: 2, Quantity: 5},
+		{ProductID: 1, Quantity: 3},
+		{ProductID: 2, Quantity: 7},
+	}}
+
+	assert.Equal(t, []ShipmentLine{{ProductID: 2, Quantity: 12}, {ProductID: 1, Quantity: 3}}, request.MergedLines())
+}
@@ -0,0 +1,83 @@
+package entities
+
+import (
+	"math"
+	"time"
+)
+
+// IsLowStock проверяет, что остаток материала опустился до минимального или ниже.
+// Материалы без минимального остатка и архивные материалы не отслеживаются
+func (m *Material) IsLowStock() bool {
+	return m.MinStockQuantity > 0 && !m.IsArchived() && m.StockQuantity <= m.MinStockQuantity
+}
+
+// LowStockItem представляет материал с остатком не выше минимального и рекомендацию по закупке
+type LowStockItem struct {
+	Material *Material
+	// Shortage - сколько не хватает до минимального остатка
+	Shortage float64
+	// SuggestedPackages - рекомендуемое количество упаковок к закупке
+	SuggestedPackages int
+	// SuggestedQuantity - рекомендуемое количество к закупке в единице учета материала
+	SuggestedQuantity float64
+	// SuggestedCost - стоимость рекомендуемой закупки по текущей цене материала
+	SuggestedCost float64
+}
+
+// NewLowStockItem рассчитывает рекомендацию по закупке: недостающее до минимума количество
+// округляется до целых упаковок так, чтобы остаток после закупки стал выше минимального.
+// Если остаток ровно равен минимальному, рекомендуется одна упаковка
+func NewLowStockItem(material *Material) LowStockItem {
+	shortage := roundMovement(material.MinStockQuantity - material.StockQuantity)
+
+	packages := 1
+	if material.PackageQuantity > 0 {
+		packages = int(math.Floor(roundMovement(shortage/material.PackageQuantity))) + 1
+	}
+	quantity := roundMovement(float64(packages) * material.PackageQuantity)
+
+	return LowStockItem{
+		Material:          material,
+		Shortage:          shortage,
+		SuggestedPackages: packages,
+		SuggestedQuantity: quantity,
+		SuggestedCost:     math.Round(quantity*material.CostPerUnit*100) / 100,
+	}
+}
+
+// StockNotification представляет уведомление о снижении остатка материала до минимального.
+// Уведомление открыто, пока остаток не восстановится (ResolvedAt)
+type StockNotification struct {
+	ID               int
+	MaterialID       int
+	StockQuantity    float64
+	MinStockQuantity float64
+	CreatedAt        time.Time
+	AcknowledgedAt   *time.Time
+	ResolvedAt       *time.Time
+
+	// Связанные данные
+	Material *Material
+}
+
+// IsOpen проверяет, что остаток материала еще не восстановлен
+func (n *StockNotification) IsOpen() bool {
+	return n.ResolvedAt == nil
+}
+
+// NewStockNotification создает уведомление по текущему остатку материала
+func NewStockNotification(material *Material) *StockNotification {
+	return &StockNotification{
+		MaterialID:       material.ID,
+		StockQuantity:    material.StockQuantity,
+		MinStockQuantity: material.MinStockQuantity,
+		Material:         material,
+	}
+}
+
+// StockCheckResult представляет итог проверки остатков: новые уведомления и количество
+// закрытых уведомлений по материалам, остаток которых восстановился
+type StockCheckResult struct {
+	Created  []StockNotification
+	Resolved int
+}
@@ -0,0 +1,72 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaterial_IsLowStock(t *testing.T) {
+	archivedAt := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		material Material
+		expected bool
+	}{
+		{"ниже минимума", Material{StockQuantity: 5, MinStockQuantity: 10}, true},
+		{"ровно минимум", Material{StockQuantity: 10, MinStockQuantity: 10}, true},
+		{"выше минимума", Material{StockQuantity: 10.001, MinStockQuantity: 10}, false},
+		{"минимум не задан", Material{StockQuantity: 0, MinStockQuantity: 0}, false},
+		{"архивный материал", Material{StockQuantity: 0, MinStockQuantity: 10, ArchivedAt: &archivedAt}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.material.IsLowStock())
+		})
+	}
+}
+
+func TestNewLowStockItem_RoundsUpToPackages(t *testing.T) {
+	tests := []struct {
+		name     string
+		stock    float64
+		packages int
+		quantity float64
+		shortage float64
+		cost     float64
+	}{
+		{"нехватка не кратна упаковке", 25, 2, 50, 35, 625},
+		{"нехватка кратна упаковке", 35, 2, 50, 25, 625},
+		{"остаток равен минимуму", 60, 1, 25, 0, 312.5},
+		{"остаток нулевой", 0, 3, 75, 60, 937.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			material := &Material{StockQuantity: tt.stock, MinStockQuantity: 60, PackageQuantity: 25, CostPerUnit: 12.5}
+
+			item := NewLowStockItem(material)
+
+			assert.Equal(t, tt.shortage, item.Shortage)
+			assert.Equal(t, tt.packages, item.SuggestedPackages)
+			assert.Equal(t, tt.quantity, item.SuggestedQuantity)
+			assert.Equal(t, tt.cost, item.SuggestedCost)
+			assert.Greater(t, tt.stock+item.SuggestedQuantity, material.MinStockQuantity)
+		})
+	}
+}
+
+func TestNewLowStockItem_FractionalPackage(t *testing.T) {
+	// Подготовка данных: погрешность деления не добавляет лишнюю упаковку
+	material := &Material{StockQuantity: 0.7, MinStockQuantity: 1, PackageQuantity: 0.1, CostPerUnit: 10}
+
+	item := NewLowStockItem(material)
+
+	assert.Equal(t, 0.3, item.Shortage)
+	assert.Equal(t, 4, item.SuggestedPackages)
+	assert.Equal(t, 0.4, item.SuggestedQuantity)
+	assert.Equal(t, 4.0, item.SuggestedCost)
+}
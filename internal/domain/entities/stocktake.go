@@ -0,0 +1,216 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// StocktakeStatus определяет состояние инвентаризации
+type StocktakeStatus string
+
+const (
+	// StocktakeOpen - идет пересчет, фактические остатки можно вводить и исправлять
+	StocktakeOpen StocktakeStatus = "open"
+	// StocktakeClosed - инвентаризация закрыта, расхождения проведены в складском журнале
+	StocktakeClosed StocktakeStatus = "closed"
+	// StocktakeCancelled - инвентаризация отменена без изменения остатков
+	StocktakeCancelled StocktakeStatus = "cancelled"
+)
+
+// Label возвращает название состояния инвентаризации для интерфейса
+func (s StocktakeStatus) Label() string {
+	switch s {
+	case StocktakeOpen:
+		return "Идет пересчет"
+	case StocktakeClosed:
+		return "Закрыта"
+	case StocktakeCancelled:
+		return "Отменена"
+	}
+	return string(s)
+}
+
+// Stocktake представляет инвентаризацию материалов. При открытии по каждому материалу
+// фиксируется учетный остаток и цена; фактический остаток вводится по мере пересчета
+type Stocktake struct {
+	ID        int
+	Status    StocktakeStatus
+	Note      *string
+	CreatedAt time.Time
+	ClosedAt  *time.Time
+
+	// Связанные данные
+	Items []StocktakeItem
+}
+
+// IsOpen проверяет, что пересчет еще идет
+func (s *Stocktake) IsOpen() bool {
+	return s.Status == StocktakeOpen
+}
+
+// EnsureOpen возвращает ошибку, если инвентаризация уже закрыта или отменена
+func (s *Stocktake) EnsureOpen() error {
+	if !s.IsOpen() {
+		return NewBusinessError("STOCKTAKE_NOT_OPEN",
+			fmt.Sprintf("инвентаризация №%d в состоянии «%s», изменения невозможны", s.ID, s.Status.Label()))
+	}
+	return nil
+}
+
+// ValidateCounts проверяет введенные фактические остатки: инвентаризация открыта, материал
+// входит в нее и указан один раз, остаток не отрицательный. Остатки округляются до точности
+// складского журнала
+func (s *Stocktake) ValidateCounts(counts []StocktakeCount) error {
+	if err := s.EnsureOpen(); err != nil {
+		return err
+	}
+	if len(counts) == 0 {
+		return NewValidationError("counts", "не указаны фактические остатки")
+	}
+
+	included := make(map[int]bool, len(s.Items))
+	for _, item := range s.Items {
+		included[item.MaterialID] = true
+	}
+
+	seen := make(map[int]bool, len(counts))
+	for i := range counts {
+		count := &counts[i]
+		if !included[count.MaterialID] {
+			return NewValidationError("material_id",
+				fmt.Sprintf("материал с ID %d не входит в инвентаризацию", count.MaterialID))
+		}
+		if seen[count.MaterialID] {
+			return NewValidationError("material_id",
+				fmt.Sprintf("материал с ID %d указан несколько раз", count.MaterialID))
+		}
+		seen[count.MaterialID] = true
+
+		if count.Quantity != nil {
+			quantity := roundMovement(*count.Quantity)
+			if quantity < 0 {
+				return NewValidationError("counted_quantity", "фактический остаток не может быть отрицательным")
+			}
+			count.Quantity = &quantity
+		}
+	}
+	return nil
+}
+
+// StocktakeItem представляет строку инвентаризации. ExpectedQuantity и UnitCost - учетный
+// остаток и цена материала на момент открытия; CountedQuantity - фактический остаток,
+// пока материал не пересчитан, nil
+type StocktakeItem struct {
+	ID               int
+	StocktakeID      int
+	MaterialID       int
+	ExpectedQuantity float64
+	CountedQuantity  *float64
+	UnitCost         float64
+	CountedAt        *time.Time
+	MovementID       *int
+
+	// Связанные данные
+	Material *Material
+}
+
+// IsCounted проверяет, что фактический остаток материала введен
+func (i *StocktakeItem) IsCounted() bool {
+	return i.CountedQuantity != nil
+}
+
+// Variance возвращает расхождение фактического остатка с учетным: излишек положительный,
+// недостача отрицательная. Для непересчитанного материала расхождения нет
+func (i *StocktakeItem) Variance() float64 {
+	if !i.IsCounted() {
+		return 0
+	}
+	return roundMovement(*i.CountedQuantity - i.ExpectedQuantity)
+}
+
+// VarianceValue возвращает стоимость расхождения по цене на момент открытия инвентаризации
+func (i *StocktakeItem) VarianceValue() float64 {
+	return roundMoney(i.Variance() * i.UnitCost)
+}
+
+// Adjustment создает движение, проводящее расхождение: приход излишка или списание
+// недостачи. Движение меняет текущий остаток на величину расхождения, поэтому движения,
+// проведенные после открытия инвентаризации, сохраняются. Если расхождения нет, возвращает nil
+func (i *StocktakeItem) Adjustment() *MaterialMovement {
+	if !i.IsCounted() {
+		return nil
+	}
+
+	movement := NewStockAdjustment(i.MaterialID, i.ExpectedQuantity, *i.CountedQuantity,
+		"Инвентаризация №"+strconv.Itoa(i.StocktakeID))
+	if movement == nil {
+		return nil
+	}
+	stocktakeID := i.StocktakeID
+	movement.ReferenceID = &stocktakeID
+	movement.ReferenceType = ReferenceStocktake
+	return movement
+}
+
+// StocktakeCount представляет введенный фактический остаток материала. Quantity nil
+// отменяет ранее введенный пересчет
+type StocktakeCount struct {
+	MaterialID int
+	Quantity   *float64
+}
+
+// StocktakeReport представляет ведомость расхождений инвентаризации в стоимостном выражении
+type StocktakeReport struct {
+	Stocktake *Stocktake
+	// Variances - пересчитанные материалы с расхождением, по убыванию стоимости расхождения
+	Variances    []StocktakeItem
+	ItemsTotal   int
+	ItemsCounted int
+	// SurplusValue - стоимость излишков, ShortageValue - стоимость недостач (положительная)
+	SurplusValue  float64
+	ShortageValue float64
+	// NetValue - итоговое расхождение: излишки за вычетом недостач
+	NetValue float64
+}
+
+// NewStocktakeReport рассчитывает ведомость расхождений по строкам инвентаризации
+func NewStocktakeReport(stocktake *Stocktake) *StocktakeReport {
+	report := &StocktakeReport{
+		Stocktake:  stocktake,
+		Variances:  []StocktakeItem{},
+		ItemsTotal: len(stocktake.Items),
+	}
+
+	for _, item := range stocktake.Items {
+		if !item.IsCounted() {
+			continue
+		}
+		report.ItemsCounted++
+		if item.Variance() == 0 {
+			continue
+		}
+
+		report.Variances = append(report.Variances, item)
+		if value := item.VarianceValue(); value > 0 {
+			report.SurplusValue += value
+		} else {
+			report.ShortageValue -= value
+		}
+	}
+
+	sort.SliceStable(report.Variances, func(i, j int) bool {
+		return math.Abs(report.Variances[i].VarianceValue()) > math.Abs(report.Variances[j].VarianceValue())
+	})
+	report.SurplusValue = roundMoney(report.SurplusValue)
+	report.ShortageValue = roundMoney(report.ShortageValue)
+	report.NetValue = roundMoney(report.SurplusValue - report.ShortageValue)
+	return report
+}
+
+// roundMoney округляет сумму до копеек
+func roundMoney(value float64) float64 {
+	return math.Round(value*100) / 100
+}
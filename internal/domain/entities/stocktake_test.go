@@ -0,0 +1,117 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
+
+func TestStocktakeItem_VarianceAndAdjustment(t *testing.T) {
+	tests := []struct {
+		name          string
+		item          StocktakeItem
+		variance      float64
+		varianceValue float64
+		movementType  MovementType
+		quantity      float64
+	}{
+		{"не пересчитан", StocktakeItem{ExpectedQuantity: 10, UnitCost: 100}, 0, 0, "", 0},
+		{"без расхождения", StocktakeItem{ExpectedQuantity: 10, CountedQuantity: floatPtr(10), UnitCost: 100}, 0, 0, "", 0},
+		{"излишек", StocktakeItem{ExpectedQuantity: 10, CountedQuantity: floatPtr(12.5), UnitCost: 99.99}, 2.5, 249.98, MovementIncome, 2.5},
+		{"недостача", StocktakeItem{ExpectedQuantity: 10, CountedQuantity: floatPtr(9.7), UnitCost: 150}, -0.3, -45, MovementWriteOff, 0.3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := tt.item
+			item.StocktakeID = 4
+			item.MaterialID = 7
+
+			assert.Equal(t, tt.variance, item.Variance())
+			assert.Equal(t, tt.varianceValue, item.VarianceValue())
+
+			movement := item.Adjustment()
+			if tt.movementType == "" {
+				assert.Nil(t, movement)
+				return
+			}
+			require.NotNil(t, movement)
+			assert.Equal(t, 7, movement.MaterialID)
+			assert.Equal(t, tt.movementType, movement.Type)
+			assert.Equal(t, tt.quantity, movement.Quantity)
+			assert.Equal(t, ReferenceStocktake, movement.ReferenceType)
+			require.NotNil(t, movement.ReferenceID)
+			assert.Equal(t, 4, *movement.ReferenceID)
+			require.NotNil(t, movement.Note)
+			assert.Equal(t, "Инвентаризация №4", *movement.Note)
+			require.NoError(t, movement.Validate())
+		})
+	}
+}
+
+func TestStocktake_ValidateCounts(t *testing.T) {
+	stocktake := Stocktake{ID: 3, Status: StocktakeOpen, Items: []StocktakeItem{{MaterialID: 1}, {MaterialID: 2}}}
+
+	tests := []struct {
+		name   string
+		counts []StocktakeCount
+		field  string
+	}{
+		{"нет остатков", nil, "counts"},
+		{"материал вне инвентаризации", []StocktakeCount{{MaterialID: 5, Quantity: floatPtr(1)}}, "material_id"},
+		{"материал дважды", []StocktakeCount{{MaterialID: 1, Quantity: floatPtr(1)}, {MaterialID: 1, Quantity: floatPtr(2)}}, "material_id"},
+		{"отрицательный остаток", []StocktakeCount{{MaterialID: 2, Quantity: floatPtr(-1)}}, "counted_quantity"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := stocktake.ValidateCounts(tt.counts)
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+
+	// Частичный пересчет и отмена пересчета допустимы, остаток округляется
+	counts := []StocktakeCount{{MaterialID: 2, Quantity: floatPtr(3.14159)}, {MaterialID: 1}}
+	require.NoError(t, stocktake.ValidateCounts(counts))
+	assert.Equal(t, 3.142, *counts[0].Quantity)
+	assert.Nil(t, counts[1].Quantity)
+
+	// Закрытую инвентаризацию изменить нельзя
+	stocktake.Status = StocktakeClosed
+	var businessErr *BusinessError
+	require.ErrorAs(t, stocktake.ValidateCounts(counts), &businessErr)
+	assert.Equal(t, "STOCKTAKE_NOT_OPEN", businessErr.Code)
+}
+
+func TestNewStocktakeReport(t *testing.T) {
+	// Подготовка данных: один материал не пересчитан, один без расхождения
+	stocktake := &Stocktake{ID: 1, Status: StocktakeOpen, Items: []StocktakeItem{
+		{MaterialID: 1, ExpectedQuantity: 10, CountedQuantity: floatPtr(11), UnitCost: 20},
+		{MaterialID: 2, ExpectedQuantity: 5, UnitCost: 1000},
+		{MaterialID: 3, ExpectedQuantity: 8, CountedQuantity: floatPtr(8), UnitCost: 50},
+		{MaterialID: 4, ExpectedQuantity: 4, CountedQuantity: floatPtr(3.5), UnitCost: 300},
+		{MaterialID: 5, ExpectedQuantity: 0, CountedQuantity: floatPtr(0.1), UnitCost: 0.15},
+	}}
+
+	// Выполнение
+	report := NewStocktakeReport(stocktake)
+
+	// Проверки: расхождения упорядочены по убыванию стоимости
+	assert.Equal(t, 5, report.ItemsTotal)
+	assert.Equal(t, 4, report.ItemsCounted)
+	require.Len(t, report.Variances, 3)
+	assert.Equal(t, 4, report.Variances[0].MaterialID)
+	assert.Equal(t, 1, report.Variances[1].MaterialID)
+	assert.Equal(t, 5, report.Variances[2].MaterialID)
+	assert.Equal(t, 20.02, report.SurplusValue)
+	assert.Equal(t, 150.0, report.ShortageValue)
+	assert.Equal(t, -129.98, report.NetValue)
+}
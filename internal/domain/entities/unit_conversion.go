@@ -0,0 +1,232 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// UnitCategory определяет физическую величину единицы измерения
+type UnitCategory string
+
+const (
+	UnitCategoryLength UnitCategory = "length"
+	UnitCategoryArea   UnitCategory = "area"
+	UnitCategoryVolume UnitCategory = "volume"
+	UnitCategoryMass   UnitCategory = "mass"
+	UnitCategoryPiece  UnitCategory = "piece"
+	UnitCategoryRoll   UnitCategory = "roll"
+)
+
+// unitCategoryLabels содержит названия категорий для интерфейса
+var unitCategoryLabels = map[UnitCategory]string{
+	UnitCategoryLength: "Длина",
+	UnitCategoryArea:   "Площадь",
+	UnitCategoryVolume: "Объем",
+	UnitCategoryMass:   "Масса",
+	UnitCategoryPiece:  "Штуки",
+	UnitCategoryRoll:   "Рулоны",
+}
+
+// convertedQuantityScale ограничивает точность пересчитанного количества, чтобы 1500 г
+// читались как 1.5 кг, а не 1.5000000000000002
+const convertedQuantityScale = 1e6
+
+// IsValid проверяет, что категория известна
+func (c UnitCategory) IsValid() bool {
+	_, ok := unitCategoryLabels[c]
+	return ok
+}
+
+// Label возвращает название категории; для единицы без категории - пустую строку
+func (c UnitCategory) Label() string {
+	return unitCategoryLabels[c]
+}
+
+// Convertible сообщает, можно ли пересчитывать единицу в другие единицы ее категории
+func (u *MeasurementUnit) Convertible() bool {
+	return u.Category.IsValid() && u.BaseFactor > 0
+}
+
+// SameCategory сообщает, пересчитываются ли единицы друг в друга без сведений о материале
+func (u *MeasurementUnit) SameCategory(other *MeasurementUnit) bool {
+	if u.ID != 0 && u.ID == other.ID {
+		return true
+	}
+	return u.Convertible() && other.Convertible() && u.Category == other.Category
+}
+
+// NewIncompatibleUnitsError создает ошибку пересчета между несовместимыми единицами
+func NewIncompatibleUnitsError(from, to *MeasurementUnit) *BusinessError {
+	return NewBusinessError("INCOMPATIBLE_UNITS",
+		fmt.Sprintf("единицы %s и %s несовместимы", from.Abbreviation, to.Abbreviation))
+}
+
+// ConvertUnits пересчитывает количество между единицами одной категории
+func ConvertUnits(quantity float64, from, to *MeasurementUnit) (float64, error) {
+	if !from.SameCategory(to) {
+		return 0, NewIncompatibleUnitsError(from, to)
+	}
+	if from.ID != 0 && from.ID == to.ID {
+		return quantity, nil
+	}
+	return roundConvertedQuantity(quantity * from.BaseFactor / to.BaseFactor), nil
+}
+
+func roundConvertedQuantity(quantity float64) float64 {
+	return math.Round(quantity*convertedQuantityScale) / convertedQuantityScale
+}
+
+// MaterialUnitConversion задает пересчет единицы в единицу учета конкретного материала:
+// 1 Unit = Factor единиц материала. Для рулонов множитель можно задать размерами рулона (м)
+type MaterialUnitConversion struct {
+	ID         int
+	MaterialID int
+	UnitID     int
+	Factor     float64
+	RollWidth  *float64
+	RollLength *float64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+
+	Unit *MeasurementUnit
+}
+
+// ResolveFactor проверяет пересчет относительно единицы учета материала и, если заданы размеры
+// рулона, вычисляет по ним множитель: площадь рулона для материала в единицах площади,
+// длину рулона - для материала в единицах длины
+func (c *MaterialUnitConversion) ResolveFactor(materialUnit *MeasurementUnit) error {
+	if c.Unit == nil {
+		return NewValidationError("unit_id", "единица измерения не указана")
+	}
+	if c.Unit.SameCategory(materialUnit) {
+		return NewValidationError("unit_id",
+			fmt.Sprintf("единица %s пересчитывается в %s автоматически", c.Unit.Abbreviation, materialUnit.Abbreviation))
+	}
+
+	if c.RollWidth == nil && c.RollLength == nil {
+		if c.Factor <= 0 {
+			return NewValidationError("factor", "множитель пересчета должен быть больше нуля")
+		}
+		return nil
+	}
+
+	if c.Unit.Category != UnitCategoryRoll {
+		return NewValidationError("unit_id", "размеры рулона задаются только для пересчета рулонов")
+	}
+	if c.RollLength == nil || *c.RollLength <= 0 {
+		return NewValidationError("roll_length", "длина рулона должна быть больше нуля")
+	}
+	if !materialUnit.Convertible() {
+		return NewValidationError("unit_id",
+			fmt.Sprintf("единица материала %s не пересчитывается по размерам рулона", materialUnit.Abbreviation))
+	}
+
+	switch materialUnit.Category {
+	case UnitCategoryArea:
+		if c.RollWidth == nil || *c.RollWidth <= 0 {
+			return NewValidationError("roll_width", "ширина рулона должна быть больше нуля")
+		}
+		c.Factor = roundConvertedQuantity(*c.RollWidth * *c.RollLength / materialUnit.BaseFactor)
+	case UnitCategoryLength:
+		c.Factor = roundConvertedQuantity(*c.RollLength / materialUnit.BaseFactor)
+	default:
+		return NewValidationError("unit_id",
+			fmt.Sprintf("материал в единицах %s не пересчитывается по размерам рулона", materialUnit.Abbreviation))
+	}
+	return nil
+}
+
+// UnitEquivalent показывает, сколько единиц учета материала содержится в одной единице Unit
+type UnitEquivalent struct {
+	Unit   MeasurementUnit
+	Factor float64
+}
+
+// MaterialUnitSet описывает единицы, в которых можно выразить количество материала:
+// единицы категории единицы учета и категорий, для которых заданы пересчеты материала
+type MaterialUnitSet struct {
+	Unit        MeasurementUnit
+	Conversions []MaterialUnitConversion
+}
+
+// unitFactor возвращает количество единиц учета материала в одной единице unit
+func (s *MaterialUnitSet) unitFactor(unit *MeasurementUnit) (float64, bool) {
+	if unit.SameCategory(&s.Unit) {
+		if unit.ID == s.Unit.ID {
+			return 1, true
+		}
+		return unit.BaseFactor / s.Unit.BaseFactor, true
+	}
+
+	for _, conversion := range s.Conversions {
+		if conversion.Unit == nil || !unit.SameCategory(conversion.Unit) {
+			continue
+		}
+		if unit.ID == conversion.Unit.ID {
+			return conversion.Factor, true
+		}
+		return unit.BaseFactor / conversion.Unit.BaseFactor * conversion.Factor, true
+	}
+	return 0, false
+}
+
+// Supports сообщает, можно ли выразить количество материала в единице unit
+func (s *MaterialUnitSet) Supports(unit *MeasurementUnit) bool {
+	_, ok := s.unitFactor(unit)
+	return ok
+}
+
+// Convert пересчитывает количество материала из единицы from в единицу to
+func (s *MaterialUnitSet) Convert(quantity float64, from, to *MeasurementUnit) (float64, error) {
+	fromFactor, ok := s.unitFactor(from)
+	if !ok {
+		return 0, NewIncompatibleUnitsError(from, &s.Unit)
+	}
+	toFactor, ok := s.unitFactor(to)
+	if !ok {
+		return 0, NewIncompatibleUnitsError(&s.Unit, to)
+	}
+	if from.ID != 0 && from.ID == to.ID {
+		return quantity, nil
+	}
+	return roundConvertedQuantity(quantity * fromFactor / toFactor), nil
+}
+
+// FromMaterialUnit пересчитывает количество из единицы учета материала в единицу to
+func (s *MaterialUnitSet) FromMaterialUnit(quantity float64, to *MeasurementUnit) (float64, error) {
+	return s.Convert(quantity, &s.Unit, to)
+}
+
+// Equivalents возвращает единицы из units, в которых можно выразить материал, кроме единицы учета
+func (s *MaterialUnitSet) Equivalents(units []MeasurementUnit) []UnitEquivalent {
+	result := []UnitEquivalent{}
+	for _, unit := range units {
+		if unit.ID == s.Unit.ID {
+			continue
+		}
+		if factor, ok := s.unitFactor(&unit); ok {
+			result = append(result, UnitEquivalent{Unit: unit, Factor: roundConvertedQuantity(factor)})
+		}
+	}
+	return result
+}
+
+// UnitConversion описывает пересчет количества; MaterialID задан для пересчета между категориями
+type UnitConversion struct {
+	MaterialID *int
+	Quantity   float64
+	From       MeasurementUnit
+	To         MeasurementUnit
+	Result     float64
+}
+
+// StockInUnit представляет остаток материала, выраженный в выбранной единице
+type StockInUnit struct {
+	MaterialID        int
+	StockQuantity     float64
+	ReservedQuantity  float64
+	AvailableQuantity float64
+	MinStockQuantity  float64
+	Unit              MeasurementUnit
+}
@@ -0,0 +1,158 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testUnitMetre       = MeasurementUnit{ID: 1, Name: "метр", Abbreviation: "м", Category: UnitCategoryLength, BaseFactor: 1}
+	testUnitKilogram    = MeasurementUnit{ID: 2, Name: "килограмм", Abbreviation: "кг", Category: UnitCategoryMass, BaseFactor: 1}
+	testUnitSquareMetre = MeasurementUnit{ID: 5, Name: "квадратный метр", Abbreviation: "м²", Category: UnitCategoryArea, BaseFactor: 1}
+	testUnitRoll        = MeasurementUnit{ID: 6, Name: "рулон", Abbreviation: "рул", Category: UnitCategoryRoll, BaseFactor: 1}
+	testUnitMillimetre  = MeasurementUnit{ID: 7, Name: "миллиметр", Abbreviation: "мм", Category: UnitCategoryLength, BaseFactor: 0.001}
+	testUnitGram        = MeasurementUnit{ID: 8, Name: "грамм", Abbreviation: "г", Category: UnitCategoryMass, BaseFactor: 0.001}
+)
+
+func assertIncompatibleUnits(t *testing.T, err error) {
+	t.Helper()
+	var businessErr *BusinessError
+	require.ErrorAs(t, err, &businessErr)
+	assert.Equal(t, "INCOMPATIBLE_UNITS", businessErr.Code)
+}
+
+func TestConvertUnits(t *testing.T) {
+	grams, err := ConvertUnits(1.5, &testUnitKilogram, &testUnitGram)
+	require.NoError(t, err)
+	assert.Equal(t, 1500.0, grams)
+
+	kilograms, err := ConvertUnits(300, &testUnitGram, &testUnitKilogram)
+	require.NoError(t, err)
+	assert.Equal(t, 0.3, kilograms)
+
+	metres, err := ConvertUnits(530, &testUnitMillimetre, &testUnitMetre)
+	require.NoError(t, err)
+	assert.Equal(t, 0.53, metres)
+
+	_, err = ConvertUnits(1, &testUnitKilogram, &testUnitMetre)
+	assertIncompatibleUnits(t, err)
+}
+
+func TestConvertUnits_UncategorizedUnit(t *testing.T) {
+	box := MeasurementUnit{ID: 20, Name: "коробка", Abbreviation: "кор"}
+
+	quantity, err := ConvertUnits(3, &box, &box)
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, quantity)
+
+	other := MeasurementUnit{ID: 21, Name: "ящик", Abbreviation: "ящ"}
+	_, err = ConvertUnits(3, &box, &other)
+	assertIncompatibleUnits(t, err)
+}
+
+func TestMaterialUnitConversion_ResolveFactor(t *testing.T) {
+	width, length := 1.06, 10.05
+
+	// Рулон бумаги-основы в м² - по площади рулона
+	conversion := MaterialUnitConversion{Unit: &testUnitRoll, RollWidth: &width, RollLength: &length}
+	require.NoError(t, conversion.ResolveFactor(&testUnitSquareMetre))
+	assert.Equal(t, 10.653, conversion.Factor)
+
+	// Рулон материала в метрах - по длине рулона
+	conversion = MaterialUnitConversion{Unit: &testUnitRoll, RollLength: &length}
+	require.NoError(t, conversion.ResolveFactor(&testUnitMetre))
+	assert.Equal(t, 10.05, conversion.Factor)
+
+	// Явный множитель
+	conversion = MaterialUnitConversion{Unit: &testUnitKilogram, Factor: 4}
+	require.NoError(t, conversion.ResolveFactor(&testUnitSquareMetre))
+	assert.Equal(t, 4.0, conversion.Factor)
+}
+
+func TestMaterialUnitConversion_ResolveFactor_Invalid(t *testing.T) {
+	width, length, zero := 1.06, 10.05, 0.0
+
+	tests := []struct {
+		name         string
+		conversion   MaterialUnitConversion
+		materialUnit MeasurementUnit
+		field        string
+	}{
+		{"без единицы", MaterialUnitConversion{Factor: 2}, testUnitSquareMetre, "unit_id"},
+		{"та же категория", MaterialUnitConversion{Unit: &testUnitGram, Factor: 1000}, testUnitKilogram, "unit_id"},
+		{"без множителя", MaterialUnitConversion{Unit: &testUnitRoll}, testUnitSquareMetre, "factor"},
+		{"размеры не для рулона", MaterialUnitConversion{Unit: &testUnitKilogram, RollLength: &length}, testUnitSquareMetre, "unit_id"},
+		{"без длины рулона", MaterialUnitConversion{Unit: &testUnitRoll, RollWidth: &width}, testUnitSquareMetre, "roll_length"},
+		{"нулевая длина", MaterialUnitConversion{Unit: &testUnitRoll, RollLength: &zero}, testUnitMetre, "roll_length"},
+		{"без ширины для площади", MaterialUnitConversion{Unit: &testUnitRoll, RollLength: &length}, testUnitSquareMetre, "roll_width"},
+		{"рулон в килограммах", MaterialUnitConversion{Unit: &testUnitRoll, RollWidth: &width, RollLength: &length}, testUnitKilogram, "unit_id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.conversion.ResolveFactor(&tt.materialUnit)
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+}
+
+func TestMaterialUnitSet_Convert(t *testing.T) {
+	// Бумага-основа учитывается в м², закупается рулонами по 10.653 м²
+	set := MaterialUnitSet{
+		Unit:        testUnitSquareMetre,
+		Conversions: []MaterialUnitConversion{{UnitID: testUnitRoll.ID, Factor: 10.653, Unit: &testUnitRoll}},
+	}
+
+	squareMetres, err := set.Convert(3, &testUnitRoll, &testUnitSquareMetre)
+	require.NoError(t, err)
+	assert.Equal(t, 31.959, squareMetres)
+
+	rolls, err := set.FromMaterialUnit(21.306, &testUnitRoll)
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, rolls)
+
+	same, err := set.Convert(5, &testUnitRoll, &testUnitRoll)
+	require.NoError(t, err)
+	assert.Equal(t, 5.0, same)
+
+	_, err = set.FromMaterialUnit(1, &testUnitKilogram)
+	assertIncompatibleUnits(t, err)
+	assert.False(t, set.Supports(&testUnitKilogram))
+	assert.True(t, set.Supports(&testUnitRoll))
+}
+
+func TestMaterialUnitSet_ConvertThroughCategory(t *testing.T) {
+	// Клей учитывается в кг; 1 шт (банка) = 0.5 кг, поэтому банку можно выразить и в граммах
+	piece := MeasurementUnit{ID: 3, Name: "штука", Abbreviation: "шт", Category: UnitCategoryPiece, BaseFactor: 1}
+	set := MaterialUnitSet{
+		Unit:        testUnitKilogram,
+		Conversions: []MaterialUnitConversion{{UnitID: piece.ID, Factor: 0.5, Unit: &piece}},
+	}
+
+	grams, err := set.Convert(4, &piece, &testUnitGram)
+	require.NoError(t, err)
+	assert.Equal(t, 2000.0, grams)
+
+	pieces, err := set.Convert(1250, &testUnitGram, &piece)
+	require.NoError(t, err)
+	assert.Equal(t, 2.5, pieces)
+}
+
+func TestMaterialUnitSet_Equivalents(t *testing.T) {
+	set := MaterialUnitSet{
+		Unit:        testUnitSquareMetre,
+		Conversions: []MaterialUnitConversion{{UnitID: testUnitRoll.ID, Factor: 10.653, Unit: &testUnitRoll}},
+	}
+
+	equivalents := set.Equivalents([]MeasurementUnit{
+		testUnitMetre, testUnitKilogram, testUnitSquareMetre, testUnitRoll, testUnitGram,
+	})
+
+	require.Len(t, equivalents, 1)
+	assert.Equal(t, testUnitRoll.ID, equivalents[0].Unit.ID)
+	assert.Equal(t, 10.653, equivalents[0].Factor)
+}
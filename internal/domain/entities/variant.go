@@ -0,0 +1,139 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxVariantSuffixLength - наибольшая длина суффикса артикула варианта
+const MaxVariantSuffixLength = 20
+
+// ProductVariant представляет вариант (расцветку) продукции. Вариант наследует паспорт
+// и рецептуру базовой продукции и отличается суффиксом артикула, цветом, изображением
+// и расходом отдельных материалов
+type ProductVariant struct {
+	ID            int
+	ProductID     int
+	ArticleSuffix string
+	ColorName     string
+	ImagePath     *string
+	ThumbnailPath *string
+	PreviewPath   *string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+
+	// Overrides - замены строк базовой рецептуры
+	Overrides []VariantMaterialOverride
+}
+
+// VariantMaterialOverride задает расход материала в варианте вместо расхода в базовой рецептуре.
+// Нулевой расход исключает материал, материал вне базовой рецептуры добавляется
+type VariantMaterialOverride struct {
+	ID              int
+	VariantID       int
+	MaterialID      int
+	QuantityPerUnit float64
+	Material        *Material
+}
+
+// VariantArticle возвращает артикул варианта по артикулу продукции и суффиксу
+func VariantArticle(productArticle, suffix string) string {
+	return productArticle + "-" + suffix
+}
+
+// Images возвращает ссылки на изображение варианта и его уменьшенные копии
+func (v *ProductVariant) Images() ImagePaths {
+	return ImagePaths{ImagePath: v.ImagePath, ThumbnailPath: v.ThumbnailPath, PreviewPath: v.PreviewPath}
+}
+
+// Override возвращает замену расхода материала или nil, если материал не переопределен
+func (v *ProductVariant) Override(materialID int) *VariantMaterialOverride {
+	for i := range v.Overrides {
+		if v.Overrides[i].MaterialID == materialID {
+			return &v.Overrides[i]
+		}
+	}
+	return nil
+}
+
+// Validate проверяет корректность варианта и замен рецептуры
+func (v *ProductVariant) Validate() error {
+	if v.ArticleSuffix == "" {
+		return NewValidationError("article_suffix", "суффикс артикула не может быть пустым")
+	}
+	if len([]rune(v.ArticleSuffix)) > MaxVariantSuffixLength {
+		return NewValidationError("article_suffix",
+			fmt.Sprintf("суффикс артикула не может быть длиннее %d символов", MaxVariantSuffixLength))
+	}
+	if strings.ContainsAny(v.ArticleSuffix, " \t") {
+		return NewValidationError("article_suffix", "суффикс артикула не может содержать пробелы")
+	}
+	if v.ColorName == "" {
+		return NewValidationError("color_name", "название цвета не может быть пустым")
+	}
+
+	seen := make(map[int]bool, len(v.Overrides))
+	for _, override := range v.Overrides {
+		if override.MaterialID <= 0 {
+			return NewValidationError("material_id", "ID материала должен быть больше нуля")
+		}
+		if override.QuantityPerUnit < 0 {
+			return NewValidationError("quantity_per_unit", "расход материала не может быть отрицательным")
+		}
+		if seen[override.MaterialID] {
+			return NewValidationError("material_id",
+				fmt.Sprintf("материал с ID %d переопределен повторно", override.MaterialID))
+		}
+		seen[override.MaterialID] = true
+	}
+	return nil
+}
+
+// Resolve возвращает продукцию варианта: паспорт и полуфабрикаты базовой продукции,
+// артикул и изображение варианта и рецептуру с примененными заменами. Для базовой продукции
+// должны быть загружены материалы рецептуры. Сохраненная себестоимость базовой продукции
+// к варианту не относится и не переносится
+func (v *ProductVariant) Resolve(base *Product) *Product {
+	resolved := *base
+	resolved.Article = VariantArticle(base.Article, v.ArticleSuffix)
+	resolved.Name = fmt.Sprintf("%s (%s)", base.Name, v.ColorName)
+	resolved.CalculatedCost = nil
+	resolved.CostCalculatedAt = nil
+	resolved.CalculatedPrice = nil
+	resolved.AppliedPricingRule = nil
+	resolved.Variants = nil
+	if v.ImagePath != nil {
+		resolved.ImagePath, resolved.ThumbnailPath, resolved.PreviewPath = v.ImagePath, v.ThumbnailPath, v.PreviewPath
+	}
+
+	resolved.Materials = make([]ProductMaterial, 0, len(base.Materials)+len(v.Overrides))
+	inBase := make(map[int]bool, len(base.Materials))
+	for _, pm := range base.Materials {
+		inBase[pm.MaterialID] = true
+		if override := v.Override(pm.MaterialID); override != nil {
+			if override.QuantityPerUnit == 0 {
+				continue
+			}
+			pm.QuantityPerUnit = override.QuantityPerUnit
+			if override.Material != nil {
+				pm.Material = override.Material
+			}
+		}
+		resolved.Materials = append(resolved.Materials, pm)
+	}
+
+	for _, override := range v.Overrides {
+		if inBase[override.MaterialID] || override.QuantityPerUnit == 0 {
+			continue
+		}
+		resolved.Materials = append(resolved.Materials, ProductMaterial{
+			ProductID:       base.ID,
+			MaterialID:      override.MaterialID,
+			QuantityPerUnit: override.QuantityPerUnit,
+			Material:        override.Material,
+		})
+	}
+
+	return &resolved
+}
@@ -0,0 +1,99 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductVariant_Resolve(t *testing.T) {
+	baseImage := "/uploads/products/1/base.jpg"
+	variantImage := "/uploads/variants/5/blue.jpg"
+	cost := 120.0
+	blue := &Material{ID: 9, Article: "PAINT-BLUE"}
+	base := &Product{
+		ID:             1,
+		Article:        "ART001",
+		Name:           "Обои с розами",
+		ImagePath:      &baseImage,
+		CalculatedCost: &cost,
+		Materials: []ProductMaterial{
+			{ProductID: 1, MaterialID: 3, QuantityPerUnit: 2.0},
+			{ProductID: 1, MaterialID: 4, QuantityPerUnit: 0.5},
+			{ProductID: 1, MaterialID: 5, QuantityPerUnit: 1.0},
+		},
+		Components: []ProductComponent{{ProductID: 1, ComponentProductID: 7, QuantityPerUnit: 1}},
+	}
+	variant := &ProductVariant{
+		ID:            5,
+		ProductID:     1,
+		ArticleSuffix: "BL",
+		ColorName:     "Синий",
+		ImagePath:     &variantImage,
+		Overrides: []VariantMaterialOverride{
+			{MaterialID: 4, QuantityPerUnit: 0.7},
+			{MaterialID: 5, QuantityPerUnit: 0},
+			{MaterialID: 9, QuantityPerUnit: 0.2, Material: blue},
+		},
+	}
+
+	resolved := variant.Resolve(base)
+
+	assert.Equal(t, "ART001-BL", resolved.Article)
+	assert.Equal(t, "Обои с розами (Синий)", resolved.Name)
+	assert.Equal(t, &variantImage, resolved.ImagePath)
+	assert.Nil(t, resolved.CalculatedCost)
+	assert.Len(t, resolved.Components, 1)
+	require.Len(t, resolved.Materials, 3)
+	assert.Equal(t, 2.0, resolved.Materials[0].QuantityPerUnit)
+	assert.Equal(t, 0.7, resolved.Materials[1].QuantityPerUnit)
+	assert.Equal(t, ProductMaterial{ProductID: 1, MaterialID: 9, QuantityPerUnit: 0.2, Material: blue}, resolved.Materials[2])
+
+	// Базовая продукция не меняется
+	assert.Equal(t, "ART001", base.Article)
+	assert.Equal(t, 0.5, base.Materials[1].QuantityPerUnit)
+	assert.Equal(t, &cost, base.CalculatedCost)
+}
+
+func TestProductVariant_Resolve_WithoutOverridesKeepsImage(t *testing.T) {
+	baseImage := "/uploads/products/1/base.jpg"
+	base := &Product{Article: "ART001", Name: "Обои", ImagePath: &baseImage}
+	variant := &ProductVariant{ArticleSuffix: "GR", ColorName: "Серый"}
+
+	resolved := variant.Resolve(base)
+
+	assert.Equal(t, &baseImage, resolved.ImagePath)
+	assert.NotNil(t, resolved.Materials)
+	assert.Empty(t, resolved.Materials)
+}
+
+func TestProductVariant_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		variant ProductVariant
+		field   string
+	}{
+		{"пустой суффикс", ProductVariant{ColorName: "Синий"}, "article_suffix"},
+		{"суффикс с пробелом", ProductVariant{ArticleSuffix: "B L", ColorName: "Синий"}, "article_suffix"},
+		{"длинный суффикс", ProductVariant{ArticleSuffix: "ОЧЕНЬ-ДЛИННЫЙ-СУФФИКС", ColorName: "Синий"}, "article_suffix"},
+		{"без цвета", ProductVariant{ArticleSuffix: "BL"}, "color_name"},
+		{"отрицательный расход", ProductVariant{ArticleSuffix: "BL", ColorName: "Синий",
+			Overrides: []VariantMaterialOverride{{MaterialID: 3, QuantityPerUnit: -1}}}, "quantity_per_unit"},
+		{"повтор материала", ProductVariant{ArticleSuffix: "BL", ColorName: "Синий",
+			Overrides: []VariantMaterialOverride{{MaterialID: 3, QuantityPerUnit: 1}, {MaterialID: 3}}}, "material_id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.variant.Validate()
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+
+	valid := ProductVariant{ArticleSuffix: "BL", ColorName: "Синий", Overrides: []VariantMaterialOverride{{MaterialID: 3}}}
+	assert.NoError(t, valid.Validate())
+}
@@ -0,0 +1,92 @@
+package mocks
+
+import (
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCertificateRepository - мок для интерфейса CertificateRepository
+type MockCertificateRepository struct {
+	mock.Mock
+}
+
+// GetAll возвращает все сертификаты
+func (m *MockCertificateRepository) GetAll() ([]entities.QualityCertificate, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.QualityCertificate), args.Error(1)
+}
+
+// GetByID возвращает сертификат по ID
+func (m *MockCertificateRepository) GetByID(id int) (*entities.QualityCertificate, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.QualityCertificate), args.Error(1)
+}
+
+// GetByProduct возвращает сертификаты продукции
+func (m *MockCertificateRepository) GetByProduct(productID int) ([]entities.QualityCertificate, error) {
+	args := m.Called(productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.QualityCertificate), args.Error(1)
+}
+
+// Create создает сертификат
+func (m *MockCertificateRepository) Create(certificate *entities.QualityCertificate) error {
+	args := m.Called(certificate)
+	return args.Error(0)
+}
+
+// Update обновляет сертификат
+func (m *MockCertificateRepository) Update(certificate *entities.QualityCertificate) error {
+	args := m.Called(certificate)
+	return args.Error(0)
+}
+
+// Delete удаляет сертификат
+func (m *MockCertificateRepository) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// UpdateFilePath сохраняет ссылку на скан сертификата
+func (m *MockCertificateRepository) UpdateFilePath(id int, filePath *string) error {
+	args := m.Called(id, filePath)
+	return args.Error(0)
+}
+
+// GetExpiring возвращает продукцию с истекающими сертификатами
+func (m *MockCertificateRepository) GetExpiring(until time.Time) ([]entities.ExpiringCertificate, error) {
+	args := m.Called(until)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.ExpiringCertificate), args.Error(1)
+}
+
+// GetCertification возвращает состояние сертификации продукции на дату
+func (m *MockCertificateRepository) GetCertification(productIDs []int, date time.Time) ([]entities.ProductCertification, error) {
+	args := m.Called(productIDs, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.ProductCertification), args.Error(1)
+}
+
+// GetOrderProductIDs возвращает ID продукции в позициях заказа
+func (m *MockCertificateRepository) GetOrderProductIDs(orderID int) ([]int, error) {
+	args := m.Called(orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int), args.Error(1)
+}
@@ -0,0 +1,22 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+// MockFileStorage - мок для интерфейса FileStorage
+type MockFileStorage struct {
+	mock.Mock
+}
+
+// Save сохраняет файл и возвращает ссылку на него
+func (m *MockFileStorage) Save(key string, data []byte) (string, error) {
+	args := m.Called(key, data)
+	return args.String(0), args.Error(1)
+}
+
+// Delete удаляет файл по ссылке
+func (m *MockFileStorage) Delete(path string) error {
+	args := m.Called(path)
+	return args.Error(0)
+}
@@ -0,0 +1,36 @@
+package mocks
+
+import (
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMaterialCostingRepository - мок для интерфейса MaterialCostingRepository
+type MockMaterialCostingRepository struct {
+	mock.Mock
+}
+
+// GetByMaterialID возвращает себестоимость материала
+func (m *MockMaterialCostingRepository) GetByMaterialID(materialID int) (*entities.MaterialCosting, error) {
+	args := m.Called(materialID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.MaterialCosting), args.Error(1)
+}
+
+// Update сохраняет настройки себестоимости и пересчитывает ее
+func (m *MockMaterialCostingRepository) Update(costing *entities.MaterialCosting) error {
+	args := m.Called(costing)
+	return args.Error(0)
+}
+
+// Recalculate пересчитывает себестоимость материала по журналу
+func (m *MockMaterialCostingRepository) Recalculate(materialID int) (*entities.MaterialCosting, error) {
+	args := m.Called(materialID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.MaterialCosting), args.Error(1)
+}
@@ -0,0 +1,63 @@
+package mocks
+
+import (
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMaterialLotRepository - мок для интерфейса MaterialLotRepository
+type MockMaterialLotRepository struct {
+	mock.Mock
+}
+
+// GetSuppliers возвращает поставщиков
+func (m *MockMaterialLotRepository) GetSuppliers() ([]entities.Supplier, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.Supplier), args.Error(1)
+}
+
+// ReceiveSupply сохраняет поставку и создает партию
+func (m *MockMaterialLotRepository) ReceiveSupply(supply *entities.MaterialSupply, lot *entities.MaterialLot) error {
+	args := m.Called(supply, lot)
+	return args.Error(0)
+}
+
+// GetMaterialLots возвращает партии материала
+func (m *MockMaterialLotRepository) GetMaterialLots(materialID int) ([]entities.MaterialLot, error) {
+	args := m.Called(materialID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.MaterialLot), args.Error(1)
+}
+
+// GetByID возвращает партию
+func (m *MockMaterialLotRepository) GetByID(id int) (*entities.MaterialLot, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.MaterialLot), args.Error(1)
+}
+
+// GetLotConsumptions возвращает расход из партии
+func (m *MockMaterialLotRepository) GetLotConsumptions(lotID int) ([]entities.LotConsumption, error) {
+	args := m.Called(lotID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.LotConsumption), args.Error(1)
+}
+
+// GetOrderConsumptions возвращает партии, ушедшие в расход по заказу
+func (m *MockMaterialLotRepository) GetOrderConsumptions(orderID int) ([]entities.LotConsumption, error) {
+	args := m.Called(orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.LotConsumption), args.Error(1)
+}
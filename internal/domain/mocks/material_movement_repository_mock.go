@@ -0,0 +1,33 @@
+package mocks
+
+import (
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMaterialMovementRepository - мок для интерфейса MaterialMovementRepository
+type MockMaterialMovementRepository struct {
+	mock.Mock
+}
+
+// Post проводит движение материала
+func (m *MockMaterialMovementRepository) Post(movement *entities.MaterialMovement) error {
+	args := m.Called(movement)
+	return args.Error(0)
+}
+
+// PostBatch проводит несколько движений
+func (m *MockMaterialMovementRepository) PostBatch(movements []entities.MaterialMovement) error {
+	args := m.Called(movements)
+	return args.Error(0)
+}
+
+// FindByCriteria возвращает страницу журнала движений
+func (m *MockMaterialMovementRepository) FindByCriteria(criteria entities.MovementCriteria) ([]entities.MaterialMovement, int, error) {
+	args := m.Called(criteria)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]entities.MaterialMovement), args.Int(1), args.Error(2)
+}
@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"time"
+
 	"wallpaper-system/internal/domain/entities"
 
 	"github.com/stretchr/testify/mock"
@@ -17,6 +19,12 @@ func (m *MockMaterialRepository) GetAll() ([]entities.Material, error) {
 	return args.Get(0).([]entities.Material), args.Error(1)
 }
 
+// FindByCriteria возвращает страницу материалов по фильтрам и общее количество найденных записей
+func (m *MockMaterialRepository) FindByCriteria(criteria entities.MaterialCriteria) ([]entities.Material, int, error) {
+	args := m.Called(criteria)
+	return args.Get(0).([]entities.Material), args.Int(1), args.Error(2)
+}
+
 // GetByID возвращает материал по ID
 func (m *MockMaterialRepository) GetByID(id int) (*entities.Material, error) {
 	args := m.Called(id)
@@ -38,12 +46,39 @@ func (m *MockMaterialRepository) Update(material *entities.Material) error {
 	return args.Error(0)
 }
 
-// Delete удаляет материал по ID
-func (m *MockMaterialRepository) Delete(id int) error {
+// GetByArticles возвращает материалы по артикулам
+func (m *MockMaterialRepository) GetByArticles(articles []string) ([]entities.Material, error) {
+	args := m.Called(articles)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.Material), args.Error(1)
+}
+
+// SaveBatch сохраняет пакет записей в одной транзакции
+func (m *MockMaterialRepository) SaveBatch(items []entities.Material) error {
+	args := m.Called(items)
+	return args.Error(0)
+}
+
+// Archive переносит материал в архив
+func (m *MockMaterialRepository) Archive(id int, archivedAt time.Time) error {
+	args := m.Called(id, archivedAt)
+	return args.Error(0)
+}
+
+// Restore возвращает материал из архива
+func (m *MockMaterialRepository) Restore(id int) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
+// UpdateImages сохраняет ссылки на изображение материала и его уменьшенные копии
+func (m *MockMaterialRepository) UpdateImages(id int, images entities.ImagePaths) error {
+	args := m.Called(id, images)
+	return args.Error(0)
+}
+
 // GetMaterialTypeByID возвращает тип материала по ID
 func (m *MockMaterialRepository) GetMaterialTypeByID(id int) (*entities.MaterialType, error) {
 	args := m.Called(id)
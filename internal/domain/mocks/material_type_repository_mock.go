@@ -0,0 +1,63 @@
+package mocks
+
+import (
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMaterialTypeRepository - мок для интерфейса MaterialTypeRepository
+type MockMaterialTypeRepository struct {
+	mock.Mock
+}
+
+// GetAll возвращает все типы материалов
+func (m *MockMaterialTypeRepository) GetAll() ([]entities.MaterialType, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.MaterialType), args.Error(1)
+}
+
+// GetByID возвращает тип материала по ID
+func (m *MockMaterialTypeRepository) GetByID(id int) (*entities.MaterialType, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.MaterialType), args.Error(1)
+}
+
+// Create создает тип материала
+func (m *MockMaterialTypeRepository) Create(materialType *entities.MaterialType, change *entities.DefectRateChange) error {
+	args := m.Called(materialType, change)
+	return args.Error(0)
+}
+
+// Update обновляет тип материала
+func (m *MockMaterialTypeRepository) Update(materialType *entities.MaterialType, change *entities.DefectRateChange) error {
+	args := m.Called(materialType, change)
+	return args.Error(0)
+}
+
+// Delete удаляет тип материала
+func (m *MockMaterialTypeRepository) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// CountMaterials возвращает количество материалов типа
+func (m *MockMaterialTypeRepository) CountMaterials(materialTypeID int) (int, error) {
+	args := m.Called(materialTypeID)
+	return args.Int(0), args.Error(1)
+}
+
+// GetDefectRateHistory возвращает историю процента брака
+func (m *MockMaterialTypeRepository) GetDefectRateHistory(materialTypeID int) ([]entities.DefectRateChange, error) {
+	args := m.Called(materialTypeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.DefectRateChange), args.Error(1)
+}
@@ -0,0 +1,45 @@
+package mocks
+
+import (
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockOrderRepository - мок для интерфейса OrderRepository
+type MockOrderRepository struct {
+	mock.Mock
+}
+
+// GetByID возвращает заказ
+func (m *MockOrderRepository) GetByID(id int) (*entities.Order, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Order), args.Error(1)
+}
+
+// GetItems возвращает позиции заказа
+func (m *MockOrderRepository) GetItems(orderID int) ([]entities.OrderItem, error) {
+	args := m.Called(orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.OrderItem), args.Error(1)
+}
+
+// GetReservations возвращает резервы материалов заказа
+func (m *MockOrderRepository) GetReservations(orderID int) ([]entities.MaterialReservation, error) {
+	args := m.Called(orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.MaterialReservation), args.Error(1)
+}
+
+// ChangeStatus меняет статус заказа
+func (m *MockOrderRepository) ChangeStatus(change *entities.OrderStatusChange) error {
+	args := m.Called(change)
+	return args.Error(0)
+}
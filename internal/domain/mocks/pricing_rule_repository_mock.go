@@ -0,0 +1,71 @@
+package mocks
+
+import (
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPricingRuleRepository - мок для интерфейса PricingRuleRepository
+type MockPricingRuleRepository struct {
+	mock.Mock
+}
+
+// GetAll возвращает список всех правил ценообразования
+func (m *MockPricingRuleRepository) GetAll() ([]entities.PricingRule, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.PricingRule), args.Error(1)
+}
+
+// GetByID возвращает правило ценообразования по ID
+func (m *MockPricingRuleRepository) GetByID(id int) (*entities.PricingRule, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PricingRule), args.Error(1)
+}
+
+// GetActiveRules возвращает активные правила, действующие на указанную дату
+func (m *MockPricingRuleRepository) GetActiveRules(date time.Time) ([]entities.PricingRule, error) {
+	args := m.Called(date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.PricingRule), args.Error(1)
+}
+
+// Create создает новое правило ценообразования
+func (m *MockPricingRuleRepository) Create(rule *entities.PricingRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+// Update обновляет правило ценообразования
+func (m *MockPricingRuleRepository) Update(rule *entities.PricingRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+// Delete удаляет правило ценообразования
+func (m *MockPricingRuleRepository) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// GetPartnerTypes возвращает все типы партнеров
+func (m *MockPricingRuleRepository) GetPartnerTypes() ([]entities.PartnerType, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.PartnerType), args.Error(1)
+}
+
+// GetPartnerTypeByID возвращает тип партнера по ID
+func (m *MockPricingRuleRepository) GetPartnerTypeByID(id int) (*entities.PartnerType, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PartnerType), args.Error(1)
+}
@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"time"
+
 	"wallpaper-system/internal/domain/entities"
 
 	"github.com/stretchr/testify/mock"
@@ -17,6 +19,12 @@ func (m *MockProductRepository) GetAll() ([]entities.Product, error) {
 	return args.Get(0).([]entities.Product), args.Error(1)
 }
 
+// FindByCriteria возвращает страницу продукции по фильтрам и общее количество найденных записей
+func (m *MockProductRepository) FindByCriteria(criteria entities.ProductCriteria) ([]entities.Product, int, error) {
+	args := m.Called(criteria)
+	return args.Get(0).([]entities.Product), args.Int(1), args.Error(2)
+}
+
 // GetByID возвращает продукцию по ID
 func (m *MockProductRepository) GetByID(id int) (*entities.Product, error) {
 	args := m.Called(id)
@@ -32,18 +40,51 @@ func (m *MockProductRepository) Create(product *entities.Product) error {
 	return args.Error(0)
 }
 
+// CreateWithRecipe создает продукцию вместе с рецептурой
+func (m *MockProductRepository) CreateWithRecipe(product *entities.Product) error {
+	args := m.Called(product)
+	return args.Error(0)
+}
+
 // Update обновляет продукцию
 func (m *MockProductRepository) Update(product *entities.Product) error {
 	args := m.Called(product)
 	return args.Error(0)
 }
 
-// Delete удаляет продукцию
-func (m *MockProductRepository) Delete(id int) error {
+// GetByArticles возвращает продукцию по артикулам
+func (m *MockProductRepository) GetByArticles(articles []string) ([]entities.Product, error) {
+	args := m.Called(articles)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.Product), args.Error(1)
+}
+
+// SaveBatch сохраняет пакет записей в одной транзакции
+func (m *MockProductRepository) SaveBatch(items []entities.Product) error {
+	args := m.Called(items)
+	return args.Error(0)
+}
+
+// Archive переносит продукцию в архив
+func (m *MockProductRepository) Archive(id int, archivedAt time.Time) error {
+	args := m.Called(id, archivedAt)
+	return args.Error(0)
+}
+
+// Restore возвращает продукцию из архива
+func (m *MockProductRepository) Restore(id int) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
+// UpdateImages сохраняет ссылки на изображение продукции и его уменьшенные копии
+func (m *MockProductRepository) UpdateImages(id int, images entities.ImagePaths) error {
+	args := m.Called(id, images)
+	return args.Error(0)
+}
+
 // GetProductTypes возвращает все типы продукции
 func (m *MockProductRepository) GetProductTypes() ([]entities.ProductType, error) {
 	args := m.Called()
@@ -64,3 +105,81 @@ func (m *MockProductRepository) GetMaterialsForProduct(productID int) ([]entitie
 	args := m.Called(productID)
 	return args.Get(0).([]entities.ProductMaterial), args.Error(1)
 }
+
+// AddProductMaterial добавляет материал в рецептуру продукции
+func (m *MockProductRepository) AddProductMaterial(productMaterial *entities.ProductMaterial) error {
+	args := m.Called(productMaterial)
+	return args.Error(0)
+}
+
+// UpdateProductMaterial изменяет расход материала в рецептуре продукции
+func (m *MockProductRepository) UpdateProductMaterial(productMaterial *entities.ProductMaterial) error {
+	args := m.Called(productMaterial)
+	return args.Error(0)
+}
+
+// RemoveProductMaterial удаляет материал из рецептуры продукции
+func (m *MockProductRepository) RemoveProductMaterial(productID, materialID int) error {
+	args := m.Called(productID, materialID)
+	return args.Error(0)
+}
+
+// ReplaceProductMaterials заменяет всю рецептуру продукции
+func (m *MockProductRepository) ReplaceProductMaterials(productID int, materials []entities.ProductMaterial) error {
+	args := m.Called(productID, materials)
+	return args.Error(0)
+}
+
+// GetComponentsForProduct возвращает полуфабрикаты продукции
+func (m *MockProductRepository) GetComponentsForProduct(productID int) ([]entities.ProductComponent, error) {
+	args := m.Called(productID)
+	return args.Get(0).([]entities.ProductComponent), args.Error(1)
+}
+
+// AddProductComponent добавляет полуфабрикат в рецептуру продукции
+func (m *MockProductRepository) AddProductComponent(component *entities.ProductComponent) error {
+	args := m.Called(component)
+	return args.Error(0)
+}
+
+// UpdateProductComponent изменяет расход полуфабриката в рецептуре продукции
+func (m *MockProductRepository) UpdateProductComponent(component *entities.ProductComponent) error {
+	args := m.Called(component)
+	return args.Error(0)
+}
+
+// RemoveProductComponent удаляет полуфабрикат из рецептуры продукции
+func (m *MockProductRepository) RemoveProductComponent(productID, componentProductID int) error {
+	args := m.Called(productID, componentProductID)
+	return args.Error(0)
+}
+
+// UpdateCalculatedCost сохраняет себестоимость, рассчитанную по рецептуре
+func (m *MockProductRepository) UpdateCalculatedCost(productID int, cost float64, calculatedAt time.Time) error {
+	args := m.Called(productID, cost, calculatedAt)
+	return args.Error(0)
+}
+
+// GetProductIDsUsingMaterial возвращает ID продукции, использующей материал
+func (m *MockProductRepository) GetProductIDsUsingMaterial(materialID int) ([]int, error) {
+	args := m.Called(materialID)
+	return args.Get(0).([]int), args.Error(1)
+}
+
+// GetProductIDsUsingProduct возвращает ID продукции, в которую продукция входит полуфабрикатом
+func (m *MockProductRepository) GetProductIDsUsingProduct(productID int) ([]int, error) {
+	args := m.Called(productID)
+	return args.Get(0).([]int), args.Error(1)
+}
+
+// GetProductIDsOfType возвращает ID продукции типа и продукции, в которую она входит
+func (m *MockProductRepository) GetProductIDsOfType(productTypeID int) ([]int, error) {
+	args := m.Called(productTypeID)
+	return args.Get(0).([]int), args.Error(1)
+}
+
+// GetAllProductIDs возвращает ID всей продукции
+func (m *MockProductRepository) GetAllProductIDs() ([]int, error) {
+	args := m.Called()
+	return args.Get(0).([]int), args.Error(1)
+}
@@ -0,0 +1,65 @@
+package mocks
+
+import (
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProductTypeRepository - мок для интерфейса ProductTypeRepository
+type MockProductTypeRepository struct {
+	mock.Mock
+}
+
+// GetAll возвращает типы продукции
+func (m *MockProductTypeRepository) GetAll(includeArchived bool) ([]entities.ProductType, error) {
+	args := m.Called(includeArchived)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.ProductType), args.Error(1)
+}
+
+// GetByID возвращает тип продукции по ID
+func (m *MockProductTypeRepository) GetByID(id int) (*entities.ProductType, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ProductType), args.Error(1)
+}
+
+// Create создает тип продукции
+func (m *MockProductTypeRepository) Create(productType *entities.ProductType, change *entities.CoefficientChange) error {
+	args := m.Called(productType, change)
+	return args.Error(0)
+}
+
+// Update обновляет тип продукции
+func (m *MockProductTypeRepository) Update(productType *entities.ProductType, change *entities.CoefficientChange) error {
+	args := m.Called(productType, change)
+	return args.Error(0)
+}
+
+// Archive выводит тип продукции из обращения
+func (m *MockProductTypeRepository) Archive(id int, archivedAt time.Time) error {
+	args := m.Called(id, archivedAt)
+	return args.Error(0)
+}
+
+// Restore возвращает тип продукции в обращение
+func (m *MockProductTypeRepository) Restore(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// GetCoefficientHistory возвращает историю коэффициента
+func (m *MockProductTypeRepository) GetCoefficientHistory(productTypeID int) ([]entities.CoefficientChange, error) {
+	args := m.Called(productTypeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.CoefficientChange), args.Error(1)
+}
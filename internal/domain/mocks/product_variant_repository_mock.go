@@ -0,0 +1,54 @@
+package mocks
+
+import (
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProductVariantRepository - мок для интерфейса ProductVariantRepository
+type MockProductVariantRepository struct {
+	mock.Mock
+}
+
+// GetByProduct возвращает варианты продукции
+func (m *MockProductVariantRepository) GetByProduct(productID int) ([]entities.ProductVariant, error) {
+	args := m.Called(productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.ProductVariant), args.Error(1)
+}
+
+// GetByID возвращает вариант по ID
+func (m *MockProductVariantRepository) GetByID(id int) (*entities.ProductVariant, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ProductVariant), args.Error(1)
+}
+
+// Create создает вариант
+func (m *MockProductVariantRepository) Create(variant *entities.ProductVariant) error {
+	args := m.Called(variant)
+	return args.Error(0)
+}
+
+// Update обновляет вариант
+func (m *MockProductVariantRepository) Update(variant *entities.ProductVariant) error {
+	args := m.Called(variant)
+	return args.Error(0)
+}
+
+// Delete удаляет вариант
+func (m *MockProductVariantRepository) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// UpdateImages сохраняет ссылки на изображение варианта
+func (m *MockProductVariantRepository) UpdateImages(id int, images entities.ImagePaths) error {
+	args := m.Called(id, images)
+	return args.Error(0)
+}
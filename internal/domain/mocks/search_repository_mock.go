@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSearchRepository - мок для интерфейса SearchRepository
+type MockSearchRepository struct {
+	mock.Mock
+}
+
+// SearchProducts ищет продукцию по названию, описанию и артикулу
+func (m *MockSearchRepository) SearchProducts(query entities.SearchQuery) ([]entities.SearchResult, error) {
+	args := m.Called(query)
+	return args.Get(0).([]entities.SearchResult), args.Error(1)
+}
+
+// SearchMaterials ищет материалы по названию, описанию и артикулу
+func (m *MockSearchRepository) SearchMaterials(query entities.SearchQuery) ([]entities.SearchResult, error) {
+	args := m.Called(query)
+	return args.Get(0).([]entities.SearchResult), args.Error(1)
+}
+
+// SearchPartners ищет партнеров по наименованию, ИНН, директору и адресу
+func (m *MockSearchRepository) SearchPartners(query entities.SearchQuery) ([]entities.SearchResult, error) {
+	args := m.Called(query)
+	return args.Get(0).([]entities.SearchResult), args.Error(1)
+}
@@ -0,0 +1,59 @@
+package mocks
+
+import (
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockStockAlertRepository - мок для интерфейса StockAlertRepository
+type MockStockAlertRepository struct {
+	mock.Mock
+}
+
+// GetLowStockMaterials возвращает материалы с остатком не выше минимального
+func (m *MockStockAlertRepository) GetLowStockMaterials() ([]entities.Material, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.Material), args.Error(1)
+}
+
+// GetOpenNotifications возвращает открытые уведомления
+func (m *MockStockAlertRepository) GetOpenNotifications() ([]entities.StockNotification, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.StockNotification), args.Error(1)
+}
+
+// FindNotifications возвращает уведомления
+func (m *MockStockAlertRepository) FindNotifications(openOnly bool) ([]entities.StockNotification, error) {
+	args := m.Called(openOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.StockNotification), args.Error(1)
+}
+
+// CreateNotification сохраняет уведомление
+func (m *MockStockAlertRepository) CreateNotification(notification *entities.StockNotification) error {
+	args := m.Called(notification)
+	return args.Error(0)
+}
+
+// ResolveNotifications закрывает уведомления
+func (m *MockStockAlertRepository) ResolveNotifications(ids []int, resolvedAt time.Time) error {
+	args := m.Called(ids, resolvedAt)
+	return args.Error(0)
+}
+
+// AcknowledgeNotification отмечает уведомление просмотренным
+func (m *MockStockAlertRepository) AcknowledgeNotification(id int, acknowledgedAt time.Time) error {
+	args := m.Called(id, acknowledgedAt)
+	return args.Error(0)
+}
@@ -0,0 +1,59 @@
+package mocks
+
+import (
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockStocktakeRepository - мок для интерфейса StocktakeRepository
+type MockStocktakeRepository struct {
+	mock.Mock
+}
+
+// GetAll возвращает инвентаризации
+func (m *MockStocktakeRepository) GetAll() ([]entities.Stocktake, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.Stocktake), args.Error(1)
+}
+
+// GetByID возвращает инвентаризацию со строками
+func (m *MockStocktakeRepository) GetByID(id int) (*entities.Stocktake, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Stocktake), args.Error(1)
+}
+
+// Create открывает инвентаризацию
+func (m *MockStocktakeRepository) Create(stocktake *entities.Stocktake) error {
+	args := m.Called(stocktake)
+	return args.Error(0)
+}
+
+// SaveCounts сохраняет фактические остатки
+func (m *MockStocktakeRepository) SaveCounts(stocktakeID int, counts []entities.StocktakeCount, countedAt time.Time) error {
+	args := m.Called(stocktakeID, counts, countedAt)
+	return args.Error(0)
+}
+
+// Close проводит расхождения и закрывает инвентаризацию
+func (m *MockStocktakeRepository) Close(stocktakeID int, closedAt time.Time) ([]int, error) {
+	args := m.Called(stocktakeID, closedAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int), args.Error(1)
+}
+
+// Cancel отменяет инвентаризацию
+func (m *MockStocktakeRepository) Cancel(stocktakeID int, cancelledAt time.Time) error {
+	args := m.Called(stocktakeID, cancelledAt)
+	return args.Error(0)
+}
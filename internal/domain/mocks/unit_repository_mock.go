@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockUnitRepository - мок для интерфейса UnitRepository
+type MockUnitRepository struct {
+	mock.Mock
+}
+
+// GetAll возвращает все единицы измерения
+func (m *MockUnitRepository) GetAll() ([]entities.MeasurementUnit, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.MeasurementUnit), args.Error(1)
+}
+
+// GetMaterialConversions возвращает пересчеты материала
+func (m *MockUnitRepository) GetMaterialConversions(materialID int) ([]entities.MaterialUnitConversion, error) {
+	args := m.Called(materialID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.MaterialUnitConversion), args.Error(1)
+}
+
+// GetConversionsForMaterials возвращает пересчеты нескольких материалов
+func (m *MockUnitRepository) GetConversionsForMaterials(materialIDs []int) (map[int][]entities.MaterialUnitConversion, error) {
+	args := m.Called(materialIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int][]entities.MaterialUnitConversion), args.Error(1)
+}
+
+// SaveMaterialConversion сохраняет пересчет материала
+func (m *MockUnitRepository) SaveMaterialConversion(conversion *entities.MaterialUnitConversion) error {
+	args := m.Called(conversion)
+	return args.Error(0)
+}
+
+// DeleteMaterialConversion удаляет пересчет материала
+func (m *MockUnitRepository) DeleteMaterialConversion(materialID, unitID int) error {
+	args := m.Called(materialID, unitID)
+	return args.Error(0)
+}
@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// CertificateRepository определяет интерфейс для работы с сертификатами качества
+type CertificateRepository interface {
+	// GetAll возвращает все сертификаты вместе с продукцией, на которую они распространяются
+	GetAll() ([]entities.QualityCertificate, error)
+
+	// GetByID возвращает сертификат по ID
+	GetByID(id int) (*entities.QualityCertificate, error)
+
+	// GetByProduct возвращает сертификаты, распространяющиеся на продукцию
+	GetByProduct(productID int) ([]entities.QualityCertificate, error)
+
+	// Create создает сертификат и его связи с продукцией в одной транзакции
+	Create(certificate *entities.QualityCertificate) error
+
+	// Update обновляет сертификат и заменяет его связи с продукцией в одной транзакции
+	Update(certificate *entities.QualityCertificate) error
+
+	// Delete удаляет сертификат
+	Delete(id int) error
+
+	// UpdateFilePath сохраняет ссылку на скан сертификата
+	UpdateFilePath(id int, filePath *string) error
+
+	// GetExpiring возвращает неархивную продукцию, у которой самый поздний сертификат
+	// действует не дольше указанной даты (включая уже истекшие), по возрастанию срока
+	GetExpiring(until time.Time) ([]entities.ExpiringCertificate, error)
+
+	// GetCertification возвращает состояние сертификации продукции на дату
+	GetCertification(productIDs []int, date time.Time) ([]entities.ProductCertification, error)
+
+	// GetOrderProductIDs возвращает ID продукции в позициях заказа
+	GetOrderProductIDs(orderID int) ([]int, error)
+}
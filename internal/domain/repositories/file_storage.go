@@ -0,0 +1,11 @@
+package repositories
+
+// FileStorage определяет интерфейс хранилища загруженных файлов (изображений, документов)
+type FileStorage interface {
+	// Save сохраняет файл под ключом вида "products/12/a1b2c3.jpg" и возвращает ссылку на него
+	Save(key string, data []byte) (string, error)
+
+	// Delete удаляет файл по ссылке, полученной от Save. Ссылки на файлы вне хранилища
+	// и уже удаленные файлы пропускаются без ошибки
+	Delete(path string) error
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import "wallpaper-system/internal/domain/entities"
+
+// MaterialCostingRepository определяет интерфейс для работы с себестоимостью материалов.
+// Себестоимость движений рассчитывается при их проведении в складском журнале
+type MaterialCostingRepository interface {
+	// GetByMaterialID возвращает себестоимость материала; если материала нет - ошибку NotFoundError
+	GetByMaterialID(materialID int) (*entities.MaterialCosting, error)
+
+	// Update сохраняет метод и фиксацию себестоимости и заново рассчитывает себестоимость
+	// по журналу в одной транзакции; результат расчета записывается в costing
+	Update(costing *entities.MaterialCosting) error
+
+	// Recalculate заново рассчитывает себестоимость движений, партий и материала по журналу
+	Recalculate(materialID int) (*entities.MaterialCosting, error)
+}
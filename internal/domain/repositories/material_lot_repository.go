@@ -0,0 +1,26 @@
+package repositories
+
+import "wallpaper-system/internal/domain/entities"
+
+// MaterialLotRepository определяет интерфейс для работы с поставками и партиями материалов.
+// Партии без поставки и расход из партий ведет складской журнал при проведении движений
+type MaterialLotRepository interface {
+	// GetSuppliers возвращает поставщиков по названию
+	GetSuppliers() ([]entities.Supplier, error)
+
+	// ReceiveSupply сохраняет поставку, проводит приход и создает партию lot в одной транзакции.
+	// Если поставщика или материала нет, возвращает ошибку NotFoundError
+	ReceiveSupply(supply *entities.MaterialSupply, lot *entities.MaterialLot) error
+
+	// GetMaterialLots возвращает партии материала в порядке расхода (FIFO)
+	GetMaterialLots(materialID int) ([]entities.MaterialLot, error)
+
+	// GetByID возвращает партию с материалом и поставщиком; если партии нет - ошибку NotFoundError
+	GetByID(id int) (*entities.MaterialLot, error)
+
+	// GetLotConsumptions возвращает расход и списания из партии вместе с движениями
+	GetLotConsumptions(lotID int) ([]entities.LotConsumption, error)
+
+	// GetOrderConsumptions возвращает партии, из которых списан материал в расход по заказу
+	GetOrderConsumptions(orderID int) ([]entities.LotConsumption, error)
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import "wallpaper-system/internal/domain/entities"
+
+// MaterialMovementRepository определяет интерфейс складского журнала материалов. Остаток
+// материала меняется только проведением движения
+type MaterialMovementRepository interface {
+	// Post проводит движение: меняет остаток материала и записывает движение в журнал
+	Post(movement *entities.MaterialMovement) error
+
+	// PostBatch проводит несколько движений в одной транзакции
+	PostBatch(movements []entities.MaterialMovement) error
+
+	// FindByCriteria возвращает страницу журнала движений материала, новые движения первыми,
+	// и общее количество найденных записей
+	FindByCriteria(criteria entities.MovementCriteria) ([]entities.MaterialMovement, int, error)
+}
@@ -1,23 +1,43 @@
 package repositories
 
-import "wallpaper-system/internal/domain/entities"
+import (
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+)
 
 // MaterialRepository определяет интерфейс для работы с материалами
 type MaterialRepository interface {
 	// GetAll возвращает список всех материалов
 	GetAll() ([]entities.Material, error)
 
+	// FindByCriteria возвращает страницу материалов по фильтрам и общее количество найденных записей
+	FindByCriteria(criteria entities.MaterialCriteria) ([]entities.Material, int, error)
+
 	// GetByID возвращает материал по ID
 	GetByID(id int) (*entities.Material, error)
 
 	// Create создает новый материал
 	Create(material *entities.Material) error
 
-	// Update обновляет существующий материал
+	// Update обновляет существующий материал; себестоимость меняется, только если она зафиксирована
 	Update(material *entities.Material) error
 
-	// Delete удаляет материал по ID
-	Delete(id int) error
+	// GetByArticles возвращает материалы, включая архивные записи, с указанными артикулами
+	GetByArticles(articles []string) ([]entities.Material, error)
+
+	// SaveBatch создает записи без ID и обновляет записи с ID в одной транзакции. Остаток
+	// и себестоимость после проведения изменения остатка переносятся в items
+	SaveBatch(items []entities.Material) error
+
+	// Archive переносит материал в архив
+	Archive(id int, archivedAt time.Time) error
+
+	// Restore возвращает материал из архива
+	Restore(id int) error
+
+	// UpdateImages сохраняет ссылки на изображение материала и его уменьшенные копии
+	UpdateImages(id int, images entities.ImagePaths) error
 
 	// GetMaterialTypeByID возвращает тип материала по ID
 	GetMaterialTypeByID(id int) (*entities.MaterialType, error)
@@ -0,0 +1,28 @@
+package repositories
+
+import "wallpaper-system/internal/domain/entities"
+
+// MaterialTypeRepository определяет интерфейс для управления типами материалов
+type MaterialTypeRepository interface {
+	// GetAll возвращает все типы материалов
+	GetAll() ([]entities.MaterialType, error)
+
+	// GetByID возвращает тип материала по ID
+	GetByID(id int) (*entities.MaterialType, error)
+
+	// Create создает тип материала и записывает начальный процент брака в историю в одной транзакции
+	Create(materialType *entities.MaterialType, change *entities.DefectRateChange) error
+
+	// Update обновляет тип материала; при изменении процента брака change записывается в историю
+	// в той же транзакции, иначе change равен nil
+	Update(materialType *entities.MaterialType, change *entities.DefectRateChange) error
+
+	// Delete удаляет тип материала вместе с историей
+	Delete(id int) error
+
+	// CountMaterials возвращает количество материалов типа, включая архивные
+	CountMaterials(materialTypeID int) (int, error)
+
+	// GetDefectRateHistory возвращает историю процента брака, начиная с последнего изменения
+	GetDefectRateHistory(materialTypeID int) ([]entities.DefectRateChange, error)
+}
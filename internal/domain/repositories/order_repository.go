@@ -0,0 +1,21 @@
+package repositories
+
+import "wallpaper-system/internal/domain/entities"
+
+// OrderRepository определяет интерфейс для работы с заказами партнеров
+type OrderRepository interface {
+	// GetByID возвращает заказ; если заказа нет - ошибку NotFoundError
+	GetByID(id int) (*entities.Order, error)
+
+	// GetItems возвращает позиции заказа; если заказа нет - ошибку NotFoundError
+	GetItems(orderID int) ([]entities.OrderItem, error)
+
+	// GetReservations возвращает резервы материалов заказа вместе с материалами
+	GetReservations(orderID int) ([]entities.MaterialReservation, error)
+
+	// ChangeStatus меняет статус заказа и выполняет действия с резервами в одной транзакции:
+	// новые резервы создаются до списания, поэтому списываются вместе с прежними.
+	// Если статус заказа уже изменился, возвращает ошибку ORDER_STATUS_CHANGED; если свободного
+	// остатка не хватает для резерва - ошибку INSUFFICIENT_AVAILABLE
+	ChangeStatus(change *entities.OrderStatusChange) error
+}
@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// PricingRuleRepository определяет интерфейс для работы с правилами ценообразования
+type PricingRuleRepository interface {
+	// GetAll возвращает список всех правил ценообразования
+	GetAll() ([]entities.PricingRule, error)
+
+	// GetByID возвращает правило ценообразования по ID
+	GetByID(id int) (*entities.PricingRule, error)
+
+	// GetActiveRules возвращает активные правила, действующие на указанную дату
+	GetActiveRules(date time.Time) ([]entities.PricingRule, error)
+
+	// Create создает новое правило ценообразования
+	Create(rule *entities.PricingRule) error
+
+	// Update обновляет правило ценообразования
+	Update(rule *entities.PricingRule) error
+
+	// Delete удаляет правило ценообразования
+	Delete(id int) error
+
+	// GetPartnerTypes возвращает все типы партнеров
+	GetPartnerTypes() ([]entities.PartnerType, error)
+
+	// GetPartnerTypeByID возвращает тип партнера по ID
+	GetPartnerTypeByID(id int) (*entities.PartnerType, error)
+}
@@ -1,12 +1,19 @@
 package repositories
 
-import "wallpaper-system/internal/domain/entities"
+import (
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+)
 
 // ProductRepository определяет интерфейс для работы с продукцией
 type ProductRepository interface {
 	// GetAll возвращает список всей продукции
 	GetAll() ([]entities.Product, error)
 
+	// FindByCriteria возвращает страницу продукции по фильтрам и общее количество найденных записей
+	FindByCriteria(criteria entities.ProductCriteria) ([]entities.Product, int, error)
+
 	// GetByID возвращает продукцию по ID
 	GetByID(id int) (*entities.Product, error)
 
@@ -16,8 +23,23 @@ type ProductRepository interface {
 	// Update обновляет продукцию
 	Update(product *entities.Product) error
 
-	// Delete удаляет продукцию
-	Delete(id int) error
+	// CreateWithRecipe создает продукцию вместе с рецептурой (материалы и полуфабрикаты) в одной транзакции
+	CreateWithRecipe(product *entities.Product) error
+
+	// GetByArticles возвращает продукцию, включая архивные записи, с указанными артикулами
+	GetByArticles(articles []string) ([]entities.Product, error)
+
+	// SaveBatch создает записи без ID и обновляет записи с ID в одной транзакции
+	SaveBatch(items []entities.Product) error
+
+	// Archive переносит продукцию в архив
+	Archive(id int, archivedAt time.Time) error
+
+	// Restore возвращает продукцию из архива
+	Restore(id int) error
+
+	// UpdateImages сохраняет ссылки на изображение продукции и его уменьшенные копии
+	UpdateImages(id int, images entities.ImagePaths) error
 
 	// GetProductTypes возвращает все типы продукции
 	GetProductTypes() ([]entities.ProductType, error)
@@ -27,4 +49,43 @@ type ProductRepository interface {
 
 	// GetMaterialsForProduct возвращает материалы для продукции
 	GetMaterialsForProduct(productID int) ([]entities.ProductMaterial, error)
+
+	// AddProductMaterial добавляет материал в рецептуру продукции
+	AddProductMaterial(productMaterial *entities.ProductMaterial) error
+
+	// UpdateProductMaterial изменяет расход материала в рецептуре продукции
+	UpdateProductMaterial(productMaterial *entities.ProductMaterial) error
+
+	// RemoveProductMaterial удаляет материал из рецептуры продукции
+	RemoveProductMaterial(productID, materialID int) error
+
+	// ReplaceProductMaterials заменяет всю рецептуру продукции в одной транзакции
+	ReplaceProductMaterials(productID int, materials []entities.ProductMaterial) error
+
+	// GetComponentsForProduct возвращает полуфабрикаты, входящие в рецептуру продукции
+	GetComponentsForProduct(productID int) ([]entities.ProductComponent, error)
+
+	// AddProductComponent добавляет полуфабрикат в рецептуру продукции
+	AddProductComponent(component *entities.ProductComponent) error
+
+	// UpdateProductComponent изменяет расход полуфабриката в рецептуре продукции
+	UpdateProductComponent(component *entities.ProductComponent) error
+
+	// RemoveProductComponent удаляет полуфабрикат из рецептуры продукции
+	RemoveProductComponent(productID, componentProductID int) error
+
+	// UpdateCalculatedCost сохраняет себестоимость, рассчитанную по рецептуре
+	UpdateCalculatedCost(productID int, cost float64, calculatedAt time.Time) error
+
+	// GetProductIDsUsingMaterial возвращает ID продукции, использующей материал напрямую или через полуфабрикаты
+	GetProductIDsUsingMaterial(materialID int) ([]int, error)
+
+	// GetProductIDsUsingProduct возвращает ID продукции, в которую продукция входит полуфабрикатом
+	GetProductIDsUsingProduct(productID int) ([]int, error)
+
+	// GetProductIDsOfType возвращает ID продукции типа вместе с продукцией, в которую она входит полуфабрикатом
+	GetProductIDsOfType(productTypeID int) ([]int, error)
+
+	// GetAllProductIDs возвращает ID всей продукции
+	GetAllProductIDs() ([]int, error)
 }
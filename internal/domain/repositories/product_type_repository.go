@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// ProductTypeRepository определяет интерфейс для управления типами продукции
+type ProductTypeRepository interface {
+	// GetAll возвращает типы продукции; архивные - только при includeArchived
+	GetAll(includeArchived bool) ([]entities.ProductType, error)
+
+	// GetByID возвращает тип продукции по ID, в том числе архивный
+	GetByID(id int) (*entities.ProductType, error)
+
+	// Create создает тип продукции и записывает начальный коэффициент в историю в одной транзакции
+	Create(productType *entities.ProductType, change *entities.CoefficientChange) error
+
+	// Update обновляет тип продукции; при изменении коэффициента change записывается в историю
+	// в той же транзакции, иначе change равен nil
+	Update(productType *entities.ProductType, change *entities.CoefficientChange) error
+
+	// Archive выводит тип продукции из обращения
+	Archive(id int, archivedAt time.Time) error
+
+	// Restore возвращает тип продукции в обращение
+	Restore(id int) error
+
+	// GetCoefficientHistory возвращает историю коэффициента, начиная с последнего изменения
+	GetCoefficientHistory(productTypeID int) ([]entities.CoefficientChange, error)
+}
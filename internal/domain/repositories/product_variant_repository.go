@@ -0,0 +1,24 @@
+package repositories
+
+import "wallpaper-system/internal/domain/entities"
+
+// ProductVariantRepository определяет интерфейс для работы с вариантами (расцветками) продукции
+type ProductVariantRepository interface {
+	// GetByProduct возвращает варианты продукции вместе с заменами рецептуры
+	GetByProduct(productID int) ([]entities.ProductVariant, error)
+
+	// GetByID возвращает вариант по ID вместе с заменами рецептуры
+	GetByID(id int) (*entities.ProductVariant, error)
+
+	// Create создает вариант вместе с заменами рецептуры в одной транзакции
+	Create(variant *entities.ProductVariant) error
+
+	// Update обновляет вариант и заменяет все замены рецептуры в одной транзакции
+	Update(variant *entities.ProductVariant) error
+
+	// Delete удаляет вариант
+	Delete(id int) error
+
+	// UpdateImages сохраняет ссылки на изображение варианта и его уменьшенные копии
+	UpdateImages(id int, images entities.ImagePaths) error
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import "wallpaper-system/internal/domain/entities"
+
+// SearchRepository определяет интерфейс полнотекстового поиска
+type SearchRepository interface {
+	// SearchProducts ищет продукцию по названию, описанию и артикулу
+	SearchProducts(query entities.SearchQuery) ([]entities.SearchResult, error)
+
+	// SearchMaterials ищет материалы по названию, описанию и артикулу
+	SearchMaterials(query entities.SearchQuery) ([]entities.SearchResult, error)
+
+	// SearchPartners ищет партнеров по наименованию, ИНН, директору и адресу
+	SearchPartners(query entities.SearchQuery) ([]entities.SearchResult, error)
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// StockAlertRepository определяет интерфейс контроля минимальных остатков материалов
+type StockAlertRepository interface {
+	// GetLowStockMaterials возвращает действующие материалы с заданным минимальным остатком,
+	// остаток которых не выше минимального
+	GetLowStockMaterials() ([]entities.Material, error)
+
+	// GetOpenNotifications возвращает уведомления, остаток по которым еще не восстановлен
+	GetOpenNotifications() ([]entities.StockNotification, error)
+
+	// FindNotifications возвращает уведомления вместе с материалами, новые первыми;
+	// openOnly оставляет только открытые уведомления
+	FindNotifications(openOnly bool) ([]entities.StockNotification, error)
+
+	// CreateNotification сохраняет новое уведомление
+	CreateNotification(notification *entities.StockNotification) error
+
+	// ResolveNotifications закрывает уведомления: остаток материалов восстановлен
+	ResolveNotifications(ids []int, resolvedAt time.Time) error
+
+	// AcknowledgeNotification отмечает уведомление просмотренным
+	AcknowledgeNotification(id int, acknowledgedAt time.Time) error
+}
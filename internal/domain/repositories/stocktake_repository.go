@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// StocktakeRepository определяет интерфейс для работы с инвентаризациями материалов
+type StocktakeRepository interface {
+	// GetAll возвращает инвентаризации без строк, новые первыми
+	GetAll() ([]entities.Stocktake, error)
+
+	// GetByID возвращает инвентаризацию со строками и материалами; если инвентаризации нет -
+	// ошибку NotFoundError
+	GetByID(id int) (*entities.Stocktake, error)
+
+	// Create открывает инвентаризацию и фиксирует учетный остаток и цену каждого неархивного
+	// материала. Если уже есть открытая инвентаризация, возвращает ошибку STOCKTAKE_ALREADY_OPEN
+	Create(stocktake *entities.Stocktake) error
+
+	// SaveCounts сохраняет фактические остатки открытой инвентаризации; остаток nil отменяет пересчет
+	SaveCounts(stocktakeID int, counts []entities.StocktakeCount, countedAt time.Time) error
+
+	// Close проводит расхождения пересчитанных материалов в складском журнале и закрывает
+	// инвентаризацию в одной транзакции: если хотя бы одно движение не проходит, остатки не меняются.
+	// Возвращает материалы, себестоимость которых изменилась при проведении
+	Close(stocktakeID int, closedAt time.Time) ([]int, error)
+
+	// Cancel отменяет открытую инвентаризацию без изменения остатков
+	Cancel(stocktakeID int, cancelledAt time.Time) error
+}
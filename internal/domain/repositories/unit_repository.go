@@ -0,0 +1,21 @@
+package repositories
+
+import "wallpaper-system/internal/domain/entities"
+
+// UnitRepository определяет интерфейс для работы с единицами измерения и пересчетами материалов
+type UnitRepository interface {
+	// GetAll возвращает все единицы измерения
+	GetAll() ([]entities.MeasurementUnit, error)
+
+	// GetMaterialConversions возвращает пересчеты материала вместе с единицами измерения
+	GetMaterialConversions(materialID int) ([]entities.MaterialUnitConversion, error)
+
+	// GetConversionsForMaterials возвращает пересчеты нескольких материалов, сгруппированные по ID материала
+	GetConversionsForMaterials(materialIDs []int) (map[int][]entities.MaterialUnitConversion, error)
+
+	// SaveMaterialConversion создает или заменяет пересчет материала в единицу conversion.UnitID
+	SaveMaterialConversion(conversion *entities.MaterialUnitConversion) error
+
+	// DeleteMaterialConversion удаляет пересчет материала в единицу unitID
+	DeleteMaterialConversion(materialID, unitID int) error
+}
@@ -3,12 +3,15 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 )
 
 // Config содержит конфигурацию приложения
 type Config struct {
-	Server   ServerConfig   `json:"server"`
-	Database DatabaseConfig `json:"database"`
+	Server    ServerConfig    `json:"server"`
+	Database  DatabaseConfig  `json:"database"`
+	Storage   StorageConfig   `json:"storage"`
+	Inventory InventoryConfig `json:"inventory"`
 }
 
 // ServerConfig содержит конфигурацию сервера
@@ -27,6 +30,18 @@ type DatabaseConfig struct {
 	SSLMode  string `json:"sslmode" default:"disable"`
 }
 
+// StorageConfig содержит настройки хранилища загруженных файлов
+type StorageConfig struct {
+	UploadDir string `json:"upload_dir" default:"./uploads"`
+	URLPrefix string `json:"url_prefix" default:"/uploads"`
+}
+
+// InventoryConfig содержит настройки складского учета
+type InventoryConfig struct {
+	// LowStockCheckInterval - период фоновой проверки минимальных остатков; 0 отключает проверку
+	LowStockCheckInterval time.Duration `json:"low_stock_check_interval" default:"15m"`
+}
+
 // Load загружает конфигурацию из переменных окружения с дефолтными значениями
 func Load() *Config {
 	config := &Config{
@@ -42,6 +57,13 @@ func Load() *Config {
 			DBName:   getEnv("DB_NAME", "wallpaper_system"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
 		},
+		Storage: StorageConfig{
+			UploadDir: getEnv("UPLOAD_DIR", "./uploads"),
+			URLPrefix: getEnv("UPLOAD_URL_PREFIX", "/uploads"),
+		},
+		Inventory: InventoryConfig{
+			LowStockCheckInterval: getDurationEnv("LOW_STOCK_CHECK_INTERVAL", 15*time.Minute),
+		},
 	}
 
 	return config
@@ -65,3 +87,14 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getDurationEnv получает длительность из переменной окружения (например, "15m" или "1h");
+// при отсутствии или ошибке формата возвращает дефолтное значение
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if duration, err := time.ParseDuration(value); err == nil && duration >= 0 {
+			return duration
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,78 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"wallpaper-system/internal/usecases"
+
+	"go.uber.org/zap"
+)
+
+// LowStockChecker периодически сравнивает остатки материалов с минимальными и создает
+// уведомления о материалах, остаток которых опустился до минимального
+type LowStockChecker struct {
+	alertUseCase usecases.StockAlertUseCaseInterface
+	interval     time.Duration
+	logger       *zap.SugaredLogger
+}
+
+// NewLowStockChecker создает фоновую проверку остатков с заданным периодом
+func NewLowStockChecker(
+	alertUseCase usecases.StockAlertUseCaseInterface,
+	interval time.Duration,
+	logger *zap.SugaredLogger,
+) *LowStockChecker {
+	return &LowStockChecker{
+		alertUseCase: alertUseCase,
+		interval:     interval,
+		logger:       logger,
+	}
+}
+
+// Run выполняет проверку сразу и затем с заданным периодом до отмены контекста.
+// Ошибка проверки записывается в журнал и не останавливает следующие проверки
+func (c *LowStockChecker) Run(ctx context.Context) {
+	if c.interval <= 0 {
+		c.logger.Info("Фоновая проверка минимальных остатков отключена")
+		return
+	}
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.check()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check выполняет одну проверку остатков и записывает новые уведомления в журнал
+func (c *LowStockChecker) check() {
+	result, err := c.alertUseCase.CheckLowStock()
+	if err != nil {
+		c.logger.Errorw("Ошибка проверки минимальных остатков", "error", err)
+		return
+	}
+
+	for _, notification := range result.Created {
+		article := ""
+		if notification.Material != nil {
+			article = notification.Material.Article
+		}
+		c.logger.Warnw("Остаток материала опустился до минимального",
+			"material_id", notification.MaterialID,
+			"article", article,
+			"stock_quantity", notification.StockQuantity,
+			"min_stock_quantity", notification.MinStockQuantity,
+		)
+	}
+	if result.Resolved > 0 {
+		c.logger.Infow("Остатки материалов восстановлены", "resolved", result.Resolved)
+	}
+}
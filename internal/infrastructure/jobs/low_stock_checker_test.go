@@ -0,0 +1,60 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/usecases/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestLowStockChecker_ChecksUntilCanceled(t *testing.T) {
+	// Настройка моков: ошибка одной проверки не останавливает следующие
+	var checks atomic.Int32
+	countCheck := func(mock.Arguments) { checks.Add(1) }
+	alertUseCase := new(mocks.MockStockAlertUseCase)
+	alertUseCase.On("CheckLowStock").Return(nil, errors.New("нет соединения с базой данных")).Run(countCheck).Once()
+	alertUseCase.On("CheckLowStock").Run(countCheck).Return(&entities.StockCheckResult{
+		Created: []entities.StockNotification{{MaterialID: 1, StockQuantity: 5, MinStockQuantity: 10}},
+	}, nil)
+
+	checker := NewLowStockChecker(alertUseCase, 10*time.Millisecond, zap.NewNop().Sugar())
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	// Выполнение
+	go func() {
+		checker.Run(ctx)
+		close(done)
+	}()
+	assert.Eventually(t, func() bool {
+		return checks.Load() >= 3
+	}, time.Second, 5*time.Millisecond)
+	cancel()
+
+	// Проверки
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("проверка остатков не остановилась после отмены контекста")
+	}
+}
+
+func TestLowStockChecker_Disabled(t *testing.T) {
+	// Подготовка данных
+	alertUseCase := new(mocks.MockStockAlertUseCase)
+	checker := NewLowStockChecker(alertUseCase, 0, zap.NewNop().Sugar())
+
+	// Выполнение
+	checker.Run(context.Background())
+
+	// Проверки
+	alertUseCase.AssertNotCalled(t, "CheckLowStock")
+}
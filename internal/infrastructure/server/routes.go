@@ -12,6 +12,23 @@ func SetupRoutes(
 	productController *controllers.ProductController,
 	calculatorController *controllers.CalculatorController,
 	materialController *controllers.MaterialController,
+	pricingRuleController *controllers.PricingRuleController,
+	searchController *controllers.SearchController,
+	importController *controllers.ImportController,
+	exportController *controllers.ExportController,
+	imageController *controllers.ImageController,
+	certificateController *controllers.CertificateController,
+	variantController *controllers.VariantController,
+	productTypeController *controllers.ProductTypeController,
+	materialTypeController *controllers.MaterialTypeController,
+	unitController *controllers.UnitController,
+	shippingController *controllers.ShippingController,
+	movementController *controllers.MovementController,
+	stockAlertController *controllers.StockAlertController,
+	orderController *controllers.OrderController,
+	stocktakeController *controllers.StocktakeController,
+	lotController *controllers.LotController,
+	costingController *controllers.CostingController,
 ) {
 	// Главная страница - перенаправление на продукцию
 	router.GET("/", func(c *gin.Context) {
@@ -19,10 +36,10 @@ func SetupRoutes(
 	})
 
 	// Веб-страницы
-	setupWebRoutes(router, productController, calculatorController, materialController)
+	setupWebRoutes(router, productController, calculatorController, materialController, searchController, importController, imageController, certificateController, variantController, productTypeController, materialTypeController, unitController, movementController, stockAlertController, stocktakeController, lotController, costingController)
 
 	// API маршруты
-	setupAPIRoutes(router, productController, calculatorController, materialController)
+	setupAPIRoutes(router, productController, calculatorController, materialController, pricingRuleController, searchController, importController, exportController, imageController, certificateController, variantController, productTypeController, materialTypeController, unitController, shippingController, movementController, stockAlertController, orderController, stocktakeController, lotController, costingController)
 }
 
 // setupWebRoutes настраивает веб-маршруты
@@ -31,6 +48,19 @@ func setupWebRoutes(
 	productController *controllers.ProductController,
 	calculatorController *controllers.CalculatorController,
 	materialController *controllers.MaterialController,
+	searchController *controllers.SearchController,
+	importController *controllers.ImportController,
+	imageController *controllers.ImageController,
+	certificateController *controllers.CertificateController,
+	variantController *controllers.VariantController,
+	productTypeController *controllers.ProductTypeController,
+	materialTypeController *controllers.MaterialTypeController,
+	unitController *controllers.UnitController,
+	movementController *controllers.MovementController,
+	stockAlertController *controllers.StockAlertController,
+	stocktakeController *controllers.StocktakeController,
+	lotController *controllers.LotController,
+	costingController *controllers.CostingController,
 ) {
 	// Продукция
 	router.GET("/products", productController.GetProductsPage)
@@ -39,6 +69,28 @@ func setupWebRoutes(
 	router.GET("/products/:id/edit", productController.GetEditProductPage)
 	router.POST("/products/:id", productController.UpdateProductWeb)
 	router.GET("/products/:id", productController.GetProductDetailsPage)
+	router.POST("/products/:id/materials", productController.AddProductMaterialWeb)
+	router.POST("/products/:id/materials/:material_id", productController.UpdateProductMaterialWeb)
+	router.POST("/products/:id/materials/:material_id/delete", productController.RemoveProductMaterialWeb)
+	router.POST("/products/:id/components", productController.AddProductComponentWeb)
+	router.POST("/products/:id/components/:component_id", productController.UpdateProductComponentWeb)
+	router.POST("/products/:id/components/:component_id/delete", productController.RemoveProductComponentWeb)
+	router.POST("/products/:id/recalculate-cost", productController.RecalculateCostWeb)
+	router.POST("/products/:id/archive", productController.ArchiveProductWeb)
+	router.POST("/products/:id/restore", productController.RestoreProductWeb)
+	router.POST("/products/:id/clone", productController.CloneProductWeb)
+	router.POST("/products/:id/image", imageController.UploadProductImageWeb)
+	router.POST("/products/:id/image/delete", imageController.DeleteProductImageWeb)
+
+	// Варианты (расцветки) продукции
+	router.GET("/products/:id/variants", variantController.GetVariantsPage)
+	router.POST("/products/:id/variants", variantController.CreateVariantWeb)
+	router.POST("/variants/:id", variantController.UpdateVariantWeb)
+	router.POST("/variants/:id/delete", variantController.DeleteVariantWeb)
+	router.POST("/variants/:id/materials", variantController.SetVariantOverrideWeb)
+	router.POST("/variants/:id/materials/:material_id/delete", variantController.RemoveVariantOverrideWeb)
+	router.POST("/variants/:id/image", imageController.UploadVariantImageWeb)
+	router.POST("/variants/:id/image/delete", imageController.DeleteVariantImageWeb)
 
 	// Материалы
 	router.GET("/materials", materialController.GetMaterialsPage)
@@ -47,10 +99,70 @@ func setupWebRoutes(
 	router.GET("/materials/:id/edit", materialController.GetEditMaterialPage)
 	router.POST("/materials/:id", materialController.UpdateMaterialWeb)
 	router.GET("/materials/:id", materialController.GetMaterialDetailsPage)
+	router.POST("/materials/:id/archive", materialController.ArchiveMaterialWeb)
+	router.POST("/materials/:id/restore", materialController.RestoreMaterialWeb)
+	router.POST("/materials/:id/image", imageController.UploadMaterialImageWeb)
+	router.POST("/materials/:id/image/delete", imageController.DeleteMaterialImageWeb)
+	router.GET("/materials/:id/units", unitController.GetMaterialUnitsPage)
+	router.POST("/materials/:id/units", unitController.SaveMaterialConversionWeb)
+	router.POST("/materials/:id/units/:unit_id/delete", unitController.DeleteMaterialConversionWeb)
+	router.GET("/materials/:id/history", movementController.GetMaterialHistoryPage)
+	router.POST("/materials/:id/history", movementController.PostMaterialMovementWeb)
+	router.GET("/materials/:id/lots", lotController.GetMaterialLotsPage)
+	router.POST("/materials/:id/lots", lotController.ReceiveSupplyWeb)
+	router.GET("/materials/:id/costing", costingController.GetCostingPage)
+	router.POST("/materials/:id/costing", costingController.UpdateCostingWeb)
+	router.POST("/materials/:id/costing/recalculate", costingController.RecalculateCostWeb)
+
+	// Прослеживаемость партий материалов
+	router.GET("/lots/:id", lotController.GetLotPage)
+
+	// Пополнение склада
+	router.GET("/materials/low-stock", stockAlertController.GetLowStockPage)
+	router.POST("/stock-notifications/check", stockAlertController.CheckLowStockWeb)
+	router.POST("/stock-notifications/:id/acknowledge", stockAlertController.AcknowledgeNotificationWeb)
+
+	// Инвентаризация
+	router.GET("/stocktakes", stocktakeController.GetStocktakesPage)
+	router.POST("/stocktakes", stocktakeController.OpenStocktakeWeb)
+	router.GET("/stocktakes/:id", stocktakeController.GetStocktakePage)
+	router.POST("/stocktakes/:id/counts", stocktakeController.SaveStocktakeCountsWeb)
+	router.POST("/stocktakes/:id/close", stocktakeController.CloseStocktakeWeb)
+	router.POST("/stocktakes/:id/cancel", stocktakeController.CancelStocktakeWeb)
 
 	// Калькулятор
 	router.GET("/calculator", calculatorController.GetCalculatorPage)
 	router.POST("/calculator", calculatorController.CalculateMaterial)
+	router.GET("/calculator/room", calculatorController.GetRoomCalculatorPage)
+	router.POST("/calculator/room", calculatorController.CalculateRoom)
+
+	// Поиск
+	router.GET("/search", searchController.GetSearchPage)
+
+	// Импорт из файлов CSV и XLSX
+	router.GET("/import", importController.GetImportPage)
+	router.POST("/import", importController.ImportWeb)
+
+	// Сертификаты качества
+	router.GET("/certificates", certificateController.GetCertificatesPage)
+	router.POST("/certificates", certificateController.CreateCertificateWeb)
+	router.POST("/certificates/:id/file", certificateController.UploadCertificateFileWeb)
+	router.POST("/certificates/:id/delete", certificateController.DeleteCertificateWeb)
+
+	// Типы продукции
+	router.GET("/product-types", productTypeController.GetProductTypesPage)
+	router.POST("/product-types", productTypeController.CreateProductTypeWeb)
+	router.GET("/product-types/:id", productTypeController.GetProductTypeEditPage)
+	router.POST("/product-types/:id", productTypeController.UpdateProductTypeWeb)
+	router.POST("/product-types/:id/archive", productTypeController.ArchiveProductTypeWeb)
+	router.POST("/product-types/:id/restore", productTypeController.RestoreProductTypeWeb)
+
+	// Типы материалов
+	router.GET("/material-types", materialTypeController.GetMaterialTypesPage)
+	router.POST("/material-types", materialTypeController.CreateMaterialTypeWeb)
+	router.GET("/material-types/:id", materialTypeController.GetMaterialTypeEditPage)
+	router.POST("/material-types/:id", materialTypeController.UpdateMaterialTypeWeb)
+	router.POST("/material-types/:id/delete", materialTypeController.DeleteMaterialTypeWeb)
 }
 
 // setupAPIRoutes настраивает API маршруты
@@ -59,6 +171,23 @@ func setupAPIRoutes(
 	productController *controllers.ProductController,
 	calculatorController *controllers.CalculatorController,
 	materialController *controllers.MaterialController,
+	pricingRuleController *controllers.PricingRuleController,
+	searchController *controllers.SearchController,
+	importController *controllers.ImportController,
+	exportController *controllers.ExportController,
+	imageController *controllers.ImageController,
+	certificateController *controllers.CertificateController,
+	variantController *controllers.VariantController,
+	productTypeController *controllers.ProductTypeController,
+	materialTypeController *controllers.MaterialTypeController,
+	unitController *controllers.UnitController,
+	shippingController *controllers.ShippingController,
+	movementController *controllers.MovementController,
+	stockAlertController *controllers.StockAlertController,
+	orderController *controllers.OrderController,
+	stocktakeController *controllers.StocktakeController,
+	lotController *controllers.LotController,
+	costingController *controllers.CostingController,
 ) {
 	api := router.Group("/api/v1")
 	{
@@ -69,7 +198,41 @@ func setupAPIRoutes(
 			products.GET("/:id", productController.GetProductByID)
 			products.POST("", productController.CreateProduct)
 			products.PUT("/:id", productController.UpdateProduct)
-			products.DELETE("/:id", productController.DeleteProduct)
+			products.DELETE("/:id", productController.ArchiveProduct)
+			products.POST("/:id/restore", productController.RestoreProduct)
+			products.POST("/:id/clone", productController.CloneProduct)
+
+			// Рецептура продукции
+			products.GET("/:id/materials", productController.GetProductMaterials)
+			products.POST("/:id/materials", productController.AddProductMaterial)
+			products.PUT("/:id/materials", productController.ReplaceProductMaterials)
+			products.PUT("/:id/materials/:material_id", productController.UpdateProductMaterial)
+			products.DELETE("/:id/materials/:material_id", productController.RemoveProductMaterial)
+			products.GET("/:id/components", productController.GetProductComponents)
+			products.POST("/:id/components", productController.AddProductComponent)
+			products.PUT("/:id/components/:component_id", productController.UpdateProductComponent)
+			products.DELETE("/:id/components/:component_id", productController.RemoveProductComponent)
+			products.GET("/:id/explosion", productController.GetMaterialExplosion)
+			products.GET("/:id/price", productController.GetProductPrice)
+
+			// Изображение продукции
+			products.POST("/:id/image", imageController.UploadProductImage)
+			products.DELETE("/:id/image", imageController.DeleteProductImage)
+
+			// Сертификаты качества продукции
+			products.GET("/:id/certificates", certificateController.GetProductCertificates)
+
+			// Варианты (расцветки) продукции
+			products.GET("/:id/variants", variantController.GetProductVariants)
+			products.POST("/:id/variants", variantController.CreateVariant)
+
+			// Себестоимость по рецептуре
+			products.POST("/:id/recalculate-cost", productController.RecalculateCost)
+			products.POST("/recalculate-costs", productController.RecalculateAllCosts)
+
+			// Импорт из файла и выгрузка прайс-листа
+			products.POST("/import", importController.ImportProducts)
+			products.GET("/export", exportController.ExportProducts)
 		}
 
 		// Материалы API
@@ -79,18 +242,148 @@ func setupAPIRoutes(
 			materials.GET("/:id", materialController.GetMaterialByID)
 			materials.POST("", materialController.CreateMaterial)
 			materials.PUT("/:id", materialController.UpdateMaterial)
-			materials.DELETE("/:id", materialController.DeleteMaterial)
+			materials.DELETE("/:id", materialController.ArchiveMaterial)
+			materials.POST("/:id/restore", materialController.RestoreMaterial)
+			materials.POST("/:id/image", imageController.UploadMaterialImage)
+			materials.DELETE("/:id/image", imageController.DeleteMaterialImage)
+			materials.POST("/import", importController.ImportMaterials)
+			materials.GET("/export", exportController.ExportMaterials)
+
+			// Единицы измерения и пересчеты материала
+			materials.GET("/:id/units", unitController.GetMaterialUnits)
+			materials.GET("/:id/stock", unitController.GetMaterialStock)
+			materials.GET("/:id/unit-conversions", unitController.GetMaterialConversions)
+			materials.POST("/:id/unit-conversions", unitController.SaveMaterialConversion)
+			materials.DELETE("/:id/unit-conversions/:unit_id", unitController.DeleteMaterialConversion)
+
+			// Складской журнал материала
+			materials.GET("/:id/movements", movementController.GetMaterialMovements)
+			materials.POST("/:id/movements", movementController.PostMaterialMovement)
+
+			// Партии материала и прием поставок
+			materials.GET("/:id/lots", lotController.GetMaterialLots)
+			materials.POST("/:id/lots", lotController.ReceiveSupply)
+
+			// Себестоимость материала по журналу
+			materials.GET("/:id/costing", costingController.GetCosting)
+			materials.PUT("/:id/costing", costingController.UpdateCosting)
+			materials.POST("/:id/costing/recalculate", costingController.RecalculateCost)
+
+			// Материалы с остатком не выше минимального
+			materials.GET("/low-stock", stockAlertController.GetLowStock)
+		}
+
+		// Уведомления о низком остатке материалов API
+		stockNotifications := api.Group("/stock-notifications")
+		{
+			stockNotifications.GET("", stockAlertController.GetNotifications)
+			stockNotifications.POST("/check", stockAlertController.CheckLowStock)
+			stockNotifications.POST("/:id/acknowledge", stockAlertController.AcknowledgeNotification)
 		}
 
+		// Поставщики и прослеживаемость партий материалов
+		api.GET("/suppliers", lotController.GetSuppliers)
+		api.GET("/lots/:id", lotController.GetLotTrace)
+
+		// Инвентаризация материалов API
+		stocktakes := api.Group("/stocktakes")
+		{
+			stocktakes.GET("", stocktakeController.GetStocktakes)
+			stocktakes.POST("", stocktakeController.OpenStocktake)
+			stocktakes.GET("/:id", stocktakeController.GetStocktake)
+			stocktakes.PUT("/:id/counts", stocktakeController.SaveStocktakeCounts)
+			stocktakes.POST("/:id/close", stocktakeController.CloseStocktake)
+			stocktakes.POST("/:id/cancel", stocktakeController.CancelStocktake)
+		}
+
+		// Варианты продукции API
+		variants := api.Group("/variants")
+		{
+			variants.GET("/:id", variantController.GetVariantByID)
+			variants.PUT("/:id", variantController.UpdateVariant)
+			variants.DELETE("/:id", variantController.DeleteVariant)
+			variants.PUT("/:id/materials", variantController.SetVariantOverride)
+			variants.DELETE("/:id/materials/:material_id", variantController.RemoveVariantOverride)
+			variants.GET("/:id/price", variantController.GetVariantPrice)
+			variants.GET("/:id/explosion", variantController.GetVariantExplosion)
+			variants.POST("/:id/image", imageController.UploadVariantImage)
+			variants.DELETE("/:id/image", imageController.DeleteVariantImage)
+		}
+
+		// Правила ценообразования API
+		pricingRules := api.Group("/pricing-rules")
+		{
+			pricingRules.GET("", pricingRuleController.GetPricingRules)
+			pricingRules.GET("/:id", pricingRuleController.GetPricingRuleByID)
+			pricingRules.POST("", pricingRuleController.CreatePricingRule)
+			pricingRules.PUT("/:id", pricingRuleController.UpdatePricingRule)
+			pricingRules.DELETE("/:id", pricingRuleController.DeletePricingRule)
+		}
+
+		// Сертификаты качества API
+		certificates := api.Group("/certificates")
+		{
+			certificates.GET("", certificateController.GetCertificates)
+			certificates.GET("/expiring", certificateController.GetExpiringCertificates)
+			certificates.POST("/check", certificateController.CheckProducts)
+			certificates.GET("/:id", certificateController.GetCertificateByID)
+			certificates.POST("", certificateController.CreateCertificate)
+			certificates.PUT("/:id", certificateController.UpdateCertificate)
+			certificates.DELETE("/:id", certificateController.DeleteCertificate)
+			certificates.POST("/:id/file", certificateController.UploadCertificateFile)
+		}
+
+		// Типы продукции API
+		productTypes := api.Group("/product-types")
+		{
+			productTypes.GET("", productTypeController.GetProductTypes)
+			productTypes.GET("/:id", productTypeController.GetProductTypeByID)
+			productTypes.POST("", productTypeController.CreateProductType)
+			productTypes.PUT("/:id", productTypeController.UpdateProductType)
+			productTypes.DELETE("/:id", productTypeController.ArchiveProductType)
+			productTypes.POST("/:id/restore", productTypeController.RestoreProductType)
+			productTypes.GET("/:id/coefficient-history", productTypeController.GetCoefficientHistory)
+		}
+
+		// Типы материалов API
+		materialTypes := api.Group("/material-types")
+		{
+			materialTypes.GET("", materialTypeController.GetMaterialTypes)
+			materialTypes.GET("/:id", materialTypeController.GetMaterialTypeByID)
+			materialTypes.POST("", materialTypeController.CreateMaterialType)
+			materialTypes.PUT("/:id", materialTypeController.UpdateMaterialType)
+			materialTypes.DELETE("/:id", materialTypeController.DeleteMaterialType)
+			materialTypes.GET("/:id/defect-rate-history", materialTypeController.GetDefectRateHistory)
+		}
+
+		// Статусы заказов и резервы материалов
+		api.GET("/orders/:id", orderController.GetOrder)
+		api.PUT("/orders/:id/status", orderController.ChangeOrderStatus)
+		api.GET("/orders/:id/reservations", orderController.GetOrderReservations)
+
+		// Партии материалов, списанные в расход по заказу
+		api.GET("/orders/:id/lots", lotController.GetOrderLots)
+
+		// Предупреждения о продаже несертифицированной продукции в заказе
+		api.GET("/orders/:id/certificate-warnings", certificateController.GetOrderCertificateWarnings)
+
+		// Расчет отгрузки заказа на поддонах
+		api.GET("/orders/:id/shipping", shippingController.GetOrderShipment)
+
 		// Калькулятор API
 		calculator := api.Group("/calculator")
 		{
 			calculator.POST("/calculate", calculatorController.CalculateMaterialAPI)
+			calculator.POST("/room", calculatorController.CalculateRoomAPI)
+			calculator.POST("/shipping", shippingController.CalculateShipment)
 		}
 
+		// Полнотекстовый поиск API
+		api.GET("/search", searchController.Search)
+
 		// Справочники API
-		api.GET("/product-types", productController.GetProductTypes)
-		api.GET("/material-types", materialController.GetMaterialTypes)
-		api.GET("/measurement-units", materialController.GetMeasurementUnits)
+		api.GET("/measurement-units", unitController.GetMeasurementUnits)
+		api.POST("/measurement-units/convert", unitController.ConvertQuantity)
+		api.GET("/partner-types", pricingRuleController.GetPartnerTypes)
 	}
 }
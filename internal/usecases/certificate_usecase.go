@@ -0,0 +1,207 @@
+package usecases
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// CertificateUseCase содержит бизнес-логику сертификатов качества продукции
+type CertificateUseCase struct {
+	certificateRepo repositories.CertificateRepository
+	productRepo     repositories.ProductRepository
+	storage         repositories.FileStorage
+}
+
+// NewCertificateUseCase создает новый use case сертификатов качества
+func NewCertificateUseCase(
+	certificateRepo repositories.CertificateRepository,
+	productRepo repositories.ProductRepository,
+	storage repositories.FileStorage,
+) *CertificateUseCase {
+	return &CertificateUseCase{
+		certificateRepo: certificateRepo,
+		productRepo:     productRepo,
+		storage:         storage,
+	}
+}
+
+// GetAllCertificates возвращает все сертификаты
+func (uc *CertificateUseCase) GetAllCertificates() ([]entities.QualityCertificate, error) {
+	return uc.certificateRepo.GetAll()
+}
+
+// GetCertificateByID возвращает сертификат по ID
+func (uc *CertificateUseCase) GetCertificateByID(id int) (*entities.QualityCertificate, error) {
+	return uc.certificateRepo.GetByID(id)
+}
+
+// GetProductCertificates возвращает сертификаты, распространяющиеся на продукцию
+func (uc *CertificateUseCase) GetProductCertificates(productID int) ([]entities.QualityCertificate, error) {
+	if _, err := uc.productRepo.GetByID(productID); err != nil {
+		return nil, err
+	}
+
+	return uc.certificateRepo.GetByProduct(productID)
+}
+
+// CreateCertificate создает сертификат
+func (uc *CertificateUseCase) CreateCertificate(certificate *entities.QualityCertificate) error {
+	if err := uc.validateCertificate(certificate); err != nil {
+		return err
+	}
+
+	return uc.certificateRepo.Create(certificate)
+}
+
+// UpdateCertificate обновляет реквизиты сертификата и список продукции
+func (uc *CertificateUseCase) UpdateCertificate(certificate *entities.QualityCertificate) error {
+	if _, err := uc.certificateRepo.GetByID(certificate.ID); err != nil {
+		return err
+	}
+
+	if err := uc.validateCertificate(certificate); err != nil {
+		return err
+	}
+
+	return uc.certificateRepo.Update(certificate)
+}
+
+// DeleteCertificate удаляет сертификат вместе со сканом
+func (uc *CertificateUseCase) DeleteCertificate(id int) error {
+	certificate, err := uc.certificateRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.certificateRepo.Delete(id); err != nil {
+		return err
+	}
+
+	// Ошибка удаления файла не отменяет удаление сертификата: оставшийся скан ни на что не влияет
+	if certificate.FilePath != nil {
+		_ = uc.storage.Delete(*certificate.FilePath)
+	}
+	return nil
+}
+
+// UploadCertificateFile сохраняет скан сертификата и заменяет прежний
+func (uc *CertificateUseCase) UploadCertificateFile(id int, data []byte, contentType string) (*entities.QualityCertificate, error) {
+	extension, err := entities.CertificateFileExtension(contentType)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > entities.MaxCertificateFileSize {
+		return nil, entities.NewValidationError("file",
+			fmt.Sprintf("размер скана превышает %d МБ", entities.MaxCertificateFileSize>>20))
+	}
+
+	certificate, err := uc.certificateRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	path, err := uc.storage.Save(fmt.Sprintf("certificates/%d/%s%s", id, hex.EncodeToString(sum[:8]), extension), data)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сохранения скана сертификата: %w", err)
+	}
+
+	if err := uc.certificateRepo.UpdateFilePath(id, &path); err != nil {
+		_ = uc.storage.Delete(path)
+		return nil, err
+	}
+
+	if previous := certificate.FilePath; previous != nil && *previous != path {
+		_ = uc.storage.Delete(*previous)
+	}
+
+	certificate.FilePath = &path
+	return certificate, nil
+}
+
+// GetExpiringCertificates возвращает продукцию, сертификаты которой истекают в ближайшие days дней
+// или уже истекли. days == 0 - горизонт по умолчанию
+func (uc *CertificateUseCase) GetExpiringCertificates(days int) ([]entities.ExpiringCertificate, error) {
+	if days == 0 {
+		days = entities.DefaultCertificateExpiryDays
+	}
+	if days < 0 || days > entities.MaxCertificateExpiryDays {
+		return nil, entities.NewValidationError("days",
+			fmt.Sprintf("горизонт отчета должен быть от 1 до %d дней", entities.MaxCertificateExpiryDays))
+	}
+
+	today := time.Now()
+	expiring, err := uc.certificateRepo.GetExpiring(today.AddDate(0, 0, days))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range expiring {
+		expiring[i].DaysLeft = entities.DaysUntil(today, expiring[i].ValidTo)
+	}
+	return expiring, nil
+}
+
+// CheckProducts возвращает предупреждения о продукции без действующего на дату сертификата
+func (uc *CertificateUseCase) CheckProducts(productIDs []int, date time.Time) ([]entities.CertificateWarning, error) {
+	if len(productIDs) == 0 {
+		return nil, entities.NewValidationError("product_ids", "укажите продукцию для проверки")
+	}
+
+	certifications, err := uc.certificateRepo.GetCertification(productIDs, date)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[int]bool, len(certifications))
+	for _, certification := range certifications {
+		found[certification.Product.ID] = true
+	}
+	for _, id := range productIDs {
+		if !found[id] {
+			return nil, entities.NewNotFoundError("продукция", strconv.Itoa(id))
+		}
+	}
+
+	warnings := []entities.CertificateWarning{}
+	for i := range certifications {
+		if warning := certifications[i].Warning(); warning != nil {
+			warnings = append(warnings, *warning)
+		}
+	}
+	return warnings, nil
+}
+
+// CheckOrder возвращает предупреждения о продаже в заказе продукции без действующего сертификата
+func (uc *CertificateUseCase) CheckOrder(orderID int, date time.Time) ([]entities.CertificateWarning, error) {
+	productIDs, err := uc.certificateRepo.GetOrderProductIDs(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if len(productIDs) == 0 {
+		return []entities.CertificateWarning{}, nil
+	}
+
+	return uc.CheckProducts(productIDs, date)
+}
+
+// validateCertificate проверяет сертификат и существование продукции
+func (uc *CertificateUseCase) validateCertificate(certificate *entities.QualityCertificate) error {
+	if err := certificate.Validate(); err != nil {
+		return fmt.Errorf("ошибка валидации: %w", err)
+	}
+
+	for _, productID := range certificate.ProductIDs {
+		if _, err := uc.productRepo.GetByID(productID); err != nil {
+			return fmt.Errorf("продукция не найдена: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,255 @@
+package usecases
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CertificateUseCaseTestSuite struct {
+	suite.Suite
+	certificateRepo *mocks.MockCertificateRepository
+	productRepo     *mocks.MockProductRepository
+	storage         *mocks.MockFileStorage
+	useCase         *CertificateUseCase
+}
+
+func (suite *CertificateUseCaseTestSuite) SetupTest() {
+	suite.certificateRepo = new(mocks.MockCertificateRepository)
+	suite.productRepo = new(mocks.MockProductRepository)
+	suite.storage = new(mocks.MockFileStorage)
+	suite.useCase = NewCertificateUseCase(suite.certificateRepo, suite.productRepo, suite.storage)
+}
+
+func newTestCertificate() *entities.QualityCertificate {
+	return &entities.QualityCertificate{
+		Number:      "РОСС RU.АГ17.Н01234",
+		IssuingBody: "ООО «Сертификационный центр»",
+		ValidFrom:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		ValidTo:     time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+		ProductIDs:  []int{1, 2},
+	}
+}
+
+func (suite *CertificateUseCaseTestSuite) TestCreateCertificate_Success() {
+	// Подготовка данных
+	certificate := newTestCertificate()
+
+	// Настройка моков
+	suite.productRepo.On("GetByID", 1).Return(&entities.Product{ID: 1}, nil)
+	suite.productRepo.On("GetByID", 2).Return(&entities.Product{ID: 2}, nil)
+	suite.certificateRepo.On("Create", certificate).Return(nil)
+
+	// Выполнение
+	err := suite.useCase.CreateCertificate(certificate)
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	suite.certificateRepo.AssertExpectations(suite.T())
+}
+
+func (suite *CertificateUseCaseTestSuite) TestCreateCertificate_UnknownProduct() {
+	// Подготовка данных
+	certificate := newTestCertificate()
+
+	// Настройка моков
+	suite.productRepo.On("GetByID", 1).Return(&entities.Product{ID: 1}, nil)
+	suite.productRepo.On("GetByID", 2).Return(nil, entities.NewNotFoundError("продукция", "2"))
+
+	// Выполнение
+	err := suite.useCase.CreateCertificate(certificate)
+
+	// Проверки
+	var notFoundErr *entities.NotFoundError
+	assert.ErrorAs(suite.T(), err, &notFoundErr)
+	suite.certificateRepo.AssertNotCalled(suite.T(), "Create", mock.Anything)
+}
+
+func (suite *CertificateUseCaseTestSuite) TestCreateCertificate_InvalidPeriod() {
+	// Подготовка данных
+	certificate := newTestCertificate()
+	certificate.ValidTo = certificate.ValidFrom.AddDate(0, 0, -1)
+
+	// Выполнение
+	err := suite.useCase.CreateCertificate(certificate)
+
+	// Проверки
+	var validationErr *entities.ValidationError
+	assert.ErrorAs(suite.T(), err, &validationErr)
+	suite.certificateRepo.AssertNotCalled(suite.T(), "Create", mock.Anything)
+}
+
+func (suite *CertificateUseCaseTestSuite) TestUploadCertificateFile_ReplacesPreviousScan() {
+	// Подготовка данных
+	oldPath := "/uploads/certificates/5/old.pdf"
+	certificate := newTestCertificate()
+	certificate.ID = 5
+	certificate.FilePath = &oldPath
+	data := []byte("%PDF-1.4 scan")
+	newPath := "/uploads/certificates/5/scan.pdf"
+
+	// Настройка моков
+	suite.certificateRepo.On("GetByID", 5).Return(certificate, nil)
+	suite.storage.On("Save", mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, "certificates/5/") && strings.HasSuffix(key, ".pdf")
+	}), data).Return(newPath, nil)
+	suite.certificateRepo.On("UpdateFilePath", 5, &newPath).Return(nil)
+	suite.storage.On("Delete", oldPath).Return(nil)
+
+	// Выполнение
+	result, err := suite.useCase.UploadCertificateFile(5, data, "application/pdf")
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), newPath, *result.FilePath)
+	suite.storage.AssertExpectations(suite.T())
+}
+
+func (suite *CertificateUseCaseTestSuite) TestUploadCertificateFile_UnsupportedFormat() {
+	// Выполнение
+	_, err := suite.useCase.UploadCertificateFile(5, []byte("PK\x03\x04"), "application/zip")
+
+	// Проверки
+	var validationErr *entities.ValidationError
+	assert.ErrorAs(suite.T(), err, &validationErr)
+	suite.storage.AssertNotCalled(suite.T(), "Save", mock.Anything, mock.Anything)
+}
+
+func (suite *CertificateUseCaseTestSuite) TestUploadCertificateFile_UpdateFailedRemovesNewFile() {
+	// Подготовка данных
+	certificate := newTestCertificate()
+	certificate.ID = 5
+	newPath := "/uploads/certificates/5/scan.png"
+
+	// Настройка моков
+	suite.certificateRepo.On("GetByID", 5).Return(certificate, nil)
+	suite.storage.On("Save", mock.AnythingOfType("string"), mock.Anything).Return(newPath, nil)
+	suite.certificateRepo.On("UpdateFilePath", 5, &newPath).Return(errors.New("connection reset"))
+	suite.storage.On("Delete", newPath).Return(nil)
+
+	// Выполнение
+	_, err := suite.useCase.UploadCertificateFile(5, []byte("png"), "image/png")
+
+	// Проверки
+	assert.Error(suite.T(), err)
+	suite.storage.AssertExpectations(suite.T())
+}
+
+func (suite *CertificateUseCaseTestSuite) TestDeleteCertificate_RemovesScan() {
+	// Подготовка данных
+	path := "/uploads/certificates/5/scan.pdf"
+	certificate := newTestCertificate()
+	certificate.ID = 5
+	certificate.FilePath = &path
+
+	// Настройка моков
+	suite.certificateRepo.On("GetByID", 5).Return(certificate, nil)
+	suite.certificateRepo.On("Delete", 5).Return(nil)
+	suite.storage.On("Delete", path).Return(nil)
+
+	// Выполнение
+	err := suite.useCase.DeleteCertificate(5)
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	suite.storage.AssertExpectations(suite.T())
+}
+
+func (suite *CertificateUseCaseTestSuite) TestGetExpiringCertificates_DefaultHorizonAndDaysLeft() {
+	// Подготовка данных: сертификат истекает через 10 дней, другой истек 5 дней назад
+	today := time.Now()
+	expiring := []entities.ExpiringCertificate{
+		{Product: entities.CertificateProduct{ID: 1, Article: "WP-001"}, ValidTo: today.AddDate(0, 0, -5)},
+		{Product: entities.CertificateProduct{ID: 2, Article: "WP-002"}, ValidTo: today.AddDate(0, 0, 10)},
+	}
+
+	// Настройка мока: горизонт по умолчанию - 30 дней от сегодня
+	suite.certificateRepo.On("GetExpiring", mock.MatchedBy(func(until time.Time) bool {
+		return entities.DaysUntil(today, until) == entities.DefaultCertificateExpiryDays
+	})).Return(expiring, nil)
+
+	// Выполнение
+	result, err := suite.useCase.GetExpiringCertificates(0)
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), -5, result[0].DaysLeft)
+	assert.Equal(suite.T(), 10, result[1].DaysLeft)
+}
+
+func (suite *CertificateUseCaseTestSuite) TestGetExpiringCertificates_InvalidHorizon() {
+	// Выполнение
+	_, err := suite.useCase.GetExpiringCertificates(-1)
+
+	// Проверки
+	var validationErr *entities.ValidationError
+	assert.ErrorAs(suite.T(), err, &validationErr)
+}
+
+func (suite *CertificateUseCaseTestSuite) TestCheckOrder_WarnsAboutUncertifiedProducts() {
+	// Подготовка данных: WP-001 сертифицирована, у WP-002 сертификат истек, у WP-003 его нет
+	date := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	validTo := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+	expiredAt := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	certifications := []entities.ProductCertification{
+		{Product: entities.CertificateProduct{ID: 1, Article: "WP-001"}, ValidTo: &validTo, LastValidTo: &validTo},
+		{Product: entities.CertificateProduct{ID: 2, Article: "WP-002"}, LastValidTo: &expiredAt},
+		{Product: entities.CertificateProduct{ID: 3, Article: "WP-003"}},
+	}
+
+	// Настройка моков
+	suite.certificateRepo.On("GetOrderProductIDs", 1).Return([]int{1, 2, 3}, nil)
+	suite.certificateRepo.On("GetCertification", []int{1, 2, 3}, date).Return(certifications, nil)
+
+	// Выполнение
+	warnings, err := suite.useCase.CheckOrder(1, date)
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), warnings, 2)
+	assert.Equal(suite.T(), entities.CertificateWarningExpired, warnings[0].Reason)
+	assert.Equal(suite.T(), "WP-002", warnings[0].Product.Article)
+	assert.Equal(suite.T(), entities.CertificateWarningMissing, warnings[1].Reason)
+}
+
+func (suite *CertificateUseCaseTestSuite) TestCheckOrder_EmptyOrder() {
+	// Настройка моков
+	suite.certificateRepo.On("GetOrderProductIDs", 1).Return([]int{}, nil)
+
+	// Выполнение
+	warnings, err := suite.useCase.CheckOrder(1, time.Now())
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), warnings)
+	suite.certificateRepo.AssertNotCalled(suite.T(), "GetCertification", mock.Anything, mock.Anything)
+}
+
+func (suite *CertificateUseCaseTestSuite) TestCheckProducts_UnknownProduct() {
+	// Подготовка данных
+	date := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	// Настройка моков: продукции 99 нет
+	suite.certificateRepo.On("GetCertification", []int{1, 99}, date).Return([]entities.ProductCertification{
+		{Product: entities.CertificateProduct{ID: 1, Article: "WP-001"}},
+	}, nil)
+
+	// Выполнение
+	_, err := suite.useCase.CheckProducts([]int{1, 99}, date)
+
+	// Проверки
+	var notFoundErr *entities.NotFoundError
+	assert.ErrorAs(suite.T(), err, &notFoundErr)
+}
+
+func TestCertificateUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CertificateUseCaseTestSuite))
+}
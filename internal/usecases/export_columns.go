@@ -0,0 +1,257 @@
+package usecases
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"wallpaper-system/internal/domain/entities"
+)
+
+// productExportColumn описывает столбец выгрузки продукции и способ получить его значение
+type productExportColumn struct {
+	entities.ExportColumn
+	value func(product *entities.Product) entities.ExportCell
+}
+
+// productExportColumns - столбцы, доступные в выгрузке продукции. Заголовки совпадают
+// с заголовками импорта, поэтому выгруженный файл можно загрузить обратно
+var productExportColumns = []productExportColumn{
+	{entities.ExportColumn{Key: "article", Title: "Артикул"}, func(p *entities.Product) entities.ExportCell {
+		return entities.TextCell(p.Article)
+	}},
+	{entities.ExportColumn{Key: "name", Title: "Наименование"}, func(p *entities.Product) entities.ExportCell {
+		return entities.TextCell(p.Name)
+	}},
+	{entities.ExportColumn{Key: "product_type", Title: "Тип продукции"}, func(p *entities.Product) entities.ExportCell {
+		return entities.TextCell(productTypeName(p))
+	}},
+	{entities.ExportColumn{Key: "description", Title: "Описание"}, func(p *entities.Product) entities.ExportCell {
+		return entities.TextCell(stringValue(p.Description))
+	}},
+	{entities.ExportColumn{Key: "min_partner_price", Title: "Мин. цена для партнера", Numeric: true}, func(p *entities.Product) entities.ExportCell {
+		return entities.NumberCell(&p.MinPartnerPrice)
+	}},
+	{entities.ExportColumn{Key: "price", Title: "Цена", Numeric: true}, func(p *entities.Product) entities.ExportCell {
+		return entities.NumberCell(p.CalculatedPrice)
+	}},
+	{entities.ExportColumn{Key: "pricing_rule", Title: "Правило ценообразования"}, func(p *entities.Product) entities.ExportCell {
+		if p.AppliedPricingRule == nil {
+			return entities.TextCell("")
+		}
+		return entities.TextCell(p.AppliedPricingRule.Name)
+	}},
+	{entities.ExportColumn{Key: "cost", Title: "Себестоимость", Numeric: true}, func(p *entities.Product) entities.ExportCell {
+		return entities.NumberCell(p.EffectiveCost())
+	}},
+	{entities.ExportColumn{Key: "roll_width", Title: "Ширина рулона (м)", Numeric: true}, func(p *entities.Product) entities.ExportCell {
+		return entities.NumberCell(p.RollWidth)
+	}},
+	{entities.ExportColumn{Key: "roll_length", Title: "Длина рулона (м)", Numeric: true}, func(p *entities.Product) entities.ExportCell {
+		return entities.NumberCell(p.RollLength)
+	}},
+	{entities.ExportColumn{Key: "pattern_repeat", Title: "Раппорт (м)", Numeric: true}, func(p *entities.Product) entities.ExportCell {
+		return entities.NumberCell(p.PatternRepeat)
+	}},
+	{entities.ExportColumn{Key: "package_length", Title: "Длина упаковки (м)", Numeric: true}, func(p *entities.Product) entities.ExportCell {
+		return entities.NumberCell(p.PackageLength)
+	}},
+	{entities.ExportColumn{Key: "package_width", Title: "Ширина упаковки (м)", Numeric: true}, func(p *entities.Product) entities.ExportCell {
+		return entities.NumberCell(p.PackageWidth)
+	}},
+	{entities.ExportColumn{Key: "package_height", Title: "Высота упаковки (м)", Numeric: true}, func(p *entities.Product) entities.ExportCell {
+		return entities.NumberCell(p.PackageHeight)
+	}},
+	{entities.ExportColumn{Key: "weight_without_package", Title: "Вес без упаковки (кг)", Numeric: true}, func(p *entities.Product) entities.ExportCell {
+		return entities.NumberCell(p.WeightWithoutPackage)
+	}},
+	{entities.ExportColumn{Key: "weight_with_package", Title: "Вес с упаковкой (кг)", Numeric: true}, func(p *entities.Product) entities.ExportCell {
+		return entities.NumberCell(p.WeightWithPackage)
+	}},
+	{entities.ExportColumn{Key: "standard_number", Title: "Номер стандарта"}, func(p *entities.Product) entities.ExportCell {
+		return entities.TextCell(stringValue(p.StandardNumber))
+	}},
+	{entities.ExportColumn{Key: "production_time_hours", Title: "Время производства (ч)", Numeric: true}, func(p *entities.Product) entities.ExportCell {
+		return entities.NumberCell(p.ProductionTimeHours)
+	}},
+	{entities.ExportColumn{Key: "workshop_number", Title: "Номер цеха"}, func(p *entities.Product) entities.ExportCell {
+		return entities.TextCell(stringValue(p.WorkshopNumber))
+	}},
+	{entities.ExportColumn{Key: "required_workers", Title: "Количество рабочих", Numeric: true}, func(p *entities.Product) entities.ExportCell {
+		if p.RequiredWorkers == nil {
+			return entities.NumberCell(nil)
+		}
+		workers := float64(*p.RequiredWorkers)
+		return entities.NumberCell(&workers)
+	}},
+}
+
+// defaultProductExportColumns - столбцы прайс-листа по умолчанию
+var defaultProductExportColumns = []string{"article", "name", "product_type", "min_partner_price", "price"}
+
+// materialExportColumn описывает столбец выгрузки материалов и способ получить его значение
+type materialExportColumn struct {
+	entities.ExportColumn
+	value func(material *entities.Material) entities.ExportCell
+}
+
+// materialExportColumns - столбцы, доступные в выгрузке материалов
+var materialExportColumns = []materialExportColumn{
+	{entities.ExportColumn{Key: "article", Title: "Артикул"}, func(m *entities.Material) entities.ExportCell {
+		return entities.TextCell(m.Article)
+	}},
+	{entities.ExportColumn{Key: "name", Title: "Наименование"}, func(m *entities.Material) entities.ExportCell {
+		return entities.TextCell(m.Name)
+	}},
+	{entities.ExportColumn{Key: "material_type", Title: "Тип материала"}, func(m *entities.Material) entities.ExportCell {
+		return entities.TextCell(materialTypeName(m))
+	}},
+	{entities.ExportColumn{Key: "measurement_unit", Title: "Единица измерения"}, func(m *entities.Material) entities.ExportCell {
+		if m.MeasurementUnit == nil {
+			return entities.TextCell("")
+		}
+		return entities.TextCell(m.MeasurementUnit.Abbreviation)
+	}},
+	{entities.ExportColumn{Key: "package_quantity", Title: "Количество в упаковке", Numeric: true}, func(m *entities.Material) entities.ExportCell {
+		return entities.NumberCell(&m.PackageQuantity)
+	}},
+	{entities.ExportColumn{Key: "cost_per_unit", Title: "Стоимость за единицу", Numeric: true}, func(m *entities.Material) entities.ExportCell {
+		return entities.NumberCell(&m.CostPerUnit)
+	}},
+	{entities.ExportColumn{Key: "stock_quantity", Title: "Количество на складе", Numeric: true}, func(m *entities.Material) entities.ExportCell {
+		return entities.NumberCell(&m.StockQuantity)
+	}},
+	{entities.ExportColumn{Key: "min_stock_quantity", Title: "Минимальный остаток", Numeric: true}, func(m *entities.Material) entities.ExportCell {
+		return entities.NumberCell(&m.MinStockQuantity)
+	}},
+	{entities.ExportColumn{Key: "description", Title: "Описание"}, func(m *entities.Material) entities.ExportCell {
+		return entities.TextCell(stringValue(m.Description))
+	}},
+}
+
+// defaultMaterialExportColumns - столбцы выгрузки материалов по умолчанию
+var defaultMaterialExportColumns = []string{"article", "name", "material_type", "measurement_unit", "cost_per_unit", "stock_quantity"}
+
+// selectProductExportColumns возвращает запрошенные столбцы в заданном порядке
+func selectProductExportColumns(keys []string) ([]productExportColumn, error) {
+	if len(keys) == 0 {
+		keys = defaultProductExportColumns
+	}
+
+	var selected []productExportColumn
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if seen[key] {
+			continue
+		}
+		column, ok := findProductExportColumn(key)
+		if !ok {
+			return nil, unknownExportColumnError(key)
+		}
+		selected = append(selected, column)
+		seen[key] = true
+	}
+	return selected, nil
+}
+
+func findProductExportColumn(key string) (productExportColumn, bool) {
+	for _, column := range productExportColumns {
+		if column.Key == key {
+			return column, true
+		}
+	}
+	return productExportColumn{}, false
+}
+
+// selectMaterialExportColumns возвращает запрошенные столбцы в заданном порядке
+func selectMaterialExportColumns(keys []string) ([]materialExportColumn, error) {
+	if len(keys) == 0 {
+		keys = defaultMaterialExportColumns
+	}
+
+	var selected []materialExportColumn
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if seen[key] {
+			continue
+		}
+		column, ok := findMaterialExportColumn(key)
+		if !ok {
+			return nil, unknownExportColumnError(key)
+		}
+		selected = append(selected, column)
+		seen[key] = true
+	}
+	return selected, nil
+}
+
+func findMaterialExportColumn(key string) (materialExportColumn, bool) {
+	for _, column := range materialExportColumns {
+		if column.Key == key {
+			return column, true
+		}
+	}
+	return materialExportColumn{}, false
+}
+
+func unknownExportColumnError(key string) error {
+	return entities.NewValidationError("columns", fmt.Sprintf("неизвестный столбец выгрузки %q", key))
+}
+
+// exportGrouper собирает строки выгрузки в группы; группы упорядочены по названию,
+// строки внутри группы сохраняют порядок выборки
+type exportGrouper struct {
+	index  map[string]int
+	groups []entities.ExportGroup
+}
+
+func newExportGrouper() *exportGrouper {
+	return &exportGrouper{index: make(map[string]int)}
+}
+
+func (g *exportGrouper) add(name string, row []entities.ExportCell) {
+	i, ok := g.index[name]
+	if !ok {
+		i = len(g.groups)
+		g.index[name] = i
+		g.groups = append(g.groups, entities.ExportGroup{Name: name})
+	}
+	g.groups[i].Rows = append(g.groups[i].Rows, row)
+}
+
+func (g *exportGrouper) result() []entities.ExportGroup {
+	sort.SliceStable(g.groups, func(i, j int) bool {
+		return strings.ToLower(g.groups[i].Name) < strings.ToLower(g.groups[j].Name)
+	})
+	return g.groups
+}
+
+// checkExportTotal не дает выгрузить больше MaxExportRows строк за раз
+func checkExportTotal(total int) error {
+	if total > entities.MaxExportRows {
+		return entities.NewValidationError("filters",
+			fmt.Sprintf("найдено %d позиций, за раз можно выгрузить не больше %d - уточните фильтры", total, entities.MaxExportRows))
+	}
+	return nil
+}
+
+func productTypeName(product *entities.Product) string {
+	if product.ProductType == nil {
+		return "Без типа"
+	}
+	return product.ProductType.Name
+}
+
+func materialTypeName(material *entities.Material) string {
+	if material.MaterialType == nil {
+		return "Без типа"
+	}
+	return material.MaterialType.Name
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,203 @@
+package usecases
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// ImageUseCase содержит бизнес-логику загрузки изображений продукции, ее вариантов и материалов
+type ImageUseCase struct {
+	productRepo  repositories.ProductRepository
+	materialRepo repositories.MaterialRepository
+	variantRepo  repositories.ProductVariantRepository
+	storage      repositories.FileStorage
+}
+
+// NewImageUseCase создает новый use case изображений
+func NewImageUseCase(
+	productRepo repositories.ProductRepository,
+	materialRepo repositories.MaterialRepository,
+	variantRepo repositories.ProductVariantRepository,
+	storage repositories.FileStorage,
+) *ImageUseCase {
+	return &ImageUseCase{
+		productRepo:  productRepo,
+		materialRepo: materialRepo,
+		variantRepo:  variantRepo,
+		storage:      storage,
+	}
+}
+
+// UploadProductImage сохраняет изображение продукции с уменьшенными копиями и заменяет прежнее
+func (uc *ImageUseCase) UploadProductImage(productID int, upload *entities.ImageUpload) (*entities.Product, error) {
+	product, err := uc.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	images, err := uc.replaceImages(fmt.Sprintf("products/%d", productID), product.Images(), upload,
+		func(images entities.ImagePaths) error { return uc.productRepo.UpdateImages(productID, images) })
+	if err != nil {
+		return nil, err
+	}
+
+	product.ImagePath, product.ThumbnailPath, product.PreviewPath = images.ImagePath, images.ThumbnailPath, images.PreviewPath
+	return product, nil
+}
+
+// DeleteProductImage удаляет изображение продукции вместе с уменьшенными копиями
+func (uc *ImageUseCase) DeleteProductImage(productID int) (*entities.Product, error) {
+	product, err := uc.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.replaceImages("", product.Images(), nil,
+		func(images entities.ImagePaths) error { return uc.productRepo.UpdateImages(productID, images) }); err != nil {
+		return nil, err
+	}
+
+	product.ImagePath, product.ThumbnailPath, product.PreviewPath = nil, nil, nil
+	return product, nil
+}
+
+// UploadMaterialImage сохраняет изображение материала с уменьшенными копиями и заменяет прежнее
+func (uc *ImageUseCase) UploadMaterialImage(materialID int, upload *entities.ImageUpload) (*entities.Material, error) {
+	material, err := uc.materialRepo.GetByID(materialID)
+	if err != nil {
+		return nil, err
+	}
+
+	images, err := uc.replaceImages(fmt.Sprintf("materials/%d", materialID), material.Images(), upload,
+		func(images entities.ImagePaths) error { return uc.materialRepo.UpdateImages(materialID, images) })
+	if err != nil {
+		return nil, err
+	}
+
+	material.ImagePath, material.ThumbnailPath, material.PreviewPath = images.ImagePath, images.ThumbnailPath, images.PreviewPath
+	return material, nil
+}
+
+// DeleteMaterialImage удаляет изображение материала вместе с уменьшенными копиями
+func (uc *ImageUseCase) DeleteMaterialImage(materialID int) (*entities.Material, error) {
+	material, err := uc.materialRepo.GetByID(materialID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.replaceImages("", material.Images(), nil,
+		func(images entities.ImagePaths) error { return uc.materialRepo.UpdateImages(materialID, images) }); err != nil {
+		return nil, err
+	}
+
+	material.ImagePath, material.ThumbnailPath, material.PreviewPath = nil, nil, nil
+	return material, nil
+}
+
+// UploadVariantImage сохраняет изображение варианта продукции с уменьшенными копиями и заменяет прежнее
+func (uc *ImageUseCase) UploadVariantImage(variantID int, upload *entities.ImageUpload) (*entities.ProductVariant, error) {
+	variant, err := uc.variantRepo.GetByID(variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	images, err := uc.replaceImages(fmt.Sprintf("variants/%d", variantID), variant.Images(), upload,
+		func(images entities.ImagePaths) error { return uc.variantRepo.UpdateImages(variantID, images) })
+	if err != nil {
+		return nil, err
+	}
+
+	variant.ImagePath, variant.ThumbnailPath, variant.PreviewPath = images.ImagePath, images.ThumbnailPath, images.PreviewPath
+	return variant, nil
+}
+
+// DeleteVariantImage удаляет изображение варианта продукции вместе с уменьшенными копиями
+func (uc *ImageUseCase) DeleteVariantImage(variantID int) (*entities.ProductVariant, error) {
+	variant, err := uc.variantRepo.GetByID(variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.replaceImages("", variant.Images(), nil,
+		func(images entities.ImagePaths) error { return uc.variantRepo.UpdateImages(variantID, images) }); err != nil {
+		return nil, err
+	}
+
+	variant.ImagePath, variant.ThumbnailPath, variant.PreviewPath = nil, nil, nil
+	return variant, nil
+}
+
+// replaceImages сохраняет файлы нового изображения (upload == nil - изображение удаляется),
+// записывает ссылки через update и удаляет файлы прежнего изображения. Если ссылки
+// записать не удалось, удаляются уже сохраненные новые файлы
+func (uc *ImageUseCase) replaceImages(
+	prefix string,
+	previous entities.ImagePaths,
+	upload *entities.ImageUpload,
+	update func(images entities.ImagePaths) error,
+) (entities.ImagePaths, error) {
+	var images entities.ImagePaths
+	if upload != nil {
+		saved, err := uc.saveFiles(prefix, upload)
+		if err != nil {
+			return images, err
+		}
+		images = saved
+	}
+
+	if err := update(images); err != nil {
+		uc.deleteFiles(images.Files(), nil)
+		return images, fmt.Errorf("ошибка сохранения ссылок на изображение: %w", err)
+	}
+
+	uc.deleteFiles(previous.Files(), images.Files())
+	return images, nil
+}
+
+// saveFiles сохраняет исходное изображение и уменьшенные копии. Имя файла - хеш содержимого,
+// поэтому повторная загрузка того же файла не плодит копии, а браузер не показывает устаревший кеш
+func (uc *ImageUseCase) saveFiles(prefix string, upload *entities.ImageUpload) (entities.ImagePaths, error) {
+	sum := sha256.Sum256(upload.Original.Data)
+	name := prefix + "/" + hex.EncodeToString(sum[:8])
+
+	var images entities.ImagePaths
+	save := func(key string, file entities.ImageFile) (*string, error) {
+		path, err := uc.storage.Save(key, file.Data)
+		if err != nil {
+			uc.deleteFiles(images.Files(), nil)
+			return nil, fmt.Errorf("ошибка сохранения изображения: %w", err)
+		}
+		return &path, nil
+	}
+
+	var err error
+	if images.ImagePath, err = save(name+upload.Original.Extension, upload.Original); err != nil {
+		return entities.ImagePaths{}, err
+	}
+	if images.ThumbnailPath, err = save(name+"_"+entities.ThumbnailSize.Name+upload.Thumbnail.Extension, upload.Thumbnail); err != nil {
+		return entities.ImagePaths{}, err
+	}
+	if images.PreviewPath, err = save(name+"_"+entities.PreviewSize.Name+upload.Preview.Extension, upload.Preview); err != nil {
+		return entities.ImagePaths{}, err
+	}
+
+	return images, nil
+}
+
+// deleteFiles удаляет файлы, кроме оставляемых keep. Ошибки удаления не прерывают операцию:
+// ссылки уже обновлены, а оставшийся файл ни на что не влияет
+func (uc *ImageUseCase) deleteFiles(files, keep []string) {
+	kept := make(map[string]bool, len(keep))
+	for _, path := range keep {
+		kept[path] = true
+	}
+	for _, path := range files {
+		if !kept[path] {
+			_ = uc.storage.Delete(path)
+		}
+	}
+}
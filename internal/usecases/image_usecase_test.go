@@ -0,0 +1,148 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImageUseCaseTestSuite struct {
+	suite.Suite
+	productRepo  *mocks.MockProductRepository
+	materialRepo *mocks.MockMaterialRepository
+	variantRepo  *mocks.MockProductVariantRepository
+	storage      *mocks.MockFileStorage
+	useCase      *ImageUseCase
+}
+
+func (suite *ImageUseCaseTestSuite) SetupTest() {
+	suite.productRepo = new(mocks.MockProductRepository)
+	suite.materialRepo = new(mocks.MockMaterialRepository)
+	suite.variantRepo = new(mocks.MockProductVariantRepository)
+	suite.storage = new(mocks.MockFileStorage)
+	suite.useCase = NewImageUseCase(suite.productRepo, suite.materialRepo, suite.variantRepo, suite.storage)
+}
+
+func newTestImageUpload() *entities.ImageUpload {
+	return &entities.ImageUpload{
+		Original:  entities.ImageFile{Data: []byte("original"), Extension: ".png"},
+		Thumbnail: entities.ImageFile{Data: []byte("thumb"), Extension: ".jpg"},
+		Preview:   entities.ImageFile{Data: []byte("preview"), Extension: ".jpg"},
+	}
+}
+
+// expectSaves настраивает сохранение трех файлов изображения и возвращает ожидаемые ссылки
+func (suite *ImageUseCaseTestSuite) expectSaves(prefix string) entities.ImagePaths {
+	// Имя файла - первые 8 байт SHA-256 от "original"
+	name := prefix + "/0682c5f2076f099c"
+	image, thumbnail, preview := "/uploads/"+name+".png", "/uploads/"+name+"_thumb.jpg", "/uploads/"+name+"_preview.jpg"
+
+	suite.storage.On("Save", name+".png", []byte("original")).Return(image, nil)
+	suite.storage.On("Save", name+"_thumb.jpg", []byte("thumb")).Return(thumbnail, nil)
+	suite.storage.On("Save", name+"_preview.jpg", []byte("preview")).Return(preview, nil)
+
+	return entities.ImagePaths{ImagePath: &image, ThumbnailPath: &thumbnail, PreviewPath: &preview}
+}
+
+func (suite *ImageUseCaseTestSuite) TestUploadProductImage_ReplacesPreviousFiles() {
+	// Подготовка данных: у продукции уже есть загруженное изображение
+	oldImage, oldThumbnail := "/uploads/products/1/old.jpg", "/uploads/products/1/old_thumb.jpg"
+	product := &entities.Product{ID: 1, Article: "WP-001", ImagePath: &oldImage, ThumbnailPath: &oldThumbnail}
+	expected := suite.expectSaves("products/1")
+
+	// Настройка моков
+	suite.productRepo.On("GetByID", 1).Return(product, nil)
+	suite.productRepo.On("UpdateImages", 1, expected).Return(nil)
+	suite.storage.On("Delete", oldImage).Return(nil)
+	suite.storage.On("Delete", oldThumbnail).Return(nil)
+
+	// Выполнение
+	result, err := suite.useCase.UploadProductImage(1, newTestImageUpload())
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expected, result.Images())
+	suite.productRepo.AssertExpectations(suite.T())
+	suite.storage.AssertExpectations(suite.T())
+}
+
+func (suite *ImageUseCaseTestSuite) TestUploadVariantImage() {
+	// Подготовка данных
+	variant := &entities.ProductVariant{ID: 5, ProductID: 1, ArticleSuffix: "BL"}
+	expected := suite.expectSaves("variants/5")
+
+	// Настройка моков
+	suite.variantRepo.On("GetByID", 5).Return(variant, nil)
+	suite.variantRepo.On("UpdateImages", 5, expected).Return(nil)
+
+	// Выполнение
+	result, err := suite.useCase.UploadVariantImage(5, newTestImageUpload())
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expected, result.Images())
+	suite.variantRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ImageUseCaseTestSuite) TestUploadProductImage_UpdateFailedRemovesNewFiles() {
+	// Подготовка данных
+	expected := suite.expectSaves("products/1")
+
+	// Настройка моков
+	suite.productRepo.On("GetByID", 1).Return(&entities.Product{ID: 1}, nil)
+	suite.productRepo.On("UpdateImages", 1, expected).Return(errors.New("connection reset"))
+	suite.storage.On("Delete", mock.AnythingOfType("string")).Return(nil)
+
+	// Выполнение
+	result, err := suite.useCase.UploadProductImage(1, newTestImageUpload())
+
+	// Проверки
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), result)
+	suite.storage.AssertCalled(suite.T(), "Delete", *expected.ImagePath)
+	suite.storage.AssertCalled(suite.T(), "Delete", *expected.ThumbnailPath)
+	suite.storage.AssertCalled(suite.T(), "Delete", *expected.PreviewPath)
+}
+
+func (suite *ImageUseCaseTestSuite) TestUploadProductImage_NotFound() {
+	// Настройка моков
+	suite.productRepo.On("GetByID", 99).Return(nil, entities.NewNotFoundError("продукция", "99"))
+
+	// Выполнение
+	result, err := suite.useCase.UploadProductImage(99, newTestImageUpload())
+
+	// Проверки
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), result)
+	suite.storage.AssertNotCalled(suite.T(), "Save", mock.Anything, mock.Anything)
+}
+
+func (suite *ImageUseCaseTestSuite) TestDeleteMaterialImage() {
+	// Подготовка данных: путь задан вручную, копий нет
+	imagePath := "/static/images/materials/glue.jpg"
+	material := &entities.Material{ID: 3, ImagePath: &imagePath}
+
+	// Настройка моков
+	suite.materialRepo.On("GetByID", 3).Return(material, nil)
+	suite.materialRepo.On("UpdateImages", 3, entities.ImagePaths{}).Return(nil)
+	suite.storage.On("Delete", imagePath).Return(nil)
+
+	// Выполнение
+	result, err := suite.useCase.DeleteMaterialImage(3)
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), result.ImagePath)
+	suite.materialRepo.AssertExpectations(suite.T())
+	suite.storage.AssertExpectations(suite.T())
+}
+
+func TestImageUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ImageUseCaseTestSuite))
+}
@@ -0,0 +1,531 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// importColumn описывает столбец файла импорта и заголовки, под которыми он встречается
+type importColumn struct {
+	field    string
+	aliases  []string
+	required bool // обязателен для новых позиций
+}
+
+// productImportColumns - столбцы импорта продукции
+var productImportColumns = []importColumn{
+	{field: "article", aliases: []string{"артикул"}, required: true},
+	{field: "name", aliases: []string{"наименование", "название", "наименование продукции"}, required: true},
+	{field: "product_type", aliases: []string{"тип продукции", "тип"}, required: true},
+	{field: "description", aliases: []string{"описание"}},
+	{field: "min_partner_price", aliases: []string{"минимальная стоимость для партнера", "минимальная цена для партнера", "мин цена для партнера", "мин цена"}},
+	{field: "roll_width", aliases: []string{"ширина рулона"}},
+	{field: "roll_length", aliases: []string{"длина рулона"}},
+	{field: "pattern_repeat", aliases: []string{"раппорт", "шаг рисунка"}},
+	{field: "package_length", aliases: []string{"длина упаковки"}},
+	{field: "package_width", aliases: []string{"ширина упаковки"}},
+	{field: "package_height", aliases: []string{"высота упаковки"}},
+	{field: "weight_without_package", aliases: []string{"вес без упаковки", "вес нетто"}},
+	{field: "weight_with_package", aliases: []string{"вес с упаковкой", "вес брутто"}},
+	{field: "standard_number", aliases: []string{"номер стандарта", "стандарт"}},
+	{field: "production_time_hours", aliases: []string{"время производства"}},
+	{field: "workshop_number", aliases: []string{"номер цеха", "цех"}},
+	{field: "required_workers", aliases: []string{"количество рабочих", "требуется рабочих"}},
+}
+
+// materialImportColumns - столбцы импорта материалов
+var materialImportColumns = []importColumn{
+	{field: "article", aliases: []string{"артикул"}, required: true},
+	{field: "name", aliases: []string{"наименование", "название", "наименование материала"}, required: true},
+	{field: "material_type", aliases: []string{"тип материала", "тип"}, required: true},
+	{field: "measurement_unit", aliases: []string{"единица измерения", "ед изм"}, required: true},
+	{field: "package_quantity", aliases: []string{"количество в упаковке"}, required: true},
+	{field: "cost_per_unit", aliases: []string{"стоимость", "цена единицы материала", "стоимость за единицу"}, required: true},
+	{field: "stock_quantity", aliases: []string{"количество на складе", "остаток"}},
+	{field: "min_stock_quantity", aliases: []string{"минимальное количество", "минимальный остаток"}},
+	{field: "description", aliases: []string{"описание"}},
+}
+
+// ImportUseCase содержит бизнес-логику массового импорта продукции и материалов
+type ImportUseCase struct {
+	productRepo      repositories.ProductRepository
+	materialRepo     repositories.MaterialRepository
+	costRecalculator ProductCostRecalculator
+}
+
+// NewImportUseCase создает новый use case импорта
+func NewImportUseCase(
+	productRepo repositories.ProductRepository,
+	materialRepo repositories.MaterialRepository,
+	costRecalculator ProductCostRecalculator,
+) *ImportUseCase {
+	return &ImportUseCase{
+		productRepo:      productRepo,
+		materialRepo:     materialRepo,
+		costRecalculator: costRecalculator,
+	}
+}
+
+// ImportProducts импортирует продукцию из таблицы. Позиции сопоставляются по артикулу:
+// существующие обновляются, новые создаются. Пустая ячейка не меняет значение поля.
+// Строки с ошибками пропускаются, остальные сохраняются в одной транзакции;
+// в режиме dryRun ничего не сохраняется
+func (uc *ImportUseCase) ImportProducts(table *entities.ImportTable, dryRun bool) (*entities.ImportReport, error) {
+	layout, report, err := prepareImport(table, productImportColumns, dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	productTypes, err := uc.productRepo.GetProductTypes()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения типов продукции: %w", err)
+	}
+	typeIDs := make(referenceIndex)
+	for _, productType := range productTypes {
+		typeIDs.add(productType.ID, productType.Name)
+	}
+
+	existing, err := uc.productRepo.GetByArticles(importArticles(table, layout))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска продукции по артикулам: %w", err)
+	}
+	byArticle := make(map[string]entities.Product, len(existing))
+	for _, product := range existing {
+		byArticle[product.Article] = product
+	}
+
+	var products []entities.Product
+	seen := make(map[string]int)
+	for _, row := range table.Rows {
+		record := newImportRecord(row, layout)
+		article := record.article()
+
+		var product entities.Product
+		if found, ok := byArticle[article]; ok {
+			product = found
+			record.action = entities.ImportActionUpdate
+		}
+
+		if record.checkArticle(article, seen) {
+			product.Article = article
+			record.text("name", &product.Name)
+			record.reference("product_type", typeIDs, "тип продукции", &product.ProductTypeID)
+			record.optionalText("description", &product.Description)
+			record.number("min_partner_price", &product.MinPartnerPrice)
+			record.optionalNumber("roll_width", &product.RollWidth)
+			record.optionalNumber("roll_length", &product.RollLength)
+			record.optionalNumber("pattern_repeat", &product.PatternRepeat)
+			record.optionalNumber("package_length", &product.PackageLength)
+			record.optionalNumber("package_width", &product.PackageWidth)
+			record.optionalNumber("package_height", &product.PackageHeight)
+			record.optionalNumber("weight_without_package", &product.WeightWithoutPackage)
+			record.optionalNumber("weight_with_package", &product.WeightWithPackage)
+			record.optionalText("standard_number", &product.StandardNumber)
+			record.optionalNumber("production_time_hours", &product.ProductionTimeHours)
+			record.optionalText("workshop_number", &product.WorkshopNumber)
+			record.optionalInteger("required_workers", &product.RequiredWorkers)
+			record.validate(product.Validate())
+		}
+
+		if record.accept(report) {
+			products = append(products, product)
+		}
+	}
+
+	if dryRun || len(products) == 0 {
+		return report, nil
+	}
+
+	if err := uc.productRepo.SaveBatch(products); err != nil {
+		return nil, fmt.Errorf("ошибка сохранения продукции: %w", err)
+	}
+
+	return report, nil
+}
+
+// ImportMaterials импортирует материалы из таблицы по тем же правилам, что и продукцию.
+// Если у существующего материала изменилась цена, себестоимость продукции пересчитывается
+func (uc *ImportUseCase) ImportMaterials(table *entities.ImportTable, dryRun bool) (*entities.ImportReport, error) {
+	layout, report, err := prepareImport(table, materialImportColumns, dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	materialTypes, err := uc.materialRepo.GetMaterialTypes()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения типов материалов: %w", err)
+	}
+	typeIDs := make(referenceIndex)
+	for _, materialType := range materialTypes {
+		typeIDs.add(materialType.ID, materialType.Name)
+	}
+
+	units, err := uc.materialRepo.GetMeasurementUnits()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения единиц измерения: %w", err)
+	}
+	unitIDs := make(referenceIndex)
+	for _, unit := range units {
+		unitIDs.add(unit.ID, unit.Name, unit.Abbreviation)
+	}
+
+	existing, err := uc.materialRepo.GetByArticles(importArticles(table, layout))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска материалов по артикулам: %w", err)
+	}
+	byArticle := make(map[string]entities.Material, len(existing))
+	for _, material := range existing {
+		byArticle[material.Article] = material
+	}
+
+	var materials []entities.Material
+	seen := make(map[string]int)
+	for _, row := range table.Rows {
+		record := newImportRecord(row, layout)
+		article := record.article()
+
+		var material entities.Material
+		found, isUpdate := byArticle[article]
+		if isUpdate {
+			material = found
+			record.action = entities.ImportActionUpdate
+		}
+
+		if record.checkArticle(article, seen) {
+			material.Article = article
+			record.text("name", &material.Name)
+			record.reference("material_type", typeIDs, "тип материала", &material.MaterialTypeID)
+			record.reference("measurement_unit", unitIDs, "единица измерения", &material.MeasurementUnitID)
+			record.number("package_quantity", &material.PackageQuantity)
+			record.number("cost_per_unit", &material.CostPerUnit)
+			record.number("stock_quantity", &material.StockQuantity)
+			record.number("min_stock_quantity", &material.MinStockQuantity)
+			record.optionalText("description", &material.Description)
+			if material.MinStockQuantity < 0 {
+				record.addError("min_stock_quantity", "минимальный остаток не может быть отрицательным")
+			}
+			record.validate(material.Validate())
+			if isUpdate {
+				record.validate(found.ValidateCostChange(material.CostPerUnit))
+			}
+		}
+
+		if record.accept(report) {
+			materials = append(materials, material)
+		}
+	}
+
+	if dryRun || len(materials) == 0 {
+		return report, nil
+	}
+
+	if err := uc.materialRepo.SaveBatch(materials); err != nil {
+		return nil, fmt.Errorf("ошибка сохранения материалов: %w", err)
+	}
+
+	// Цена сырья изменилась из файла или при проведении изменения остатка - пересчитываем
+	// себестоимость продукции, в которую оно входит. Материалы уже сохранены, поэтому ошибки
+	// пересчета попадают в отчет, а не отменяют импорт
+	var changedCostIDs []int
+	for _, material := range materials {
+		if found, ok := byArticle[material.Article]; ok && found.CostPerUnit != material.CostPerUnit {
+			changedCostIDs = append(changedCostIDs, material.ID)
+		}
+	}
+	if err := recalculateCostsForMaterials(uc.costRecalculator, "материалы импортированы", changedCostIDs); err != nil {
+		report.CostWarning = err.Error()
+	}
+
+	return report, nil
+}
+
+// prepareImport проверяет таблицу и сопоставляет ее заголовки со столбцами импорта
+func prepareImport(table *entities.ImportTable, definitions []importColumn, dryRun bool) (*importLayout, *entities.ImportReport, error) {
+	if table == nil {
+		return nil, nil, entities.NewValidationError("file", "файл не передан")
+	}
+	if err := table.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	report := &entities.ImportReport{DryRun: dryRun}
+	layout := &importLayout{
+		columns:  make(map[string]importColumnRef),
+		required: make(map[string]bool),
+	}
+	for _, definition := range definitions {
+		layout.required[definition.field] = definition.required
+	}
+
+	for index, header := range table.Header {
+		definition, ok := matchImportColumn(header, definitions)
+		if !ok {
+			if header != "" {
+				report.IgnoredColumns = append(report.IgnoredColumns, header)
+			}
+			continue
+		}
+		if previous, duplicate := layout.columns[definition.field]; duplicate {
+			return nil, nil, entities.NewValidationError("file",
+				fmt.Sprintf("столбцы %q и %q означают одно и то же поле", previous.header, header))
+		}
+		layout.columns[definition.field] = importColumnRef{index: index, header: header}
+		report.Columns = append(report.Columns, definition.field)
+	}
+
+	if _, ok := layout.columns["article"]; !ok {
+		return nil, nil, entities.NewValidationError("file", "в файле нет столбца с артикулом")
+	}
+
+	return layout, report, nil
+}
+
+// matchImportColumn находит столбец по заголовку: подходит имя поля или любой из русских заголовков
+func matchImportColumn(header string, definitions []importColumn) (importColumn, bool) {
+	normalized := normalizeImportName(header)
+	for _, definition := range definitions {
+		if normalized == definition.field {
+			return definition, true
+		}
+		for _, alias := range definition.aliases {
+			if normalized == alias {
+				return definition, true
+			}
+		}
+	}
+	return importColumn{}, false
+}
+
+var (
+	importUnitSuffix = regexp.MustCompile(`\s*[(\[].*$`)
+	importSeparators = regexp.MustCompile(`[\s.,]+`)
+)
+
+// normalizeImportName приводит заголовок или название справочника к виду для сравнения:
+// нижний регистр, "ё" как "е", без единиц измерения в скобках и лишних пробелов
+func normalizeImportName(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	value = strings.ReplaceAll(value, "ё", "е")
+	value = importUnitSuffix.ReplaceAllString(value, "")
+	value = importSeparators.ReplaceAllString(value, " ")
+	return strings.TrimSpace(value)
+}
+
+func importArticles(table *entities.ImportTable, layout *importLayout) []string {
+	articles := make([]string, 0, len(table.Rows))
+	for _, row := range table.Rows {
+		if article := newImportRecord(row, layout).article(); article != "" {
+			articles = append(articles, article)
+		}
+	}
+	return articles
+}
+
+// referenceIndex сопоставляет названия и ID записей справочника с их ID
+type referenceIndex map[string]int
+
+func (idx referenceIndex) add(id int, names ...string) {
+	idx[strconv.Itoa(id)] = id
+	for _, name := range names {
+		if name != "" {
+			idx[normalizeImportName(name)] = id
+		}
+	}
+}
+
+// importColumnRef - положение столбца импорта в файле
+type importColumnRef struct {
+	index  int
+	header string
+}
+
+// importLayout описывает сопоставление столбцов файла с полями импорта
+type importLayout struct {
+	columns  map[string]importColumnRef
+	required map[string]bool
+}
+
+// importRecord накапливает ошибки разбора одной строки файла
+type importRecord struct {
+	row    entities.ImportRow
+	layout *importLayout
+	action entities.ImportAction
+	errors []entities.ImportRowError
+}
+
+func newImportRecord(row entities.ImportRow, layout *importLayout) *importRecord {
+	return &importRecord{row: row, layout: layout, action: entities.ImportActionCreate}
+}
+
+// value возвращает значение ячейки; пустая строка означает, что значение не задано
+func (r *importRecord) value(field string) string {
+	column, ok := r.layout.columns[field]
+	if !ok || column.index >= len(r.row.Values) {
+		return ""
+	}
+	return r.row.Values[column.index]
+}
+
+// lookup возвращает заполненное значение ячейки. Для новой позиции пустая ячейка
+// обязательного столбца - ошибка
+func (r *importRecord) lookup(field string) (string, bool) {
+	value := r.value(field)
+	if value != "" {
+		return value, true
+	}
+
+	if r.action == entities.ImportActionCreate && r.layout.required[field] {
+		r.addError(field, "обязательное поле не заполнено")
+	}
+	return "", false
+}
+
+func (r *importRecord) article() string {
+	return r.value("article")
+}
+
+// checkArticle проверяет артикул строки и то, что он не повторяется в файле
+func (r *importRecord) checkArticle(article string, seen map[string]int) bool {
+	if article == "" {
+		r.addError("article", "артикул не может быть пустым")
+		return false
+	}
+	if previous, ok := seen[article]; ok {
+		r.addError("article", fmt.Sprintf("артикул уже встречался в строке %d", previous))
+		return false
+	}
+	seen[article] = r.row.Number
+	return true
+}
+
+func (r *importRecord) text(field string, target *string) {
+	if value, ok := r.lookup(field); ok {
+		*target = value
+	}
+}
+
+func (r *importRecord) optionalText(field string, target **string) {
+	if value, ok := r.lookup(field); ok {
+		*target = &value
+	}
+}
+
+func (r *importRecord) number(field string, target *float64) {
+	value, ok := r.lookup(field)
+	if !ok {
+		return
+	}
+	number, err := parseImportNumber(value)
+	if err != nil {
+		r.addError(field, err.Error())
+		return
+	}
+	*target = number
+}
+
+func (r *importRecord) optionalNumber(field string, target **float64) {
+	value, ok := r.lookup(field)
+	if !ok {
+		return
+	}
+	number, err := parseImportNumber(value)
+	if err != nil {
+		r.addError(field, err.Error())
+		return
+	}
+	*target = &number
+}
+
+func (r *importRecord) optionalInteger(field string, target **int) {
+	value, ok := r.lookup(field)
+	if !ok {
+		return
+	}
+	number, err := parseImportNumber(value)
+	if err != nil {
+		r.addError(field, err.Error())
+		return
+	}
+	if number != math.Trunc(number) {
+		r.addError(field, "ожидается целое число")
+		return
+	}
+	integer := int(number)
+	*target = &integer
+}
+
+// reference находит ID записи справочника по названию или ID
+func (r *importRecord) reference(field string, index referenceIndex, entity string, target *int) {
+	value, ok := r.lookup(field)
+	if !ok {
+		return
+	}
+	id, found := index[normalizeImportName(value)]
+	if !found {
+		r.addError(field, fmt.Sprintf("%s %q не найден", entity, value))
+		return
+	}
+	*target = id
+}
+
+// validate добавляет ошибку проверки сущности, если разбор строки прошел без ошибок
+func (r *importRecord) validate(err error) {
+	if err == nil || len(r.errors) > 0 {
+		return
+	}
+	var validationErr *entities.ValidationError
+	if errors.As(err, &validationErr) {
+		r.addError(validationErr.Field, validationErr.Message)
+		return
+	}
+	r.addError("", err.Error())
+}
+
+func (r *importRecord) addError(field, message string) {
+	column := field
+	if ref, ok := r.layout.columns[field]; ok {
+		column = ref.header
+	}
+	r.errors = append(r.errors, entities.ImportRowError{Row: r.row.Number, Column: column, Message: message})
+}
+
+// accept добавляет результат строки в отчет и сообщает, можно ли ее сохранять
+func (r *importRecord) accept(report *entities.ImportReport) bool {
+	action := r.action
+	if len(r.errors) > 0 {
+		action = entities.ImportActionSkip
+	}
+	report.Rows = append(report.Rows, entities.ImportRowResult{
+		Row:     r.row.Number,
+		Article: r.article(),
+		Action:  action,
+		Errors:  r.errors,
+	})
+	return action != entities.ImportActionSkip
+}
+
+// parseImportNumber разбирает число в русской записи: пробелы между разрядами и запятая как разделитель дробной части
+func parseImportNumber(value string) (float64, error) {
+	cleaned := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\u00a0', '\u202f':
+			return -1
+		case ',':
+			return '.'
+		}
+		return r
+	}, value)
+
+	number, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0, fmt.Errorf("%q не является числом", value)
+	}
+	return number, nil
+}
@@ -0,0 +1,344 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/mocks"
+	usecasemocks "wallpaper-system/internal/usecases/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImportUseCaseTestSuite struct {
+	suite.Suite
+	productRepo      *mocks.MockProductRepository
+	materialRepo     *mocks.MockMaterialRepository
+	costRecalculator *usecasemocks.MockProductUseCase
+	useCase          *ImportUseCase
+}
+
+func (suite *ImportUseCaseTestSuite) SetupTest() {
+	suite.productRepo = new(mocks.MockProductRepository)
+	suite.materialRepo = new(mocks.MockMaterialRepository)
+	suite.costRecalculator = new(usecasemocks.MockProductUseCase)
+	suite.useCase = NewImportUseCase(suite.productRepo, suite.materialRepo, suite.costRecalculator)
+
+	suite.productRepo.On("GetProductTypes").Return([]entities.ProductType{
+		{ID: 1, Name: "Обои флизелиновые"},
+		{ID: 2, Name: "Обои виниловые"},
+	}, nil).Maybe()
+	suite.materialRepo.On("GetMaterialTypes").Return([]entities.MaterialType{
+		{ID: 3, Name: "Бумага"},
+	}, nil).Maybe()
+	suite.materialRepo.On("GetMeasurementUnits").Return([]entities.MeasurementUnit{
+		{ID: 4, Name: "метр", Abbreviation: "м"},
+	}, nil).Maybe()
+}
+
+func productImportTable(rows ...[]string) *entities.ImportTable {
+	table := &entities.ImportTable{
+		Header: []string{"Артикул", "Наименование", "Тип продукции", "Мин. цена для партнера (руб.)", "Комментарий"},
+	}
+	for i, values := range rows {
+		table.Rows = append(table.Rows, entities.ImportRow{Number: i + 2, Values: values})
+	}
+	return table
+}
+
+func (suite *ImportUseCaseTestSuite) TestImportProducts_CreatesAndUpdatesByArticle() {
+	// Подготовка данных
+	description := "Старое описание"
+	existing := entities.Product{ID: 10, Article: "WP-002", Name: "Старое название", ProductTypeID: 2, Description: &description}
+	table := productImportTable(
+		[]string{"WP-001", "Обои белые", "обои  ФЛИЗЕЛИНОВЫЕ", "1 250,50", "новинка"},
+		[]string{"WP-002", "Обои серые", "", "900"},
+	)
+
+	// Настройка моков
+	suite.productRepo.On("GetByArticles", []string{"WP-001", "WP-002"}).Return([]entities.Product{existing}, nil)
+	suite.productRepo.On("SaveBatch", mock.MatchedBy(func(products []entities.Product) bool {
+		return len(products) == 2 &&
+			products[0].ID == 0 && products[0].ProductTypeID == 1 && products[0].MinPartnerPrice == 1250.50 &&
+			products[1].ID == 10 && products[1].Name == "Обои серые" && products[1].ProductTypeID == 2 &&
+			products[1].Description == &description
+	})).Return(nil)
+
+	// Выполнение
+	report, err := suite.useCase.ImportProducts(table, false)
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, report.Created())
+	assert.Equal(suite.T(), 1, report.Updated())
+	assert.Equal(suite.T(), 0, report.Skipped())
+	assert.Equal(suite.T(), []string{"Комментарий"}, report.IgnoredColumns)
+	suite.productRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ImportUseCaseTestSuite) TestImportProducts_ReportsRowErrors() {
+	// Подготовка данных
+	table := productImportTable(
+		[]string{"WP-001", "Обои белые", "Обои бумажные", "100"},
+		[]string{"WP-002", "", "Обои виниловые", "сто"},
+		[]string{"WP-003", "Обои синие", "Обои виниловые", "-5"},
+		[]string{"WP-003", "Обои синие", "Обои виниловые", "5"},
+		[]string{"WP-004", "Обои зеленые", "Обои виниловые", "300"},
+	)
+
+	// Настройка моков
+	suite.productRepo.On("GetByArticles", mock.Anything).Return([]entities.Product{}, nil)
+	suite.productRepo.On("SaveBatch", mock.MatchedBy(func(products []entities.Product) bool {
+		return len(products) == 1 && products[0].Article == "WP-004"
+	})).Return(nil)
+
+	// Выполнение
+	report, err := suite.useCase.ImportProducts(table, false)
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, report.Created())
+	assert.Equal(suite.T(), 4, report.Skipped())
+
+	errs := report.Errors()
+	require.Len(suite.T(), errs, 5)
+	assert.Equal(suite.T(), entities.ImportRowError{Row: 2, Column: "Тип продукции", Message: `тип продукции "Обои бумажные" не найден`}, errs[0])
+	assert.Equal(suite.T(), 3, errs[1].Row)
+	assert.Equal(suite.T(), "Наименование", errs[1].Column)
+	assert.Equal(suite.T(), "обязательное поле не заполнено", errs[1].Message)
+	assert.Contains(suite.T(), errs[2].Message, "не является числом")
+	assert.Equal(suite.T(), entities.ImportRowError{Row: 4, Column: "Мин. цена для партнера (руб.)", Message: "минимальная партнерская цена не может быть отрицательной"}, errs[3])
+	assert.Equal(suite.T(), "артикул уже встречался в строке 4", errs[4].Message)
+}
+
+func (suite *ImportUseCaseTestSuite) TestImportProducts_Passport() {
+	// Подготовка данных
+	table := &entities.ImportTable{
+		Header: []string{"Артикул", "Наименование", "Тип продукции", "Длина упаковки (м)", "Ширина упаковки (м)",
+			"Высота упаковки (м)", "Вес без упаковки (кг)", "Вес с упаковкой (кг)", "Номер стандарта", "Количество рабочих"},
+		Rows: []entities.ImportRow{
+			{Number: 2, Values: []string{"WP-001", "Обои белые", "Обои виниловые", "1,1", "0,15", "0,15", "1,5", "1,6", "ГОСТ 6810-2002", "3"}},
+			{Number: 3, Values: []string{"WP-002", "Обои серые", "Обои виниловые", "1,1", "0,15", "0,15", "1,6", "1,5", "", ""}},
+			{Number: 4, Values: []string{"WP-003", "Обои синие", "Обои виниловые", "", "", "", "", "", "", "2,5"}},
+		},
+	}
+
+	// Настройка моков
+	suite.productRepo.On("GetByArticles", mock.Anything).Return([]entities.Product{}, nil)
+	suite.productRepo.On("SaveBatch", mock.MatchedBy(func(products []entities.Product) bool {
+		product := products[0]
+		return len(products) == 1 && product.Article == "WP-001" &&
+			*product.PackageLength == 1.1 && *product.PackageHeight == 0.15 &&
+			*product.WeightWithPackage == 1.6 && *product.StandardNumber == "ГОСТ 6810-2002" &&
+			*product.RequiredWorkers == 3
+	})).Return(nil)
+
+	// Выполнение
+	report, err := suite.useCase.ImportProducts(table, false)
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, report.Created())
+	errs := report.Errors()
+	require.Len(suite.T(), errs, 2)
+	assert.Equal(suite.T(), entities.ImportRowError{Row: 3, Column: "Вес с упаковкой (кг)", Message: "вес с упаковкой не может быть меньше веса без упаковки"}, errs[0])
+	assert.Equal(suite.T(), entities.ImportRowError{Row: 4, Column: "Количество рабочих", Message: "ожидается целое число"}, errs[1])
+	suite.productRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ImportUseCaseTestSuite) TestImportProducts_DryRunDoesNotSave() {
+	// Подготовка данных
+	table := productImportTable([]string{"WP-001", "Обои белые", "1", "100"})
+
+	// Настройка моков
+	suite.productRepo.On("GetByArticles", []string{"WP-001"}).Return([]entities.Product{}, nil)
+
+	// Выполнение
+	report, err := suite.useCase.ImportProducts(table, true)
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), report.DryRun)
+	assert.Equal(suite.T(), 1, report.Created())
+	suite.productRepo.AssertNotCalled(suite.T(), "SaveBatch", mock.Anything)
+}
+
+func (suite *ImportUseCaseTestSuite) TestImportProducts_MissingArticleColumn() {
+	// Подготовка данных
+	table := &entities.ImportTable{
+		Header: []string{"Наименование"},
+		Rows:   []entities.ImportRow{{Number: 2, Values: []string{"Обои"}}},
+	}
+
+	// Выполнение
+	report, err := suite.useCase.ImportProducts(table, false)
+
+	// Проверки
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), report)
+	assert.IsType(suite.T(), &entities.ValidationError{}, err)
+}
+
+func (suite *ImportUseCaseTestSuite) TestImportMaterials_CostChangeRecalculatesProducts() {
+	// Подготовка данных
+	existing := entities.Material{
+		ID: 5, Article: "MAT-001", Name: "Бумага-основа", MaterialTypeID: 3,
+		MeasurementUnitID: 4, PackageQuantity: 100, CostPerUnit: 10, CostLocked: true,
+	}
+	table := &entities.ImportTable{
+		Header: []string{"article", "name", "material_type", "measurement_unit", "package_quantity", "cost_per_unit"},
+		Rows: []entities.ImportRow{
+			{Number: 2, Values: []string{"MAT-001", "", "", "", "", "12,5"}},
+			{Number: 3, Values: []string{"MAT-002", "Клей", "Бумага", "м", "20", "3"}},
+		},
+	}
+
+	// Настройка моков
+	suite.materialRepo.On("GetByArticles", []string{"MAT-001", "MAT-002"}).Return([]entities.Material{existing}, nil)
+	suite.materialRepo.On("SaveBatch", mock.MatchedBy(func(materials []entities.Material) bool {
+		return len(materials) == 2 &&
+			materials[0].ID == 5 && materials[0].CostPerUnit == 12.5 && materials[0].Name == "Бумага-основа" &&
+			materials[1].MaterialTypeID == 3 && materials[1].MeasurementUnitID == 4
+	})).Return(nil)
+	suite.costRecalculator.On("RecalculateCostsForMaterial", 5).Return(2, nil)
+
+	// Выполнение
+	report, err := suite.useCase.ImportMaterials(table, false)
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, report.Created())
+	assert.Equal(suite.T(), 1, report.Updated())
+	suite.materialRepo.AssertExpectations(suite.T())
+	suite.costRecalculator.AssertExpectations(suite.T())
+}
+
+func (suite *ImportUseCaseTestSuite) TestImportMaterials_UnlockedCostRejected() {
+	// Подготовка данных: незафиксированная себестоимость рассчитывается по журналу,
+	// строка с другой ценой пропускается
+	existing := entities.Material{
+		ID: 5, Article: "MAT-001", Name: "Бумага-основа", MaterialTypeID: 3,
+		MeasurementUnitID: 4, PackageQuantity: 100, CostPerUnit: 10,
+	}
+	table := &entities.ImportTable{
+		Header: []string{"article", "Стоимость"},
+		Rows:   []entities.ImportRow{{Number: 2, Values: []string{"MAT-001", "12,5"}}},
+	}
+
+	// Настройка моков
+	suite.materialRepo.On("GetByArticles", []string{"MAT-001"}).Return([]entities.Material{existing}, nil)
+
+	// Выполнение
+	report, err := suite.useCase.ImportMaterials(table, false)
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, report.Updated())
+	require.Len(suite.T(), report.Errors(), 1)
+	assert.Equal(suite.T(), "Стоимость", report.Errors()[0].Column)
+	suite.materialRepo.AssertNotCalled(suite.T(), "SaveBatch", mock.Anything)
+}
+
+func (suite *ImportUseCaseTestSuite) TestImportMaterials_RecalculationErrorKeepsImport() {
+	// Подготовка данных
+	existing := entities.Material{
+		ID: 5, Article: "MAT-001", Name: "Бумага-основа", MaterialTypeID: 3,
+		MeasurementUnitID: 4, PackageQuantity: 100, CostPerUnit: 10, CostLocked: true,
+	}
+	table := &entities.ImportTable{
+		Header: []string{"article", "cost_per_unit"},
+		Rows:   []entities.ImportRow{{Number: 2, Values: []string{"MAT-001", "12,5"}}},
+	}
+
+	// Настройка моков
+	suite.materialRepo.On("GetByArticles", []string{"MAT-001"}).Return([]entities.Material{existing}, nil)
+	suite.materialRepo.On("SaveBatch", mock.Anything).Return(nil)
+	suite.costRecalculator.On("RecalculateCostsForMaterial", 5).Return(0, errors.New("цикл в рецептуре"))
+
+	// Выполнение
+	report, err := suite.useCase.ImportMaterials(table, false)
+
+	// Проверки: материалы сохранены, ошибка пересчета попадает в отчет
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, report.Updated())
+	assert.Contains(suite.T(), report.CostWarning, "материалы импортированы, но себестоимость продукции не пересчитана")
+	suite.materialRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ImportUseCaseTestSuite) TestImportMaterials_RecalculatesAllChangedMaterials() {
+	// Подготовка данных
+	paper := entities.Material{
+		ID: 5, Article: "MAT-001", Name: "Бумага-основа", MaterialTypeID: 3,
+		MeasurementUnitID: 4, PackageQuantity: 100, CostPerUnit: 10, CostLocked: true,
+	}
+	paint := entities.Material{
+		ID: 6, Article: "MAT-002", Name: "Краска", MaterialTypeID: 3,
+		MeasurementUnitID: 4, PackageQuantity: 10, CostPerUnit: 40, CostLocked: true,
+	}
+	glue := entities.Material{
+		ID: 7, Article: "MAT-003", Name: "Клей", MaterialTypeID: 3,
+		MeasurementUnitID: 4, PackageQuantity: 5, CostPerUnit: 20, CostLocked: true,
+	}
+	table := &entities.ImportTable{
+		Header: []string{"article", "cost_per_unit"},
+		Rows: []entities.ImportRow{
+			{Number: 2, Values: []string{"MAT-001", "12,5"}},
+			{Number: 3, Values: []string{"MAT-002", "45"}},
+			{Number: 4, Values: []string{"MAT-003", "25"}},
+		},
+	}
+
+	// Настройка моков
+	suite.materialRepo.On("GetByArticles", []string{"MAT-001", "MAT-002", "MAT-003"}).
+		Return([]entities.Material{paper, paint, glue}, nil)
+	suite.materialRepo.On("SaveBatch", mock.Anything).Return(nil)
+	suite.costRecalculator.On("RecalculateCostsForMaterial", 5).Return(0, errors.New("цикл в рецептуре"))
+	suite.costRecalculator.On("RecalculateCostsForMaterial", 6).Return(2, nil)
+	suite.costRecalculator.On("RecalculateCostsForMaterial", 7).Return(0, errors.New("ошибка базы данных"))
+
+	// Выполнение
+	report, err := suite.useCase.ImportMaterials(table, false)
+
+	// Проверки: ошибка по первому материалу не остановила пересчет остальных,
+	// в отчет попали обе ошибки
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, report.Updated())
+	assert.Contains(suite.T(), report.CostWarning, "материал с ID 5: цикл в рецептуре")
+	assert.Contains(suite.T(), report.CostWarning, "материал с ID 7: ошибка базы данных")
+	suite.costRecalculator.AssertExpectations(suite.T())
+}
+
+func (suite *ImportUseCaseTestSuite) TestImportMaterials_StockPostingChangedCost() {
+	// Подготовка данных: цена в файле не меняется, меняется только остаток
+	existing := entities.Material{
+		ID: 5, Article: "MAT-001", Name: "Бумага-основа", MaterialTypeID: 3,
+		MeasurementUnitID: 4, PackageQuantity: 100, CostPerUnit: 10, StockQuantity: 50,
+	}
+	table := &entities.ImportTable{
+		Header: []string{"article", "stock_quantity"},
+		Rows:   []entities.ImportRow{{Number: 2, Values: []string{"MAT-001", "20"}}},
+	}
+
+	// Настройка моков: списание по FIFO при проведении остатка изменило себестоимость
+	suite.materialRepo.On("GetByArticles", []string{"MAT-001"}).Return([]entities.Material{existing}, nil)
+	suite.materialRepo.On("SaveBatch", mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).([]entities.Material)[0].CostPerUnit = 12
+	}).Return(nil)
+	suite.costRecalculator.On("RecalculateCostsForMaterial", 5).Return(2, nil)
+
+	// Выполнение
+	report, err := suite.useCase.ImportMaterials(table, false)
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Empty(suite.T(), report.CostWarning)
+	suite.costRecalculator.AssertExpectations(suite.T())
+}
+
+func TestImportUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportUseCaseTestSuite))
+}
@@ -1,30 +1,233 @@
 package usecases
 
-import "wallpaper-system/internal/domain/entities"
+import (
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+)
 
 // ProductUseCaseInterface определяет интерфейс для работы с продукцией
 type ProductUseCaseInterface interface {
 	GetAllProducts() ([]entities.Product, error)
+	FindProducts(criteria entities.ProductCriteria) (*entities.ProductList, error)
 	GetProductByID(id int) (*entities.Product, error)
 	CreateProduct(product *entities.Product) error
 	UpdateProduct(product *entities.Product) error
-	DeleteProduct(id int) error
+	CloneProduct(id int, request entities.ProductCloneRequest) (*entities.Product, error)
+	ArchiveProduct(id int) error
+	RestoreProduct(id int) error
 	GetProductTypes() ([]entities.ProductType, error)
+	GetProductMaterials(productID int) ([]entities.ProductMaterial, error)
+	AddProductMaterial(productID int, productMaterial *entities.ProductMaterial) (*entities.Product, error)
+	UpdateProductMaterial(productID int, productMaterial *entities.ProductMaterial) (*entities.Product, error)
+	RemoveProductMaterial(productID, materialID int) (*entities.Product, error)
+	ReplaceProductMaterials(productID int, materials []entities.ProductMaterial) (*entities.Product, error)
+	GetProductComponents(productID int) ([]entities.ProductComponent, error)
+	AddProductComponent(productID int, component *entities.ProductComponent) (*entities.Product, error)
+	UpdateProductComponent(productID int, component *entities.ProductComponent) (*entities.Product, error)
+	RemoveProductComponent(productID, componentProductID int) (*entities.Product, error)
+	ExplodeMaterials(productID int, quantity float64) ([]entities.MaterialRequirement, error)
+	CalculateProductPrice(productID int, partnerTypeID *int, date time.Time) (*entities.PriceCalculation, error)
+	RecalculateCost(productID int) (*entities.Product, error)
+	RecalculateCostsForMaterial(materialID int) (int, error)
+	RecalculateCostsForProductType(productTypeID int) (int, error)
+	RecalculateAllCosts() (int, error)
+	ExportProducts(request entities.ProductExportRequest) (*entities.ExportTable, error)
+}
+
+// ProductCostRecalculator пересчитывает сохраненную себестоимость продукции при изменении сырья
+type ProductCostRecalculator interface {
+	RecalculateCostsForMaterial(materialID int) (int, error)
+}
+
+// ProductTypeCostRecalculator пересчитывает сохраненную себестоимость продукции
+// при изменении коэффициента типа
+type ProductTypeCostRecalculator interface {
+	RecalculateCostsForProductType(productTypeID int) (int, error)
+}
+
+// MaterialExploder раскладывает рецептуру продукции до сырья, например для резерва
+// материалов под заказ
+type MaterialExploder interface {
+	ExplodeMaterials(productID int, quantity float64) ([]entities.MaterialRequirement, error)
+}
+
+// RecipeCalculator рассчитывает цену и потребность в сырье для уже загруженной продукции,
+// например варианта с подставленной рецептурой
+type RecipeCalculator interface {
+	CalculatePriceFor(product *entities.Product, partnerTypeID *int, date time.Time) (*entities.PriceCalculation, error)
+	ExplodeMaterialsFor(product *entities.Product, quantity float64) ([]entities.MaterialRequirement, error)
+}
+
+// ProductVariantUseCaseInterface определяет интерфейс для работы с вариантами (расцветками) продукции
+type ProductVariantUseCaseInterface interface {
+	GetProductVariants(productID int) (*entities.Product, []entities.ProductVariant, error)
+	GetVariantByID(id int) (*entities.ProductVariant, error)
+	CreateVariant(variant *entities.ProductVariant) error
+	UpdateVariant(variant *entities.ProductVariant) error
+	DeleteVariant(id int) error
+	SetVariantOverride(variantID int, override entities.VariantMaterialOverride) (*entities.ProductVariant, error)
+	RemoveVariantOverride(variantID, materialID int) (*entities.ProductVariant, error)
+	ResolveVariant(id int) (*entities.Product, error)
+	CalculateVariantPrice(id int, partnerTypeID *int, date time.Time) (*entities.PriceCalculation, error)
+	ExplodeVariantMaterials(id int, quantity float64) ([]entities.MaterialRequirement, error)
+}
+
+// ProductTypeUseCaseInterface определяет интерфейс управления типами продукции
+type ProductTypeUseCaseInterface interface {
+	GetProductTypes(includeArchived bool) ([]entities.ProductType, error)
+	GetProductTypeByID(id int) (*entities.ProductType, error)
+	CreateProductType(productType *entities.ProductType, change *entities.CoefficientChange) error
+	UpdateProductType(productType *entities.ProductType, change *entities.CoefficientChange) error
+	ArchiveProductType(id int) error
+	RestoreProductType(id int) error
+	GetCoefficientHistory(id int) ([]entities.CoefficientChange, error)
+}
+
+// MaterialTypeUseCaseInterface определяет интерфейс управления типами материалов
+type MaterialTypeUseCaseInterface interface {
+	GetMaterialTypes() ([]entities.MaterialType, error)
+	GetMaterialTypeByID(id int) (*entities.MaterialType, error)
+	CreateMaterialType(materialType *entities.MaterialType, change *entities.DefectRateChange) error
+	UpdateMaterialType(materialType *entities.MaterialType, change *entities.DefectRateChange) error
+	DeleteMaterialType(id int) error
+	GetDefectRateHistory(id int) ([]entities.DefectRateChange, error)
+}
+
+// UnitConversionUseCaseInterface определяет интерфейс пересчета единиц измерения
+type UnitConversionUseCaseInterface interface {
+	GetUnits() ([]entities.MeasurementUnit, error)
+	Convert(materialID *int, quantity float64, from, to string) (*entities.UnitConversion, error)
+	GetMaterialEquivalents(materialID int) ([]entities.UnitEquivalent, error)
+	GetMaterialConversions(materialID int) ([]entities.MaterialUnitConversion, error)
+	SaveMaterialConversion(conversion *entities.MaterialUnitConversion) error
+	DeleteMaterialConversion(materialID, unitID int) error
+	GetMaterialStock(materialID int, unit string) (*entities.StockInUnit, error)
+	ExpressProductMaterials(lines []entities.ProductMaterial, unit string) ([]entities.ProductMaterial, error)
+	ExpressRequirements(requirements []entities.MaterialRequirement, unit string) ([]entities.MaterialRequirement, error)
 }
 
 // MaterialUseCaseInterface определяет интерфейс для работы с материалами
 type MaterialUseCaseInterface interface {
 	GetAllMaterials() ([]entities.Material, error)
+	FindMaterials(criteria entities.MaterialCriteria) (*entities.MaterialList, error)
 	GetMaterialByID(id int) (*entities.Material, error)
 	CreateMaterial(material *entities.Material) error
 	UpdateMaterial(material *entities.Material) error
-	DeleteMaterial(id int) error
+	ArchiveMaterial(id int) error
+	RestoreMaterial(id int) error
 	GetMaterialTypes() ([]entities.MaterialType, error)
 	GetMeasurementUnits() ([]entities.MeasurementUnit, error)
 	GetMaterialsForProduct(productID int) ([]entities.Material, error)
+	ExportMaterials(request entities.MaterialExportRequest) (*entities.ExportTable, error)
 }
 
 // CalculatorUseCaseInterface определяет интерфейс для калькулятора
 type CalculatorUseCaseInterface interface {
 	CalculateRequiredMaterial(request *entities.MaterialCalculationRequest) (int, error)
 }
+
+// RoomCalculatorUseCaseInterface определяет интерфейс калькулятора рулонов обоев для комнаты
+type RoomCalculatorUseCaseInterface interface {
+	GetRollProducts() ([]entities.Product, error)
+	GetPartnerTypes() ([]entities.PartnerType, error)
+	CalculateRoomRolls(request *entities.RoomRollRequest) (*entities.RoomRollCalculation, error)
+}
+
+// PricingRuleUseCaseInterface определяет интерфейс для работы с правилами ценообразования
+type PricingRuleUseCaseInterface interface {
+	GetAllRules() ([]entities.PricingRule, error)
+	GetRuleByID(id int) (*entities.PricingRule, error)
+	CreateRule(rule *entities.PricingRule) error
+	UpdateRule(rule *entities.PricingRule) error
+	DeleteRule(id int) error
+	GetPartnerTypes() ([]entities.PartnerType, error)
+}
+
+// SearchUseCaseInterface определяет интерфейс полнотекстового поиска
+type SearchUseCaseInterface interface {
+	Search(query entities.SearchQuery) (*entities.SearchResults, error)
+}
+
+// ImportUseCaseInterface определяет интерфейс массового импорта из файлов
+type ImportUseCaseInterface interface {
+	ImportProducts(table *entities.ImportTable, dryRun bool) (*entities.ImportReport, error)
+	ImportMaterials(table *entities.ImportTable, dryRun bool) (*entities.ImportReport, error)
+}
+
+// ImageUseCaseInterface определяет интерфейс загрузки изображений продукции и материалов
+type ImageUseCaseInterface interface {
+	UploadProductImage(productID int, upload *entities.ImageUpload) (*entities.Product, error)
+	DeleteProductImage(productID int) (*entities.Product, error)
+	UploadMaterialImage(materialID int, upload *entities.ImageUpload) (*entities.Material, error)
+	DeleteMaterialImage(materialID int) (*entities.Material, error)
+	UploadVariantImage(variantID int, upload *entities.ImageUpload) (*entities.ProductVariant, error)
+	DeleteVariantImage(variantID int) (*entities.ProductVariant, error)
+}
+
+// CertificateUseCaseInterface определяет интерфейс работы с сертификатами качества
+type CertificateUseCaseInterface interface {
+	GetAllCertificates() ([]entities.QualityCertificate, error)
+	GetCertificateByID(id int) (*entities.QualityCertificate, error)
+	GetProductCertificates(productID int) ([]entities.QualityCertificate, error)
+	CreateCertificate(certificate *entities.QualityCertificate) error
+	UpdateCertificate(certificate *entities.QualityCertificate) error
+	DeleteCertificate(id int) error
+	UploadCertificateFile(id int, data []byte, contentType string) (*entities.QualityCertificate, error)
+	GetExpiringCertificates(days int) ([]entities.ExpiringCertificate, error)
+	CheckProducts(productIDs []int, date time.Time) ([]entities.CertificateWarning, error)
+	CheckOrder(orderID int, date time.Time) ([]entities.CertificateWarning, error)
+}
+
+// ShippingUseCaseInterface определяет интерфейс расчета отгрузки на поддонах
+type ShippingUseCaseInterface interface {
+	CalculateShipment(request *entities.ShipmentRequest) (*entities.ShipmentPlan, error)
+	CalculateOrderShipment(orderID int, pallet entities.PalletSpec) (*entities.ShipmentPlan, error)
+}
+
+// MaterialMovementUseCaseInterface определяет интерфейс складского журнала материалов
+type MaterialMovementUseCaseInterface interface {
+	GetMovements(criteria entities.MovementCriteria) (*entities.MovementList, error)
+	PostMovement(movement *entities.MaterialMovement) error
+}
+
+// StockAlertUseCaseInterface определяет интерфейс контроля минимальных остатков материалов
+type StockAlertUseCaseInterface interface {
+	GetLowStock() ([]entities.LowStockItem, error)
+	CheckLowStock() (*entities.StockCheckResult, error)
+	GetNotifications(openOnly bool) ([]entities.StockNotification, error)
+	AcknowledgeNotification(id int) error
+}
+
+// OrderUseCaseInterface определяет интерфейс смены статусов заказов и резервов материалов
+type OrderUseCaseInterface interface {
+	GetOrder(id int) (*entities.Order, error)
+	GetReservations(orderID int) ([]entities.MaterialReservation, error)
+	ChangeStatus(orderID int, status entities.OrderStatus) (*entities.Order, error)
+}
+
+// StocktakeUseCaseInterface определяет интерфейс инвентаризации материалов
+type StocktakeUseCaseInterface interface {
+	GetStocktakes() ([]entities.Stocktake, error)
+	GetReport(id int) (*entities.StocktakeReport, error)
+	OpenStocktake(note *string) (*entities.Stocktake, error)
+	SaveCounts(id int, counts []entities.StocktakeCount) (*entities.StocktakeReport, error)
+	CloseStocktake(id int) (*entities.StocktakeReport, error)
+	CancelStocktake(id int) error
+}
+
+// MaterialLotUseCaseInterface определяет интерфейс поставок и партий материалов
+type MaterialLotUseCaseInterface interface {
+	GetSuppliers() ([]entities.Supplier, error)
+	ReceiveSupply(supply *entities.MaterialSupply, batchNumber *string) (*entities.MaterialLot, error)
+	GetMaterialLots(materialID int) ([]entities.MaterialLot, error)
+	GetLotTrace(lotID int) (*entities.LotTrace, error)
+	GetOrderLots(orderID int) ([]entities.LotConsumption, error)
+}
+
+// MaterialCostingUseCaseInterface определяет интерфейс себестоимости материалов
+type MaterialCostingUseCaseInterface interface {
+	GetCosting(materialID int) (*entities.MaterialCosting, error)
+	UpdateCosting(materialID int, method entities.CostingMethod, locked bool, manualCost *float64) (*entities.MaterialCosting, error)
+	RecalculateCost(materialID int) (*entities.MaterialCosting, error)
+}
@@ -0,0 +1,84 @@
+package usecases
+
+import (
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// MaterialCostingUseCase содержит бизнес-логику себестоимости материалов по журналу
+type MaterialCostingUseCase struct {
+	costingRepo      repositories.MaterialCostingRepository
+	costRecalculator ProductCostRecalculator
+}
+
+// NewMaterialCostingUseCase создает новый use case себестоимости материалов
+func NewMaterialCostingUseCase(
+	costingRepo repositories.MaterialCostingRepository,
+	costRecalculator ProductCostRecalculator,
+) *MaterialCostingUseCase {
+	return &MaterialCostingUseCase{
+		costingRepo:      costingRepo,
+		costRecalculator: costRecalculator,
+	}
+}
+
+// GetCosting возвращает метод расчета, фактическую и используемую себестоимость материала
+func (uc *MaterialCostingUseCase) GetCosting(materialID int) (*entities.MaterialCosting, error) {
+	return uc.costingRepo.GetByMaterialID(materialID)
+}
+
+// UpdateCosting меняет метод расчета и фиксацию себестоимости материала и пересчитывает
+// себестоимость по журналу. Зафиксированную себестоимость можно задать вручную (manualCost)
+func (uc *MaterialCostingUseCase) UpdateCosting(materialID int, method entities.CostingMethod, locked bool, manualCost *float64) (*entities.MaterialCosting, error) {
+	costing, err := uc.costingRepo.GetByMaterialID(materialID)
+	if err != nil {
+		return nil, err
+	}
+	previousCost := costing.CostPerUnit
+
+	if err := costing.ChangeSettings(method, locked, manualCost); err != nil {
+		return nil, err
+	}
+	if err := uc.costingRepo.Update(costing); err != nil {
+		return nil, err
+	}
+
+	return uc.afterRecost(materialID, previousCost, costing.CostPerUnit)
+}
+
+// RecalculateCost заново рассчитывает себестоимость материала по журналу текущим методом
+func (uc *MaterialCostingUseCase) RecalculateCost(materialID int) (*entities.MaterialCosting, error) {
+	costing, err := uc.costingRepo.GetByMaterialID(materialID)
+	if err != nil {
+		return nil, err
+	}
+	previousCost := costing.CostPerUnit
+
+	recalculated, err := uc.costingRepo.Recalculate(materialID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.afterRecost(materialID, previousCost, recalculated.CostPerUnit)
+}
+
+// afterRecost пересчитывает себестоимость продукции, если себестоимость материала
+// изменилась, и возвращает себестоимость материала после пересчета. Себестоимость материала
+// уже сохранена, поэтому ошибка пересчета продукции возвращается вместе с ней отдельно
+func (uc *MaterialCostingUseCase) afterRecost(materialID int, previousCost, cost float64) (*entities.MaterialCosting, error) {
+	var recalcErr error
+	if previousCost != cost {
+		if _, err := uc.costRecalculator.RecalculateCostsForMaterial(materialID); err != nil {
+			recalcErr = entities.NewCostRecalculationError("себестоимость материала пересчитана", err)
+		}
+	}
+
+	costing, err := uc.costingRepo.GetByMaterialID(materialID)
+	if err != nil {
+		return nil, err
+	}
+	if recalcErr != nil {
+		return costing, recalcErr
+	}
+	return costing, nil
+}
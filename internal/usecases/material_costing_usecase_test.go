@@ -0,0 +1,117 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/mocks"
+	usecasemocks "wallpaper-system/internal/usecases/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type MaterialCostingUseCaseTestSuite struct {
+	suite.Suite
+	costingRepo      *mocks.MockMaterialCostingRepository
+	costRecalculator *usecasemocks.MockProductUseCase
+	useCase          *MaterialCostingUseCase
+}
+
+func (suite *MaterialCostingUseCaseTestSuite) SetupTest() {
+	suite.costingRepo = new(mocks.MockMaterialCostingRepository)
+	suite.costRecalculator = new(usecasemocks.MockProductUseCase)
+	suite.useCase = NewMaterialCostingUseCase(suite.costingRepo, suite.costRecalculator)
+}
+
+func (suite *MaterialCostingUseCaseTestSuite) TestUpdateCosting_SwitchToFIFO() {
+	// Подготовка данных
+	costing := &entities.MaterialCosting{MaterialID: 7, Method: entities.CostingWeightedAverage, ActualCost: 115, CostPerUnit: 115}
+	updated := &entities.MaterialCosting{MaterialID: 7, Method: entities.CostingFIFO, ActualCost: 130, CostPerUnit: 130}
+
+	// Настройка моков
+	suite.costingRepo.On("GetByMaterialID", 7).Return(costing, nil).Once()
+	suite.costingRepo.On("Update", mock.MatchedBy(func(c *entities.MaterialCosting) bool {
+		return c.Method == entities.CostingFIFO && !c.Locked
+	})).Run(func(args mock.Arguments) {
+		c := args.Get(0).(*entities.MaterialCosting)
+		c.ActualCost = 130
+		c.CostPerUnit = 130
+	}).Return(nil)
+	suite.costRecalculator.On("RecalculateCostsForMaterial", 7).Return(2, nil)
+	suite.costingRepo.On("GetByMaterialID", 7).Return(updated, nil).Once()
+
+	// Выполнение
+	result, err := suite.useCase.UpdateCosting(7, entities.CostingFIFO, false, nil)
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), updated, result)
+	suite.costingRepo.AssertExpectations(suite.T())
+	suite.costRecalculator.AssertExpectations(suite.T())
+}
+
+func (suite *MaterialCostingUseCaseTestSuite) TestUpdateCosting_ManualCostRequiresLock() {
+	// Подготовка данных
+	manualCost := 95.0
+	costing := &entities.MaterialCosting{MaterialID: 7, Method: entities.CostingWeightedAverage, CostPerUnit: 115}
+
+	// Настройка моков
+	suite.costingRepo.On("GetByMaterialID", 7).Return(costing, nil)
+
+	// Выполнение
+	result, err := suite.useCase.UpdateCosting(7, entities.CostingWeightedAverage, false, &manualCost)
+
+	// Проверки
+	var validationErr *entities.ValidationError
+	require.ErrorAs(suite.T(), err, &validationErr)
+	assert.Equal(suite.T(), "cost_per_unit", validationErr.Field)
+	assert.Nil(suite.T(), result)
+	suite.costingRepo.AssertNotCalled(suite.T(), "Update", mock.Anything)
+}
+
+func (suite *MaterialCostingUseCaseTestSuite) TestRecalculateCost_UnchangedSkipsProducts() {
+	// Подготовка данных
+	costing := &entities.MaterialCosting{MaterialID: 7, Method: entities.CostingWeightedAverage, ActualCost: 115, CostPerUnit: 115}
+
+	// Настройка моков
+	suite.costingRepo.On("GetByMaterialID", 7).Return(costing, nil)
+	suite.costingRepo.On("Recalculate", 7).Return(costing, nil)
+
+	// Выполнение
+	result, err := suite.useCase.RecalculateCost(7)
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 115.0, result.CostPerUnit)
+	suite.costRecalculator.AssertNotCalled(suite.T(), "RecalculateCostsForMaterial", mock.Anything)
+}
+
+func (suite *MaterialCostingUseCaseTestSuite) TestRecalculateCost_ProductRecalculationFails() {
+	// Подготовка данных
+	costing := &entities.MaterialCosting{MaterialID: 7, Method: entities.CostingFIFO, CostPerUnit: 110}
+	recalculated := &entities.MaterialCosting{MaterialID: 7, Method: entities.CostingFIFO, ActualCost: 130, CostPerUnit: 130}
+
+	// Настройка моков
+	suite.costingRepo.On("GetByMaterialID", 7).Return(costing, nil).Once()
+	suite.costingRepo.On("Recalculate", 7).Return(recalculated, nil)
+	suite.costRecalculator.On("RecalculateCostsForMaterial", 7).Return(0, errors.New("db error"))
+	suite.costingRepo.On("GetByMaterialID", 7).Return(recalculated, nil).Once()
+
+	// Выполнение
+	result, err := suite.useCase.RecalculateCost(7)
+
+	// Проверки: себестоимость материала пересчитана, ошибка пересчета продукции возвращается отдельно
+	var recalcErr *entities.CostRecalculationError
+	require.ErrorAs(suite.T(), err, &recalcErr)
+	require.NotNil(suite.T(), result)
+	assert.Equal(suite.T(), 130.0, result.CostPerUnit)
+	suite.costingRepo.AssertExpectations(suite.T())
+}
+
+func TestMaterialCostingUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(MaterialCostingUseCaseTestSuite))
+}
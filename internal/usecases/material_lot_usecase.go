@@ -0,0 +1,157 @@
+package usecases
+
+import (
+	"fmt"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// MaterialLotUseCase содержит бизнес-логику поставок, партий материалов и их прослеживаемости
+type MaterialLotUseCase struct {
+	lotRepo          repositories.MaterialLotRepository
+	orderRepo        repositories.OrderRepository
+	productRepo      repositories.ProductRepository
+	exploder         MaterialExploder
+	costRecalculator ProductCostRecalculator
+}
+
+// NewMaterialLotUseCase создает новый use case партий материалов
+func NewMaterialLotUseCase(
+	lotRepo repositories.MaterialLotRepository,
+	orderRepo repositories.OrderRepository,
+	productRepo repositories.ProductRepository,
+	exploder MaterialExploder,
+	costRecalculator ProductCostRecalculator,
+) *MaterialLotUseCase {
+	return &MaterialLotUseCase{
+		lotRepo:          lotRepo,
+		orderRepo:        orderRepo,
+		productRepo:      productRepo,
+		exploder:         exploder,
+		costRecalculator: costRecalculator,
+	}
+}
+
+// GetSuppliers возвращает поставщиков по названию
+func (uc *MaterialLotUseCase) GetSuppliers() ([]entities.Supplier, error) {
+	suppliers, err := uc.lotRepo.GetSuppliers()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения поставщиков: %w", err)
+	}
+	return suppliers, nil
+}
+
+// ReceiveSupply принимает поставку материала на склад: проводит приход и создает партию
+// с поставщиком, датой поступления, номером партии поставщика и ценой поставки. Поставка
+// меняет себестоимость материала, поэтому себестоимость продукции с ним пересчитывается
+func (uc *MaterialLotUseCase) ReceiveSupply(supply *entities.MaterialSupply, batchNumber *string) (*entities.MaterialLot, error) {
+	if err := supply.Validate(); err != nil {
+		return nil, err
+	}
+
+	lot := entities.NewSupplyLot(supply, batchNumber)
+	if err := uc.lotRepo.ReceiveSupply(supply, lot); err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.costRecalculator.RecalculateCostsForMaterial(supply.MaterialID); err != nil {
+		return lot, entities.NewCostRecalculationError("поставка принята", err)
+	}
+	return lot, nil
+}
+
+// GetMaterialLots возвращает партии материала в порядке расхода (FIFO)
+func (uc *MaterialLotUseCase) GetMaterialLots(materialID int) ([]entities.MaterialLot, error) {
+	lots, err := uc.lotRepo.GetMaterialLots(materialID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения партий материала: %w", err)
+	}
+	return lots, nil
+}
+
+// GetLotTrace возвращает прослеживаемость партии: движения, забравшие ее материал,
+// и продукцию заказов, в рецептуру которой входит материал партии
+func (uc *MaterialLotUseCase) GetLotTrace(lotID int) (*entities.LotTrace, error) {
+	lot, err := uc.lotRepo.GetByID(lotID)
+	if err != nil {
+		return nil, err
+	}
+
+	consumptions, err := uc.lotRepo.GetLotConsumptions(lotID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения расхода из партии: %w", err)
+	}
+
+	trace := &entities.LotTrace{Lot: lot, Consumptions: consumptions}
+	if trace.Products, err = uc.productsUsing(lot.MaterialID, trace.OrderIDs()); err != nil {
+		return nil, err
+	}
+	return trace, nil
+}
+
+// GetOrderLots возвращает партии, из которых списан материал в расход по заказу
+func (uc *MaterialLotUseCase) GetOrderLots(orderID int) ([]entities.LotConsumption, error) {
+	if _, err := uc.orderRepo.GetByID(orderID); err != nil {
+		return nil, err
+	}
+
+	consumptions, err := uc.lotRepo.GetOrderConsumptions(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения партий заказа: %w", err)
+	}
+	return consumptions, nil
+}
+
+// productsUsing возвращает позиции заказов, в рецептуру продукции которых (с учетом
+// полуфабрикатов) входит материал
+func (uc *MaterialLotUseCase) productsUsing(materialID int, orderIDs []int) ([]entities.LotProductUsage, error) {
+	usesMaterial := make(map[int]bool)
+	products := make(map[int]*entities.Product)
+
+	var usages []entities.LotProductUsage
+	for _, orderID := range orderIDs {
+		items, err := uc.orderRepo.GetItems(orderID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			uses, checked := usesMaterial[item.ProductID]
+			if !checked {
+				requirements, err := uc.exploder.ExplodeMaterials(item.ProductID, 1)
+				if err != nil {
+					return nil, fmt.Errorf("ошибка расчета сырья для продукции с ID %d: %w", item.ProductID, err)
+				}
+				for _, requirement := range requirements {
+					if requirement.MaterialID == materialID {
+						uses = true
+						break
+					}
+				}
+				usesMaterial[item.ProductID] = uses
+			}
+			if !uses {
+				continue
+			}
+
+			product, ok := products[item.ProductID]
+			if !ok {
+				if product, err = uc.productRepo.GetByID(item.ProductID); err != nil {
+					return nil, err
+				}
+				products[item.ProductID] = product
+			}
+
+			usages = append(usages, entities.LotProductUsage{
+				OrderID:        orderID,
+				ProductID:      product.ID,
+				ProductArticle: product.Article,
+				ProductName:    product.Name,
+				Quantity:       item.Quantity,
+			})
+		}
+	}
+
+	return usages, nil
+}
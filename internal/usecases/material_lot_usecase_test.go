@@ -0,0 +1,170 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/mocks"
+	usecasemocks "wallpaper-system/internal/usecases/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type MaterialLotUseCaseTestSuite struct {
+	suite.Suite
+	lotRepo     *mocks.MockMaterialLotRepository
+	orderRepo   *mocks.MockOrderRepository
+	productRepo *mocks.MockProductRepository
+	exploder    *usecasemocks.MockProductUseCase
+	useCase     *MaterialLotUseCase
+}
+
+func (suite *MaterialLotUseCaseTestSuite) SetupTest() {
+	suite.lotRepo = new(mocks.MockMaterialLotRepository)
+	suite.orderRepo = new(mocks.MockOrderRepository)
+	suite.productRepo = new(mocks.MockProductRepository)
+	suite.exploder = new(usecasemocks.MockProductUseCase)
+	suite.useCase = NewMaterialLotUseCase(suite.lotRepo, suite.orderRepo, suite.productRepo, suite.exploder, suite.exploder)
+}
+
+func (suite *MaterialLotUseCaseTestSuite) TestReceiveSupply() {
+	// Подготовка данных
+	batch := "  B-2024-17 "
+	supply := &entities.MaterialSupply{
+		SupplierID: 2, MaterialID: 7, Quantity: 120.5, UnitPrice: 310,
+		SupplyDate: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+	}
+
+	// Настройка моков
+	suite.lotRepo.On("ReceiveSupply", supply, mock.MatchedBy(func(lot *entities.MaterialLot) bool {
+		return lot.MaterialID == 7 && lot.InitialQuantity == 120.5 && lot.RemainingQuantity == 120.5 && lot.UnitCost == 310 &&
+			lot.BatchNumber != nil && *lot.BatchNumber == "B-2024-17" && lot.ReceivedAt.Equal(supply.SupplyDate)
+	})).Run(func(args mock.Arguments) {
+		lot := args.Get(1).(*entities.MaterialLot)
+		lot.ID = 11
+	}).Return(nil)
+	suite.exploder.On("RecalculateCostsForMaterial", 7).Return(3, nil)
+
+	// Выполнение
+	lot, err := suite.useCase.ReceiveSupply(supply, &batch)
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 11, lot.ID)
+	assert.Equal(suite.T(), 37355.0, supply.TotalAmount)
+	suite.lotRepo.AssertExpectations(suite.T())
+	suite.exploder.AssertExpectations(suite.T())
+}
+
+func (suite *MaterialLotUseCaseTestSuite) TestReceiveSupply_RecalculationErrorKeepsLot() {
+	// Подготовка данных
+	supply := &entities.MaterialSupply{SupplierID: 2, MaterialID: 7, Quantity: 10, UnitPrice: 310, SupplyDate: time.Now()}
+
+	// Настройка моков
+	suite.lotRepo.On("ReceiveSupply", supply, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*entities.MaterialLot).ID = 11
+	}).Return(nil)
+	suite.exploder.On("RecalculateCostsForMaterial", 7).Return(0, errors.New("database error"))
+
+	// Выполнение
+	lot, err := suite.useCase.ReceiveSupply(supply, nil)
+
+	// Проверки: поставка принята, ошибка пересчета продукции возвращается отдельно
+	var recalcErr *entities.CostRecalculationError
+	require.ErrorAs(suite.T(), err, &recalcErr)
+	require.NotNil(suite.T(), lot)
+	assert.Equal(suite.T(), 11, lot.ID)
+	suite.lotRepo.AssertExpectations(suite.T())
+}
+
+func (suite *MaterialLotUseCaseTestSuite) TestReceiveSupply_InvalidQuantity() {
+	// Подготовка данных
+	supply := &entities.MaterialSupply{SupplierID: 2, MaterialID: 7, Quantity: 0, SupplyDate: time.Now()}
+
+	// Выполнение
+	lot, err := suite.useCase.ReceiveSupply(supply, nil)
+
+	// Проверки
+	var validationErr *entities.ValidationError
+	require.ErrorAs(suite.T(), err, &validationErr)
+	assert.Equal(suite.T(), "quantity", validationErr.Field)
+	assert.Nil(suite.T(), lot)
+	suite.lotRepo.AssertNotCalled(suite.T(), "ReceiveSupply", mock.Anything, mock.Anything)
+}
+
+func (suite *MaterialLotUseCaseTestSuite) TestGetLotTrace_ProductsUsingLot() {
+	// Подготовка данных: материал партии ушел в заказы 5 и 6, обои 10 содержат его в рецептуре,
+	// клей 12 - нет
+	orderID5, orderID6 := 5, 6
+	lot := &entities.MaterialLot{ID: 11, MaterialID: 7, InitialQuantity: 100, RemainingQuantity: 40}
+	consumptions := []entities.LotConsumption{
+		{LotID: 11, Quantity: 30, Movement: &entities.MaterialMovement{ReferenceType: entities.ReferenceOrder, ReferenceID: &orderID5}},
+		{LotID: 11, Quantity: 5, Movement: &entities.MaterialMovement{ReferenceType: entities.ReferenceWriteOff}},
+		{LotID: 11, Quantity: 25, Movement: &entities.MaterialMovement{ReferenceType: entities.ReferenceOrder, ReferenceID: &orderID6}},
+	}
+
+	// Настройка моков
+	suite.lotRepo.On("GetByID", 11).Return(lot, nil)
+	suite.lotRepo.On("GetLotConsumptions", 11).Return(consumptions, nil)
+	suite.orderRepo.On("GetItems", 5).Return([]entities.OrderItem{
+		{OrderID: 5, ProductID: 10, Quantity: 20},
+		{OrderID: 5, ProductID: 12, Quantity: 3},
+	}, nil)
+	suite.orderRepo.On("GetItems", 6).Return([]entities.OrderItem{{OrderID: 6, ProductID: 10, Quantity: 8}}, nil)
+	suite.exploder.On("ExplodeMaterials", 10, 1.0).Return([]entities.MaterialRequirement{
+		{MaterialID: 2, Quantity: 0.1},
+		{MaterialID: 7, Quantity: 0.6},
+	}, nil).Once()
+	suite.exploder.On("ExplodeMaterials", 12, 1.0).Return([]entities.MaterialRequirement{{MaterialID: 2, Quantity: 1}}, nil).Once()
+	suite.productRepo.On("GetByID", 10).Return(&entities.Product{ID: 10, Article: "WP-10", Name: "Обои Флора"}, nil).Once()
+
+	// Выполнение
+	trace, err := suite.useCase.GetLotTrace(11)
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []int{5, 6}, trace.OrderIDs())
+	require.Len(suite.T(), trace.Products, 2)
+	assert.Equal(suite.T(), entities.LotProductUsage{OrderID: 5, ProductID: 10, ProductArticle: "WP-10", ProductName: "Обои Флора", Quantity: 20}, trace.Products[0])
+	assert.Equal(suite.T(), 6, trace.Products[1].OrderID)
+	assert.Equal(suite.T(), 8, trace.Products[1].Quantity)
+	suite.exploder.AssertExpectations(suite.T())
+	suite.productRepo.AssertExpectations(suite.T())
+}
+
+func (suite *MaterialLotUseCaseTestSuite) TestGetLotTrace_NotFound() {
+	// Настройка моков
+	suite.lotRepo.On("GetByID", 99).Return(nil, entities.NewNotFoundError("партия материала", "99"))
+
+	// Выполнение
+	trace, err := suite.useCase.GetLotTrace(99)
+
+	// Проверки
+	var notFoundErr *entities.NotFoundError
+	require.ErrorAs(suite.T(), err, &notFoundErr)
+	assert.Nil(suite.T(), trace)
+	suite.lotRepo.AssertNotCalled(suite.T(), "GetLotConsumptions", mock.Anything)
+}
+
+func (suite *MaterialLotUseCaseTestSuite) TestGetOrderLots_OrderNotFound() {
+	// Настройка моков
+	suite.orderRepo.On("GetByID", 8).Return(nil, entities.NewNotFoundError("заказ", "8"))
+
+	// Выполнение
+	consumptions, err := suite.useCase.GetOrderLots(8)
+
+	// Проверки
+	var notFoundErr *entities.NotFoundError
+	require.ErrorAs(suite.T(), err, &notFoundErr)
+	assert.Nil(suite.T(), consumptions)
+	suite.lotRepo.AssertNotCalled(suite.T(), "GetOrderConsumptions", mock.Anything)
+}
+
+func TestMaterialLotUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(MaterialLotUseCaseTestSuite))
+}
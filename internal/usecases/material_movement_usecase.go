@@ -0,0 +1,72 @@
+package usecases
+
+import (
+	"fmt"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// MaterialMovementUseCase содержит бизнес-логику складского журнала материалов
+type MaterialMovementUseCase struct {
+	movementRepo     repositories.MaterialMovementRepository
+	materialRepo     repositories.MaterialRepository
+	costRecalculator ProductCostRecalculator
+}
+
+// NewMaterialMovementUseCase создает новый use case складского журнала
+func NewMaterialMovementUseCase(
+	movementRepo repositories.MaterialMovementRepository,
+	materialRepo repositories.MaterialRepository,
+	costRecalculator ProductCostRecalculator,
+) *MaterialMovementUseCase {
+	return &MaterialMovementUseCase{
+		movementRepo:     movementRepo,
+		materialRepo:     materialRepo,
+		costRecalculator: costRecalculator,
+	}
+}
+
+// GetMovements возвращает страницу журнала движений материала
+func (uc *MaterialMovementUseCase) GetMovements(criteria entities.MovementCriteria) (*entities.MovementList, error) {
+	if err := criteria.Validate(); err != nil {
+		return nil, err
+	}
+	criteria.Pagination.Normalize()
+
+	if _, err := uc.materialRepo.GetByID(criteria.MaterialID); err != nil {
+		return nil, err
+	}
+
+	movements, total, err := uc.movementRepo.FindByCriteria(criteria)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения движений материала: %w", err)
+	}
+
+	return &entities.MovementList{
+		Items:    movements,
+		PageInfo: entities.PageInfo{Page: criteria.Page, PageSize: criteria.PageSize, Total: total},
+	}, nil
+}
+
+// PostMovement проводит приход, расход или списание материала; новый остаток сохраняется
+// в movement.RemainingQuantity. Резерв и снятие резерва проводятся только по заказу.
+// Если движение изменило себестоимость материала, а себестоимость продукции пересчитать
+// не удалось, возвращается CostRecalculationError: движение при этом остается проведенным
+func (uc *MaterialMovementUseCase) PostMovement(movement *entities.MaterialMovement) error {
+	if err := movement.Validate(); err != nil {
+		return err
+	}
+	if movement.Type == entities.MovementReserve || movement.Type == entities.MovementRelease {
+		return entities.NewValidationError("movement_type", "резерв материала проводится по заказу")
+	}
+
+	if err := uc.movementRepo.Post(movement); err != nil {
+		return err
+	}
+
+	if movement.CostChanged {
+		return recalculateCostsForMaterials(uc.costRecalculator, "движение проведено", []int{movement.MaterialID})
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/mocks"
+	usecasemocks "wallpaper-system/internal/usecases/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type MaterialMovementUseCaseTestSuite struct {
+	suite.Suite
+	movementRepo     *mocks.MockMaterialMovementRepository
+	materialRepo     *mocks.MockMaterialRepository
+	costRecalculator *usecasemocks.MockProductUseCase
+	useCase          *MaterialMovementUseCase
+}
+
+func (suite *MaterialMovementUseCaseTestSuite) SetupTest() {
+	suite.movementRepo = new(mocks.MockMaterialMovementRepository)
+	suite.materialRepo = new(mocks.MockMaterialRepository)
+	suite.costRecalculator = new(usecasemocks.MockProductUseCase)
+	suite.useCase = NewMaterialMovementUseCase(suite.movementRepo, suite.materialRepo, suite.costRecalculator)
+}
+
+func (suite *MaterialMovementUseCaseTestSuite) TestGetMovements_DefaultPage() {
+	// Подготовка данных
+	movements := []entities.MaterialMovement{{ID: 2, MaterialID: 3, Type: entities.MovementWriteOff, Quantity: 1}}
+	expectedCriteria := entities.MovementCriteria{
+		MaterialID: 3,
+		Type:       entities.MovementWriteOff,
+		Pagination: entities.Pagination{Page: 1, PageSize: entities.DefaultPageSize},
+	}
+
+	// Настройка моков
+	suite.materialRepo.On("GetByID", 3).Return(&entities.Material{ID: 3}, nil)
+	suite.movementRepo.On("FindByCriteria", expectedCriteria).Return(movements, 41, nil)
+
+	// Выполнение
+	list, err := suite.useCase.GetMovements(entities.MovementCriteria{MaterialID: 3, Type: entities.MovementWriteOff})
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), movements, list.Items)
+	assert.Equal(suite.T(), 41, list.Total)
+	assert.Equal(suite.T(), 3, list.TotalPages())
+}
+
+func (suite *MaterialMovementUseCaseTestSuite) TestGetMovements_MaterialNotFound() {
+	// Настройка моков
+	suite.materialRepo.On("GetByID", 99).Return(nil, entities.NewNotFoundError("материал", "99"))
+
+	// Выполнение
+	_, err := suite.useCase.GetMovements(entities.MovementCriteria{MaterialID: 99})
+
+	// Проверки
+	var notFoundErr *entities.NotFoundError
+	require.ErrorAs(suite.T(), err, &notFoundErr)
+	suite.movementRepo.AssertNotCalled(suite.T(), "FindByCriteria", mock.Anything)
+}
+
+func (suite *MaterialMovementUseCaseTestSuite) TestGetMovements_UnknownType() {
+	// Выполнение
+	_, err := suite.useCase.GetMovements(entities.MovementCriteria{MaterialID: 3, Type: "transfer"})
+
+	// Проверки
+	var validationErr *entities.ValidationError
+	require.ErrorAs(suite.T(), err, &validationErr)
+	assert.Equal(suite.T(), "movement_type", validationErr.Field)
+}
+
+func (suite *MaterialMovementUseCaseTestSuite) TestPostMovement_Success() {
+	// Подготовка данных
+	movement := &entities.MaterialMovement{MaterialID: 3, Type: entities.MovementIncome, Quantity: 12.3456,
+		ReferenceType: entities.ReferenceSupply}
+
+	// Настройка моков: репозиторий рассчитывает остаток после движения
+	suite.movementRepo.On("Post", movement).Run(func(args mock.Arguments) {
+		args.Get(0).(*entities.MaterialMovement).RemainingQuantity = 20.346
+	}).Return(nil)
+
+	// Выполнение
+	err := suite.useCase.PostMovement(movement)
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 12.346, movement.Quantity)
+	assert.Equal(suite.T(), 20.346, movement.RemainingQuantity)
+	suite.movementRepo.AssertExpectations(suite.T())
+	suite.costRecalculator.AssertNotCalled(suite.T(), "RecalculateCostsForMaterial", mock.Anything)
+}
+
+func (suite *MaterialMovementUseCaseTestSuite) TestPostMovement_CostChangedRecalculatesProducts() {
+	// Подготовка данных
+	movement := &entities.MaterialMovement{MaterialID: 3, Type: entities.MovementWriteOff, Quantity: 2}
+
+	// Настройка моков: списание по FIFO изменило себестоимость материала
+	suite.movementRepo.On("Post", movement).Run(func(args mock.Arguments) {
+		args.Get(0).(*entities.MaterialMovement).CostChanged = true
+	}).Return(nil)
+	suite.costRecalculator.On("RecalculateCostsForMaterial", 3).Return(0, errors.New("database error"))
+
+	// Выполнение
+	err := suite.useCase.PostMovement(movement)
+
+	// Проверки: движение проведено, ошибка пересчета продукции возвращается отдельно
+	var recalcErr *entities.CostRecalculationError
+	require.ErrorAs(suite.T(), err, &recalcErr)
+	assert.Contains(suite.T(), err.Error(), "движение проведено")
+	suite.costRecalculator.AssertExpectations(suite.T())
+}
+
+func (suite *MaterialMovementUseCaseTestSuite) TestPostMovement_ReserveRejected() {
+	// Резерв и снятие резерва проводятся только сменой статуса заказа
+	for _, movementType := range []entities.MovementType{entities.MovementReserve, entities.MovementRelease} {
+		// Выполнение
+		err := suite.useCase.PostMovement(&entities.MaterialMovement{MaterialID: 3, Type: movementType, Quantity: 1})
+
+		// Проверки
+		var validationErr *entities.ValidationError
+		require.ErrorAs(suite.T(), err, &validationErr)
+		assert.Equal(suite.T(), "movement_type", validationErr.Field)
+	}
+	suite.movementRepo.AssertNotCalled(suite.T(), "Post", mock.Anything)
+}
+
+func (suite *MaterialMovementUseCaseTestSuite) TestPostMovement_InsufficientStock() {
+	// Подготовка данных
+	movement := &entities.MaterialMovement{MaterialID: 3, Type: entities.MovementConsumption, Quantity: 100}
+
+	// Настройка моков
+	suite.movementRepo.On("Post", movement).Return(entities.NewBusinessError("INSUFFICIENT_STOCK", "недостаточно материала на складе"))
+
+	// Выполнение
+	err := suite.useCase.PostMovement(movement)
+
+	// Проверки
+	var businessErr *entities.BusinessError
+	require.ErrorAs(suite.T(), err, &businessErr)
+	assert.Equal(suite.T(), "INSUFFICIENT_STOCK", businessErr.Code)
+}
+
+func TestMaterialMovementUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(MaterialMovementUseCaseTestSuite))
+}
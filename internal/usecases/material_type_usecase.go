@@ -0,0 +1,137 @@
+package usecases
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// wastePercentageScale соответствует точности хранения процента брака: доля DECIMAL(5,4) - это сотые доли процента
+const wastePercentageScale = 100
+
+// MaterialTypeUseCase содержит бизнес-логику управления типами материалов
+type MaterialTypeUseCase struct {
+	materialTypeRepo repositories.MaterialTypeRepository
+}
+
+// NewMaterialTypeUseCase создает новый use case типов материалов
+func NewMaterialTypeUseCase(materialTypeRepo repositories.MaterialTypeRepository) *MaterialTypeUseCase {
+	return &MaterialTypeUseCase{materialTypeRepo: materialTypeRepo}
+}
+
+// GetMaterialTypes возвращает все типы материалов
+func (uc *MaterialTypeUseCase) GetMaterialTypes() ([]entities.MaterialType, error) {
+	return uc.materialTypeRepo.GetAll()
+}
+
+// GetMaterialTypeByID возвращает тип материала по ID
+func (uc *MaterialTypeUseCase) GetMaterialTypeByID(id int) (*entities.MaterialType, error) {
+	return uc.materialTypeRepo.GetByID(id)
+}
+
+// CreateMaterialType создает тип материала и записывает начальный процент брака в историю
+// от имени автора change
+func (uc *MaterialTypeUseCase) CreateMaterialType(materialType *entities.MaterialType, change *entities.DefectRateChange) error {
+	materialType.WastePercentage = roundWastePercentage(materialType.WastePercentage)
+	if err := uc.validateMaterialType(materialType); err != nil {
+		return err
+	}
+	if err := prepareDefectRateChange(change, nil, materialType.WastePercentage); err != nil {
+		return err
+	}
+
+	return uc.materialTypeRepo.Create(materialType, change)
+}
+
+// UpdateMaterialType обновляет тип материала. Если процент брака изменился, изменение
+// записывается в историю от имени автора change
+func (uc *MaterialTypeUseCase) UpdateMaterialType(materialType *entities.MaterialType, change *entities.DefectRateChange) error {
+	existing, err := uc.materialTypeRepo.GetByID(materialType.ID)
+	if err != nil {
+		return fmt.Errorf("тип материала не найден: %w", err)
+	}
+
+	materialType.WastePercentage = roundWastePercentage(materialType.WastePercentage)
+	if err := uc.validateMaterialType(materialType); err != nil {
+		return err
+	}
+	materialType.CreatedAt = existing.CreatedAt
+
+	if materialType.WastePercentage == existing.WastePercentage {
+		return uc.materialTypeRepo.Update(materialType, nil)
+	}
+
+	oldPercentage := existing.WastePercentage
+	if err := prepareDefectRateChange(change, &oldPercentage, materialType.WastePercentage); err != nil {
+		return err
+	}
+	return uc.materialTypeRepo.Update(materialType, change)
+}
+
+// DeleteMaterialType удаляет тип материала, если к нему не относится ни один материал
+func (uc *MaterialTypeUseCase) DeleteMaterialType(id int) error {
+	if _, err := uc.materialTypeRepo.GetByID(id); err != nil {
+		return fmt.Errorf("тип материала не найден: %w", err)
+	}
+
+	count, err := uc.materialTypeRepo.CountMaterials(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return entities.NewBusinessError("MATERIAL_TYPE_IN_USE",
+			fmt.Sprintf("тип материала используется в %d материалах, в том числе архивных", count))
+	}
+
+	return uc.materialTypeRepo.Delete(id)
+}
+
+// GetDefectRateHistory возвращает историю процента брака типа материала, начиная с последнего изменения
+func (uc *MaterialTypeUseCase) GetDefectRateHistory(id int) ([]entities.DefectRateChange, error) {
+	if _, err := uc.materialTypeRepo.GetByID(id); err != nil {
+		return nil, fmt.Errorf("тип материала не найден: %w", err)
+	}
+
+	return uc.materialTypeRepo.GetDefectRateHistory(id)
+}
+
+// validateMaterialType проверяет тип материала и уникальность его названия без учета регистра
+func (uc *MaterialTypeUseCase) validateMaterialType(materialType *entities.MaterialType) error {
+	if err := materialType.Validate(); err != nil {
+		return err
+	}
+
+	types, err := uc.materialTypeRepo.GetAll()
+	if err != nil {
+		return fmt.Errorf("ошибка получения типов материалов: %w", err)
+	}
+	for _, other := range types {
+		if other.ID != materialType.ID && strings.EqualFold(other.Name, materialType.Name) {
+			return entities.NewBusinessError("DUPLICATE_MATERIAL_TYPE",
+				fmt.Sprintf("тип материала %s уже существует", other.Name))
+		}
+	}
+	return nil
+}
+
+// prepareDefectRateChange проверяет автора изменения и заполняет прежний и новый процент брака
+func prepareDefectRateChange(change *entities.DefectRateChange, oldPercentage *float64, newPercentage float64) error {
+	if change == nil {
+		return entities.NewValidationError("changed_by", "укажите, кто изменяет процент брака")
+	}
+	if err := change.Validate(); err != nil {
+		return err
+	}
+
+	change.OldWastePercentage = oldPercentage
+	change.NewWastePercentage = newPercentage
+	return nil
+}
+
+// roundWastePercentage округляет процент брака до точности хранения, чтобы история совпадала с базой
+func roundWastePercentage(percentage float64) float64 {
+	return math.Round(percentage*wastePercentageScale) / wastePercentageScale
+}
@@ -0,0 +1,140 @@
+package usecases
+
+import (
+	"testing"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type MaterialTypeUseCaseTestSuite struct {
+	suite.Suite
+	materialTypeRepo *mocks.MockMaterialTypeRepository
+	useCase          *MaterialTypeUseCase
+}
+
+func (suite *MaterialTypeUseCaseTestSuite) SetupTest() {
+	suite.materialTypeRepo = new(mocks.MockMaterialTypeRepository)
+	suite.useCase = NewMaterialTypeUseCase(suite.materialTypeRepo)
+
+	// По умолчанию в справочнике один тип - Основа
+	suite.materialTypeRepo.On("GetAll").Return([]entities.MaterialType{*newTestMaterialType()}, nil).Maybe()
+}
+
+func newTestMaterialType() *entities.MaterialType {
+	return &entities.MaterialType{ID: 1, Name: "Основа", WastePercentage: 3}
+}
+
+func (suite *MaterialTypeUseCaseTestSuite) TestCreateMaterialType_RecordsInitialPercentage() {
+	// Подготовка данных
+	materialType := &entities.MaterialType{Name: "Покрытие", WastePercentage: 5.004}
+	change := &entities.DefectRateChange{ChangedBy: "Иванов"}
+
+	// Настройка моков
+	suite.materialTypeRepo.On("Create", materialType, change).Return(nil)
+
+	// Выполнение
+	err := suite.useCase.CreateMaterialType(materialType, change)
+
+	// Проверки: процент округлен до точности хранения
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 5.0, materialType.WastePercentage)
+	assert.Nil(suite.T(), change.OldWastePercentage)
+	assert.Equal(suite.T(), 5.0, change.NewWastePercentage)
+}
+
+func (suite *MaterialTypeUseCaseTestSuite) TestCreateMaterialType_PercentageOutOfRange() {
+	// Выполнение
+	err := suite.useCase.CreateMaterialType(&entities.MaterialType{Name: "Покрытие", WastePercentage: 120},
+		&entities.DefectRateChange{ChangedBy: "Иванов"})
+
+	// Проверки
+	var validationErr *entities.ValidationError
+	require.ErrorAs(suite.T(), err, &validationErr)
+	assert.Equal(suite.T(), "waste_percentage", validationErr.Field)
+	suite.materialTypeRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *MaterialTypeUseCaseTestSuite) TestCreateMaterialType_DuplicateName() {
+	// Выполнение
+	err := suite.useCase.CreateMaterialType(&entities.MaterialType{Name: "ОСНОВА", WastePercentage: 2},
+		&entities.DefectRateChange{ChangedBy: "Иванов"})
+
+	// Проверки
+	var businessErr *entities.BusinessError
+	require.ErrorAs(suite.T(), err, &businessErr)
+	assert.Equal(suite.T(), "DUPLICATE_MATERIAL_TYPE", businessErr.Code)
+}
+
+func (suite *MaterialTypeUseCaseTestSuite) TestUpdateMaterialType_PercentageChanged_RecordsHistory() {
+	// Подготовка данных
+	materialType := &entities.MaterialType{ID: 1, Name: "Основа", WastePercentage: 4.5}
+	change := &entities.DefectRateChange{ChangedBy: "Петрова"}
+
+	// Настройка моков
+	suite.materialTypeRepo.On("GetByID", 1).Return(newTestMaterialType(), nil)
+	suite.materialTypeRepo.On("Update", materialType, change).Return(nil)
+
+	// Выполнение
+	err := suite.useCase.UpdateMaterialType(materialType, change)
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	require.NotNil(suite.T(), change.OldWastePercentage)
+	assert.Equal(suite.T(), 3.0, *change.OldWastePercentage)
+	assert.Equal(suite.T(), 4.5, change.NewWastePercentage)
+}
+
+func (suite *MaterialTypeUseCaseTestSuite) TestUpdateMaterialType_PercentageUnchanged_NoHistory() {
+	// Подготовка данных: меняется только название, автор изменения не указан
+	materialType := &entities.MaterialType{ID: 1, Name: "Бумага-основа", WastePercentage: 3}
+
+	// Настройка моков
+	suite.materialTypeRepo.On("GetByID", 1).Return(newTestMaterialType(), nil)
+	suite.materialTypeRepo.On("Update", materialType, (*entities.DefectRateChange)(nil)).Return(nil)
+
+	// Выполнение
+	err := suite.useCase.UpdateMaterialType(materialType, &entities.DefectRateChange{})
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	suite.materialTypeRepo.AssertExpectations(suite.T())
+}
+
+func (suite *MaterialTypeUseCaseTestSuite) TestDeleteMaterialType_InUse() {
+	// Настройка моков
+	suite.materialTypeRepo.On("GetByID", 1).Return(newTestMaterialType(), nil)
+	suite.materialTypeRepo.On("CountMaterials", 1).Return(4, nil)
+
+	// Выполнение
+	err := suite.useCase.DeleteMaterialType(1)
+
+	// Проверки
+	var businessErr *entities.BusinessError
+	require.ErrorAs(suite.T(), err, &businessErr)
+	assert.Equal(suite.T(), "MATERIAL_TYPE_IN_USE", businessErr.Code)
+	suite.materialTypeRepo.AssertNotCalled(suite.T(), "Delete", mock.Anything)
+}
+
+func (suite *MaterialTypeUseCaseTestSuite) TestDeleteMaterialType_Success() {
+	// Настройка моков
+	suite.materialTypeRepo.On("GetByID", 1).Return(newTestMaterialType(), nil)
+	suite.materialTypeRepo.On("CountMaterials", 1).Return(0, nil)
+	suite.materialTypeRepo.On("Delete", 1).Return(nil)
+
+	// Выполнение
+	err := suite.useCase.DeleteMaterialType(1)
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	suite.materialTypeRepo.AssertExpectations(suite.T())
+}
+
+func TestMaterialTypeUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(MaterialTypeUseCaseTestSuite))
+}
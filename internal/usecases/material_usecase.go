@@ -3,6 +3,7 @@ package usecases
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"wallpaper-system/internal/domain/entities"
 	"wallpaper-system/internal/domain/repositories"
@@ -10,13 +11,18 @@ import (
 
 // MaterialUseCase содержит бизнес-логику для работы с материалами
 type MaterialUseCase struct {
-	materialRepo repositories.MaterialRepository
+	materialRepo     repositories.MaterialRepository
+	costRecalculator ProductCostRecalculator
 }
 
 // NewMaterialUseCase создает новый use case материалов
-func NewMaterialUseCase(materialRepo repositories.MaterialRepository) *MaterialUseCase {
+func NewMaterialUseCase(
+	materialRepo repositories.MaterialRepository,
+	costRecalculator ProductCostRecalculator,
+) *MaterialUseCase {
 	return &MaterialUseCase{
-		materialRepo: materialRepo,
+		materialRepo:     materialRepo,
+		costRecalculator: costRecalculator,
 	}
 }
 
@@ -25,6 +31,65 @@ func (uc *MaterialUseCase) GetAllMaterials() ([]entities.Material, error) {
 	return uc.materialRepo.GetAll()
 }
 
+// FindMaterials возвращает страницу материалов по фильтрам
+func (uc *MaterialUseCase) FindMaterials(criteria entities.MaterialCriteria) (*entities.MaterialList, error) {
+	if err := criteria.Validate(); err != nil {
+		return nil, err
+	}
+	criteria.Normalize()
+
+	materials, total, err := uc.materialRepo.FindByCriteria(criteria)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения материалов: %w", err)
+	}
+
+	return &entities.MaterialList{
+		Items:    materials,
+		PageInfo: entities.PageInfo{Page: criteria.Page, PageSize: criteria.PageSize, Total: total},
+	}, nil
+}
+
+// ExportMaterials готовит выгрузку каталога материалов по фильтрам со всеми найденными позициями,
+// сгруппированную по типам материалов
+func (uc *MaterialUseCase) ExportMaterials(request entities.MaterialExportRequest) (*entities.ExportTable, error) {
+	columns, err := selectMaterialExportColumns(request.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	criteria := request.Criteria
+	if err := criteria.Validate(); err != nil {
+		return nil, err
+	}
+	criteria.Normalize()
+	criteria.Pagination = entities.Pagination{Page: 1, PageSize: entities.MaxExportRows}
+
+	materials, total, err := uc.materialRepo.FindByCriteria(criteria)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения материалов: %w", err)
+	}
+	if err := checkExportTotal(total); err != nil {
+		return nil, err
+	}
+
+	table := &entities.ExportTable{Title: "Каталог материалов", GeneratedAt: time.Now()}
+	for _, column := range columns {
+		table.Columns = append(table.Columns, column.ExportColumn)
+	}
+
+	groups := newExportGrouper()
+	for i := range materials {
+		row := make([]entities.ExportCell, len(columns))
+		for j, column := range columns {
+			row[j] = column.value(&materials[i])
+		}
+		groups.add(materialTypeName(&materials[i]), row)
+	}
+	table.Groups = groups.result()
+
+	return table, nil
+}
+
 // GetMaterialByID возвращает материал по ID
 func (uc *MaterialUseCase) GetMaterialByID(id int) (*entities.Material, error) {
 	return uc.materialRepo.GetByID(id)
@@ -50,7 +115,9 @@ func (uc *MaterialUseCase) CreateMaterial(material *entities.Material) error {
 	return uc.materialRepo.Create(material)
 }
 
-// UpdateMaterial обновляет существующий материал
+// UpdateMaterial обновляет существующий материал. Себестоимость вручную меняется только
+// зафиксированная. Если материал сохранен, а себестоимость продукции пересчитать не удалось,
+// возвращается CostRecalculationError: изменение материала при этом остается в силе
 func (uc *MaterialUseCase) UpdateMaterial(material *entities.Material) error {
 	// Проверяем, что материал существует
 	existing, err := uc.materialRepo.GetByID(material.ID)
@@ -61,17 +128,52 @@ func (uc *MaterialUseCase) UpdateMaterial(material *entities.Material) error {
 		return entities.NewNotFoundError("материал", strconv.Itoa(material.ID))
 	}
 
+	// Остаток меняется только движениями складского журнала
+	material.StockQuantity = existing.StockQuantity
+	material.CostLocked = existing.CostLocked
+
 	// Валидация
 	if err := uc.validateMaterial(material); err != nil {
 		return fmt.Errorf("ошибка валидации материала: %w", err)
 	}
+	if err := existing.ValidateCostChange(material.CostPerUnit); err != nil {
+		return fmt.Errorf("ошибка валидации материала: %w", err)
+	}
+
+	if err := uc.materialRepo.Update(material); err != nil {
+		return err
+	}
 
-	return uc.materialRepo.Update(material)
+	// Цена сырья изменилась - пересчитываем себестоимость продукции, в которую оно входит
+	if existing.CostPerUnit != material.CostPerUnit {
+		if _, err := uc.costRecalculator.RecalculateCostsForMaterial(material.ID); err != nil {
+			return entities.NewCostRecalculationError("материал обновлен", err)
+		}
+	}
+
+	return nil
 }
 
-// DeleteMaterial удаляет материал
-func (uc *MaterialUseCase) DeleteMaterial(id int) error {
-	// Проверяем, что материал существует
+// recalculateCostsForMaterials пересчитывает себестоимость продукции с каждым из материалов, не
+// останавливаясь на первой ошибке. Изменение материалов уже сохранено, поэтому ошибки пересчета
+// возвращаются одной CostRecalculationError с описанием действия action
+func recalculateCostsForMaterials(recalculator ProductCostRecalculator, action string, materialIDs []int) error {
+	var failures []error
+	for _, materialID := range materialIDs {
+		if _, err := recalculator.RecalculateCostsForMaterial(materialID); err != nil {
+			failures = append(failures, fmt.Errorf("материал с ID %d: %w", materialID, err))
+		}
+	}
+
+	if err := joinErrors(failures); err != nil {
+		return entities.NewCostRecalculationError(action, err)
+	}
+	return nil
+}
+
+// ArchiveMaterial переносит материал в архив вместо удаления:
+// рецептуры, в которых он используется, сохраняются
+func (uc *MaterialUseCase) ArchiveMaterial(id int) error {
 	existing, err := uc.materialRepo.GetByID(id)
 	if err != nil {
 		return fmt.Errorf("материал не найден: %w", err)
@@ -79,8 +181,27 @@ func (uc *MaterialUseCase) DeleteMaterial(id int) error {
 	if existing == nil {
 		return entities.NewNotFoundError("материал", strconv.Itoa(id))
 	}
+	if existing.IsArchived() {
+		return entities.NewBusinessError("ALREADY_ARCHIVED", "материал уже находится в архиве")
+	}
+
+	return uc.materialRepo.Archive(id, time.Now())
+}
+
+// RestoreMaterial возвращает материал из архива
+func (uc *MaterialUseCase) RestoreMaterial(id int) error {
+	existing, err := uc.materialRepo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("материал не найден: %w", err)
+	}
+	if existing == nil {
+		return entities.NewNotFoundError("материал", strconv.Itoa(id))
+	}
+	if !existing.IsArchived() {
+		return entities.NewBusinessError("NOT_ARCHIVED", "материал не находится в архиве")
+	}
 
-	return uc.materialRepo.Delete(id)
+	return uc.materialRepo.Restore(id)
 }
 
 // GetMeasurementUnits возвращает все единицы измерения
@@ -0,0 +1,203 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/mocks"
+	usecasemocks "wallpaper-system/internal/usecases/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type MaterialUseCaseTestSuite struct {
+	suite.Suite
+	materialRepo     *mocks.MockMaterialRepository
+	costRecalculator *usecasemocks.MockProductUseCase
+	useCase          *MaterialUseCase
+}
+
+func (suite *MaterialUseCaseTestSuite) SetupTest() {
+	suite.materialRepo = new(mocks.MockMaterialRepository)
+	suite.costRecalculator = new(usecasemocks.MockProductUseCase)
+	suite.useCase = NewMaterialUseCase(suite.materialRepo, suite.costRecalculator)
+}
+
+func newTestMaterial(costPerUnit float64) *entities.Material {
+	return &entities.Material{
+		ID:                1,
+		Article:           "MAT001",
+		Name:              "Бумага-основа",
+		MaterialTypeID:    1,
+		MeasurementUnitID: 1,
+		PackageQuantity:   100,
+		CostPerUnit:       costPerUnit,
+	}
+}
+
+func (suite *MaterialUseCaseTestSuite) TestUpdateMaterial_CostChanged_RecalculatesProducts() {
+	// Подготовка данных: себестоимость зафиксирована и задается вручную
+	existing := newTestMaterial(100.0)
+	existing.CostLocked = true
+	material := newTestMaterial(120.0)
+
+	// Настройка моков
+	suite.materialRepo.On("GetByID", 1).Return(existing, nil)
+	suite.materialRepo.On("Update", material).Return(nil)
+	suite.costRecalculator.On("RecalculateCostsForMaterial", 1).Return(3, nil)
+
+	// Выполнение
+	err := suite.useCase.UpdateMaterial(material)
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	suite.materialRepo.AssertExpectations(suite.T())
+	suite.costRecalculator.AssertExpectations(suite.T())
+}
+
+func (suite *MaterialUseCaseTestSuite) TestUpdateMaterial_UnlockedCostRejected() {
+	// Подготовка данных: незафиксированная себестоимость рассчитывается по журналу
+	material := newTestMaterial(120.0)
+
+	// Настройка моков
+	suite.materialRepo.On("GetByID", 1).Return(newTestMaterial(100.0), nil)
+
+	// Выполнение
+	err := suite.useCase.UpdateMaterial(material)
+
+	// Проверки
+	var validationErr *entities.ValidationError
+	require.ErrorAs(suite.T(), err, &validationErr)
+	assert.Equal(suite.T(), "cost_per_unit", validationErr.Field)
+	suite.materialRepo.AssertNotCalled(suite.T(), "Update", mock.Anything)
+	suite.costRecalculator.AssertNotCalled(suite.T(), "RecalculateCostsForMaterial", mock.Anything)
+}
+
+func (suite *MaterialUseCaseTestSuite) TestUpdateMaterial_CostUnchanged_SkipsRecalculation() {
+	// Подготовка данных
+	material := newTestMaterial(100.0)
+	material.Name = "Бумага-основа плотная"
+
+	// Настройка моков
+	suite.materialRepo.On("GetByID", 1).Return(newTestMaterial(100.0), nil)
+	suite.materialRepo.On("Update", material).Return(nil)
+
+	// Выполнение
+	err := suite.useCase.UpdateMaterial(material)
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	suite.costRecalculator.AssertNotCalled(suite.T(), "RecalculateCostsForMaterial", 1)
+}
+
+func (suite *MaterialUseCaseTestSuite) TestUpdateMaterial_KeepsStockQuantity() {
+	// Подготовка данных: остаток из формы игнорируется, он меняется только движениями
+	existing := newTestMaterial(100.0)
+	existing.StockQuantity = 42.5
+	material := newTestMaterial(100.0)
+	material.StockQuantity = 1000
+
+	// Настройка моков
+	suite.materialRepo.On("GetByID", 1).Return(existing, nil)
+	suite.materialRepo.On("Update", mock.MatchedBy(func(m *entities.Material) bool {
+		return m.StockQuantity == 42.5
+	})).Return(nil)
+
+	// Выполнение
+	err := suite.useCase.UpdateMaterial(material)
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	suite.materialRepo.AssertExpectations(suite.T())
+}
+
+func (suite *MaterialUseCaseTestSuite) TestUpdateMaterial_RecalculationError() {
+	// Подготовка данных
+	existing := newTestMaterial(100.0)
+	existing.CostLocked = true
+	material := newTestMaterial(120.0)
+
+	// Настройка моков
+	suite.materialRepo.On("GetByID", 1).Return(existing, nil)
+	suite.materialRepo.On("Update", material).Return(nil)
+	suite.costRecalculator.On("RecalculateCostsForMaterial", 1).Return(0, errors.New("цикл в рецептуре"))
+
+	// Выполнение
+	err := suite.useCase.UpdateMaterial(material)
+
+	// Проверки: материал сохранен, ошибка пересчета отделена от ошибок изменения
+	var recalcErr *entities.CostRecalculationError
+	require.ErrorAs(suite.T(), err, &recalcErr)
+	assert.Contains(suite.T(), err.Error(), "материал обновлен, но себестоимость продукции не пересчитана")
+	suite.materialRepo.AssertCalled(suite.T(), "Update", material)
+}
+
+func (suite *MaterialUseCaseTestSuite) TestArchiveMaterial_KeepsRecipes() {
+	// Настройка моков: архивирование не удаляет строки рецептур, только помечает материал
+	suite.materialRepo.On("GetByID", 1).Return(newTestMaterial(100.0), nil)
+	suite.materialRepo.On("Archive", 1, mock.AnythingOfType("time.Time")).Return(nil)
+
+	// Выполнение
+	err := suite.useCase.ArchiveMaterial(1)
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	suite.materialRepo.AssertExpectations(suite.T())
+}
+
+func (suite *MaterialUseCaseTestSuite) TestRestoreMaterial() {
+	// Подготовка данных
+	archivedAt := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	archived := newTestMaterial(100.0)
+	archived.ArchivedAt = &archivedAt
+
+	// Настройка моков
+	suite.materialRepo.On("GetByID", 1).Return(archived, nil)
+	suite.materialRepo.On("Restore", 1).Return(nil)
+
+	// Выполнение
+	err := suite.useCase.RestoreMaterial(1)
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	suite.materialRepo.AssertExpectations(suite.T())
+}
+
+func (suite *MaterialUseCaseTestSuite) TestExportMaterials_GroupsByType() {
+	// Подготовка данных: материал без типа попадает в группу "Без типа"
+	materials := []entities.Material{
+		{ID: 1, Article: "MAT-001", Name: "Бумага-основа", CostPerUnit: 100.0,
+			MaterialType:    &entities.MaterialType{ID: 1, Name: "Бумага"},
+			MeasurementUnit: &entities.MeasurementUnit{ID: 1, Abbreviation: "м"}},
+		{ID: 2, Article: "MAT-002", Name: "Клей", CostPerUnit: 50.0},
+	}
+
+	// Настройка моков
+	suite.materialRepo.On("FindByCriteria", mock.MatchedBy(func(criteria entities.MaterialCriteria) bool {
+		return criteria.Pagination.PageSize == entities.MaxExportRows
+	})).Return(materials, 2, nil)
+
+	// Выполнение
+	table, err := suite.useCase.ExportMaterials(entities.MaterialExportRequest{
+		Columns: []string{"article", "measurement_unit", "cost_per_unit"},
+	})
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Каталог материалов", table.Title)
+	assert.Len(suite.T(), table.Groups, 2)
+	assert.Equal(suite.T(), "Без типа", table.Groups[0].Name)
+	assert.Equal(suite.T(), "", table.Groups[0].Rows[0][1].Text)
+	assert.Equal(suite.T(), "Бумага", table.Groups[1].Name)
+	assert.Equal(suite.T(), "м", table.Groups[1].Rows[0][1].Text)
+	assert.Equal(suite.T(), 100.0, *table.Groups[1].Rows[0][2].Number)
+}
+
+func TestMaterialUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(MaterialUseCaseTestSuite))
+}
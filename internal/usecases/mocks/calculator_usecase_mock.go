@@ -17,6 +17,32 @@ func (m *MockCalculatorUseCase) CalculateRequiredMaterial(request *entities.Mate
 	return args.Int(0), args.Error(1)
 }
 
+// MockRoomCalculatorUseCase - мок для RoomCalculatorUseCase
+type MockRoomCalculatorUseCase struct {
+	mock.Mock
+}
+
+// GetRollProducts возвращает продукцию с заданными размерами рулона
+func (m *MockRoomCalculatorUseCase) GetRollProducts() ([]entities.Product, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.Product), args.Error(1)
+}
+
+// GetPartnerTypes возвращает типы партнеров
+func (m *MockRoomCalculatorUseCase) GetPartnerTypes() ([]entities.PartnerType, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.PartnerType), args.Error(1)
+}
+
+// CalculateRoomRolls рассчитывает количество рулонов для комнаты
+func (m *MockRoomCalculatorUseCase) CalculateRoomRolls(request *entities.RoomRollRequest) (*entities.RoomRollCalculation, error) {
+	args := m.Called(request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.RoomRollCalculation), args.Error(1)
+}
+
 // MockMaterialUseCase - мок для MaterialUseCase
 type MockMaterialUseCase struct {
 	mock.Mock
@@ -28,6 +54,15 @@ func (m *MockMaterialUseCase) GetAllMaterials() ([]entities.Material, error) {
 	return args.Get(0).([]entities.Material), args.Error(1)
 }
 
+// FindMaterials возвращает страницу материалов по фильтрам
+func (m *MockMaterialUseCase) FindMaterials(criteria entities.MaterialCriteria) (*entities.MaterialList, error) {
+	args := m.Called(criteria)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.MaterialList), args.Error(1)
+}
+
 // GetMaterialByID возвращает материал по ID
 func (m *MockMaterialUseCase) GetMaterialByID(id int) (*entities.Material, error) {
 	args := m.Called(id)
@@ -49,8 +84,14 @@ func (m *MockMaterialUseCase) UpdateMaterial(material *entities.Material) error
 	return args.Error(0)
 }
 
-// DeleteMaterial удаляет материал
-func (m *MockMaterialUseCase) DeleteMaterial(id int) error {
+// ArchiveMaterial переносит материал в архив
+func (m *MockMaterialUseCase) ArchiveMaterial(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// RestoreMaterial возвращает материал из архива
+func (m *MockMaterialUseCase) RestoreMaterial(id int) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
@@ -72,3 +113,12 @@ func (m *MockMaterialUseCase) GetMaterialsForProduct(productID int) ([]entities.
 	args := m.Called(productID)
 	return args.Get(0).([]entities.Material), args.Error(1)
 }
+
+// ExportMaterials готовит выгрузку каталога материалов
+func (m *MockMaterialUseCase) ExportMaterials(request entities.MaterialExportRequest) (*entities.ExportTable, error) {
+	args := m.Called(request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ExportTable), args.Error(1)
+}
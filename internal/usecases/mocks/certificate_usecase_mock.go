@@ -0,0 +1,95 @@
+package mocks
+
+import (
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCertificateUseCase - мок для CertificateUseCase
+type MockCertificateUseCase struct {
+	mock.Mock
+}
+
+// GetAllCertificates возвращает все сертификаты
+func (m *MockCertificateUseCase) GetAllCertificates() ([]entities.QualityCertificate, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.QualityCertificate), args.Error(1)
+}
+
+// GetCertificateByID возвращает сертификат по ID
+func (m *MockCertificateUseCase) GetCertificateByID(id int) (*entities.QualityCertificate, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.QualityCertificate), args.Error(1)
+}
+
+// GetProductCertificates возвращает сертификаты продукции
+func (m *MockCertificateUseCase) GetProductCertificates(productID int) ([]entities.QualityCertificate, error) {
+	args := m.Called(productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.QualityCertificate), args.Error(1)
+}
+
+// CreateCertificate создает сертификат
+func (m *MockCertificateUseCase) CreateCertificate(certificate *entities.QualityCertificate) error {
+	args := m.Called(certificate)
+	return args.Error(0)
+}
+
+// UpdateCertificate обновляет сертификат
+func (m *MockCertificateUseCase) UpdateCertificate(certificate *entities.QualityCertificate) error {
+	args := m.Called(certificate)
+	return args.Error(0)
+}
+
+// DeleteCertificate удаляет сертификат
+func (m *MockCertificateUseCase) DeleteCertificate(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// UploadCertificateFile сохраняет скан сертификата
+func (m *MockCertificateUseCase) UploadCertificateFile(id int, data []byte, contentType string) (*entities.QualityCertificate, error) {
+	args := m.Called(id, data, contentType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.QualityCertificate), args.Error(1)
+}
+
+// GetExpiringCertificates возвращает продукцию с истекающими сертификатами
+func (m *MockCertificateUseCase) GetExpiringCertificates(days int) ([]entities.ExpiringCertificate, error) {
+	args := m.Called(days)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.ExpiringCertificate), args.Error(1)
+}
+
+// CheckProducts возвращает предупреждения о продукции без действующего сертификата
+func (m *MockCertificateUseCase) CheckProducts(productIDs []int, date time.Time) ([]entities.CertificateWarning, error) {
+	args := m.Called(productIDs, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.CertificateWarning), args.Error(1)
+}
+
+// CheckOrder возвращает предупреждения по позициям заказа
+func (m *MockCertificateUseCase) CheckOrder(orderID int, date time.Time) ([]entities.CertificateWarning, error) {
+	args := m.Called(orderID, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.CertificateWarning), args.Error(1)
+}
@@ -0,0 +1,66 @@
+package mocks
+
+import (
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockImageUseCase - мок для ImageUseCase
+type MockImageUseCase struct {
+	mock.Mock
+}
+
+// UploadProductImage сохраняет изображение продукции
+func (m *MockImageUseCase) UploadProductImage(productID int, upload *entities.ImageUpload) (*entities.Product, error) {
+	args := m.Called(productID, upload)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Product), args.Error(1)
+}
+
+// DeleteProductImage удаляет изображение продукции
+func (m *MockImageUseCase) DeleteProductImage(productID int) (*entities.Product, error) {
+	args := m.Called(productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Product), args.Error(1)
+}
+
+// UploadMaterialImage сохраняет изображение материала
+func (m *MockImageUseCase) UploadMaterialImage(materialID int, upload *entities.ImageUpload) (*entities.Material, error) {
+	args := m.Called(materialID, upload)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Material), args.Error(1)
+}
+
+// DeleteMaterialImage удаляет изображение материала
+func (m *MockImageUseCase) DeleteMaterialImage(materialID int) (*entities.Material, error) {
+	args := m.Called(materialID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Material), args.Error(1)
+}
+
+// UploadVariantImage сохраняет изображение варианта продукции
+func (m *MockImageUseCase) UploadVariantImage(variantID int, upload *entities.ImageUpload) (*entities.ProductVariant, error) {
+	args := m.Called(variantID, upload)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ProductVariant), args.Error(1)
+}
+
+// DeleteVariantImage удаляет изображение варианта продукции
+func (m *MockImageUseCase) DeleteVariantImage(variantID int) (*entities.ProductVariant, error) {
+	args := m.Called(variantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ProductVariant), args.Error(1)
+}
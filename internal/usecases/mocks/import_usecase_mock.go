@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockImportUseCase - мок для ImportUseCase
+type MockImportUseCase struct {
+	mock.Mock
+}
+
+// ImportProducts импортирует продукцию из таблицы
+func (m *MockImportUseCase) ImportProducts(table *entities.ImportTable, dryRun bool) (*entities.ImportReport, error) {
+	args := m.Called(table, dryRun)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ImportReport), args.Error(1)
+}
+
+// ImportMaterials импортирует материалы из таблицы
+func (m *MockImportUseCase) ImportMaterials(table *entities.ImportTable, dryRun bool) (*entities.ImportReport, error) {
+	args := m.Called(table, dryRun)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ImportReport), args.Error(1)
+}
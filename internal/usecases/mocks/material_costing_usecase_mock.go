@@ -0,0 +1,39 @@
+package mocks
+
+import (
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMaterialCostingUseCase - мок для MaterialCostingUseCase
+type MockMaterialCostingUseCase struct {
+	mock.Mock
+}
+
+// GetCosting возвращает себестоимость материала
+func (m *MockMaterialCostingUseCase) GetCosting(materialID int) (*entities.MaterialCosting, error) {
+	args := m.Called(materialID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.MaterialCosting), args.Error(1)
+}
+
+// UpdateCosting меняет метод и фиксацию себестоимости
+func (m *MockMaterialCostingUseCase) UpdateCosting(materialID int, method entities.CostingMethod, locked bool, manualCost *float64) (*entities.MaterialCosting, error) {
+	args := m.Called(materialID, method, locked, manualCost)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.MaterialCosting), args.Error(1)
+}
+
+// RecalculateCost пересчитывает себестоимость материала
+func (m *MockMaterialCostingUseCase) RecalculateCost(materialID int) (*entities.MaterialCosting, error) {
+	args := m.Called(materialID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.MaterialCosting), args.Error(1)
+}
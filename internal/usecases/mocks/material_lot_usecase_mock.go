@@ -0,0 +1,57 @@
+package mocks
+
+import (
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMaterialLotUseCase - мок для MaterialLotUseCase
+type MockMaterialLotUseCase struct {
+	mock.Mock
+}
+
+// GetSuppliers возвращает поставщиков
+func (m *MockMaterialLotUseCase) GetSuppliers() ([]entities.Supplier, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.Supplier), args.Error(1)
+}
+
+// ReceiveSupply принимает поставку материала
+func (m *MockMaterialLotUseCase) ReceiveSupply(supply *entities.MaterialSupply, batchNumber *string) (*entities.MaterialLot, error) {
+	args := m.Called(supply, batchNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.MaterialLot), args.Error(1)
+}
+
+// GetMaterialLots возвращает партии материала
+func (m *MockMaterialLotUseCase) GetMaterialLots(materialID int) ([]entities.MaterialLot, error) {
+	args := m.Called(materialID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.MaterialLot), args.Error(1)
+}
+
+// GetLotTrace возвращает прослеживаемость партии
+func (m *MockMaterialLotUseCase) GetLotTrace(lotID int) (*entities.LotTrace, error) {
+	args := m.Called(lotID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.LotTrace), args.Error(1)
+}
+
+// GetOrderLots возвращает партии, списанные в расход по заказу
+func (m *MockMaterialLotUseCase) GetOrderLots(orderID int) ([]entities.LotConsumption, error) {
+	args := m.Called(orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.LotConsumption), args.Error(1)
+}
@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMaterialMovementUseCase - мок для MaterialMovementUseCase
+type MockMaterialMovementUseCase struct {
+	mock.Mock
+}
+
+// GetMovements возвращает страницу журнала движений материала
+func (m *MockMaterialMovementUseCase) GetMovements(criteria entities.MovementCriteria) (*entities.MovementList, error) {
+	args := m.Called(criteria)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.MovementList), args.Error(1)
+}
+
+// PostMovement проводит движение материала
+func (m *MockMaterialMovementUseCase) PostMovement(movement *entities.MaterialMovement) error {
+	args := m.Called(movement)
+	return args.Error(0)
+}
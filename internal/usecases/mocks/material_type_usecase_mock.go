@@ -0,0 +1,57 @@
+package mocks
+
+import (
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMaterialTypeUseCase - мок для MaterialTypeUseCase
+type MockMaterialTypeUseCase struct {
+	mock.Mock
+}
+
+// GetMaterialTypes возвращает все типы материалов
+func (m *MockMaterialTypeUseCase) GetMaterialTypes() ([]entities.MaterialType, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.MaterialType), args.Error(1)
+}
+
+// GetMaterialTypeByID возвращает тип материала по ID
+func (m *MockMaterialTypeUseCase) GetMaterialTypeByID(id int) (*entities.MaterialType, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.MaterialType), args.Error(1)
+}
+
+// CreateMaterialType создает тип материала
+func (m *MockMaterialTypeUseCase) CreateMaterialType(materialType *entities.MaterialType, change *entities.DefectRateChange) error {
+	args := m.Called(materialType, change)
+	return args.Error(0)
+}
+
+// UpdateMaterialType обновляет тип материала
+func (m *MockMaterialTypeUseCase) UpdateMaterialType(materialType *entities.MaterialType, change *entities.DefectRateChange) error {
+	args := m.Called(materialType, change)
+	return args.Error(0)
+}
+
+// DeleteMaterialType удаляет тип материала
+func (m *MockMaterialTypeUseCase) DeleteMaterialType(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// GetDefectRateHistory возвращает историю процента брака
+func (m *MockMaterialTypeUseCase) GetDefectRateHistory(id int) ([]entities.DefectRateChange, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.DefectRateChange), args.Error(1)
+}
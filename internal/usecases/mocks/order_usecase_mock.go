@@ -0,0 +1,39 @@
+package mocks
+
+import (
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockOrderUseCase - мок для OrderUseCase
+type MockOrderUseCase struct {
+	mock.Mock
+}
+
+// GetOrder возвращает заказ
+func (m *MockOrderUseCase) GetOrder(id int) (*entities.Order, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Order), args.Error(1)
+}
+
+// GetReservations возвращает резервы материалов заказа
+func (m *MockOrderUseCase) GetReservations(orderID int) ([]entities.MaterialReservation, error) {
+	args := m.Called(orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.MaterialReservation), args.Error(1)
+}
+
+// ChangeStatus переводит заказ в новый статус
+func (m *MockOrderUseCase) ChangeStatus(orderID int, status entities.OrderStatus) (*entities.Order, error) {
+	args := m.Called(orderID, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Order), args.Error(1)
+}
@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPricingRuleUseCase - мок для PricingRuleUseCase
+type MockPricingRuleUseCase struct {
+	mock.Mock
+}
+
+// GetAllRules возвращает все правила ценообразования
+func (m *MockPricingRuleUseCase) GetAllRules() ([]entities.PricingRule, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.PricingRule), args.Error(1)
+}
+
+// GetRuleByID возвращает правило ценообразования по ID
+func (m *MockPricingRuleUseCase) GetRuleByID(id int) (*entities.PricingRule, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PricingRule), args.Error(1)
+}
+
+// CreateRule создает новое правило ценообразования
+func (m *MockPricingRuleUseCase) CreateRule(rule *entities.PricingRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+// UpdateRule обновляет правило ценообразования
+func (m *MockPricingRuleUseCase) UpdateRule(rule *entities.PricingRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+// DeleteRule удаляет правило ценообразования
+func (m *MockPricingRuleUseCase) DeleteRule(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// GetPartnerTypes возвращает все типы партнеров
+func (m *MockPricingRuleUseCase) GetPartnerTypes() ([]entities.PartnerType, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.PartnerType), args.Error(1)
+}
@@ -0,0 +1,63 @@
+package mocks
+
+import (
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProductTypeUseCase - мок для ProductTypeUseCase
+type MockProductTypeUseCase struct {
+	mock.Mock
+}
+
+// GetProductTypes возвращает типы продукции
+func (m *MockProductTypeUseCase) GetProductTypes(includeArchived bool) ([]entities.ProductType, error) {
+	args := m.Called(includeArchived)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.ProductType), args.Error(1)
+}
+
+// GetProductTypeByID возвращает тип продукции по ID
+func (m *MockProductTypeUseCase) GetProductTypeByID(id int) (*entities.ProductType, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ProductType), args.Error(1)
+}
+
+// CreateProductType создает тип продукции
+func (m *MockProductTypeUseCase) CreateProductType(productType *entities.ProductType, change *entities.CoefficientChange) error {
+	args := m.Called(productType, change)
+	return args.Error(0)
+}
+
+// UpdateProductType обновляет тип продукции
+func (m *MockProductTypeUseCase) UpdateProductType(productType *entities.ProductType, change *entities.CoefficientChange) error {
+	args := m.Called(productType, change)
+	return args.Error(0)
+}
+
+// ArchiveProductType выводит тип продукции из обращения
+func (m *MockProductTypeUseCase) ArchiveProductType(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// RestoreProductType возвращает тип продукции в обращение
+func (m *MockProductTypeUseCase) RestoreProductType(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// GetCoefficientHistory возвращает историю коэффициента
+func (m *MockProductTypeUseCase) GetCoefficientHistory(id int) ([]entities.CoefficientChange, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.CoefficientChange), args.Error(1)
+}
@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"time"
+
 	"wallpaper-system/internal/domain/entities"
 
 	"github.com/stretchr/testify/mock"
@@ -17,6 +19,15 @@ func (m *MockProductUseCase) GetAllProducts() ([]entities.Product, error) {
 	return args.Get(0).([]entities.Product), args.Error(1)
 }
 
+// FindProducts возвращает страницу продукции по фильтрам
+func (m *MockProductUseCase) FindProducts(criteria entities.ProductCriteria) (*entities.ProductList, error) {
+	args := m.Called(criteria)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ProductList), args.Error(1)
+}
+
 // GetProductByID возвращает продукцию по ID с материалами и рассчитанной ценой
 func (m *MockProductUseCase) GetProductByID(id int) (*entities.Product, error) {
 	args := m.Called(id)
@@ -38,8 +49,23 @@ func (m *MockProductUseCase) UpdateProduct(product *entities.Product) error {
 	return args.Error(0)
 }
 
-// DeleteProduct удаляет продукцию
-func (m *MockProductUseCase) DeleteProduct(id int) error {
+// CloneProduct копирует продукцию вместе с рецептурой
+func (m *MockProductUseCase) CloneProduct(id int, request entities.ProductCloneRequest) (*entities.Product, error) {
+	args := m.Called(id, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Product), args.Error(1)
+}
+
+// ArchiveProduct переносит продукцию в архив
+func (m *MockProductUseCase) ArchiveProduct(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// RestoreProduct возвращает продукцию из архива
+func (m *MockProductUseCase) RestoreProduct(id int) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
@@ -49,3 +75,129 @@ func (m *MockProductUseCase) GetProductTypes() ([]entities.ProductType, error) {
 	args := m.Called()
 	return args.Get(0).([]entities.ProductType), args.Error(1)
 }
+
+// GetProductMaterials возвращает рецептуру продукции
+func (m *MockProductUseCase) GetProductMaterials(productID int) ([]entities.ProductMaterial, error) {
+	args := m.Called(productID)
+	return args.Get(0).([]entities.ProductMaterial), args.Error(1)
+}
+
+// AddProductMaterial добавляет материал в рецептуру
+func (m *MockProductUseCase) AddProductMaterial(productID int, productMaterial *entities.ProductMaterial) (*entities.Product, error) {
+	args := m.Called(productID, productMaterial)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Product), args.Error(1)
+}
+
+// UpdateProductMaterial изменяет расход материала в рецептуре
+func (m *MockProductUseCase) UpdateProductMaterial(productID int, productMaterial *entities.ProductMaterial) (*entities.Product, error) {
+	args := m.Called(productID, productMaterial)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Product), args.Error(1)
+}
+
+// RemoveProductMaterial удаляет материал из рецептуры
+func (m *MockProductUseCase) RemoveProductMaterial(productID, materialID int) (*entities.Product, error) {
+	args := m.Called(productID, materialID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Product), args.Error(1)
+}
+
+// ReplaceProductMaterials заменяет всю рецептуру продукции
+func (m *MockProductUseCase) ReplaceProductMaterials(productID int, materials []entities.ProductMaterial) (*entities.Product, error) {
+	args := m.Called(productID, materials)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Product), args.Error(1)
+}
+
+// GetProductComponents возвращает полуфабрикаты продукции
+func (m *MockProductUseCase) GetProductComponents(productID int) ([]entities.ProductComponent, error) {
+	args := m.Called(productID)
+	return args.Get(0).([]entities.ProductComponent), args.Error(1)
+}
+
+// AddProductComponent добавляет полуфабрикат в рецептуру
+func (m *MockProductUseCase) AddProductComponent(productID int, component *entities.ProductComponent) (*entities.Product, error) {
+	args := m.Called(productID, component)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Product), args.Error(1)
+}
+
+// UpdateProductComponent изменяет расход полуфабриката в рецептуре
+func (m *MockProductUseCase) UpdateProductComponent(productID int, component *entities.ProductComponent) (*entities.Product, error) {
+	args := m.Called(productID, component)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Product), args.Error(1)
+}
+
+// RemoveProductComponent удаляет полуфабрикат из рецептуры
+func (m *MockProductUseCase) RemoveProductComponent(productID, componentProductID int) (*entities.Product, error) {
+	args := m.Called(productID, componentProductID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Product), args.Error(1)
+}
+
+// ExplodeMaterials раскладывает рецептуру продукции до сырья
+func (m *MockProductUseCase) ExplodeMaterials(productID int, quantity float64) ([]entities.MaterialRequirement, error) {
+	args := m.Called(productID, quantity)
+	return args.Get(0).([]entities.MaterialRequirement), args.Error(1)
+}
+
+// CalculateProductPrice рассчитывает цену продукции по правилам ценообразования
+func (m *MockProductUseCase) CalculateProductPrice(productID int, partnerTypeID *int, date time.Time) (*entities.PriceCalculation, error) {
+	args := m.Called(productID, partnerTypeID, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PriceCalculation), args.Error(1)
+}
+
+// RecalculateCost пересчитывает и сохраняет себестоимость продукции
+func (m *MockProductUseCase) RecalculateCost(productID int) (*entities.Product, error) {
+	args := m.Called(productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Product), args.Error(1)
+}
+
+// RecalculateCostsForProductType пересчитывает себестоимость продукции типа
+func (m *MockProductUseCase) RecalculateCostsForProductType(productTypeID int) (int, error) {
+	args := m.Called(productTypeID)
+	return args.Int(0), args.Error(1)
+}
+
+// RecalculateCostsForMaterial пересчитывает себестоимость продукции, использующей материал
+func (m *MockProductUseCase) RecalculateCostsForMaterial(materialID int) (int, error) {
+	args := m.Called(materialID)
+	return args.Int(0), args.Error(1)
+}
+
+// RecalculateAllCosts пересчитывает себестоимость всей продукции
+func (m *MockProductUseCase) RecalculateAllCosts() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+// ExportProducts готовит выгрузку каталога продукции
+func (m *MockProductUseCase) ExportProducts(request entities.ProductExportRequest) (*entities.ExportTable, error) {
+	args := m.Called(request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ExportTable), args.Error(1)
+}
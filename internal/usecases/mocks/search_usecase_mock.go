@@ -0,0 +1,21 @@
+package mocks
+
+import (
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSearchUseCase - мок для SearchUseCase
+type MockSearchUseCase struct {
+	mock.Mock
+}
+
+// Search ищет продукцию, материалы и партнеров
+func (m *MockSearchUseCase) Search(query entities.SearchQuery) (*entities.SearchResults, error) {
+	args := m.Called(query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.SearchResults), args.Error(1)
+}
@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockShippingUseCase - мок для ShippingUseCase
+type MockShippingUseCase struct {
+	mock.Mock
+}
+
+// CalculateShipment рассчитывает отгрузку списка продукции
+func (m *MockShippingUseCase) CalculateShipment(request *entities.ShipmentRequest) (*entities.ShipmentPlan, error) {
+	args := m.Called(request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ShipmentPlan), args.Error(1)
+}
+
+// CalculateOrderShipment рассчитывает отгрузку позиций заказа
+func (m *MockShippingUseCase) CalculateOrderShipment(orderID int, pallet entities.PalletSpec) (*entities.ShipmentPlan, error) {
+	args := m.Called(orderID, pallet)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ShipmentPlan), args.Error(1)
+}
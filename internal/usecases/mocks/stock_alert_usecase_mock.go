@@ -0,0 +1,45 @@
+package mocks
+
+import (
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockStockAlertUseCase - мок для StockAlertUseCase
+type MockStockAlertUseCase struct {
+	mock.Mock
+}
+
+// GetLowStock возвращает материалы с остатком не выше минимального
+func (m *MockStockAlertUseCase) GetLowStock() ([]entities.LowStockItem, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.LowStockItem), args.Error(1)
+}
+
+// CheckLowStock проверяет остатки и создает уведомления
+func (m *MockStockAlertUseCase) CheckLowStock() (*entities.StockCheckResult, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.StockCheckResult), args.Error(1)
+}
+
+// GetNotifications возвращает уведомления об остатках
+func (m *MockStockAlertUseCase) GetNotifications(openOnly bool) ([]entities.StockNotification, error) {
+	args := m.Called(openOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.StockNotification), args.Error(1)
+}
+
+// AcknowledgeNotification отмечает уведомление просмотренным
+func (m *MockStockAlertUseCase) AcknowledgeNotification(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
@@ -0,0 +1,63 @@
+package mocks
+
+import (
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockStocktakeUseCase - мок для StocktakeUseCase
+type MockStocktakeUseCase struct {
+	mock.Mock
+}
+
+// GetStocktakes возвращает инвентаризации
+func (m *MockStocktakeUseCase) GetStocktakes() ([]entities.Stocktake, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.Stocktake), args.Error(1)
+}
+
+// GetReport возвращает ведомость расхождений
+func (m *MockStocktakeUseCase) GetReport(id int) (*entities.StocktakeReport, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.StocktakeReport), args.Error(1)
+}
+
+// OpenStocktake открывает инвентаризацию
+func (m *MockStocktakeUseCase) OpenStocktake(note *string) (*entities.Stocktake, error) {
+	args := m.Called(note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Stocktake), args.Error(1)
+}
+
+// SaveCounts сохраняет фактические остатки
+func (m *MockStocktakeUseCase) SaveCounts(id int, counts []entities.StocktakeCount) (*entities.StocktakeReport, error) {
+	args := m.Called(id, counts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.StocktakeReport), args.Error(1)
+}
+
+// CloseStocktake проводит расхождения и закрывает инвентаризацию
+func (m *MockStocktakeUseCase) CloseStocktake(id int) (*entities.StocktakeReport, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.StocktakeReport), args.Error(1)
+}
+
+// CancelStocktake отменяет инвентаризацию
+func (m *MockStocktakeUseCase) CancelStocktake(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
@@ -0,0 +1,87 @@
+package mocks
+
+import (
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockUnitConversionUseCase - мок для UnitConversionUseCase
+type MockUnitConversionUseCase struct {
+	mock.Mock
+}
+
+// GetUnits возвращает все единицы измерения
+func (m *MockUnitConversionUseCase) GetUnits() ([]entities.MeasurementUnit, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.MeasurementUnit), args.Error(1)
+}
+
+// Convert пересчитывает количество между единицами
+func (m *MockUnitConversionUseCase) Convert(materialID *int, quantity float64, from, to string) (*entities.UnitConversion, error) {
+	args := m.Called(materialID, quantity, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.UnitConversion), args.Error(1)
+}
+
+// GetMaterialEquivalents возвращает единицы, в которых можно выразить материал
+func (m *MockUnitConversionUseCase) GetMaterialEquivalents(materialID int) ([]entities.UnitEquivalent, error) {
+	args := m.Called(materialID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.UnitEquivalent), args.Error(1)
+}
+
+// GetMaterialConversions возвращает пересчеты материала
+func (m *MockUnitConversionUseCase) GetMaterialConversions(materialID int) ([]entities.MaterialUnitConversion, error) {
+	args := m.Called(materialID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.MaterialUnitConversion), args.Error(1)
+}
+
+// SaveMaterialConversion сохраняет пересчет материала
+func (m *MockUnitConversionUseCase) SaveMaterialConversion(conversion *entities.MaterialUnitConversion) error {
+	args := m.Called(conversion)
+	return args.Error(0)
+}
+
+// DeleteMaterialConversion удаляет пересчет материала
+func (m *MockUnitConversionUseCase) DeleteMaterialConversion(materialID, unitID int) error {
+	args := m.Called(materialID, unitID)
+	return args.Error(0)
+}
+
+// GetMaterialStock возвращает остаток материала в выбранной единице
+func (m *MockUnitConversionUseCase) GetMaterialStock(materialID int, unit string) (*entities.StockInUnit, error) {
+	args := m.Called(materialID, unit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.StockInUnit), args.Error(1)
+}
+
+// ExpressProductMaterials выражает рецептуру в выбранной единице
+func (m *MockUnitConversionUseCase) ExpressProductMaterials(lines []entities.ProductMaterial, unit string) ([]entities.ProductMaterial, error) {
+	args := m.Called(lines, unit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.ProductMaterial), args.Error(1)
+}
+
+// ExpressRequirements выражает потребность в сырье в выбранной единице
+func (m *MockUnitConversionUseCase) ExpressRequirements(requirements []entities.MaterialRequirement, unit string) ([]entities.MaterialRequirement, error) {
+	args := m.Called(requirements, unit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.MaterialRequirement), args.Error(1)
+}
@@ -0,0 +1,95 @@
+package mocks
+
+import (
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProductVariantUseCase - мок для интерфейса ProductVariantUseCaseInterface
+type MockProductVariantUseCase struct {
+	mock.Mock
+}
+
+// GetProductVariants возвращает продукцию вместе с вариантами
+func (m *MockProductVariantUseCase) GetProductVariants(productID int) (*entities.Product, []entities.ProductVariant, error) {
+	args := m.Called(productID)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*entities.Product), args.Get(1).([]entities.ProductVariant), args.Error(2)
+}
+
+// GetVariantByID возвращает вариант по ID
+func (m *MockProductVariantUseCase) GetVariantByID(id int) (*entities.ProductVariant, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ProductVariant), args.Error(1)
+}
+
+// CreateVariant создает вариант
+func (m *MockProductVariantUseCase) CreateVariant(variant *entities.ProductVariant) error {
+	args := m.Called(variant)
+	return args.Error(0)
+}
+
+// UpdateVariant обновляет вариант
+func (m *MockProductVariantUseCase) UpdateVariant(variant *entities.ProductVariant) error {
+	args := m.Called(variant)
+	return args.Error(0)
+}
+
+// DeleteVariant удаляет вариант
+func (m *MockProductVariantUseCase) DeleteVariant(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// SetVariantOverride задает расход материала в варианте
+func (m *MockProductVariantUseCase) SetVariantOverride(variantID int, override entities.VariantMaterialOverride) (*entities.ProductVariant, error) {
+	args := m.Called(variantID, override)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ProductVariant), args.Error(1)
+}
+
+// RemoveVariantOverride убирает замену материала
+func (m *MockProductVariantUseCase) RemoveVariantOverride(variantID, materialID int) (*entities.ProductVariant, error) {
+	args := m.Called(variantID, materialID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ProductVariant), args.Error(1)
+}
+
+// ResolveVariant возвращает продукцию варианта с действующей рецептурой
+func (m *MockProductVariantUseCase) ResolveVariant(id int) (*entities.Product, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Product), args.Error(1)
+}
+
+// CalculateVariantPrice рассчитывает цену варианта
+func (m *MockProductVariantUseCase) CalculateVariantPrice(id int, partnerTypeID *int, date time.Time) (*entities.PriceCalculation, error) {
+	args := m.Called(id, partnerTypeID, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PriceCalculation), args.Error(1)
+}
+
+// ExplodeVariantMaterials раскладывает рецептуру варианта до сырья
+func (m *MockProductVariantUseCase) ExplodeVariantMaterials(id int, quantity float64) ([]entities.MaterialRequirement, error) {
+	args := m.Called(id, quantity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.MaterialRequirement), args.Error(1)
+}
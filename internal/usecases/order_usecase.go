@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"fmt"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// OrderUseCase содержит бизнес-логику смены статусов заказов и резервов материалов под заказы
+type OrderUseCase struct {
+	orderRepo        repositories.OrderRepository
+	exploder         MaterialExploder
+	costRecalculator ProductCostRecalculator
+}
+
+// NewOrderUseCase создает новый use case заказов
+func NewOrderUseCase(orderRepo repositories.OrderRepository, exploder MaterialExploder, costRecalculator ProductCostRecalculator) *OrderUseCase {
+	return &OrderUseCase{
+		orderRepo:        orderRepo,
+		exploder:         exploder,
+		costRecalculator: costRecalculator,
+	}
+}
+
+// GetOrder возвращает заказ по ID
+func (uc *OrderUseCase) GetOrder(id int) (*entities.Order, error) {
+	return uc.orderRepo.GetByID(id)
+}
+
+// GetReservations возвращает резервы материалов заказа
+func (uc *OrderUseCase) GetReservations(orderID int) ([]entities.MaterialReservation, error) {
+	if _, err := uc.orderRepo.GetByID(orderID); err != nil {
+		return nil, err
+	}
+
+	reservations, err := uc.orderRepo.GetReservations(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения резервов заказа: %w", err)
+	}
+	return reservations, nil
+}
+
+// ChangeStatus переводит заказ в новый статус. При подтверждении рецептура позиций
+// раскладывается до сырья и материалы резервируются, если хватает свободного остатка;
+// при отмене резерв снимается, при запуске в производство списывается в расход. У заказа,
+// подтвержденного до появления резервов, материалы резервируются и списываются вместе
+// при запуске в производство. Если расход
+// изменил себестоимость материалов, а себестоимость продукции пересчитать не удалось, вместе
+// с заказом возвращается CostRecalculationError
+func (uc *OrderUseCase) ChangeStatus(orderID int, status entities.OrderStatus) (*entities.Order, error) {
+	order, err := uc.orderRepo.GetByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := entities.NewOrderStatusChange(order, status)
+	if err != nil {
+		return nil, err
+	}
+
+	reserve := status == entities.OrderConfirmed
+	if status == entities.OrderInProduction {
+		if reserve, err = uc.lacksActiveReservations(orderID); err != nil {
+			return nil, err
+		}
+	}
+	if reserve {
+		if change.Reservations, err = uc.reservationsFor(orderID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.orderRepo.ChangeStatus(change); err != nil {
+		return nil, err
+	}
+	recalcErr := recalculateCostsForMaterials(uc.costRecalculator, "статус заказа изменен", change.CostChangedMaterialIDs)
+
+	order, err = uc.orderRepo.GetByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if recalcErr != nil {
+		return order, recalcErr
+	}
+	return order, nil
+}
+
+// lacksActiveReservations проверяет, что у заказа нет активных резервов: так бывает
+// у заказов, подтвержденных до появления резервов материалов
+func (uc *OrderUseCase) lacksActiveReservations(orderID int) (bool, error) {
+	reservations, err := uc.orderRepo.GetReservations(orderID)
+	if err != nil {
+		return false, fmt.Errorf("ошибка получения резервов заказа: %w", err)
+	}
+	for _, reservation := range reservations {
+		if reservation.Status == entities.ReservationActive {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// reservationsFor рассчитывает резервы материалов по рецептуре позиций заказа
+func (uc *OrderUseCase) reservationsFor(orderID int) ([]entities.MaterialReservation, error) {
+	items, err := uc.orderRepo.GetItems(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, entities.NewBusinessError("EMPTY_ORDER", "в заказе нет позиций")
+	}
+
+	var requirements []entities.MaterialRequirement
+	for _, item := range items {
+		itemRequirements, err := uc.exploder.ExplodeMaterials(item.ProductID, float64(item.Quantity))
+		if err != nil {
+			return nil, fmt.Errorf("ошибка расчета сырья для продукции с ID %d: %w", item.ProductID, err)
+		}
+		requirements = append(requirements, itemRequirements...)
+	}
+
+	return entities.NewOrderReservations(orderID, requirements), nil
+}
@@ -0,0 +1,211 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/mocks"
+	usecasemocks "wallpaper-system/internal/usecases/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type OrderUseCaseTestSuite struct {
+	suite.Suite
+	orderRepo *mocks.MockOrderRepository
+	exploder  *usecasemocks.MockProductUseCase
+	useCase   *OrderUseCase
+}
+
+func (suite *OrderUseCaseTestSuite) SetupTest() {
+	suite.orderRepo = new(mocks.MockOrderRepository)
+	suite.exploder = new(usecasemocks.MockProductUseCase)
+	suite.useCase = NewOrderUseCase(suite.orderRepo, suite.exploder, suite.exploder)
+}
+
+func (suite *OrderUseCaseTestSuite) TestChangeStatus_ConfirmReservesExplodedMaterials() {
+	// Подготовка данных: винил входит в рецептуру обеих позиций заказа
+	items := []entities.OrderItem{
+		{ID: 1, OrderID: 5, ProductID: 10, Quantity: 20},
+		{ID: 2, OrderID: 5, ProductID: 11, Quantity: 4},
+	}
+
+	// Настройка моков
+	suite.orderRepo.On("GetByID", 5).Return(&entities.Order{ID: 5, Status: entities.OrderCreated}, nil).Once()
+	suite.orderRepo.On("GetItems", 5).Return(items, nil)
+	suite.exploder.On("ExplodeMaterials", 10, 20.0).Return([]entities.MaterialRequirement{
+		{MaterialID: 7, Quantity: 12.5},
+		{MaterialID: 2, Quantity: 1.2},
+	}, nil)
+	suite.exploder.On("ExplodeMaterials", 11, 4.0).Return([]entities.MaterialRequirement{
+		{MaterialID: 7, Quantity: 2.25},
+	}, nil)
+	suite.orderRepo.On("ChangeStatus", mock.MatchedBy(func(change *entities.OrderStatusChange) bool {
+		return change.OrderID == 5 && change.From == entities.OrderCreated && change.To == entities.OrderConfirmed &&
+			len(change.Reservations) == 2 &&
+			change.Reservations[0].MaterialID == 2 && change.Reservations[0].Quantity == 1.2 &&
+			change.Reservations[1].MaterialID == 7 && change.Reservations[1].Quantity == 14.75 &&
+			!change.ReleaseReservations && !change.ConsumeReservations
+	})).Return(nil)
+	suite.orderRepo.On("GetByID", 5).Return(&entities.Order{ID: 5, Status: entities.OrderConfirmed}, nil).Once()
+
+	// Выполнение
+	order, err := suite.useCase.ChangeStatus(5, entities.OrderConfirmed)
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), entities.OrderConfirmed, order.Status)
+	suite.orderRepo.AssertExpectations(suite.T())
+}
+
+func (suite *OrderUseCaseTestSuite) TestChangeStatus_ConfirmInsufficientAvailable() {
+	// Настройка моков: свободного остатка не хватает, статус заказа не меняется
+	suite.orderRepo.On("GetByID", 5).Return(&entities.Order{ID: 5, Status: entities.OrderCreated}, nil)
+	suite.orderRepo.On("GetItems", 5).Return([]entities.OrderItem{{ID: 1, OrderID: 5, ProductID: 10, Quantity: 20}}, nil)
+	suite.exploder.On("ExplodeMaterials", 10, 20.0).Return([]entities.MaterialRequirement{{MaterialID: 7, Quantity: 12.5}}, nil)
+	suite.orderRepo.On("ChangeStatus", mock.Anything).Return(entities.NewInsufficientAvailableError(
+		[]entities.ReservationShortage{{Article: "VIN-01", Required: 12.5, Available: 3}}))
+
+	// Выполнение
+	order, err := suite.useCase.ChangeStatus(5, entities.OrderConfirmed)
+
+	// Проверки
+	var businessErr *entities.BusinessError
+	require.ErrorAs(suite.T(), err, &businessErr)
+	assert.Equal(suite.T(), "INSUFFICIENT_AVAILABLE", businessErr.Code)
+	assert.Nil(suite.T(), order)
+}
+
+func (suite *OrderUseCaseTestSuite) TestChangeStatus_ConfirmEmptyOrder() {
+	// Настройка моков
+	suite.orderRepo.On("GetByID", 5).Return(&entities.Order{ID: 5, Status: entities.OrderCreated}, nil)
+	suite.orderRepo.On("GetItems", 5).Return([]entities.OrderItem{}, nil)
+
+	// Выполнение
+	_, err := suite.useCase.ChangeStatus(5, entities.OrderConfirmed)
+
+	// Проверки
+	var businessErr *entities.BusinessError
+	require.ErrorAs(suite.T(), err, &businessErr)
+	assert.Equal(suite.T(), "EMPTY_ORDER", businessErr.Code)
+	suite.orderRepo.AssertNotCalled(suite.T(), "ChangeStatus", mock.Anything)
+}
+
+func (suite *OrderUseCaseTestSuite) TestChangeStatus_CancelReleasesReservations() {
+	// Настройка моков
+	suite.orderRepo.On("GetByID", 5).Return(&entities.Order{ID: 5, Status: entities.OrderPrepaid}, nil)
+	suite.orderRepo.On("ChangeStatus", &entities.OrderStatusChange{
+		OrderID: 5, From: entities.OrderPrepaid, To: entities.OrderCancelled, ReleaseReservations: true,
+	}).Return(nil)
+
+	// Выполнение
+	_, err := suite.useCase.ChangeStatus(5, entities.OrderCancelled)
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	suite.exploder.AssertNotCalled(suite.T(), "ExplodeMaterials", mock.Anything, mock.Anything)
+	suite.orderRepo.AssertExpectations(suite.T())
+}
+
+func (suite *OrderUseCaseTestSuite) TestChangeStatus_ProductionConsumesReservations() {
+	// Настройка моков
+	suite.orderRepo.On("GetByID", 5).Return(&entities.Order{ID: 5, Status: entities.OrderConfirmed}, nil)
+	suite.orderRepo.On("GetReservations", 5).Return([]entities.MaterialReservation{
+		{ID: 1, OrderID: 5, MaterialID: 7, Quantity: 12.5, Status: entities.ReservationActive},
+	}, nil)
+	suite.orderRepo.On("ChangeStatus", &entities.OrderStatusChange{
+		OrderID: 5, From: entities.OrderConfirmed, To: entities.OrderInProduction, ConsumeReservations: true,
+	}).Return(nil)
+
+	// Выполнение
+	_, err := suite.useCase.ChangeStatus(5, entities.OrderInProduction)
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	suite.exploder.AssertNotCalled(suite.T(), "ExplodeMaterials", mock.Anything, mock.Anything)
+	suite.orderRepo.AssertExpectations(suite.T())
+}
+
+func (suite *OrderUseCaseTestSuite) TestChangeStatus_ProductionReservesOrderWithoutReservations() {
+	// Подготовка данных: заказ подтвержден до появления резервов, их нет
+	items := []entities.OrderItem{{ID: 1, OrderID: 5, ProductID: 10, Quantity: 20}}
+
+	// Настройка моков
+	suite.orderRepo.On("GetByID", 5).Return(&entities.Order{ID: 5, Status: entities.OrderPrepaid}, nil)
+	suite.orderRepo.On("GetReservations", 5).Return([]entities.MaterialReservation{}, nil)
+	suite.orderRepo.On("GetItems", 5).Return(items, nil)
+	suite.exploder.On("ExplodeMaterials", 10, 20.0).Return([]entities.MaterialRequirement{{MaterialID: 7, Quantity: 12.5}}, nil)
+	suite.orderRepo.On("ChangeStatus", mock.MatchedBy(func(change *entities.OrderStatusChange) bool {
+		return change.From == entities.OrderPrepaid && change.To == entities.OrderInProduction &&
+			len(change.Reservations) == 1 && change.Reservations[0].MaterialID == 7 &&
+			change.Reservations[0].Quantity == 12.5 && change.ConsumeReservations
+	})).Return(nil)
+
+	// Выполнение
+	_, err := suite.useCase.ChangeStatus(5, entities.OrderInProduction)
+
+	// Проверки: материалы резервируются и сразу списываются в расход
+	require.NoError(suite.T(), err)
+	suite.orderRepo.AssertExpectations(suite.T())
+	suite.exploder.AssertExpectations(suite.T())
+}
+
+func (suite *OrderUseCaseTestSuite) TestChangeStatus_ProductionRecalculatesChangedCosts() {
+	// Настройка моков: расход по FIFO изменил себестоимость материала 7
+	suite.orderRepo.On("GetByID", 5).Return(&entities.Order{ID: 5, Status: entities.OrderConfirmed}, nil).Once()
+	suite.orderRepo.On("GetReservations", 5).Return([]entities.MaterialReservation{
+		{ID: 1, OrderID: 5, MaterialID: 7, Quantity: 12.5, Status: entities.ReservationActive},
+	}, nil)
+	suite.orderRepo.On("ChangeStatus", mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*entities.OrderStatusChange).CostChangedMaterialIDs = []int{7}
+	}).Return(nil)
+	suite.orderRepo.On("GetByID", 5).Return(&entities.Order{ID: 5, Status: entities.OrderInProduction}, nil).Once()
+	suite.exploder.On("RecalculateCostsForMaterial", 7).Return(0, errors.New("database error"))
+
+	// Выполнение
+	order, err := suite.useCase.ChangeStatus(5, entities.OrderInProduction)
+
+	// Проверки: статус изменен, ошибка пересчета продукции возвращается отдельно
+	var recalcErr *entities.CostRecalculationError
+	require.ErrorAs(suite.T(), err, &recalcErr)
+	require.NotNil(suite.T(), order)
+	assert.Equal(suite.T(), entities.OrderInProduction, order.Status)
+	suite.exploder.AssertExpectations(suite.T())
+}
+
+func (suite *OrderUseCaseTestSuite) TestChangeStatus_InvalidTransition() {
+	// Настройка моков
+	suite.orderRepo.On("GetByID", 5).Return(&entities.Order{ID: 5, Status: entities.OrderCompleted}, nil)
+
+	// Выполнение
+	_, err := suite.useCase.ChangeStatus(5, entities.OrderCancelled)
+
+	// Проверки
+	var businessErr *entities.BusinessError
+	require.ErrorAs(suite.T(), err, &businessErr)
+	assert.Equal(suite.T(), "INVALID_STATUS_TRANSITION", businessErr.Code)
+	suite.orderRepo.AssertNotCalled(suite.T(), "ChangeStatus", mock.Anything)
+}
+
+func (suite *OrderUseCaseTestSuite) TestChangeStatus_ExplosionError() {
+	// Настройка моков
+	suite.orderRepo.On("GetByID", 5).Return(&entities.Order{ID: 5, Status: entities.OrderCreated}, nil)
+	suite.orderRepo.On("GetItems", 5).Return([]entities.OrderItem{{ID: 1, OrderID: 5, ProductID: 10, Quantity: 1}}, nil)
+	suite.exploder.On("ExplodeMaterials", 10, 1.0).Return([]entities.MaterialRequirement(nil), errors.New("цикл в рецептуре"))
+
+	// Выполнение
+	_, err := suite.useCase.ChangeStatus(5, entities.OrderConfirmed)
+
+	// Проверки
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "продукции с ID 10")
+	suite.orderRepo.AssertNotCalled(suite.T(), "ChangeStatus", mock.Anything)
+}
+
+func TestOrderUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(OrderUseCaseTestSuite))
+}
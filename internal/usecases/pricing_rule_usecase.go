@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	"fmt"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// PricingRuleUseCase содержит бизнес-логику для работы с правилами ценообразования
+type PricingRuleUseCase struct {
+	pricingRuleRepo repositories.PricingRuleRepository
+	productRepo     repositories.ProductRepository
+}
+
+// NewPricingRuleUseCase создает новый use case правил ценообразования
+func NewPricingRuleUseCase(
+	pricingRuleRepo repositories.PricingRuleRepository,
+	productRepo repositories.ProductRepository,
+) *PricingRuleUseCase {
+	return &PricingRuleUseCase{
+		pricingRuleRepo: pricingRuleRepo,
+		productRepo:     productRepo,
+	}
+}
+
+// GetAllRules возвращает все правила ценообразования
+func (uc *PricingRuleUseCase) GetAllRules() ([]entities.PricingRule, error) {
+	return uc.pricingRuleRepo.GetAll()
+}
+
+// GetRuleByID возвращает правило ценообразования по ID
+func (uc *PricingRuleUseCase) GetRuleByID(id int) (*entities.PricingRule, error) {
+	return uc.pricingRuleRepo.GetByID(id)
+}
+
+// CreateRule создает новое правило ценообразования
+func (uc *PricingRuleUseCase) CreateRule(rule *entities.PricingRule) error {
+	if err := uc.validateRule(rule); err != nil {
+		return err
+	}
+
+	return uc.pricingRuleRepo.Create(rule)
+}
+
+// UpdateRule обновляет правило ценообразования
+func (uc *PricingRuleUseCase) UpdateRule(rule *entities.PricingRule) error {
+	if _, err := uc.pricingRuleRepo.GetByID(rule.ID); err != nil {
+		return fmt.Errorf("правило ценообразования не найдено: %w", err)
+	}
+
+	if err := uc.validateRule(rule); err != nil {
+		return err
+	}
+
+	return uc.pricingRuleRepo.Update(rule)
+}
+
+// DeleteRule удаляет правило ценообразования
+func (uc *PricingRuleUseCase) DeleteRule(id int) error {
+	return uc.pricingRuleRepo.Delete(id)
+}
+
+// GetPartnerTypes возвращает все типы партнеров
+func (uc *PricingRuleUseCase) GetPartnerTypes() ([]entities.PartnerType, error) {
+	return uc.pricingRuleRepo.GetPartnerTypes()
+}
+
+// validateRule проверяет правило и существование связанных справочников
+func (uc *PricingRuleUseCase) validateRule(rule *entities.PricingRule) error {
+	if err := rule.Validate(); err != nil {
+		return fmt.Errorf("ошибка валидации: %w", err)
+	}
+
+	if rule.ProductTypeID != nil {
+		if _, err := uc.productRepo.GetProductTypeByID(*rule.ProductTypeID); err != nil {
+			return fmt.Errorf("тип продукции не найден: %w", err)
+		}
+	}
+
+	if rule.PartnerTypeID != nil {
+		if _, err := uc.pricingRuleRepo.GetPartnerTypeByID(*rule.PartnerTypeID); err != nil {
+			return fmt.Errorf("тип партнера не найден: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package usecases
+
+import (
+	"testing"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type PricingRuleUseCaseTestSuite struct {
+	suite.Suite
+	pricingRuleRepo *mocks.MockPricingRuleRepository
+	productRepo     *mocks.MockProductRepository
+	useCase         *PricingRuleUseCase
+}
+
+func (suite *PricingRuleUseCaseTestSuite) SetupTest() {
+	suite.pricingRuleRepo = new(mocks.MockPricingRuleRepository)
+	suite.productRepo = new(mocks.MockProductRepository)
+	suite.useCase = NewPricingRuleUseCase(suite.pricingRuleRepo, suite.productRepo)
+}
+
+func newTestPricingRule() *entities.PricingRule {
+	productTypeID := 2
+	return &entities.PricingRule{
+		Name:          "Флизелин",
+		ProductTypeID: &productTypeID,
+		MarkupPercent: 30,
+		RoundingMode:  entities.RoundingUp,
+		RoundingStep:  10,
+		IsActive:      true,
+	}
+}
+
+func (suite *PricingRuleUseCaseTestSuite) TestCreateRule_Success() {
+	// Подготовка данных
+	rule := newTestPricingRule()
+
+	// Настройка моков
+	suite.productRepo.On("GetProductTypeByID", 2).Return(&entities.ProductType{ID: 2, Name: "Флизелин"}, nil)
+	suite.pricingRuleRepo.On("Create", rule).Return(nil)
+
+	// Выполнение
+	err := suite.useCase.CreateRule(rule)
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	suite.pricingRuleRepo.AssertExpectations(suite.T())
+}
+
+func (suite *PricingRuleUseCaseTestSuite) TestCreateRule_RoundingStepBelowStoredPrecision() {
+	// Подготовка данных: шаг 0.001 сохранился бы в DECIMAL(10,2) как ноль
+	rule := newTestPricingRule()
+	rule.RoundingStep = 0.001
+
+	// Выполнение
+	err := suite.useCase.CreateRule(rule)
+
+	// Проверки
+	var validationErr *entities.ValidationError
+	require.ErrorAs(suite.T(), err, &validationErr)
+	assert.Equal(suite.T(), "rounding_step", validationErr.Field)
+	suite.pricingRuleRepo.AssertNotCalled(suite.T(), "Create", mock.Anything)
+}
+
+func (suite *PricingRuleUseCaseTestSuite) TestCreateRule_UnknownPartnerType() {
+	// Подготовка данных
+	rule := newTestPricingRule()
+	rule.ProductTypeID = nil
+	partnerTypeID := 9
+	rule.PartnerTypeID = &partnerTypeID
+
+	// Настройка моков
+	suite.pricingRuleRepo.On("GetPartnerTypeByID", 9).Return(nil, entities.NewNotFoundError("тип партнера", "9"))
+
+	// Выполнение
+	err := suite.useCase.CreateRule(rule)
+
+	// Проверки
+	var notFoundErr *entities.NotFoundError
+	require.ErrorAs(suite.T(), err, &notFoundErr)
+	suite.pricingRuleRepo.AssertNotCalled(suite.T(), "Create", mock.Anything)
+}
+
+func (suite *PricingRuleUseCaseTestSuite) TestUpdateRule_NotFound() {
+	// Подготовка данных
+	rule := newTestPricingRule()
+	rule.ID = 4
+
+	// Настройка моков
+	suite.pricingRuleRepo.On("GetByID", 4).Return(nil, entities.NewNotFoundError("правило ценообразования", "4"))
+
+	// Выполнение
+	err := suite.useCase.UpdateRule(rule)
+
+	// Проверки
+	var notFoundErr *entities.NotFoundError
+	require.ErrorAs(suite.T(), err, &notFoundErr)
+	suite.pricingRuleRepo.AssertNotCalled(suite.T(), "Update", mock.Anything)
+}
+
+func (suite *PricingRuleUseCaseTestSuite) TestUpdateRule_Success() {
+	// Подготовка данных
+	rule := newTestPricingRule()
+	rule.ID = 4
+	rule.RoundingStep = 0.5
+
+	// Настройка моков
+	suite.pricingRuleRepo.On("GetByID", 4).Return(newTestPricingRule(), nil)
+	suite.productRepo.On("GetProductTypeByID", 2).Return(&entities.ProductType{ID: 2, Name: "Флизелин"}, nil)
+	suite.pricingRuleRepo.On("Update", rule).Return(nil)
+
+	// Выполнение
+	err := suite.useCase.UpdateRule(rule)
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	suite.pricingRuleRepo.AssertExpectations(suite.T())
+}
+
+func TestPricingRuleUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(PricingRuleUseCaseTestSuite))
+}
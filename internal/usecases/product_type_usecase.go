@@ -0,0 +1,167 @@
+package usecases
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// coefficientScale соответствует точности хранения коэффициента DECIMAL(10,4)
+const coefficientScale = 10000
+
+// ProductTypeUseCase содержит бизнес-логику управления типами продукции
+type ProductTypeUseCase struct {
+	productTypeRepo  repositories.ProductTypeRepository
+	costRecalculator ProductTypeCostRecalculator
+}
+
+// NewProductTypeUseCase создает новый use case типов продукции
+func NewProductTypeUseCase(
+	productTypeRepo repositories.ProductTypeRepository,
+	costRecalculator ProductTypeCostRecalculator,
+) *ProductTypeUseCase {
+	return &ProductTypeUseCase{
+		productTypeRepo:  productTypeRepo,
+		costRecalculator: costRecalculator,
+	}
+}
+
+// GetProductTypes возвращает типы продукции; выведенные из обращения - только при includeArchived
+func (uc *ProductTypeUseCase) GetProductTypes(includeArchived bool) ([]entities.ProductType, error) {
+	return uc.productTypeRepo.GetAll(includeArchived)
+}
+
+// GetProductTypeByID возвращает тип продукции по ID
+func (uc *ProductTypeUseCase) GetProductTypeByID(id int) (*entities.ProductType, error) {
+	return uc.productTypeRepo.GetByID(id)
+}
+
+// CreateProductType создает тип продукции и записывает начальный коэффициент в историю
+// от имени автора change
+func (uc *ProductTypeUseCase) CreateProductType(productType *entities.ProductType, change *entities.CoefficientChange) error {
+	productType.Coefficient = roundCoefficient(productType.Coefficient)
+	if err := uc.validateProductType(productType); err != nil {
+		return err
+	}
+	if err := uc.prepareChange(change, nil, productType.Coefficient); err != nil {
+		return err
+	}
+
+	return uc.productTypeRepo.Create(productType, change)
+}
+
+// UpdateProductType обновляет тип продукции. Если коэффициент изменился, изменение записывается
+// в историю от имени автора change, а сохраненная себестоимость продукции типа пересчитывается
+func (uc *ProductTypeUseCase) UpdateProductType(productType *entities.ProductType, change *entities.CoefficientChange) error {
+	existing, err := uc.productTypeRepo.GetByID(productType.ID)
+	if err != nil {
+		return fmt.Errorf("тип продукции не найден: %w", err)
+	}
+
+	productType.Coefficient = roundCoefficient(productType.Coefficient)
+	if err := uc.validateProductType(productType); err != nil {
+		return err
+	}
+	productType.ArchivedAt = existing.ArchivedAt
+	productType.CreatedAt = existing.CreatedAt
+
+	if productType.Coefficient == existing.Coefficient {
+		return uc.productTypeRepo.Update(productType, nil)
+	}
+
+	oldCoefficient := existing.Coefficient
+	if err := uc.prepareChange(change, &oldCoefficient, productType.Coefficient); err != nil {
+		return err
+	}
+	if err := uc.productTypeRepo.Update(productType, change); err != nil {
+		return err
+	}
+
+	if _, err := uc.costRecalculator.RecalculateCostsForProductType(productType.ID); err != nil {
+		return entities.NewCostRecalculationError("коэффициент изменен", err)
+	}
+	return nil
+}
+
+// ArchiveProductType выводит тип продукции из обращения: новую продукцию этого типа создать
+// нельзя, существующая продукция и история сохраняются
+func (uc *ProductTypeUseCase) ArchiveProductType(id int) error {
+	existing, err := uc.productTypeRepo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("тип продукции не найден: %w", err)
+	}
+	if existing.IsArchived() {
+		return entities.NewBusinessError("ALREADY_ARCHIVED", "тип продукции уже выведен из обращения")
+	}
+
+	return uc.productTypeRepo.Archive(id, time.Now())
+}
+
+// RestoreProductType возвращает тип продукции в обращение
+func (uc *ProductTypeUseCase) RestoreProductType(id int) error {
+	existing, err := uc.productTypeRepo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("тип продукции не найден: %w", err)
+	}
+	if !existing.IsArchived() {
+		return entities.NewBusinessError("NOT_ARCHIVED", "тип продукции не выведен из обращения")
+	}
+
+	return uc.productTypeRepo.Restore(id)
+}
+
+// GetCoefficientHistory возвращает историю коэффициента типа продукции, начиная с последнего изменения
+func (uc *ProductTypeUseCase) GetCoefficientHistory(id int) ([]entities.CoefficientChange, error) {
+	if _, err := uc.productTypeRepo.GetByID(id); err != nil {
+		return nil, fmt.Errorf("тип продукции не найден: %w", err)
+	}
+
+	return uc.productTypeRepo.GetCoefficientHistory(id)
+}
+
+// validateProductType проверяет тип продукции и уникальность его названия без учета регистра
+func (uc *ProductTypeUseCase) validateProductType(productType *entities.ProductType) error {
+	if err := productType.Validate(); err != nil {
+		return err
+	}
+
+	types, err := uc.productTypeRepo.GetAll(true)
+	if err != nil {
+		return fmt.Errorf("ошибка получения типов продукции: %w", err)
+	}
+	for _, other := range types {
+		if other.ID != productType.ID && strings.EqualFold(other.Name, productType.Name) {
+			return entities.NewBusinessError("DUPLICATE_PRODUCT_TYPE",
+				fmt.Sprintf("тип продукции %s уже существует", other.Name))
+		}
+	}
+	return nil
+}
+
+// prepareChange заполняет запись истории коэффициента; без даты изменение действует с сегодняшнего дня
+func (uc *ProductTypeUseCase) prepareChange(change *entities.CoefficientChange, oldCoefficient *float64, newCoefficient float64) error {
+	if change == nil {
+		return entities.NewValidationError("changed_by", "укажите, кто изменяет коэффициент")
+	}
+
+	now := time.Now()
+	if change.EffectiveFrom.IsZero() {
+		change.EffectiveFrom = now
+	}
+	if err := change.Validate(now); err != nil {
+		return err
+	}
+
+	change.OldCoefficient = oldCoefficient
+	change.NewCoefficient = newCoefficient
+	return nil
+}
+
+// roundCoefficient округляет коэффициент до точности хранения, чтобы история совпадала с базой
+func roundCoefficient(coefficient float64) float64 {
+	return math.Round(coefficient*coefficientScale) / coefficientScale
+}
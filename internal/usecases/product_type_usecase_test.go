@@ -0,0 +1,183 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/mocks"
+	usecasemocks "wallpaper-system/internal/usecases/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProductTypeUseCaseTestSuite struct {
+	suite.Suite
+	productTypeRepo  *mocks.MockProductTypeRepository
+	costRecalculator *usecasemocks.MockProductUseCase
+	useCase          *ProductTypeUseCase
+}
+
+func (suite *ProductTypeUseCaseTestSuite) SetupTest() {
+	suite.productTypeRepo = new(mocks.MockProductTypeRepository)
+	suite.costRecalculator = new(usecasemocks.MockProductUseCase)
+	suite.useCase = NewProductTypeUseCase(suite.productTypeRepo, suite.costRecalculator)
+
+	// По умолчанию в справочнике один тип - Флизелин
+	suite.productTypeRepo.On("GetAll", true).Return([]entities.ProductType{*newTestProductType()}, nil).Maybe()
+}
+
+func newTestProductType() *entities.ProductType {
+	return &entities.ProductType{ID: 1, Name: "Флизелин", Coefficient: 1.2}
+}
+
+func (suite *ProductTypeUseCaseTestSuite) TestCreateProductType_RecordsInitialCoefficient() {
+	// Подготовка данных
+	productType := &entities.ProductType{Name: "Винил", Coefficient: 1.50004}
+	change := &entities.CoefficientChange{ChangedBy: "Иванов"}
+
+	// Настройка моков
+	suite.productTypeRepo.On("Create", productType, change).Return(nil)
+
+	// Выполнение
+	err := suite.useCase.CreateProductType(productType, change)
+
+	// Проверки: коэффициент округлен до точности хранения, без даты действует с сегодня
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1.5, productType.Coefficient)
+	assert.Nil(suite.T(), change.OldCoefficient)
+	assert.Equal(suite.T(), 1.5, change.NewCoefficient)
+	assert.Equal(suite.T(), time.Now().Format("2006-01-02"), change.EffectiveFrom.Format("2006-01-02"))
+}
+
+func (suite *ProductTypeUseCaseTestSuite) TestCreateProductType_DuplicateName() {
+	// Выполнение
+	err := suite.useCase.CreateProductType(&entities.ProductType{Name: "флизелин", Coefficient: 1.5},
+		&entities.CoefficientChange{ChangedBy: "Иванов"})
+
+	// Проверки
+	var businessErr *entities.BusinessError
+	require.ErrorAs(suite.T(), err, &businessErr)
+	assert.Equal(suite.T(), "DUPLICATE_PRODUCT_TYPE", businessErr.Code)
+	suite.productTypeRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductTypeUseCaseTestSuite) TestUpdateProductType_CoefficientChanged_RecordsHistoryAndRecalculates() {
+	// Подготовка данных
+	productType := &entities.ProductType{ID: 1, Name: "Флизелин", Coefficient: 1.35}
+	change := &entities.CoefficientChange{
+		ChangedBy:     "Петрова",
+		EffectiveFrom: time.Now().AddDate(0, 0, -3),
+	}
+
+	// Настройка моков
+	suite.productTypeRepo.On("GetByID", 1).Return(newTestProductType(), nil)
+	suite.productTypeRepo.On("Update", productType, change).Return(nil)
+	suite.costRecalculator.On("RecalculateCostsForProductType", 1).Return(4, nil)
+
+	// Выполнение
+	err := suite.useCase.UpdateProductType(productType, change)
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	require.NotNil(suite.T(), change.OldCoefficient)
+	assert.Equal(suite.T(), 1.2, *change.OldCoefficient)
+	assert.Equal(suite.T(), 1.35, change.NewCoefficient)
+	suite.costRecalculator.AssertExpectations(suite.T())
+}
+
+func (suite *ProductTypeUseCaseTestSuite) TestUpdateProductType_RecalculationError() {
+	// Подготовка данных
+	change := &entities.CoefficientChange{ChangedBy: "Петрова"}
+
+	// Настройка моков
+	suite.productTypeRepo.On("GetByID", 1).Return(newTestProductType(), nil)
+	suite.productTypeRepo.On("Update", mock.Anything, change).Return(nil)
+	suite.costRecalculator.On("RecalculateCostsForProductType", 1).Return(0, errors.New("connection refused"))
+
+	// Выполнение
+	err := suite.useCase.UpdateProductType(&entities.ProductType{ID: 1, Name: "Флизелин", Coefficient: 1.35}, change)
+
+	// Проверки: коэффициент сохранен, ошибка пересчета отделена от ошибок изменения
+	var recalcErr *entities.CostRecalculationError
+	require.ErrorAs(suite.T(), err, &recalcErr)
+	suite.productTypeRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ProductTypeUseCaseTestSuite) TestUpdateProductType_CoefficientUnchanged_NoHistory() {
+	// Подготовка данных: меняется только описание, автор изменения не указан
+	description := "Обои на флизелиновой основе"
+	productType := &entities.ProductType{ID: 1, Name: "Флизелин", Description: &description, Coefficient: 1.2}
+
+	// Настройка моков
+	suite.productTypeRepo.On("GetByID", 1).Return(newTestProductType(), nil)
+	suite.productTypeRepo.On("Update", productType, (*entities.CoefficientChange)(nil)).Return(nil)
+
+	// Выполнение
+	err := suite.useCase.UpdateProductType(productType, &entities.CoefficientChange{})
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	suite.productTypeRepo.AssertExpectations(suite.T())
+	suite.costRecalculator.AssertNotCalled(suite.T(), "RecalculateCostsForProductType", mock.Anything)
+}
+
+func (suite *ProductTypeUseCaseTestSuite) TestUpdateProductType_CoefficientChangedWithoutAuthor() {
+	// Настройка моков
+	suite.productTypeRepo.On("GetByID", 1).Return(newTestProductType(), nil)
+
+	// Выполнение
+	err := suite.useCase.UpdateProductType(&entities.ProductType{ID: 1, Name: "Флизелин", Coefficient: 1.4},
+		&entities.CoefficientChange{})
+
+	// Проверки
+	var validationErr *entities.ValidationError
+	require.ErrorAs(suite.T(), err, &validationErr)
+	assert.Equal(suite.T(), "changed_by", validationErr.Field)
+	suite.productTypeRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductTypeUseCaseTestSuite) TestArchiveProductType_AlreadyArchived() {
+	// Подготовка данных
+	archivedAt := time.Now()
+	productType := newTestProductType()
+	productType.ArchivedAt = &archivedAt
+
+	// Настройка моков
+	suite.productTypeRepo.On("GetByID", 1).Return(productType, nil)
+
+	// Выполнение
+	err := suite.useCase.ArchiveProductType(1)
+
+	// Проверки
+	var businessErr *entities.BusinessError
+	require.ErrorAs(suite.T(), err, &businessErr)
+	assert.Equal(suite.T(), "ALREADY_ARCHIVED", businessErr.Code)
+	suite.productTypeRepo.AssertNotCalled(suite.T(), "Archive", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductTypeUseCaseTestSuite) TestRestoreProductType_Success() {
+	// Подготовка данных
+	archivedAt := time.Now()
+	productType := newTestProductType()
+	productType.ArchivedAt = &archivedAt
+
+	// Настройка моков
+	suite.productTypeRepo.On("GetByID", 1).Return(productType, nil)
+	suite.productTypeRepo.On("Restore", 1).Return(nil)
+
+	// Выполнение
+	err := suite.useCase.RestoreProductType(1)
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	suite.productTypeRepo.AssertExpectations(suite.T())
+}
+
+func TestProductTypeUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ProductTypeUseCaseTestSuite))
+}
@@ -3,6 +3,8 @@ package usecases
 import (
 	"fmt"
 	"math"
+	"strings"
+	"time"
 
 	"wallpaper-system/internal/domain/entities"
 	"wallpaper-system/internal/domain/repositories"
@@ -10,18 +12,21 @@ import (
 
 // ProductUseCase содержит бизнес-логику для работы с продукцией
 type ProductUseCase struct {
-	productRepo  repositories.ProductRepository
-	materialRepo repositories.MaterialRepository
+	productRepo     repositories.ProductRepository
+	materialRepo    repositories.MaterialRepository
+	pricingRuleRepo repositories.PricingRuleRepository
 }
 
 // NewProductUseCase создает новый use case продукции
 func NewProductUseCase(
 	productRepo repositories.ProductRepository,
 	materialRepo repositories.MaterialRepository,
+	pricingRuleRepo repositories.PricingRuleRepository,
 ) *ProductUseCase {
 	return &ProductUseCase{
-		productRepo:  productRepo,
-		materialRepo: materialRepo,
+		productRepo:     productRepo,
+		materialRepo:    materialRepo,
+		pricingRuleRepo: pricingRuleRepo,
 	}
 }
 
@@ -32,17 +37,47 @@ func (uc *ProductUseCase) GetAllProducts() ([]entities.Product, error) {
 		return nil, fmt.Errorf("ошибка получения продукции: %w", err)
 	}
 
+	// Правила загружаем один раз для всего списка
+	rules, err := uc.pricingRuleRepo.GetActiveRules(time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения правил ценообразования: %w", err)
+	}
+
 	// Рассчитываем цены для каждой продукции
 	for i := range products {
-		price, err := uc.calculateProductPrice(&products[i])
-		if err == nil && price > 0 {
-			products[i].CalculatedPrice = &price
-		}
+		uc.applyPrice(&products[i], rules)
 	}
 
 	return products, nil
 }
 
+// FindProducts возвращает страницу продукции по фильтрам с рассчитанными ценами
+func (uc *ProductUseCase) FindProducts(criteria entities.ProductCriteria) (*entities.ProductList, error) {
+	if err := criteria.Validate(); err != nil {
+		return nil, err
+	}
+	criteria.Normalize()
+
+	products, total, err := uc.productRepo.FindByCriteria(criteria)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения продукции: %w", err)
+	}
+
+	rules, err := uc.pricingRuleRepo.GetActiveRules(time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения правил ценообразования: %w", err)
+	}
+
+	for i := range products {
+		uc.applyPrice(&products[i], rules)
+	}
+
+	return &entities.ProductList{
+		Items:    products,
+		PageInfo: entities.PageInfo{Page: criteria.Page, PageSize: criteria.PageSize, Total: total},
+	}, nil
+}
+
 // GetProductByID возвращает продукцию по ID с материалами и рассчитанной ценой
 func (uc *ProductUseCase) GetProductByID(id int) (*entities.Product, error) {
 	product, err := uc.productRepo.GetByID(id)
@@ -50,15 +85,116 @@ func (uc *ProductUseCase) GetProductByID(id int) (*entities.Product, error) {
 		return nil, fmt.Errorf("ошибка получения продукции: %w", err)
 	}
 
-	// Рассчитываем цену
-	price, err := uc.calculateProductPrice(product)
-	if err == nil && price > 0 {
-		product.CalculatedPrice = &price
+	rules, err := uc.pricingRuleRepo.GetActiveRules(time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения правил ценообразования: %w", err)
 	}
 
+	uc.applyPrice(product, rules)
+
 	return product, nil
 }
 
+// CalculateProductPrice рассчитывает цену продукции для типа партнера на указанную дату
+func (uc *ProductUseCase) CalculateProductPrice(productID int, partnerTypeID *int, date time.Time) (*entities.PriceCalculation, error) {
+	product, err := uc.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения продукции: %w", err)
+	}
+
+	return uc.CalculatePriceFor(product, partnerTypeID, date)
+}
+
+// CalculatePriceFor рассчитывает цену уже загруженной продукции, например варианта
+// с подставленной рецептурой, для типа партнера на указанную дату
+func (uc *ProductUseCase) CalculatePriceFor(product *entities.Product, partnerTypeID *int, date time.Time) (*entities.PriceCalculation, error) {
+	if partnerTypeID != nil {
+		if _, err := uc.pricingRuleRepo.GetPartnerTypeByID(*partnerTypeID); err != nil {
+			return nil, fmt.Errorf("тип партнера не найден: %w", err)
+		}
+	}
+
+	rules, err := uc.pricingRuleRepo.GetActiveRules(date)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения правил ценообразования: %w", err)
+	}
+
+	return uc.calculateProductPrice(product, rules, partnerTypeID, date)
+}
+
+// ExportProducts готовит выгрузку каталога продукции по фильтрам со всеми найденными позициями.
+// Цены рассчитываются по правилам ценообразования для указанного типа партнера,
+// строки группируются по типам продукции
+func (uc *ProductUseCase) ExportProducts(request entities.ProductExportRequest) (*entities.ExportTable, error) {
+	columns, err := selectProductExportColumns(request.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	criteria := request.Criteria
+	if criteria.SortBy == "" {
+		criteria.SortBy = entities.ProductSortName
+	}
+	if err := criteria.Validate(); err != nil {
+		return nil, err
+	}
+	criteria.Normalize()
+	criteria.Pagination = entities.Pagination{Page: 1, PageSize: entities.MaxExportRows}
+
+	if request.PartnerTypeID != nil {
+		if _, err := uc.pricingRuleRepo.GetPartnerTypeByID(*request.PartnerTypeID); err != nil {
+			return nil, fmt.Errorf("тип партнера не найден: %w", err)
+		}
+	}
+
+	products, total, err := uc.productRepo.FindByCriteria(criteria)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения продукции: %w", err)
+	}
+	if err := checkExportTotal(total); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	rules, err := uc.pricingRuleRepo.GetActiveRules(now)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения правил ценообразования: %w", err)
+	}
+
+	table := &entities.ExportTable{Title: "Прайс-лист продукции", GeneratedAt: now}
+	for _, column := range columns {
+		table.Columns = append(table.Columns, column.ExportColumn)
+	}
+
+	groups := newExportGrouper()
+	for i := range products {
+		product := &products[i]
+		calculation, err := uc.calculateProductPrice(product, rules, request.PartnerTypeID, now)
+		if err == nil && calculation.Price > 0 {
+			product.CalculatedPrice = &calculation.Price
+			product.AppliedPricingRule = calculation.Rule
+		}
+
+		row := make([]entities.ExportCell, len(columns))
+		for j, column := range columns {
+			row[j] = column.value(product)
+		}
+		groups.add(productTypeName(product), row)
+	}
+	table.Groups = groups.result()
+
+	return table, nil
+}
+
+// applyPrice заполняет рассчитанную цену и примененное правило продукции без учета типа партнера
+func (uc *ProductUseCase) applyPrice(product *entities.Product, rules []entities.PricingRule) {
+	calculation, err := uc.calculateProductPrice(product, rules, nil, time.Now())
+	if err == nil && calculation.Price > 0 {
+		product.CalculatedPrice = &calculation.Price
+		product.AppliedPricingRule = calculation.Rule
+	}
+}
+
 // CreateProduct создает новую продукцию
 func (uc *ProductUseCase) CreateProduct(product *entities.Product) error {
 	// Валидация
@@ -67,15 +203,16 @@ func (uc *ProductUseCase) CreateProduct(product *entities.Product) error {
 	}
 
 	// Проверяем существование типа продукции
-	_, err := uc.productRepo.GetProductTypeByID(product.ProductTypeID)
-	if err != nil {
-		return fmt.Errorf("тип продукции не найден: %w", err)
+	if err := uc.checkProductType(product.ProductTypeID); err != nil {
+		return err
 	}
 
 	return uc.productRepo.Create(product)
 }
 
-// UpdateProduct обновляет продукцию
+// UpdateProduct обновляет продукцию. При смене типа продукции себестоимость пересчитывается
+// с коэффициентом нового типа; если продукция сохранена, а пересчитать себестоимость не удалось,
+// возвращается CostRecalculationError
 func (uc *ProductUseCase) UpdateProduct(product *entities.Product) error {
 	// Валидация
 	if err := product.Validate(); err != nil {
@@ -89,25 +226,108 @@ func (uc *ProductUseCase) UpdateProduct(product *entities.Product) error {
 	}
 
 	// Проверяем тип продукции если он изменился
-	if existing.ProductTypeID != product.ProductTypeID {
-		_, err := uc.productRepo.GetProductTypeByID(product.ProductTypeID)
-		if err != nil {
-			return fmt.Errorf("тип продукции не найден: %w", err)
+	typeChanged := existing.ProductTypeID != product.ProductTypeID
+	if typeChanged {
+		if err := uc.checkProductType(product.ProductTypeID); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.productRepo.Update(product); err != nil {
+		return err
+	}
+
+	// Коэффициент типа входит в себестоимость продукции и всей продукции, куда она входит полуфабрикатом
+	if typeChanged {
+		if err := uc.refreshStoredCosts(product.ID); err != nil {
+			return entities.NewCostRecalculationError("продукция обновлена", err)
 		}
 	}
 
-	return uc.productRepo.Update(product)
+	return nil
 }
 
-// DeleteProduct удаляет продукцию
-func (uc *ProductUseCase) DeleteProduct(id int) error {
-	// Проверяем существование
-	_, err := uc.productRepo.GetByID(id)
+// checkProductType проверяет, что тип продукции существует и не выведен из обращения
+func (uc *ProductUseCase) checkProductType(productTypeID int) error {
+	productType, err := uc.productRepo.GetProductTypeByID(productTypeID)
+	if err != nil {
+		return fmt.Errorf("тип продукции не найден: %w", err)
+	}
+	if productType.IsArchived() {
+		return entities.NewBusinessError("ARCHIVED_PRODUCT_TYPE",
+			fmt.Sprintf("тип продукции %s выведен из обращения", productType.Name))
+	}
+	return nil
+}
+
+// CloneProduct копирует продукцию вместе с рецептурой под новым артикулом и возвращает копию
+// с рассчитанной себестоимостью и ценой. Если копия создана, а себестоимость рассчитать
+// не удалось, копия возвращается вместе с CostRecalculationError
+func (uc *ProductUseCase) CloneProduct(id int, request entities.ProductCloneRequest) (*entities.Product, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("ошибка валидации: %w", err)
+	}
+
+	source, err := uc.productRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("продукция не найдена: %w", err)
+	}
+
+	existing, err := uc.productRepo.GetByArticles([]string{request.Article})
+	if err != nil {
+		return nil, fmt.Errorf("ошибка проверки артикула: %w", err)
+	}
+	if len(existing) > 0 {
+		return nil, entities.NewBusinessError("DUPLICATE_ARTICLE",
+			fmt.Sprintf("продукция с артикулом %s уже существует", request.Article))
+	}
+
+	clone, err := source.Clone(request)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка валидации: %w", err)
+	}
+
+	if err := uc.productRepo.CreateWithRecipe(clone); err != nil {
+		return nil, err
+	}
+
+	recalcErr := uc.recalculateStoredCost(clone.ID)
+
+	created, err := uc.GetProductByID(clone.ID)
+	if err != nil {
+		return nil, err
+	}
+	if recalcErr != nil {
+		return created, entities.NewCostRecalculationError("продукция скопирована", recalcErr)
+	}
+	return created, nil
+}
+
+// ArchiveProduct переносит продукцию в архив вместо удаления:
+// заказы, история продаж и рецептуры сохраняются
+func (uc *ProductUseCase) ArchiveProduct(id int) error {
+	product, err := uc.productRepo.GetByID(id)
 	if err != nil {
 		return fmt.Errorf("продукция не найдена: %w", err)
 	}
+	if product.IsArchived() {
+		return entities.NewBusinessError("ALREADY_ARCHIVED", "продукция уже находится в архиве")
+	}
 
-	return uc.productRepo.Delete(id)
+	return uc.productRepo.Archive(id, time.Now())
+}
+
+// RestoreProduct возвращает продукцию из архива
+func (uc *ProductUseCase) RestoreProduct(id int) error {
+	product, err := uc.productRepo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("продукция не найдена: %w", err)
+	}
+	if !product.IsArchived() {
+		return entities.NewBusinessError("NOT_ARCHIVED", "продукция не находится в архиве")
+	}
+
+	return uc.productRepo.Restore(id)
 }
 
 // GetProductTypes возвращает все типы продукции
@@ -115,8 +335,41 @@ func (uc *ProductUseCase) GetProductTypes() ([]entities.ProductType, error) {
 	return uc.productRepo.GetProductTypes()
 }
 
-// calculateProductPrice рассчитывает стоимость продукции
-func (uc *ProductUseCase) calculateProductPrice(product *entities.Product) (float64, error) {
+// calculateProductPrice рассчитывает стоимость продукции по подходящему правилу ценообразования.
+// Если ни одно правило не подходит, применяется базовая наценка.
+func (uc *ProductUseCase) calculateProductPrice(
+	product *entities.Product,
+	rules []entities.PricingRule,
+	partnerTypeID *int,
+	date time.Time,
+) (*entities.PriceCalculation, error) {
+	cost, err := uc.rollUpCost(product)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := entities.SelectPricingRule(rules, entities.PricingContext{
+		ProductTypeID: product.ProductTypeID,
+		PartnerTypeID: partnerTypeID,
+		Date:          date,
+	})
+	if rule == nil {
+		rule = entities.DefaultPricingRule()
+	}
+
+	calculation := &entities.PriceCalculation{
+		Cost: math.Round(cost*100) / 100,
+		Rule: rule,
+	}
+	if cost > 0 {
+		calculation.Price = rule.Apply(cost)
+	}
+
+	return calculation, nil
+}
+
+// rollUpCost догружает тип, материалы и дерево полуфабрикатов и рассчитывает себестоимость по рецептуре
+func (uc *ProductUseCase) rollUpCost(product *entities.Product) (float64, error) {
 	// Получаем тип продукции
 	if product.ProductType == nil {
 		productType, err := uc.productRepo.GetProductTypeByID(product.ProductTypeID)
@@ -126,8 +379,8 @@ func (uc *ProductUseCase) calculateProductPrice(product *entities.Product) (floa
 		product.ProductType = productType
 	}
 
-	// Получаем материалы если их нет
-	if len(product.Materials) == 0 {
+	// Получаем материалы, если рецептура не загружена
+	if product.Materials == nil {
 		materials, err := uc.productRepo.GetMaterialsForProduct(product.ID)
 		if err != nil {
 			return 0, fmt.Errorf("ошибка получения материалов: %w", err)
@@ -135,9 +388,387 @@ func (uc *ProductUseCase) calculateProductPrice(product *entities.Product) (floa
 		product.Materials = materials
 	}
 
+	// Загружаем дерево полуфабрикатов
+	if err := uc.loadComponentTree(product, make(map[int]bool)); err != nil {
+		return 0, err
+	}
+
 	// Используем доменную логику для расчета
-	price := product.CalculatePrice()
+	return product.CalculateCost()
+}
+
+// RecalculateCost пересчитывает по рецептуре и сохраняет себестоимость продукции
+func (uc *ProductUseCase) RecalculateCost(productID int) (*entities.Product, error) {
+	if err := uc.recalculateStoredCost(productID); err != nil {
+		return nil, err
+	}
+
+	return uc.GetProductByID(productID)
+}
+
+// RecalculateCostsForMaterial пересчитывает себестоимость всей продукции, использующей материал
+// напрямую или через полуфабрикаты. Возвращает количество пересчитанной продукции.
+func (uc *ProductUseCase) RecalculateCostsForMaterial(materialID int) (int, error) {
+	productIDs, err := uc.productRepo.GetProductIDsUsingMaterial(materialID)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка поиска продукции по материалу: %w", err)
+	}
+
+	return uc.recalculateStoredCosts(productIDs)
+}
+
+// RecalculateCostsForProductType пересчитывает себестоимость продукции типа после изменения
+// коэффициента, а также продукции, в которую она входит полуфабрикатом.
+// Возвращает количество пересчитанной продукции.
+func (uc *ProductUseCase) RecalculateCostsForProductType(productTypeID int) (int, error) {
+	productIDs, err := uc.productRepo.GetProductIDsOfType(productTypeID)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка поиска продукции по типу: %w", err)
+	}
+
+	return uc.recalculateStoredCosts(productIDs)
+}
+
+// RecalculateAllCosts пересчитывает себестоимость всей продукции.
+// Возвращает количество пересчитанной продукции.
+func (uc *ProductUseCase) RecalculateAllCosts() (int, error) {
+	productIDs, err := uc.productRepo.GetAllProductIDs()
+	if err != nil {
+		return 0, fmt.Errorf("ошибка получения списка продукции: %w", err)
+	}
+
+	return uc.recalculateStoredCosts(productIDs)
+}
+
+// afterRecipeChange пересчитывает себестоимость после изменения рецептуры и возвращает продукцию.
+// Рецептура уже сохранена, поэтому ошибка пересчета возвращается вместе с продукцией
+// как CostRecalculationError
+func (uc *ProductUseCase) afterRecipeChange(productID int) (*entities.Product, error) {
+	recalcErr := uc.refreshStoredCosts(productID)
+
+	product, err := uc.GetProductByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if recalcErr != nil {
+		return product, entities.NewCostRecalculationError("рецептура изменена", recalcErr)
+	}
+	return product, nil
+}
+
+// refreshStoredCosts пересчитывает себестоимость продукции после изменения ее рецептуры,
+// а также всей продукции, в которую она входит полуфабрикатом
+func (uc *ProductUseCase) refreshStoredCosts(productID int) error {
+	parentIDs, err := uc.productRepo.GetProductIDsUsingProduct(productID)
+	if err != nil {
+		return fmt.Errorf("ошибка поиска продукции по полуфабрикату: %w", err)
+	}
+
+	_, err = uc.recalculateStoredCosts(append([]int{productID}, parentIDs...))
+	return err
+}
+
+// recalculateStoredCosts пересчитывает и сохраняет себестоимость списка продукции. Ошибка
+// одной продукции не останавливает пересчет остальных: возвращается количество пересчитанной
+// продукции и ошибки всей продукции, которую пересчитать не удалось
+func (uc *ProductUseCase) recalculateStoredCosts(productIDs []int) (int, error) {
+	var failures []error
+	for _, productID := range productIDs {
+		if err := uc.recalculateStoredCost(productID); err != nil {
+			failures = append(failures, err)
+		}
+	}
+
+	return len(productIDs) - len(failures), joinErrors(failures)
+}
+
+// joinErrors объединяет ошибки в одну; без ошибок возвращает nil
+func joinErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	return multiError(errs)
+}
+
+// multiError - несколько ошибок с сообщениями через точку с запятой; errors.Is и errors.As
+// проверяют каждую из них
+type multiError []error
+
+func (e multiError) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e multiError) Unwrap() []error {
+	return e
+}
+
+// recalculateStoredCost пересчитывает и сохраняет себестоимость одной продукции
+func (uc *ProductUseCase) recalculateStoredCost(productID int) error {
+	product, err := uc.productRepo.GetByID(productID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения продукции: %w", err)
+	}
+
+	cost, err := uc.rollUpCost(product)
+	if err != nil {
+		return fmt.Errorf("ошибка расчета себестоимости продукции %s: %w", product.Article, err)
+	}
+
+	if err := uc.productRepo.UpdateCalculatedCost(productID, math.Round(cost*100)/100, time.Now()); err != nil {
+		return fmt.Errorf("ошибка сохранения себестоимости продукции %s: %w", product.Article, err)
+	}
+
+	return nil
+}
+
+// loadComponentTree рекурсивно загружает рецептуры полуфабрикатов и проверяет отсутствие циклов
+func (uc *ProductUseCase) loadComponentTree(product *entities.Product, path map[int]bool) error {
+	path[product.ID] = true
+	defer delete(path, product.ID)
+
+	for i := range product.Components {
+		pc := &product.Components[i]
+		if path[pc.ComponentProductID] {
+			return entities.NewBOMCycleError(pc.ComponentProductID)
+		}
+
+		component, err := uc.productRepo.GetByID(pc.ComponentProductID)
+		if err != nil {
+			return fmt.Errorf("ошибка получения полуфабриката: %w", err)
+		}
+		pc.Component = component
+
+		if err := uc.loadComponentTree(component, path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetProductMaterials возвращает рецептуру продукции
+func (uc *ProductUseCase) GetProductMaterials(productID int) ([]entities.ProductMaterial, error) {
+	if _, err := uc.productRepo.GetByID(productID); err != nil {
+		return nil, fmt.Errorf("продукция не найдена: %w", err)
+	}
+
+	return uc.productRepo.GetMaterialsForProduct(productID)
+}
+
+// AddProductMaterial добавляет материал в рецептуру и возвращает продукцию с пересчитанной ценой
+func (uc *ProductUseCase) AddProductMaterial(productID int, productMaterial *entities.ProductMaterial) (*entities.Product, error) {
+	productMaterial.ProductID = productID
+	if err := productMaterial.Validate(); err != nil {
+		return nil, fmt.Errorf("ошибка валидации: %w", err)
+	}
+
+	product, err := uc.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("продукция не найдена: %w", err)
+	}
+
+	for _, existing := range product.Materials {
+		if existing.MaterialID == productMaterial.MaterialID {
+			return nil, entities.NewBusinessError("DUPLICATE_MATERIAL",
+				fmt.Sprintf("материал с ID %d уже есть в рецептуре", productMaterial.MaterialID))
+		}
+	}
+
+	material, err := uc.materialRepo.GetByID(productMaterial.MaterialID)
+	if err != nil {
+		return nil, fmt.Errorf("материал не найден: %w", err)
+	}
+	if material.IsArchived() {
+		return nil, newArchivedMaterialError(material)
+	}
+
+	if err := uc.productRepo.AddProductMaterial(productMaterial); err != nil {
+		return nil, err
+	}
+
+	return uc.afterRecipeChange(productID)
+}
+
+// UpdateProductMaterial изменяет расход материала в рецептуре и возвращает продукцию с пересчитанной ценой
+func (uc *ProductUseCase) UpdateProductMaterial(productID int, productMaterial *entities.ProductMaterial) (*entities.Product, error) {
+	productMaterial.ProductID = productID
+	if err := productMaterial.Validate(); err != nil {
+		return nil, fmt.Errorf("ошибка валидации: %w", err)
+	}
+
+	if _, err := uc.productRepo.GetByID(productID); err != nil {
+		return nil, fmt.Errorf("продукция не найдена: %w", err)
+	}
+
+	if err := uc.productRepo.UpdateProductMaterial(productMaterial); err != nil {
+		return nil, err
+	}
+
+	return uc.afterRecipeChange(productID)
+}
+
+// RemoveProductMaterial удаляет материал из рецептуры и возвращает продукцию с пересчитанной ценой
+func (uc *ProductUseCase) RemoveProductMaterial(productID, materialID int) (*entities.Product, error) {
+	if _, err := uc.productRepo.GetByID(productID); err != nil {
+		return nil, fmt.Errorf("продукция не найдена: %w", err)
+	}
+
+	if err := uc.productRepo.RemoveProductMaterial(productID, materialID); err != nil {
+		return nil, err
+	}
+
+	return uc.afterRecipeChange(productID)
+}
+
+// ReplaceProductMaterials заменяет всю рецептуру продукции и возвращает продукцию с пересчитанной ценой
+func (uc *ProductUseCase) ReplaceProductMaterials(productID int, materials []entities.ProductMaterial) (*entities.Product, error) {
+	if err := entities.ValidateRecipe(materials); err != nil {
+		return nil, fmt.Errorf("ошибка валидации: %w", err)
+	}
+
+	product, err := uc.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("продукция не найдена: %w", err)
+	}
+
+	// Архивные материалы можно оставить в рецептуре, но нельзя добавить заново
+	current := make(map[int]bool, len(product.Materials))
+	for _, pm := range product.Materials {
+		current[pm.MaterialID] = true
+	}
+
+	for _, pm := range materials {
+		material, err := uc.materialRepo.GetByID(pm.MaterialID)
+		if err != nil {
+			return nil, fmt.Errorf("материал не найден: %w", err)
+		}
+		if material.IsArchived() && !current[pm.MaterialID] {
+			return nil, newArchivedMaterialError(material)
+		}
+	}
+
+	if err := uc.productRepo.ReplaceProductMaterials(productID, materials); err != nil {
+		return nil, err
+	}
+
+	return uc.afterRecipeChange(productID)
+}
+
+// GetProductComponents возвращает полуфабрикаты, входящие в рецептуру продукции
+func (uc *ProductUseCase) GetProductComponents(productID int) ([]entities.ProductComponent, error) {
+	product, err := uc.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("продукция не найдена: %w", err)
+	}
+
+	if err := uc.loadComponentTree(product, make(map[int]bool)); err != nil {
+		return nil, err
+	}
+
+	return product.Components, nil
+}
+
+// AddProductComponent добавляет полуфабрикат в рецептуру и возвращает продукцию с пересчитанной ценой
+func (uc *ProductUseCase) AddProductComponent(productID int, component *entities.ProductComponent) (*entities.Product, error) {
+	component.ProductID = productID
+	if err := component.Validate(); err != nil {
+		return nil, fmt.Errorf("ошибка валидации: %w", err)
+	}
+
+	product, err := uc.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("продукция не найдена: %w", err)
+	}
+
+	for _, existing := range product.Components {
+		if existing.ComponentProductID == component.ComponentProductID {
+			return nil, entities.NewBusinessError("DUPLICATE_COMPONENT",
+				fmt.Sprintf("полуфабрикат с ID %d уже есть в рецептуре", component.ComponentProductID))
+		}
+	}
+
+	// Полуфабрикат не должен сам (прямо или косвенно) содержать эту продукцию
+	componentProduct, err := uc.productRepo.GetByID(component.ComponentProductID)
+	if err != nil {
+		return nil, fmt.Errorf("полуфабрикат не найден: %w", err)
+	}
+	if componentProduct.IsArchived() {
+		return nil, entities.NewBusinessError("ARCHIVED_PRODUCT",
+			fmt.Sprintf("продукция %s находится в архиве и не может быть добавлена как полуфабрикат", componentProduct.Article))
+	}
+	if err := uc.loadComponentTree(componentProduct, make(map[int]bool)); err != nil {
+		return nil, err
+	}
+	if componentProduct.UsesProduct(productID) {
+		return nil, entities.NewBOMCycleError(productID)
+	}
+
+	if err := uc.productRepo.AddProductComponent(component); err != nil {
+		return nil, err
+	}
+
+	return uc.afterRecipeChange(productID)
+}
+
+// UpdateProductComponent изменяет расход полуфабриката и возвращает продукцию с пересчитанной ценой
+func (uc *ProductUseCase) UpdateProductComponent(productID int, component *entities.ProductComponent) (*entities.Product, error) {
+	component.ProductID = productID
+	if err := component.Validate(); err != nil {
+		return nil, fmt.Errorf("ошибка валидации: %w", err)
+	}
+
+	if _, err := uc.productRepo.GetByID(productID); err != nil {
+		return nil, fmt.Errorf("продукция не найдена: %w", err)
+	}
+
+	if err := uc.productRepo.UpdateProductComponent(component); err != nil {
+		return nil, err
+	}
+
+	return uc.afterRecipeChange(productID)
+}
+
+// RemoveProductComponent удаляет полуфабрикат из рецептуры и возвращает продукцию с пересчитанной ценой
+func (uc *ProductUseCase) RemoveProductComponent(productID, componentProductID int) (*entities.Product, error) {
+	if _, err := uc.productRepo.GetByID(productID); err != nil {
+		return nil, fmt.Errorf("продукция не найдена: %w", err)
+	}
+
+	if err := uc.productRepo.RemoveProductComponent(productID, componentProductID); err != nil {
+		return nil, err
+	}
+
+	return uc.afterRecipeChange(productID)
+}
+
+// ExplodeMaterials раскладывает многоуровневую рецептуру продукции до сырья на заданное количество
+func (uc *ProductUseCase) ExplodeMaterials(productID int, quantity float64) ([]entities.MaterialRequirement, error) {
+	product, err := uc.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("продукция не найдена: %w", err)
+	}
+
+	return uc.ExplodeMaterialsFor(product, quantity)
+}
+
+// ExplodeMaterialsFor раскладывает до сырья рецептуру уже загруженной продукции
+func (uc *ProductUseCase) ExplodeMaterialsFor(product *entities.Product, quantity float64) ([]entities.MaterialRequirement, error) {
+	if err := uc.loadComponentTree(product, make(map[int]bool)); err != nil {
+		return nil, err
+	}
+
+	return product.ExplodeMaterials(quantity)
+}
 
-	// Округляем до 2 знаков после запятой
-	return math.Round(price*100) / 100, nil
+// newArchivedMaterialError сообщает о попытке добавить в рецептуру архивный материал
+func newArchivedMaterialError(material *entities.Material) error {
+	return entities.NewBusinessError("ARCHIVED_MATERIAL",
+		fmt.Sprintf("материал %s находится в архиве и не может быть добавлен в рецептуру", material.Article))
 }
@@ -1,26 +1,35 @@
 package usecases
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"wallpaper-system/internal/domain/entities"
 	"wallpaper-system/internal/domain/mocks"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
 type ProductUseCaseTestSuite struct {
 	suite.Suite
-	productRepo  *mocks.MockProductRepository
-	materialRepo *mocks.MockMaterialRepository
-	useCase      *ProductUseCase
+	productRepo     *mocks.MockProductRepository
+	materialRepo    *mocks.MockMaterialRepository
+	pricingRuleRepo *mocks.MockPricingRuleRepository
+	useCase         *ProductUseCase
 }
 
 func (suite *ProductUseCaseTestSuite) SetupTest() {
 	suite.productRepo = new(mocks.MockProductRepository)
 	suite.materialRepo = new(mocks.MockMaterialRepository)
-	suite.useCase = NewProductUseCase(suite.productRepo, suite.materialRepo)
+	suite.pricingRuleRepo = new(mocks.MockPricingRuleRepository)
+	suite.useCase = NewProductUseCase(suite.productRepo, suite.materialRepo, suite.pricingRuleRepo)
+
+	// По умолчанию правил нет - применяется базовая наценка
+	suite.pricingRuleRepo.On("GetActiveRules", mock.Anything).Return([]entities.PricingRule{}, nil).Maybe()
 }
 
 func (suite *ProductUseCaseTestSuite) TestGetAllProducts() {
@@ -67,6 +76,59 @@ func (suite *ProductUseCaseTestSuite) TestGetAllProducts() {
 	suite.productRepo.AssertExpectations(suite.T())
 }
 
+func (suite *ProductUseCaseTestSuite) TestFindProducts_NormalizesCriteria() {
+	// Подготовка данных
+	products := []entities.Product{
+		{
+			ID:          1,
+			Article:     "ART001",
+			ProductType: &entities.ProductType{ID: 1, Name: "Винил", Coefficient: 1.0},
+			Materials: []entities.ProductMaterial{
+				{ID: 1, QuantityPerUnit: 1.0, Material: &entities.Material{ID: 1, CostPerUnit: 100.0}},
+			},
+		},
+	}
+	expected := entities.ProductCriteria{
+		ArticlePrefix: "ART",
+		SortBy:        entities.ProductSortCreatedAt,
+		SortDirection: entities.SortDesc,
+		Pagination:    entities.Pagination{Page: 1, PageSize: entities.MaxPageSize},
+	}
+
+	// Настройка моков
+	suite.productRepo.On("FindByCriteria", expected).Return(products, 41, nil)
+
+	// Выполнение
+	result, err := suite.useCase.FindProducts(entities.ProductCriteria{
+		ArticlePrefix: "ART",
+		Pagination:    entities.Pagination{PageSize: 500},
+	})
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Items, 1)
+	assert.Equal(suite.T(), 41, result.Total)
+	assert.Equal(suite.T(), 1, result.TotalPages())
+	assert.NotNil(suite.T(), result.Items[0].CalculatedPrice)
+	assert.Equal(suite.T(), 120.0, *result.Items[0].CalculatedPrice)
+
+	suite.productRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ProductUseCaseTestSuite) TestFindProducts_InvalidRange() {
+	// Выполнение
+	minPrice, maxPrice := 500.0, 100.0
+	result, err := suite.useCase.FindProducts(entities.ProductCriteria{
+		MinPrice: &minPrice,
+		MaxPrice: &maxPrice,
+	})
+
+	// Проверки
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), result)
+	suite.productRepo.AssertNotCalled(suite.T(), "FindByCriteria", mock.Anything)
+}
+
 func (suite *ProductUseCaseTestSuite) TestGetProductByID_Success() {
 	// Подготовка данных
 	productID := 1
@@ -154,6 +216,30 @@ func (suite *ProductUseCaseTestSuite) TestCreateProduct_Success() {
 	suite.productRepo.AssertExpectations(suite.T())
 }
 
+func (suite *ProductUseCaseTestSuite) TestCreateProduct_ArchivedProductType() {
+	// Подготовка данных: тип продукции выведен из обращения
+	archivedAt := time.Now()
+	product := &entities.Product{
+		Article:         "ART002",
+		Name:            "Новые обои",
+		ProductTypeID:   1,
+		MinPartnerPrice: 150.0,
+	}
+
+	// Настройка моков
+	suite.productRepo.On("GetProductTypeByID", 1).
+		Return(&entities.ProductType{ID: 1, Name: "Винил", Coefficient: 1.5, ArchivedAt: &archivedAt}, nil)
+
+	// Выполнение
+	err := suite.useCase.CreateProduct(product)
+
+	// Проверки
+	var businessErr *entities.BusinessError
+	require.ErrorAs(suite.T(), err, &businessErr)
+	assert.Equal(suite.T(), "ARCHIVED_PRODUCT_TYPE", businessErr.Code)
+	suite.productRepo.AssertNotCalled(suite.T(), "Create", mock.Anything)
+}
+
 func (suite *ProductUseCaseTestSuite) TestCreateProduct_ValidationError() {
 	// Подготовка данных (невалидный продукт)
 	product := &entities.Product{
@@ -173,6 +259,119 @@ func (suite *ProductUseCaseTestSuite) TestCreateProduct_ValidationError() {
 	suite.productRepo.AssertNotCalled(suite.T(), "Create")
 }
 
+func (suite *ProductUseCaseTestSuite) TestCreateProduct_PackageWeightValidation() {
+	// Подготовка данных: вес с упаковкой меньше веса без нее
+	weightWithoutPackage, weightWithPackage := 1.6, 1.5
+	product := &entities.Product{
+		Article:              "ART002",
+		Name:                 "Новые обои",
+		ProductTypeID:        1,
+		MinPartnerPrice:      150.0,
+		WeightWithoutPackage: &weightWithoutPackage,
+		WeightWithPackage:    &weightWithPackage,
+	}
+
+	// Выполнение
+	err := suite.useCase.CreateProduct(product)
+
+	// Проверки
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "вес с упаковкой")
+	suite.productRepo.AssertNotCalled(suite.T(), "Create")
+}
+
+func (suite *ProductUseCaseTestSuite) TestCloneProduct_Success() {
+	// Подготовка данных
+	material := &entities.Material{ID: 3, CostPerUnit: 100.0}
+	productType := &entities.ProductType{ID: 1, Coefficient: 1.5}
+	source := &entities.Product{
+		ID:            1,
+		Article:       "ART001",
+		Name:          "Обои с розами",
+		ProductTypeID: 1,
+		ProductType:   productType,
+		Materials:     []entities.ProductMaterial{{ID: 10, ProductID: 1, MaterialID: 3, QuantityPerUnit: 2.0, Material: material}},
+	}
+	stored := &entities.Product{
+		ID:            5,
+		Article:       "ART002",
+		Name:          "Обои с лилиями",
+		ProductTypeID: 1,
+		ProductType:   productType,
+		Materials:     []entities.ProductMaterial{{ID: 11, ProductID: 5, MaterialID: 3, QuantityPerUnit: 2.2, Material: material}},
+	}
+
+	// Настройка моков
+	suite.productRepo.On("GetByID", 1).Return(source, nil)
+	suite.productRepo.On("GetByArticles", []string{"ART002"}).Return([]entities.Product{}, nil)
+	suite.productRepo.On("CreateWithRecipe", mock.MatchedBy(func(product *entities.Product) bool {
+		return product.ID == 0 && product.Article == "ART002" && product.Name == "Обои с лилиями" &&
+			len(product.Materials) == 1 && product.Materials[0].QuantityPerUnit == 2.2
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*entities.Product).ID = 5
+	}).Return(nil)
+	suite.productRepo.On("GetByID", 5).Return(stored, nil)
+	suite.productRepo.On("UpdateCalculatedCost", 5, 330.0, mock.Anything).Return(nil) // 2.2 * 100 * 1.5
+
+	// Выполнение
+	clone, err := suite.useCase.CloneProduct(1, entities.ProductCloneRequest{
+		Article: "ART002", Name: "Обои с лилиями", QuantityChangePercent: 10,
+	})
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 5, clone.ID)
+	suite.productRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ProductUseCaseTestSuite) TestCloneProduct_RecalculationErrorKeepsClone() {
+	// Подготовка данных
+	source := &entities.Product{ID: 1, Article: "ART001", Name: "Обои с розами", ProductTypeID: 1}
+	stored := &entities.Product{
+		ID:            5,
+		Article:       "ART002",
+		Name:          "Обои с лилиями",
+		ProductTypeID: 1,
+		ProductType:   &entities.ProductType{ID: 1, Coefficient: 1.0},
+		Materials:     []entities.ProductMaterial{},
+	}
+
+	// Настройка моков
+	suite.productRepo.On("GetByID", 1).Return(source, nil)
+	suite.productRepo.On("GetByArticles", []string{"ART002"}).Return([]entities.Product{}, nil)
+	suite.productRepo.On("CreateWithRecipe", mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*entities.Product).ID = 5
+	}).Return(nil)
+	suite.productRepo.On("GetByID", 5).Return(stored, nil)
+	suite.productRepo.On("UpdateCalculatedCost", 5, 0.0, mock.Anything).Return(errors.New("ошибка базы данных"))
+
+	// Выполнение
+	clone, err := suite.useCase.CloneProduct(1, entities.ProductCloneRequest{Article: "ART002", Name: "Обои с лилиями"})
+
+	// Проверки: копия создана и возвращается, повторное копирование не требуется
+	var recalcErr *entities.CostRecalculationError
+	require.ErrorAs(suite.T(), err, &recalcErr)
+	assert.Contains(suite.T(), err.Error(), "продукция скопирована, но себестоимость продукции не пересчитана")
+	require.NotNil(suite.T(), clone)
+	assert.Equal(suite.T(), 5, clone.ID)
+}
+
+func (suite *ProductUseCaseTestSuite) TestCloneProduct_DuplicateArticle() {
+	// Настройка моков
+	suite.productRepo.On("GetByID", 1).Return(&entities.Product{ID: 1, Article: "ART001", Name: "Обои"}, nil)
+	suite.productRepo.On("GetByArticles", []string{"ART002"}).Return([]entities.Product{{ID: 2, Article: "ART002"}}, nil)
+
+	// Выполнение
+	clone, err := suite.useCase.CloneProduct(1, entities.ProductCloneRequest{Article: "ART002"})
+
+	// Проверки
+	assert.Nil(suite.T(), clone)
+	var businessErr *entities.BusinessError
+	assert.ErrorAs(suite.T(), err, &businessErr)
+	assert.Equal(suite.T(), "DUPLICATE_ARTICLE", businessErr.Code)
+	suite.productRepo.AssertNotCalled(suite.T(), "CreateWithRecipe", mock.Anything)
+}
+
 func (suite *ProductUseCaseTestSuite) TestUpdateProduct_Success() {
 	// Подготовка данных - изменяем тип продукции чтобы вызвался GetProductTypeByID
 	product := &entities.Product{
@@ -197,38 +396,73 @@ func (suite *ProductUseCaseTestSuite) TestUpdateProduct_Success() {
 		Coefficient: 1.2,
 	}
 
+	// После сохранения продукция читается с новым типом для пересчета себестоимости
+	updatedProduct := &entities.Product{
+		ID:            1,
+		Article:       "ART001",
+		ProductTypeID: 2,
+		ProductType:   productType,
+		Materials: []entities.ProductMaterial{
+			{MaterialID: 1, QuantityPerUnit: 1.0, Material: &entities.Material{ID: 1, CostPerUnit: 100.0}},
+		},
+	}
+
 	// Настройка моков
-	suite.productRepo.On("GetByID", 1).Return(existingProduct, nil)
+	suite.productRepo.On("GetByID", 1).Return(existingProduct, nil).Once()
+	suite.productRepo.On("GetByID", 1).Return(updatedProduct, nil)
 	suite.productRepo.On("GetProductTypeByID", 2).Return(productType, nil)
 	suite.productRepo.On("Update", product).Return(nil)
+	suite.productRepo.On("GetProductIDsUsingProduct", 1).Return([]int{}, nil)
+	suite.productRepo.On("UpdateCalculatedCost", 1, 120.0, mock.Anything).Return(nil)
 
 	// Выполнение
 	err := suite.useCase.UpdateProduct(product)
 
-	// Проверки
+	// Проверки: себестоимость пересчитана с коэффициентом нового типа
 	assert.NoError(suite.T(), err)
 
 	suite.productRepo.AssertExpectations(suite.T())
 }
 
-func (suite *ProductUseCaseTestSuite) TestDeleteProduct_Success() {
+func (suite *ProductUseCaseTestSuite) TestUpdateProduct_TypeChangeRecalculationError() {
+	// Подготовка данных
+	product := &entities.Product{ID: 1, Article: "ART001", Name: "Обои", ProductTypeID: 2}
+	existingProduct := &entities.Product{ID: 1, Article: "ART001", Name: "Обои", ProductTypeID: 1}
+
+	// Настройка моков
+	suite.productRepo.On("GetByID", 1).Return(existingProduct, nil)
+	suite.productRepo.On("GetProductTypeByID", 2).Return(&entities.ProductType{ID: 2, Coefficient: 1.2}, nil)
+	suite.productRepo.On("Update", product).Return(nil)
+	suite.productRepo.On("GetProductIDsUsingProduct", 1).Return([]int{}, errors.New("ошибка базы данных"))
+
+	// Выполнение
+	err := suite.useCase.UpdateProduct(product)
+
+	// Проверки: продукция сохранена, ошибка пересчета отделена от ошибок изменения
+	var recalcErr *entities.CostRecalculationError
+	require.ErrorAs(suite.T(), err, &recalcErr)
+	assert.Contains(suite.T(), err.Error(), "продукция обновлена, но себестоимость продукции не пересчитана")
+	suite.productRepo.AssertCalled(suite.T(), "Update", product)
+}
+
+func (suite *ProductUseCaseTestSuite) TestArchiveProduct_Success() {
 	// Подготовка данных
 	productID := 1
 
 	existingProduct := &entities.Product{
 		ID:              1,
 		Article:         "ART001",
-		Name:            "Удаляемые обои",
+		Name:            "Архивируемые обои",
 		ProductTypeID:   1,
 		MinPartnerPrice: 150.0,
 	}
 
 	// Настройка моков
 	suite.productRepo.On("GetByID", productID).Return(existingProduct, nil)
-	suite.productRepo.On("Delete", productID).Return(nil)
+	suite.productRepo.On("Archive", productID, mock.AnythingOfType("time.Time")).Return(nil)
 
 	// Выполнение
-	err := suite.useCase.DeleteProduct(productID)
+	err := suite.useCase.ArchiveProduct(productID)
 
 	// Проверки
 	assert.NoError(suite.T(), err)
@@ -236,6 +470,33 @@ func (suite *ProductUseCaseTestSuite) TestDeleteProduct_Success() {
 	suite.productRepo.AssertExpectations(suite.T())
 }
 
+func (suite *ProductUseCaseTestSuite) TestArchiveProduct_AlreadyArchived() {
+	// Подготовка данных
+	archivedAt := time.Date(2026, time.January, 10, 0, 0, 0, 0, time.UTC)
+	suite.productRepo.On("GetByID", 1).Return(&entities.Product{ID: 1, ArchivedAt: &archivedAt}, nil)
+
+	// Выполнение
+	err := suite.useCase.ArchiveProduct(1)
+
+	// Проверки
+	assert.Error(suite.T(), err)
+	suite.productRepo.AssertNotCalled(suite.T(), "Archive", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductUseCaseTestSuite) TestRestoreProduct() {
+	// Подготовка данных
+	archivedAt := time.Date(2026, time.January, 10, 0, 0, 0, 0, time.UTC)
+	suite.productRepo.On("GetByID", 1).Return(&entities.Product{ID: 1, ArchivedAt: &archivedAt}, nil)
+	suite.productRepo.On("GetByID", 2).Return(&entities.Product{ID: 2}, nil)
+	suite.productRepo.On("Restore", 1).Return(nil)
+
+	// Выполнение и проверки
+	assert.NoError(suite.T(), suite.useCase.RestoreProduct(1))
+	assert.Error(suite.T(), suite.useCase.RestoreProduct(2), "продукцию вне архива восстанавливать нельзя")
+
+	suite.productRepo.AssertNotCalled(suite.T(), "Restore", 2)
+}
+
 func (suite *ProductUseCaseTestSuite) TestGetProductTypes() {
 	// Подготовка данных
 	productTypes := []entities.ProductType{
@@ -266,6 +527,391 @@ func (suite *ProductUseCaseTestSuite) TestGetProductTypes() {
 	suite.productRepo.AssertExpectations(suite.T())
 }
 
+func (suite *ProductUseCaseTestSuite) TestAddProductMaterial_Success() {
+	// Подготовка данных
+	product := &entities.Product{
+		ID:            1,
+		Article:       "ART001",
+		Name:          "Обои винил",
+		ProductTypeID: 1,
+		ProductType:   &entities.ProductType{ID: 1, Coefficient: 1.0},
+		Materials: []entities.ProductMaterial{
+			{MaterialID: 1, QuantityPerUnit: 1.0, Material: &entities.Material{ID: 1, CostPerUnit: 100.0}},
+		},
+	}
+	productMaterial := &entities.ProductMaterial{MaterialID: 2, QuantityPerUnit: 0.5}
+
+	// Настройка моков
+	suite.productRepo.On("GetByID", 1).Return(product, nil)
+	suite.materialRepo.On("GetByID", 2).Return(&entities.Material{ID: 2, CostPerUnit: 200.0}, nil)
+	suite.productRepo.On("AddProductMaterial", productMaterial).Return(nil)
+	suite.productRepo.On("GetProductIDsUsingProduct", 1).Return([]int{}, nil)
+	suite.productRepo.On("UpdateCalculatedCost", 1, 100.0, mock.Anything).Return(nil)
+
+	// Выполнение
+	result, err := suite.useCase.AddProductMaterial(1, productMaterial)
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), result)
+	assert.Equal(suite.T(), 1, productMaterial.ProductID)
+
+	suite.productRepo.AssertExpectations(suite.T())
+	suite.materialRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ProductUseCaseTestSuite) TestAddProductMaterial_ArchivedMaterial() {
+	// Подготовка данных
+	archivedAt := time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)
+	productMaterial := &entities.ProductMaterial{MaterialID: 2, QuantityPerUnit: 0.5}
+
+	// Настройка моков
+	suite.productRepo.On("GetByID", 1).Return(&entities.Product{ID: 1}, nil)
+	suite.materialRepo.On("GetByID", 2).Return(&entities.Material{ID: 2, Article: "MAT002", ArchivedAt: &archivedAt}, nil)
+
+	// Выполнение
+	result, err := suite.useCase.AddProductMaterial(1, productMaterial)
+
+	// Проверки
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), result)
+	assert.Contains(suite.T(), err.Error(), "в архиве")
+	suite.productRepo.AssertNotCalled(suite.T(), "AddProductMaterial", mock.Anything)
+}
+
+func (suite *ProductUseCaseTestSuite) TestAddProductMaterial_Duplicate() {
+	// Подготовка данных
+	product := &entities.Product{
+		ID: 1,
+		Materials: []entities.ProductMaterial{
+			{MaterialID: 1, QuantityPerUnit: 1.0, Material: &entities.Material{ID: 1}},
+		},
+	}
+
+	// Настройка моков
+	suite.productRepo.On("GetByID", 1).Return(product, nil)
+
+	// Выполнение
+	result, err := suite.useCase.AddProductMaterial(1, &entities.ProductMaterial{MaterialID: 1, QuantityPerUnit: 2.0})
+
+	// Проверки
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), result)
+	assert.IsType(suite.T(), &entities.BusinessError{}, err)
+
+	suite.productRepo.AssertNotCalled(suite.T(), "AddProductMaterial")
+}
+
+func (suite *ProductUseCaseTestSuite) TestReplaceProductMaterials_DuplicateMaterials() {
+	// Подготовка данных
+	materials := []entities.ProductMaterial{
+		{MaterialID: 1, QuantityPerUnit: 1.0},
+		{MaterialID: 1, QuantityPerUnit: 2.0},
+	}
+
+	// Выполнение
+	result, err := suite.useCase.ReplaceProductMaterials(1, materials)
+
+	// Проверки
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), result)
+	assert.Contains(suite.T(), err.Error(), "валидации")
+
+	// Репозиторий не должен вызываться при ошибке валидации
+	suite.productRepo.AssertNotCalled(suite.T(), "ReplaceProductMaterials")
+}
+
+func (suite *ProductUseCaseTestSuite) TestReplaceProductMaterials_Success() {
+	// Подготовка данных
+	materials := []entities.ProductMaterial{
+		{MaterialID: 1, QuantityPerUnit: 2.0},
+		{MaterialID: 2, QuantityPerUnit: 0.5},
+	}
+	product := &entities.Product{
+		ID:          1,
+		ProductType: &entities.ProductType{ID: 1, Coefficient: 1.0},
+		Materials: []entities.ProductMaterial{
+			{MaterialID: 1, QuantityPerUnit: 2.0, Material: &entities.Material{ID: 1, CostPerUnit: 100.0}},
+			{MaterialID: 2, QuantityPerUnit: 0.5, Material: &entities.Material{ID: 2, CostPerUnit: 200.0}},
+		},
+	}
+
+	// Настройка моков
+	suite.productRepo.On("GetByID", 1).Return(product, nil)
+	suite.materialRepo.On("GetByID", 1).Return(&entities.Material{ID: 1}, nil)
+	suite.materialRepo.On("GetByID", 2).Return(&entities.Material{ID: 2}, nil)
+	suite.productRepo.On("ReplaceProductMaterials", 1, materials).Return(nil)
+	suite.productRepo.On("GetProductIDsUsingProduct", 1).Return([]int{}, nil)
+	suite.productRepo.On("UpdateCalculatedCost", 1, 300.0, mock.Anything).Return(nil)
+
+	// Выполнение
+	result, err := suite.useCase.ReplaceProductMaterials(1, materials)
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), result.CalculatedPrice)
+	assert.Equal(suite.T(), 360.0, *result.CalculatedPrice) // (2*100 + 0.5*200) * 1.0 * 1.2
+
+	suite.productRepo.AssertExpectations(suite.T())
+	suite.materialRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ProductUseCaseTestSuite) TestAddProductComponent_Cycle() {
+	// Подготовка данных: полуфабрикат 2 уже содержит продукцию 1
+	product := &entities.Product{ID: 1}
+	componentProduct := &entities.Product{
+		ID: 2,
+		Components: []entities.ProductComponent{
+			{ProductID: 2, ComponentProductID: 1, QuantityPerUnit: 1.0},
+		},
+	}
+
+	// Настройка моков
+	suite.productRepo.On("GetByID", 1).Return(product, nil)
+	suite.productRepo.On("GetByID", 2).Return(componentProduct, nil)
+
+	// Выполнение
+	result, err := suite.useCase.AddProductComponent(1, &entities.ProductComponent{ComponentProductID: 2, QuantityPerUnit: 2.0})
+
+	// Проверки
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), result)
+	var businessErr *entities.BusinessError
+	assert.ErrorAs(suite.T(), err, &businessErr)
+	assert.Equal(suite.T(), "BOM_CYCLE", businessErr.Code)
+
+	suite.productRepo.AssertNotCalled(suite.T(), "AddProductComponent")
+}
+
+func (suite *ProductUseCaseTestSuite) TestGetProductByID_WithComponents() {
+	// Подготовка данных
+	componentProduct := &entities.Product{
+		ID:          2,
+		ProductType: &entities.ProductType{ID: 2, Coefficient: 1.5},
+		Materials: []entities.ProductMaterial{
+			{MaterialID: 1, QuantityPerUnit: 2.0, Material: &entities.Material{ID: 1, CostPerUnit: 10.0}},
+		},
+	}
+	product := &entities.Product{
+		ID:          1,
+		ProductType: &entities.ProductType{ID: 1, Coefficient: 1.0},
+		Components: []entities.ProductComponent{
+			{ProductID: 1, ComponentProductID: 2, QuantityPerUnit: 4.0},
+		},
+	}
+
+	// Настройка моков
+	suite.productRepo.On("GetByID", 1).Return(product, nil)
+	suite.productRepo.On("GetByID", 2).Return(componentProduct, nil)
+	suite.productRepo.On("GetMaterialsForProduct", 1).Return([]entities.ProductMaterial{}, nil)
+
+	// Выполнение
+	result, err := suite.useCase.GetProductByID(1)
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), result.CalculatedPrice)
+	assert.Equal(suite.T(), 144.0, *result.CalculatedPrice) // 4 * (2*10*1.5) * 1.0 * 1.2
+
+	suite.productRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ProductUseCaseTestSuite) TestRecalculateCostsForMaterial() {
+	// Подготовка данных: материал 1 входит в полуфабрикат 2, а тот - в продукцию 3
+	material := &entities.Material{ID: 1, CostPerUnit: 50.0}
+	semiFinished := &entities.Product{
+		ID:          2,
+		Article:     "SEMI-001",
+		ProductType: &entities.ProductType{ID: 2, Coefficient: 1.0},
+		Materials: []entities.ProductMaterial{
+			{MaterialID: 1, QuantityPerUnit: 2.0, Material: material},
+		},
+	}
+	product := &entities.Product{
+		ID:          3,
+		Article:     "ART003",
+		ProductType: &entities.ProductType{ID: 1, Coefficient: 1.5},
+		Materials: []entities.ProductMaterial{
+			{MaterialID: 1, QuantityPerUnit: 1.0, Material: material},
+		},
+		Components: []entities.ProductComponent{
+			{ProductID: 3, ComponentProductID: 2, QuantityPerUnit: 2.0},
+		},
+	}
+
+	// Настройка моков
+	suite.productRepo.On("GetProductIDsUsingMaterial", 1).Return([]int{2, 3}, nil)
+	suite.productRepo.On("GetByID", 2).Return(semiFinished, nil)
+	suite.productRepo.On("GetByID", 3).Return(product, nil)
+	suite.productRepo.On("UpdateCalculatedCost", 2, 100.0, mock.Anything).Return(nil)
+	suite.productRepo.On("UpdateCalculatedCost", 3, 375.0, mock.Anything).Return(nil) // (50 + 2*100) * 1.5
+
+	// Выполнение
+	count, err := suite.useCase.RecalculateCostsForMaterial(1)
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, count)
+
+	suite.productRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ProductUseCaseTestSuite) TestRecalculateCostsForMaterial_ContinuesAfterFailure() {
+	// Подготовка данных: продукция 2 не загружается, продукция 3 пересчитывается
+	product := &entities.Product{
+		ID:          3,
+		Article:     "ART003",
+		ProductType: &entities.ProductType{ID: 1, Coefficient: 1.5},
+		Materials: []entities.ProductMaterial{
+			{MaterialID: 1, QuantityPerUnit: 2.0, Material: &entities.Material{ID: 1, CostPerUnit: 50.0}},
+		},
+	}
+
+	// Настройка моков
+	suite.productRepo.On("GetProductIDsUsingMaterial", 1).Return([]int{2, 3}, nil)
+	suite.productRepo.On("GetByID", 2).Return(nil, errors.New("database error"))
+	suite.productRepo.On("GetByID", 3).Return(product, nil)
+	suite.productRepo.On("UpdateCalculatedCost", 3, 150.0, mock.Anything).Return(nil)
+
+	// Выполнение
+	count, err := suite.useCase.RecalculateCostsForMaterial(1)
+
+	// Проверки: ошибка продукции 2 не помешала пересчету продукции 3
+	require.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "database error")
+	assert.Equal(suite.T(), 1, count)
+	suite.productRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ProductUseCaseTestSuite) TestRemoveProductComponent_RecalculatesParents() {
+	// Подготовка данных: продукция 2 входит полуфабрикатом в продукцию 5
+	product := &entities.Product{
+		ID:          2,
+		Article:     "SEMI-001",
+		ProductType: &entities.ProductType{ID: 2, Coefficient: 1.0},
+		Materials: []entities.ProductMaterial{
+			{MaterialID: 1, QuantityPerUnit: 1.0, Material: &entities.Material{ID: 1, CostPerUnit: 10.0}},
+		},
+	}
+	parent := &entities.Product{
+		ID:          5,
+		Article:     "ART005",
+		ProductType: &entities.ProductType{ID: 1, Coefficient: 1.0},
+		Materials: []entities.ProductMaterial{
+			{MaterialID: 1, QuantityPerUnit: 1.0, Material: &entities.Material{ID: 1, CostPerUnit: 10.0}},
+		},
+	}
+
+	// Настройка моков
+	suite.productRepo.On("GetByID", 2).Return(product, nil)
+	suite.productRepo.On("GetByID", 5).Return(parent, nil)
+	suite.productRepo.On("RemoveProductComponent", 2, 7).Return(nil)
+	suite.productRepo.On("GetProductIDsUsingProduct", 2).Return([]int{5}, nil)
+	suite.productRepo.On("UpdateCalculatedCost", 2, 10.0, mock.Anything).Return(nil)
+	suite.productRepo.On("UpdateCalculatedCost", 5, 10.0, mock.Anything).Return(nil)
+
+	// Выполнение
+	_, err := suite.useCase.RemoveProductComponent(2, 7)
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	suite.productRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ProductUseCaseTestSuite) TestUpdateProductMaterial_RecalculationErrorKeepsChange() {
+	// Подготовка данных
+	product := &entities.Product{
+		ID:          1,
+		Article:     "ART001",
+		ProductType: &entities.ProductType{ID: 1, Coefficient: 1.0},
+		Materials: []entities.ProductMaterial{
+			{MaterialID: 1, QuantityPerUnit: 2.0, Material: &entities.Material{ID: 1, CostPerUnit: 10.0}},
+		},
+	}
+	productMaterial := &entities.ProductMaterial{MaterialID: 1, QuantityPerUnit: 2.0}
+
+	// Настройка моков
+	suite.productRepo.On("GetByID", 1).Return(product, nil)
+	suite.productRepo.On("UpdateProductMaterial", productMaterial).Return(nil)
+	suite.productRepo.On("GetProductIDsUsingProduct", 1).Return([]int{}, nil)
+	suite.productRepo.On("UpdateCalculatedCost", 1, 20.0, mock.Anything).Return(errors.New("ошибка базы данных"))
+
+	// Выполнение
+	result, err := suite.useCase.UpdateProductMaterial(1, productMaterial)
+
+	// Проверки: рецептура сохранена, ошибка пересчета возвращается вместе с продукцией
+	var recalcErr *entities.CostRecalculationError
+	require.ErrorAs(suite.T(), err, &recalcErr)
+	assert.Contains(suite.T(), err.Error(), "рецептура изменена, но себестоимость продукции не пересчитана")
+	require.NotNil(suite.T(), result)
+	assert.Equal(suite.T(), 1, result.ID)
+	suite.productRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ProductUseCaseTestSuite) TestExportProducts_GroupsByTypeWithPrices() {
+	// Подготовка данных: выборка отсортирована по названию, группы - по названию типа
+	vinyl := &entities.ProductType{ID: 1, Name: "Виниловые обои", Coefficient: 1.0}
+	paper := &entities.ProductType{ID: 2, Name: "Бумажные обои", Coefficient: 1.0}
+	material := &entities.Material{ID: 1, CostPerUnit: 100.0}
+	products := []entities.Product{
+		{ID: 1, Article: "WP-001", Name: "Винил белый", ProductTypeID: 1, ProductType: vinyl, MinPartnerPrice: 150,
+			Materials: []entities.ProductMaterial{{MaterialID: 1, QuantityPerUnit: 2.0, Material: material}}},
+		{ID: 2, Article: "WP-002", Name: "Дуплекс", ProductTypeID: 2, ProductType: paper, MinPartnerPrice: 90,
+			Materials: []entities.ProductMaterial{{MaterialID: 1, QuantityPerUnit: 1.0, Material: material}}},
+	}
+
+	// Настройка моков
+	suite.productRepo.On("FindByCriteria", mock.MatchedBy(func(criteria entities.ProductCriteria) bool {
+		return criteria.SortBy == entities.ProductSortName && criteria.Pagination.PageSize == entities.MaxExportRows
+	})).Return(products, 2, nil)
+
+	// Выполнение
+	table, err := suite.useCase.ExportProducts(entities.ProductExportRequest{
+		Columns: []string{"article", "price"},
+	})
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []entities.ExportColumn{
+		{Key: "article", Title: "Артикул"},
+		{Key: "price", Title: "Цена", Numeric: true},
+	}, table.Columns)
+	assert.Len(suite.T(), table.Groups, 2)
+	assert.Equal(suite.T(), "Бумажные обои", table.Groups[0].Name)
+	assert.Equal(suite.T(), "WP-002", table.Groups[0].Rows[0][0].Text)
+	assert.Equal(suite.T(), 120.0, *table.Groups[0].Rows[0][1].Number)
+	assert.Equal(suite.T(), "Виниловые обои", table.Groups[1].Name)
+	assert.Equal(suite.T(), 240.0, *table.Groups[1].Rows[0][1].Number)
+	assert.Equal(suite.T(), 2, table.RowCount())
+}
+
+func (suite *ProductUseCaseTestSuite) TestExportProducts_UnknownColumn() {
+	// Выполнение
+	table, err := suite.useCase.ExportProducts(entities.ProductExportRequest{
+		Columns: []string{"article", "secret"},
+	})
+
+	// Проверки
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), table)
+	var validationErr *entities.ValidationError
+	assert.ErrorAs(suite.T(), err, &validationErr)
+	suite.productRepo.AssertNotCalled(suite.T(), "FindByCriteria", mock.Anything)
+}
+
+func (suite *ProductUseCaseTestSuite) TestExportProducts_TooManyRows() {
+	// Настройка моков
+	suite.productRepo.On("FindByCriteria", mock.Anything).Return([]entities.Product{}, entities.MaxExportRows+1, nil)
+
+	// Выполнение
+	table, err := suite.useCase.ExportProducts(entities.ProductExportRequest{})
+
+	// Проверки
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), table)
+	assert.Contains(suite.T(), err.Error(), "уточните фильтры")
+}
+
 func TestProductUseCaseTestSuite(t *testing.T) {
 	suite.Run(t, new(ProductUseCaseTestSuite))
 }
@@ -274,7 +920,8 @@ func TestProductUseCaseTestSuite(t *testing.T) {
 func TestProductUseCase_CalculatePriceEdgeCases(t *testing.T) {
 	productRepo := new(mocks.MockProductRepository)
 	materialRepo := new(mocks.MockMaterialRepository)
-	useCase := NewProductUseCase(productRepo, materialRepo)
+	pricingRuleRepo := new(mocks.MockPricingRuleRepository)
+	useCase := NewProductUseCase(productRepo, materialRepo, pricingRuleRepo)
 
 	t.Run("Продукт без типа", func(t *testing.T) {
 		product := &entities.Product{
@@ -293,9 +940,9 @@ func TestProductUseCase_CalculatePriceEdgeCases(t *testing.T) {
 		productRepo.On("GetProductTypeByID", 1).Return(productType, nil)
 		productRepo.On("GetMaterialsForProduct", 1).Return([]entities.ProductMaterial{}, nil)
 
-		price, err := useCase.calculateProductPrice(product)
+		calculation, err := useCase.calculateProductPrice(product, nil, nil, time.Now())
 		assert.NoError(t, err) // Теперь должно работать корректно
-		assert.Equal(t, 0.0, price)
+		assert.Equal(t, 0.0, calculation.Price)
 
 		productRepo.AssertExpectations(t)
 	})
@@ -309,9 +956,9 @@ func TestProductUseCase_CalculatePriceEdgeCases(t *testing.T) {
 			Materials: []entities.ProductMaterial{},
 		}
 
-		price, err := useCase.calculateProductPrice(product)
+		calculation, err := useCase.calculateProductPrice(product, nil, nil, time.Now())
 		assert.NoError(t, err)
-		assert.Equal(t, 0.0, price)
+		assert.Equal(t, 0.0, calculation.Price)
 	})
 
 	t.Run("Материал без цены", func(t *testing.T) {
@@ -330,8 +977,82 @@ func TestProductUseCase_CalculatePriceEdgeCases(t *testing.T) {
 			},
 		}
 
-		price, err := useCase.calculateProductPrice(product)
+		calculation, err := useCase.calculateProductPrice(product, nil, nil, time.Now())
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, calculation.Price)
+	})
+}
+
+func TestProductUseCase_PricingRules(t *testing.T) {
+	textileTypeID := 4
+	wholesaleTypeID := 2
+	rules := []entities.PricingRule{
+		{ID: 1, Name: "Базовая наценка", IsActive: true, MarkupPercent: 20, RoundingMode: entities.RoundingNearest, RoundingStep: 0.01},
+		{ID: 2, Name: "Текстиль", IsActive: true, ProductTypeID: &textileTypeID, MarkupPercent: 35, RoundingMode: entities.RoundingNearest, RoundingStep: 0.01},
+		{
+			ID: 3, Name: "Текстиль опт", IsActive: true, ProductTypeID: &textileTypeID, PartnerTypeID: &wholesaleTypeID,
+			MarkupPercent: 10, FixedSurcharge: 15, RoundingMode: entities.RoundingUp, RoundingStep: 10,
+		},
+	}
+
+	newProduct := func() *entities.Product {
+		return &entities.Product{
+			ID:            1,
+			ProductTypeID: textileTypeID,
+			ProductType:   &entities.ProductType{ID: textileTypeID, Name: "Текстильные обои", Coefficient: 1.0},
+			Materials: []entities.ProductMaterial{
+				{MaterialID: 1, QuantityPerUnit: 2.0, Material: &entities.Material{ID: 1, CostPerUnit: 100.0}},
+			},
+		}
+	}
+
+	t.Run("Цена продукции по правилу типа продукции", func(t *testing.T) {
+		productRepo := new(mocks.MockProductRepository)
+		pricingRuleRepo := new(mocks.MockPricingRuleRepository)
+		useCase := NewProductUseCase(productRepo, new(mocks.MockMaterialRepository), pricingRuleRepo)
+
+		productRepo.On("GetByID", 1).Return(newProduct(), nil)
+		pricingRuleRepo.On("GetActiveRules", mock.Anything).Return(rules, nil)
+
+		result, err := useCase.GetProductByID(1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 270.0, *result.CalculatedPrice) // 200 * 1.35
+		assert.Equal(t, 2, result.AppliedPricingRule.ID)
+	})
+
+	t.Run("Цена для оптового партнера", func(t *testing.T) {
+		productRepo := new(mocks.MockProductRepository)
+		pricingRuleRepo := new(mocks.MockPricingRuleRepository)
+		useCase := NewProductUseCase(productRepo, new(mocks.MockMaterialRepository), pricingRuleRepo)
+		date := time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+		productRepo.On("GetByID", 1).Return(newProduct(), nil)
+		pricingRuleRepo.On("GetPartnerTypeByID", wholesaleTypeID).Return(&entities.PartnerType{ID: wholesaleTypeID}, nil)
+		pricingRuleRepo.On("GetActiveRules", date).Return(rules, nil)
+
+		calculation, err := useCase.CalculateProductPrice(1, &wholesaleTypeID, date)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 200.0, calculation.Cost)
+		assert.Equal(t, 240.0, calculation.Price) // 200 * 1.10 + 15 = 235, вверх до 10
+		assert.Equal(t, 3, calculation.Rule.ID)
+
+		pricingRuleRepo.AssertExpectations(t)
+	})
+
+	t.Run("Без правил применяется базовая наценка", func(t *testing.T) {
+		productRepo := new(mocks.MockProductRepository)
+		pricingRuleRepo := new(mocks.MockPricingRuleRepository)
+		useCase := NewProductUseCase(productRepo, new(mocks.MockMaterialRepository), pricingRuleRepo)
+
+		productRepo.On("GetByID", 1).Return(newProduct(), nil)
+		pricingRuleRepo.On("GetActiveRules", mock.Anything).Return([]entities.PricingRule{}, nil)
+
+		calculation, err := useCase.CalculateProductPrice(1, nil, time.Now())
+
 		assert.NoError(t, err)
-		assert.Equal(t, 0.0, price)
+		assert.Equal(t, 240.0, calculation.Price)
+		assert.Equal(t, entities.DefaultMarkupPercent, calculation.Rule.MarkupPercent)
 	})
 }
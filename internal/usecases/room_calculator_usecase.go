@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"fmt"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// RoomCalculatorUseCase содержит бизнес-логику расчета рулонов обоев для комнаты
+type RoomCalculatorUseCase struct {
+	productRepo     repositories.ProductRepository
+	pricingRuleRepo repositories.PricingRuleRepository
+	calculator      RecipeCalculator
+}
+
+// NewRoomCalculatorUseCase создает новый use case калькулятора комнаты
+func NewRoomCalculatorUseCase(
+	productRepo repositories.ProductRepository,
+	pricingRuleRepo repositories.PricingRuleRepository,
+	calculator RecipeCalculator,
+) *RoomCalculatorUseCase {
+	return &RoomCalculatorUseCase{
+		productRepo:     productRepo,
+		pricingRuleRepo: pricingRuleRepo,
+		calculator:      calculator,
+	}
+}
+
+// GetRollProducts возвращает действующую продукцию с заданными размерами рулона
+func (uc *RoomCalculatorUseCase) GetRollProducts() ([]entities.Product, error) {
+	products, err := uc.productRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения продукции: %w", err)
+	}
+
+	rollProducts := make([]entities.Product, 0, len(products))
+	for _, product := range products {
+		if product.HasRollSize() && !product.IsArchived() {
+			rollProducts = append(rollProducts, product)
+		}
+	}
+	return rollProducts, nil
+}
+
+// GetPartnerTypes возвращает типы партнеров для выбора цены
+func (uc *RoomCalculatorUseCase) GetPartnerTypes() ([]entities.PartnerType, error) {
+	partnerTypes, err := uc.pricingRuleRepo.GetPartnerTypes()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения типов партнеров: %w", err)
+	}
+	return partnerTypes, nil
+}
+
+// CalculateRoomRolls рассчитывает количество рулонов выбранной продукции для комнаты
+// и их стоимость по цене для типа партнера
+func (uc *RoomCalculatorUseCase) CalculateRoomRolls(request *entities.RoomRollRequest) (*entities.RoomRollCalculation, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	product, err := uc.productRepo.GetByID(request.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения продукции: %w", err)
+	}
+	if product.IsArchived() {
+		return nil, entities.NewBusinessError("ARCHIVED_PRODUCT",
+			fmt.Sprintf("продукция %s находится в архиве", product.Article))
+	}
+	if !product.HasRollSize() {
+		return nil, entities.NewBusinessError("ROLL_SIZE_MISSING",
+			fmt.Sprintf("у продукции %s не заданы ширина и длина рулона", product.Article))
+	}
+
+	var patternRepeat float64
+	if product.PatternRepeat != nil {
+		patternRepeat = *product.PatternRepeat
+	}
+
+	calculation, err := entities.CalculateRoomRolls(request, *product.RollWidth, *product.RollLength, patternRepeat)
+	if err != nil {
+		return nil, err
+	}
+
+	price, err := uc.calculator.CalculatePriceFor(product, request.PartnerTypeID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	calculation.Product = product
+	calculation.ApplyPrice(price)
+	return calculation, nil
+}
@@ -0,0 +1,143 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type RoomCalculatorUseCaseTestSuite struct {
+	suite.Suite
+	productRepo     *mocks.MockProductRepository
+	materialRepo    *mocks.MockMaterialRepository
+	pricingRuleRepo *mocks.MockPricingRuleRepository
+	useCase         *RoomCalculatorUseCase
+}
+
+func (suite *RoomCalculatorUseCaseTestSuite) SetupTest() {
+	suite.productRepo = new(mocks.MockProductRepository)
+	suite.materialRepo = new(mocks.MockMaterialRepository)
+	suite.pricingRuleRepo = new(mocks.MockPricingRuleRepository)
+
+	calculator := NewProductUseCase(suite.productRepo, suite.materialRepo, suite.pricingRuleRepo)
+	suite.useCase = NewRoomCalculatorUseCase(suite.productRepo, suite.pricingRuleRepo, calculator)
+
+	// По умолчанию правил нет - применяется базовая наценка
+	suite.pricingRuleRepo.On("GetActiveRules", mock.Anything).Return([]entities.PricingRule{}, nil).Maybe()
+}
+
+// newRollProduct возвращает продукцию с размерами рулона и рецептурой на 500 ₽ себестоимости
+func newRollProduct() *entities.Product {
+	width, length, repeat := 0.53, 10.05, 0.64
+	return &entities.Product{
+		ID:            1,
+		Article:       "ART001",
+		Name:          "Обои с розами",
+		ProductTypeID: 1,
+		ProductType:   &entities.ProductType{ID: 1, Name: "Флизелин", Coefficient: 1.0},
+		RollWidth:     &width,
+		RollLength:    &length,
+		PatternRepeat: &repeat,
+		Materials: []entities.ProductMaterial{
+			{ProductID: 1, MaterialID: 3, QuantityPerUnit: 5, Material: &entities.Material{ID: 3, CostPerUnit: 100}},
+		},
+	}
+}
+
+func (suite *RoomCalculatorUseCaseTestSuite) TestCalculateRoomRolls_Success() {
+	// Подготовка данных
+	request := &entities.RoomRollRequest{
+		ProductID:      1,
+		Perimeter:      14,
+		Height:         2.7,
+		Openings:       []entities.RoomOpening{{Width: 1.5, Height: 1.4}},
+		ReservePercent: 10,
+	}
+
+	// Настройка моков
+	suite.productRepo.On("GetByID", 1).Return(newRollProduct(), nil)
+
+	// Выполнение
+	calculation, err := suite.useCase.CalculateRoomRolls(request)
+
+	// Проверки: 10 рулонов по 600 ₽ (себестоимость 500 + базовая наценка 20%)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "ART001", calculation.Product.Article)
+	assert.Equal(suite.T(), 10, calculation.TotalRolls)
+	assert.Equal(suite.T(), 600.0, calculation.RollPrice)
+	assert.Equal(suite.T(), 6000.0, calculation.TotalCost)
+}
+
+func (suite *RoomCalculatorUseCaseTestSuite) TestCalculateRoomRolls_RollSizeMissing() {
+	// Подготовка данных
+	product := newRollProduct()
+	product.RollLength = nil
+
+	// Настройка моков
+	suite.productRepo.On("GetByID", 1).Return(product, nil)
+
+	// Выполнение
+	calculation, err := suite.useCase.CalculateRoomRolls(&entities.RoomRollRequest{ProductID: 1, Perimeter: 10, Height: 2.5})
+
+	// Проверки
+	assert.Nil(suite.T(), calculation)
+	var businessErr *entities.BusinessError
+	require.ErrorAs(suite.T(), err, &businessErr)
+	assert.Equal(suite.T(), "ROLL_SIZE_MISSING", businessErr.Code)
+}
+
+func (suite *RoomCalculatorUseCaseTestSuite) TestCalculateRoomRolls_ArchivedProduct() {
+	// Подготовка данных
+	archivedAt := time.Now()
+	product := newRollProduct()
+	product.ArchivedAt = &archivedAt
+
+	// Настройка моков
+	suite.productRepo.On("GetByID", 1).Return(product, nil)
+
+	// Выполнение
+	_, err := suite.useCase.CalculateRoomRolls(&entities.RoomRollRequest{ProductID: 1, Perimeter: 10, Height: 2.5})
+
+	// Проверки
+	var businessErr *entities.BusinessError
+	require.ErrorAs(suite.T(), err, &businessErr)
+	assert.Equal(suite.T(), "ARCHIVED_PRODUCT", businessErr.Code)
+}
+
+func (suite *RoomCalculatorUseCaseTestSuite) TestCalculateRoomRolls_InvalidRoom() {
+	// Выполнение
+	_, err := suite.useCase.CalculateRoomRolls(&entities.RoomRollRequest{ProductID: 1, Height: 2.5})
+
+	// Проверки
+	var validationErr *entities.ValidationError
+	require.ErrorAs(suite.T(), err, &validationErr)
+	assert.Equal(suite.T(), "perimeter", validationErr.Field)
+	suite.productRepo.AssertNotCalled(suite.T(), "GetByID", mock.Anything)
+}
+
+func (suite *RoomCalculatorUseCaseTestSuite) TestGetRollProducts_SkipsProductsWithoutRollSize() {
+	// Подготовка данных
+	withoutSize := entities.Product{ID: 2, Article: "ART002"}
+
+	// Настройка моков
+	suite.productRepo.On("GetAll").Return([]entities.Product{*newRollProduct(), withoutSize}, nil)
+
+	// Выполнение
+	products, err := suite.useCase.GetRollProducts()
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), products, 1)
+	assert.Equal(suite.T(), 1, products[0].ID)
+}
+
+func TestRoomCalculatorUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(RoomCalculatorUseCaseTestSuite))
+}
@@ -0,0 +1,50 @@
+package usecases
+
+import (
+	"fmt"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// SearchUseCase содержит бизнес-логику полнотекстового поиска
+type SearchUseCase struct {
+	searchRepo repositories.SearchRepository
+}
+
+// NewSearchUseCase создает новый use case поиска
+func NewSearchUseCase(searchRepo repositories.SearchRepository) *SearchUseCase {
+	return &SearchUseCase{
+		searchRepo: searchRepo,
+	}
+}
+
+// Search ищет продукцию, материалы и партнеров; результаты каждого типа упорядочены по релевантности
+func (uc *SearchUseCase) Search(query entities.SearchQuery) (*entities.SearchResults, error) {
+	query.Normalize()
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	products, err := uc.searchRepo.SearchProducts(query)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска продукции: %w", err)
+	}
+
+	materials, err := uc.searchRepo.SearchMaterials(query)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска материалов: %w", err)
+	}
+
+	partners, err := uc.searchRepo.SearchPartners(query)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска партнеров: %w", err)
+	}
+
+	return &entities.SearchResults{
+		Query:     query.Text,
+		Products:  products,
+		Materials: materials,
+		Partners:  partners,
+	}, nil
+}
@@ -0,0 +1,79 @@
+package usecases
+
+import (
+	"testing"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type SearchUseCaseTestSuite struct {
+	suite.Suite
+	searchRepo *mocks.MockSearchRepository
+	useCase    *SearchUseCase
+}
+
+func (suite *SearchUseCaseTestSuite) SetupTest() {
+	suite.searchRepo = new(mocks.MockSearchRepository)
+	suite.useCase = NewSearchUseCase(suite.searchRepo)
+}
+
+func (suite *SearchUseCaseTestSuite) TestSearch_GroupsResults() {
+	// Подготовка данных
+	query := entities.SearchQuery{Text: "флизелин белый", Limit: entities.DefaultSearchLimit}
+	products := []entities.SearchResult{
+		{EntityType: entities.SearchEntityProduct, ID: 1, Title: "Обои флизелиновые белые", Rank: 0.8},
+	}
+	materials := []entities.SearchResult{
+		{EntityType: entities.SearchEntityMaterial, ID: 5, Title: "Флизелин 85 г/м²", Rank: 0.4},
+	}
+
+	// Настройка моков
+	suite.searchRepo.On("SearchProducts", query).Return(products, nil)
+	suite.searchRepo.On("SearchMaterials", query).Return(materials, nil)
+	suite.searchRepo.On("SearchPartners", query).Return([]entities.SearchResult{}, nil)
+
+	// Выполнение
+	results, err := suite.useCase.Search(entities.SearchQuery{Text: "  флизелин белый  "})
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "флизелин белый", results.Query)
+	assert.Equal(suite.T(), 2, results.Total())
+	assert.Equal(suite.T(), products, results.Products)
+	assert.Equal(suite.T(), materials, results.Materials)
+	assert.Empty(suite.T(), results.Partners)
+
+	suite.searchRepo.AssertExpectations(suite.T())
+}
+
+func (suite *SearchUseCaseTestSuite) TestSearch_TooShortQuery() {
+	// Выполнение
+	results, err := suite.useCase.Search(entities.SearchQuery{Text: "о"})
+
+	// Проверки
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), results)
+	suite.searchRepo.AssertNotCalled(suite.T(), "SearchProducts", mock.Anything)
+}
+
+func (suite *SearchUseCaseTestSuite) TestSearch_RepositoryError() {
+	// Настройка моков
+	suite.searchRepo.On("SearchProducts", mock.Anything).Return([]entities.SearchResult{}, assert.AnError)
+
+	// Выполнение
+	results, err := suite.useCase.Search(entities.SearchQuery{Text: "винил"})
+
+	// Проверки
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), results)
+	assert.Contains(suite.T(), err.Error(), "ошибка поиска продукции")
+}
+
+func TestSearchUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchUseCaseTestSuite))
+}
@@ -0,0 +1,64 @@
+package usecases
+
+import (
+	"fmt"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// ShippingUseCase содержит бизнес-логику расчета отгрузки: числа упаковок, раскладки
+// по поддонам, объема и веса брутто
+type ShippingUseCase struct {
+	productRepo repositories.ProductRepository
+	orderRepo   repositories.OrderRepository
+}
+
+// NewShippingUseCase создает новый use case расчета отгрузки
+func NewShippingUseCase(
+	productRepo repositories.ProductRepository,
+	orderRepo repositories.OrderRepository,
+) *ShippingUseCase {
+	return &ShippingUseCase{
+		productRepo: productRepo,
+		orderRepo:   orderRepo,
+	}
+}
+
+// CalculateShipment рассчитывает отгрузку списка продукции. Позиции одной продукции
+// объединяются
+func (uc *ShippingUseCase) CalculateShipment(request *entities.ShipmentRequest) (*entities.ShipmentPlan, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	lines := request.MergedLines()
+	items := make([]entities.ShipmentItem, 0, len(lines))
+	for _, line := range lines {
+		product, err := uc.productRepo.GetByID(line.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, entities.ShipmentItem{Product: product, Quantity: line.Quantity})
+	}
+
+	return entities.PlanShipment(request.Pallet, items)
+}
+
+// CalculateOrderShipment рассчитывает отгрузку позиций заказа на поддонах pallet
+func (uc *ShippingUseCase) CalculateOrderShipment(orderID int, pallet entities.PalletSpec) (*entities.ShipmentPlan, error) {
+	orderItems, err := uc.orderRepo.GetItems(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if len(orderItems) == 0 {
+		return nil, entities.NewBusinessError("EMPTY_ORDER", fmt.Sprintf("в заказе %d нет позиций", orderID))
+	}
+
+	request := &entities.ShipmentRequest{Pallet: pallet, Lines: make([]entities.ShipmentLine, len(orderItems))}
+	for i, item := range orderItems {
+		request.Lines[i] = entities.ShipmentLine{ProductID: item.ProductID, Quantity: item.Quantity}
+	}
+
+	return uc.CalculateShipment(request)
+}
@@ -0,0 +1,139 @@
+package usecases
+
+import (
+	"testing"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ShippingUseCaseTestSuite struct {
+	suite.Suite
+	productRepo *mocks.MockProductRepository
+	orderRepo   *mocks.MockOrderRepository
+	useCase     *ShippingUseCase
+}
+
+func (suite *ShippingUseCaseTestSuite) SetupTest() {
+	suite.productRepo = new(mocks.MockProductRepository)
+	suite.orderRepo = new(mocks.MockOrderRepository)
+	suite.useCase = NewShippingUseCase(suite.productRepo, suite.orderRepo)
+}
+
+// newTestShippedProduct возвращает продукцию с упаковкой 0.55 × 0.12 × 0.12 м весом 1.6 кг
+func newTestShippedProduct(id int, article string) *entities.Product {
+	length, width, height, weight := 0.55, 0.12, 0.12, 1.6
+	return &entities.Product{ID: id, Article: article, Name: "Обои " + article,
+		PackageLength: &length, PackageWidth: &width, PackageHeight: &height, WeightWithPackage: &weight}
+}
+
+func (suite *ShippingUseCaseTestSuite) TestCalculateShipment_MergesLines() {
+	// Подготовка данных
+	request := &entities.ShipmentRequest{
+		Pallet: entities.DefaultPalletSpec(),
+		Lines: []entities.ShipmentLine{
+			{ProductID: 1, Quantity: 100},
+			{ProductID: 2, Quantity: 20},
+			{ProductID: 1, Quantity: 60},
+		},
+	}
+
+	// Настройка моков
+	suite.productRepo.On("GetByID", 1).Return(newTestShippedProduct(1, "WP-001"), nil).Once()
+	suite.productRepo.On("GetByID", 2).Return(newTestShippedProduct(2, "WP-002"), nil).Once()
+
+	// Выполнение
+	plan, err := suite.useCase.CalculateShipment(request)
+
+	// Проверки: 160 упаковок WP-001 не помещаются на один поддон (156)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), plan.Lines, 2)
+	assert.Equal(suite.T(), 160, plan.Lines[0].Quantity)
+	assert.Equal(suite.T(), 2, plan.Lines[0].Pallets)
+	assert.Equal(suite.T(), 3, plan.Pallets)
+	assert.Equal(suite.T(), 180, plan.Packages)
+	suite.productRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ShippingUseCaseTestSuite) TestCalculateShipment_InvalidRequest() {
+	// Выполнение
+	_, err := suite.useCase.CalculateShipment(&entities.ShipmentRequest{Pallet: entities.DefaultPalletSpec()})
+
+	// Проверки
+	var validationErr *entities.ValidationError
+	require.ErrorAs(suite.T(), err, &validationErr)
+	assert.Equal(suite.T(), "lines", validationErr.Field)
+	suite.productRepo.AssertNotCalled(suite.T(), "GetByID", mock.Anything)
+}
+
+func (suite *ShippingUseCaseTestSuite) TestCalculateShipment_ProductNotFound() {
+	// Подготовка данных
+	request := &entities.ShipmentRequest{
+		Pallet: entities.DefaultPalletSpec(),
+		Lines:  []entities.ShipmentLine{{ProductID: 99, Quantity: 1}},
+	}
+
+	// Настройка моков
+	suite.productRepo.On("GetByID", 99).Return(nil, entities.NewNotFoundError("продукция", "99"))
+
+	// Выполнение
+	_, err := suite.useCase.CalculateShipment(request)
+
+	// Проверки
+	var notFoundErr *entities.NotFoundError
+	require.ErrorAs(suite.T(), err, &notFoundErr)
+}
+
+func (suite *ShippingUseCaseTestSuite) TestCalculateOrderShipment() {
+	// Настройка моков
+	suite.orderRepo.On("GetItems", 7).Return([]entities.OrderItem{
+		{ID: 1, OrderID: 7, ProductID: 1, Quantity: 10},
+		{ID: 2, OrderID: 7, ProductID: 2, Quantity: 5},
+	}, nil)
+	suite.productRepo.On("GetByID", 1).Return(newTestShippedProduct(1, "WP-001"), nil)
+	suite.productRepo.On("GetByID", 2).Return(newTestShippedProduct(2, "WP-002"), nil)
+
+	// Выполнение
+	plan, err := suite.useCase.CalculateOrderShipment(7, entities.DefaultPalletSpec())
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 15, plan.Packages)
+	assert.Equal(suite.T(), 2, plan.Pallets)
+	assert.Equal(suite.T(), 74.0, plan.GrossWeight)
+}
+
+func (suite *ShippingUseCaseTestSuite) TestCalculateOrderShipment_EmptyOrder() {
+	// Настройка моков
+	suite.orderRepo.On("GetItems", 7).Return([]entities.OrderItem{}, nil)
+
+	// Выполнение
+	_, err := suite.useCase.CalculateOrderShipment(7, entities.DefaultPalletSpec())
+
+	// Проверки
+	var businessErr *entities.BusinessError
+	require.ErrorAs(suite.T(), err, &businessErr)
+	assert.Equal(suite.T(), "EMPTY_ORDER", businessErr.Code)
+}
+
+func (suite *ShippingUseCaseTestSuite) TestCalculateOrderShipment_OrderNotFound() {
+	// Настройка моков
+	suite.orderRepo.On("GetItems", 99).Return(nil, entities.NewNotFoundError("заказ", "99"))
+
+	// Выполнение
+	_, err := suite.useCase.CalculateOrderShipment(99, entities.DefaultPalletSpec())
+
+	// Проверки
+	var notFoundErr *entities.NotFoundError
+	require.ErrorAs(suite.T(), err, &notFoundErr)
+	suite.productRepo.AssertNotCalled(suite.T(), "GetByID", mock.Anything)
+}
+
+func TestShippingUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ShippingUseCaseTestSuite))
+}
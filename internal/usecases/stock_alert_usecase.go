@@ -0,0 +1,95 @@
+package usecases
+
+import (
+	"fmt"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// StockAlertUseCase содержит бизнес-логику контроля минимальных остатков материалов
+type StockAlertUseCase struct {
+	alertRepo repositories.StockAlertRepository
+}
+
+// NewStockAlertUseCase создает новый use case контроля остатков
+func NewStockAlertUseCase(alertRepo repositories.StockAlertRepository) *StockAlertUseCase {
+	return &StockAlertUseCase{alertRepo: alertRepo}
+}
+
+// GetLowStock возвращает материалы с остатком не выше минимального и рекомендации по закупке
+func (uc *StockAlertUseCase) GetLowStock() ([]entities.LowStockItem, error) {
+	materials, err := uc.alertRepo.GetLowStockMaterials()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения материалов с низким остатком: %w", err)
+	}
+
+	items := make([]entities.LowStockItem, len(materials))
+	for i := range materials {
+		items[i] = entities.NewLowStockItem(&materials[i])
+	}
+	return items, nil
+}
+
+// CheckLowStock сравнивает остатки с минимальными: по материалу, остаток которого опустился
+// до минимального, создается одно уведомление; уведомления по материалам, остаток которых
+// восстановился, закрываются. Повторная проверка без изменений остатков ничего не меняет
+func (uc *StockAlertUseCase) CheckLowStock() (*entities.StockCheckResult, error) {
+	materials, err := uc.alertRepo.GetLowStockMaterials()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения материалов с низким остатком: %w", err)
+	}
+	open, err := uc.alertRepo.GetOpenNotifications()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения открытых уведомлений: %w", err)
+	}
+
+	lowStock := make(map[int]bool, len(materials))
+	for _, material := range materials {
+		lowStock[material.ID] = true
+	}
+
+	notified := make(map[int]bool, len(open))
+	var resolved []int
+	for _, notification := range open {
+		notified[notification.MaterialID] = true
+		if !lowStock[notification.MaterialID] {
+			resolved = append(resolved, notification.ID)
+		}
+	}
+
+	result := &entities.StockCheckResult{}
+	if err := uc.alertRepo.ResolveNotifications(resolved, time.Now()); err != nil {
+		return nil, err
+	}
+	result.Resolved = len(resolved)
+
+	for i := range materials {
+		if notified[materials[i].ID] {
+			continue
+		}
+		notification := entities.NewStockNotification(&materials[i])
+		if err := uc.alertRepo.CreateNotification(notification); err != nil {
+			return nil, err
+		}
+		result.Created = append(result.Created, *notification)
+	}
+
+	return result, nil
+}
+
+// GetNotifications возвращает уведомления о низком остатке; openOnly оставляет только
+// уведомления по материалам, остаток которых еще не восстановлен
+func (uc *StockAlertUseCase) GetNotifications(openOnly bool) ([]entities.StockNotification, error) {
+	notifications, err := uc.alertRepo.FindNotifications(openOnly)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения уведомлений об остатках: %w", err)
+	}
+	return notifications, nil
+}
+
+// AcknowledgeNotification отмечает уведомление просмотренным
+func (uc *StockAlertUseCase) AcknowledgeNotification(id int) error {
+	return uc.alertRepo.AcknowledgeNotification(id, time.Now())
+}
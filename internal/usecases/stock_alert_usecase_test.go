@@ -0,0 +1,114 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type StockAlertUseCaseTestSuite struct {
+	suite.Suite
+	alertRepo *mocks.MockStockAlertRepository
+	useCase   *StockAlertUseCase
+}
+
+func (suite *StockAlertUseCaseTestSuite) SetupTest() {
+	suite.alertRepo = new(mocks.MockStockAlertRepository)
+	suite.useCase = NewStockAlertUseCase(suite.alertRepo)
+}
+
+func (suite *StockAlertUseCaseTestSuite) TestGetLowStock_SuggestsPurchase() {
+	// Подготовка данных
+	materials := []entities.Material{
+		{ID: 1, Article: "MAT-001", StockQuantity: 12, MinStockQuantity: 50, PackageQuantity: 20, CostPerUnit: 4},
+	}
+
+	// Настройка моков
+	suite.alertRepo.On("GetLowStockMaterials").Return(materials, nil)
+
+	// Выполнение
+	items, err := suite.useCase.GetLowStock()
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), items, 1)
+	assert.Equal(suite.T(), "MAT-001", items[0].Material.Article)
+	assert.Equal(suite.T(), 38.0, items[0].Shortage)
+	assert.Equal(suite.T(), 2, items[0].SuggestedPackages)
+	assert.Equal(suite.T(), 40.0, items[0].SuggestedQuantity)
+	assert.Equal(suite.T(), 160.0, items[0].SuggestedCost)
+}
+
+func (suite *StockAlertUseCaseTestSuite) TestCheckLowStock_NotifiesOncePerCrossing() {
+	// Подготовка данных: материал 1 уже с уведомлением, материал 2 только что опустился до минимума,
+	// остаток материала 3 восстановился
+	materials := []entities.Material{
+		{ID: 1, StockQuantity: 3, MinStockQuantity: 10},
+		{ID: 2, Article: "MAT-002", StockQuantity: 10, MinStockQuantity: 10},
+	}
+	open := []entities.StockNotification{
+		{ID: 11, MaterialID: 1},
+		{ID: 13, MaterialID: 3},
+	}
+
+	// Настройка моков
+	suite.alertRepo.On("GetLowStockMaterials").Return(materials, nil)
+	suite.alertRepo.On("GetOpenNotifications").Return(open, nil)
+	suite.alertRepo.On("ResolveNotifications", []int{13}, mock.AnythingOfType("time.Time")).Return(nil)
+	suite.alertRepo.On("CreateNotification", mock.MatchedBy(func(n *entities.StockNotification) bool {
+		return n.MaterialID == 2 && n.StockQuantity == 10 && n.MinStockQuantity == 10
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*entities.StockNotification).ID = 14
+	}).Return(nil).Once()
+
+	// Выполнение
+	result, err := suite.useCase.CheckLowStock()
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), result.Created, 1)
+	assert.Equal(suite.T(), 14, result.Created[0].ID)
+	assert.Equal(suite.T(), "MAT-002", result.Created[0].Material.Article)
+	assert.Equal(suite.T(), 1, result.Resolved)
+	suite.alertRepo.AssertExpectations(suite.T())
+}
+
+func (suite *StockAlertUseCaseTestSuite) TestCheckLowStock_NothingChanged() {
+	// Настройка моков: повторная проверка без изменения остатков не создает уведомлений
+	suite.alertRepo.On("GetLowStockMaterials").Return([]entities.Material{{ID: 1, StockQuantity: 3, MinStockQuantity: 10}}, nil)
+	suite.alertRepo.On("GetOpenNotifications").Return([]entities.StockNotification{{ID: 11, MaterialID: 1}}, nil)
+	suite.alertRepo.On("ResolveNotifications", []int(nil), mock.AnythingOfType("time.Time")).Return(nil)
+
+	// Выполнение
+	result, err := suite.useCase.CheckLowStock()
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Empty(suite.T(), result.Created)
+	assert.Equal(suite.T(), 0, result.Resolved)
+	suite.alertRepo.AssertNotCalled(suite.T(), "CreateNotification", mock.Anything)
+}
+
+func (suite *StockAlertUseCaseTestSuite) TestCheckLowStock_RepositoryError() {
+	// Настройка моков
+	suite.alertRepo.On("GetLowStockMaterials").Return(nil, errors.New("нет соединения с базой данных"))
+
+	// Выполнение
+	result, err := suite.useCase.CheckLowStock()
+
+	// Проверки
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), result)
+	suite.alertRepo.AssertNotCalled(suite.T(), "GetOpenNotifications")
+}
+
+func TestStockAlertUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(StockAlertUseCaseTestSuite))
+}
@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"fmt"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// StocktakeUseCase содержит бизнес-логику инвентаризации материалов
+type StocktakeUseCase struct {
+	stocktakeRepo    repositories.StocktakeRepository
+	costRecalculator ProductCostRecalculator
+}
+
+// NewStocktakeUseCase создает новый use case инвентаризации
+func NewStocktakeUseCase(stocktakeRepo repositories.StocktakeRepository, costRecalculator ProductCostRecalculator) *StocktakeUseCase {
+	return &StocktakeUseCase{
+		stocktakeRepo:    stocktakeRepo,
+		costRecalculator: costRecalculator,
+	}
+}
+
+// GetStocktakes возвращает инвентаризации, новые первыми
+func (uc *StocktakeUseCase) GetStocktakes() ([]entities.Stocktake, error) {
+	stocktakes, err := uc.stocktakeRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения инвентаризаций: %w", err)
+	}
+	return stocktakes, nil
+}
+
+// GetReport возвращает инвентаризацию со строками и ведомость расхождений
+func (uc *StocktakeUseCase) GetReport(id int) (*entities.StocktakeReport, error) {
+	stocktake, err := uc.stocktakeRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return entities.NewStocktakeReport(stocktake), nil
+}
+
+// OpenStocktake открывает инвентаризацию и фиксирует учетные остатки материалов.
+// Одновременно открыта может быть только одна инвентаризация
+func (uc *StocktakeUseCase) OpenStocktake(note *string) (*entities.Stocktake, error) {
+	stocktake := &entities.Stocktake{Note: note}
+	if err := uc.stocktakeRepo.Create(stocktake); err != nil {
+		return nil, err
+	}
+	return stocktake, nil
+}
+
+// SaveCounts сохраняет фактические остатки. Пересчет можно вводить частями и исправлять,
+// пока инвентаризация открыта; остаток nil отменяет пересчет материала
+func (uc *StocktakeUseCase) SaveCounts(id int, counts []entities.StocktakeCount) (*entities.StocktakeReport, error) {
+	stocktake, err := uc.stocktakeRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := stocktake.ValidateCounts(counts); err != nil {
+		return nil, err
+	}
+
+	if err := uc.stocktakeRepo.SaveCounts(id, counts, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return uc.GetReport(id)
+}
+
+// CloseStocktake проводит расхождения пересчитанных материалов приходом излишка или
+// списанием недостачи и закрывает инвентаризацию. Непересчитанные материалы не меняются.
+// Если себестоимость продукции после изменения себестоимости материалов пересчитать не удалось,
+// вместе с отчетом возвращается CostRecalculationError
+func (uc *StocktakeUseCase) CloseStocktake(id int) (*entities.StocktakeReport, error) {
+	stocktake, err := uc.stocktakeRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := stocktake.EnsureOpen(); err != nil {
+		return nil, err
+	}
+
+	costChangedIDs, err := uc.stocktakeRepo.Close(id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	recalcErr := recalculateCostsForMaterials(uc.costRecalculator, "инвентаризация закрыта", costChangedIDs)
+
+	report, err := uc.GetReport(id)
+	if err != nil {
+		return nil, err
+	}
+	if recalcErr != nil {
+		return report, recalcErr
+	}
+	return report, nil
+}
+
+// CancelStocktake отменяет открытую инвентаризацию без изменения остатков
+func (uc *StocktakeUseCase) CancelStocktake(id int) error {
+	stocktake, err := uc.stocktakeRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if err := stocktake.EnsureOpen(); err != nil {
+		return err
+	}
+
+	return uc.stocktakeRepo.Cancel(id, time.Now())
+}
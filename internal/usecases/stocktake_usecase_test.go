@@ -0,0 +1,176 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/mocks"
+	usecasemocks "wallpaper-system/internal/usecases/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type StocktakeUseCaseTestSuite struct {
+	suite.Suite
+	stocktakeRepo    *mocks.MockStocktakeRepository
+	costRecalculator *usecasemocks.MockProductUseCase
+	useCase          *StocktakeUseCase
+}
+
+func (suite *StocktakeUseCaseTestSuite) SetupTest() {
+	suite.stocktakeRepo = new(mocks.MockStocktakeRepository)
+	suite.costRecalculator = new(usecasemocks.MockProductUseCase)
+	suite.useCase = NewStocktakeUseCase(suite.stocktakeRepo, suite.costRecalculator)
+}
+
+func openStocktake() *entities.Stocktake {
+	return &entities.Stocktake{ID: 3, Status: entities.StocktakeOpen, Items: []entities.StocktakeItem{
+		{StocktakeID: 3, MaterialID: 1, ExpectedQuantity: 10, UnitCost: 20},
+		{StocktakeID: 3, MaterialID: 2, ExpectedQuantity: 4, UnitCost: 300},
+	}}
+}
+
+func (suite *StocktakeUseCaseTestSuite) TestOpenStocktake() {
+	// Подготовка данных
+	note := "Квартальная инвентаризация"
+
+	// Настройка моков
+	suite.stocktakeRepo.On("Create", mock.MatchedBy(func(stocktake *entities.Stocktake) bool {
+		return stocktake.Note == &note
+	})).Run(func(args mock.Arguments) {
+		stocktake := args.Get(0).(*entities.Stocktake)
+		stocktake.ID = 3
+		stocktake.Status = entities.StocktakeOpen
+	}).Return(nil)
+
+	// Выполнение
+	stocktake, err := suite.useCase.OpenStocktake(&note)
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, stocktake.ID)
+	assert.True(suite.T(), stocktake.IsOpen())
+}
+
+func (suite *StocktakeUseCaseTestSuite) TestSaveCounts_PartialCount() {
+	// Подготовка данных: пересчитан только первый материал
+	counted := 9.5
+	counts := []entities.StocktakeCount{{MaterialID: 1, Quantity: &counted}}
+	updated := openStocktake()
+	updated.Items[0].CountedQuantity = &counted
+
+	// Настройка моков
+	suite.stocktakeRepo.On("GetByID", 3).Return(openStocktake(), nil).Once()
+	suite.stocktakeRepo.On("SaveCounts", 3, counts, mock.Anything).Return(nil)
+	suite.stocktakeRepo.On("GetByID", 3).Return(updated, nil).Once()
+
+	// Выполнение
+	report, err := suite.useCase.SaveCounts(3, counts)
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, report.ItemsTotal)
+	assert.Equal(suite.T(), 1, report.ItemsCounted)
+	assert.Equal(suite.T(), 10.0, report.ShortageValue)
+	suite.stocktakeRepo.AssertExpectations(suite.T())
+}
+
+func (suite *StocktakeUseCaseTestSuite) TestSaveCounts_UnknownMaterial() {
+	// Подготовка данных
+	counted := 1.0
+	counts := []entities.StocktakeCount{{MaterialID: 99, Quantity: &counted}}
+
+	// Настройка моков
+	suite.stocktakeRepo.On("GetByID", 3).Return(openStocktake(), nil)
+
+	// Выполнение
+	_, err := suite.useCase.SaveCounts(3, counts)
+
+	// Проверки
+	var validationErr *entities.ValidationError
+	require.ErrorAs(suite.T(), err, &validationErr)
+	assert.Equal(suite.T(), "material_id", validationErr.Field)
+	suite.stocktakeRepo.AssertNotCalled(suite.T(), "SaveCounts", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *StocktakeUseCaseTestSuite) TestCloseStocktake() {
+	// Подготовка данных
+	closed := openStocktake()
+	closed.Status = entities.StocktakeClosed
+
+	// Настройка моков
+	suite.stocktakeRepo.On("GetByID", 3).Return(openStocktake(), nil).Once()
+	suite.stocktakeRepo.On("Close", 3, mock.Anything).Return(nil, nil)
+	suite.stocktakeRepo.On("GetByID", 3).Return(closed, nil).Once()
+
+	// Выполнение
+	report, err := suite.useCase.CloseStocktake(3)
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), entities.StocktakeClosed, report.Stocktake.Status)
+	suite.stocktakeRepo.AssertExpectations(suite.T())
+	suite.costRecalculator.AssertNotCalled(suite.T(), "RecalculateCostsForMaterial", mock.Anything)
+}
+
+func (suite *StocktakeUseCaseTestSuite) TestCloseStocktake_RecalculatesChangedCosts() {
+	// Подготовка данных
+	closed := openStocktake()
+	closed.Status = entities.StocktakeClosed
+
+	// Настройка моков: списание недостачи изменило себестоимость материалов 1 и 2
+	suite.stocktakeRepo.On("GetByID", 3).Return(openStocktake(), nil).Once()
+	suite.stocktakeRepo.On("Close", 3, mock.Anything).Return([]int{1, 2}, nil)
+	suite.stocktakeRepo.On("GetByID", 3).Return(closed, nil).Once()
+	suite.costRecalculator.On("RecalculateCostsForMaterial", 1).Return(0, errors.New("database error"))
+	suite.costRecalculator.On("RecalculateCostsForMaterial", 2).Return(3, nil)
+
+	// Выполнение
+	report, err := suite.useCase.CloseStocktake(3)
+
+	// Проверки: инвентаризация закрыта, ошибка пересчета продукции возвращается отдельно
+	var recalcErr *entities.CostRecalculationError
+	require.ErrorAs(suite.T(), err, &recalcErr)
+	require.NotNil(suite.T(), report)
+	assert.Equal(suite.T(), entities.StocktakeClosed, report.Stocktake.Status)
+	suite.costRecalculator.AssertExpectations(suite.T())
+}
+
+func (suite *StocktakeUseCaseTestSuite) TestCloseStocktake_AlreadyClosed() {
+	// Подготовка данных
+	closed := openStocktake()
+	closed.Status = entities.StocktakeClosed
+
+	// Настройка моков
+	suite.stocktakeRepo.On("GetByID", 3).Return(closed, nil)
+
+	// Выполнение
+	_, err := suite.useCase.CloseStocktake(3)
+
+	// Проверки
+	var businessErr *entities.BusinessError
+	require.ErrorAs(suite.T(), err, &businessErr)
+	assert.Equal(suite.T(), "STOCKTAKE_NOT_OPEN", businessErr.Code)
+	suite.stocktakeRepo.AssertNotCalled(suite.T(), "Close", mock.Anything, mock.Anything)
+}
+
+func (suite *StocktakeUseCaseTestSuite) TestCancelStocktake_NotFound() {
+	// Настройка моков
+	suite.stocktakeRepo.On("GetByID", 8).Return(nil, entities.NewNotFoundError("инвентаризация", "8"))
+
+	// Выполнение
+	err := suite.useCase.CancelStocktake(8)
+
+	// Проверки
+	var notFoundErr *entities.NotFoundError
+	require.ErrorAs(suite.T(), err, &notFoundErr)
+	suite.stocktakeRepo.AssertNotCalled(suite.T(), "Cancel", mock.Anything, mock.Anything)
+}
+
+func TestStocktakeUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(StocktakeUseCaseTestSuite))
+}
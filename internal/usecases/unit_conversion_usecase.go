@@ -0,0 +1,330 @@
+package usecases
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// UnitConversionUseCase содержит бизнес-логику пересчета единиц измерения: единицы одной категории
+// пересчитываются по множителю к базовой единице, между категориями - через пересчеты материала
+type UnitConversionUseCase struct {
+	unitRepo     repositories.UnitRepository
+	materialRepo repositories.MaterialRepository
+}
+
+// NewUnitConversionUseCase создает новый use case пересчета единиц измерения
+func NewUnitConversionUseCase(
+	unitRepo repositories.UnitRepository,
+	materialRepo repositories.MaterialRepository,
+) *UnitConversionUseCase {
+	return &UnitConversionUseCase{
+		unitRepo:     unitRepo,
+		materialRepo: materialRepo,
+	}
+}
+
+// GetUnits возвращает все единицы измерения
+func (uc *UnitConversionUseCase) GetUnits() ([]entities.MeasurementUnit, error) {
+	return uc.unitRepo.GetAll()
+}
+
+// Convert пересчитывает количество из единицы from в единицу to. Единицы задаются обозначением
+// или ID; без материала пересчет возможен только внутри одной категории
+func (uc *UnitConversionUseCase) Convert(materialID *int, quantity float64, from, to string) (*entities.UnitConversion, error) {
+	if math.IsNaN(quantity) || math.IsInf(quantity, 0) {
+		return nil, entities.NewValidationError("quantity", "некорректное количество")
+	}
+
+	units, err := uc.unitRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения единиц измерения: %w", err)
+	}
+	fromUnit, err := findUnit(units, "from", from)
+	if err != nil {
+		return nil, err
+	}
+	toUnit, err := findUnit(units, "to", to)
+	if err != nil {
+		return nil, err
+	}
+
+	conversion := &entities.UnitConversion{
+		MaterialID: materialID,
+		Quantity:   quantity,
+		From:       *fromUnit,
+		To:         *toUnit,
+	}
+
+	if materialID == nil {
+		conversion.Result, err = entities.ConvertUnits(quantity, fromUnit, toUnit)
+		if err != nil {
+			return nil, err
+		}
+		return conversion, nil
+	}
+
+	set, err := uc.materialUnitSet(*materialID, units)
+	if err != nil {
+		return nil, err
+	}
+	conversion.Result, err = set.Convert(quantity, fromUnit, toUnit)
+	if err != nil {
+		return nil, err
+	}
+	return conversion, nil
+}
+
+// GetMaterialEquivalents возвращает единицы, в которых можно выразить материал, и количество
+// единиц учета материала в каждой из них
+func (uc *UnitConversionUseCase) GetMaterialEquivalents(materialID int) ([]entities.UnitEquivalent, error) {
+	units, err := uc.unitRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения единиц измерения: %w", err)
+	}
+
+	set, err := uc.materialUnitSet(materialID, units)
+	if err != nil {
+		return nil, err
+	}
+	return set.Equivalents(units), nil
+}
+
+// GetMaterialConversions возвращает пересчеты материала
+func (uc *UnitConversionUseCase) GetMaterialConversions(materialID int) ([]entities.MaterialUnitConversion, error) {
+	if _, err := uc.materialRepo.GetByID(materialID); err != nil {
+		return nil, fmt.Errorf("материал не найден: %w", err)
+	}
+
+	return uc.unitRepo.GetMaterialConversions(materialID)
+}
+
+// SaveMaterialConversion создает или заменяет пересчет материала в единицу conversion.UnitID.
+// Если заданы размеры рулона, множитель вычисляется по ним
+func (uc *UnitConversionUseCase) SaveMaterialConversion(conversion *entities.MaterialUnitConversion) error {
+	material, err := uc.materialRepo.GetByID(conversion.MaterialID)
+	if err != nil {
+		return fmt.Errorf("материал не найден: %w", err)
+	}
+
+	units, err := uc.unitRepo.GetAll()
+	if err != nil {
+		return fmt.Errorf("ошибка получения единиц измерения: %w", err)
+	}
+	materialUnit, err := findUnit(units, "measurement_unit_id", strconv.Itoa(material.MeasurementUnitID))
+	if err != nil {
+		return err
+	}
+	conversion.Unit, err = findUnit(units, "unit_id", strconv.Itoa(conversion.UnitID))
+	if err != nil {
+		return err
+	}
+
+	if err := conversion.ResolveFactor(materialUnit); err != nil {
+		return err
+	}
+
+	return uc.unitRepo.SaveMaterialConversion(conversion)
+}
+
+// DeleteMaterialConversion удаляет пересчет материала в единицу unitID
+func (uc *UnitConversionUseCase) DeleteMaterialConversion(materialID, unitID int) error {
+	return uc.unitRepo.DeleteMaterialConversion(materialID, unitID)
+}
+
+// GetMaterialStock возвращает остаток и минимальный запас материала в единице unit;
+// пустая единица означает единицу учета материала
+func (uc *UnitConversionUseCase) GetMaterialStock(materialID int, unit string) (*entities.StockInUnit, error) {
+	material, err := uc.materialRepo.GetByID(materialID)
+	if err != nil {
+		return nil, fmt.Errorf("материал не найден: %w", err)
+	}
+
+	units, err := uc.unitRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения единиц измерения: %w", err)
+	}
+	set, err := uc.unitSetFor(material, units)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &set.Unit
+	if strings.TrimSpace(unit) != "" {
+		if target, err = findUnit(units, "unit", unit); err != nil {
+			return nil, err
+		}
+	}
+
+	stock := &entities.StockInUnit{MaterialID: material.ID, Unit: *target}
+	if stock.StockQuantity, err = set.FromMaterialUnit(material.StockQuantity, target); err != nil {
+		return nil, err
+	}
+	if stock.ReservedQuantity, err = set.FromMaterialUnit(material.ReservedQuantity, target); err != nil {
+		return nil, err
+	}
+	if stock.AvailableQuantity, err = set.FromMaterialUnit(material.AvailableQuantity, target); err != nil {
+		return nil, err
+	}
+	if stock.MinStockQuantity, err = set.FromMaterialUnit(material.MinStockQuantity, target); err != nil {
+		return nil, err
+	}
+	return stock, nil
+}
+
+// ExpressProductMaterials выражает строки рецептуры в единице unit: расход и стоимость единицы
+// пересчитываются, итоговая стоимость строки не меняется. Строки материалов, которые нельзя
+// выразить в этой единице, остаются в единице учета; пустая единица возвращает строки без изменений
+func (uc *UnitConversionUseCase) ExpressProductMaterials(lines []entities.ProductMaterial, unit string) ([]entities.ProductMaterial, error) {
+	if strings.TrimSpace(unit) == "" {
+		return lines, nil
+	}
+
+	materials := make([]*entities.Material, len(lines))
+	for i := range lines {
+		materials[i] = lines[i].Material
+	}
+	express, err := uc.expresser(materials, unit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]entities.ProductMaterial, len(lines))
+	for i, line := range lines {
+		result[i] = line
+		result[i].QuantityPerUnit, result[i].Material = express(line.QuantityPerUnit, line.Material)
+	}
+	return result, nil
+}
+
+// ExpressRequirements выражает потребность в сырье в единице unit по тем же правилам,
+// что и ExpressProductMaterials
+func (uc *UnitConversionUseCase) ExpressRequirements(requirements []entities.MaterialRequirement, unit string) ([]entities.MaterialRequirement, error) {
+	if strings.TrimSpace(unit) == "" {
+		return requirements, nil
+	}
+
+	materials := make([]*entities.Material, len(requirements))
+	for i := range requirements {
+		materials[i] = requirements[i].Material
+	}
+	express, err := uc.expresser(materials, unit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]entities.MaterialRequirement, len(requirements))
+	for i, requirement := range requirements {
+		result[i] = requirement
+		result[i].Quantity, result[i].Material = express(requirement.Quantity, requirement.Material)
+	}
+	return result, nil
+}
+
+// expresser загружает единицы и пересчеты материалов и возвращает функцию, выражающую
+// количество материала в единице unit вместе с копией материала, у которой пересчитана
+// стоимость единицы и заменена единица измерения
+func (uc *UnitConversionUseCase) expresser(materials []*entities.Material, unit string) (
+	func(quantity float64, material *entities.Material) (float64, *entities.Material), error,
+) {
+	units, err := uc.unitRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения единиц измерения: %w", err)
+	}
+	target, err := findUnit(units, "unit", unit)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []int{}
+	for _, material := range materials {
+		if material != nil {
+			ids = append(ids, material.ID)
+		}
+	}
+	conversions, err := uc.unitRepo.GetConversionsForMaterials(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(quantity float64, material *entities.Material) (float64, *entities.Material) {
+		if material == nil {
+			return quantity, material
+		}
+		materialUnit := unitByID(units, material.MeasurementUnitID)
+		if materialUnit == nil {
+			return quantity, material
+		}
+		set := entities.MaterialUnitSet{Unit: *materialUnit, Conversions: conversions[material.ID]}
+		converted, err := set.FromMaterialUnit(quantity, target)
+		if err != nil {
+			return quantity, material
+		}
+		perTarget, err := set.Convert(1, target, materialUnit)
+		if err != nil {
+			return quantity, material
+		}
+
+		expressed := *material
+		expressed.CostPerUnit = material.CostPerUnit * perTarget
+		expressed.MeasurementUnit = target
+		return converted, &expressed
+	}, nil
+}
+
+// materialUnitSet загружает материал и его пересчеты
+func (uc *UnitConversionUseCase) materialUnitSet(materialID int, units []entities.MeasurementUnit) (*entities.MaterialUnitSet, error) {
+	material, err := uc.materialRepo.GetByID(materialID)
+	if err != nil {
+		return nil, fmt.Errorf("материал не найден: %w", err)
+	}
+	return uc.unitSetFor(material, units)
+}
+
+// unitSetFor собирает единицу учета и пересчеты загруженного материала
+func (uc *UnitConversionUseCase) unitSetFor(material *entities.Material, units []entities.MeasurementUnit) (*entities.MaterialUnitSet, error) {
+	materialUnit := unitByID(units, material.MeasurementUnitID)
+	if materialUnit == nil {
+		return nil, entities.NewNotFoundError("единица измерения", strconv.Itoa(material.MeasurementUnitID))
+	}
+
+	conversions, err := uc.unitRepo.GetMaterialConversions(material.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.MaterialUnitSet{Unit: *materialUnit, Conversions: conversions}, nil
+}
+
+// findUnit находит единицу по обозначению без учета регистра или по ID
+func findUnit(units []entities.MeasurementUnit, field, ref string) (*entities.MeasurementUnit, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, entities.NewValidationError(field, "единица измерения не указана")
+	}
+
+	for i := range units {
+		if strings.EqualFold(units[i].Abbreviation, ref) {
+			return &units[i], nil
+		}
+	}
+	if id, err := strconv.Atoi(ref); err == nil {
+		if unit := unitByID(units, id); unit != nil {
+			return unit, nil
+		}
+	}
+	return nil, entities.NewValidationError(field, fmt.Sprintf("единица измерения %s не найдена", ref))
+}
+
+// unitByID возвращает единицу по ID или nil
+func unitByID(units []entities.MeasurementUnit, id int) *entities.MeasurementUnit {
+	for i := range units {
+		if units[i].ID == id {
+			return &units[i]
+		}
+	}
+	return nil
+}
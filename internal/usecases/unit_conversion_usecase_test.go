@@ -0,0 +1,253 @@
+package usecases
+
+import (
+	"testing"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type UnitConversionUseCaseTestSuite struct {
+	suite.Suite
+	unitRepo     *mocks.MockUnitRepository
+	materialRepo *mocks.MockMaterialRepository
+	useCase      *UnitConversionUseCase
+}
+
+func (suite *UnitConversionUseCaseTestSuite) SetupTest() {
+	suite.unitRepo = new(mocks.MockUnitRepository)
+	suite.materialRepo = new(mocks.MockMaterialRepository)
+	suite.useCase = NewUnitConversionUseCase(suite.unitRepo, suite.materialRepo)
+
+	suite.unitRepo.On("GetAll").Return(newTestUnits(), nil).Maybe()
+}
+
+// newTestUnits возвращает справочник единиц: кг и г, м², рулон и шт
+func newTestUnits() []entities.MeasurementUnit {
+	return []entities.MeasurementUnit{
+		{ID: 2, Name: "килограмм", Abbreviation: "кг", Category: entities.UnitCategoryMass, BaseFactor: 1},
+		{ID: 3, Name: "штука", Abbreviation: "шт", Category: entities.UnitCategoryPiece, BaseFactor: 1},
+		{ID: 5, Name: "квадратный метр", Abbreviation: "м²", Category: entities.UnitCategoryArea, BaseFactor: 1},
+		{ID: 6, Name: "рулон", Abbreviation: "рул", Category: entities.UnitCategoryRoll, BaseFactor: 1},
+		{ID: 8, Name: "грамм", Abbreviation: "г", Category: entities.UnitCategoryMass, BaseFactor: 0.001},
+	}
+}
+
+// newTestPaper возвращает бумагу-основу, которая учитывается в м²
+func newTestPaper() *entities.Material {
+	return &entities.Material{ID: 10, Name: "Бумага-основа", MeasurementUnitID: 5, CostPerUnit: 20,
+		StockQuantity: 106.53, MinStockQuantity: 21.306}
+}
+
+// newTestPaperRolls возвращает пересчет бумаги-основы: 1 рулон = 10.653 м²
+func newTestPaperRolls() []entities.MaterialUnitConversion {
+	units := newTestUnits()
+	return []entities.MaterialUnitConversion{{MaterialID: 10, UnitID: 6, Factor: 10.653, Unit: &units[3]}}
+}
+
+func (suite *UnitConversionUseCaseTestSuite) TestConvert_SameCategory() {
+	// Выполнение
+	conversion, err := suite.useCase.Convert(nil, 2.5, "кг", "г")
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2500.0, conversion.Result)
+	assert.Equal(suite.T(), "кг", conversion.From.Abbreviation)
+	assert.Equal(suite.T(), "г", conversion.To.Abbreviation)
+	suite.materialRepo.AssertNotCalled(suite.T(), "GetByID", mock.Anything)
+}
+
+func (suite *UnitConversionUseCaseTestSuite) TestConvert_UnitByID() {
+	// Выполнение
+	conversion, err := suite.useCase.Convert(nil, 500, "8", "кг")
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0.5, conversion.Result)
+}
+
+func (suite *UnitConversionUseCaseTestSuite) TestConvert_IncompatibleWithoutMaterial() {
+	// Выполнение
+	_, err := suite.useCase.Convert(nil, 1, "рул", "м²")
+
+	// Проверки
+	var businessErr *entities.BusinessError
+	require.ErrorAs(suite.T(), err, &businessErr)
+	assert.Equal(suite.T(), "INCOMPATIBLE_UNITS", businessErr.Code)
+}
+
+func (suite *UnitConversionUseCaseTestSuite) TestConvert_UnknownUnit() {
+	// Выполнение
+	_, err := suite.useCase.Convert(nil, 1, "кг", "пуд")
+
+	// Проверки
+	var validationErr *entities.ValidationError
+	require.ErrorAs(suite.T(), err, &validationErr)
+	assert.Equal(suite.T(), "to", validationErr.Field)
+}
+
+func (suite *UnitConversionUseCaseTestSuite) TestConvert_ThroughMaterial() {
+	// Подготовка данных
+	materialID := 10
+
+	// Настройка моков
+	suite.materialRepo.On("GetByID", 10).Return(newTestPaper(), nil)
+	suite.unitRepo.On("GetMaterialConversions", 10).Return(newTestPaperRolls(), nil)
+
+	// Выполнение
+	conversion, err := suite.useCase.Convert(&materialID, 3, "рул", "м²")
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 31.959, conversion.Result)
+}
+
+func (suite *UnitConversionUseCaseTestSuite) TestSaveMaterialConversion_FromRollDimensions() {
+	// Подготовка данных
+	width, length := 1.06, 10.05
+	conversion := &entities.MaterialUnitConversion{MaterialID: 10, UnitID: 6, RollWidth: &width, RollLength: &length}
+
+	// Настройка моков
+	suite.materialRepo.On("GetByID", 10).Return(newTestPaper(), nil)
+	suite.unitRepo.On("SaveMaterialConversion", conversion).Return(nil)
+
+	// Выполнение
+	err := suite.useCase.SaveMaterialConversion(conversion)
+
+	// Проверки: множитель вычислен по площади рулона
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 10.653, conversion.Factor)
+	assert.Equal(suite.T(), "рул", conversion.Unit.Abbreviation)
+	suite.unitRepo.AssertExpectations(suite.T())
+}
+
+func (suite *UnitConversionUseCaseTestSuite) TestSaveMaterialConversion_SameCategoryRejected() {
+	// Подготовка данных: клей в кг, пересчет в граммы не нужен
+	glue := &entities.Material{ID: 11, Name: "Клей", MeasurementUnitID: 2}
+	conversion := &entities.MaterialUnitConversion{MaterialID: 11, UnitID: 8, Factor: 0.001}
+
+	// Настройка моков
+	suite.materialRepo.On("GetByID", 11).Return(glue, nil)
+
+	// Выполнение
+	err := suite.useCase.SaveMaterialConversion(conversion)
+
+	// Проверки
+	var validationErr *entities.ValidationError
+	require.ErrorAs(suite.T(), err, &validationErr)
+	assert.Equal(suite.T(), "unit_id", validationErr.Field)
+	suite.unitRepo.AssertNotCalled(suite.T(), "SaveMaterialConversion", mock.Anything)
+}
+
+func (suite *UnitConversionUseCaseTestSuite) TestSaveMaterialConversion_MaterialNotFound() {
+	// Настройка моков
+	suite.materialRepo.On("GetByID", 99).Return(nil, entities.NewNotFoundError("материал", "99"))
+
+	// Выполнение
+	err := suite.useCase.SaveMaterialConversion(&entities.MaterialUnitConversion{MaterialID: 99, UnitID: 6, Factor: 2})
+
+	// Проверки
+	var notFoundErr *entities.NotFoundError
+	require.ErrorAs(suite.T(), err, &notFoundErr)
+}
+
+func (suite *UnitConversionUseCaseTestSuite) TestGetMaterialStock_InRolls() {
+	// Настройка моков
+	suite.materialRepo.On("GetByID", 10).Return(newTestPaper(), nil)
+	suite.unitRepo.On("GetMaterialConversions", 10).Return(newTestPaperRolls(), nil)
+
+	// Выполнение
+	stock, err := suite.useCase.GetMaterialStock(10, "рул")
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 10.0, stock.StockQuantity)
+	assert.Equal(suite.T(), 2.0, stock.MinStockQuantity)
+	assert.Equal(suite.T(), "рул", stock.Unit.Abbreviation)
+}
+
+func (suite *UnitConversionUseCaseTestSuite) TestGetMaterialStock_DefaultUnit() {
+	// Настройка моков
+	suite.materialRepo.On("GetByID", 10).Return(newTestPaper(), nil)
+	suite.unitRepo.On("GetMaterialConversions", 10).Return(newTestPaperRolls(), nil)
+
+	// Выполнение
+	stock, err := suite.useCase.GetMaterialStock(10, "")
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 106.53, stock.StockQuantity)
+	assert.Equal(suite.T(), "м²", stock.Unit.Abbreviation)
+}
+
+func (suite *UnitConversionUseCaseTestSuite) TestExpressRequirements() {
+	// Подготовка данных: бумага в м² и клей в кг, потребность запрошена в граммах
+	units := newTestUnits()
+	paper := newTestPaper()
+	paper.MeasurementUnit = &units[2]
+	glue := &entities.Material{ID: 11, Name: "Клей", MeasurementUnitID: 2, CostPerUnit: 300, MeasurementUnit: &units[0]}
+	requirements := []entities.MaterialRequirement{
+		{MaterialID: 10, Material: paper, Quantity: 42.612},
+		{MaterialID: 11, Material: glue, Quantity: 1.25},
+	}
+
+	// Настройка моков
+	suite.unitRepo.On("GetConversionsForMaterials", []int{10, 11}).
+		Return(map[int][]entities.MaterialUnitConversion{10: newTestPaperRolls()}, nil)
+
+	// Выполнение
+	result, err := suite.useCase.ExpressRequirements(requirements, "г")
+
+	// Проверки: бумагу нельзя выразить в граммах - строка остается в м²
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), result, 2)
+	assert.Equal(suite.T(), 42.612, result[0].Quantity)
+	assert.Equal(suite.T(), "м²", result[0].Material.MeasurementUnit.Abbreviation)
+
+	assert.Equal(suite.T(), 1250.0, result[1].Quantity)
+	assert.Equal(suite.T(), "г", result[1].Material.MeasurementUnit.Abbreviation)
+	assert.InDelta(suite.T(), 0.3, result[1].Material.CostPerUnit, 1e-9)
+
+	// Исходные материалы не изменены
+	assert.Equal(suite.T(), 300.0, glue.CostPerUnit)
+	assert.Equal(suite.T(), "кг", glue.MeasurementUnit.Abbreviation)
+}
+
+func (suite *UnitConversionUseCaseTestSuite) TestExpressProductMaterials_InRolls() {
+	// Подготовка данных
+	lines := []entities.ProductMaterial{{MaterialID: 10, QuantityPerUnit: 5.3265, Material: newTestPaper()}}
+
+	// Настройка моков
+	suite.unitRepo.On("GetConversionsForMaterials", []int{10}).
+		Return(map[int][]entities.MaterialUnitConversion{10: newTestPaperRolls()}, nil)
+
+	// Выполнение
+	result, err := suite.useCase.ExpressProductMaterials(lines, "рул")
+
+	// Проверки: стоимость строки сохраняется
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0.5, result[0].QuantityPerUnit)
+	assert.InDelta(suite.T(), 5.3265*20, result[0].QuantityPerUnit*result[0].Material.CostPerUnit, 1e-6)
+}
+
+func (suite *UnitConversionUseCaseTestSuite) TestExpressRequirements_EmptyUnit() {
+	// Подготовка данных
+	requirements := []entities.MaterialRequirement{{MaterialID: 10, Material: newTestPaper(), Quantity: 3}}
+
+	// Выполнение
+	result, err := suite.useCase.ExpressRequirements(requirements, " ")
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), requirements, result)
+	suite.unitRepo.AssertNotCalled(suite.T(), "GetAll")
+}
+
+func TestUnitConversionUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(UnitConversionUseCaseTestSuite))
+}
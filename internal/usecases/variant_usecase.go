@@ -0,0 +1,260 @@
+package usecases
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/repositories"
+)
+
+// ProductVariantUseCase содержит бизнес-логику вариантов (расцветок) продукции
+type ProductVariantUseCase struct {
+	variantRepo  repositories.ProductVariantRepository
+	productRepo  repositories.ProductRepository
+	materialRepo repositories.MaterialRepository
+	calculator   RecipeCalculator
+	storage      repositories.FileStorage
+}
+
+// NewProductVariantUseCase создает новый use case вариантов продукции
+func NewProductVariantUseCase(
+	variantRepo repositories.ProductVariantRepository,
+	productRepo repositories.ProductRepository,
+	materialRepo repositories.MaterialRepository,
+	calculator RecipeCalculator,
+	storage repositories.FileStorage,
+) *ProductVariantUseCase {
+	return &ProductVariantUseCase{
+		variantRepo:  variantRepo,
+		productRepo:  productRepo,
+		materialRepo: materialRepo,
+		calculator:   calculator,
+		storage:      storage,
+	}
+}
+
+// GetProductVariants возвращает базовую продукцию вместе с ее вариантами
+func (uc *ProductVariantUseCase) GetProductVariants(productID int) (*entities.Product, []entities.ProductVariant, error) {
+	product, err := uc.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	variants, err := uc.variantRepo.GetByProduct(productID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка получения вариантов продукции: %w", err)
+	}
+
+	return product, variants, nil
+}
+
+// GetVariantByID возвращает вариант по ID
+func (uc *ProductVariantUseCase) GetVariantByID(id int) (*entities.ProductVariant, error) {
+	return uc.variantRepo.GetByID(id)
+}
+
+// CreateVariant создает вариант продукции вместе с заменами рецептуры
+func (uc *ProductVariantUseCase) CreateVariant(variant *entities.ProductVariant) error {
+	product, err := uc.productRepo.GetByID(variant.ProductID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.validateVariant(product, variant); err != nil {
+		return err
+	}
+
+	return uc.variantRepo.Create(variant)
+}
+
+// UpdateVariant обновляет суффикс артикула и цвет варианта. Замены рецептуры сохраняются
+// и изменяются через SetVariantOverride и RemoveVariantOverride
+func (uc *ProductVariantUseCase) UpdateVariant(variant *entities.ProductVariant) error {
+	existing, err := uc.variantRepo.GetByID(variant.ID)
+	if err != nil {
+		return err
+	}
+
+	product, err := uc.productRepo.GetByID(existing.ProductID)
+	if err != nil {
+		return err
+	}
+
+	variant.ProductID = existing.ProductID
+	variant.Overrides = existing.Overrides
+	if err := uc.validateVariant(product, variant); err != nil {
+		return err
+	}
+
+	return uc.variantRepo.Update(variant)
+}
+
+// DeleteVariant удаляет вариант вместе с изображением
+func (uc *ProductVariantUseCase) DeleteVariant(id int) error {
+	variant, err := uc.variantRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.variantRepo.Delete(id); err != nil {
+		return err
+	}
+
+	// Ошибка удаления файла не отменяет удаление варианта
+	images := variant.Images()
+	for _, path := range images.Files() {
+		_ = uc.storage.Delete(path)
+	}
+
+	return nil
+}
+
+// SetVariantOverride задает расход материала в варианте. Нулевой расход исключает
+// материал базовой рецептуры из варианта
+func (uc *ProductVariantUseCase) SetVariantOverride(variantID int, override entities.VariantMaterialOverride) (*entities.ProductVariant, error) {
+	variant, err := uc.variantRepo.GetByID(variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := uc.productRepo.GetByID(variant.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	override.VariantID = variantID
+	if existing := variant.Override(override.MaterialID); existing != nil {
+		*existing = override
+	} else {
+		variant.Overrides = append(variant.Overrides, override)
+	}
+
+	if err := uc.validateVariant(product, variant); err != nil {
+		return nil, err
+	}
+
+	if err := uc.variantRepo.Update(variant); err != nil {
+		return nil, err
+	}
+
+	return uc.variantRepo.GetByID(variantID)
+}
+
+// RemoveVariantOverride убирает замену, возвращая варианту расход из базовой рецептуры
+func (uc *ProductVariantUseCase) RemoveVariantOverride(variantID, materialID int) (*entities.ProductVariant, error) {
+	variant, err := uc.variantRepo.GetByID(variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := make([]entities.VariantMaterialOverride, 0, len(variant.Overrides))
+	for _, override := range variant.Overrides {
+		if override.MaterialID != materialID {
+			overrides = append(overrides, override)
+		}
+	}
+	if len(overrides) == len(variant.Overrides) {
+		return nil, entities.NewNotFoundError("замена материала в варианте", strconv.Itoa(materialID))
+	}
+	variant.Overrides = overrides
+
+	if err := uc.variantRepo.Update(variant); err != nil {
+		return nil, err
+	}
+
+	return uc.variantRepo.GetByID(variantID)
+}
+
+// ResolveVariant возвращает продукцию варианта с действующей рецептурой
+func (uc *ProductVariantUseCase) ResolveVariant(id int) (*entities.Product, error) {
+	variant, err := uc.variantRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := uc.productRepo.GetByID(variant.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения базовой продукции: %w", err)
+	}
+
+	return variant.Resolve(product), nil
+}
+
+// CalculateVariantPrice рассчитывает цену варианта по его действующей рецептуре
+func (uc *ProductVariantUseCase) CalculateVariantPrice(id int, partnerTypeID *int, date time.Time) (*entities.PriceCalculation, error) {
+	resolved, err := uc.ResolveVariant(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.calculator.CalculatePriceFor(resolved, partnerTypeID, date)
+}
+
+// ExplodeVariantMaterials раскладывает действующую рецептуру варианта до сырья на заданное количество
+func (uc *ProductVariantUseCase) ExplodeVariantMaterials(id int, quantity float64) ([]entities.MaterialRequirement, error) {
+	resolved, err := uc.ResolveVariant(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.calculator.ExplodeMaterialsFor(resolved, quantity)
+}
+
+// validateVariant проверяет вариант, уникальность его артикула и материалы замен
+func (uc *ProductVariantUseCase) validateVariant(product *entities.Product, variant *entities.ProductVariant) error {
+	variant.ArticleSuffix = strings.TrimSpace(variant.ArticleSuffix)
+	variant.ColorName = strings.TrimSpace(variant.ColorName)
+	if err := variant.Validate(); err != nil {
+		return fmt.Errorf("ошибка валидации: %w", err)
+	}
+
+	siblings, err := uc.variantRepo.GetByProduct(product.ID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения вариантов продукции: %w", err)
+	}
+	for _, sibling := range siblings {
+		if sibling.ID != variant.ID && strings.EqualFold(sibling.ArticleSuffix, variant.ArticleSuffix) {
+			return entities.NewBusinessError("DUPLICATE_VARIANT",
+				fmt.Sprintf("у продукции %s уже есть вариант с суффиксом %s", product.Article, sibling.ArticleSuffix))
+		}
+	}
+
+	article := entities.VariantArticle(product.Article, variant.ArticleSuffix)
+	existing, err := uc.productRepo.GetByArticles([]string{article})
+	if err != nil {
+		return fmt.Errorf("ошибка проверки артикула: %w", err)
+	}
+	if len(existing) > 0 {
+		return entities.NewBusinessError("DUPLICATE_ARTICLE",
+			fmt.Sprintf("продукция с артикулом %s уже существует", article))
+	}
+
+	inBase := make(map[int]bool, len(product.Materials))
+	for _, pm := range product.Materials {
+		inBase[pm.MaterialID] = true
+	}
+
+	for i := range variant.Overrides {
+		override := &variant.Overrides[i]
+		material, err := uc.materialRepo.GetByID(override.MaterialID)
+		if err != nil {
+			return fmt.Errorf("материал не найден: %w", err)
+		}
+
+		if !inBase[override.MaterialID] {
+			if override.QuantityPerUnit == 0 {
+				return entities.NewValidationError("quantity_per_unit",
+					fmt.Sprintf("материал %s не входит в базовую рецептуру, исключать его не нужно", material.Article))
+			}
+			if material.IsArchived() {
+				return newArchivedMaterialError(material)
+			}
+		}
+		override.Material = material
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	"wallpaper-system/internal/domain/entities"
+	"wallpaper-system/internal/domain/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProductVariantUseCaseTestSuite struct {
+	suite.Suite
+	variantRepo     *mocks.MockProductVariantRepository
+	productRepo     *mocks.MockProductRepository
+	materialRepo    *mocks.MockMaterialRepository
+	pricingRuleRepo *mocks.MockPricingRuleRepository
+	storage         *mocks.MockFileStorage
+	useCase         *ProductVariantUseCase
+}
+
+func (suite *ProductVariantUseCaseTestSuite) SetupTest() {
+	suite.variantRepo = new(mocks.MockProductVariantRepository)
+	suite.productRepo = new(mocks.MockProductRepository)
+	suite.materialRepo = new(mocks.MockMaterialRepository)
+	suite.pricingRuleRepo = new(mocks.MockPricingRuleRepository)
+	suite.storage = new(mocks.MockFileStorage)
+
+	calculator := NewProductUseCase(suite.productRepo, suite.materialRepo, suite.pricingRuleRepo)
+	suite.useCase = NewProductVariantUseCase(suite.variantRepo, suite.productRepo, suite.materialRepo, calculator, suite.storage)
+
+	// По умолчанию правил нет - применяется базовая наценка
+	suite.pricingRuleRepo.On("GetActiveRules", mock.Anything).Return([]entities.PricingRule{}, nil).Maybe()
+}
+
+// newVariantBaseProduct возвращает базовую продукцию с рецептурой из двух материалов
+func newVariantBaseProduct() *entities.Product {
+	return &entities.Product{
+		ID:            1,
+		Article:       "ART001",
+		Name:          "Обои с розами",
+		ProductTypeID: 1,
+		ProductType:   &entities.ProductType{ID: 1, Name: "Флизелин", Coefficient: 1.0},
+		Materials: []entities.ProductMaterial{
+			{ProductID: 1, MaterialID: 3, QuantityPerUnit: 2.0, Material: &entities.Material{ID: 3, Article: "BASE", CostPerUnit: 10}},
+			{ProductID: 1, MaterialID: 4, QuantityPerUnit: 0.5, Material: &entities.Material{ID: 4, Article: "PAINT-RED", CostPerUnit: 100}},
+		},
+	}
+}
+
+func (suite *ProductVariantUseCaseTestSuite) TestCreateVariant_Success() {
+	// Подготовка данных
+	variant := &entities.ProductVariant{
+		ProductID:     1,
+		ArticleSuffix: " BL ",
+		ColorName:     "Синий",
+		Overrides:     []entities.VariantMaterialOverride{{MaterialID: 9, QuantityPerUnit: 0.5}},
+	}
+	blue := &entities.Material{ID: 9, Article: "PAINT-BLUE", CostPerUnit: 120}
+
+	// Настройка моков
+	suite.productRepo.On("GetByID", 1).Return(newVariantBaseProduct(), nil)
+	suite.variantRepo.On("GetByProduct", 1).Return([]entities.ProductVariant{{ID: 2, ProductID: 1, ArticleSuffix: "GR"}}, nil)
+	suite.productRepo.On("GetByArticles", []string{"ART001-BL"}).Return([]entities.Product{}, nil)
+	suite.materialRepo.On("GetByID", 9).Return(blue, nil)
+	suite.variantRepo.On("Create", variant).Return(nil)
+
+	// Выполнение
+	err := suite.useCase.CreateVariant(variant)
+
+	// Проверки
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "BL", variant.ArticleSuffix)
+	assert.Equal(suite.T(), blue, variant.Overrides[0].Material)
+	suite.variantRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ProductVariantUseCaseTestSuite) TestCreateVariant_DuplicateSuffix() {
+	// Подготовка данных
+	variant := &entities.ProductVariant{ProductID: 1, ArticleSuffix: "bl", ColorName: "Голубой"}
+
+	// Настройка моков
+	suite.productRepo.On("GetByID", 1).Return(newVariantBaseProduct(), nil)
+	suite.variantRepo.On("GetByProduct", 1).Return([]entities.ProductVariant{{ID: 2, ProductID: 1, ArticleSuffix: "BL"}}, nil)
+
+	// Выполнение
+	err := suite.useCase.CreateVariant(variant)
+
+	// Проверки
+	var businessErr *entities.BusinessError
+	require.ErrorAs(suite.T(), err, &businessErr)
+	assert.Equal(suite.T(), "DUPLICATE_VARIANT", businessErr.Code)
+	suite.variantRepo.AssertNotCalled(suite.T(), "Create", mock.Anything)
+}
+
+func (suite *ProductVariantUseCaseTestSuite) TestCreateVariant_ArchivedAddedMaterial() {
+	// Подготовка данных
+	archivedAt := time.Now()
+	variant := &entities.ProductVariant{
+		ProductID:     1,
+		ArticleSuffix: "BL",
+		ColorName:     "Синий",
+		Overrides:     []entities.VariantMaterialOverride{{MaterialID: 9, QuantityPerUnit: 0.5}},
+	}
+
+	// Настройка моков
+	suite.productRepo.On("GetByID", 1).Return(newVariantBaseProduct(), nil)
+	suite.variantRepo.On("GetByProduct", 1).Return([]entities.ProductVariant{}, nil)
+	suite.productRepo.On("GetByArticles", []string{"ART001-BL"}).Return([]entities.Product{}, nil)
+	suite.materialRepo.On("GetByID", 9).Return(&entities.Material{ID: 9, Article: "PAINT-BLUE", ArchivedAt: &archivedAt}, nil)
+
+	// Выполнение
+	err := suite.useCase.CreateVariant(variant)
+
+	// Проверки
+	var businessErr *entities.BusinessError
+	require.ErrorAs(suite.T(), err, &businessErr)
+	assert.Equal(suite.T(), "ARCHIVED_MATERIAL", businessErr.Code)
+	suite.variantRepo.AssertNotCalled(suite.T(), "Create", mock.Anything)
+}
+
+func (suite *ProductVariantUseCaseTestSuite) TestCalculateVariantPrice_UsesEffectiveRecipe() {
+	// Подготовка данных: красная краска заменена синей, основа без изменений
+	variant := &entities.ProductVariant{
+		ID:            5,
+		ProductID:     1,
+		ArticleSuffix: "BL",
+		ColorName:     "Синий",
+		Overrides: []entities.VariantMaterialOverride{
+			{MaterialID: 4, QuantityPerUnit: 0},
+			{MaterialID: 9, QuantityPerUnit: 0.5, Material: &entities.Material{ID: 9, CostPerUnit: 120}},
+		},
+	}
+
+	// Настройка моков
+	suite.variantRepo.On("GetByID", 5).Return(variant, nil)
+	suite.productRepo.On("GetByID", 1).Return(newVariantBaseProduct(), nil)
+
+	// Выполнение
+	calculation, err := suite.useCase.CalculateVariantPrice(5, nil, time.Now())
+
+	// Проверки: 2 × 10 + 0.5 × 120 = 80, базовая наценка 20%
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 80.0, calculation.Cost)
+	assert.Equal(suite.T(), 96.0, calculation.Price)
+	suite.productRepo.AssertNotCalled(suite.T(), "GetMaterialsForProduct", mock.Anything)
+}
+
+func (suite *ProductVariantUseCaseTestSuite) TestRemoveVariantOverride_NotOverridden() {
+	// Настройка моков
+	suite.variantRepo.On("GetByID", 5).Return(&entities.ProductVariant{ID: 5, ProductID: 1}, nil)
+
+	// Выполнение
+	variant, err := suite.useCase.RemoveVariantOverride(5, 3)
+
+	// Проверки
+	assert.Nil(suite.T(), variant)
+	var notFoundErr *entities.NotFoundError
+	assert.ErrorAs(suite.T(), err, &notFoundErr)
+	suite.variantRepo.AssertNotCalled(suite.T(), "Update", mock.Anything)
+}
+
+func (suite *ProductVariantUseCaseTestSuite) TestDeleteVariant_RemovesImages() {
+	// Подготовка данных
+	image := "/uploads/variants/5/a.png"
+	thumbnail := "/uploads/variants/5/a_thumb.jpg"
+	variant := &entities.ProductVariant{ID: 5, ProductID: 1, ImagePath: &image, ThumbnailPath: &thumbnail}
+
+	// Настройка моков
+	suite.variantRepo.On("GetByID", 5).Return(variant, nil)
+	suite.variantRepo.On("Delete", 5).Return(nil)
+	suite.storage.On("Delete", image).Return(nil)
+	suite.storage.On("Delete", thumbnail).Return(nil)
+
+	// Выполнение
+	err := suite.useCase.DeleteVariant(5)
+
+	// Проверки
+	assert.NoError(suite.T(), err)
+	suite.storage.AssertExpectations(suite.T())
+}
+
+func TestProductVariantUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ProductVariantUseCaseTestSuite))
+}
@@ -0,0 +1,6 @@
+-- Откат полуфабрикатов
+
+DROP INDEX IF EXISTS idx_product_components_component;
+DROP INDEX IF EXISTS idx_product_components_product;
+
+DROP TABLE IF EXISTS product_components;
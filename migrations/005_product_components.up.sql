@@ -0,0 +1,15 @@
+-- Полуфабрикаты: продукция, используемая как компонент другой продукции
+
+CREATE TABLE product_components (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    component_product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+    quantity_per_unit DECIMAL(10,6) NOT NULL CHECK (quantity_per_unit > 0), -- количество полуфабриката на единицу продукции
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(product_id, component_product_id),
+    CHECK (product_id <> component_product_id)
+);
+
+CREATE INDEX idx_product_components_product ON product_components(product_id);
+CREATE INDEX idx_product_components_component ON product_components(component_product_id);
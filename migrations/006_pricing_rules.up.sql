@@ -0,0 +1,28 @@
+-- Правила ценообразования: наценка, фиксированная надбавка и округление
+-- в разрезе типа продукции, типа партнера и периода действия
+
+CREATE TABLE pricing_rules (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(200) NOT NULL,
+    product_type_id INTEGER REFERENCES product_types(id) ON DELETE CASCADE, -- NULL - любой тип продукции
+    partner_type_id INTEGER REFERENCES partner_types(id) ON DELETE CASCADE, -- NULL - любой тип партнера
+    valid_from DATE, -- NULL - без ограничения начала
+    valid_to DATE,   -- NULL - без ограничения окончания
+    markup_percent DECIMAL(7,2) NOT NULL DEFAULT 20.00 CHECK (markup_percent > -100), -- наценка в процентах
+    fixed_surcharge DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (fixed_surcharge >= 0),   -- фиксированная надбавка (₽)
+    rounding_mode VARCHAR(10) NOT NULL DEFAULT 'nearest' CHECK (rounding_mode IN ('nearest', 'up', 'down')),
+    rounding_step DECIMAL(10,2) NOT NULL DEFAULT 0.01 CHECK (rounding_step > 0), -- шаг округления (₽)
+    priority INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (valid_to IS NULL OR valid_from IS NULL OR valid_to >= valid_from)
+);
+
+CREATE INDEX idx_pricing_rules_product_type ON pricing_rules(product_type_id);
+CREATE INDEX idx_pricing_rules_partner_type ON pricing_rules(partner_type_id);
+CREATE INDEX idx_pricing_rules_period ON pricing_rules(valid_from, valid_to);
+
+-- Базовое правило, сохраняющее прежнюю наценку 20%
+INSERT INTO pricing_rules (name, markup_percent, rounding_mode, rounding_step) VALUES
+('Базовая наценка', 20.00, 'nearest', 0.01);
@@ -0,0 +1,2 @@
+ALTER TABLE products DROP COLUMN IF EXISTS cost_calculated_at;
+ALTER TABLE products DROP COLUMN IF EXISTS calculated_cost;
@@ -0,0 +1,4 @@
+-- Себестоимость, рассчитанная по рецептуре (cost_price остается ручной корректировкой)
+
+ALTER TABLE products ADD COLUMN calculated_cost DECIMAL(12,2) CHECK (calculated_cost >= 0);
+ALTER TABLE products ADD COLUMN cost_calculated_at TIMESTAMP;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_partners_search;
+DROP INDEX IF EXISTS idx_materials_search;
+DROP INDEX IF EXISTS idx_products_search;
+
+ALTER TABLE partners DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE materials DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE products DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,25 @@
+-- Полнотекстовый поиск по продукции, материалам и партнерам (русский словарь).
+-- Артикулы и ИНН индексируются словарем simple, чтобы не подвергаться стеммингу.
+
+ALTER TABLE products ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(article, '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce(description, '')), 'B')
+) STORED;
+
+ALTER TABLE materials ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(article, '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce(description, '')), 'B')
+) STORED;
+
+ALTER TABLE partners ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(inn, '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce(company_name, '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce(director_name, '')), 'B') ||
+    setweight(to_tsvector('russian', coalesce(legal_address, '')), 'C')
+) STORED;
+
+CREATE INDEX idx_products_search ON products USING GIN (search_vector);
+CREATE INDEX idx_materials_search ON materials USING GIN (search_vector);
+CREATE INDEX idx_partners_search ON partners USING GIN (search_vector);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_materials_active;
+DROP INDEX IF EXISTS idx_products_active;
+
+ALTER TABLE materials DROP COLUMN IF EXISTS archived_at;
+ALTER TABLE products DROP COLUMN IF EXISTS archived_at;
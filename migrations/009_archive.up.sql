@@ -0,0 +1,8 @@
+-- Архив вместо физического удаления: продукция и материалы скрываются из списков
+-- и калькуляторов, но остаются в заказах, истории продаж и рецептурах
+
+ALTER TABLE products ADD COLUMN archived_at TIMESTAMP;
+ALTER TABLE materials ADD COLUMN archived_at TIMESTAMP;
+
+CREATE INDEX idx_products_active ON products(id) WHERE archived_at IS NULL;
+CREATE INDEX idx_materials_active ON materials(id) WHERE archived_at IS NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE materials DROP COLUMN IF EXISTS preview_path;
+ALTER TABLE materials DROP COLUMN IF EXISTS thumbnail_path;
+ALTER TABLE products DROP COLUMN IF EXISTS preview_path;
+ALTER TABLE products DROP COLUMN IF EXISTS thumbnail_path;
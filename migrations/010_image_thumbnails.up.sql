@@ -0,0 +1,7 @@
+-- Уменьшенные копии загруженных изображений: миниатюра для списков и превью для карточки.
+-- Для изображений, заданных путем вручную, копий нет - показывается исходный файл
+
+ALTER TABLE products ADD COLUMN thumbnail_path VARCHAR(500);
+ALTER TABLE products ADD COLUMN preview_path VARCHAR(500);
+ALTER TABLE materials ADD COLUMN thumbnail_path VARCHAR(500);
+ALTER TABLE materials ADD COLUMN preview_path VARCHAR(500);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS product_certificates;
+DROP TABLE IF EXISTS quality_certificates;
@@ -0,0 +1,26 @@
+-- Сертификаты качества (соответствия): орган сертификации, стандарт, срок действия
+-- и скан документа. Один сертификат может распространяться на несколько позиций продукции.
+-- Прежние поля products.standard_number и products.quality_certificate_path сохраняются как справочные
+
+CREATE TABLE quality_certificates (
+    id SERIAL PRIMARY KEY,
+    number VARCHAR(100) NOT NULL,
+    issuing_body VARCHAR(300) NOT NULL,
+    standard_number VARCHAR(100),
+    valid_from DATE NOT NULL,
+    valid_to DATE NOT NULL,
+    file_path VARCHAR(500),
+    notes TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (valid_to >= valid_from)
+);
+
+CREATE TABLE product_certificates (
+    certificate_id INTEGER NOT NULL REFERENCES quality_certificates(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    PRIMARY KEY (certificate_id, product_id)
+);
+
+CREATE INDEX idx_product_certificates_product ON product_certificates(product_id);
+CREATE INDEX idx_quality_certificates_valid_to ON quality_certificates(valid_to);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS product_variant_materials;
+DROP TABLE IF EXISTS product_variants;
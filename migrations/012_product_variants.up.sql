@@ -0,0 +1,30 @@
+-- Варианты продукции (расцветки): один рисунок выпускается в нескольких цветах.
+-- Вариант наследует паспорт и рецептуру базовой продукции; отличаются суффикс артикула,
+-- название цвета, изображение и расход отдельных материалов (обычно пигмента)
+
+CREATE TABLE product_variants (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    article_suffix VARCHAR(20) NOT NULL, -- артикул варианта: <артикул продукции>-<суффикс>
+    color_name VARCHAR(100) NOT NULL,
+    image_path VARCHAR(500),
+    thumbnail_path VARCHAR(500),
+    preview_path VARCHAR(500),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(product_id, article_suffix)
+);
+
+-- Замены строк рецептуры: расход материала в варианте вместо расхода в базовой рецептуре.
+-- Нулевой расход исключает материал, материал вне базовой рецептуры добавляется
+CREATE TABLE product_variant_materials (
+    id SERIAL PRIMARY KEY,
+    variant_id INTEGER NOT NULL REFERENCES product_variants(id) ON DELETE CASCADE,
+    material_id INTEGER NOT NULL REFERENCES materials(id) ON DELETE RESTRICT,
+    quantity_per_unit DECIMAL(10,6) NOT NULL CHECK (quantity_per_unit >= 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(variant_id, material_id)
+);
+
+CREATE INDEX idx_product_variants_product ON product_variants(product_id);
+CREATE INDEX idx_product_variant_materials_material ON product_variant_materials(material_id);
@@ -0,0 +1,2 @@
+ALTER TABLE products DROP COLUMN IF EXISTS pattern_repeat;
+ALTER TABLE products DROP COLUMN IF EXISTS roll_length;
@@ -0,0 +1,4 @@
+-- Длина рулона и раппорт рисунка для расчета количества рулонов на помещение
+
+ALTER TABLE products ADD COLUMN roll_length DECIMAL(10,3) CHECK (roll_length >= 0); -- длина рулона (м)
+ALTER TABLE products ADD COLUMN pattern_repeat DECIMAL(10,3) CHECK (pattern_repeat >= 0); -- раппорт рисунка (м)
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS product_type_coefficient_history;
+ALTER TABLE product_types DROP COLUMN IF EXISTS archived_at;
@@ -0,0 +1,24 @@
+-- Управление типами продукции: вывод типа из обращения и история коэффициента.
+-- Коэффициент участвует в расчете себестоимости и потребности в материалах, поэтому каждое
+-- изменение сохраняется с датой вступления в силу и автором
+
+ALTER TABLE product_types ADD COLUMN archived_at TIMESTAMP; -- тип выведен из обращения
+
+CREATE TABLE product_type_coefficient_history (
+    id SERIAL PRIMARY KEY,
+    product_type_id INTEGER NOT NULL REFERENCES product_types(id) ON DELETE CASCADE,
+    old_coefficient DECIMAL(10,4), -- пусто для начального значения
+    new_coefficient DECIMAL(10,4) NOT NULL CHECK (new_coefficient > 0),
+    effective_from DATE NOT NULL,
+    changed_by VARCHAR(100) NOT NULL,
+    comment TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_product_type_coefficient_history_type
+    ON product_type_coefficient_history(product_type_id, effective_from);
+
+-- Начальные значения коэффициентов существующих типов
+INSERT INTO product_type_coefficient_history (product_type_id, new_coefficient, effective_from, changed_by, comment)
+SELECT id, coefficient, COALESCE(created_at, CURRENT_TIMESTAMP)::date, 'system', 'Начальное значение'
+FROM product_types;
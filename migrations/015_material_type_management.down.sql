@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS material_type_defect_rate_history;
+ALTER TABLE material_types DROP CONSTRAINT IF EXISTS material_types_defect_rate_range;
@@ -0,0 +1,24 @@
+-- Управление типами материалов: процент брака хранится долей (0.0500 = 5%) и не может
+-- превышать 100%. Каждое изменение процента брака сохраняется с автором, так как от него
+-- зависит расчет потребности в материалах
+
+ALTER TABLE material_types ADD CONSTRAINT material_types_defect_rate_range
+    CHECK (defect_rate >= 0 AND defect_rate <= 1);
+
+CREATE TABLE material_type_defect_rate_history (
+    id SERIAL PRIMARY KEY,
+    material_type_id INTEGER NOT NULL REFERENCES material_types(id) ON DELETE CASCADE,
+    old_defect_rate DECIMAL(5,4), -- пусто для начального значения
+    new_defect_rate DECIMAL(5,4) NOT NULL CHECK (new_defect_rate >= 0 AND new_defect_rate <= 1),
+    changed_by VARCHAR(100) NOT NULL,
+    comment TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_material_type_defect_rate_history_type
+    ON material_type_defect_rate_history(material_type_id, created_at);
+
+-- Начальные значения процента брака существующих типов
+INSERT INTO material_type_defect_rate_history (material_type_id, new_defect_rate, changed_by, comment, created_at)
+SELECT id, defect_rate, 'system', 'Начальное значение', COALESCE(created_at, CURRENT_TIMESTAMP)
+FROM material_types;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS material_unit_conversions;
+DELETE FROM measurement_units WHERE symbol IN ('мм', 'см', 'г', 'т', 'мл')
+    AND id NOT IN (SELECT measurement_unit_id FROM materials);
+ALTER TABLE measurement_units DROP COLUMN IF EXISTS base_factor;
+ALTER TABLE measurement_units DROP COLUMN IF EXISTS category;
@@ -0,0 +1,39 @@
+-- Пересчет единиц измерения. Единица относится к категории (длина, площадь, объем, масса,
+-- штуки, рулоны) и задает множитель к базовой единице категории: 1 г = 0.001 кг.
+-- Единицы одной категории пересчитываются друг в друга автоматически, между категориями -
+-- только через пересчеты конкретного материала (1 рулон бумаги-основы = 10.6 м²)
+
+ALTER TABLE measurement_units ADD COLUMN category VARCHAR(20)
+    CHECK (category IN ('length', 'area', 'volume', 'mass', 'piece', 'roll')); -- пусто: единица не пересчитывается
+ALTER TABLE measurement_units ADD COLUMN base_factor DECIMAL(18,9) NOT NULL DEFAULT 1 CHECK (base_factor > 0);
+
+UPDATE measurement_units SET category = 'length', base_factor = 1 WHERE symbol = 'м';
+UPDATE measurement_units SET category = 'mass', base_factor = 1 WHERE symbol = 'кг';
+UPDATE measurement_units SET category = 'piece', base_factor = 1 WHERE symbol = 'шт';
+UPDATE measurement_units SET category = 'volume', base_factor = 1 WHERE symbol = 'л';
+UPDATE measurement_units SET category = 'area', base_factor = 1 WHERE symbol = 'м²';
+UPDATE measurement_units SET category = 'roll', base_factor = 1 WHERE symbol = 'рул';
+
+INSERT INTO measurement_units (name, symbol, category, base_factor) VALUES
+('миллиметр', 'мм', 'length', 0.001),
+('сантиметр', 'см', 'length', 0.01),
+('грамм', 'г', 'mass', 0.001),
+('тонна', 'т', 'mass', 1000),
+('миллилитр', 'мл', 'volume', 0.001)
+ON CONFLICT DO NOTHING;
+
+-- Пересчеты конкретного материала: 1 единица unit_id = factor единиц материала.
+-- Для рулонов множитель может быть получен из размеров рулона (ширина и длина, м)
+CREATE TABLE material_unit_conversions (
+    id SERIAL PRIMARY KEY,
+    material_id INTEGER NOT NULL REFERENCES materials(id) ON DELETE CASCADE,
+    unit_id INTEGER NOT NULL REFERENCES measurement_units(id) ON DELETE RESTRICT,
+    factor DECIMAL(18,9) NOT NULL CHECK (factor > 0),
+    roll_width DECIMAL(10,3) CHECK (roll_width > 0),
+    roll_length DECIMAL(10,3) CHECK (roll_length > 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (material_id, unit_id)
+);
+
+CREATE INDEX idx_material_unit_conversions_material ON material_unit_conversions(material_id);
@@ -0,0 +1,16 @@
+-- Уведомления о снижении остатка материала до минимального. Уведомление создается, когда
+-- остаток опускается до min_stock_quantity или ниже, и закрывается (resolved_at), когда
+-- остаток восстанавливается. По материалу открыто не больше одного уведомления
+
+CREATE TABLE stock_notifications (
+    id SERIAL PRIMARY KEY,
+    material_id INTEGER NOT NULL REFERENCES materials(id) ON DELETE CASCADE,
+    stock_quantity DECIMAL(10,3) NOT NULL,       -- остаток на момент срабатывания
+    min_stock_quantity DECIMAL(10,3) NOT NULL,   -- минимальный остаток на момент срабатывания
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    acknowledged_at TIMESTAMP,                   -- уведомление просмотрено
+    resolved_at TIMESTAMP                        -- остаток восстановлен
+);
+
+CREATE UNIQUE INDEX idx_stock_notifications_open ON stock_notifications(material_id) WHERE resolved_at IS NULL;
+CREATE INDEX idx_stock_notifications_created_at ON stock_notifications(created_at DESC);
@@ -0,0 +1,18 @@
+-- Резервы материалов под заказы. При подтверждении заказа рецептура его продукции
+-- раскладывается до сырья и материалы резервируются (движение reserve в журнале); при отмене
+-- резерв снимается (движение release), при запуске в производство списывается в расход
+-- (движение consumption). Свободный остаток материала - остаток на складе за вычетом
+-- активных резервов
+
+CREATE TABLE material_reservations (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    material_id INTEGER NOT NULL REFERENCES materials(id) ON DELETE RESTRICT,
+    quantity DECIMAL(10,3) NOT NULL CHECK (quantity > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'released', 'consumed')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    closed_at TIMESTAMP -- время снятия резерва или списания в расход
+);
+
+CREATE INDEX idx_material_reservations_order ON material_reservations(order_id);
+CREATE INDEX idx_material_reservations_active ON material_reservations(material_id) WHERE status = 'active';
@@ -0,0 +1,27 @@
+-- Инвентаризация материалов. При открытии по каждому неархивному материалу фиксируется
+-- учетный остаток и цена (expected_quantity, unit_cost); фактический остаток
+-- (counted_quantity) вводится по мере пересчета. При закрытии расхождения пересчитанных
+-- материалов проводятся в складском журнале приходом излишка или списанием недостачи
+-- (reference_type = 'stocktake'). Одновременно открыта не больше одной инвентаризации
+
+CREATE TABLE stocktakes (
+    id SERIAL PRIMARY KEY,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'closed', 'cancelled')),
+    note TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    closed_at TIMESTAMP -- время закрытия или отмены
+);
+
+CREATE UNIQUE INDEX idx_stocktakes_open ON stocktakes(status) WHERE status = 'open';
+
+CREATE TABLE stocktake_items (
+    id SERIAL PRIMARY KEY,
+    stocktake_id INTEGER NOT NULL REFERENCES stocktakes(id) ON DELETE CASCADE,
+    material_id INTEGER NOT NULL REFERENCES materials(id) ON DELETE RESTRICT,
+    expected_quantity DECIMAL(10,3) NOT NULL,            -- учетный остаток на момент открытия
+    unit_cost DECIMAL(10,2) NOT NULL DEFAULT 0,          -- цена на момент открытия
+    counted_quantity DECIMAL(10,3) CHECK (counted_quantity >= 0), -- NULL - не пересчитан
+    counted_at TIMESTAMP,
+    movement_id INTEGER REFERENCES material_movements(id) ON DELETE SET NULL, -- проведенное расхождение
+    UNIQUE (stocktake_id, material_id)
+);
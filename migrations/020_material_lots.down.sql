@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS material_lot_consumptions;
+DROP TABLE IF EXISTS material_lots;
@@ -0,0 +1,60 @@
+-- Партии материалов. Каждый приход создает партию: поставка (material_supplies) - партию
+-- с поставщиком и номером партии поставщика, остальные приходы (начальный остаток, излишек
+-- инвентаризации) - партию без поставки. Расход и списание забирают материал из партий по
+-- FIFO - сначала из поступивших раньше; взятое количество по каждой партии записывается
+-- в material_lot_consumptions, что позволяет проследить партию до заказа и продукции.
+-- Сумма остатков партий материала равна его остатку на складе
+
+CREATE TABLE material_lots (
+    id SERIAL PRIMARY KEY,
+    material_id INTEGER NOT NULL REFERENCES materials(id) ON DELETE CASCADE,
+    supply_id INTEGER REFERENCES material_supplies(id) ON DELETE RESTRICT,
+    batch_number VARCHAR(100),                 -- номер партии поставщика
+    received_at DATE NOT NULL,
+    initial_quantity DECIMAL(10,3) NOT NULL CHECK (initial_quantity > 0),
+    remaining_quantity DECIMAL(10,3) NOT NULL CHECK (remaining_quantity >= 0 AND remaining_quantity <= initial_quantity),
+    movement_id INTEGER REFERENCES material_movements(id) ON DELETE SET NULL, -- приход, создавший партию
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_material_lots_material ON material_lots(material_id, received_at, id);
+CREATE INDEX idx_material_lots_supply ON material_lots(supply_id);
+
+CREATE TABLE material_lot_consumptions (
+    id SERIAL PRIMARY KEY,
+    lot_id INTEGER NOT NULL REFERENCES material_lots(id) ON DELETE CASCADE,
+    movement_id INTEGER NOT NULL REFERENCES material_movements(id) ON DELETE CASCADE,
+    quantity DECIMAL(10,3) NOT NULL CHECK (quantity > 0)
+);
+
+CREATE INDEX idx_material_lot_consumptions_lot ON material_lot_consumptions(lot_id);
+CREATE INDEX idx_material_lot_consumptions_movement ON material_lot_consumptions(movement_id);
+
+-- Текущие остатки раскладываются по партиям: по FIFO на складе остались последние поставки,
+-- поэтому остаток относится к поставкам от новых к старым. Часть остатка, не покрытая
+-- поставками, становится партией начального остатка, которая расходуется первой
+INSERT INTO material_lots (material_id, received_at, initial_quantity, remaining_quantity)
+SELECT m.id, COALESCE(s.first_supply_date, CURRENT_DATE),
+    m.stock_quantity - COALESCE(s.supplied, 0), m.stock_quantity - COALESCE(s.supplied, 0)
+FROM materials m
+LEFT JOIN (
+    SELECT material_id, SUM(quantity) AS supplied, MIN(supply_date) AS first_supply_date
+    FROM material_supplies
+    GROUP BY material_id
+) s ON s.material_id = m.id
+WHERE m.stock_quantity > COALESCE(s.supplied, 0);
+
+INSERT INTO material_lots (material_id, supply_id, received_at, initial_quantity, remaining_quantity)
+SELECT s.material_id, s.id, s.supply_date, s.quantity,
+    LEAST(s.quantity, m.stock_quantity - s.newer_supplied)
+FROM (
+    SELECT id, material_id, quantity, supply_date,
+        COALESCE(SUM(quantity) OVER (
+            PARTITION BY material_id ORDER BY supply_date DESC, id DESC
+            ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING
+        ), 0) AS newer_supplied
+    FROM material_supplies
+) s
+JOIN materials m ON m.id = s.material_id
+WHERE m.stock_quantity > s.newer_supplied
+ORDER BY s.supply_date, s.id;
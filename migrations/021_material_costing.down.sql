@@ -0,0 +1,11 @@
+ALTER TABLE material_movements
+    DROP COLUMN IF EXISTS total_cost,
+    DROP COLUMN IF EXISTS unit_cost;
+
+ALTER TABLE material_lots
+    DROP COLUMN IF EXISTS unit_cost;
+
+ALTER TABLE materials
+    DROP COLUMN IF EXISTS actual_cost,
+    DROP COLUMN IF EXISTS cost_locked,
+    DROP COLUMN IF EXISTS costing_method;
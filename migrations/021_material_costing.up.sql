@@ -0,0 +1,39 @@
+-- Себестоимость материалов по журналу. Метод расчета задается для каждого материала:
+-- скользящая средневзвешенная (weighted_average) или FIFO. actual_cost - фактическая
+-- себестоимость единицы остатка; cost_per_unit следует за ней, пока не зафиксирована
+-- вручную (cost_locked). Каждое движение хранит свою себестоимость, каждая партия - цену
+
+ALTER TABLE materials
+    ADD COLUMN costing_method VARCHAR(20) NOT NULL DEFAULT 'weighted_average'
+        CHECK (costing_method IN ('weighted_average', 'fifo')),
+    ADD COLUMN cost_locked BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN actual_cost DECIMAL(12,4) NOT NULL DEFAULT 0 CHECK (actual_cost >= 0);
+
+ALTER TABLE material_lots
+    ADD COLUMN unit_cost DECIMAL(12,4) NOT NULL DEFAULT 0 CHECK (unit_cost >= 0);
+
+ALTER TABLE material_movements
+    ADD COLUMN unit_cost DECIMAL(12,4),        -- себестоимость единицы движения
+    ADD COLUMN total_cost DECIMAL(15,2);       -- себестоимость движения
+
+-- Партии поставок оцениваются по цене поставки, остальные - по текущей себестоимости материала
+UPDATE material_lots l
+SET unit_cost = ms.unit_price
+FROM material_supplies ms
+WHERE l.supply_id = ms.id;
+
+UPDATE material_lots l
+SET unit_cost = m.cost_per_unit
+FROM materials m
+WHERE l.material_id = m.id AND l.supply_id IS NULL;
+
+-- Фактическая себестоимость на начало учета - средняя цена остатков партий
+UPDATE materials m
+SET actual_cost = v.value / v.quantity
+FROM (
+    SELECT material_id, SUM(remaining_quantity * unit_cost) AS value, SUM(remaining_quantity) AS quantity
+    FROM material_lots
+    GROUP BY material_id
+    HAVING SUM(remaining_quantity) > 0
+) v
+WHERE v.material_id = m.id;
@@ -70,14 +70,14 @@ function initDeleteConfirmations() {
     });
 }
 
-// Универсальная функция удаления элементов
+// Универсальная функция переноса элементов в архив (DELETE в API архивирует, а не удаляет)
 function deleteItem(type, id) {
     const typeNames = {
         'products': 'продукцию',
         'materials': 'материал'
     };
     
-    if (confirm(`Вы уверены, что хотите удалить эту ${typeNames[type]}? Это действие нельзя отменить.`)) {
+    if (confirm(`Перенести ${typeNames[type]} в архив? Запись исчезнет из списков и калькуляторов, но сохранится в истории. Ее можно будет восстановить.`)) {
         showLoading(true);
         
         fetch(`/api/v1/${type}/${id}`, {
@@ -118,7 +118,7 @@ function deleteItem(type, id) {
             showLoading(false);
             
             if (data && data.success) {
-                showNotification(`${typeNames[type].charAt(0).toUpperCase() + typeNames[type].slice(1)} успешно удален`, 'success');
+                showNotification(data.message || 'Перенесено в архив', 'success');
                 
                 // Удаляем строку из таблицы
                 const row = document.querySelector(`tr[data-id="${id}"]`);
@@ -137,8 +137,8 @@ function deleteItem(type, id) {
         })
         .catch(error => {
             showLoading(false);
-            console.error(`Ошибка удаления ${typeNames[type]}:`, error);
-            showNotification('Ошибка удаления: ' + error.message, 'error');
+            console.error(`Ошибка переноса в архив (${type}):`, error);
+            showNotification('Ошибка переноса в архив: ' + error.message, 'error');
         });
     }
 }